@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rs/zerolog/log"
+)
+
+const apiKeyUsage = `Usage: goodschain apikey <command> [arguments]
+
+Commands:
+  create --name NAME [--scopes admin] [--expires 720h] [--tenant ID]
+         Issue an API key and print it. It cannot be shown again.
+`
+
+// runAPIKey executes the `apikey` subcommand
+func runAPIKey(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Fprint(os.Stderr, apiKeyUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the client")
+	scopes := fs.String("scopes", model.ScopeAdmin, "comma-separated scopes, such as read or cars:write")
+	expires := fs.Duration("expires", 0, "lifetime of the key; 0 never expires")
+	tenantID := fs.String("tenant", model.DefaultTenantID, "ID of the tenant whose records the key can access")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	req := &model.APIKeyRequest{Name: *name, Scopes: strings.Split(*scopes, ","), Actor: "cli"}
+	if *expires > 0 {
+		expiresAt := time.Now().Add(*expires)
+		req.ExpiresAt = &expiresAt
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		log.Error().Err(err).Msg("Invalid API key")
+		return 2
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open database")
+		return 1
+	}
+	defer db.Close()
+
+	key, err := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db)).WithTenant(*tenantID).IssueAPIKey(req)
+	if err != nil {
+		log.Error().Err(err).Str("name", req.Name).Msg("Failed to issue API key")
+		return 1
+	}
+
+	log.Info().Str("id", key.ID).Str("name", key.Name).Strs("scopes", key.Scopes).Msg("API key issued")
+	fmt.Println(key.Key)
+	return 0
+}
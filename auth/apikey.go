@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// API keys have the form gc_<prefix>_<secret>. The prefix identifies the key
+// and is stored in clear to look it up; only a hash of the whole key is stored.
+const (
+	apiKeyScheme       = "gc_"
+	apiKeyPrefixBytes  = 4  // hex encoded to 8 characters
+	apiKeySecretBytes  = 32 // hex encoded to 64 characters
+	apiKeyPrefixLength = 2 * apiKeyPrefixBytes
+)
+
+// ErrMalformedAPIKey is returned for strings that are not API keys
+var ErrMalformedAPIKey = errors.New("malformed API key")
+
+// NewAPIKey generates a random API key and returns it with its prefix
+func NewAPIKey() (key, prefix string, err error) {
+	buf := make([]byte, apiKeyPrefixBytes+apiKeySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(buf[:apiKeyPrefixBytes])
+	return apiKeyScheme + prefix + "_" + hex.EncodeToString(buf[apiKeyPrefixBytes:]), prefix, nil
+}
+
+// APIKeyPrefix returns the prefix of key
+func APIKeyPrefix(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, apiKeyScheme)
+	if !ok {
+		return "", ErrMalformedAPIKey
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != apiKeyPrefixLength || len(secret) != 2*apiKeySecretBytes {
+		return "", ErrMalformedAPIKey
+	}
+	return prefix, nil
+}
+
+// HashAPIKey returns the hex SHA-256 of key. Keys are long and random, so
+// unlike passwords they need no salt or slow hash, and can be checked on
+// every request.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAPIKey reports whether key matches the hash, in constant time
+func VerifyAPIKey(hash, key string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(HashAPIKey(key))) == 1
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, err := NewAPIKey()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, "gc_"+prefix+"_"))
+	assert.Len(t, prefix, 8)
+
+	got, err := APIKeyPrefix(key)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, got)
+
+	other, _, err := NewAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestAPIKeyPrefix_Malformed(t *testing.T) {
+	for _, key := range []string{"", "gc_", "gc_abcd1234", "xx_abcd1234_" + strings.Repeat("0", 64), "gc_abc_" + strings.Repeat("0", 64)} {
+		_, err := APIKeyPrefix(key)
+		assert.ErrorIs(t, err, ErrMalformedAPIKey, key)
+	}
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	key, _, err := NewAPIKey()
+	require.NoError(t, err)
+	hash := HashAPIKey(key)
+
+	assert.Len(t, hash, 64)
+	assert.True(t, VerifyAPIKey(hash, key))
+	assert.False(t, VerifyAPIKey(hash, key+"0"))
+	assert.False(t, VerifyAPIKey("", key))
+}
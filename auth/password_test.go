@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashPassword(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		hash, err := HashPassword("correct horse battery")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$"))
+
+		// Salts are random, so the same password hashes differently
+		other, err := HashPassword("correct horse battery")
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := HashPassword("short")
+		assert.ErrorIs(t, err, ErrPasswordTooShort)
+	})
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse battery")
+	assert.NoError(t, err)
+
+	t.Run("Match", func(t *testing.T) {
+		ok, err := VerifyPassword(hash, "correct horse battery")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		ok, err := VerifyPassword(hash, "wrong password")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		for _, invalid := range []string{"", "plaintext", "$bcrypt$v=19$m=1,t=1,p=1$c2FsdA$aGFzaA", "$argon2id$v=19$bad$c2FsdA$aGFzaA"} {
+			_, err := VerifyPassword(invalid, "whatever")
+			assert.ErrorIs(t, err, ErrInvalidHash, invalid)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Access tokens are JWTs signed with HMAC-SHA256 (RFC 7519), so they are
+// checked without a lookup of the token itself
+const tokenHeader = `{"alg":"HS256","typ":"JWT"}`
+
+// refreshTokenBytes is the length of refresh tokens, hex encoded to 64 characters
+const refreshTokenBytes = 32
+
+// ErrInvalidToken is returned for access tokens that are malformed, wrongly
+// signed or expired
+var ErrInvalidToken = errors.New("invalid access token")
+
+// Claims are the claims of an access token
+type Claims struct {
+	Subject   string `json:"sub"`  // User ID
+	Name      string `json:"name"` // Username
+	Role      string `json:"role"`
+	SessionID string `json:"sid"`
+	TenantID  string `json:"tid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// SignToken returns the access token of claims signed with secret
+func SignToken(secret []byte, claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(tokenHeader)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sign(secret, unsigned)), nil
+}
+
+// ParseToken verifies the signature and expiry of an access token at now and
+// returns its claims
+func ParseToken(secret []byte, token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+	// Only our own header is ever signed, anything else is not one of our tokens
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || string(header) != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+func sign(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// NewRefreshToken generates a random refresh token
+func NewRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashRefreshToken returns the hex SHA-256 of a refresh token. Like API keys,
+// refresh tokens are long and random, so a fast hash suffices.
+func HashRefreshToken(token string) string {
+	return HashAPIKey(token)
+}
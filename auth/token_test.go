@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignToken(t *testing.T) {
+	secret := []byte("a-secret-of-at-least-32-characters!")
+	now := time.Unix(1700000000, 0)
+	claims := Claims{Subject: "user-1", Name: "jdoe", Role: "editor", SessionID: "session-1",
+		IssuedAt: now.Unix(), ExpiresAt: now.Add(15 * time.Minute).Unix()}
+
+	token, err := SignToken(secret, claims)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(token, "."))
+
+	got, err := ParseToken(secret, token, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, claims, *got)
+
+	t.Run("Expired", func(t *testing.T) {
+		_, err := ParseToken(secret, token, now.Add(15*time.Minute))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("OtherSecret", func(t *testing.T) {
+		_, err := ParseToken([]byte("another-secret"), token, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		other, err := SignToken(secret, Claims{Subject: "user-2", Role: "admin", ExpiresAt: claims.ExpiresAt})
+		require.NoError(t, err)
+		parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+		// The payload of one token with the signature of another
+		_, err = ParseToken(secret, parts[0]+"."+otherParts[1]+"."+parts[2], now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, token := range []string{"", "a.b", "a.b.c", "gc_0a1b2c3d_secret"} {
+			_, err := ParseToken(secret, token, now)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	token, err := NewRefreshToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+	assert.Len(t, HashRefreshToken(token), 64)
+	assert.NotEqual(t, token, HashRefreshToken(token))
+
+	other, err := NewRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -0,0 +1,47 @@
+// Package blobstore stores the files uploaded to the API, such as car photos
+// and brochures, under slash-separated keys.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// ErrInvalidKey is returned for keys that are empty, absolute or contain
+// empty, "." or ".." segments
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore stores blobs by key. Put replaces any blob stored under the
+// key; Delete of a missing key succeeds.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// CheckKey returns ErrInvalidKey unless key is a relative path of segments
+// of letters, digits, '.', '-' and '_'
+func CheckKey(key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+		for _, r := range segment {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			default:
+				return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"a", "tenant1/cars/car1/media1", "photo-1_thumb.jpg"} {
+		assert.NoError(t, CheckKey(key), key)
+	}
+	for _, key := range []string{"", "/abs", "a//b", "a/../b", "./a", "a/", "a b", `a\b`, "ả"} {
+		assert.ErrorIs(t, CheckKey(key), ErrInvalidKey, key)
+	}
+}
+
+// testStore puts, gets and deletes a blob of store
+func testStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+	key := "tenant1/cars/car1/media1"
+
+	_, err := store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("first"), 5, "text/plain"))
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("second"), 6, "text/plain"))
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, store.Delete(ctx, key))
+
+	assert.ErrorIs(t, store.Put(ctx, "../escape", strings.NewReader("x"), 1, ""), ErrInvalidKey)
+}
+
+func TestLocalStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "media")
+	store, err := NewLocalStore(root)
+	require.NoError(t, err)
+	testStore(t, store)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(root, "tenant1", "cars", "car1"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/fixture"
+	"github.com/GoodsChain/backend/keyring"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// command is a CLI subcommand of the goodschain binary
+type command struct {
+	name    string
+	summary string
+	run     func(cfg *config.Config, args []string) int
+}
+
+// commands lists the available subcommands in the order shown by help
+var commands = []command{
+	{name: "serve", summary: "Start the HTTP API server (default)", run: runServe},
+	{name: "migrate", summary: "Apply, roll back or inspect database migrations", run: runMigrate},
+	{name: "seed", summary: "Create records from a fixture file, skipping existing ones", run: runSeed},
+	{name: "export", summary: "Write all data to a fixture file", run: runExport},
+	{name: "import", summary: "Create or overwrite records from a fixture file", run: runImport},
+	{name: "tenant", summary: "Manage the tenants that own records", run: runTenant},
+	{name: "user", summary: "Manage user accounts", run: runUser},
+	{name: "apikey", summary: "Manage API keys of machine clients", run: runAPIKey},
+	{name: "keys", summary: "Generate and rotate the keys encrypting customer PII", run: runKeys},
+}
+
+// runCommand dispatches args to a subcommand and returns the process exit code
+func runCommand(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		return runServe(cfg, nil)
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return 0
+	}
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(cfg, args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	printUsage()
+	return 2
+}
+
+// printUsage writes the list of subcommands to stderr
+func printUsage() {
+	var b strings.Builder
+	b.WriteString("Usage: goodschain <command> [arguments]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nRun 'goodschain <command> -h' for help on a command.\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// openDatabase connects to the database and verifies its schema version,
+// applying migrations first when auto-migrate is enabled
+func openDatabase(cfg *config.Config) (*sqlx.DB, error) {
+	db, err := connectDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureSchema(cfg); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// loadFieldCipher returns the cipher of customer PII: the keyring file of the
+// configuration, or unencrypted storage when there is none and encryption was
+// explicitly turned off
+func loadFieldCipher(cfg *config.Config) (repository.FieldCipher, error) {
+	if cfg.PIIKeyringFile == "" {
+		if cfg.PIIEncryption != "off" {
+			return nil, errors.New("PII_KEYRING_FILE is not set; set PII_ENCRYPTION=off to store customer PII unencrypted")
+		}
+		log.Warn().Msg("PII_ENCRYPTION is off, customer PII is stored unencrypted")
+		return keyring.Plaintext{}, nil
+	}
+	k, err := keyring.Load(cfg.PIIKeyringFile)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// newFixtureRepositories builds the repositories fixtures are loaded through
+func newFixtureRepositories(db *sqlx.DB, cipher repository.FieldCipher) fixture.Repositories {
+	return fixture.Repositories{
+		Supplier:    repository.NewSupplierRepository(db),
+		Car:         repository.NewCarRepository(db),
+		Customer:    repository.NewCustomerRepository(db, cipher),
+		CustomerCar: repository.NewCustomerCarRepository(db),
+	}
+}
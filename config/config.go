@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
@@ -18,19 +19,99 @@ type Config struct {
 	DBPassword     string
 	DBName         string
 	DBSSLMODE      string
-	DBMaxOpenConns int // Maximum number of open connections to the database
-	DBMaxIdleConns int // Maximum number of idle connections in the pool
-	DBConnMaxLife  int // Maximum time (in seconds) a connection may be reused
+	DBMaxOpenConns int  // Maximum number of open connections to the database
+	DBMaxIdleConns int  // Maximum number of idle connections in the pool
+	DBConnMaxLife  int  // Maximum time (in seconds) a connection may be reused
+	DBAutoMigrate  bool // Apply pending embedded migrations on server start
 
 	// API settings
 	APIPort            string
-	APIReadTimeout     int // Read timeout in seconds
-	APIWriteTimeout    int // Write timeout in seconds
-	APIIdleTimeout     int // Idle timeout in seconds
-	APIShutdownTimeout int // Graceful shutdown timeout in seconds
+	APITrustedProxies  string // Comma-separated addresses or CIDRs of the proxies whose X-Forwarded-For is trusted; empty trusts none
+	APIReadTimeout     int    // Read timeout in seconds
+	APIWriteTimeout    int    // Write timeout in seconds
+	APIIdleTimeout     int    // Idle timeout in seconds
+	APIShutdownTimeout int    // Graceful shutdown timeout in seconds
 
 	// Versioning
 	APIVersion string // API version string
+
+	// Import settings
+	ImportMaxFileSizeMB int // Maximum size of an uploaded import file in megabytes
+	ImportChunkSize     int // Rows per transaction; larger files run as resumable jobs
+
+	// Batch settings
+	BatchMaxOperations int // Maximum number of operations in one batch request
+
+	// Webhook settings
+	WebhookEnabled          bool // Run the webhook dispatcher in the API process
+	WebhookDispatchInterval int  // Seconds between polls for new events and due deliveries
+	WebhookTimeout          int  // Timeout of a delivery request in seconds
+	WebhookMaxAttempts      int  // Attempts before a delivery is dead-lettered
+	WebhookBackoffBase      int  // Delay in seconds after the first failed attempt, doubled after each further one
+	WebhookBackoffMax       int  // Maximum delay between attempts in seconds
+	WebhookAllowPrivate     bool // Allow endpoints on localhost and private networks, for development only
+
+	// Event stream settings
+	EventStreamHeartbeat    int // Seconds between heartbeat comments on idle event streams
+	EventStreamPollInterval int // Seconds between reads of new events when no notification arrives
+
+	// Event relay settings
+	RelayPublisher    string // Publisher of outbox events: stdout, file, nats or kafka; empty disables the relay
+	RelayInterval     int    // Seconds between polls for unpublished events
+	RelayBatchSize    int    // Events published per transaction
+	RelayFilePath     string // Output file of the file publisher
+	NATSURL           string // NATS server URL
+	NATSSubjectPrefix string // Subject prefix; events are published to <prefix>.<event type>
+	KafkaBrokers      string // Comma-separated Kafka broker addresses
+	KafkaTopic        string // Kafka topic events are published to
+
+	// GraphQL settings
+	GraphQLMaxDepth      int // Maximum field nesting of a query
+	GraphQLMaxComplexity int // Maximum complexity of a query, list fields count once per requested item
+
+	// Expansion settings
+	ExpandMaxDepth int // Maximum number of relations in an expand path
+
+	// gRPC settings
+	GRPCPort string // Port serving the gRPC services and their JSON gateway; empty disables them
+
+	// Rate limit settings
+	RateLimitEnabled bool   // Limit the request rate of each client
+	RateLimitStore   string // Store of the token buckets: memory, or postgres to share them between instances
+	RateLimitDefault string // Limit of every client and route group, such as 600/m
+	RateLimitGroups  string // Limits by route group, such as cars=60/m,search=30/m
+	RateLimitClients string // Limits by client ID, overriding the group limits; 0 lifts them
+
+	// Auth settings
+	AuthRequired        bool   // Reject requests without credentials; false serves them anonymously, for development
+	AuthTokenSecret     string // Key signing access tokens; random per process when empty
+	AuthAccessTokenTTL  int    // Seconds access tokens are valid
+	AuthRefreshTokenTTL int    // Seconds refresh tokens are valid
+	AuthMaxFailedLogins int    // Failed logins that lock an account; 0 never locks
+	AuthLockoutDuration int    // Seconds an account stays locked
+
+	// Encryption settings
+	PIIKeyringFile string // Keyring file encrypting customer PII at rest
+	PIIEncryption  string // on requires PIIKeyringFile; off allows storing customer PII unencrypted without it
+
+	// Media settings
+	MediaStore          string // Store of uploaded car media: local, or s3 for an S3-compatible service
+	MediaLocalDir       string // Directory of the local store
+	MediaS3Endpoint     string // Base URL of the S3-compatible service, such as http://localhost:9000
+	MediaS3Bucket       string
+	MediaS3Region       string
+	MediaS3AccessKey    string
+	MediaS3SecretKey    string
+	MediaMaxFileSizeMB  int    // Maximum size of an uploaded file in megabytes
+	MediaURLSecret      string // Key signing download URLs; random per process when empty
+	MediaURLTTL         int    // Seconds download URLs are valid
+	MediaThumbnailSize  int    // Longest side of image thumbnails in pixels
+	MediaMaxImagePixels int    // Largest width times height of an image that is decoded
+	MediaSweepInterval  int    // Seconds between removals of the files of deleted media; 0 disables
+
+	// Maintenance settings
+	MaintenanceIntervalKM     int // Kilometers between services of owned vehicles; 0 disables
+	MaintenanceIntervalMonths int // Months between services of owned vehicles; 0 disables
 }
 
 // LoadConfig reads environment variables and returns a Config struct
@@ -53,9 +134,11 @@ func LoadConfig() *Config {
 		DBMaxOpenConns: getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
 		DBMaxIdleConns: getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
 		DBConnMaxLife:  getEnvAsInt("DB_CONN_MAX_LIFE", 300), // 5 minutes
+		DBAutoMigrate:  getEnvAsBool("DB_AUTO_MIGRATE", false),
 
 		// API defaults
 		APIPort:            getEnv("API_PORT", "3000"),
+		APITrustedProxies:  getEnv("API_TRUSTED_PROXIES", ""),
 		APIReadTimeout:     getEnvAsInt("API_READ_TIMEOUT", 15),
 		APIWriteTimeout:    getEnvAsInt("API_WRITE_TIMEOUT", 15),
 		APIIdleTimeout:     getEnvAsInt("API_IDLE_TIMEOUT", 60),
@@ -63,6 +146,84 @@ func LoadConfig() *Config {
 
 		// Versioning
 		APIVersion: getEnv("API_VERSION", "v1"),
+
+		// Import defaults
+		ImportMaxFileSizeMB: getEnvAsInt("IMPORT_MAX_FILE_SIZE_MB", 20),
+		ImportChunkSize:     getEnvAsInt("IMPORT_CHUNK_SIZE", 500),
+
+		// Batch defaults
+		BatchMaxOperations: getEnvAsInt("BATCH_MAX_OPERATIONS", 1000),
+
+		// Webhook defaults
+		WebhookEnabled:          getEnvAsBool("WEBHOOK_ENABLED", true),
+		WebhookDispatchInterval: getEnvAsInt("WEBHOOK_DISPATCH_INTERVAL", 5),
+		WebhookTimeout:          getEnvAsInt("WEBHOOK_TIMEOUT", 10),
+		WebhookMaxAttempts:      getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookBackoffBase:      getEnvAsInt("WEBHOOK_BACKOFF_BASE", 30),
+		WebhookBackoffMax:       getEnvAsInt("WEBHOOK_BACKOFF_MAX", 21600), // 6 hours
+		WebhookAllowPrivate:     getEnvAsBool("WEBHOOK_ALLOW_PRIVATE", false),
+
+		// Event stream defaults
+		EventStreamHeartbeat:    getEnvAsInt("EVENT_STREAM_HEARTBEAT", 15),
+		EventStreamPollInterval: getEnvAsInt("EVENT_STREAM_POLL_INTERVAL", 2),
+
+		// Event relay defaults
+		RelayPublisher:    getEnv("RELAY_PUBLISHER", ""),
+		RelayInterval:     getEnvAsInt("RELAY_INTERVAL", 1),
+		RelayBatchSize:    getEnvAsInt("RELAY_BATCH_SIZE", 100),
+		RelayFilePath:     getEnv("RELAY_FILE_PATH", "events.ndjson"),
+		NATSURL:           getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSSubjectPrefix: getEnv("NATS_SUBJECT_PREFIX", "goodschain.events"),
+		KafkaBrokers:      getEnv("KAFKA_BROKERS", "localhost:9092"),
+		KafkaTopic:        getEnv("KAFKA_TOPIC", "goodschain.events"),
+
+		// GraphQL defaults
+		GraphQLMaxDepth:      getEnvAsInt("GRAPHQL_MAX_DEPTH", 8),
+		GraphQLMaxComplexity: getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 10000),
+
+		// Expansion defaults
+		ExpandMaxDepth: getEnvAsInt("EXPAND_MAX_DEPTH", 3),
+
+		// gRPC defaults
+		GRPCPort: getEnv("GRPC_PORT", ""),
+
+		// Rate limit defaults
+		RateLimitEnabled: getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "600/m"),
+		RateLimitGroups:  getEnv("RATE_LIMIT_GROUPS", ""),
+		RateLimitClients: getEnv("RATE_LIMIT_CLIENTS", ""),
+
+		// Auth defaults
+		AuthRequired:        getEnvAsBool("AUTH_REQUIRED", true),
+		AuthTokenSecret:     getEnv("AUTH_TOKEN_SECRET", ""),
+		AuthAccessTokenTTL:  getEnvAsInt("AUTH_ACCESS_TOKEN_TTL", 900),
+		AuthRefreshTokenTTL: getEnvAsInt("AUTH_REFRESH_TOKEN_TTL", 1209600),
+		AuthMaxFailedLogins: getEnvAsInt("AUTH_MAX_FAILED_LOGINS", 5),
+		AuthLockoutDuration: getEnvAsInt("AUTH_LOCKOUT_DURATION", 900),
+
+		// Encryption defaults
+		PIIKeyringFile: getEnv("PII_KEYRING_FILE", ""),
+		PIIEncryption:  getEnv("PII_ENCRYPTION", "on"),
+
+		// Media defaults
+		MediaStore:          getEnv("MEDIA_STORE", "local"),
+		MediaLocalDir:       getEnv("MEDIA_LOCAL_DIR", "media"),
+		MediaS3Endpoint:     getEnv("MEDIA_S3_ENDPOINT", ""),
+		MediaS3Bucket:       getEnv("MEDIA_S3_BUCKET", ""),
+		MediaS3Region:       getEnv("MEDIA_S3_REGION", "us-east-1"),
+		MediaS3AccessKey:    getEnv("MEDIA_S3_ACCESS_KEY", ""),
+		MediaS3SecretKey:    getEnv("MEDIA_S3_SECRET_KEY", ""),
+		MediaMaxFileSizeMB:  getEnvAsInt("MEDIA_MAX_FILE_SIZE_MB", 10),
+		MediaURLSecret:      getEnv("MEDIA_URL_SECRET", ""),
+		MediaURLTTL:         getEnvAsInt("MEDIA_URL_TTL", 900),
+		MediaThumbnailSize:  getEnvAsInt("MEDIA_THUMBNAIL_SIZE", 320),
+		MediaMaxImagePixels: getEnvAsInt("MEDIA_MAX_IMAGE_PIXELS", 50000000),
+		MediaSweepInterval:  getEnvAsInt("MEDIA_SWEEP_INTERVAL", 300),
+
+		// Maintenance defaults
+		MaintenanceIntervalKM:     getEnvAsInt("MAINTENANCE_INTERVAL_KM", 15000),
+		MaintenanceIntervalMonths: getEnvAsInt("MAINTENANCE_INTERVAL_MONTHS", 12),
 	}
 
 	// Validate required configuration
@@ -87,6 +248,24 @@ func (c *Config) Validate() {
 	if _, err := strconv.Atoi(c.APIPort); err != nil {
 		log.Fatal().Err(err).Str("port", c.APIPort).Msg("Invalid API_PORT, must be a number")
 	}
+	if c.GRPCPort != "" {
+		if _, err := strconv.Atoi(c.GRPCPort); err != nil {
+			log.Fatal().Err(err).Str("port", c.GRPCPort).Msg("Invalid GRPC_PORT, must be a number")
+		}
+	}
+
+	if c.AuthTokenSecret != "" && len(c.AuthTokenSecret) < 32 {
+		log.Fatal().Msg("AUTH_TOKEN_SECRET must be at least 32 characters")
+	}
+	if c.MediaURLSecret != "" && len(c.MediaURLSecret) < 32 {
+		log.Fatal().Msg("MEDIA_URL_SECRET must be at least 32 characters")
+	}
+	if c.PIIEncryption != "on" && c.PIIEncryption != "off" {
+		log.Fatal().Str("pii_encryption", c.PIIEncryption).Msg("Invalid PII_ENCRYPTION, must be on or off")
+	}
+	if c.MediaStore != "local" && c.MediaStore != "s3" {
+		log.Fatal().Str("store", c.MediaStore).Msg("Invalid MEDIA_STORE, must be local or s3")
+	}
 
 	// Log configuration (excluding sensitive data)
 	log.Info().
@@ -96,13 +275,33 @@ func (c *Config) Validate() {
 		Str("db_ssl_mode", c.DBSSLMODE).
 		Int("db_max_open_conns", c.DBMaxOpenConns).
 		Int("db_max_idle_conns", c.DBMaxIdleConns).
+		Bool("db_auto_migrate", c.DBAutoMigrate).
 		Str("api_port", c.APIPort).
+		Strs("api_trusted_proxies", c.TrustedProxies()).
 		Int("api_read_timeout", c.APIReadTimeout).
 		Int("api_write_timeout", c.APIWriteTimeout).
 		Str("api_version", c.APIVersion).
+		Str("grpc_port", c.GRPCPort).
+		Bool("rate_limit_enabled", c.RateLimitEnabled).
+		Str("rate_limit_store", c.RateLimitStore).
+		Bool("auth_required", c.AuthRequired).
+		Str("pii_encryption", c.PIIEncryption).
+		Str("media_store", c.MediaStore).
 		Msg("Configuration loaded")
 }
 
+// TrustedProxies returns the addresses and CIDRs of APITrustedProxies, or nil
+// when no proxy is trusted
+func (c *Config) TrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(c.APITrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 // GetDSN returns a formatted connection string for the database
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -127,3 +326,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// Helper function to get an environment variable as a boolean or return a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if valueStr, exists := os.LookupEnv(key); exists {
+		if value, err := strconv.ParseBool(valueStr); err == nil {
+			return value
+		}
+		log.Warn().Str("key", key).Msg("Invalid boolean value in environment variable, using default")
+	}
+	return defaultValue
+}
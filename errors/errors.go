@@ -3,6 +3,10 @@ package errors
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/GoodsChain/backend/i18n"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ErrorCode represents application-specific error codes
@@ -17,12 +21,18 @@ const (
 	ErrUnauthorized  ErrorCode = "UNAUTHORIZED"
 	ErrForbidden     ErrorCode = "FORBIDDEN"
 	ErrAlreadyExists ErrorCode = "ALREADY_EXISTS"
+	ErrConflict      ErrorCode = "CONFLICT" // The resource is in a state that does not allow the request
 	ErrTimeout       ErrorCode = "TIMEOUT"
+	ErrUnavailable   ErrorCode = "UNAVAILABLE"
+	ErrRateLimited   ErrorCode = "RATE_LIMITED" // The client exceeded its rate limit
 
 	// Business logic errors
 	ErrInvalidTransaction ErrorCode = "INVALID_TRANSACTION"
 	ErrInsufficientFunds  ErrorCode = "INSUFFICIENT_FUNDS"
 	ErrInvalidStatus      ErrorCode = "INVALID_STATUS"
+
+	// Batch errors
+	ErrAborted ErrorCode = "ABORTED" // Not applied because another operation in the batch failed
 )
 
 // AppError is a structured error for consistent API error responses
@@ -34,8 +44,10 @@ type AppError struct {
 	HTTPCode int
 	// Error code for client application to handle programmatically
 	Code ErrorCode
-	// Human-readable message
+	// Human-readable message, in English
 	Message string
+	// Localizable form of Message; zero for messages without a catalog entry
+	Msg i18n.Message
 	// Additional details for debugging (not exposed to client)
 	Details map[string]interface{}
 }
@@ -72,6 +84,22 @@ func Wrap(err error, code ErrorCode, message string) *AppError {
 	}
 }
 
+// NewLocalized creates a new AppError with a message from the i18n catalog
+func NewLocalized(code ErrorCode, msg i18n.Message) *AppError {
+	e := New(code, msg.String())
+	e.Msg = msg
+	return e
+}
+
+// Localize returns the message of the error in lang, or the English message
+// when it has no catalog entry
+func (e *AppError) Localize(lang string) string {
+	if e.Msg.IsZero() {
+		return e.Message
+	}
+	return e.Msg.Localize(lang)
+}
+
 // WithDetails adds context information to the error
 func (e *AppError) WithDetails(details map[string]interface{}) *AppError {
 	e.Details = details
@@ -89,22 +117,64 @@ func codeToHTTP(code ErrorCode) int {
 		return http.StatusUnauthorized
 	case ErrForbidden:
 		return http.StatusForbidden
-	case ErrAlreadyExists:
+	case ErrAlreadyExists, ErrConflict:
 		return http.StatusConflict
 	case ErrTimeout:
 		return http.StatusRequestTimeout
+	case ErrUnavailable:
+		return http.StatusServiceUnavailable
+	case ErrRateLimited:
+		return http.StatusTooManyRequests
 	case ErrInvalidTransaction, ErrInsufficientFunds, ErrInvalidStatus:
 		return http.StatusBadRequest
+	case ErrAborted:
+		return http.StatusFailedDependency
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
+// GRPCStatus returns the gRPC status of the error, so status.FromError and
+// the gRPC server translate an AppError like the HTTP handlers do
+func (e *AppError) GRPCStatus() *status.Status {
+	return status.New(codeToGRPC(e.Code), e.Message)
+}
+
+// Helper function to convert error codes to gRPC status codes
+func codeToGRPC(code ErrorCode) codes.Code {
+	switch code {
+	case ErrInvalid:
+		return codes.InvalidArgument
+	case ErrNotFound:
+		return codes.NotFound
+	case ErrUnauthorized:
+		return codes.Unauthenticated
+	case ErrForbidden:
+		return codes.PermissionDenied
+	case ErrAlreadyExists:
+		return codes.AlreadyExists
+	case ErrConflict:
+		return codes.FailedPrecondition
+	case ErrTimeout:
+		return codes.DeadlineExceeded
+	case ErrUnavailable:
+		return codes.Unavailable
+	case ErrRateLimited:
+		return codes.ResourceExhausted
+	case ErrInvalidTransaction, ErrInsufficientFunds, ErrInvalidStatus:
+		return codes.FailedPrecondition
+	case ErrAborted:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
+
 // Convenience error creation functions
 
 // NewNotFound creates a not found error
 func NewNotFound(resource string, id interface{}) *AppError {
-	return New(ErrNotFound, fmt.Sprintf("%s with ID '%v' not found", resource, id))
+	return NewLocalized(ErrNotFound, i18n.M("error.not_found", "resource", i18n.Resource(resource), "id", id))
 }
 
 // NewInvalidInput creates an invalid input error
@@ -114,13 +184,15 @@ func NewInvalidInput(message string) *AppError {
 
 // NewInternalError creates an internal server error
 func NewInternalError(err error) *AppError {
-	return Wrap(err, ErrInternal, "Internal server error")
+	e := NewLocalized(ErrInternal, i18n.M("error.internal"))
+	e.Err = err
+	return e
 }
 
 // NewUnauthorized creates an unauthorized error
 func NewUnauthorized(message string) *AppError {
 	if message == "" {
-		message = "Unauthorized access"
+		return NewLocalized(ErrUnauthorized, i18n.M("error.unauthorized"))
 	}
 	return New(ErrUnauthorized, message)
 }
@@ -128,12 +200,12 @@ func NewUnauthorized(message string) *AppError {
 // NewForbidden creates a forbidden error
 func NewForbidden(message string) *AppError {
 	if message == "" {
-		message = "Access forbidden"
+		return NewLocalized(ErrForbidden, i18n.M("error.forbidden"))
 	}
 	return New(ErrForbidden, message)
 }
 
 // NewAlreadyExists creates an already exists error
 func NewAlreadyExists(resource string, id interface{}) *AppError {
-	return New(ErrAlreadyExists, fmt.Sprintf("%s with ID '%v' already exists", resource, id))
+	return NewLocalized(ErrAlreadyExists, i18n.M("error.already_exists", "resource", i18n.Resource(resource), "id", id))
 }
\ No newline at end of file
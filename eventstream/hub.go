@@ -0,0 +1,313 @@
+// Package eventstream fans outbox events out to live subscribers such as
+// server-sent event clients. Every API instance runs its own Hub, woken by
+// PostgreSQL notifications when events are committed, so all instances
+// deliver the same stream in the same order.
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/rs/zerolog/log"
+)
+
+// Subscription end reasons
+var (
+	// ErrUnavailable is returned by Subscribe before the hub has read the
+	// stream position and after it has been closed
+	ErrUnavailable = errors.New("event stream is unavailable")
+	// ErrTooSlow ends a subscription whose buffer overflowed; the subscriber
+	// can resume from the last event it received
+	ErrTooSlow = errors.New("subscriber fell behind the event stream")
+)
+
+// Options tunes a Hub; zero values select the defaults
+type Options struct {
+	PollInterval time.Duration // Read interval when no notification arrives, default 2s
+	BatchSize    int           // Events read per query, default 500
+	BufferSize   int           // Live events buffered per subscriber, default 256
+}
+
+func (o Options) withDefaults() Options {
+	if o.PollInterval <= 0 {
+		o.PollInterval = 2 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 256
+	}
+	return o
+}
+
+// Hub reads new outbox events and broadcasts them to its subscriptions
+type Hub struct {
+	outboxRepo repository.OutboxRepository
+	listener   Listener
+	opts       Options
+
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	cursor model.EventPosition // position of the last event broadcast
+	ready  bool
+	closed bool
+}
+
+// NewHub creates a hub reading from outboxRepo; listener may be nil, in which
+// case new events are only picked up every PollInterval
+func NewHub(outboxRepo repository.OutboxRepository, listener Listener, opts Options) *Hub {
+	return &Hub{
+		outboxRepo: outboxRepo,
+		listener:   listener,
+		opts:       opts.withDefaults(),
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Run reads new events whenever the listener signals and every PollInterval
+// until ctx is cancelled, then closes the hub
+func (h *Hub) Run(ctx context.Context) {
+	defer h.Close()
+
+	var notify <-chan struct{}
+	if h.listener != nil {
+		notify = h.listener.Notify()
+	}
+	ticker := time.NewTicker(h.opts.PollInterval)
+	defer ticker.Stop()
+
+	h.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-notify:
+			if !ok {
+				notify = nil
+				continue
+			}
+			h.poll()
+		case <-ticker.C:
+			h.poll()
+		}
+	}
+}
+
+// poll broadcasts the events after the cursor. The first poll only reads the
+// current position, as subscribers start from there.
+func (h *Hub) poll() {
+	h.mu.Lock()
+	ready := h.ready
+	h.mu.Unlock()
+
+	if !ready {
+		pos, err := h.outboxRepo.GetLatestPosition()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read event stream position")
+			return
+		}
+		h.mu.Lock()
+		h.cursor, h.ready = pos, true
+		h.mu.Unlock()
+		return
+	}
+
+	for {
+		events, err := h.outboxRepo.GetEventsAfter(h.cursor, "", nil, h.opts.BatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read events for the event stream")
+			return
+		}
+		h.broadcast(events)
+		if len(events) < h.opts.BatchSize {
+			return
+		}
+	}
+}
+
+// broadcast hands events to the matching subscriptions and advances the
+// cursor. Subscriptions whose buffer is full are dropped.
+func (h *Hub) broadcast(events []model.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, event := range events {
+		for sub := range h.subs {
+			if !sub.matches(event) {
+				continue
+			}
+			select {
+			case sub.live <- event:
+			default:
+				h.remove(sub, ErrTooSlow)
+			}
+		}
+	}
+	h.cursor = events[len(events)-1].Position()
+}
+
+// Subscribe starts a subscription to the events of tenantID of the given
+// types, or all types when types is empty. When lastSeq is the sequence of a stored event, the
+// events after it are replayed first; otherwise the subscription starts with
+// the next event. The subscription ends when ctx is cancelled.
+func (h *Hub) Subscribe(ctx context.Context, tenantID string, types []string, lastSeq int64) (*Subscription, error) {
+	var start model.EventPosition
+	resume := false
+	if lastSeq > 0 {
+		pos, err := h.outboxRepo.GetPosition(lastSeq)
+		switch {
+		case err == nil:
+			start, resume = pos, true
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, err
+		}
+	}
+
+	sub := &Subscription{
+		hub:      h,
+		tenantID: tenantID,
+		types:    types,
+		live:     make(chan model.Event, h.opts.BufferSize),
+		events:   make(chan model.Event),
+	}
+
+	h.mu.Lock()
+	if !h.ready || h.closed {
+		h.mu.Unlock()
+		return nil, ErrUnavailable
+	}
+	if !resume {
+		start = h.cursor
+	}
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+
+	go sub.run(ctx, start)
+	return sub, nil
+}
+
+// Close ends all subscriptions and rejects new ones. It lets streaming
+// responses finish when the server shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub, ErrUnavailable)
+	}
+}
+
+// remove unregisters sub and closes its live channel; h.mu must be held
+func (h *Hub) remove(sub *Subscription, reason error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.reason = reason
+	close(sub.live)
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub, nil)
+}
+
+// Subscription delivers events in stream order without duplicates
+type Subscription struct {
+	hub      *Hub
+	tenantID string
+	types    []string
+	live     chan model.Event // events broadcast by the hub
+	events   chan model.Event // events handed to the subscriber
+	reason   error            // why live was closed, set by the hub
+	err      error
+}
+
+// Events returns the channel of events. It is closed when the subscription
+// ends, after which Err reports why.
+func (s *Subscription) Events() <-chan model.Event {
+	return s.events
+}
+
+// Err returns the error that ended the subscription, or nil when it ended
+// because its context was cancelled. Only valid once Events is closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+func (s *Subscription) matches(event model.Event) bool {
+	if event.TenantID != s.tenantID {
+		return false
+	}
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, t := range s.types {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// run replays the stored events after start, then relays live events. Live
+// events buffered during the replay that it already covered are skipped.
+func (s *Subscription) run(ctx context.Context, start model.EventPosition) {
+	defer close(s.events)
+	defer s.hub.unsubscribe(s)
+
+	pos := start
+	for {
+		events, err := s.hub.outboxRepo.GetEventsAfter(pos, s.tenantID, s.types, s.hub.opts.BatchSize)
+		if err != nil {
+			s.err = err
+			return
+		}
+		for _, event := range events {
+			if !s.send(ctx, event) {
+				return
+			}
+			pos = event.Position()
+		}
+		if len(events) < s.hub.opts.BatchSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-s.live:
+			if !ok {
+				s.err = s.reason
+				return
+			}
+			if !event.Position().After(pos) {
+				continue
+			}
+			if !s.send(ctx, event) {
+				return
+			}
+			pos = event.Position()
+		}
+	}
+}
+
+func (s *Subscription) send(ctx context.Context, event model.Event) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,261 @@
+package eventstream
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOutbox keeps events in memory, ordered like the outbox stream
+type fakeOutbox struct {
+	repository.OutboxRepository
+
+	mu     sync.Mutex
+	events []model.Event
+}
+
+func (f *fakeOutbox) add(txID int64, eventType string) {
+	f.addForTenant(model.DefaultTenantID, txID, eventType)
+}
+
+func (f *fakeOutbox) addForTenant(tenantID string, txID int64, eventType string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seq := int64(len(f.events) + 1)
+	f.events = append(f.events, model.Event{Seq: seq, TxID: txID, TenantID: tenantID, Type: eventType, Data: []byte(`{}`)})
+}
+
+func (f *fakeOutbox) GetPosition(seq int64) (model.EventPosition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range f.events {
+		if e.Seq == seq {
+			return e.Position(), nil
+		}
+	}
+	return model.EventPosition{}, repository.ErrNotFound
+}
+
+func (f *fakeOutbox) GetLatestPosition() (model.EventPosition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var latest model.EventPosition
+	for _, e := range f.events {
+		if e.Position().After(latest) {
+			latest = e.Position()
+		}
+	}
+	return latest, nil
+}
+
+func (f *fakeOutbox) GetEventsAfter(pos model.EventPosition, tenantID string, types []string, limit int) ([]model.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var events []model.Event
+	for _, e := range f.events {
+		if e.Position().After(pos) && (tenantID == "" || e.TenantID == tenantID) && (len(types) == 0 || contains(types, e.Type)) {
+			events = append(events, e)
+		}
+	}
+	// Stream order is by transaction, then sequence
+	for i := 1; i < len(events); i++ {
+		for j := i; j > 0 && events[j-1].Position().After(events[j].Position()); j-- {
+			events[j-1], events[j] = events[j], events[j-1]
+		}
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeListener struct {
+	notify chan struct{}
+}
+
+func (l *fakeListener) Notify() <-chan struct{} { return l.notify }
+func (l *fakeListener) Close() error            { return nil }
+
+// receive returns the sequences of the next n events of sub
+func receive(t *testing.T, sub *Subscription, n int) []int64 {
+	t.Helper()
+	var seqs []int64
+	for len(seqs) < n {
+		select {
+		case event, ok := <-sub.Events():
+			require.True(t, ok, "subscription ended: %v", sub.Err())
+			seqs = append(seqs, event.Seq)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %d of %d events", len(seqs), n)
+		}
+	}
+	return seqs
+}
+
+// ended waits for sub to end and returns its error
+func ended(t *testing.T, sub *Subscription) error {
+	t.Helper()
+	select {
+	case _, ok := <-sub.Events():
+		require.False(t, ok, "unexpected event")
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not end")
+	}
+	return sub.Err()
+}
+
+func TestHub_SubscribeBeforeReady(t *testing.T) {
+	hub := NewHub(&fakeOutbox{}, nil, Options{})
+	_, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 0)
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestHub_StreamsNewEventsOfSubscribedTypes(t *testing.T) {
+	outbox := &fakeOutbox{}
+	outbox.add(1, model.EventCarCreated)
+	listener := &fakeListener{notify: make(chan struct{}, 1)}
+	hub := NewHub(outbox, listener, Options{PollInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.ready
+	}, time.Second, time.Millisecond)
+
+	sub, err := hub.Subscribe(ctx, model.DefaultTenantID, []string{model.EventCarUpdated}, 0)
+	require.NoError(t, err)
+
+	// Events committed before subscribing are not sent
+	outbox.add(2, model.EventCarUpdated)
+	outbox.add(2, model.EventCustomerCarCreated)
+	outbox.add(3, model.EventCarUpdated)
+	listener.notify <- struct{}{}
+	assert.Equal(t, []int64{2, 4}, receive(t, sub, 2))
+}
+
+func TestHub_ResumesAfterLastEventID(t *testing.T) {
+	outbox := &fakeOutbox{}
+	outbox.add(1, model.EventCarCreated)
+	outbox.add(2, model.EventCarUpdated)
+	outbox.add(3, model.EventCarUpdated)
+	hub := NewHub(outbox, nil, Options{BatchSize: 1})
+	hub.poll()
+
+	sub, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, receive(t, sub, 2))
+
+	// Live events continue the replay without duplicates
+	outbox.add(4, model.EventCarDeleted)
+	hub.poll()
+	assert.Equal(t, []int64{4}, receive(t, sub, 1))
+}
+
+func TestHub_UnknownLastEventIDStartsLive(t *testing.T) {
+	outbox := &fakeOutbox{}
+	outbox.add(1, model.EventCarCreated)
+	hub := NewHub(outbox, nil, Options{})
+	hub.poll()
+
+	sub, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 99)
+	require.NoError(t, err)
+	outbox.add(2, model.EventCarUpdated)
+	hub.poll()
+	assert.Equal(t, []int64{2}, receive(t, sub, 1))
+}
+
+func TestHub_StreamsInTransactionOrder(t *testing.T) {
+	outbox := &fakeOutbox{}
+	hub := NewHub(outbox, nil, Options{})
+	hub.poll()
+	sub, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 0)
+	require.NoError(t, err)
+
+	// seq 1 was taken by the later transaction
+	outbox.add(11, model.EventCarUpdated)
+	outbox.add(10, model.EventCarCreated)
+	hub.poll()
+	assert.Equal(t, []int64{2, 1}, receive(t, sub, 2))
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	outbox := &fakeOutbox{}
+	hub := NewHub(outbox, nil, Options{BufferSize: 1})
+	hub.poll()
+	sub, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 0)
+	require.NoError(t, err)
+
+	for i := int64(1); i <= 3; i++ {
+		outbox.add(i, model.EventCarUpdated)
+		hub.poll()
+	}
+
+	// Events already replayed or buffered are delivered before the
+	// subscription ends
+	var seqs []int64
+	for event := range sub.Events() {
+		seqs = append(seqs, event.Seq)
+	}
+	assert.IsIncreasing(t, seqs)
+	assert.ErrorIs(t, sub.Err(), ErrTooSlow)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(&fakeOutbox{}, nil, Options{})
+	hub.poll()
+	sub, err := hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 0)
+	require.NoError(t, err)
+
+	hub.Close()
+	assert.ErrorIs(t, ended(t, sub), ErrUnavailable)
+	_, err = hub.Subscribe(context.Background(), model.DefaultTenantID, nil, 0)
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestHub_SubscriptionEndsWithContext(t *testing.T) {
+	hub := NewHub(&fakeOutbox{}, nil, Options{})
+	hub.poll()
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := hub.Subscribe(ctx, model.DefaultTenantID, nil, 0)
+	require.NoError(t, err)
+
+	cancel()
+	assert.NoError(t, ended(t, sub))
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	assert.Empty(t, hub.subs)
+}
+
+func TestHub_StreamsEventsOfSubscribedTenant(t *testing.T) {
+	outbox := &fakeOutbox{}
+	outbox.addForTenant("tenant1", 1, model.EventCarCreated)
+	outbox.addForTenant("tenant2", 2, model.EventCarCreated)
+	hub := NewHub(outbox, nil, Options{})
+	hub.poll()
+
+	// Replayed and live events of other tenants are skipped
+	sub, err := hub.Subscribe(context.Background(), "tenant1", nil, 1)
+	require.NoError(t, err)
+	outbox.addForTenant("tenant2", 3, model.EventCarUpdated)
+	outbox.addForTenant("tenant1", 4, model.EventCarUpdated)
+	hub.poll()
+	assert.Equal(t, []int64{4}, receive(t, sub, 1))
+}
@@ -0,0 +1,64 @@
+package eventstream
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// Channel is the PostgreSQL notification channel signalled when outbox
+// events are committed, see migration 0006
+const Channel = "outbox_event"
+
+// Listener signals that new events may have been committed
+type Listener interface {
+	// Notify returns a channel that receives a value after every signal. It
+	// is closed when the listener is closed.
+	Notify() <-chan struct{}
+	Close() error
+}
+
+type pqListener struct {
+	listener *pq.Listener
+	notify   chan struct{}
+}
+
+// NewPQListener listens for outbox notifications on a dedicated connection to
+// the database at dsn. The connection is re-established when it is lost and a
+// signal is sent after reconnecting, as notifications may have been missed.
+func NewPQListener(dsn string) (Listener, error) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warn().Err(err).Msg("Event stream listener connection problem")
+		}
+	})
+	if err := listener.Listen(Channel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	l := &pqListener{listener: listener, notify: make(chan struct{}, 1)}
+	go l.forward()
+	return l, nil
+}
+
+// forward collapses notifications into signals; a nil notification is sent
+// by pq after a reconnect
+func (l *pqListener) forward() {
+	defer close(l.notify)
+	for range l.listener.Notify {
+		select {
+		case l.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *pqListener) Notify() <-chan struct{} {
+	return l.notify
+}
+
+func (l *pqListener) Close() error {
+	return l.listener.Close()
+}
@@ -0,0 +1,341 @@
+// Package exporter encodes model records as CSV, NDJSON or XLSX one record
+// at a time, so exports can be streamed without holding the result in memory.
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is the file format of an export
+type Format string
+
+// Supported export formats
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for unknown export formats
+var ErrUnsupportedFormat = errors.New("unsupported export format, expected csv, ndjson or xlsx")
+
+// ParseFormat parses a format name; an empty name selects CSV
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Extension returns the file name extension of the format, without the dot
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Writer encodes records of a single struct type
+type Writer interface {
+	// Write encodes one record, a struct or pointer to struct
+	Write(record interface{}) error
+	// Close flushes buffered output and must be called after the last record
+	Close() error
+	// Abort releases resources without flushing buffered output, for
+	// exports that failed before completing
+	Abort()
+}
+
+// NewWriter returns a Writer for records of the same type as sample. Columns
+// are the struct fields with a JSON name, in declaration order, or the fields
+// named by fields, in that order, when any are given.
+func NewWriter(w io.Writer, format Format, sample interface{}, fields ...string) (Writer, error) {
+	columns, err := columnsOf(sample)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		if columns, err = selectColumns(columns, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatNDJSON:
+		buf := bufio.NewWriter(w)
+		nw := &ndjsonWriter{buf: buf, enc: json.NewEncoder(buf)}
+		if len(fields) > 0 {
+			nw.columns = columns
+		}
+		return nw, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// column is an exported struct field
+type column struct {
+	name  string
+	index int
+}
+
+func columnsOf(sample interface{}) ([]column, error) {
+	t := reflect.TypeOf(sample)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("export records must be structs, got %T", sample)
+	}
+
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, column{name: name, index: i})
+	}
+	return columns, nil
+}
+
+// selectColumns returns the columns named by fields, in the order of fields
+func selectColumns(columns []column, fields []string) ([]column, error) {
+	selected := make([]column, 0, len(fields))
+	for _, field := range fields {
+		found := false
+		for _, col := range columns {
+			if col.name == field {
+				selected = append(selected, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown export column '%s'", field)
+		}
+	}
+	return selected, nil
+}
+
+func headerOf(columns []column) []string {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	return header
+}
+
+func structValue(record interface{}) reflect.Value {
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
+
+// formatValue renders a field as text; times use RFC 3339, nil pointers are
+// empty and maps are JSON objects
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return ""
+		}
+		return formatValue(v.Elem())
+	case reflect.Map:
+		if v.Len() == 0 {
+			return ""
+		}
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprint(v.Interface())
+		}
+		return string(data)
+	}
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.UTC().Format(time.RFC3339)
+	case string:
+		return x
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []column
+	row     []string
+}
+
+func newCSVWriter(w io.Writer, columns []column) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), columns: columns, row: make([]string, len(columns))}
+	if err := cw.w.Write(headerOf(columns)); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(record interface{}) error {
+	v := structValue(record)
+	for i, col := range cw.columns {
+		cw.row[i] = formatValue(v.Field(col.index))
+	}
+	return cw.w.Write(cw.row)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) Abort() {}
+
+type ndjsonWriter struct {
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	columns []column // selected columns, nil to encode whole records
+}
+
+func (nw *ndjsonWriter) Write(record interface{}) error {
+	if nw.columns == nil {
+		return nw.enc.Encode(record)
+	}
+
+	// Encode the selected columns by hand to keep them in the requested order
+	v := structValue(record)
+	nw.buf.WriteByte('{')
+	for i, col := range nw.columns {
+		if i > 0 {
+			nw.buf.WriteByte(',')
+		}
+		name, err := json.Marshal(col.name)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(v.Field(col.index).Interface())
+		if err != nil {
+			return err
+		}
+		nw.buf.Write(name)
+		nw.buf.WriteByte(':')
+		nw.buf.Write(value)
+	}
+	_, err := nw.buf.WriteString("}\n")
+	return err
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.buf.Flush()
+}
+
+func (nw *ndjsonWriter) Abort() {}
+
+// xlsxWriter uses the excelize stream writer, which spills rows to a
+// temporary file instead of keeping the worksheet in memory
+type xlsxWriter struct {
+	out     io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	columns []column
+	row     int
+}
+
+const xlsxSheet = "Sheet1"
+
+func newXLSXWriter(w io.Writer, columns []column) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	xw := &xlsxWriter{out: w, file: file, stream: stream, columns: columns, row: 1}
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return xw, nil
+}
+
+func (xw *xlsxWriter) Write(record interface{}) error {
+	v := structValue(record)
+	cells := make([]interface{}, len(xw.columns))
+	for i, col := range xw.columns {
+		f := v.Field(col.index)
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64, reflect.Bool:
+			cells[i] = f.Interface()
+		default:
+			cells[i] = formatValue(f)
+		}
+	}
+
+	xw.row++
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+	return xw.stream.SetRow(cell, cells)
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	return xw.file.Write(xw.out)
+}
+
+func (xw *xlsxWriter) Abort() {
+	xw.file.Close()
+}
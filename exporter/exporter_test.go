@@ -0,0 +1,123 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type sample struct {
+	ID        string    `json:"id"`
+	Price     int       `json:"price"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	internal  string
+}
+
+var createdAt = time.Date(2023, 3, 20, 10, 0, 0, 0, time.UTC)
+
+func writeAll(t *testing.T, format Format, records ...interface{}) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, sample{})
+	require.NoError(t, err)
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"": FormatCSV, "CSV": FormatCSV, "ndjson": FormatNDJSON, " xlsx ": FormatXLSX} {
+		got, err := ParseFormat(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseFormat("pdf")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.Equal(t, "application/x-ndjson", FormatNDJSON.ContentType())
+}
+
+func TestWriter_CSV(t *testing.T) {
+	out := writeAll(t, FormatCSV,
+		&sample{ID: "car1", Price: 25000, Secret: "x", CreatedAt: createdAt},
+		sample{ID: "car,2", Price: 1})
+
+	assert.Equal(t, "id,price,created_at\ncar1,25000,2023-03-20T10:00:00Z\n\"car,2\",1,\n", string(out))
+}
+
+func TestWriter_CSVPointersAndMaps(t *testing.T) {
+	type specced struct {
+		ID     string                 `json:"id"`
+		TrimID *string                `json:"trim_id"`
+		Specs  map[string]interface{} `json:"specs"`
+	}
+	trimID := "trim1"
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV, specced{})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(specced{ID: "car1", TrimID: &trimID, Specs: map[string]interface{}{"fuel": "hybrid", "seats": float64(7)}}))
+	require.NoError(t, w.Write(specced{ID: "car2"}))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "id,trim_id,specs\ncar1,trim1,\"{\"\"fuel\"\":\"\"hybrid\"\",\"\"seats\"\":7}\"\ncar2,,\n", buf.String())
+}
+
+func TestWriter_CSVHeaderOnly(t *testing.T) {
+	assert.Equal(t, "id,price,created_at\n", string(writeAll(t, FormatCSV)))
+}
+
+func TestWriter_NDJSON(t *testing.T) {
+	out := writeAll(t, FormatNDJSON, &sample{ID: "car1", Price: 25000, CreatedAt: createdAt}, &sample{ID: "car2"})
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 2)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	assert.Equal(t, map[string]interface{}{"id": "car1", "price": float64(25000), "created_at": "2023-03-20T10:00:00Z"}, got)
+}
+
+func TestWriter_XLSX(t *testing.T) {
+	out := writeAll(t, FormatXLSX, &sample{ID: "car1", Price: 25000, CreatedAt: createdAt})
+
+	f, err := excelize.OpenReader(bytes.NewReader(out))
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows(xlsxSheet)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"id", "price", "created_at"}, {"car1", "25000", "2023-03-20T10:00:00Z"}}, rows)
+}
+
+func TestNewWriter_Errors(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, FormatCSV, "not a struct")
+	assert.Error(t, err)
+	_, err = NewWriter(&bytes.Buffer{}, Format("pdf"), sample{})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestWriter_Fields(t *testing.T) {
+	record := &sample{ID: "car1", Price: 25000, CreatedAt: createdAt}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV, sample{}, "price", "id")
+	require.NoError(t, err)
+	require.NoError(t, w.Write(record))
+	require.NoError(t, w.Close())
+	assert.Equal(t, "price,id\n25000,car1\n", buf.String())
+
+	buf.Reset()
+	w, err = NewWriter(&buf, FormatNDJSON, sample{}, "created_at", "id")
+	require.NoError(t, err)
+	require.NoError(t, w.Write(record))
+	require.NoError(t, w.Close())
+	assert.Equal(t, `{"created_at":"2023-03-20T10:00:00Z","id":"car1"}`+"\n", buf.String())
+
+	_, err = NewWriter(&buf, FormatCSV, sample{}, "id", "Secret")
+	assert.EqualError(t, err, "unknown export column 'Secret'")
+}
@@ -0,0 +1,155 @@
+// Package fixture reads and writes data fixtures (suppliers, cars, customers
+// and customer-car links) in YAML or JSON and loads them through the
+// repository layer. It backs the seed, export and import CLI commands.
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin/binding"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization format of a fixture file
+type Format string
+
+// Supported fixture formats
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// Fixture is a snapshot of the domain data. Records use the same JSON field
+// names as the API so fixtures can be written by hand from API responses.
+type Fixture struct {
+	Suppliers    []model.Supplier    `json:"suppliers"`
+	Cars         []model.Car         `json:"cars"`
+	Customers    []model.Customer    `json:"customers"`
+	CustomerCars []model.CustomerCar `json:"customer_cars"`
+}
+
+// ParseFormat converts a format name to a Format
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported fixture format %q", name)
+	}
+}
+
+// FormatFromPath infers the fixture format from a file extension
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Load reads a fixture file, inferring the format from its extension
+func Load(path string) (*Fixture, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f, format)
+}
+
+// Decode reads a fixture in the given format and validates every record
+// against the model binding rules
+func Decode(r io.Reader, format Format) (*Fixture, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is converted to JSON first so the models' json tags apply to both formats
+	if format == FormatYAML {
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid YAML fixture: %w", err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("invalid YAML fixture: %w", err)
+		}
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+	if err := fixture.Validate(); err != nil {
+		return nil, err
+	}
+	return &fixture, nil
+}
+
+// Encode writes the fixture in the given format
+func (f *Fixture) Encode(w io.Writer, format Format) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if format == FormatYAML {
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// Validate checks every record against the binding rules used by the API.
+// Records in a fixture must also carry an ID so links between them resolve.
+func (f *Fixture) Validate() error {
+	for i := range f.Suppliers {
+		if err := validateRecord("suppliers", i, f.Suppliers[i].ID, &f.Suppliers[i]); err != nil {
+			return err
+		}
+	}
+	for i := range f.Cars {
+		if err := validateRecord("cars", i, f.Cars[i].ID, &f.Cars[i]); err != nil {
+			return err
+		}
+	}
+	for i := range f.Customers {
+		if err := validateRecord("customers", i, f.Customers[i].ID, &f.Customers[i]); err != nil {
+			return err
+		}
+	}
+	for i := range f.CustomerCars {
+		if err := validateRecord("customer_cars", i, f.CustomerCars[i].ID, &f.CustomerCars[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRecord(section string, index int, id string, record interface{}) error {
+	if id == "" {
+		return fmt.Errorf("%s[%d]: id is required", section, index)
+	}
+	if err := binding.Validator.ValidateStruct(record); err != nil {
+		return fmt.Errorf("%s[%d] (%s): %w", section, index, id, err)
+	}
+	return nil
+}
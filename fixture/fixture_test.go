@@ -0,0 +1,188 @@
+package fixture
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const sampleYAML = `
+suppliers:
+  - id: "supp-1"
+    name: "Toyota Việt Nam"
+    address: "Số 15 Phạm Hùng, Hà Nội"
+    email: "contact@toyotavn.com.vn"
+cars:
+  - id: "car-1"
+    name: "Toyota Vios"
+    supplier_id: "supp-1"
+    price: 450000000
+customers:
+  - id: "cust-1"
+    name: "Nguyễn Văn An"
+    address: "56 Lý Thường Kiệt, Hà Nội"
+    email: "nguyenvanan@demo.com"
+customer_cars:
+  - id: "cc-1"
+    car_id: "car-1"
+    customer_id: "cust-1"
+`
+
+func TestLoad_DemoFixture(t *testing.T) {
+	f, err := Load("../fixtures/demo.yaml")
+	assert.NoError(t, err)
+	assert.Len(t, f.Suppliers, 10)
+	assert.Len(t, f.Cars, 10)
+	assert.Len(t, f.Customers, 10)
+	assert.Len(t, f.CustomerCars, 10)
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		f, err := Decode(strings.NewReader(sampleYAML), FormatYAML)
+		assert.NoError(t, err)
+		assert.Equal(t, "supp-1", f.Cars[0].SupplierID)
+		assert.Equal(t, 450000000, f.Cars[0].Price)
+		assert.Equal(t, "cust-1", f.CustomerCars[0].CustomerID)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		f, err := Decode(strings.NewReader(`{"cars":[{"id":"car-1","name":"Vios","supplier_id":"s","price":1}]}`), FormatJSON)
+		assert.NoError(t, err)
+		assert.Len(t, f.Cars, 1)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{"cars":[{"id":"car-1","name":"Vios","supplier_id":"s","price":0}]}`), FormatJSON)
+		assert.ErrorContains(t, err, "cars[0] (car-1)")
+	})
+
+	t.Run("MissingID", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{"cars":[{"name":"Vios","supplier_id":"s","price":1}]}`), FormatJSON)
+		assert.EqualError(t, err, "cars[0]: id is required")
+	})
+
+	t.Run("InvalidYAML", func(t *testing.T) {
+		_, err := Decode(strings.NewReader("cars: [unterminated"), FormatYAML)
+		assert.ErrorContains(t, err, "invalid YAML fixture")
+	})
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	original, err := Decode(strings.NewReader(sampleYAML), FormatYAML)
+	assert.NoError(t, err)
+
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		var buf bytes.Buffer
+		assert.NoError(t, original.Encode(&buf, format))
+
+		decoded, err := Decode(&buf, format)
+		assert.NoError(t, err, format)
+		assert.Equal(t, original, decoded, format)
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	format, err := FormatFromPath("data/demo.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatYAML, format)
+
+	format, err = FormatFromPath("export.JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = FormatFromPath("export.csv")
+	assert.Error(t, err)
+}
+
+func newMockRepositories(ctrl *gomock.Controller) (Repositories, *mock.MockSupplierRepository, *mock.MockCarRepository, *mock.MockCustomerRepository, *mock.MockCustomerCarRepository) {
+	supplierRepo := mock.NewMockSupplierRepository(ctrl)
+	carRepo := mock.NewMockCarRepository(ctrl)
+	customerRepo := mock.NewMockCustomerRepository(ctrl)
+	customerCarRepo := mock.NewMockCustomerCarRepository(ctrl)
+	return Repositories{Supplier: supplierRepo, Car: carRepo, Customer: customerRepo, CustomerCar: customerCarRepo},
+		supplierRepo, carRepo, customerRepo, customerCarRepo
+}
+
+func TestApply(t *testing.T) {
+	t.Run("SkipExisting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repos, supplierRepo, carRepo, customerRepo, customerCarRepo := newMockRepositories(ctrl)
+		f, _ := Decode(strings.NewReader(sampleYAML), FormatYAML)
+
+		// Supplier exists and is skipped, everything else is created
+		supplierRepo.EXPECT().Get("supp-1").Return(&model.Supplier{ID: "supp-1"}, nil)
+		carRepo.EXPECT().GetCarByID("car-1").Return(nil, repository.ErrNotFound)
+		carRepo.EXPECT().CreateCar(gomock.Any()).DoAndReturn(func(c *model.Car) error {
+			assert.Equal(t, "seed", c.CreatedBy)
+			return nil
+		})
+		customerRepo.EXPECT().Get("cust-1").Return(&model.Customer{}, sql.ErrNoRows)
+		customerRepo.EXPECT().Create(gomock.Any()).Return(nil)
+		customerCarRepo.EXPECT().GetByID("cc-1").Return(nil, repository.ErrNotFound)
+		customerCarRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		result, err := Apply(f, repos, ModeSkipExisting, "seed")
+		assert.NoError(t, err)
+		assert.Equal(t, Counts{Skipped: 1}, result.Suppliers)
+		assert.Equal(t, Counts{Created: 1}, result.Cars)
+		assert.Equal(t, Counts{Created: 1}, result.Customers)
+		assert.Equal(t, Counts{Created: 1}, result.CustomerCars)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repos, supplierRepo, carRepo, customerRepo, customerCarRepo := newMockRepositories(ctrl)
+		f, _ := Decode(strings.NewReader(sampleYAML), FormatYAML)
+
+		supplierRepo.EXPECT().Get("supp-1").Return(&model.Supplier{ID: "supp-1"}, nil)
+		supplierRepo.EXPECT().Update("supp-1", gomock.Any()).Return(nil)
+		carRepo.EXPECT().GetCarByID("car-1").Return(&model.Car{ID: "car-1"}, nil)
+		carRepo.EXPECT().UpdateCar("car-1", gomock.Any()).Return(nil)
+		customerRepo.EXPECT().Get("cust-1").Return(&model.Customer{ID: "cust-1"}, nil)
+		customerRepo.EXPECT().Update("cust-1", gomock.Any()).Return(nil)
+		customerCarRepo.EXPECT().GetByID("cc-1").Return(&model.CustomerCar{ID: "cc-1"}, nil)
+		customerCarRepo.EXPECT().Update("cc-1", gomock.Any()).Return(nil)
+
+		result, err := Apply(f, repos, ModeUpsert, "import")
+		assert.NoError(t, err)
+		assert.Equal(t, Counts{Updated: 1}, result.Suppliers)
+		assert.Equal(t, Counts{Updated: 1}, result.CustomerCars)
+	})
+
+	t.Run("StopsOnError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repos, supplierRepo, _, _, _ := newMockRepositories(ctrl)
+		f, _ := Decode(strings.NewReader(sampleYAML), FormatYAML)
+
+		supplierRepo.EXPECT().Get("supp-1").Return(nil, repository.ErrNotFound)
+		supplierRepo.EXPECT().Create(gomock.Any()).Return(sql.ErrConnDone)
+
+		_, err := Apply(f, repos, ModeSkipExisting, "seed")
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.ErrorContains(t, err, "supplier supp-1")
+	})
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repos, supplierRepo, carRepo, customerRepo, customerCarRepo := newMockRepositories(ctrl)
+
+	supplierRepo.EXPECT().GetAll(model.SupplierFilter{}).Return([]*model.Supplier{{ID: "supp-1"}}, nil)
+	carRepo.EXPECT().GetAllCars(model.CarFilter{}).Return(nil, nil)
+	customerRepo.EXPECT().GetAll(model.CustomerFilter{}).Return([]*model.Customer{{ID: "cust-1"}, {ID: "cust-2"}}, nil)
+	customerCarRepo.EXPECT().GetAll(model.CustomerCarFilter{}).Return([]*model.CustomerCar{}, nil)
+
+	f, err := Export(repos)
+	assert.NoError(t, err)
+	assert.Len(t, f.Suppliers, 1)
+	assert.NotNil(t, f.Cars)
+	assert.Len(t, f.Customers, 2)
+}
@@ -0,0 +1,169 @@
+package fixture
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+)
+
+// Mode controls how records that already exist are handled
+type Mode int
+
+const (
+	// ModeSkipExisting creates missing records and leaves existing ones untouched
+	ModeSkipExisting Mode = iota
+	// ModeUpsert creates missing records and overwrites existing ones
+	ModeUpsert
+)
+
+// Repositories groups the repositories a fixture is loaded through
+type Repositories struct {
+	Supplier    repository.SupplierRepository
+	Car         repository.CarRepository
+	Customer    repository.CustomerRepository
+	CustomerCar repository.CustomerCarRepository
+}
+
+// Counts records how many records of one kind were created, updated or skipped
+type Counts struct {
+	Created int
+	Updated int
+	Skipped int
+}
+
+// Result summarizes a fixture load per resource
+type Result struct {
+	Suppliers    Counts
+	Cars         Counts
+	Customers    Counts
+	CustomerCars Counts
+}
+
+// Apply loads the fixture through the repositories. Records are written in
+// dependency order (suppliers, cars, customers, customer-car links). The actor
+// is recorded as CreatedBy/UpdatedBy on records that do not specify one.
+func Apply(f *Fixture, repos Repositories, mode Mode, actor string) (Result, error) {
+	var result Result
+
+	for i := range f.Suppliers {
+		s := &f.Suppliers[i]
+		_, err := repos.Supplier.Get(s.ID)
+		if err := apply(&result.Suppliers, mode, err,
+			func() error { return repos.Supplier.Create(s) },
+			func() error { return repos.Supplier.Update(s.ID, s) }); err != nil {
+			return result, fmt.Errorf("supplier %s: %w", s.ID, err)
+		}
+	}
+
+	for i := range f.Cars {
+		c := &f.Cars[i]
+		c.CreatedBy, c.UpdatedBy = withActor(c.CreatedBy, actor), withActor(c.UpdatedBy, actor)
+		_, err := repos.Car.GetCarByID(c.ID)
+		if err := apply(&result.Cars, mode, err,
+			func() error { return repos.Car.CreateCar(c) },
+			func() error { return repos.Car.UpdateCar(c.ID, c) }); err != nil {
+			return result, fmt.Errorf("car %s: %w", c.ID, err)
+		}
+	}
+
+	for i := range f.Customers {
+		c := &f.Customers[i]
+		_, err := repos.Customer.Get(c.ID)
+		if err := apply(&result.Customers, mode, err,
+			func() error { return repos.Customer.Create(c) },
+			func() error { return repos.Customer.Update(c.ID, c) }); err != nil {
+			return result, fmt.Errorf("customer %s: %w", c.ID, err)
+		}
+	}
+
+	for i := range f.CustomerCars {
+		cc := &f.CustomerCars[i]
+		cc.CreatedBy, cc.UpdatedBy = withActor(cc.CreatedBy, actor), withActor(cc.UpdatedBy, actor)
+		_, err := repos.CustomerCar.GetByID(cc.ID)
+		if err := apply(&result.CustomerCars, mode, err,
+			func() error { return repos.CustomerCar.Create(cc) },
+			func() error { return repos.CustomerCar.Update(cc.ID, cc) }); err != nil {
+			return result, fmt.Errorf("customer car %s: %w", cc.ID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// apply creates or updates a single record depending on the lookup result
+func apply(counts *Counts, mode Mode, lookupErr error, create, update func() error) error {
+	switch {
+	case isNotFound(lookupErr):
+		if err := create(); err != nil {
+			return err
+		}
+		counts.Created++
+	case lookupErr != nil:
+		return lookupErr
+	case mode == ModeUpsert:
+		if err := update(); err != nil {
+			return err
+		}
+		counts.Updated++
+	default:
+		counts.Skipped++
+	}
+	return nil
+}
+
+// withActor returns value, or actor if value is empty
+func withActor(value, actor string) string {
+	if value == "" {
+		return actor
+	}
+	return value
+}
+
+// isNotFound reports whether a repository lookup failed because the record
+// does not exist. Not every repository maps sql.ErrNoRows to ErrNotFound.
+func isNotFound(err error) bool {
+	return errors.Is(err, repository.ErrNotFound) || errors.Is(err, sql.ErrNoRows)
+}
+
+// Export builds a fixture from everything currently stored
+func Export(repos Repositories) (*Fixture, error) {
+	suppliers, err := repos.Supplier.GetAll(model.SupplierFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("export suppliers: %w", err)
+	}
+	cars, err := repos.Car.GetAllCars(model.CarFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("export cars: %w", err)
+	}
+	customers, err := repos.Customer.GetAll(model.CustomerFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("export customers: %w", err)
+	}
+	customerCars, err := repos.CustomerCar.GetAll(model.CustomerCarFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("export customer cars: %w", err)
+	}
+
+	f := &Fixture{
+		Suppliers:    make([]model.Supplier, 0, len(suppliers)),
+		Cars:         cars,
+		Customers:    make([]model.Customer, 0, len(customers)),
+		CustomerCars: make([]model.CustomerCar, 0, len(customerCars)),
+	}
+	if f.Cars == nil {
+		f.Cars = []model.Car{}
+	}
+	for _, s := range suppliers {
+		f.Suppliers = append(f.Suppliers, *s)
+	}
+	for _, c := range customers {
+		f.Customers = append(f.Customers, *c)
+	}
+	for _, cc := range customerCars {
+		f.CustomerCars = append(f.CustomerCars, *cc)
+	}
+	return f, nil
+}
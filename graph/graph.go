@@ -0,0 +1,97 @@
+// Package graph serves a read-only GraphQL schema of customers, suppliers,
+// cars and customer-car relationships over the usecases. Related records are
+// fetched through per-request loaders, so a nested query costs one usecase
+// call per level instead of one per record, and queries are rejected before
+// execution when they are nested too deeply or may return too much.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Options tunes a Server; zero values select the defaults
+type Options struct {
+	MaxDepth      int           // Maximum field nesting, default 8
+	MaxComplexity int           // Maximum query complexity, default 10000
+	BatchWait     time.Duration // Time a loader collects IDs before fetching them, default 5ms
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxDepth <= 0 {
+		o.MaxDepth = 8
+	}
+	if o.MaxComplexity <= 0 {
+		o.MaxComplexity = 10000
+	}
+	if o.BatchWait <= 0 {
+		o.BatchWait = 5 * time.Millisecond
+	}
+	return o
+}
+
+// Scope is what a request may see: the records of a tenant, with the emails
+// and phones of customers or suppliers masked for callers that may not see them
+type Scope struct {
+	TenantID      string
+	MaskCustomers bool
+	MaskSuppliers bool
+}
+
+// Request is a GraphQL request as sent in a POST body
+type Request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Server executes GraphQL requests
+type Server struct {
+	resolver *Resolver
+	schema   *graphql.Schema
+	analysis *ast.Schema // The same schema, used to check query limits
+	opts     Options
+}
+
+// NewServer creates a Server resolving queries with the given usecases
+func NewServer(customerUsecase usecase.CustomerUsecase, supplierUsecase usecase.SupplierUsecase,
+	carUsecase usecase.CarUsecase, customerCarUsecase usecase.CustomerCarUsecase, opts Options) (*Server, error) {
+	resolver := &Resolver{
+		customers:    customerUsecase,
+		suppliers:    supplierUsecase,
+		cars:         carUsecase,
+		customerCars: customerCarUsecase,
+	}
+	// Resolvers blocked on a loader hold a parallelism slot, allow a full
+	// page of them so the page is fetched in one batch
+	schema, err := graphql.ParseSchema(schemaSDL, resolver, graphql.UseStringDescriptions(),
+		graphql.MaxParallelism(2*maxFirst))
+	if err != nil {
+		return nil, err
+	}
+	analysis, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		return nil, err
+	}
+	return &Server{resolver: resolver, schema: schema, analysis: analysis, opts: opts.withDefaults()}, nil
+}
+
+// Execute runs req over the records of scope and returns the response to
+// send, with any errors in it
+func (s *Server) Execute(ctx context.Context, scope Scope, req Request) *graphql.Response {
+	if err := checkLimits(s.analysis, req, s.opts.MaxDepth, s.opts.MaxComplexity); err != nil {
+		return &graphql.Response{Errors: []*errors.QueryError{err}}
+	}
+	ctx = withLoaders(ctx, newLoaders(s.resolver.withScope(scope), s.opts.BatchWait))
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
@@ -0,0 +1,227 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type serverMocks struct {
+	customers    *usecasemock.MockCustomerUsecase
+	suppliers    *usecasemock.MockSupplierUsecase
+	cars         *usecasemock.MockCarUsecase
+	customerCars *usecasemock.MockCustomerCarUsecase
+}
+
+func newTestServer(t *testing.T, opts Options) (*Server, serverMocks) {
+	ctrl := gomock.NewController(t)
+	m := serverMocks{
+		customers:    usecasemock.NewMockCustomerUsecase(ctrl),
+		suppliers:    usecasemock.NewMockSupplierUsecase(ctrl),
+		cars:         usecasemock.NewMockCarUsecase(ctrl),
+		customerCars: usecasemock.NewMockCustomerCarUsecase(ctrl),
+	}
+	// Queries only read the records of the tenant they are executed for
+	m.customers.EXPECT().WithTenant("tenant1").Return(m.customers).AnyTimes()
+	m.suppliers.EXPECT().WithTenant("tenant1").Return(m.suppliers).AnyTimes()
+	m.cars.EXPECT().WithTenant("tenant1").Return(m.cars).AnyTimes()
+	m.customerCars.EXPECT().WithTenant("tenant1").Return(m.customerCars).AnyTimes()
+	server, err := NewServer(m.customers, m.suppliers, m.cars, m.customerCars, opts)
+	require.NoError(t, err)
+	return server, m
+}
+
+// execute runs query and decodes the data of a successful response into data
+func execute(t *testing.T, server *Server, req Request, data any) *graphql.Response {
+	resp := server.Execute(context.Background(), Scope{TenantID: "tenant1"}, req)
+	if len(resp.Errors) == 0 && data != nil {
+		require.NoError(t, json.Unmarshal(resp.Data, data))
+	}
+	return resp
+}
+
+func TestServer_NestedQueryIsBatched(t *testing.T) {
+	server, m := newTestServer(t, Options{BatchWait: 50 * time.Millisecond})
+	created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	// Every level is fetched with one call, whatever the number of parents
+	m.customers.EXPECT().GetAllCustomers(model.CustomerFilter{Name: "doe"}).Return([]*model.Customer{
+		{ID: "cust1", Name: "John Doe", CreatedAt: created},
+		{ID: "cust2", Name: "Jane Doe", CreatedAt: created},
+	}, nil)
+	m.customerCars.EXPECT().GetCustomerCarsByCustomerIDs(gomock.InAnyOrder([]string{"cust1", "cust2"})).Return([]*model.CustomerCar{
+		{ID: "cc1", CustomerID: "cust1", CarID: "car1"},
+		{ID: "cc2", CustomerID: "cust1", CarID: "car2"},
+		{ID: "cc3", CustomerID: "cust2", CarID: "car1"},
+	}, nil)
+	m.cars.EXPECT().GetCarsByIDs(gomock.InAnyOrder([]string{"car1", "car2"})).Return([]model.Car{
+		{ID: "car1", Name: "Camry", SupplierID: "supp1", Price: 25000},
+		{ID: "car2", Name: "Corolla", SupplierID: "supp1", Price: 20000},
+	}, nil)
+	m.suppliers.EXPECT().GetSuppliersByIDs([]string{"supp1"}).Return([]*model.Supplier{{ID: "supp1", Name: "Toyota"}}, nil)
+
+	var data struct {
+		Customers []struct {
+			ID           string
+			Name         string
+			CreatedAt    time.Time
+			CustomerCars []struct {
+				Car struct {
+					Name     string
+					Price    int
+					Supplier struct{ Name string }
+				}
+			}
+		}
+	}
+	resp := execute(t, server, Request{Query: `{
+		customers(name: "doe") {
+			id name createdAt
+			customerCars { car { name price supplier { name } } }
+		}
+	}`}, &data)
+	require.Empty(t, resp.Errors)
+
+	require.Len(t, data.Customers, 2)
+	assert.Equal(t, "cust1", data.Customers[0].ID)
+	assert.Equal(t, created, data.Customers[0].CreatedAt)
+	require.Len(t, data.Customers[0].CustomerCars, 2)
+	assert.Equal(t, "Corolla", data.Customers[0].CustomerCars[1].Car.Name)
+	assert.Equal(t, 20000, data.Customers[0].CustomerCars[1].Car.Price)
+	require.Len(t, data.Customers[1].CustomerCars, 1)
+	assert.Equal(t, "Toyota", data.Customers[1].CustomerCars[0].Car.Supplier.Name)
+}
+
+func TestServer_MasksContacts(t *testing.T) {
+	server, m := newTestServer(t, Options{})
+	m.customers.EXPECT().GetCustomersByIDs([]string{"cust1"}).Return([]*model.Customer{{ID: "cust1", Email: "john@example.com", Phone: "555-123-4567"}}, nil)
+
+	var data struct {
+		Customer struct{ Email, Phone string }
+	}
+	resp := server.Execute(context.Background(), Scope{TenantID: "tenant1", MaskCustomers: true},
+		Request{Query: `{ customer(id: "cust1") { email phone } }`})
+	require.Empty(t, resp.Errors)
+	require.NoError(t, json.Unmarshal(resp.Data, &data))
+	assert.Equal(t, "j***@example.com", data.Customer.Email)
+	assert.Equal(t, "555-***-4567", data.Customer.Phone)
+}
+
+func TestServer_Lookups(t *testing.T) {
+	t.Run("AliasesShareABatch", func(t *testing.T) {
+		server, m := newTestServer(t, Options{BatchWait: 50 * time.Millisecond})
+		m.suppliers.EXPECT().GetSuppliersByIDs(gomock.InAnyOrder([]string{"supp1", "missing"})).
+			Return([]*model.Supplier{{ID: "supp1", Name: "Toyota"}}, nil)
+		m.cars.EXPECT().GetCarsBySupplierIDs([]string{"supp1"}).Return([]model.Car{
+			{ID: "car1", SupplierID: "supp1"}, {ID: "car2", SupplierID: "supp1"}, {ID: "car3", SupplierID: "supp1"},
+		}, nil)
+
+		var data struct {
+			A *struct{ Cars []struct{ ID string } }
+			B *struct{ Name string }
+		}
+		resp := execute(t, server, Request{Query: `{ a: supplier(id: "supp1") { cars(first: 2) { id } } b: supplier(id: "missing") { name } }`}, &data)
+		require.Empty(t, resp.Errors)
+		require.NotNil(t, data.A)
+		assert.Len(t, data.A.Cars, 2)
+		assert.Nil(t, data.B)
+	})
+
+	t.Run("CustomerCarNotFound", func(t *testing.T) {
+		server, m := newTestServer(t, Options{})
+		m.customerCars.EXPECT().GetCustomerCar("missing").Return(nil, repository.ErrNotFound)
+
+		var data struct{ CustomerCar *struct{ ID string } }
+		resp := execute(t, server, Request{Query: `query($id: ID!) { customerCar(id: $id) { id } }`,
+			Variables: map[string]any{"id": "missing"}}, &data)
+		require.Empty(t, resp.Errors)
+		assert.Nil(t, data.CustomerCar)
+	})
+
+	t.Run("FirstOutOfRange", func(t *testing.T) {
+		server, _ := newTestServer(t, Options{})
+		resp := execute(t, server, Request{Query: `{ cars(first: 500) { id } }`}, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "first must be between 0 and 100", resp.Errors[0].Message)
+	})
+}
+
+func TestServer_Limits(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		req       Request
+		code      string
+		errSubstr string
+	}{
+		{
+			name: "TooDeep",
+			opts: Options{MaxDepth: 4},
+			req:  Request{Query: `{ customer(id: "c") { customerCars { car { supplier { name } } } } }`},
+			code: CodeQueryTooDeep, errSubstr: "query depth 5 exceeds the limit of 4",
+		},
+		{
+			name: "TooDeepThroughFragment",
+			opts: Options{MaxDepth: 4},
+			req: Request{Query: `query { customer(id: "c") { ...links } }
+				fragment links on Customer { customerCars { car { supplier { name } } } }`},
+			code: CodeQueryTooDeep, errSubstr: "query depth 5",
+		},
+		{
+			// 1 + 100 * (1 + 1 + 20 * (1 + 1)) = 4201
+			name: "TooComplex",
+			opts: Options{MaxComplexity: 4000},
+			req:  Request{Query: `{ customers(first: 100) { name customerCars { id customerId } } }`},
+			code: CodeQueryTooComplex, errSubstr: "query complexity 4201 exceeds the limit of 4000",
+		},
+		{
+			name: "ComplexityUsesVariables",
+			opts: Options{MaxComplexity: 50},
+			req: Request{Query: `query($n: Int) { cars(first: $n) { id name } }`,
+				Variables: map[string]any{"n": float64(30)}},
+			code: CodeQueryTooComplex, errSubstr: "query complexity 61",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t, tt.opts)
+			resp := execute(t, server, tt.req, nil)
+			require.Len(t, resp.Errors, 1)
+			assert.Contains(t, resp.Errors[0].Message, tt.errSubstr)
+			assert.Equal(t, tt.code, resp.Errors[0].Extensions["code"])
+			assert.Nil(t, resp.Data)
+		})
+	}
+
+	t.Run("WithinLimits", func(t *testing.T) {
+		server, m := newTestServer(t, Options{MaxDepth: 2, MaxComplexity: 21})
+		m.cars.EXPECT().GetAllCars(model.CarFilter{}).Return([]model.Car{{ID: "car1"}}, nil)
+
+		// 1 + 10 * (1 + 1) = 21
+		resp := execute(t, server, Request{Query: `{ cars(first: 10) { id name } }`}, nil)
+		assert.Empty(t, resp.Errors)
+	})
+
+	t.Run("IntrospectionIsNotLimited", func(t *testing.T) {
+		server, _ := newTestServer(t, Options{MaxDepth: 2, MaxComplexity: 10})
+		resp := execute(t, server, Request{Query: `{ __schema { types { name fields { name type { ofType { name } } } } } }`}, nil)
+		assert.Empty(t, resp.Errors)
+	})
+
+	t.Run("InvalidQueryIsReportedByExecutor", func(t *testing.T) {
+		server, _ := newTestServer(t, Options{})
+		resp := execute(t, server, Request{Query: `{ cars { colour } }`}, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.Contains(t, resp.Errors[0].Message, "colour")
+	})
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Error codes set in the extensions of rejected queries
+const (
+	CodeQueryTooDeep    = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+)
+
+// cost is the depth and complexity of a selection set. Every field costs 1;
+// the selections under a list field count once for every item it may return,
+// bounded by its first argument. Introspection fields count as one field and
+// are not descended into.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// checkLimits rejects an operation nested deeper than maxDepth or more complex
+// than maxComplexity; a zero limit is not checked. Queries that do not
+// validate pass, their errors are reported by the executor.
+func checkLimits(schema *ast.Schema, req Request, maxDepth, maxComplexity int) *errors.QueryError {
+	doc, errs := gqlparser.LoadQuery(schema, req.Query)
+	if len(errs) > 0 {
+		return nil
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	a := analyzer{variables: req.Variables, fragments: map[string]cost{}}
+	c := a.selectionSet(op.SelectionSet)
+	switch {
+	case maxDepth > 0 && c.depth > maxDepth:
+		err := errors.Errorf("query depth %d exceeds the limit of %d", c.depth, maxDepth)
+		err.Extensions = map[string]any{"code": CodeQueryTooDeep}
+		return err
+	case maxComplexity > 0 && c.complexity > maxComplexity:
+		err := errors.Errorf("query complexity %d exceeds the limit of %d", c.complexity, maxComplexity)
+		err.Extensions = map[string]any{"code": CodeQueryTooComplex}
+		return err
+	}
+	return nil
+}
+
+type analyzer struct {
+	variables map[string]any
+	fragments map[string]cost // Cost of the named fragments seen so far
+}
+
+func (a *analyzer) selectionSet(set ast.SelectionSet) cost {
+	var total cost
+	for _, selection := range set {
+		var c cost
+		switch s := selection.(type) {
+		case *ast.Field:
+			c = a.field(s)
+		case *ast.InlineFragment:
+			c = a.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			var ok bool
+			if c, ok = a.fragments[s.Name]; !ok {
+				c = a.selectionSet(s.Definition.SelectionSet)
+				a.fragments[s.Name] = c
+			}
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+func (a *analyzer) field(f *ast.Field) cost {
+	if strings.HasPrefix(f.Name, "__") {
+		return cost{depth: 1, complexity: 1}
+	}
+	children := a.selectionSet(f.SelectionSet)
+	items := 1
+	if f.Definition.Type.Elem != nil {
+		items = maxFirst
+		if first, ok := intValue(f.ArgumentMap(a.variables)["first"]); ok && first >= 0 && first < maxFirst {
+			items = first
+		}
+	}
+	return cost{depth: 1 + children.depth, complexity: 1 + items*children.complexity}
+}
+
+// intValue converts an argument value, a literal or a decoded JSON variable, to an int
+func intValue(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	default:
+		return 0, false
+	}
+}
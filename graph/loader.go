@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the lookups of one request: every ID requested while a batch
+// is open is fetched with a single usecase call
+type loaders struct {
+	customer               *dataloader.Loader[string, *model.Customer]
+	supplier               *dataloader.Loader[string, *model.Supplier]
+	car                    *dataloader.Loader[string, *model.Car]
+	carsBySupplier         *dataloader.Loader[string, []*model.Car]
+	customerCarsByCustomer *dataloader.Loader[string, []*model.CustomerCar]
+	customerCarsByCar      *dataloader.Loader[string, []*model.CustomerCar]
+	// resolver is the tenant-scoped resolver the loaders fetch with
+	resolver *Resolver
+}
+
+func newLoaders(r *Resolver, wait time.Duration) *loaders {
+	return &loaders{
+		customer: dataloader.NewBatchedLoader(byKey(r.customers.GetCustomersByIDs,
+			func(c *model.Customer) string { return c.ID }), dataloader.WithWait[string, *model.Customer](wait)),
+		supplier: dataloader.NewBatchedLoader(byKey(r.suppliers.GetSuppliersByIDs,
+			func(s *model.Supplier) string { return s.ID }), dataloader.WithWait[string, *model.Supplier](wait)),
+		car: dataloader.NewBatchedLoader(byKey(pointers(r.cars.GetCarsByIDs),
+			func(c *model.Car) string { return c.ID }), dataloader.WithWait[string, *model.Car](wait)),
+		carsBySupplier: dataloader.NewBatchedLoader(groupByKey(pointers(r.cars.GetCarsBySupplierIDs),
+			func(c *model.Car) string { return c.SupplierID }), dataloader.WithWait[string, []*model.Car](wait)),
+		customerCarsByCustomer: dataloader.NewBatchedLoader(groupByKey(r.customerCars.GetCustomerCarsByCustomerIDs,
+			func(cc *model.CustomerCar) string { return cc.CustomerID }), dataloader.WithWait[string, []*model.CustomerCar](wait)),
+		customerCarsByCar: dataloader.NewBatchedLoader(groupByKey(r.customerCars.GetCustomerCarsByCarIDs,
+			func(cc *model.CustomerCar) string { return cc.CarID }), dataloader.WithWait[string, []*model.CustomerCar](wait)),
+		resolver: r,
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// byKey adapts fetch to a batch function returning one record per key; keys
+// without a record resolve to nil
+func byKey[V any](fetch func(keys []string) ([]*V, error), key func(*V) string) dataloader.BatchFunc[string, *V] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[*V] {
+		records, err := fetch(keys)
+		results := make([]*dataloader.Result[*V], len(keys))
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*V]{Error: err}
+			}
+			return results
+		}
+
+		found := make(map[string]*V, len(records))
+		for _, record := range records {
+			found[key(record)] = record
+		}
+		for i, k := range keys {
+			results[i] = &dataloader.Result[*V]{Data: found[k]}
+		}
+		return results
+	}
+}
+
+// groupByKey adapts fetch to a batch function returning the records of each
+// key, in the order fetch returned them
+func groupByKey[V any](fetch func(keys []string) ([]*V, error), key func(*V) string) dataloader.BatchFunc[string, []*V] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[[]*V] {
+		records, err := fetch(keys)
+		results := make([]*dataloader.Result[[]*V], len(keys))
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[[]*V]{Error: err}
+			}
+			return results
+		}
+
+		groups := make(map[string][]*V, len(keys))
+		for _, record := range records {
+			k := key(record)
+			groups[k] = append(groups[k], record)
+		}
+		for i, k := range keys {
+			results[i] = &dataloader.Result[[]*V]{Data: groups[k]}
+		}
+		return results
+	}
+}
+
+// pointers adapts a fetch returning values, such as the car usecase, to one
+// returning pointers
+func pointers[V any](fetch func(keys []string) ([]V, error)) func(keys []string) ([]*V, error) {
+	return func(keys []string) ([]*V, error) {
+		values, err := fetch(keys)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]*V, len(values))
+		for i := range values {
+			records[i] = &values[i]
+		}
+		return records, nil
+	}
+}
@@ -0,0 +1,355 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/redact"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxFirst is the largest page a list field returns
+const maxFirst = 100
+
+// Resolver resolves the Query type
+type Resolver struct {
+	customers    usecase.CustomerUsecase
+	suppliers    usecase.SupplierUsecase
+	cars         usecase.CarUsecase
+	customerCars usecase.CustomerCarUsecase
+	access       Scope
+}
+
+// withScope returns a copy of the resolver that only reads the records of
+// the tenant of scope
+func (r *Resolver) withScope(scope Scope) *Resolver {
+	return &Resolver{
+		customers:    r.customers.WithTenant(scope.TenantID),
+		suppliers:    r.suppliers.WithTenant(scope.TenantID),
+		cars:         r.cars.WithTenant(scope.TenantID),
+		customerCars: r.customerCars.WithTenant(scope.TenantID),
+		access:       scope,
+	}
+}
+
+// scope returns the resolver of the tenant of the request in ctx
+func (r *Resolver) scope(ctx context.Context) *Resolver {
+	return loadersFrom(ctx).resolver
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+type listArgs struct {
+	First int32
+}
+
+// limit validates the first argument of a list field
+func limit(first int32) (int, error) {
+	if first < 0 || first > maxFirst {
+		return 0, fmt.Errorf("first must be between 0 and %d", maxFirst)
+	}
+	return int(first), nil
+}
+
+// head returns the first n records
+func head[V any](records []V, n int) []V {
+	if len(records) > n {
+		return records[:n]
+	}
+	return records
+}
+
+func deref[V any](v *V) V {
+	var zero V
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+func (r *Resolver) Customer(ctx context.Context, args idArgs) (*customerResolver, error) {
+	customer, err := loadersFrom(ctx).customer.Load(ctx, string(args.ID))()
+	if err != nil || customer == nil {
+		return nil, err
+	}
+	return &customerResolver{customer}, nil
+}
+
+func (r *Resolver) Customers(ctx context.Context, args struct {
+	Name  *string
+	Email *string
+	First int32
+}) ([]*customerResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	customers, err := r.scope(ctx).customers.GetAllCustomers(model.CustomerFilter{Name: deref(args.Name), Email: deref(args.Email)})
+	if err != nil {
+		return nil, err
+	}
+	return customerResolvers(head(customers, n)), nil
+}
+
+func (r *Resolver) Supplier(ctx context.Context, args idArgs) (*supplierResolver, error) {
+	supplier, err := loadersFrom(ctx).supplier.Load(ctx, string(args.ID))()
+	if err != nil || supplier == nil {
+		return nil, err
+	}
+	return &supplierResolver{supplier}, nil
+}
+
+func (r *Resolver) Suppliers(ctx context.Context, args struct {
+	Name  *string
+	Email *string
+	First int32
+}) ([]*supplierResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	suppliers, err := r.scope(ctx).suppliers.GetAllSuppliers(model.SupplierFilter{Name: deref(args.Name), Email: deref(args.Email)})
+	if err != nil {
+		return nil, err
+	}
+	suppliers = head(suppliers, n)
+	resolvers := make([]*supplierResolver, len(suppliers))
+	for i, supplier := range suppliers {
+		resolvers[i] = &supplierResolver{supplier}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Car(ctx context.Context, args idArgs) (*carResolver, error) {
+	car, err := loadersFrom(ctx).car.Load(ctx, string(args.ID))()
+	if err != nil || car == nil {
+		return nil, err
+	}
+	return &carResolver{car}, nil
+}
+
+func (r *Resolver) Cars(ctx context.Context, args struct {
+	Name       *string
+	SupplierID *graphql.ID
+	MinPrice   *int32
+	MaxPrice   *int32
+	First      int32
+}) ([]*carResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	cars, err := r.scope(ctx).cars.GetAllCars(model.CarFilter{
+		Name:       deref(args.Name),
+		SupplierID: string(deref(args.SupplierID)),
+		MinPrice:   int(deref(args.MinPrice)),
+		MaxPrice:   int(deref(args.MaxPrice)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cars = head(cars, n)
+	resolvers := make([]*carResolver, len(cars))
+	for i := range cars {
+		resolvers[i] = &carResolver{&cars[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CustomerCar(ctx context.Context, args idArgs) (*customerCarResolver, error) {
+	customerCar, err := r.scope(ctx).customerCars.GetCustomerCar(string(args.ID))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customerCarResolver{customerCar}, nil
+}
+
+func (r *Resolver) CustomerCars(ctx context.Context, args struct {
+	CustomerID *graphql.ID
+	CarID      *graphql.ID
+	First      int32
+}) ([]*customerCarResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	customerCars, err := r.scope(ctx).customerCars.GetAllCustomerCars(model.CustomerCarFilter{
+		CustomerID: string(deref(args.CustomerID)),
+		CarID:      string(deref(args.CarID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return customerCarResolvers(head(customerCars, n)), nil
+}
+
+type customerResolver struct {
+	customer *model.Customer
+}
+
+func customerResolvers(customers []*model.Customer) []*customerResolver {
+	resolvers := make([]*customerResolver, len(customers))
+	for i, customer := range customers {
+		resolvers[i] = &customerResolver{customer}
+	}
+	return resolvers
+}
+
+func (r *customerResolver) ID() graphql.ID          { return graphql.ID(r.customer.ID) }
+func (r *customerResolver) Name() string            { return r.customer.Name }
+func (r *customerResolver) Address() string         { return r.customer.Address }
+func (r *customerResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.customer.CreatedAt} }
+func (r *customerResolver) CreatedBy() string       { return r.customer.CreatedBy }
+func (r *customerResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.customer.UpdatedAt} }
+func (r *customerResolver) UpdatedBy() string       { return r.customer.UpdatedBy }
+
+func (r *customerResolver) Phone(ctx context.Context) string {
+	if loadersFrom(ctx).resolver.access.MaskCustomers {
+		return redact.Phone(r.customer.Phone)
+	}
+	return r.customer.Phone
+}
+
+func (r *customerResolver) Email(ctx context.Context) string {
+	if loadersFrom(ctx).resolver.access.MaskCustomers {
+		return redact.Email(r.customer.Email)
+	}
+	return r.customer.Email
+}
+
+func (r *customerResolver) CustomerCars(ctx context.Context, args listArgs) ([]*customerCarResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	customerCars, err := loadersFrom(ctx).customerCarsByCustomer.Load(ctx, r.customer.ID)()
+	if err != nil {
+		return nil, err
+	}
+	return customerCarResolvers(head(customerCars, n)), nil
+}
+
+type supplierResolver struct {
+	supplier *model.Supplier
+}
+
+func (r *supplierResolver) ID() graphql.ID          { return graphql.ID(r.supplier.ID) }
+func (r *supplierResolver) Name() string            { return r.supplier.Name }
+func (r *supplierResolver) Address() string         { return r.supplier.Address }
+func (r *supplierResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.supplier.CreatedAt} }
+func (r *supplierResolver) CreatedBy() string       { return r.supplier.CreatedBy }
+func (r *supplierResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.supplier.UpdatedAt} }
+func (r *supplierResolver) UpdatedBy() string       { return r.supplier.UpdatedBy }
+
+func (r *supplierResolver) Phone(ctx context.Context) string {
+	if loadersFrom(ctx).resolver.access.MaskSuppliers {
+		return redact.Phone(r.supplier.Phone)
+	}
+	return r.supplier.Phone
+}
+
+func (r *supplierResolver) Email(ctx context.Context) string {
+	if loadersFrom(ctx).resolver.access.MaskSuppliers {
+		return redact.Email(r.supplier.Email)
+	}
+	return r.supplier.Email
+}
+
+func (r *supplierResolver) Cars(ctx context.Context, args listArgs) ([]*carResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	cars, err := loadersFrom(ctx).carsBySupplier.Load(ctx, r.supplier.ID)()
+	if err != nil {
+		return nil, err
+	}
+	cars = head(cars, n)
+	resolvers := make([]*carResolver, len(cars))
+	for i, car := range cars {
+		resolvers[i] = &carResolver{car}
+	}
+	return resolvers, nil
+}
+
+type carResolver struct {
+	car *model.Car
+}
+
+func (r *carResolver) ID() graphql.ID          { return graphql.ID(r.car.ID) }
+func (r *carResolver) Name() string            { return r.car.Name }
+func (r *carResolver) SupplierID() graphql.ID  { return graphql.ID(r.car.SupplierID) }
+func (r *carResolver) Price() int32            { return int32(r.car.Price) }
+func (r *carResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.car.CreatedAt} }
+func (r *carResolver) CreatedBy() string       { return r.car.CreatedBy }
+func (r *carResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.car.UpdatedAt} }
+func (r *carResolver) UpdatedBy() string       { return r.car.UpdatedBy }
+
+func (r *carResolver) Supplier(ctx context.Context) (*supplierResolver, error) {
+	supplier, err := loadersFrom(ctx).supplier.Load(ctx, r.car.SupplierID)()
+	if err != nil || supplier == nil {
+		return nil, err
+	}
+	return &supplierResolver{supplier}, nil
+}
+
+func (r *carResolver) CustomerCars(ctx context.Context, args listArgs) ([]*customerCarResolver, error) {
+	n, err := limit(args.First)
+	if err != nil {
+		return nil, err
+	}
+	customerCars, err := loadersFrom(ctx).customerCarsByCar.Load(ctx, r.car.ID)()
+	if err != nil {
+		return nil, err
+	}
+	return customerCarResolvers(head(customerCars, n)), nil
+}
+
+type customerCarResolver struct {
+	customerCar *model.CustomerCar
+}
+
+func customerCarResolvers(customerCars []*model.CustomerCar) []*customerCarResolver {
+	resolvers := make([]*customerCarResolver, len(customerCars))
+	for i, customerCar := range customerCars {
+		resolvers[i] = &customerCarResolver{customerCar}
+	}
+	return resolvers
+}
+
+func (r *customerCarResolver) ID() graphql.ID         { return graphql.ID(r.customerCar.ID) }
+func (r *customerCarResolver) CarID() graphql.ID      { return graphql.ID(r.customerCar.CarID) }
+func (r *customerCarResolver) CustomerID() graphql.ID { return graphql.ID(r.customerCar.CustomerID) }
+func (r *customerCarResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.customerCar.CreatedAt}
+}
+func (r *customerCarResolver) CreatedBy() string { return r.customerCar.CreatedBy }
+func (r *customerCarResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.customerCar.UpdatedAt}
+}
+func (r *customerCarResolver) UpdatedBy() string { return r.customerCar.UpdatedBy }
+
+func (r *customerCarResolver) Car(ctx context.Context) (*carResolver, error) {
+	car, err := loadersFrom(ctx).car.Load(ctx, r.customerCar.CarID)()
+	if err != nil || car == nil {
+		return nil, err
+	}
+	return &carResolver{car}, nil
+}
+
+func (r *customerCarResolver) Customer(ctx context.Context) (*customerResolver, error) {
+	customer, err := loadersFrom(ctx).customer.Load(ctx, r.customerCar.CustomerID)()
+	if err != nil || customer == nil {
+		return nil, err
+	}
+	return &customerResolver{customer}, nil
+}
@@ -0,0 +1,159 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Options configures how calls are authenticated
+type Options struct {
+	// APIKeys and Auth resolve the principal of calls sent with
+	// "authorization: ApiKey <key>" and "authorization: Bearer <access
+	// token>" metadata. Without them calls are not authenticated.
+	APIKeys usecase.APIKeyUsecase
+	Auth    usecase.AuthUsecase
+	// Required rejects calls without credentials; otherwise they are served
+	// anonymously on the default tenant, as over HTTP
+	Required bool
+}
+
+// serviceGroups are the scope groups of the services; calls to services
+// without a group, such as health checks and reflection, are not authorized
+var serviceGroups = map[string]string{
+	"goodschain.v1.CustomerService":    "customers",
+	"goodschain.v1.SupplierService":    "suppliers",
+	"goodschain.v1.CarService":         "cars",
+	"goodschain.v1.CustomerCarService": "customer-cars",
+}
+
+// readPrefixes are the prefixes of the names of methods that only read
+var readPrefixes = []string{"Get", "List", "Stream", "BatchGet", "BatchList"}
+
+type principalKey struct{}
+
+// authenticator authenticates calls by their authorization metadata and
+// authorizes them by the scopes of their principal
+type authenticator struct {
+	opts Options
+}
+
+// unary authorizes a unary call
+func (a authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// stream authorizes a streaming call
+func (a authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize returns ctx carrying the principal of the call to method, or
+// the error rejecting the call: Unauthenticated for missing or invalid
+// credentials and PermissionDenied for a principal without the scope
+func (a authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	if a.opts.APIKeys == nil || a.opts.Auth == nil {
+		return ctx, nil
+	}
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	group, ok := serviceGroups[service]
+	if !ok {
+		return ctx, nil
+	}
+
+	var header string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		header = values[0]
+	}
+	if header == "" {
+		if a.opts.Required {
+			return nil, status.Error(codes.Unauthenticated, "Authentication required")
+		}
+		return ctx, nil
+	}
+
+	scheme, credentials, _ := strings.Cut(header, " ")
+	credentials = strings.TrimSpace(credentials)
+	var principal *model.Principal
+	err := usecase.ErrInvalidToken
+	switch {
+	case strings.EqualFold(scheme, "Bearer"):
+		principal, err = a.opts.Auth.Authenticate(credentials)
+	case strings.EqualFold(scheme, "ApiKey"):
+		principal, err = a.opts.APIKeys.Authenticate(credentials)
+	}
+	switch {
+	case errors.Is(err, usecase.ErrInvalidToken), errors.Is(err, usecase.ErrInvalidAPIKey):
+		return nil, status.Error(codes.Unauthenticated, "Invalid credentials")
+	case err != nil:
+		return nil, toStatus(err)
+	}
+
+	scope := group + ":" + model.ScopeWrite
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			scope = group + ":" + model.ScopeRead
+			break
+		}
+	}
+	if !principal.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "Missing scope %s", scope)
+	}
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// principalStream is a server stream whose context carries the principal
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// principalOf returns the principal of the call with ctx, or nil when it is anonymous
+func principalOf(ctx context.Context) *model.Principal {
+	p, _ := ctx.Value(principalKey{}).(*model.Principal)
+	return p
+}
+
+// tenantOf returns the tenant whose records the call with ctx can access:
+// that of its principal, or the default tenant for anonymous calls
+func tenantOf(ctx context.Context) string {
+	if p := principalOf(ctx); p != nil && p.TenantID != "" {
+		return p.TenantID
+	}
+	return model.DefaultTenantID
+}
+
+// actorOf returns the actor recorded in created_by and updated_by for the
+// call with ctx, or "" for anonymous calls, which the usecases record as system
+func actorOf(ctx context.Context) string {
+	if p := principalOf(ctx); p != nil {
+		return p.Actor
+	}
+	return ""
+}
+
+// masksContacts reports whether the emails and phones of the records of group
+// are masked for the call with ctx: its principal may not write group, or it
+// is anonymous
+func masksContacts(ctx context.Context, group string) bool {
+	return model.MasksContacts(principalOf(ctx), group)
+}
@@ -0,0 +1,161 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type authMocks struct {
+	suppliers *usecasemock.MockSupplierUsecase
+	apiKeys   *usecasemock.MockAPIKeyUsecase
+	auth      *usecasemock.MockAuthUsecase
+}
+
+// newAuthTestConn serves the services authenticating calls over an
+// in-memory listener and returns a client connection to them
+func newAuthTestConn(t *testing.T, required bool) (*grpc.ClientConn, authMocks) {
+	ctrl := gomock.NewController(t)
+	m := authMocks{
+		suppliers: usecasemock.NewMockSupplierUsecase(ctrl),
+		apiKeys:   usecasemock.NewMockAPIKeyUsecase(ctrl),
+		auth:      usecasemock.NewMockAuthUsecase(ctrl),
+	}
+	server := NewServer(usecasemock.NewMockCustomerUsecase(ctrl), m.suppliers, usecasemock.NewMockCarUsecase(ctrl),
+		usecasemock.NewMockCustomerCarUsecase(ctrl), Options{APIKeys: m.apiKeys, Auth: m.auth, Required: required})
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, m
+}
+
+// withAuthorization returns a context sending header as authorization metadata
+func withAuthorization(header string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", header)
+}
+
+func TestAuthentication(t *testing.T) {
+	reader := &model.Principal{Type: model.PrincipalAPIKey, ID: "key1", Actor: "api_key:ci",
+		Scopes: []string{"suppliers:read"}, TenantID: "tenant-a"}
+
+	t.Run("AppliesTenantOfPrincipal", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, true)
+		m.apiKeys.EXPECT().Authenticate("gc_secret").Return(reader, nil)
+		m.suppliers.EXPECT().WithTenant("tenant-a").Return(m.suppliers)
+		m.suppliers.EXPECT().GetSupplier("supp1").Return(&model.Supplier{ID: "supp1", Name: "Acme"}, nil)
+
+		supplier, err := pb.NewSupplierServiceClient(conn).GetSupplier(withAuthorization("ApiKey gc_secret"),
+			&pb.GetSupplierRequest{Id: "supp1"})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme", supplier.GetName())
+	})
+
+	t.Run("MasksContactsForReaders", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, true)
+		m.apiKeys.EXPECT().Authenticate("gc_secret").Return(reader, nil)
+		m.suppliers.EXPECT().WithTenant("tenant-a").Return(m.suppliers)
+		m.suppliers.EXPECT().GetSupplier("supp1").Return(&model.Supplier{ID: "supp1", Email: "sales@acme.example", Phone: "555-987-6543"}, nil)
+
+		supplier, err := pb.NewSupplierServiceClient(conn).GetSupplier(withAuthorization("ApiKey gc_secret"),
+			&pb.GetSupplierRequest{Id: "supp1"})
+		require.NoError(t, err)
+		assert.Equal(t, "s***@acme.example", supplier.GetEmail())
+		assert.Equal(t, "555-***-6543", supplier.GetPhone())
+	})
+
+	t.Run("WriteScope", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, true)
+		writer := &model.Principal{Type: model.PrincipalUser, ID: "u1", Actor: "jane@example.com",
+			Scopes: []string{model.ScopeWrite}, TenantID: "tenant-a"}
+		m.auth.EXPECT().Authenticate("token").Return(writer, nil)
+		m.suppliers.EXPECT().WithTenant("tenant-a").Return(m.suppliers)
+		m.suppliers.EXPECT().DeleteSupplier("supp1").Return(nil)
+
+		_, err := pb.NewSupplierServiceClient(conn).DeleteSupplier(withAuthorization("Bearer token"),
+			&pb.DeleteSupplierRequest{Id: "supp1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("MissingCredentials", func(t *testing.T) {
+		conn, _ := newAuthTestConn(t, true)
+
+		_, err := pb.NewSupplierServiceClient(conn).GetSupplier(context.Background(), &pb.GetSupplierRequest{Id: "supp1"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("AnonymousWhenNotRequired", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, false)
+		m.suppliers.EXPECT().WithTenant(model.DefaultTenantID).Return(m.suppliers)
+		m.suppliers.EXPECT().GetSupplier("supp1").Return(&model.Supplier{ID: "supp1", Name: "Acme"}, nil)
+
+		_, err := pb.NewSupplierServiceClient(conn).GetSupplier(context.Background(), &pb.GetSupplierRequest{Id: "supp1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, false)
+		m.auth.EXPECT().Authenticate("expired").Return(nil, usecase.ErrInvalidToken)
+
+		_, err := pb.NewSupplierServiceClient(conn).GetSupplier(withAuthorization("Bearer expired"),
+			&pb.GetSupplierRequest{Id: "supp1"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = pb.NewSupplierServiceClient(conn).GetSupplier(withAuthorization("Basic dXNlcjpwYXNz"),
+			&pb.GetSupplierRequest{Id: "supp1"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("MissingScope", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, true)
+		m.apiKeys.EXPECT().Authenticate("gc_secret").Return(reader, nil).Times(2)
+
+		_, err := pb.NewSupplierServiceClient(conn).DeleteSupplier(withAuthorization("ApiKey gc_secret"),
+			&pb.DeleteSupplierRequest{Id: "supp1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = pb.NewCarServiceClient(conn).GetCar(withAuthorization("ApiKey gc_secret"), &pb.GetCarRequest{Id: "car1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("StreamAppliesTenantOfPrincipal", func(t *testing.T) {
+		conn, m := newAuthTestConn(t, true)
+		m.apiKeys.EXPECT().Authenticate("gc_secret").Return(reader, nil)
+		m.suppliers.EXPECT().WithTenant("tenant-a").Return(m.suppliers)
+		m.suppliers.EXPECT().StreamSuppliers(gomock.Any(), gomock.Any()).Return(nil)
+
+		stream, err := pb.NewSupplierServiceClient(conn).StreamSuppliers(withAuthorization("ApiKey gc_secret"),
+			&pb.ListSuppliersRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("HealthIsNotAuthorized", func(t *testing.T) {
+		conn, _ := newAuthTestConn(t, true)
+
+		_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+	})
+}
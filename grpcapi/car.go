@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type carServer struct {
+	pb.UnimplementedCarServiceServer
+	cars usecase.CarUsecase
+}
+
+// scoped returns the usecase managing the cars of the
+// tenant of the call with ctx
+func (s *carServer) scoped(ctx context.Context) usecase.CarUsecase {
+	return s.cars.WithTenant(tenantOf(ctx))
+}
+
+func (s *carServer) CreateCar(ctx context.Context, req *pb.CreateCarRequest) (*pb.Car, error) {
+	car := carFromProto(req.GetCar())
+	if err := validate(car); err != nil {
+		return nil, err
+	}
+	if car.ID == "" {
+		car.ID = uuid.New().String()
+	}
+	if a := actorOf(ctx); a != "" {
+		car.CreatedBy, car.UpdatedBy = a, a
+	}
+	if err := s.scoped(ctx).CreateCar(car); err != nil {
+		return nil, err
+	}
+	return s.GetCar(ctx, &pb.GetCarRequest{Id: car.ID})
+}
+
+func (s *carServer) GetCar(ctx context.Context, req *pb.GetCarRequest) (*pb.Car, error) {
+	car, err := s.scoped(ctx).GetCar(req.GetId())
+	if err != nil {
+		return nil, notFound(err, "Car", req.GetId())
+	}
+	return carToProto(car), nil
+}
+
+func (s *carServer) BatchGetCars(ctx context.Context, req *pb.BatchGetCarsRequest) (*pb.BatchGetCarsResponse, error) {
+	cars, err := s.scoped(ctx).GetCarsByIDs(req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchGetCarsResponse{Cars: carsToProto(cars)}, nil
+}
+
+func (s *carServer) ListCars(ctx context.Context, req *pb.ListCarsRequest) (*pb.ListCarsResponse, error) {
+	filter := carFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+	cars, err := s.scoped(ctx).GetAllCars(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListCarsResponse{Cars: carsToProto(cars)}, nil
+}
+
+func (s *carServer) StreamCars(req *pb.ListCarsRequest, stream pb.CarService_StreamCarsServer) error {
+	filter := carFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return err
+	}
+	return s.scoped(stream.Context()).StreamCars(filter, func(car *model.Car) error {
+		return stream.Send(carToProto(car))
+	})
+}
+
+func (s *carServer) ListSupplierCars(ctx context.Context, req *pb.ListSupplierCarsRequest) (*pb.ListCarsResponse, error) {
+	cars, err := s.scoped(ctx).GetCarsBySupplierIDs(req.GetSupplierIds())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListCarsResponse{Cars: carsToProto(cars)}, nil
+}
+
+func (s *carServer) UpdateCar(ctx context.Context, req *pb.UpdateCarRequest) (*pb.Car, error) {
+	car := carFromProto(req.GetCar())
+	if err := validate(car); err != nil {
+		return nil, err
+	}
+	if a := actorOf(ctx); a != "" {
+		car.UpdatedBy = a
+	}
+	if err := s.scoped(ctx).UpdateCar(req.GetId(), car); err != nil {
+		return nil, notFound(err, "Car", req.GetId())
+	}
+	return s.GetCar(ctx, &pb.GetCarRequest{Id: req.GetId()})
+}
+
+func (s *carServer) DeleteCar(ctx context.Context, req *pb.DeleteCarRequest) (*emptypb.Empty, error) {
+	if err := s.scoped(ctx).DeleteCar(req.GetId()); err != nil {
+		return nil, notFound(err, "Car", req.GetId())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func carFromProto(c *pb.Car) *model.Car {
+	return &model.Car{
+		ID:         c.GetId(),
+		Name:       c.GetName(),
+		SupplierID: c.GetSupplierId(),
+		Price:      int(c.GetPrice()),
+	}
+}
+
+func carToProto(c *model.Car) *pb.Car {
+	return &pb.Car{
+		Id:         c.ID,
+		Name:       c.Name,
+		SupplierId: c.SupplierID,
+		Price:      int64(c.Price),
+		CreatedAt:  timestamppb.New(c.CreatedAt),
+		CreatedBy:  c.CreatedBy,
+		UpdatedAt:  timestamppb.New(c.UpdatedAt),
+		UpdatedBy:  c.UpdatedBy,
+	}
+}
+
+func carsToProto(cars []model.Car) []*pb.Car {
+	out := make([]*pb.Car, len(cars))
+	for i := range cars {
+		out[i] = carToProto(&cars[i])
+	}
+	return out
+}
+
+func carFilterFromProto(req *pb.ListCarsRequest) model.CarFilter {
+	return model.CarFilter{
+		Name:         req.GetName(),
+		SupplierID:   req.GetSupplierId(),
+		MinPrice:     int(req.GetMinPrice()),
+		MaxPrice:     int(req.GetMaxPrice()),
+		CreatedRange: createdRangeFromProto(req.GetCreated()),
+	}
+}
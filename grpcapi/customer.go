@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type customerServer struct {
+	pb.UnimplementedCustomerServiceServer
+	customers usecase.CustomerUsecase
+}
+
+// scoped returns the usecase managing the customers of the
+// tenant of the call with ctx
+func (s *customerServer) scoped(ctx context.Context) usecase.CustomerUsecase {
+	return s.customers.WithTenant(tenantOf(ctx))
+}
+
+func (s *customerServer) CreateCustomer(ctx context.Context, req *pb.CreateCustomerRequest) (*pb.Customer, error) {
+	customer := customerFromProto(req.GetCustomer())
+	if err := validate(customer); err != nil {
+		return nil, err
+	}
+	if customer.ID == "" {
+		customer.ID = uuid.New().String()
+	}
+	if a := actorOf(ctx); a != "" {
+		customer.CreatedBy, customer.UpdatedBy = a, a
+	}
+	if err := s.scoped(ctx).CreateCustomer(customer); err != nil {
+		return nil, err
+	}
+	return s.GetCustomer(ctx, &pb.GetCustomerRequest{Id: customer.ID})
+}
+
+func (s *customerServer) GetCustomer(ctx context.Context, req *pb.GetCustomerRequest) (*pb.Customer, error) {
+	customer, err := s.scoped(ctx).GetCustomer(req.GetId())
+	if err != nil {
+		return nil, notFound(err, "Customer", req.GetId())
+	}
+	maskCustomers(ctx, customer)
+	return customerToProto(customer), nil
+}
+
+func (s *customerServer) BatchGetCustomers(ctx context.Context, req *pb.BatchGetCustomersRequest) (*pb.BatchGetCustomersResponse, error) {
+	customers, err := s.scoped(ctx).GetCustomersByIDs(req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	maskCustomers(ctx, customers...)
+	return &pb.BatchGetCustomersResponse{Customers: customersToProto(customers)}, nil
+}
+
+func (s *customerServer) ListCustomers(ctx context.Context, req *pb.ListCustomersRequest) (*pb.ListCustomersResponse, error) {
+	filter := customerFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+	customers, err := s.scoped(ctx).GetAllCustomers(filter)
+	if err != nil {
+		return nil, err
+	}
+	maskCustomers(ctx, customers...)
+	return &pb.ListCustomersResponse{Customers: customersToProto(customers)}, nil
+}
+
+func (s *customerServer) StreamCustomers(req *pb.ListCustomersRequest, stream pb.CustomerService_StreamCustomersServer) error {
+	filter := customerFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return err
+	}
+	return s.scoped(stream.Context()).StreamCustomers(filter, func(customer *model.Customer) error {
+		maskCustomers(stream.Context(), customer)
+		return stream.Send(customerToProto(customer))
+	})
+}
+
+func (s *customerServer) UpdateCustomer(ctx context.Context, req *pb.UpdateCustomerRequest) (*pb.Customer, error) {
+	customer := customerFromProto(req.GetCustomer())
+	if err := validate(customer); err != nil {
+		return nil, err
+	}
+	if a := actorOf(ctx); a != "" {
+		customer.UpdatedBy = a
+	}
+	if err := s.scoped(ctx).UpdateCustomer(req.GetId(), customer); err != nil {
+		return nil, notFound(err, "Customer", req.GetId())
+	}
+	return s.GetCustomer(ctx, &pb.GetCustomerRequest{Id: req.GetId()})
+}
+
+func (s *customerServer) DeleteCustomer(ctx context.Context, req *pb.DeleteCustomerRequest) (*emptypb.Empty, error) {
+	if err := s.scoped(ctx).DeleteCustomer(req.GetId()); err != nil {
+		return nil, notFound(err, "Customer", req.GetId())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// maskCustomers masks the emails and phones of customers for callers that may not
+// see them, as over HTTP
+func maskCustomers(ctx context.Context, customers ...*model.Customer) {
+	if masksContacts(ctx, "customers") {
+		for _, customer := range customers {
+			customer.MaskContact()
+		}
+	}
+}
+
+func customerFromProto(c *pb.Customer) *model.Customer {
+	return &model.Customer{
+		ID:      c.GetId(),
+		Name:    c.GetName(),
+		Address: c.GetAddress(),
+		Phone:   c.GetPhone(),
+		Email:   c.GetEmail(),
+	}
+}
+
+func customerToProto(c *model.Customer) *pb.Customer {
+	return &pb.Customer{
+		Id:        c.ID,
+		Name:      c.Name,
+		Address:   c.Address,
+		Phone:     c.Phone,
+		Email:     c.Email,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		UpdatedBy: c.UpdatedBy,
+	}
+}
+
+func customersToProto(customers []*model.Customer) []*pb.Customer {
+	out := make([]*pb.Customer, len(customers))
+	for i, customer := range customers {
+		out[i] = customerToProto(customer)
+	}
+	return out
+}
+
+func customerFilterFromProto(req *pb.ListCustomersRequest) model.CustomerFilter {
+	return model.CustomerFilter{
+		Name:         req.GetName(),
+		Email:        req.GetEmail(),
+		CreatedRange: createdRangeFromProto(req.GetCreated()),
+	}
+}
+
+// createdRangeFromProto converts a creation time range; unset bounds stay open
+func createdRangeFromProto(r *pb.CreatedRange) model.CreatedRange {
+	var created model.CreatedRange
+	if r.GetCreatedAfter() != nil {
+		created.CreatedAfter = r.GetCreatedAfter().AsTime()
+	}
+	if r.GetCreatedBefore() != nil {
+		created.CreatedBefore = r.GetCreatedBefore().AsTime()
+	}
+	return created
+}
@@ -0,0 +1,138 @@
+package grpcapi
+
+import (
+	"context"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type customerCarServer struct {
+	pb.UnimplementedCustomerCarServiceServer
+	customerCars usecase.CustomerCarUsecase
+}
+
+// scoped returns the usecase managing the customer-car relationships of the
+// tenant of the call with ctx
+func (s *customerCarServer) scoped(ctx context.Context) usecase.CustomerCarUsecase {
+	return s.customerCars.WithTenant(tenantOf(ctx))
+}
+
+func (s *customerCarServer) CreateCustomerCar(ctx context.Context, req *pb.CreateCustomerCarRequest) (*pb.CustomerCar, error) {
+	customerCar := customerCarFromProto(req.GetCustomerCar())
+	if err := validate(customerCar); err != nil {
+		return nil, err
+	}
+	if customerCar.ID == "" {
+		customerCar.ID = uuid.New().String()
+	}
+	if a := actorOf(ctx); a != "" {
+		customerCar.CreatedBy, customerCar.UpdatedBy = a, a
+	}
+	if err := s.scoped(ctx).CreateCustomerCar(customerCar); err != nil {
+		return nil, err
+	}
+	return s.GetCustomerCar(ctx, &pb.GetCustomerCarRequest{Id: customerCar.ID})
+}
+
+func (s *customerCarServer) GetCustomerCar(ctx context.Context, req *pb.GetCustomerCarRequest) (*pb.CustomerCar, error) {
+	customerCar, err := s.scoped(ctx).GetCustomerCar(req.GetId())
+	if err != nil {
+		return nil, notFound(err, "CustomerCar", req.GetId())
+	}
+	return customerCarToProto(customerCar), nil
+}
+
+func (s *customerCarServer) ListCustomerCars(ctx context.Context, req *pb.ListCustomerCarsRequest) (*pb.ListCustomerCarsResponse, error) {
+	customerCars, err := s.scoped(ctx).GetAllCustomerCars(customerCarFilterFromProto(req))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListCustomerCarsResponse{CustomerCars: customerCarsToProto(customerCars)}, nil
+}
+
+func (s *customerCarServer) StreamCustomerCars(req *pb.ListCustomerCarsRequest, stream pb.CustomerCarService_StreamCustomerCarsServer) error {
+	return s.scoped(stream.Context()).StreamCustomerCars(customerCarFilterFromProto(req), func(customerCar *model.CustomerCar) error {
+		return stream.Send(customerCarToProto(customerCar))
+	})
+}
+
+func (s *customerCarServer) BatchListCustomerCars(ctx context.Context, req *pb.BatchListCustomerCarsRequest) (*pb.ListCustomerCarsResponse, error) {
+	var customerCars []*model.CustomerCar
+	var err error
+	switch {
+	case len(req.GetCustomerIds()) > 0 && len(req.GetCarIds()) > 0,
+		len(req.GetCustomerIds()) == 0 && len(req.GetCarIds()) == 0:
+		return nil, appErrors.NewInvalidInput("exactly one of customer_ids and car_ids must be set")
+	case len(req.GetCustomerIds()) > 0:
+		customerCars, err = s.scoped(ctx).GetCustomerCarsByCustomerIDs(req.GetCustomerIds())
+	default:
+		customerCars, err = s.scoped(ctx).GetCustomerCarsByCarIDs(req.GetCarIds())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListCustomerCarsResponse{CustomerCars: customerCarsToProto(customerCars)}, nil
+}
+
+func (s *customerCarServer) UpdateCustomerCar(ctx context.Context, req *pb.UpdateCustomerCarRequest) (*pb.CustomerCar, error) {
+	customerCar := customerCarFromProto(req.GetCustomerCar())
+	if err := validate(customerCar); err != nil {
+		return nil, err
+	}
+	if a := actorOf(ctx); a != "" {
+		customerCar.UpdatedBy = a
+	}
+	if err := s.scoped(ctx).UpdateCustomerCar(req.GetId(), customerCar); err != nil {
+		return nil, notFound(err, "CustomerCar", req.GetId())
+	}
+	return s.GetCustomerCar(ctx, &pb.GetCustomerCarRequest{Id: req.GetId()})
+}
+
+func (s *customerCarServer) DeleteCustomerCar(ctx context.Context, req *pb.DeleteCustomerCarRequest) (*emptypb.Empty, error) {
+	if err := s.scoped(ctx).DeleteCustomerCar(req.GetId()); err != nil {
+		return nil, notFound(err, "CustomerCar", req.GetId())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func customerCarFromProto(cc *pb.CustomerCar) *model.CustomerCar {
+	return &model.CustomerCar{
+		ID:         cc.GetId(),
+		CarID:      cc.GetCarId(),
+		CustomerID: cc.GetCustomerId(),
+	}
+}
+
+func customerCarToProto(cc *model.CustomerCar) *pb.CustomerCar {
+	return &pb.CustomerCar{
+		Id:         cc.ID,
+		CarId:      cc.CarID,
+		CustomerId: cc.CustomerID,
+		CreatedAt:  timestamppb.New(cc.CreatedAt),
+		CreatedBy:  cc.CreatedBy,
+		UpdatedAt:  timestamppb.New(cc.UpdatedAt),
+		UpdatedBy:  cc.UpdatedBy,
+	}
+}
+
+func customerCarsToProto(customerCars []*model.CustomerCar) []*pb.CustomerCar {
+	out := make([]*pb.CustomerCar, len(customerCars))
+	for i, customerCar := range customerCars {
+		out[i] = customerCarToProto(customerCar)
+	}
+	return out
+}
+
+func customerCarFilterFromProto(req *pb.ListCustomerCarsRequest) model.CustomerCarFilter {
+	return model.CustomerCarFilter{
+		CustomerID:   req.GetCustomerId(),
+		CarID:        req.GetCarId(),
+		CreatedRange: createdRangeFromProto(req.GetCreated()),
+	}
+}
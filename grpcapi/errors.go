@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"unicode"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus translates an error returned by a service to a gRPC status error.
+// AppErrors carry their own status; repository errors map to the code
+// closest to their HTTP response, with the fixed message of the repository
+// error rather than the database message naming constraints, and anything
+// else is an internal error, whose cause is logged but not returned.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var appErr *appErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.GRPCStatus().Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, repository.ErrNotFound.Error())
+	case errors.Is(err, repository.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, repository.ErrDuplicate.Error())
+	case errors.Is(err, repository.ErrReference):
+		return status.Error(codes.FailedPrecondition, repository.ErrReference.Error())
+	case errors.Is(err, repository.ErrInvalidValue):
+		return status.Error(codes.InvalidArgument, repository.ErrInvalidValue.Error())
+	default:
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+// notFound reports a missing record as a not found error naming it
+func notFound(err error, resource, id string) error {
+	if errors.Is(err, repository.ErrNotFound) || errors.Is(err, sql.ErrNoRows) {
+		return appErrors.Wrap(err, appErrors.ErrNotFound, fmt.Sprintf("%s with ID '%v' not found", resource, id))
+	}
+	return err
+}
+
+// validate checks v against its binding tags, as the HTTP handlers do when
+// binding a request, and reports the failed fields as BadRequest details.
+// The message only counts them; the validator message names Go types.
+func validate(v any) error {
+	err := binding.Validator.ValidateStruct(v)
+	if err == nil {
+		return nil
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return status.Error(codes.InvalidArgument, "Invalid request")
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, fe := range fieldErrs {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       snakeCase(fe.Field()),
+			Description: fmt.Sprintf("failed on the '%s' rule", fe.Tag()),
+		})
+	}
+	message := fmt.Sprintf("%d fields are invalid", len(fieldErrs))
+	if len(fieldErrs) == 1 {
+		message = "1 field is invalid"
+	}
+	st, detailErr := status.New(codes.InvalidArgument, message).WithDetails(badRequest)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+// snakeCase converts a Go field name, such as SupplierID, to the name of the
+// matching proto field, supplier_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	out := make([]rune, 0, len(runes)+4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a word at an upper case letter after a lower case one, or
+			// at the last upper case letter of an acronym followed by a word
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// unaryInterceptor translates the error of a unary call and logs the call
+func unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return resp, toStatus(err)
+}
+
+// streamInterceptor translates the error of a streaming call and logs the call
+func streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(info.FullMethod, start, err)
+	return toStatus(err)
+}
+
+// logCall logs a completed call like the HTTP middleware logs a request:
+// server errors at error level and client errors at warn level
+func logCall(method string, start time.Time, err error) {
+	code := status.Code(toStatus(err))
+	var event *zerolog.Event
+	switch code {
+	case codes.OK:
+		event = log.Info()
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		event = log.Error().Err(err)
+	default:
+		event = log.Warn().Err(err)
+	}
+	event.Str("method", method).
+		Str("code", code.String()).
+		Dur("latency", time.Since(start)).
+		Msg("gRPC call")
+}
@@ -0,0 +1,74 @@
+// Package grpcapi serves the customer, supplier, car and customer-car
+// usecases as the gRPC services defined in proto/goodschain/v1. Usecase
+// errors are translated to gRPC status codes, the server supports
+// reflection, and the same services are exposed as JSON over HTTP through a
+// grpc-gateway following the google.api.http annotations of the protos.
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewServer creates a gRPC server with the services of the given usecases,
+// the health service and server reflection registered. Calls to the services
+// are authenticated as opts configures and access the tenant of their principal.
+func NewServer(customerUsecase usecase.CustomerUsecase, supplierUsecase usecase.SupplierUsecase,
+	carUsecase usecase.CarUsecase, customerCarUsecase usecase.CustomerCarUsecase, opts Options) *grpc.Server {
+	auth := authenticator{opts: opts}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor, auth.unary),
+		grpc.ChainStreamInterceptor(streamInterceptor, auth.stream),
+	)
+	pb.RegisterCustomerServiceServer(server, &customerServer{customers: customerUsecase})
+	pb.RegisterSupplierServiceServer(server, &supplierServer{suppliers: supplierUsecase})
+	pb.RegisterCarServiceServer(server, &carServer{cars: carUsecase})
+	pb.RegisterCustomerCarServiceServer(server, &customerCarServer{customerCars: customerCarUsecase})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	return server
+}
+
+// NewGateway creates an HTTP handler translating JSON requests to calls on
+// the services behind conn. Fields keep their proto names, such as
+// supplier_id, as in the REST API.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}))
+	for _, register := range []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		pb.RegisterCustomerServiceHandler,
+		pb.RegisterSupplierServiceHandler,
+		pb.RegisterCarServiceHandler,
+		pb.RegisterCustomerCarServiceHandler,
+	} {
+		if err := register(ctx, mux, conn); err != nil {
+			return nil, err
+		}
+	}
+	return mux, nil
+}
+
+// Handler serves gRPC requests with server and every other request with
+// gateway, so both share one port. gRPC requires HTTP/2, which the serving
+// http.Server must allow unencrypted when it does not use TLS.
+func Handler(server *grpc.Server, gateway http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			server.ServeHTTP(w, r)
+			return
+		}
+		gateway.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,367 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type serverMocks struct {
+	customers    *usecasemock.MockCustomerUsecase
+	suppliers    *usecasemock.MockSupplierUsecase
+	cars         *usecasemock.MockCarUsecase
+	customerCars *usecasemock.MockCustomerCarUsecase
+}
+
+// newTestConn serves the services over an in-memory listener and returns a
+// client connection to them
+func newTestConn(t *testing.T) (*grpc.ClientConn, serverMocks) {
+	ctrl := gomock.NewController(t)
+	m := serverMocks{
+		customers:    usecasemock.NewMockCustomerUsecase(ctrl),
+		suppliers:    usecasemock.NewMockSupplierUsecase(ctrl),
+		cars:         usecasemock.NewMockCarUsecase(ctrl),
+		customerCars: usecasemock.NewMockCustomerCarUsecase(ctrl),
+	}
+	m.customers.EXPECT().WithTenant(model.DefaultTenantID).Return(m.customers).AnyTimes()
+	m.suppliers.EXPECT().WithTenant(model.DefaultTenantID).Return(m.suppliers).AnyTimes()
+	m.cars.EXPECT().WithTenant(model.DefaultTenantID).Return(m.cars).AnyTimes()
+	m.customerCars.EXPECT().WithTenant(model.DefaultTenantID).Return(m.customerCars).AnyTimes()
+	server := NewServer(m.customers, m.suppliers, m.cars, m.customerCars, Options{})
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, m
+}
+
+func TestCustomerService(t *testing.T) {
+	created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Create", func(t *testing.T) {
+		conn, m := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		var id string
+		m.customers.EXPECT().CreateCustomer(gomock.Any()).DoAndReturn(func(c *model.Customer) error {
+			assert.NotEmpty(t, c.ID)
+			assert.Equal(t, "John Doe", c.Name)
+			id = c.ID
+			return nil
+		})
+		m.customers.EXPECT().GetCustomer(gomock.Any()).DoAndReturn(func(got string) (*model.Customer, error) {
+			assert.Equal(t, id, got)
+			return &model.Customer{ID: id, Name: "John Doe", Address: "1 Main St", Email: "john@example.com",
+				CreatedAt: created, CreatedBy: "admin"}, nil
+		})
+
+		customer, err := client.CreateCustomer(context.Background(), &pb.CreateCustomerRequest{Customer: &pb.Customer{
+			Name: "John Doe", Address: "1 Main St", Email: "john@example.com",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, id, customer.GetId())
+		assert.Equal(t, "admin", customer.GetCreatedBy())
+		assert.Equal(t, created, customer.GetCreatedAt().AsTime())
+	})
+
+	t.Run("Create Invalid", func(t *testing.T) {
+		conn, _ := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		_, err := client.CreateCustomer(context.Background(), &pb.CreateCustomerRequest{Customer: &pb.Customer{
+			Name: "John Doe", Address: "1 Main St", Email: "not-an-email",
+		}})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "1 field is invalid", st.Message())
+		require.Len(t, st.Details(), 1)
+		badRequest := st.Details()[0].(*errdetails.BadRequest)
+		require.Len(t, badRequest.GetFieldViolations(), 1)
+		assert.Equal(t, "email", badRequest.GetFieldViolations()[0].GetField())
+	})
+
+	t.Run("Get Not Found", func(t *testing.T) {
+		conn, m := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		// The customer repository reports a missing row as sql.ErrNoRows
+		m.customers.EXPECT().GetCustomer("missing").Return(nil, sql.ErrNoRows)
+		_, err := client.GetCustomer(context.Background(), &pb.GetCustomerRequest{Id: "missing"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "Customer with ID 'missing' not found", st.Message())
+	})
+
+	t.Run("List", func(t *testing.T) {
+		conn, m := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		m.customers.EXPECT().GetAllCustomers(model.CustomerFilter{
+			Name:         "doe",
+			CreatedRange: model.CreatedRange{CreatedAfter: created},
+		}).Return([]*model.Customer{{ID: "cust1"}, {ID: "cust2"}}, nil)
+
+		resp, err := client.ListCustomers(context.Background(), &pb.ListCustomersRequest{
+			Name:    "doe",
+			Created: &pb.CreatedRange{CreatedAfter: timestamppb.New(created)},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetCustomers(), 2)
+		assert.Equal(t, "cust2", resp.GetCustomers()[1].GetId())
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		conn, m := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		m.customers.EXPECT().StreamCustomers(model.CustomerFilter{}, gomock.Any()).DoAndReturn(
+			func(_ model.CustomerFilter, fn func(*model.Customer) error) error {
+				for _, id := range []string{"cust1", "cust2", "cust3"} {
+					if err := fn(&model.Customer{ID: id}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		stream, err := client.StreamCustomers(context.Background(), &pb.ListCustomersRequest{})
+		require.NoError(t, err)
+		var ids []string
+		for {
+			customer, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			ids = append(ids, customer.GetId())
+		}
+		assert.Equal(t, []string{"cust1", "cust2", "cust3"}, ids)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		conn, m := newTestConn(t)
+		client := pb.NewCustomerServiceClient(conn)
+
+		m.customers.EXPECT().DeleteCustomer("cust1").Return(nil)
+		_, err := client.DeleteCustomer(context.Background(), &pb.DeleteCustomerRequest{Id: "cust1"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCarService_Update(t *testing.T) {
+	conn, m := newTestConn(t)
+	client := pb.NewCarServiceClient(conn)
+
+	m.cars.EXPECT().UpdateCar("car1", &model.Car{Name: "Camry", SupplierID: "supp1", Price: 25000}).Return(nil)
+	m.cars.EXPECT().GetCar("car1").Return(&model.Car{ID: "car1", Name: "Camry", SupplierID: "supp1", Price: 25000}, nil)
+
+	car, err := client.UpdateCar(context.Background(), &pb.UpdateCarRequest{
+		Id:  "car1",
+		Car: &pb.Car{Name: "Camry", SupplierId: "supp1", Price: 25000},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(25000), car.GetPrice())
+
+	_, err = client.UpdateCar(context.Background(), &pb.UpdateCarRequest{
+		Id:  "car1",
+		Car: &pb.Car{Name: "Camry", Price: 25000},
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "supplier_id", st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField())
+}
+
+func TestCustomerCarService_BatchList(t *testing.T) {
+	conn, m := newTestConn(t)
+	client := pb.NewCustomerCarServiceClient(conn)
+
+	m.customerCars.EXPECT().GetCustomerCarsByCarIDs([]string{"car1", "car2"}).Return([]*model.CustomerCar{
+		{ID: "cc1", CarID: "car1"}, {ID: "cc2", CarID: "car2"},
+	}, nil)
+	resp, err := client.BatchListCustomerCars(context.Background(), &pb.BatchListCustomerCarsRequest{CarIds: []string{"car1", "car2"}})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetCustomerCars(), 2)
+
+	_, err = client.BatchListCustomerCars(context.Background(), &pb.BatchListCustomerCarsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"Not Found", appErrors.NewNotFound("Car", "car1"), codes.NotFound, "Car with ID 'car1' not found"},
+		{"Invalid Input", appErrors.NewInvalidInput("bad"), codes.InvalidArgument, "bad"},
+		{"Unauthorized", appErrors.NewUnauthorized(""), codes.Unauthenticated, "Unauthorized access"},
+		{"Forbidden", appErrors.NewForbidden(""), codes.PermissionDenied, "Access forbidden"},
+		{"Already Exists", appErrors.NewAlreadyExists("Car", "car1"), codes.AlreadyExists, "Car with ID 'car1' already exists"},
+		{"Timeout", appErrors.New(appErrors.ErrTimeout, "slow"), codes.DeadlineExceeded, "slow"},
+		{"Invalid Status", appErrors.New(appErrors.ErrInvalidStatus, "closed"), codes.FailedPrecondition, "closed"},
+		{"Aborted", appErrors.New(appErrors.ErrAborted, "rolled back"), codes.Aborted, "rolled back"},
+		{"Internal", appErrors.NewInternalError(errors.New("disk full")), codes.Internal, "Internal server error"},
+		{"Wrapped AppError", errors.Join(errors.New("context"), appErrors.NewInvalidInput("bad")), codes.InvalidArgument, "bad"},
+		{"Repository Not Found", repository.ErrNotFound, codes.NotFound, repository.ErrNotFound.Error()},
+		{"Duplicate", repository.ErrDuplicate, codes.AlreadyExists, repository.ErrDuplicate.Error()},
+		{"Reference", repository.ErrReference, codes.FailedPrecondition, repository.ErrReference.Error()},
+		{"Invalid Value", repository.ErrInvalidValue, codes.InvalidArgument, repository.ErrInvalidValue.Error()},
+		{"Duplicate Hides Constraint", fmt.Errorf("%w: %s", repository.ErrDuplicate, `duplicate key value violates unique constraint "customer_email_key"`),
+			codes.AlreadyExists, repository.ErrDuplicate.Error()},
+		{"Reference Hides Constraint", fmt.Errorf("%w: %s", repository.ErrReference, `insert or update on table "car" violates foreign key constraint "car_supplier_id_fkey"`),
+			codes.FailedPrecondition, repository.ErrReference.Error()},
+		{"Deadline", context.DeadlineExceeded, codes.DeadlineExceeded, context.DeadlineExceeded.Error()},
+		{"Status", status.Error(codes.Unavailable, "down"), codes.Unavailable, "down"},
+		{"Unknown", errors.New("pq: connection refused"), codes.Internal, "Internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+		})
+	}
+	assert.NoError(t, toStatus(nil))
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"Name":       "name",
+		"SupplierID": "supplier_id",
+		"CarID":      "car_id",
+		"MinPrice":   "min_price",
+		"IDToken":    "id_token",
+	} {
+		assert.Equal(t, want, snakeCase(in), in)
+	}
+}
+
+func TestGateway(t *testing.T) {
+	conn, m := newTestConn(t)
+	gateway, err := NewGateway(context.Background(), conn)
+	require.NoError(t, err)
+
+	t.Run("Get", func(t *testing.T) {
+		m.cars.EXPECT().GetCar("car1").Return(&model.Car{ID: "car1", Name: "Camry", SupplierID: "supp1", Price: 25000}, nil)
+
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/cars/car1", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var car map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &car))
+		assert.Equal(t, "car1", car["id"])
+		assert.Equal(t, "supp1", car["supplier_id"])
+		assert.Equal(t, "25000", car["price"]) // int64 is a string in proto JSON
+	})
+
+	t.Run("List With Query", func(t *testing.T) {
+		m.customerCars.EXPECT().GetAllCustomerCars(model.CustomerCarFilter{CustomerID: "cust1"}).
+			Return([]*model.CustomerCar{{ID: "cc1", CustomerID: "cust1"}}, nil)
+
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/customer-cars?customer_id=cust1", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"cc1"`)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		m.cars.EXPECT().DeleteCar("missing").Return(repository.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/cars/missing", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Car with ID 'missing' not found")
+	})
+}
+
+func TestHandler_SharesPort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	suppliers := usecasemock.NewMockSupplierUsecase(ctrl)
+	suppliers.EXPECT().WithTenant(model.DefaultTenantID).Return(suppliers).AnyTimes()
+	server := NewServer(usecasemock.NewMockCustomerUsecase(ctrl), suppliers,
+		usecasemock.NewMockCarUsecase(ctrl), usecasemock.NewMockCustomerCarUsecase(ctrl), Options{})
+	t.Cleanup(server.Stop)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	gateway, err := NewGateway(context.Background(), conn)
+	require.NoError(t, err)
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{Handler: Handler(server, gateway), Protocols: protocols}
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Close() })
+
+	suppliers.EXPECT().GetSupplier("supp1").Return(&model.Supplier{ID: "supp1", Name: "Acme"}, nil).Times(2)
+
+	// A gRPC call
+	supplier, err := pb.NewSupplierServiceClient(conn).GetSupplier(context.Background(), &pb.GetSupplierRequest{Id: "supp1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", supplier.GetName())
+
+	// A JSON request over HTTP/1.1 on the same port
+	resp, err := http.Get("http://" + lis.Addr().String() + "/v1/suppliers/supp1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"Acme"`)
+
+	// Reflection lists the services
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range reply.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Subset(t, services, []string{
+		"goodschain.v1.CustomerService",
+		"goodschain.v1.SupplierService",
+		"goodschain.v1.CarService",
+		"goodschain.v1.CustomerCarService",
+		"grpc.health.v1.Health",
+	})
+}
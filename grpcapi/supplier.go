@@ -0,0 +1,153 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/GoodsChain/backend/model"
+	pb "github.com/GoodsChain/backend/proto/goodschain/v1"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type supplierServer struct {
+	pb.UnimplementedSupplierServiceServer
+	suppliers usecase.SupplierUsecase
+}
+
+// scoped returns the usecase managing the suppliers of the
+// tenant of the call with ctx
+func (s *supplierServer) scoped(ctx context.Context) usecase.SupplierUsecase {
+	return s.suppliers.WithTenant(tenantOf(ctx))
+}
+
+func (s *supplierServer) CreateSupplier(ctx context.Context, req *pb.CreateSupplierRequest) (*pb.Supplier, error) {
+	supplier := supplierFromProto(req.GetSupplier())
+	if err := validate(supplier); err != nil {
+		return nil, err
+	}
+	if supplier.ID == "" {
+		supplier.ID = uuid.New().String()
+	}
+	if a := actorOf(ctx); a != "" {
+		supplier.CreatedBy, supplier.UpdatedBy = a, a
+	}
+	if err := s.scoped(ctx).CreateSupplier(supplier); err != nil {
+		return nil, err
+	}
+	return s.GetSupplier(ctx, &pb.GetSupplierRequest{Id: supplier.ID})
+}
+
+func (s *supplierServer) GetSupplier(ctx context.Context, req *pb.GetSupplierRequest) (*pb.Supplier, error) {
+	supplier, err := s.scoped(ctx).GetSupplier(req.GetId())
+	if err != nil {
+		return nil, notFound(err, "Supplier", req.GetId())
+	}
+	maskSuppliers(ctx, supplier)
+	return supplierToProto(supplier), nil
+}
+
+func (s *supplierServer) BatchGetSuppliers(ctx context.Context, req *pb.BatchGetSuppliersRequest) (*pb.BatchGetSuppliersResponse, error) {
+	suppliers, err := s.scoped(ctx).GetSuppliersByIDs(req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	maskSuppliers(ctx, suppliers...)
+	return &pb.BatchGetSuppliersResponse{Suppliers: suppliersToProto(suppliers)}, nil
+}
+
+func (s *supplierServer) ListSuppliers(ctx context.Context, req *pb.ListSuppliersRequest) (*pb.ListSuppliersResponse, error) {
+	filter := supplierFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+	suppliers, err := s.scoped(ctx).GetAllSuppliers(filter)
+	if err != nil {
+		return nil, err
+	}
+	maskSuppliers(ctx, suppliers...)
+	return &pb.ListSuppliersResponse{Suppliers: suppliersToProto(suppliers)}, nil
+}
+
+func (s *supplierServer) StreamSuppliers(req *pb.ListSuppliersRequest, stream pb.SupplierService_StreamSuppliersServer) error {
+	filter := supplierFilterFromProto(req)
+	if err := validate(filter); err != nil {
+		return err
+	}
+	return s.scoped(stream.Context()).StreamSuppliers(filter, func(supplier *model.Supplier) error {
+		maskSuppliers(stream.Context(), supplier)
+		return stream.Send(supplierToProto(supplier))
+	})
+}
+
+func (s *supplierServer) UpdateSupplier(ctx context.Context, req *pb.UpdateSupplierRequest) (*pb.Supplier, error) {
+	supplier := supplierFromProto(req.GetSupplier())
+	if err := validate(supplier); err != nil {
+		return nil, err
+	}
+	if a := actorOf(ctx); a != "" {
+		supplier.UpdatedBy = a
+	}
+	if err := s.scoped(ctx).UpdateSupplier(req.GetId(), supplier); err != nil {
+		return nil, notFound(err, "Supplier", req.GetId())
+	}
+	return s.GetSupplier(ctx, &pb.GetSupplierRequest{Id: req.GetId()})
+}
+
+func (s *supplierServer) DeleteSupplier(ctx context.Context, req *pb.DeleteSupplierRequest) (*emptypb.Empty, error) {
+	if err := s.scoped(ctx).DeleteSupplier(req.GetId()); err != nil {
+		return nil, notFound(err, "Supplier", req.GetId())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// maskSuppliers masks the emails and phones of suppliers for callers that may not
+// see them, as over HTTP
+func maskSuppliers(ctx context.Context, suppliers ...*model.Supplier) {
+	if masksContacts(ctx, "suppliers") {
+		for _, supplier := range suppliers {
+			supplier.MaskContact()
+		}
+	}
+}
+
+func supplierFromProto(c *pb.Supplier) *model.Supplier {
+	return &model.Supplier{
+		ID:      c.GetId(),
+		Name:    c.GetName(),
+		Address: c.GetAddress(),
+		Phone:   c.GetPhone(),
+		Email:   c.GetEmail(),
+	}
+}
+
+func supplierToProto(c *model.Supplier) *pb.Supplier {
+	return &pb.Supplier{
+		Id:        c.ID,
+		Name:      c.Name,
+		Address:   c.Address,
+		Phone:     c.Phone,
+		Email:     c.Email,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		UpdatedBy: c.UpdatedBy,
+	}
+}
+
+func suppliersToProto(suppliers []*model.Supplier) []*pb.Supplier {
+	out := make([]*pb.Supplier, len(suppliers))
+	for i, supplier := range suppliers {
+		out[i] = supplierToProto(supplier)
+	}
+	return out
+}
+
+func supplierFilterFromProto(req *pb.ListSuppliersRequest) model.SupplierFilter {
+	return model.SupplierFilter{
+		Name:         req.GetName(),
+		Email:        req.GetEmail(),
+		CreatedRange: createdRangeFromProto(req.GetCreated()),
+	}
+}
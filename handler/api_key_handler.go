@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles HTTP requests for API keys
+type APIKeyHandler struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(uc usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUsecase: uc}
+}
+
+// CreateAPIKey godoc
+// @Summary Issue an API key
+// @Description Issues a key for a machine client, sent as "Authorization: ApiKey <key>". Scopes are admin, read, write, or "<group>:read" and "<group>:write" for the customers, suppliers, cars, customer-cars, imports, webhooks, events, search, graphql and catalog groups. The key is only returned in this response. Requires the admin scope.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param api_key body model.APIKeyRequest true "Name, scopes and optional expiry"
+// @Success 201 {object} model.APIKey "Successfully issued key, including the key itself"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 401 {object} model.Problem "Missing or invalid credentials"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req model.APIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+	req.Actor = actor(c)
+
+	key, err := h.apiKeyUsecase.WithTenant(tenantID(c)).IssueAPIKey(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, key)
+}
+
+// GetAllAPIKeys godoc
+// @Summary Get all API keys
+// @Description Retrieves all API keys, revoked ones included. Keys are not included. Requires the admin scope.
+// @Tags API Keys
+// @Produce json
+// @Success 200 {array} model.APIKey "Successfully retrieved keys"
+// @Failure 401 {object} model.Problem "Missing or invalid credentials"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAllAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyUsecase.WithTenant(tenantID(c)).GetAllAPIKeys()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// GetAPIKey godoc
+// @Summary Get an API key by ID
+// @Description Retrieves an API key with its scopes, expiry and last use. The key is not included. Requires the admin scope.
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} model.APIKey "Successfully retrieved key"
+// @Failure 401 {object} model.Problem "Missing or invalid credentials"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 404 {object} model.Problem "API key not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	key, err := h.apiKeyUsecase.WithTenant(tenantID(c)).GetAPIKey(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate an API key
+// @Description Replaces the key, keeping the ID, name, scopes and expiry. The previous key stops working at once; the new one is only returned in this response. Requires the admin scope.
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} model.APIKey "Successfully rotated key, including the new key"
+// @Failure 401 {object} model.Problem "Missing or invalid credentials"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 404 {object} model.Problem "API key not found"
+// @Failure 409 {object} model.Problem "API key is revoked"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	key, err := h.apiKeyUsecase.WithTenant(tenantID(c)).RotateAPIKey(c.Param("id"), actor(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revokes a key for good. It stays listed with its revocation time. Requires the admin scope.
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} model.SuccessResponse "API key revoked successfully"
+// @Failure 401 {object} model.Problem "Missing or invalid credentials"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 404 {object} model.Problem "API key not found"
+// @Failure 409 {object} model.Problem "API key is already revoked"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	if err := h.apiKeyUsecase.WithTenant(tenantID(c)).RevokeAPIKey(c.Param("id"), actor(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "API key revoked successfully"})
+}
+
+func (h *APIKeyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondNotFound(c, "API key")
+	case errors.Is(err, usecase.ErrUnknownScope), errors.Is(err, usecase.ErrAPIKeyExpiry):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	case errors.Is(err, usecase.ErrAPIKeyRevoked):
+		respondProblem(c, http.StatusConflict, appErrors.ErrConflict, err.Error())
+	default:
+		respondInternal(c, err)
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupAPIKeyRouter(t *testing.T) (*gin.Engine, *usecasemock.MockAPIKeyUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockAPIKeyUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	apiKeyHandler := NewAPIKeyHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Requests come from an admin key
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalAPIKey, ID: "admin", Actor: "api_key:admin",
+			Scopes: []string{model.ScopeAdmin}})
+	})
+	router.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+	router.GET("/api-keys/:id", apiKeyHandler.GetAPIKey)
+	router.POST("/api-keys/:id/rotate", apiKeyHandler.RotateAPIKey)
+	router.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+	return router, mockUsecase
+}
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	router, mockUsecase := setupAPIKeyRouter(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func()
+		wantStatus int
+	}{
+		{
+			name: "Success",
+			body: `{"name":"erp","scopes":["cars:read"]}`,
+			setup: func() {
+				mockUsecase.EXPECT().IssueAPIKey(gomock.Any()).DoAndReturn(func(req *model.APIKeyRequest) (*model.APIKey, error) {
+					assert.Equal(t, "api_key:admin", req.Actor)
+					return &model.APIKey{ID: "key-1", Name: req.Name, Key: "gc_0a1b2c3d_secret"}, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{name: "MissingScopes", body: `{"name":"erp"}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "UnknownScope",
+			body: `{"name":"erp","scopes":["trucks:read"]}`,
+			setup: func() {
+				mockUsecase.EXPECT().IssueAPIKey(gomock.Any()).Return(nil, fmt.Errorf("%w '%s'", usecase.ErrUnknownScope, "trucks:read"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			req, _ := http.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAPIKeyHandler_GetAPIKey(t *testing.T) {
+	router, mockUsecase := setupAPIKeyRouter(t)
+
+	mockUsecase.EXPECT().GetAPIKey("key-1").Return(&model.APIKey{ID: "key-1", KeyHash: "hash"}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api-keys/key-1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// Neither the key nor its hash is ever listed
+	assert.NotContains(t, w.Body.String(), "hash")
+	assert.NotContains(t, w.Body.String(), `"key"`)
+
+	mockUsecase.EXPECT().GetAPIKey("missing").Return(nil, repository.ErrNotFound)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api-keys/missing", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "API key not found")
+}
+
+func TestAPIKeyHandler_RotateAPIKey(t *testing.T) {
+	router, mockUsecase := setupAPIKeyRouter(t)
+
+	mockUsecase.EXPECT().RotateAPIKey("key-1", "api_key:admin").Return(&model.APIKey{ID: "key-1", Key: "gc_0a1b2c3d_new"}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api-keys/key-1/rotate", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var key model.APIKey
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &key))
+	assert.Equal(t, "gc_0a1b2c3d_new", key.Key)
+
+	mockUsecase.EXPECT().RotateAPIKey("key-2", "api_key:admin").Return(nil, usecase.ErrAPIKeyRevoked)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api-keys/key-2/rotate", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	router, mockUsecase := setupAPIKeyRouter(t)
+
+	mockUsecase.EXPECT().RevokeAPIKey("key-1", "api_key:admin").Return(nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api-keys/key-1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockUsecase.EXPECT().RevokeAPIKey("key-1", "api_key:admin").Return(usecase.ErrAPIKeyRevoked)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/api-keys/key-1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextPrincipal is the context key of the *model.Principal of an
+// authenticated request
+const ContextPrincipal = "principal"
+
+// adminGroups are the route groups only principals with the admin scope can use
+var adminGroups = map[string]bool{"api-keys": true, "privacy": true}
+
+// Authenticator authenticates requests by their Authorization header and
+// authorizes them by the scopes of their principal
+type Authenticator struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+	authUsecase   usecase.AuthUsecase
+	required      bool
+}
+
+// NewAuthenticator creates a new Authenticator. Unless required, requests
+// without credentials are served anonymously, outside the admin groups.
+func NewAuthenticator(apiKeyUsecase usecase.APIKeyUsecase, authUsecase usecase.AuthUsecase, required bool) *Authenticator {
+	return &Authenticator{apiKeyUsecase: apiKeyUsecase, authUsecase: authUsecase, required: required}
+}
+
+// Authenticate returns the middleware resolving the principal of requests
+// sent with "Authorization: Bearer <access token>" or "Authorization: ApiKey
+// <key>". Invalid credentials are rejected even where anonymous requests are
+// allowed. A nil Authenticator does nothing.
+func (a *Authenticator) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil {
+			return
+		}
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			return
+		}
+
+		scheme, credentials, _ := strings.Cut(header, " ")
+		credentials = strings.TrimSpace(credentials)
+		var principal *model.Principal
+		err := usecase.ErrInvalidToken
+		switch {
+		case strings.EqualFold(scheme, "Bearer"):
+			principal, err = a.authUsecase.Authenticate(credentials)
+		case strings.EqualFold(scheme, "ApiKey"):
+			principal, err = a.apiKeyUsecase.Authenticate(credentials)
+		}
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken), errors.Is(err, usecase.ErrInvalidAPIKey):
+			respondUnauthorized(c, appErrors.NewLocalized(appErrors.ErrUnauthorized, i18n.M("error.invalid_credentials")))
+			return
+		case err != nil:
+			respondInternal(c, err)
+			c.Abort()
+			return
+		}
+		c.Set(ContextPrincipal, principal)
+		c.Set(ContextClientID, principal.ClientID())
+	}
+}
+
+// Authorize returns the middleware requiring the scope of group for the
+// method of the request: "<group>:read" for reads and "<group>:write" for
+// changes, or admin for the admin groups. Anonymous requests are only
+// allowed outside the admin groups, unless credentials are required. A nil
+// Authenticator does nothing.
+func (a *Authenticator) Authorize(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil {
+			return
+		}
+		scope := requiredScope(group, c.Request.Method)
+		p := principal(c)
+		if p == nil {
+			if a.required || adminGroups[group] {
+				respondUnauthorized(c, appErrors.NewUnauthorized(""))
+			}
+			return
+		}
+		if !p.HasScope(scope) {
+			respondError(c, http.StatusForbidden, appErrors.NewLocalized(appErrors.ErrForbidden, i18n.M("error.missing_scope", "scope", scope)))
+			c.Abort()
+		}
+	}
+}
+
+// RequireUser returns the middleware only letting signed-in users through,
+// for the operations on their own account. A nil Authenticator does nothing.
+func (a *Authenticator) RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil {
+			return
+		}
+		switch p := principal(c); {
+		case p == nil:
+			respondUnauthorized(c, appErrors.NewUnauthorized(""))
+		case p.Type != model.PrincipalUser:
+			respondError(c, http.StatusForbidden, appErrors.NewLocalized(appErrors.ErrForbidden, i18n.M("error.user_required")))
+			c.Abort()
+		}
+	}
+}
+
+// requiredScope returns the scope a request with method to group needs
+func requiredScope(group, method string) string {
+	switch {
+	case adminGroups[group]:
+		return model.ScopeAdmin
+	case group == "graphql", method == http.MethodGet, method == http.MethodHead, method == http.MethodOptions:
+		// GraphQL only has queries
+		return group + ":" + model.ScopeRead
+	default:
+		return group + ":" + model.ScopeWrite
+	}
+}
+
+// respondUnauthorized writes the 401 response of appErr, naming the accepted schemes
+func respondUnauthorized(c *gin.Context, appErr *appErrors.AppError) {
+	c.Header("WWW-Authenticate", "Bearer, ApiKey")
+	respondError(c, http.StatusUnauthorized, appErr)
+	c.Abort()
+}
+
+// principal returns the principal of the request in c, or nil when it is anonymous
+func principal(c *gin.Context) *model.Principal {
+	if p, ok := c.Get(ContextPrincipal); ok {
+		return p.(*model.Principal)
+	}
+	return nil
+}
+
+// actor returns the actor recorded in created_by and updated_by for the
+// request in c, or "" for anonymous requests, which the usecases record as system
+func actor(c *gin.Context) string {
+	if p := principal(c); p != nil {
+		return p.Actor
+	}
+	return ""
+}
+
+// tenantID returns the tenant whose records the request in c can access. It is
+// the tenant of the principal; anonymous requests access the default tenant.
+func tenantID(c *gin.Context) string {
+	if p := principal(c); p != nil && p.TenantID != "" {
+		return p.TenantID
+	}
+	return model.DefaultTenantID
+}
+
+// masksContacts reports whether the emails and phones of the records of group
+// must be masked in the responses to the request in c: its principal may not
+// write group, like the users of the viewer role, or it is anonymous.
+func masksContacts(c *gin.Context, group string) bool {
+	return model.MasksContacts(principal(c), group)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/GoodsChain/backend/auth"
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles HTTP requests for password logins and tokens
+type AuthHandler struct {
+	authUsecase usecase.AuthUsecase
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(uc usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{authUsecase: uc}
+}
+
+// Login godoc
+// @Summary Sign in with a password
+// @Description Starts a session and issues a short-lived access token, sent as "Authorization: Bearer <token>", and a single-use refresh token. Repeated failed logins lock the account for a while.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body model.LoginRequest true "Username and password"
+// @Success 200 {object} model.TokenResponse "Tokens of the new session"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 401 {object} model.Problem "Invalid username or password; locked accounts answer alike"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req model.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+
+	tokens, err := h.authUsecase.Login(req.Username, req.Password)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary Refresh the tokens of a session
+// @Description Exchanges a refresh token for a new access token and a new refresh token. Each refresh token works once; presenting a used one signs its session out.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param refresh body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.TokenResponse "New tokens of the session"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 401 {object} model.Problem "Invalid, expired, used or revoked refresh token"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+
+	tokens, err := h.authUsecase.Refresh(req.RefreshToken)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Sign out
+// @Description Revokes the session of the access token, or with "all" every session of the user. Their access and refresh tokens stop working at once.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param logout body model.LogoutRequest false "Sessions to sign out of"
+// @Success 200 {object} model.SuccessResponse "Signed out successfully"
+// @Failure 401 {object} model.Problem "Missing or invalid access token"
+// @Failure 403 {object} model.Problem "Not a signed-in user"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req model.LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err, &req)
+			return
+		}
+	}
+
+	if err := h.authUsecase.Logout(principal(c), req.All); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Signed out successfully"})
+}
+
+// ChangePassword godoc
+// @Summary Change the password of the signed-in user
+// @Description Replaces the password after checking the current one, and signs out of every session; sign in again with the new password.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param password body model.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} model.SuccessResponse "Password changed successfully"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 401 {object} model.Problem "Missing or invalid access token"
+// @Failure 403 {object} model.Problem "Wrong current password, or not a signed-in user"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /auth/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req model.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+
+	if err := h.authUsecase.ChangePassword(principal(c), req.CurrentPassword, req.NewPassword); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Password changed successfully"})
+}
+
+func (h *AuthHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidLogin):
+		respondError(c, http.StatusUnauthorized, appErrors.NewLocalized(appErrors.ErrUnauthorized, i18n.M("error.invalid_login")))
+	case errors.Is(err, usecase.ErrInvalidToken):
+		respondError(c, http.StatusUnauthorized, appErrors.NewLocalized(appErrors.ErrUnauthorized, i18n.M("error.invalid_credentials")))
+	case errors.Is(err, usecase.ErrWrongPassword):
+		respondError(c, http.StatusForbidden, appErrors.NewLocalized(appErrors.ErrForbidden, i18n.M("error.wrong_password")))
+	case errors.Is(err, auth.ErrPasswordTooShort):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	default:
+		respondInternal(c, err)
+	}
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testViewer = &model.Principal{Type: model.PrincipalUser, ID: "user-1", Actor: "jdoe", Scopes: []string{model.ScopeRead}, SessionID: "session-1"}
+
+func setupAuthRouter(t *testing.T) (*gin.Engine, *usecasemock.MockAuthUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockAuthUsecase(ctrl)
+	authHandler := NewAuthHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	signedIn := func(c *gin.Context) { c.Set(ContextPrincipal, testViewer) }
+	router.POST("/auth/login", authHandler.Login)
+	router.POST("/auth/refresh", authHandler.Refresh)
+	router.POST("/auth/logout", signedIn, authHandler.Logout)
+	router.POST("/auth/password", signedIn, authHandler.ChangePassword)
+	return router, mockUsecase
+}
+
+func postJSON(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	router, mockUsecase := setupAuthRouter(t)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().Login("jdoe", "correct horse battery").
+			Return(&model.TokenResponse{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 900, RefreshToken: "refresh"}, nil)
+
+		w := postJSON(router, "/auth/login", `{"username":"jdoe","password":"correct horse battery"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var tokens model.TokenResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+		assert.Equal(t, "refresh", tokens.RefreshToken)
+	})
+
+	t.Run("InvalidLogin", func(t *testing.T) {
+		mockUsecase.EXPECT().Login("jdoe", "wrong").Return(nil, usecase.ErrInvalidLogin)
+
+		w := postJSON(router, "/auth/login", `{"username":"jdoe","password":"wrong"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid username or password")
+	})
+
+	t.Run("LockedAnswersAsInvalidLogin", func(t *testing.T) {
+		mockUsecase.EXPECT().Login("jdoe", "wrong").
+			Return(nil, &usecase.AccountLockedError{Until: time.Now().Add(90 * time.Second)})
+
+		w := postJSON(router, "/auth/login", `{"username":"jdoe","password":"wrong"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+		var problem model.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "error.invalid_login", problem.DetailKey)
+	})
+
+	t.Run("MissingPassword", func(t *testing.T) {
+		w := postJSON(router, "/auth/login", `{"username":"jdoe"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	router, mockUsecase := setupAuthRouter(t)
+
+	mockUsecase.EXPECT().Refresh("used").Return(nil, usecase.ErrInvalidToken)
+	w := postJSON(router, "/auth/refresh", `{"refresh_token":"used"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	router, mockUsecase := setupAuthRouter(t)
+
+	mockUsecase.EXPECT().Logout(testViewer, false).Return(nil)
+	req, _ := http.NewRequest(http.MethodPost, "/auth/logout", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockUsecase.EXPECT().Logout(testViewer, true).Return(nil)
+	w = postJSON(router, "/auth/logout", `{"all":true}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	router, mockUsecase := setupAuthRouter(t)
+
+	mockUsecase.EXPECT().ChangePassword(testViewer, "correct horse battery", "a new long passphrase").Return(nil)
+	w := postJSON(router, "/auth/password", `{"current_password":"correct horse battery","new_password":"a new long passphrase"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockUsecase.EXPECT().ChangePassword(testViewer, "wrong", "a new long passphrase").Return(usecase.ErrWrongPassword)
+	w = postJSON(router, "/auth/password", `{"current_password":"wrong","new_password":"a new long passphrase"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = postJSON(router, "/auth/password", `{"current_password":"correct horse battery","new_password":"short"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// authMocks are the usecases behind an Authenticator
+type authMocks struct {
+	apiKeys *usecasemock.MockAPIKeyUsecase
+	tokens  *usecasemock.MockAuthUsecase
+}
+
+func newAuthRouter(t *testing.T, required bool) (*gin.Engine, authMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := authMocks{apiKeys: usecasemock.NewMockAPIKeyUsecase(ctrl), tokens: usecasemock.NewMockAuthUsecase(ctrl)}
+	authenticator := NewAuthenticator(mocks.apiKeys, mocks.tokens, required)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authenticator.Authenticate())
+	whoami := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"actor": actor(c), "client": c.GetString(ContextClientID)})
+	}
+	router.GET("/cars", authenticator.Authorize("cars"), whoami)
+	router.POST("/cars", authenticator.Authorize("cars"), whoami)
+	router.POST("/graphql", authenticator.Authorize("graphql"), whoami)
+	router.GET("/api-keys", authenticator.Authorize("api-keys"), whoami)
+	router.POST("/auth/login", whoami)
+	router.POST("/auth/logout", authenticator.RequireUser(), whoami)
+	return router, mocks
+}
+
+func TestAuthenticator(t *testing.T) {
+	reader := &model.Principal{Type: model.PrincipalAPIKey, ID: "key-1", Actor: "api_key:key-1", Scopes: []string{"cars:read", "graphql:read"}}
+	writer := &model.Principal{Type: model.PrincipalAPIKey, ID: "key-2", Actor: "api_key:key-2", Scopes: []string{model.ScopeWrite}}
+	viewer := &model.Principal{Type: model.PrincipalUser, ID: "user-1", Actor: "jdoe", Scopes: []string{model.ScopeRead}, SessionID: "session-1"}
+
+	tests := []struct {
+		name       string
+		required   bool
+		method     string
+		path       string
+		header     string
+		setup      func(m authMocks)
+		wantStatus int
+		wantCode   string
+		wantActor  string
+		wantClient string
+	}{
+		{name: "Anonymous", method: http.MethodPost, path: "/cars", wantStatus: http.StatusOK},
+		{name: "AnonymousRequired", required: true, method: http.MethodGet, path: "/cars", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "AnonymousLoginRequired", required: true, method: http.MethodPost, path: "/auth/login", wantStatus: http.StatusOK},
+		{name: "AnonymousLogout", method: http.MethodPost, path: "/auth/logout", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "AnonymousAdmin", method: http.MethodGet, path: "/api-keys", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "UnsupportedScheme", method: http.MethodGet, path: "/cars", header: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{
+			name: "InvalidKey", method: http.MethodGet, path: "/cars", header: "ApiKey gc_bad",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_bad").Return(nil, usecase.ErrInvalidAPIKey)
+			},
+			wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED",
+		},
+		{
+			name: "StoreError", method: http.MethodGet, path: "/cars", header: "ApiKey gc_key",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_key").Return(nil, errors.New("database error"))
+			},
+			wantStatus: http.StatusInternalServerError, wantCode: "INTERNAL_ERROR",
+		},
+		{
+			name: "Read", method: http.MethodGet, path: "/cars", header: "apikey gc_reader",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_reader").Return(reader, nil)
+			},
+			wantStatus: http.StatusOK, wantActor: "api_key:key-1", wantClient: "api_key:key-1",
+		},
+		{
+			name: "GraphQLQueryIsRead", method: http.MethodPost, path: "/graphql", header: "ApiKey gc_reader",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_reader").Return(reader, nil)
+			},
+			wantStatus: http.StatusOK, wantActor: "api_key:key-1", wantClient: "api_key:key-1",
+		},
+		{
+			name: "MissingWriteScope", method: http.MethodPost, path: "/cars", header: "ApiKey gc_reader",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_reader").Return(reader, nil)
+			},
+			wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN",
+		},
+		{
+			name: "Write", method: http.MethodPost, path: "/cars", header: "ApiKey gc_writer",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_writer").Return(writer, nil)
+			},
+			wantStatus: http.StatusOK, wantActor: "api_key:key-2", wantClient: "api_key:key-2",
+		},
+		{
+			name: "BearerRead", method: http.MethodGet, path: "/cars", header: "Bearer access-token",
+			setup: func(m authMocks) {
+				m.tokens.EXPECT().Authenticate("access-token").Return(viewer, nil)
+			},
+			wantStatus: http.StatusOK, wantActor: "jdoe", wantClient: "user:user-1",
+		},
+		{
+			name: "BearerMissingWriteScope", method: http.MethodPost, path: "/cars", header: "Bearer access-token",
+			setup: func(m authMocks) {
+				m.tokens.EXPECT().Authenticate("access-token").Return(viewer, nil)
+			},
+			wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN",
+		},
+		{
+			name: "ExpiredToken", method: http.MethodGet, path: "/cars", header: "Bearer expired",
+			setup: func(m authMocks) {
+				m.tokens.EXPECT().Authenticate("expired").Return(nil, usecase.ErrInvalidToken)
+			},
+			wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED",
+		},
+		{
+			name: "UserLogout", method: http.MethodPost, path: "/auth/logout", header: "Bearer access-token",
+			setup: func(m authMocks) {
+				m.tokens.EXPECT().Authenticate("access-token").Return(viewer, nil)
+			},
+			wantStatus: http.StatusOK, wantActor: "jdoe", wantClient: "user:user-1",
+		},
+		{
+			name: "APIKeyLogout", method: http.MethodPost, path: "/auth/logout", header: "ApiKey gc_writer",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_writer").Return(writer, nil)
+			},
+			wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN",
+		},
+		{
+			name: "MissingAdminScope", method: http.MethodGet, path: "/api-keys", header: "ApiKey gc_writer",
+			setup: func(m authMocks) {
+				m.apiKeys.EXPECT().Authenticate("gc_writer").Return(writer, nil)
+			},
+			wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mocks := newAuthRouter(t, tt.required)
+			if tt.setup != nil {
+				tt.setup(mocks)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantCode != "" {
+				var problem model.Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.Equal(t, tt.wantCode, problem.Code)
+			}
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer, ApiKey", w.Header().Get("WWW-Authenticate"))
+			}
+			if tt.wantActor != "" {
+				var body map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, tt.wantActor, body["actor"])
+				// Rate limits apply per principal
+				assert.Equal(t, tt.wantClient, body["client"])
+			}
+		})
+	}
+}
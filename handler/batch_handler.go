@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/GoodsChain/backend/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// BatchHandler handles HTTP requests for bulk create, update and delete
+type BatchHandler struct {
+	batchUsecase usecase.BatchUsecase
+}
+
+// NewBatchHandler creates a new BatchHandler
+func NewBatchHandler(uc usecase.BatchUsecase) *BatchHandler {
+	return &BatchHandler{batchUsecase: uc}
+}
+
+// BatchCustomers godoc
+// @Summary Create, update and delete customers in bulk
+// @Description Applies a list of operations in order. Consecutive operations of the same kind are written with one statement. In atomic mode (the default) nothing is applied unless every operation succeeds; in best_effort mode the successful operations are committed. Every operation gets a result with its status and, on failure, an error code.
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Param batch body model.BatchRequest true "Operations; data is a customer object as for POST /customers"
+// @Success 200 {object} model.BatchResult "All operations applied"
+// @Success 207 {object} model.BatchResult "Some operations failed (best_effort)"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 422 {object} model.BatchResult "An operation failed and the batch was rolled back (atomic)"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customers/batch [post]
+func (h *BatchHandler) BatchCustomers(c *gin.Context) {
+	h.handleBatch(c, "customers")
+}
+
+// BatchSuppliers godoc
+// @Summary Create, update and delete suppliers in bulk
+// @Description Applies a list of operations in order. In atomic mode (the default) nothing is applied unless every operation succeeds; in best_effort mode the successful operations are committed.
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Param batch body model.BatchRequest true "Operations; data is a supplier object as for POST /suppliers"
+// @Success 200 {object} model.BatchResult "All operations applied"
+// @Success 207 {object} model.BatchResult "Some operations failed (best_effort)"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 422 {object} model.BatchResult "An operation failed and the batch was rolled back (atomic)"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /suppliers/batch [post]
+func (h *BatchHandler) BatchSuppliers(c *gin.Context) {
+	h.handleBatch(c, "suppliers")
+}
+
+// BatchCars godoc
+// @Summary Create, update and delete cars in bulk
+// @Description Applies a list of operations in order. In atomic mode (the default) nothing is applied unless every operation succeeds; in best_effort mode the successful operations are committed.
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Param batch body model.BatchRequest true "Operations; data is a car object as for POST /cars"
+// @Success 200 {object} model.BatchResult "All operations applied"
+// @Success 207 {object} model.BatchResult "Some operations failed (best_effort)"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 422 {object} model.BatchResult "An operation failed and the batch was rolled back (atomic)"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/batch [post]
+func (h *BatchHandler) BatchCars(c *gin.Context) {
+	h.handleBatch(c, "cars")
+}
+
+// BatchCustomerCars godoc
+// @Summary Create, update and delete customer-car relationships in bulk
+// @Description Applies a list of operations in order. In atomic mode (the default) nothing is applied unless every operation succeeds; in best_effort mode the successful operations are committed.
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Param batch body model.BatchRequest true "Operations; data is a customer-car object as for POST /customer-cars"
+// @Success 200 {object} model.BatchResult "All operations applied"
+// @Success 207 {object} model.BatchResult "Some operations failed (best_effort)"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 422 {object} model.BatchResult "An operation failed and the batch was rolled back (atomic)"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customer-cars/batch [post]
+func (h *BatchHandler) BatchCustomerCars(c *gin.Context) {
+	h.handleBatch(c, "customer-cars")
+}
+
+func (h *BatchHandler) handleBatch(c *gin.Context, resource string) {
+	var req model.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+	req.Actor = actor(c)
+
+	result, err := h.batchUsecase.WithTenant(tenantID(c)).Execute(resource, &req)
+	if result != nil {
+		for i := range result.Results {
+			if len(result.Results[i].Errors) > 0 {
+				result.Results[i].Errors = validation.Localize(result.Results[i].Errors, locale(c))
+			}
+		}
+	}
+	switch {
+	case errors.Is(err, usecase.ErrBatchTooLarge):
+		respondProblem(c, http.StatusRequestEntityTooLarge, appErrors.ErrInvalid, err.Error())
+	case errors.Is(err, usecase.ErrUnknownBatchResource):
+		respondProblem(c, http.StatusNotFound, appErrors.ErrNotFound, err.Error())
+	case err != nil:
+		respondInternal(c, err)
+	case result.Failed == 0:
+		c.JSON(http.StatusOK, result)
+	case result.Mode == model.BatchModeAtomic:
+		c.JSON(http.StatusUnprocessableEntity, result)
+	default:
+		c.JSON(http.StatusMultiStatus, result)
+	}
+}
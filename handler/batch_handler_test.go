@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupBatchRouter(t *testing.T) (*gin.Engine, *usecasemock.MockBatchUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockBatchUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	batchHandler := NewBatchHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/cars/batch", batchHandler.BatchCars)
+	return router, mockUsecase
+}
+
+func TestBatchHandler_BatchCars(t *testing.T) {
+	router, mockUsecase := setupBatchRouter(t)
+	body := `{"mode":"best_effort","operations":[{"op":"delete","id":"car1"},{"op":"delete","id":"car2"}]}`
+
+	tests := []struct {
+		name       string
+		body       string
+		result     *model.BatchResult
+		err        error
+		wantStatus int
+	}{
+		{"AllApplied", body, &model.BatchResult{Mode: model.BatchModeBestEffort, Succeeded: 2}, nil, http.StatusOK},
+		{"PartialFailure", body, &model.BatchResult{Mode: model.BatchModeBestEffort, Succeeded: 1, Failed: 1}, nil, http.StatusMultiStatus},
+		{"AtomicRolledBack", body, &model.BatchResult{Mode: model.BatchModeAtomic, Failed: 2}, nil, http.StatusUnprocessableEntity},
+		{"TooLarge", body, nil, usecase.ErrBatchTooLarge, http.StatusRequestEntityTooLarge},
+		{"InternalError", body, nil, errors.New("db error"), http.StatusInternalServerError},
+		{"EmptyOperations", `{"operations":[]}`, nil, nil, http.StatusBadRequest},
+		{"UnknownOp", `{"operations":[{"op":"upsert"}]}`, nil, nil, http.StatusBadRequest},
+		{"InvalidMode", `{"mode":"all","operations":[{"op":"delete","id":"car1"}]}`, nil, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != nil || tt.err != nil {
+				mockUsecase.EXPECT().Execute("cars", gomock.Any()).DoAndReturn(func(resource string, req *model.BatchRequest) (*model.BatchResult, error) {
+					assert.Equal(t, model.BatchModeBestEffort, req.Mode)
+					assert.Len(t, req.Operations, 2)
+					return tt.result, tt.err
+				})
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "/cars/batch", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.result != nil {
+				var got model.BatchResult
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, tt.result.Failed, got.Failed)
+			}
+		})
+	}
+}
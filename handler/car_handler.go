@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	// "errors" // For checking specific error types from usecase if needed
+	"strings"
 
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
 	"github.com/GoodsChain/backend/model"
 	"github.com/GoodsChain/backend/usecase"
 	"github.com/GoodsChain/backend/repository" // For repository.ErrNotFound if bubbled up
@@ -13,12 +16,13 @@ import (
 
 // CarHandler handles HTTP requests for cars
 type CarHandler struct {
-	carUsecase usecase.CarUsecase
+	carUsecase    usecase.CarUsecase
+	expandUsecase usecase.ExpandUsecase
 }
 
 // NewCarHandler creates a new CarHandler
-func NewCarHandler(uc usecase.CarUsecase) *CarHandler {
-	return &CarHandler{carUsecase: uc}
+func NewCarHandler(uc usecase.CarUsecase, expandUsecase usecase.ExpandUsecase) *CarHandler {
+	return &CarHandler{carUsecase: uc, expandUsecase: expandUsecase}
 }
 
 // CreateCar godoc
@@ -29,25 +33,30 @@ func NewCarHandler(uc usecase.CarUsecase) *CarHandler {
 // @Produce json
 // @Param car body model.Car true "Car object to be created. ID, CreatedAt, CreatedBy, UpdatedAt, UpdatedBy are ignored."
 // @Success 201 {object} model.Car "Successfully created car"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload, trim or specs"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /cars [post]
 func (h *CarHandler) CreateCar(c *gin.Context) {
 	var car model.Car
 	if err := c.ShouldBindJSON(&car); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &car)
 		return
 	}
 
-	// ID, CreatedAt, CreatedBy, UpdatedAt, UpdatedBy are handled by usecase/repository
-	// Potentially set CreatedBy/UpdatedBy from authenticated user context here
-	// car.CreatedBy = "user_from_context" 
-	// car.UpdatedBy = "user_from_context"
+	// ID, CreatedAt, UpdatedAt are handled by usecase/repository; the audit
+	// fields name the authenticated principal, if any
+	if a := actor(c); a != "" {
+		car.CreatedBy, car.UpdatedBy = a, a
+	}
 
-	if err := h.carUsecase.CreateCar(&car); err != nil {
+	if err := h.carUsecase.WithTenant(tenantID(c)).CreateCar(&car); err != nil {
+		if errors.Is(err, usecase.ErrInvalidSpecs) {
+			respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+			return
+		}
 		// TODO: Differentiate between error types from usecase if necessary
 		// e.g., if err == usecase.ErrSupplierNotFound (if validating supplier ID)
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, car)
@@ -59,22 +68,42 @@ func (h *CarHandler) CreateCar(c *gin.Context) {
 // @Tags Cars
 // @Produce json
 // @Param id path string true "Car ID" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8"
-// @Success 200 {object} model.Car "Successfully retrieved car"
-// @Failure 404 {object} model.ErrorResponse "Car not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Param expand query string false "Comma-separated relations to embed: supplier, customers, and nested paths such as customers.cars"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {object} model.ExpandedCar "Successfully retrieved car"
+// @Failure 400 {object} model.Problem "Invalid expand or fields"
+// @Failure 404 {object} model.Problem "Car not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /cars/{id} [get]
 func (h *CarHandler) GetCar(c *gin.Context) {
 	id := c.Param("id")
-	car, err := h.carUsecase.GetCar(id)
+	expand, ok := parseExpand(c, h.expandUsecase, model.ResourceCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.Car{})
+	if !ok {
+		return
+	}
+	car, err := h.carUsecase.WithTenant(tenantID(c)).GetCar(id)
 	if err != nil {
 		if err == repository.ErrNotFound { // Assuming usecase bubbles up repository.ErrNotFound
-			c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Car not found"})
+			respondNotFound(c, "Car")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, car)
+	if expand != nil {
+		expanded, err := expandFor(c, h.expandUsecase).ExpandCars([]*model.Car{car}, expand)
+		if err != nil {
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+			return
+		}
+		respondFields(c, expanded[0], fields, expand)
+		return
+	}
+	respondFields(c, car, fields, nil)
 }
 
 // GetAllCars godoc
@@ -82,16 +111,150 @@ func (h *CarHandler) GetCar(c *gin.Context) {
 // @Description Retrieves a list of all cars in the system.
 // @Tags Cars
 // @Produce json
-// @Success 200 {array} model.Car "Successfully retrieved list of cars"
-// @Failure 500 {object} model.ErrorResponse "Failed to retrieve cars"
+// @Param q query string false "Full-text search of the name, best matches first"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param supplier_id query string false "Only cars from this supplier"
+// @Param min_price query int false "Minimum price, inclusive"
+// @Param max_price query int false "Maximum price, inclusive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Param brand_id query string false "Only cars of trims of this brand"
+// @Param model_id query string false "Only cars of trims of this model"
+// @Param trim_id query string false "Only cars of this trim"
+// @Param fuel query string false "Specification filter: <attribute>=<value>[,<value>...] matches any of the values, such as fuel=hybrid,electric"
+// @Param seats_gte query int false "Specification range filter: <attribute>_gte and <attribute>_lte bound number attributes, such as seats_gte=7"
+// @Param expand query string false "Comma-separated relations to embed: supplier, customers, and nested paths such as customers.cars"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {array} model.ExpandedCar "Successfully retrieved list of cars"
+// @Failure 400 {object} model.Problem "Invalid filter, expand or fields"
+// @Failure 500 {object} model.Problem "Failed to retrieve cars"
 // @Router /cars [get]
 func (h *CarHandler) GetAllCars(c *gin.Context) {
-	cars, err := h.carUsecase.GetAllCars()
+	var filter model.CarFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	if !parseSpecFilters(c, &filter) {
+		return
+	}
+	expand, ok := parseExpand(c, h.expandUsecase, model.ResourceCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.Car{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields.With(expand.Keys(model.ResourceCar)...)
+	cars, err := h.carUsecase.WithTenant(tenantID(c)).GetAllCars(filter)
+	if err != nil {
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_cars"))
+		return
+	}
+	if expand != nil {
+		pointers := make([]*model.Car, len(cars))
+		for i := range cars {
+			pointers[i] = &cars[i]
+		}
+		expanded, err := expandFor(c, h.expandUsecase).ExpandCars(pointers, expand)
+		if err != nil {
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+			return
+		}
+		respondFields(c, expanded, fields, expand)
+		return
+	}
+	respondFields(c, cars, fields, nil)
+}
+
+// ExportCars godoc
+// @Summary Export cars
+// @Description Streams all cars matching the list filters as a CSV, NDJSON or XLSX download.
+// @Tags Cars
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format: csv (default), ndjson or xlsx"
+// @Param fields query string false "Comma-separated columns to export, in order; defaults to all"
+// @Param q query string false "Full-text search of the name, best matches first"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param supplier_id query string false "Only cars from this supplier"
+// @Param min_price query int false "Minimum price, inclusive"
+// @Param max_price query int false "Maximum price, inclusive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Param brand_id query string false "Only cars of trims of this brand"
+// @Param model_id query string false "Only cars of trims of this model"
+// @Param trim_id query string false "Only cars of this trim"
+// @Param fuel query string false "Specification filter: <attribute>=<value>[,<value>...] matches any of the values, such as fuel=hybrid,electric"
+// @Param seats_gte query int false "Specification range filter: <attribute>_gte and <attribute>_lte bound number attributes, such as seats_gte=7"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} model.Problem "Invalid filter, format or fields"
+// @Failure 500 {object} model.Problem "Failed to export cars"
+// @Router /cars/export [get]
+func (h *CarHandler) ExportCars(c *gin.Context) {
+	var filter model.CarFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	if !parseSpecFilters(c, &filter) {
+		return
+	}
+	fields, ok := parseFields(c, model.Car{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields
+	streamExport(c, "cars", model.Car{}, fields, func(write func(record interface{}) error) error {
+		return h.carUsecase.WithTenant(tenantID(c)).StreamCars(filter, func(car *model.Car) error {
+			return write(car)
+		})
+	})
+}
+
+// CompareCars godoc
+// @Summary Compare cars
+// @Description Lists 2 to 5 cars side by side with their brand, model and trim, and one row per specification attribute any of them has, flagging the rows whose values differ.
+// @Tags Cars
+// @Produce json
+// @Param ids query string true "Comma-separated IDs of the cars to compare"
+// @Success 200 {object} model.CarComparison "Side by side comparison"
+// @Failure 400 {object} model.Problem "Too few or too many cars"
+// @Failure 404 {object} model.Problem "Car not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/compare [get]
+func (h *CarHandler) CompareCars(c *gin.Context) {
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	comparison, err := h.carUsecase.WithTenant(tenantID(c)).CompareCars(ids)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to retrieve cars"})
+		switch {
+		case errors.Is(err, usecase.ErrComparedCars):
+			respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		case errors.Is(err, repository.ErrNotFound):
+			respondProblem(c, http.StatusNotFound, appErrors.ErrNotFound, err.Error())
+		default:
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.compare_cars"))
+		}
 		return
 	}
-	c.JSON(http.StatusOK, cars)
+	c.JSON(http.StatusOK, comparison)
+}
+
+// parseSpecFilters adds the specification filters of the query to filter,
+// responding with a 400 and returning false when one is invalid
+func parseSpecFilters(c *gin.Context, filter *model.CarFilter) bool {
+	specs, err := model.ParseSpecFilters(c.Request.URL.Query())
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return false
+	}
+	filter.Specs = specs
+	return true
 }
 
 // UpdateCar godoc
@@ -103,27 +266,32 @@ func (h *CarHandler) GetAllCars(c *gin.Context) {
 // @Param id path string true "Car ID" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8"
 // @Param car body model.Car true "Car object with updated details. ID, CreatedAt, CreatedBy, UpdatedAt are ignored."
 // @Success 200 {object} model.SuccessResponse "Car updated successfully"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 404 {object} model.ErrorResponse "Car not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload, trim or specs"
+// @Failure 404 {object} model.Problem "Car not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /cars/{id} [put]
 func (h *CarHandler) UpdateCar(c *gin.Context) {
 	id := c.Param("id")
 	var car model.Car
 	if err := c.ShouldBindJSON(&car); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &car)
 		return
 	}
 
-	// Potentially set UpdatedBy from authenticated user context here
-	// car.UpdatedBy = "user_from_context"
+	if a := actor(c); a != "" {
+		car.UpdatedBy = a
+	}
 
-	if err := h.carUsecase.UpdateCar(id, &car); err != nil {
+	if err := h.carUsecase.WithTenant(tenantID(c)).UpdateCar(id, &car); err != nil {
 		if err == repository.ErrNotFound { // Assuming usecase bubbles up repository.ErrNotFound
-			c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Car not found"})
+			respondNotFound(c, "Car")
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidSpecs) {
+			respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Car updated successfully"})
@@ -136,17 +304,17 @@ func (h *CarHandler) UpdateCar(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Car ID" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8"
 // @Success 200 {object} model.SuccessResponse "Car deleted successfully"
-// @Failure 404 {object} model.ErrorResponse "Car not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 404 {object} model.Problem "Car not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /cars/{id} [delete]
 func (h *CarHandler) DeleteCar(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.carUsecase.DeleteCar(id); err != nil {
+	if err := h.carUsecase.WithTenant(tenantID(c)).DeleteCar(id); err != nil {
 		if err == repository.ErrNotFound { // Assuming usecase bubbles up repository.ErrNotFound
-			c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Car not found"})
+			respondNotFound(c, "Car")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Car deleted successfully"})
@@ -4,23 +4,26 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
-	"github.com/GoodsChain/backend/mock" // Assuming mock package is at this path
+	"github.com/GoodsChain/backend/mock/usecasemock"
 	"github.com/GoodsChain/backend/model"
 	"github.com/GoodsChain/backend/repository" // For repository.ErrNotFound
+	"github.com/GoodsChain/backend/usecase"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/mock/gomock"                 // Corrected import path
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
-func setupCarRouter(t *testing.T) (*gin.Engine, *mock.MockCarUsecase) {
+func setupCarRouter(t *testing.T) (*gin.Engine, *usecasemock.MockCarUsecase) {
 	ctrl := gomock.NewController(t)
-	mockUsecase := mock.NewMockCarUsecase(ctrl)
-	carHandler := NewCarHandler(mockUsecase)
+	mockUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	carHandler := NewCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -30,6 +33,7 @@ func setupCarRouter(t *testing.T) (*gin.Engine, *mock.MockCarUsecase) {
 	carRoutes := router.Group("/cars")
 	{
 		carRoutes.POST("/", carHandler.CreateCar)
+		carRoutes.GET("/compare", carHandler.CompareCars)
 		carRoutes.GET("/:id", carHandler.GetCar)
 		carRoutes.GET("/", carHandler.GetAllCars)
 		carRoutes.PUT("/:id", carHandler.UpdateCar)
@@ -82,8 +86,8 @@ func TestCarHandler_CreateCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code", "Code key missing for BadRequest")
-		assert.Contains(t, errResp, "message", "Message key missing for BadRequest")
-		assert.NotEmpty(t, errResp["message"], "Error message should not be empty for BadRequest")
+		assert.Contains(t, errResp, "detail", "Detail key missing for BadRequest")
+		assert.NotEmpty(t, errResp["detail"], "Error message should not be empty for BadRequest")
 	})
 
 	t.Run("UsecaseError", func(t *testing.T) {
@@ -103,9 +107,9 @@ func TestCarHandler_CreateCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "internal_error", errResp["code"])
-		assert.Equal(t, "usecase create error", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "INTERNAL_ERROR", errResp["code"])
+		assert.Equal(t, "Internal server error", errResp["detail"])
 	})
 }
 
@@ -140,9 +144,9 @@ func TestCarHandler_GetCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "not_found", errResp["code"])
-		assert.Equal(t, "Car not found", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "NOT_FOUND", errResp["code"])
+		assert.Equal(t, "Car not found", errResp["detail"])
 	})
 
 	t.Run("UsecaseError", func(t *testing.T) {
@@ -157,8 +161,8 @@ func TestCarHandler_GetCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "internal_error", errResp["code"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "INTERNAL_ERROR", errResp["code"])
 	})
 }
 
@@ -170,7 +174,7 @@ func TestCarHandler_GetAllCars(t *testing.T) {
 			{ID: uuid.New().String(), Name: "Car A"},
 			{ID: uuid.New().String(), Name: "Car B"},
 		}
-		mockUsecase.EXPECT().GetAllCars().Return(expectedCars, nil).Times(1)
+		mockUsecase.EXPECT().GetAllCars(model.CarFilter{}).Return(expectedCars, nil).Times(1)
 
 		req, _ := http.NewRequest(http.MethodGet, "/cars/", nil)
 		rr := httptest.NewRecorder()
@@ -184,7 +188,7 @@ func TestCarHandler_GetAllCars(t *testing.T) {
 	})
 
 	t.Run("SuccessEmpty", func(t *testing.T) {
-		mockUsecase.EXPECT().GetAllCars().Return([]model.Car{}, nil).Times(1)
+		mockUsecase.EXPECT().GetAllCars(model.CarFilter{}).Return([]model.Car{}, nil).Times(1)
 		req, _ := http.NewRequest(http.MethodGet, "/cars/", nil)
 		rr := httptest.NewRecorder()
 		router.ServeHTTP(rr, req)
@@ -196,7 +200,7 @@ func TestCarHandler_GetAllCars(t *testing.T) {
 	})
 	
 	t.Run("UsecaseError", func(t *testing.T) {
-		mockUsecase.EXPECT().GetAllCars().Return(nil, errors.New("failed to fetch")).Times(1)
+		mockUsecase.EXPECT().GetAllCars(model.CarFilter{}).Return(nil, errors.New("failed to fetch")).Times(1)
 		req, _ := http.NewRequest(http.MethodGet, "/cars/", nil)
 		rr := httptest.NewRecorder()
 		router.ServeHTTP(rr, req)
@@ -207,9 +211,9 @@ func TestCarHandler_GetAllCars(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "internal_error", errResp["code"])
-		assert.Equal(t, "Failed to retrieve cars", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "INTERNAL_ERROR", errResp["code"])
+		assert.Equal(t, "Failed to retrieve cars", errResp["detail"])
 	})
 }
 
@@ -247,8 +251,8 @@ func TestCarHandler_UpdateCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code", "Code key missing for BadRequest")
-		assert.Contains(t, errResp, "message", "Message key missing for BadRequest")
-		assert.NotEmpty(t, errResp["message"], "Error message should not be empty for BadRequest")
+		assert.Contains(t, errResp, "detail", "Detail key missing for BadRequest")
+		assert.NotEmpty(t, errResp["detail"], "Error message should not be empty for BadRequest")
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
@@ -265,9 +269,9 @@ func TestCarHandler_UpdateCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "not_found", errResp["code"])
-		assert.Equal(t, "Car not found", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "NOT_FOUND", errResp["code"])
+		assert.Equal(t, "Car not found", errResp["detail"])
 	})
 	
 	t.Run("UsecaseError", func(t *testing.T) {
@@ -284,9 +288,9 @@ func TestCarHandler_UpdateCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "internal_error", errResp["code"])
-		assert.Equal(t, "update failed badly", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "INTERNAL_ERROR", errResp["code"])
+		assert.Equal(t, "Internal server error", errResp["detail"])
 	})
 }
 
@@ -318,9 +322,9 @@ func TestCarHandler_DeleteCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "not_found", errResp["code"])
-		assert.Equal(t, "Car not found", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "NOT_FOUND", errResp["code"])
+		assert.Equal(t, "Car not found", errResp["detail"])
 	})
 
 	t.Run("UsecaseError", func(t *testing.T) {
@@ -335,8 +339,105 @@ func TestCarHandler_DeleteCar(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &errResp)
 		assert.NoError(t, err)
 		assert.Contains(t, errResp, "code")
-		assert.Contains(t, errResp, "message")
-		assert.Equal(t, "internal_error", errResp["code"])
-		assert.Equal(t, "delete failed badly", errResp["message"])
+		assert.Contains(t, errResp, "detail")
+		assert.Equal(t, "INTERNAL_ERROR", errResp["code"])
+		assert.Equal(t, "Internal server error", errResp["detail"])
+	})
+}
+
+func TestCarHandler_TenantScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	tenantUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	carHandler := NewCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/cars/:id", func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalUser, ID: "user-1", TenantID: "tenant1"})
+	}, carHandler.GetCar)
+
+	// Requests only reach the cars of the tenant of their principal
+	mockUsecase.EXPECT().WithTenant("tenant1").Return(tenantUsecase)
+	tenantUsecase.EXPECT().GetCar("car1").Return(nil, repository.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cars/car1", nil)
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCarHandler_Specs(t *testing.T) {
+	router, mockUsecase := setupCarRouter(t)
+
+	t.Run("Filters", func(t *testing.T) {
+		mockUsecase.EXPECT().GetAllCars(model.CarFilter{TrimID: "trim1", Specs: []model.SpecFilter{
+			{Attribute: "fuel", Op: model.SpecEq, Values: []interface{}{"hybrid", "electric"}},
+			{Attribute: "seats", Op: model.SpecGte, Values: []interface{}{float64(7)}},
+		}}).Return([]model.Car{}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/cars/?trim_id=trim1&fuel=hybrid,electric&seats_gte=7", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/cars/?fuel=steam", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "fuel: must be one of")
+	})
+
+	t.Run("InvalidSpecs", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateCar(gomock.Any()).Return(fmt.Errorf("%w: seats must be a whole number", usecase.ErrInvalidSpecs))
+
+		req, _ := http.NewRequest(http.MethodPost, "/cars/",
+			bytes.NewBufferString(`{"name":"Sienna","supplier_id":"supp1","price":40000,"trim_id":"trim1","specs":{"seats":7.5}}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "seats must be a whole number")
+	})
+}
+
+func TestCarHandler_CompareCars(t *testing.T) {
+	router, mockUsecase := setupCarRouter(t)
+	compare := func(url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().CompareCars([]string{"car1", "car2"}).Return(&model.CarComparison{
+			Cars: []model.ComparedCar{{Car: &model.Car{ID: "car1"}, Brand: "Toyota"}, {Car: &model.Car{ID: "car2"}}},
+			Specs: []model.SpecComparison{
+				{Attribute: "seats", Values: []interface{}{float64(7), float64(8)}, Differs: true},
+			},
+		}, nil)
+
+		rr := compare("/cars/compare?ids=car1,%20car2,")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"brand":"Toyota"`)
+		assert.Contains(t, rr.Body.String(), `{"attribute":"seats","values":[7,8],"differs":true}`)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		mockUsecase.EXPECT().CompareCars([]string{"car1"}).Return(nil, usecase.ErrComparedCars)
+		assert.Equal(t, http.StatusBadRequest, compare("/cars/compare?ids=car1").Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().CompareCars([]string{"car1", "car9"}).Return(nil, fmt.Errorf("%w: car car9", repository.ErrNotFound))
+		assert.Equal(t, http.StatusNotFound, compare("/cars/compare?ids=car1,car9").Code)
+	})
+
+	t.Run("UsecaseError", func(t *testing.T) {
+		mockUsecase.EXPECT().CompareCars([]string{"car1", "car2"}).Return(nil, errors.New("db down"))
+		assert.Equal(t, http.StatusInternalServerError, compare("/cars/compare?ids=car1,car2").Code)
 	})
 }
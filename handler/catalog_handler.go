@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler handles HTTP requests for the brands, models, trims and
+// specification schemas of the car catalog
+type CatalogHandler struct {
+	catalogUsecase usecase.CatalogUsecase
+}
+
+// NewCatalogHandler creates a new CatalogHandler
+func NewCatalogHandler(uc usecase.CatalogUsecase) *CatalogHandler {
+	return &CatalogHandler{catalogUsecase: uc}
+}
+
+// CreateBrand godoc
+// @Summary Create a brand
+// @Description Adds a brand to the catalog. ID is auto-generated by the backend.
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Param brand body model.Brand true "Brand to be created"
+// @Success 201 {object} model.Brand "Successfully created brand"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 409 {object} model.Problem "A brand of that name exists"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/brands [post]
+func (h *CatalogHandler) CreateBrand(c *gin.Context) {
+	var brand model.Brand
+	if err := c.ShouldBindJSON(&brand); err != nil {
+		respondBindError(c, err, &brand)
+		return
+	}
+	brand.CreatedBy, brand.UpdatedBy = actor(c), actor(c)
+
+	if err := h.catalogUsecase.WithTenant(tenantID(c)).CreateBrand(&brand); err != nil {
+		h.handleError(c, err, "")
+		return
+	}
+	c.JSON(http.StatusCreated, brand)
+}
+
+// GetBrands godoc
+// @Summary Get all brands
+// @Description Retrieves the brands of the catalog by name.
+// @Tags Catalog
+// @Produce json
+// @Success 200 {array} model.Brand "Successfully retrieved brands"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/brands [get]
+func (h *CatalogHandler) GetBrands(c *gin.Context) {
+	brands, err := h.catalogUsecase.WithTenant(tenantID(c)).GetBrands()
+	if err != nil {
+		h.handleError(c, err, "")
+		return
+	}
+	c.JSON(http.StatusOK, brands)
+}
+
+// CreateModel godoc
+// @Summary Create a model of a brand
+// @Description Adds a model to a brand. Its category selects the specification schema of the cars of its trims.
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param model body model.CarModel true "Model to be created; brand_id is taken from the path"
+// @Success 201 {object} model.CarModel "Successfully created model"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 404 {object} model.Problem "Brand not found"
+// @Failure 409 {object} model.Problem "The brand has a model of that name"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/brands/{id}/models [post]
+func (h *CatalogHandler) CreateModel(c *gin.Context) {
+	var carModel model.CarModel
+	if err := c.ShouldBindJSON(&carModel); err != nil {
+		respondBindError(c, err, &carModel)
+		return
+	}
+	carModel.BrandID = c.Param("id")
+	carModel.CreatedBy, carModel.UpdatedBy = actor(c), actor(c)
+
+	if err := h.catalogUsecase.WithTenant(tenantID(c)).CreateModel(&carModel); err != nil {
+		h.handleError(c, err, "Brand")
+		return
+	}
+	c.JSON(http.StatusCreated, carModel)
+}
+
+// GetModels godoc
+// @Summary Get the models of a brand
+// @Description Retrieves the models of a brand by name; a brand without models, or that does not exist, has none.
+// @Tags Catalog
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Success 200 {array} model.CarModel "Successfully retrieved models"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/brands/{id}/models [get]
+func (h *CatalogHandler) GetModels(c *gin.Context) {
+	models, err := h.catalogUsecase.WithTenant(tenantID(c)).GetModels(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "")
+		return
+	}
+	c.JSON(http.StatusOK, models)
+}
+
+// CreateTrim godoc
+// @Summary Create a trim of a model
+// @Description Adds a trim to a model. Cars name their trim with trim_id.
+// @Tags Catalog
+// @Accept json
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param trim body model.Trim true "Trim to be created; model_id is taken from the path"
+// @Success 201 {object} model.Trim "Successfully created trim"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 404 {object} model.Problem "Model not found"
+// @Failure 409 {object} model.Problem "The model has a trim of that name"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/models/{id}/trims [post]
+func (h *CatalogHandler) CreateTrim(c *gin.Context) {
+	var trim model.Trim
+	if err := c.ShouldBindJSON(&trim); err != nil {
+		respondBindError(c, err, &trim)
+		return
+	}
+	trim.ModelID = c.Param("id")
+	trim.CreatedBy, trim.UpdatedBy = actor(c), actor(c)
+
+	if err := h.catalogUsecase.WithTenant(tenantID(c)).CreateTrim(&trim); err != nil {
+		h.handleError(c, err, "Model")
+		return
+	}
+	c.JSON(http.StatusCreated, trim)
+}
+
+// GetTrims godoc
+// @Summary Get the trims of a model
+// @Description Retrieves the trims of a model by name; a model without trims, or that does not exist, has none.
+// @Tags Catalog
+// @Produce json
+// @Param id path string true "Model ID"
+// @Success 200 {array} model.Trim "Successfully retrieved trims"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /catalog/models/{id}/trims [get]
+func (h *CatalogHandler) GetTrims(c *gin.Context) {
+	trims, err := h.catalogUsecase.WithTenant(tenantID(c)).GetTrims(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "")
+		return
+	}
+	c.JSON(http.StatusOK, trims)
+}
+
+// GetSchemas godoc
+// @Summary Get the specification schemas
+// @Description Lists the specification attributes the cars of each category can have, with their type, unit, allowed values and whether they are required.
+// @Tags Catalog
+// @Produce json
+// @Success 200 {array} model.SpecSchema "Schemas by category"
+// @Router /catalog/schemas [get]
+func (h *CatalogHandler) GetSchemas(c *gin.Context) {
+	c.JSON(http.StatusOK, model.SpecSchemas)
+}
+
+// handleError responds to err; parent names the resource that a missing or
+// malformed reference is reported as
+func (h *CatalogHandler) handleError(c *gin.Context, err error, parent string) {
+	switch {
+	case parent != "" && (errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrInvalidValue)):
+		respondNotFound(c, parent)
+	case errors.Is(err, repository.ErrDuplicate):
+		respondProblem(c, http.StatusConflict, appErrors.ErrConflict, err.Error())
+	case errors.Is(err, repository.ErrInvalidValue):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	default:
+		respondInternal(c, err)
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupCatalogRouter(t *testing.T) (*gin.Engine, *usecasemock.MockCatalogUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockCatalogUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	catalogHandler := NewCatalogHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalUser, ID: "u1", Actor: "admin", Scopes: []string{"catalog:write"}})
+	})
+	router.GET("/catalog/schemas", catalogHandler.GetSchemas)
+	router.POST("/catalog/brands", catalogHandler.CreateBrand)
+	router.GET("/catalog/brands", catalogHandler.GetBrands)
+	router.POST("/catalog/brands/:id/models", catalogHandler.CreateModel)
+	router.GET("/catalog/brands/:id/models", catalogHandler.GetModels)
+	router.POST("/catalog/models/:id/trims", catalogHandler.CreateTrim)
+	router.GET("/catalog/models/:id/trims", catalogHandler.GetTrims)
+	return router, mockUsecase
+}
+
+func serveCatalog(router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCatalogHandler_Brands(t *testing.T) {
+	router, mockUsecase := setupCatalogRouter(t)
+
+	t.Run("Create", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateBrand(&model.Brand{Name: "Toyota", CreatedBy: "admin", UpdatedBy: "admin"}).
+			DoAndReturn(func(brand *model.Brand) error {
+				brand.ID = "brand1"
+				return nil
+			})
+		w := serveCatalog(router, http.MethodPost, "/catalog/brands", `{"name":"Toyota"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"brand1"`)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateBrand(gomock.Any()).Return(repository.ErrDuplicate)
+		assert.Equal(t, http.StatusConflict, serveCatalog(router, http.MethodPost, "/catalog/brands", `{"name":"Toyota"}`).Code)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serveCatalog(router, http.MethodPost, "/catalog/brands", `{}`).Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		mockUsecase.EXPECT().GetBrands().Return([]model.Brand{{ID: "brand1", Name: "Toyota"}}, nil)
+		w := serveCatalog(router, http.MethodGet, "/catalog/brands", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"Toyota"`)
+	})
+
+	t.Run("ListError", func(t *testing.T) {
+		mockUsecase.EXPECT().GetBrands().Return(nil, errors.New("db down"))
+		assert.Equal(t, http.StatusInternalServerError, serveCatalog(router, http.MethodGet, "/catalog/brands", "").Code)
+	})
+}
+
+func TestCatalogHandler_Models(t *testing.T) {
+	router, mockUsecase := setupCatalogRouter(t)
+
+	t.Run("Create", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateModel(&model.CarModel{BrandID: "brand1", Name: "Sienna", Category: model.CategoryVan,
+			CreatedBy: "admin", UpdatedBy: "admin"}).Return(nil)
+		w := serveCatalog(router, http.MethodPost, "/catalog/brands/brand1/models", `{"name":"Sienna","category":"van"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"brand_id":"brand1"`)
+	})
+
+	t.Run("UnknownCategory", func(t *testing.T) {
+		w := serveCatalog(router, http.MethodPost, "/catalog/brands/brand1/models", `{"name":"Sienna","category":"bus"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("BrandNotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateModel(gomock.Any()).Return(repository.ErrNotFound)
+		w := serveCatalog(router, http.MethodPost, "/catalog/brands/missing/models", `{"name":"Sienna","category":"van"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Brand")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		mockUsecase.EXPECT().GetModels("brand1").Return([]model.CarModel{{ID: "model1", Name: "Sienna"}}, nil)
+		assert.Equal(t, http.StatusOK, serveCatalog(router, http.MethodGet, "/catalog/brands/brand1/models", "").Code)
+	})
+}
+
+func TestCatalogHandler_Trims(t *testing.T) {
+	router, mockUsecase := setupCatalogRouter(t)
+
+	t.Run("Create", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateTrim(&model.Trim{ModelID: "model1", Name: "XLE", CreatedBy: "admin", UpdatedBy: "admin"}).Return(nil)
+		assert.Equal(t, http.StatusCreated, serveCatalog(router, http.MethodPost, "/catalog/models/model1/trims", `{"name":"XLE"}`).Code)
+	})
+
+	t.Run("MalformedModelID", func(t *testing.T) {
+		mockUsecase.EXPECT().CreateTrim(gomock.Any()).Return(repository.ErrInvalidValue)
+		assert.Equal(t, http.StatusNotFound, serveCatalog(router, http.MethodPost, "/catalog/models/x/trims", `{"name":"XLE"}`).Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		mockUsecase.EXPECT().GetTrims("model1").Return([]model.Trim{}, nil)
+		w := serveCatalog(router, http.MethodGet, "/catalog/models/model1/trims", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[]", w.Body.String())
+	})
+}
+
+func TestCatalogHandler_GetSchemas(t *testing.T) {
+	router, _ := setupCatalogRouter(t)
+
+	w := serveCatalog(router, http.MethodGet, "/catalog/schemas", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"category":"pickup"`)
+	assert.Contains(t, w.Body.String(), `{"name":"payload_kg","type":"number","unit":"kg","max":10000,"required":true}`)
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"net/http"
 
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
 	"github.com/GoodsChain/backend/model"
 	"github.com/GoodsChain/backend/usecase"
 	"github.com/gin-gonic/gin"
@@ -11,12 +13,14 @@ import (
 // CustomerCarHandler manages HTTP requests related to customer-car relationships
 type CustomerCarHandler struct {
 	CustomerCarUsecase usecase.CustomerCarUsecase
+	ExpandUsecase      usecase.ExpandUsecase
 }
 
 // NewCustomerCarHandler creates a new instance of CustomerCarHandler
-func NewCustomerCarHandler(customerCarUsecase usecase.CustomerCarUsecase) *CustomerCarHandler {
+func NewCustomerCarHandler(customerCarUsecase usecase.CustomerCarUsecase, expandUsecase usecase.ExpandUsecase) *CustomerCarHandler {
 	return &CustomerCarHandler{
 		CustomerCarUsecase: customerCarUsecase,
+		ExpandUsecase:      expandUsecase,
 	}
 }
 
@@ -28,18 +32,21 @@ func NewCustomerCarHandler(customerCarUsecase usecase.CustomerCarUsecase) *Custo
 // @Produce json
 // @Param customerCar body model.CustomerCar true "Customer car data"
 // @Success 201 {object} model.Response
-// @Failure 400 {object} model.Response
-// @Failure 500 {object} model.Response
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/customer-cars [post]
 func (h *CustomerCarHandler) Create(c *gin.Context) {
 	var customerCar model.CustomerCar
 	if err := c.ShouldBindJSON(&customerCar); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &customerCar)
 		return
 	}
+	if a := actor(c); a != "" {
+		customerCar.CreatedBy, customerCar.UpdatedBy = a, a
+	}
 
-	if err := h.CustomerCarUsecase.CreateCustomerCar(&customerCar); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.CustomerCarUsecase.WithTenant(tenantID(c)).CreateCustomerCar(&customerCar); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
@@ -53,20 +60,40 @@ func (h *CustomerCarHandler) Create(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Customer Car ID"
-// @Success 200 {object} model.Response
-// @Failure 404 {object} model.Response
-// @Failure 500 {object} model.Response
+// @Param expand query string false "Comma-separated relations to embed: car, car.supplier, customer, and other nested paths"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {object} model.ExpandedCustomerCar
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/customer-cars/{id} [get]
 func (h *CustomerCarHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
+	expand, ok := parseExpand(c, h.ExpandUsecase, model.ResourceCustomerCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.CustomerCar{})
+	if !ok {
+		return
+	}
 
-	customerCar, err := h.CustomerCarUsecase.GetCustomerCar(id)
+	customerCar, err := h.CustomerCarUsecase.WithTenant(tenantID(c)).GetCustomerCar(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Customer car relationship not found"})
+		respondNotFound(c, "Customer car relationship")
+		return
+	}
+	if expand != nil {
+		expanded, err := expandFor(c, h.ExpandUsecase).ExpandCustomerCars([]*model.CustomerCar{customerCar}, expand)
+		if err != nil {
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+			return
+		}
+		respondFields(c, expanded[0], fields, expand)
 		return
 	}
 
-	c.JSON(http.StatusOK, customerCar)
+	respondFields(c, customerCar, fields, nil)
 }
 
 // GetAll godoc
@@ -75,17 +102,71 @@ func (h *CustomerCarHandler) GetByID(c *gin.Context) {
 // @Tags customer-cars
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.Response
-// @Failure 500 {object} model.Response
+// @Param customer_id query string false "Only relationships of this customer"
+// @Param car_id query string false "Only relationships of this car"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Param expand query string false "Comma-separated relations to embed: car, car.supplier, customer, and other nested paths"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {array} model.ExpandedCustomerCar
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/customer-cars [get]
 func (h *CustomerCarHandler) GetAll(c *gin.Context) {
-	customerCars, err := h.CustomerCarUsecase.GetAllCustomerCars()
+	var filter model.CustomerCarFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	expand, ok := parseExpand(c, h.ExpandUsecase, model.ResourceCustomerCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.CustomerCar{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields.With(expand.Keys(model.ResourceCustomerCar)...)
+	customerCars, err := h.CustomerCarUsecase.WithTenant(tenantID(c)).GetAllCustomerCars(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to get customer car relationships"})
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_customer_cars"))
 		return
 	}
 
-	c.JSON(http.StatusOK, customerCars)
+	h.respond(c, customerCars, fields, expand)
+}
+
+// Export godoc
+// @Summary Export customer car relationships
+// @Description Streams all customer car relationships matching the list filters as a CSV, NDJSON or XLSX download
+// @Tags customer-cars
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format: csv (default), ndjson or xlsx"
+// @Param fields query string false "Comma-separated columns to export, in order; defaults to all"
+// @Param customer_id query string false "Only relationships of this customer"
+// @Param car_id query string false "Only relationships of this car"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Router /api/customer-cars/export [get]
+func (h *CustomerCarHandler) Export(c *gin.Context) {
+	var filter model.CustomerCarFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	fields, ok := parseFields(c, model.CustomerCar{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields
+	streamExport(c, "customer-cars", model.CustomerCar{}, fields, func(write func(record interface{}) error) error {
+		return h.CustomerCarUsecase.WithTenant(tenantID(c)).StreamCustomerCars(filter, func(customerCar *model.CustomerCar) error {
+			return write(customerCar)
+		})
+	})
 }
 
 // GetByCustomerID godoc
@@ -94,20 +175,31 @@ func (h *CustomerCarHandler) GetAll(c *gin.Context) {
 // @Tags customer-cars
 // @Accept json
 // @Produce json
-// @Param customer_id path string true "Customer ID"
-// @Success 200 {object} model.Response
-// @Failure 500 {object} model.Response
-// @Router /api/customers/{customer_id}/cars [get]
+// @Param id path string true "Customer ID"
+// @Param expand query string false "Comma-separated relations to embed: car, car.supplier, customer, and other nested paths"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {array} model.ExpandedCustomerCar
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Router /api/customers/{id}/cars [get]
 func (h *CustomerCarHandler) GetByCustomerID(c *gin.Context) {
-	customerID := c.Param("customer_id")
+	customerID := c.Param("id")
+	expand, ok := parseExpand(c, h.ExpandUsecase, model.ResourceCustomerCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.CustomerCar{})
+	if !ok {
+		return
+	}
 
-	customerCars, err := h.CustomerCarUsecase.GetCustomerCarsByCustomerID(customerID)
+	customerCars, err := h.CustomerCarUsecase.WithTenant(tenantID(c)).GetCustomerCarsByCustomerID(customerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to get customer car relationships"})
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_customer_cars"))
 		return
 	}
 
-	c.JSON(http.StatusOK, customerCars)
+	h.respond(c, customerCars, fields, expand)
 }
 
 // GetByCarID godoc
@@ -116,20 +208,31 @@ func (h *CustomerCarHandler) GetByCustomerID(c *gin.Context) {
 // @Tags customer-cars
 // @Accept json
 // @Produce json
-// @Param car_id path string true "Car ID"
-// @Success 200 {object} model.Response
-// @Failure 500 {object} model.Response
-// @Router /api/cars/{car_id}/customers [get]
+// @Param id path string true "Car ID"
+// @Param expand query string false "Comma-separated relations to embed: car, car.supplier, customer, and other nested paths"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {array} model.ExpandedCustomerCar
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Router /api/cars/{id}/customers [get]
 func (h *CustomerCarHandler) GetByCarID(c *gin.Context) {
-	carID := c.Param("car_id")
+	carID := c.Param("id")
+	expand, ok := parseExpand(c, h.ExpandUsecase, model.ResourceCustomerCar)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.CustomerCar{})
+	if !ok {
+		return
+	}
 
-	customerCars, err := h.CustomerCarUsecase.GetCustomerCarsByCarID(carID)
+	customerCars, err := h.CustomerCarUsecase.WithTenant(tenantID(c)).GetCustomerCarsByCarID(carID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to get customer car relationships"})
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_customer_cars"))
 		return
 	}
 
-	c.JSON(http.StatusOK, customerCars)
+	h.respond(c, customerCars, fields, expand)
 }
 
 // Update godoc
@@ -141,21 +244,24 @@ func (h *CustomerCarHandler) GetByCarID(c *gin.Context) {
 // @Param id path string true "Customer Car ID"
 // @Param customerCar body model.CustomerCar true "Customer car data"
 // @Success 200 {object} model.Response
-// @Failure 400 {object} model.Response
-// @Failure 404 {object} model.Response
-// @Failure 500 {object} model.Response
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/customer-cars/{id} [put]
 func (h *CustomerCarHandler) Update(c *gin.Context) {
 	id := c.Param("id")
 	
 	var customerCar model.CustomerCar
 	if err := c.ShouldBindJSON(&customerCar); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &customerCar)
 		return
 	}
+	if a := actor(c); a != "" {
+		customerCar.UpdatedBy = a
+	}
 
-	if err := h.CustomerCarUsecase.UpdateCustomerCar(id, &customerCar); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.CustomerCarUsecase.WithTenant(tenantID(c)).UpdateCustomerCar(id, &customerCar); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
@@ -170,16 +276,31 @@ func (h *CustomerCarHandler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Customer Car ID"
 // @Success 200 {object} model.Response
-// @Failure 404 {object} model.Response
-// @Failure 500 {object} model.Response
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/customer-cars/{id} [delete]
 func (h *CustomerCarHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.CustomerCarUsecase.DeleteCustomerCar(id); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.CustomerCarUsecase.WithTenant(tenantID(c)).DeleteCustomerCar(id); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Customer car relationship deleted successfully"})
 }
+
+// respond writes customerCars trimmed to fields, with the relations in expand
+// embedded when the request asked for any
+func (h *CustomerCarHandler) respond(c *gin.Context, customerCars []*model.CustomerCar, fields model.Fields, expand model.Expand) {
+	if expand == nil {
+		respondFields(c, customerCars, fields, nil)
+		return
+	}
+	expanded, err := expandFor(c, h.ExpandUsecase).ExpandCustomerCars(customerCars, expand)
+	if err != nil {
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+		return
+	}
+	respondFields(c, expanded, fields, expand)
+}
@@ -8,8 +8,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	appErrors "github.com/GoodsChain/backend/errors"
 	"github.com/GoodsChain/backend/model"
-	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/mock/usecasemock"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -23,7 +24,7 @@ func TestCustomerCarCreate(t *testing.T) {
 	tests := []struct {
 		name           string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -33,7 +34,7 @@ func TestCustomerCarCreate(t *testing.T) {
 				"car_id":      "car123",
 				"customer_id": "cust123",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					CreateCustomerCar(gomock.Any()).
 					DoAndReturn(func(customerCar *model.CustomerCar) error {
@@ -47,7 +48,7 @@ func TestCustomerCarCreate(t *testing.T) {
 			reqBody: map[string]interface{}{
 				"customer_id": "cust123",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -57,7 +58,7 @@ func TestCustomerCarCreate(t *testing.T) {
 			reqBody: map[string]interface{}{
 				"car_id": "car123",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -68,15 +69,15 @@ func TestCustomerCarCreate(t *testing.T) {
 				"car_id":      "car123",
 				"customer_id": "cust123",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					CreateCustomerCar(gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -88,13 +89,14 @@ func TestCustomerCarCreate(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -123,7 +125,7 @@ func TestCustomerCarCreate(t *testing.T) {
 				// We might only check if an "error" key exists for BadRequest.
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -139,8 +141,8 @@ func TestCustomerCarCreate(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -161,14 +163,14 @@ func TestCustomerCarGetByID(t *testing.T) {
 	tests := []struct {
 		name           string
 		customerCarID  string
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:          "Success",
 			customerCarID: customerCar.ID,
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCar(customerCar.ID).
 					Return(customerCar, nil)
@@ -178,15 +180,15 @@ func TestCustomerCarGetByID(t *testing.T) {
 		{
 			name:          "Not Found",
 			customerCarID: "non-existent-id",
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCar("non-existent-id").
 					Return(nil, errors.New("not found"))
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: gin.H{
-				"code": "not_found",
-				"message": "Customer car relationship not found",
+				"code": "NOT_FOUND",
+				"detail": "Customer car relationship not found",
 			},
 		},
 	}
@@ -198,13 +200,14 @@ func TestCustomerCarGetByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -252,30 +255,30 @@ func TestCustomerCarGetAll(t *testing.T) {
 	// Test cases
 	tests := []struct {
 		name           string
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name: "Success",
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
-					GetAllCustomerCars().
+					GetAllCustomerCars(model.CustomerCarFilter{}).
 					Return(customerCars, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Usecase Error",
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
-					GetAllCustomerCars().
+					GetAllCustomerCars(model.CustomerCarFilter{}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "Failed to get customer car relationships",
+				"code": "INTERNAL_ERROR",
+				"detail": "Failed to get customer car relationships",
 			},
 		},
 	}
@@ -287,13 +290,14 @@ func TestCustomerCarGetAll(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -343,14 +347,14 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 	tests := []struct {
 		name           string
 		customerID     string
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:       "Success",
 			customerID: customerID,
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCarsByCustomerID(customerID).
 					Return(customerCars, nil)
@@ -360,15 +364,15 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 		{
 			name:       "Usecase Error",
 			customerID: customerID,
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCarsByCustomerID(customerID).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "Failed to get customer car relationships",
+				"code": "INTERNAL_ERROR",
+				"detail": "Failed to get customer car relationships",
 			},
 		},
 	}
@@ -380,17 +384,18 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
-			router.GET("/customers/:customer_id/cars", handler.GetByCustomerID)
+			router.GET("/customers/:id/cars", handler.GetByCustomerID)
 
 			// Create request
 			req, _ := http.NewRequest(http.MethodGet, "/customers/"+tt.customerID+"/cars", nil)
@@ -436,14 +441,14 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 	tests := []struct {
 		name           string
 		carID          string
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:  "Success",
 			carID: carID,
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCarsByCarID(carID).
 					Return(customerCars, nil)
@@ -453,15 +458,15 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 		{
 			name:  "Usecase Error",
 			carID: carID,
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomerCarsByCarID(carID).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "Failed to get customer car relationships",
+				"code": "INTERNAL_ERROR",
+				"detail": "Failed to get customer car relationships",
 			},
 		},
 	}
@@ -473,17 +478,18 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
-			router.GET("/cars/:car_id/customers", handler.GetByCarID)
+			router.GET("/cars/:id/customers", handler.GetByCarID)
 
 			// Create request
 			req, _ := http.NewRequest(http.MethodGet, "/cars/"+tt.carID+"/customers", nil)
@@ -523,7 +529,7 @@ func TestCustomerCarUpdate(t *testing.T) {
 		name           string
 		customerCarID  string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -534,7 +540,7 @@ func TestCustomerCarUpdate(t *testing.T) {
 				"car_id":      "car456",
 				"customer_id": "cust456",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					UpdateCustomerCar(gomock.Eq("cc123"), gomock.Any()).
 					Return(nil)
@@ -550,7 +556,7 @@ func TestCustomerCarUpdate(t *testing.T) {
 			reqBody: map[string]interface{}{
 				"customer_id": "cust456",
 			},
-			mockSetup:      func(mockUsecase *mock.MockCustomerCarUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockCustomerCarUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -559,7 +565,7 @@ func TestCustomerCarUpdate(t *testing.T) {
 			reqBody: map[string]interface{}{
 				"car_id": "car456",
 			},
-			mockSetup:      func(mockUsecase *mock.MockCustomerCarUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockCustomerCarUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -569,15 +575,15 @@ func TestCustomerCarUpdate(t *testing.T) {
 				"car_id":      "car456",
 				"customer_id": "cust456",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					UpdateCustomerCar(gomock.Eq("cc123"), gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -589,13 +595,14 @@ func TestCustomerCarUpdate(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -621,7 +628,7 @@ func TestCustomerCarUpdate(t *testing.T) {
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -634,8 +641,8 @@ func TestCustomerCarUpdate(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -649,14 +656,14 @@ func TestCustomerCarDelete(t *testing.T) {
 	tests := []struct {
 		name           string
 		customerCarID  string
-		mockSetup      func(*mock.MockCustomerCarUsecase)
+		mockSetup      func(*usecasemock.MockCustomerCarUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:          "Success",
 			customerCarID: "cc123",
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					DeleteCustomerCar(gomock.Eq("cc123")).
 					Return(nil)
@@ -669,15 +676,15 @@ func TestCustomerCarDelete(t *testing.T) {
 		{
 			name:          "Usecase Error",
 			customerCarID: "cc123",
-			mockSetup: func(mockUsecase *mock.MockCustomerCarUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerCarUsecase) {
 				mockUsecase.EXPECT().
 					DeleteCustomerCar(gomock.Eq("cc123")).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -689,13 +696,14 @@ func TestCustomerCarDelete(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerCarHandler(mockUsecase)
+			handler := NewCustomerCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -727,3 +735,65 @@ func TestCustomerCarDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomerCarGetByCustomerID_Expand(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	customerCars := []*model.CustomerCar{{ID: "cc123", CarID: "car123", CustomerID: "cust123"}}
+	expand := model.Expand{"car": model.Expand{"supplier": model.Expand{}}}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+		mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+		mockExpand := usecasemock.NewMockExpandUsecase(ctrl)
+		mockExpand.EXPECT().WithTenant(model.DefaultTenantID).Return(mockExpand).AnyTimes()
+		mockExpand.EXPECT().WithContactMask(true, true).Return(mockExpand).AnyTimes()
+		mockExpand.EXPECT().ParseExpand(model.ResourceCustomerCar, "car,car.supplier").Return(expand, nil)
+		mockUsecase.EXPECT().GetCustomerCarsByCustomerID("cust123").Return(customerCars, nil)
+		mockExpand.EXPECT().ExpandCustomerCars(customerCars, expand).Return([]*model.ExpandedCustomerCar{{
+			CustomerCar: customerCars[0],
+			Car: &model.ExpandedCar{
+				Car:      &model.Car{ID: "car123", Name: "Camry", SupplierID: "supp123"},
+				Supplier: &model.ExpandedSupplier{Supplier: &model.Supplier{ID: "supp123", Name: "Acme"}},
+			},
+		}}, nil)
+
+		router := gin.New()
+		router.GET("/customers/:id/cars", NewCustomerCarHandler(mockUsecase, mockExpand).GetByCustomerID)
+		req, _ := http.NewRequest(http.MethodGet, "/customers/cust123/cars?expand=car,car.supplier", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Len(t, body, 1)
+		assert.Equal(t, "cc123", body[0]["id"])
+		car := body[0]["car"].(map[string]interface{})
+		assert.Equal(t, "Camry", car["name"])
+		assert.Equal(t, "Acme", car["supplier"].(map[string]interface{})["name"])
+		assert.NotContains(t, body[0], "customer")
+	})
+
+	t.Run("Invalid Expand", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+		mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+		mockExpand := usecasemock.NewMockExpandUsecase(ctrl)
+		mockExpand.EXPECT().WithTenant(model.DefaultTenantID).Return(mockExpand).AnyTimes()
+		mockExpand.EXPECT().WithContactMask(true, true).Return(mockExpand).AnyTimes()
+		mockExpand.EXPECT().ParseExpand(model.ResourceCustomerCar, "owner").
+			Return(nil, appErrors.NewInvalidInput("unknown expand path 'owner'"))
+
+		router := gin.New()
+		router.GET("/customers/:id/cars", NewCustomerCarHandler(mockUsecase, mockExpand).GetByCustomerID)
+		req, _ := http.NewRequest(http.MethodGet, "/customers/cust123/cars?expand=owner", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"type":"urn:goodschain:problem:invalid-input","title":"Invalid input","status":400,`+
+			`"code":"INVALID_INPUT","detail":"unknown expand path 'owner'"}`, w.Body.String())
+	})
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	appErrors "github.com/GoodsChain/backend/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/GoodsChain/backend/usecase"
+	"github.com/GoodsChain/backend/i18n"
 	"github.com/GoodsChain/backend/model"
 	"net/http"
 	"github.com/google/uuid"
@@ -10,11 +12,13 @@ import (
 
 type CustomerHandler struct {
 	customerUsecase usecase.CustomerUsecase
+	expandUsecase   usecase.ExpandUsecase
 }
 
-func NewCustomerHandler(customerUsecase usecase.CustomerUsecase) *CustomerHandler {
+func NewCustomerHandler(customerUsecase usecase.CustomerUsecase, expandUsecase usecase.ExpandUsecase) *CustomerHandler {
 	return &CustomerHandler{
 		customerUsecase: customerUsecase,
+		expandUsecase:   expandUsecase,
 	}
 }
 
@@ -26,15 +30,15 @@ func NewCustomerHandler(customerUsecase usecase.CustomerUsecase) *CustomerHandle
 // @Produce json
 // @Param customer body model.Customer true "Customer object to be created"
 // @Success 201 {object} model.Customer "Successfully created customer"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /customers [post]
 func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 	var customer model.Customer
 	// Note: For request, ID, CreatedAt, CreatedBy, UpdatedAt, UpdatedBy are typically ignored or server-set.
 	// The model.Customer is used here for simplicity; a dedicated CreateCustomerRequest struct could be used.
 	if err := c.ShouldBindJSON(&customer); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &customer)
 		return
 	}
 
@@ -42,9 +46,12 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 	if customer.ID == "" {
 		customer.ID = uuid.New().String()
 	}
+	if a := actor(c); a != "" {
+		customer.CreatedBy, customer.UpdatedBy = a, a
+	}
 
-	if err := h.customerUsecase.CreateCustomer(&customer); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.customerUsecase.WithTenant(tenantID(c)).CreateCustomer(&customer); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
@@ -53,24 +60,47 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 
 // GetCustomer godoc
 // @Summary Get a customer by ID
-// @Description Retrieves a customer's details based on their unique ID.
+// @Description Retrieves a customer's details based on their unique ID. Callers that may only read customers, such as viewers, receive masked emails and phones.
 // @Tags Customers
 // @Produce json
 // @Param id path string true "Customer ID" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"
-// @Success 200 {object} model.Customer "Successfully retrieved customer"
-// @Failure 404 {object} model.ErrorResponse "Customer not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Param expand query string false "Comma-separated relations to embed: cars, and nested paths such as cars.supplier"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {object} model.ExpandedCustomer "Successfully retrieved customer"
+// @Failure 400 {object} model.Problem "Invalid expand or fields"
+// @Failure 404 {object} model.Problem "Customer not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /customers/{id} [get]
 func (h *CustomerHandler) GetCustomer(c *gin.Context) {
 	id := c.Param("id")
-	customer, err := h.customerUsecase.GetCustomer(id)
+	expand, ok := parseExpand(c, h.expandUsecase, model.ResourceCustomer)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.Customer{})
+	if !ok {
+		return
+	}
+	customer, err := h.customerUsecase.WithTenant(tenantID(c)).GetCustomer(id)
 	if err != nil {
 		// Assuming GetCustomer returns a specific error type that can be checked for "not found"
 		// For now, using the existing logic which might be improved in usecase layer.
-		c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Customer not found"})
+		respondNotFound(c, "Customer")
 		return
 	}
-	c.JSON(http.StatusOK, customer)
+	if masksContacts(c, "customers") {
+		customer.MaskContact()
+	}
+	if expand != nil {
+		expanded, err := expandFor(c, h.expandUsecase).ExpandCustomers([]*model.Customer{customer}, expand)
+		if err != nil {
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+			return
+		}
+		respondFields(c, expanded[0], fields, expand)
+		return
+	}
+	respondFields(c, customer, fields, nil)
 }
 
 // UpdateCustomer godoc
@@ -82,9 +112,9 @@ func (h *CustomerHandler) GetCustomer(c *gin.Context) {
 // @Param id path string true "Customer ID" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"
 // @Param customer body model.Customer true "Customer object with updated details"
 // @Success 200 {object} model.SuccessResponse "Customer updated successfully"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 404 {object} model.ErrorResponse "Customer not found (if ID in body differs or not found by usecase)"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 404 {object} model.Problem "Customer not found (if ID in body differs or not found by usecase)"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /customers/{id} [put]
 func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 	id := c.Param("id")
@@ -92,16 +122,20 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 	// Note: For request, ID, CreatedAt, CreatedBy, UpdatedAt, UpdatedBy are typically ignored or server-set.
 	// The model.Customer is used here for simplicity; a dedicated UpdateCustomerRequest struct could be used.
 	if err := c.ShouldBindJSON(&customer); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &customer)
 		return
 	}
 
+	if a := actor(c); a != "" {
+		customer.UpdatedBy = a
+	}
+
 	// It's good practice to ensure the ID in path matches ID in body if present, or usecase handles it.
 	// For now, assuming usecase uses the path `id`.
-	if err := h.customerUsecase.UpdateCustomer(id, &customer); err != nil {
+	if err := h.customerUsecase.WithTenant(tenantID(c)).UpdateCustomer(id, &customer); err != nil {
 		// This could be a not found error or other internal error.
 		// Usecase should return distinguishable errors.
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
 
@@ -115,14 +149,14 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Customer ID" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"
 // @Success 200 {object} model.SuccessResponse "Customer deleted successfully"
-// @Failure 404 {object} model.ErrorResponse "Customer not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 404 {object} model.Problem "Customer not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /customers/{id} [delete]
 func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.customerUsecase.DeleteCustomer(id); err != nil {
+	if err := h.customerUsecase.WithTenant(tenantID(c)).DeleteCustomer(id); err != nil {
 		// Usecase should return distinguishable errors for not found vs internal.
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+		respondInternal(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Customer deleted successfully"})
@@ -130,17 +164,91 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 
 // GetAllCustomers godoc
 // @Summary Get all customers
-// @Description Retrieves a list of all customers in the system.
+// @Description Retrieves a list of all customers in the system. Callers that may only read customers, such as viewers, receive masked emails and phones.
 // @Tags Customers
 // @Produce json
-// @Success 200 {array} model.Customer "Successfully retrieved list of customers"
-// @Failure 500 {object} model.ErrorResponse "Failed to retrieve customers"
+// @Param q query string false "Full-text search of the name, best matches first, or an exact email or phone"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param email query string false "Exact email address, case-insensitive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Param expand query string false "Comma-separated relations to embed: cars, and nested paths such as cars.supplier"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
+// @Success 200 {array} model.ExpandedCustomer "Successfully retrieved list of customers"
+// @Failure 400 {object} model.Problem "Invalid filter, expand or fields"
+// @Failure 500 {object} model.Problem "Failed to retrieve customers"
 // @Router /customers [get]
 func (h *CustomerHandler) GetAllCustomers(c *gin.Context) {
-	customers, err := h.customerUsecase.GetAllCustomers()
+	var filter model.CustomerFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	expand, ok := parseExpand(c, h.expandUsecase, model.ResourceCustomer)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c, model.Customer{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields.With(expand.Keys(model.ResourceCustomer)...)
+	customers, err := h.customerUsecase.WithTenant(tenantID(c)).GetAllCustomers(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to retrieve customers"})
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_customers"))
+		return
+	}
+	if masksContacts(c, "customers") {
+		for _, customer := range customers {
+			customer.MaskContact()
+		}
+	}
+	if expand != nil {
+		expanded, err := expandFor(c, h.expandUsecase).ExpandCustomers(customers, expand)
+		if err != nil {
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.expand_failed"))
+			return
+		}
+		respondFields(c, expanded, fields, expand)
+		return
+	}
+	respondFields(c, customers, fields, nil)
+}
+
+// ExportCustomers godoc
+// @Summary Export customers
+// @Description Streams all customers matching the list filters as a CSV, NDJSON or XLSX download. Callers that may only read customers, such as viewers, receive masked emails and phones.
+// @Tags Customers
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format: csv (default), ndjson or xlsx"
+// @Param fields query string false "Comma-separated columns to export, in order; defaults to all"
+// @Param q query string false "Full-text search of the name, best matches first, or an exact email or phone"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param email query string false "Exact email address, case-insensitive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} model.Problem "Invalid filter, format or fields"
+// @Failure 500 {object} model.Problem "Failed to export customers"
+// @Router /customers/export [get]
+func (h *CustomerHandler) ExportCustomers(c *gin.Context) {
+	var filter model.CustomerFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	fields, ok := parseFields(c, model.Customer{})
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, customers)
+	filter.Fields = fields
+	mask := masksContacts(c, "customers")
+	streamExport(c, "customers", model.Customer{}, fields, func(write func(record interface{}) error) error {
+		return h.customerUsecase.WithTenant(tenantID(c)).StreamCustomers(filter, func(customer *model.Customer) error {
+			if mask {
+				customer.MaskContact()
+			}
+			return write(customer)
+		})
+	})
 }
@@ -9,7 +9,7 @@ import (
 	"testing"
 
 	"github.com/GoodsChain/backend/model"
-	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/mock/usecasemock"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +24,7 @@ func TestCreateCustomer(t *testing.T) {
 	tests := []struct {
 		name           string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockCustomerUsecase)
+		mockSetup      func(*usecasemock.MockCustomerUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -35,7 +35,7 @@ func TestCreateCustomer(t *testing.T) {
 				"email":   "test@example.com",
 				"address": "123 Main St",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					CreateCustomer(gomock.Any()).
 					DoAndReturn(func(customer *model.Customer) error {
@@ -50,7 +50,7 @@ func TestCreateCustomer(t *testing.T) {
 				"email":   "test@example.com",
 				"address": "123 Main St",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -63,7 +63,7 @@ func TestCreateCustomer(t *testing.T) {
 				"name":    "Test Customer",
 				"address": "123 Main St",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -74,7 +74,7 @@ func TestCreateCustomer(t *testing.T) {
 				"name":  "Test Customer",
 				"email": "test@example.com",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -86,7 +86,7 @@ func TestCreateCustomer(t *testing.T) {
 				"email":   "not-an-email",
 				"address": "123 Main St",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -98,15 +98,15 @@ func TestCreateCustomer(t *testing.T) {
 				"email":   "test@example.com",
 				"address": "123 Main St",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					CreateCustomer(gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -118,13 +118,14 @@ func TestCreateCustomer(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerHandler(mockUsecase)
+			handler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -153,7 +154,7 @@ func TestCreateCustomer(t *testing.T) {
 				// We might only check if an "error" key exists for BadRequest.
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -169,8 +170,8 @@ func TestCreateCustomer(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -191,14 +192,14 @@ func TestGetCustomer(t *testing.T) {
 	tests := []struct {
 		name           string
 		customerID     string
-		mockSetup      func(*mock.MockCustomerUsecase)
+		mockSetup      func(*usecasemock.MockCustomerUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:       "Success",
 			customerID: customer.ID,
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomer(customer.ID).
 					Return(customer, nil)
@@ -208,15 +209,15 @@ func TestGetCustomer(t *testing.T) {
 		{
 			name:       "Not Found",
 			customerID: "non-existent-id",
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					GetCustomer("non-existent-id").
 					Return(nil, errors.New("not found"))
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: gin.H{
-				"code": "not_found",
-				"message": "Customer not found",
+				"code": "NOT_FOUND",
+				"detail": "Customer not found",
 			},
 		},
 	}
@@ -228,13 +229,14 @@ func TestGetCustomer(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerHandler(mockUsecase)
+			handler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -278,7 +280,7 @@ func TestUpdateCustomer(t *testing.T) {
 		name           string
 		customerID     string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockCustomerUsecase)
+		mockSetup      func(*usecasemock.MockCustomerUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -290,7 +292,7 @@ func TestUpdateCustomer(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "456 New Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					UpdateCustomer(gomock.Eq("customer-id"), gomock.Any()).
 					Return(nil)
@@ -307,7 +309,7 @@ func TestUpdateCustomer(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "456 New Ave",
 			},
-			mockSetup:      func(mockUsecase *mock.MockCustomerUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockCustomerUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -318,7 +320,7 @@ func TestUpdateCustomer(t *testing.T) {
 				"email":   "invalid-email",
 				"address": "456 New Ave",
 			},
-			mockSetup:      func(mockUsecase *mock.MockCustomerUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockCustomerUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -329,15 +331,15 @@ func TestUpdateCustomer(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "456 New Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					UpdateCustomer(gomock.Eq("customer-id"), gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -349,13 +351,14 @@ func TestUpdateCustomer(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerHandler(mockUsecase)
+			handler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -381,7 +384,7 @@ func TestUpdateCustomer(t *testing.T) {
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -394,8 +397,8 @@ func TestUpdateCustomer(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -409,14 +412,14 @@ func TestDeleteCustomer(t *testing.T) {
 	tests := []struct {
 		name           string
 		customerID     string
-		mockSetup      func(*mock.MockCustomerUsecase)
+		mockSetup      func(*usecasemock.MockCustomerUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:       "Success",
 			customerID: "customer-id",
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					DeleteCustomer(gomock.Eq("customer-id")).
 					Return(nil)
@@ -429,15 +432,15 @@ func TestDeleteCustomer(t *testing.T) {
 		{
 			name:       "Usecase Error",
 			customerID: "customer-id",
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
 					DeleteCustomer(gomock.Eq("customer-id")).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -449,13 +452,14 @@ func TestDeleteCustomer(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerHandler(mockUsecase)
+			handler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -509,30 +513,30 @@ func TestGetAllCustomers(t *testing.T) {
 	// Test cases
 	tests := []struct {
 		name           string
-		mockSetup      func(*mock.MockCustomerUsecase)
+		mockSetup      func(*usecasemock.MockCustomerUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name: "Success",
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
-					GetAllCustomers().
+					GetAllCustomers(model.CustomerFilter{}).
 					Return(customers, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Usecase Error",
-			mockSetup: func(mockUsecase *mock.MockCustomerUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockCustomerUsecase) {
 				mockUsecase.EXPECT().
-					GetAllCustomers().
+					GetAllCustomers(model.CustomerFilter{}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "Failed to retrieve customers",
+				"code": "INTERNAL_ERROR",
+				"detail": "Failed to retrieve customers",
 			},
 		},
 	}
@@ -544,13 +548,14 @@ func TestGetAllCustomers(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockCustomerUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
 
 			// Create handler with mock usecase
-			handler := NewCustomerHandler(mockUsecase)
+			handler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
 
 			// Create router and register handler
 			router := gin.New()
@@ -584,3 +589,66 @@ func TestGetAllCustomers(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomerHandler_MaskContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	customerHandler := NewCustomerHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
+	newCustomer := func() *model.Customer {
+		return &model.Customer{ID: "cust1", Name: "John", Phone: "555-123-4567", Email: "john.doe@example.com"}
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if scope := c.GetHeader("X-Test-Scope"); scope != "" {
+			c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalUser, ID: "user-1", Scopes: []string{scope}})
+		}
+	})
+	router.GET("/customers", customerHandler.GetAllCustomers)
+	router.GET("/customers/export", customerHandler.ExportCustomers)
+	router.GET("/customers/:id", customerHandler.GetCustomer)
+	get := func(url, scope string) string {
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("X-Test-Scope", scope)
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	t.Run("Viewer", func(t *testing.T) {
+		mockUsecase.EXPECT().GetCustomer("cust1").Return(newCustomer(), nil)
+		body := get("/customers/cust1", model.ScopeRead)
+		assert.Contains(t, body, `"email":"j***@example.com"`)
+		assert.Contains(t, body, `"phone":"555-***-4567"`)
+
+		mockUsecase.EXPECT().GetAllCustomers(gomock.Any()).Return([]*model.Customer{newCustomer()}, nil)
+		body = get("/customers", "customers:read")
+		assert.Contains(t, body, `"email":"j***@example.com"`)
+		assert.NotContains(t, body, "john.doe")
+
+		mockUsecase.EXPECT().StreamCustomers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ model.CustomerFilter, fn func(*model.Customer) error) error {
+				return fn(newCustomer())
+			})
+		body = get("/customers/export?format=ndjson", model.ScopeRead)
+		assert.Contains(t, body, `"phone":"555-***-4567"`)
+		assert.NotContains(t, body, "john.doe")
+	})
+
+	t.Run("Editor", func(t *testing.T) {
+		mockUsecase.EXPECT().GetCustomer("cust1").Return(newCustomer(), nil)
+		body := get("/customers/cust1", model.ScopeWrite)
+		assert.Contains(t, body, `"email":"john.doe@example.com"`)
+		assert.Contains(t, body, `"phone":"555-123-4567"`)
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		mockUsecase.EXPECT().GetCustomer("cust1").Return(newCustomer(), nil)
+		body := get("/customers/cust1", "")
+		assert.Contains(t, body, `"email":"j***@example.com"`)
+		assert.NotContains(t, body, "555-123-4567")
+	})
+}
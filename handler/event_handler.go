@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/eventstream"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/redact"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// contactFields masks the emails and phones in event payloads
+var contactFields = redact.New(redact.Rule{Field: "email", Mask: redact.Email}, redact.Rule{Field: "phone", Mask: redact.Phone})
+
+// EventHandler streams domain events to clients as server-sent events
+type EventHandler struct {
+	hub       *eventstream.Hub
+	heartbeat time.Duration
+}
+
+// NewEventHandler creates a new EventHandler that writes a heartbeat comment
+// after every heartbeat interval, keeping idle connections open through proxies
+func NewEventHandler(hub *eventstream.Hub, heartbeat time.Duration) *EventHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &EventHandler{hub: hub, heartbeat: heartbeat}
+}
+
+// StreamEvents godoc
+// @Summary Stream domain events
+// @Description Streams domain events as server-sent events. Each event has the outbox sequence as its id, the event type as its name and the event as JSON data. A client reconnecting with the Last-Event-ID header, or the last_event_id query parameter, receives the events it missed first. The stream ends when the client falls behind; reconnecting resumes it.
+// @Tags Events
+// @Produce text/event-stream
+// @Param types query string false "Comma-separated event types to receive, e.g. car.updated,customer_car.created; all types when omitted"
+// @Param Last-Event-ID header int false "Sequence of the last event received"
+// @Param last_event_id query int false "Sequence of the last event received, for clients that cannot set headers"
+// @Success 200 {object} model.Event "Stream of events"
+// @Failure 400 {object} model.Problem "Unknown event type or invalid last event ID"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Failure 503 {object} model.Problem "Event stream not available yet"
+// @Router /events/stream [get]
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	var types []string
+	for _, t := range strings.Split(c.Query("types"), ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !model.IsEventType(t) {
+			respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.unknown_event_type", "type", t))
+			return
+		}
+		types = append(types, t)
+	}
+
+	var lastSeq int64
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.last_event_id"))
+			return
+		}
+		lastSeq = seq
+	}
+
+	// The events of customers and suppliers carry their contacts, masked like
+	// the responses of their endpoints
+	masked := map[string]bool{
+		"customer": masksContacts(c, "customers"),
+		"supplier": masksContacts(c, "suppliers"),
+	}
+
+	ctx := c.Request.Context()
+	sub, err := h.hub.Subscribe(ctx, tenantID(c), types, lastSeq)
+	if errors.Is(err, eventstream.ErrUnavailable) {
+		respondMessage(c, http.StatusServiceUnavailable, appErrors.ErrUnavailable, i18n.M("error.event_stream_unavailable"))
+		return
+	}
+	if err != nil {
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.event_stream_failed"))
+		return
+	}
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn().Err(err).Msg("Failed to clear write deadline of event stream")
+	}
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					log.Info().Err(err).Msg("Event stream ended")
+				}
+				return
+			}
+			if masked[event.AggregateType] {
+				if err := maskContacts(&event); err != nil {
+					log.Error().Err(err).Str("event_id", event.ID).Msg("Failed to mask event contacts")
+					return
+				}
+			}
+			if err := writeEvent(c.Writer, &event); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent writes event in the server-sent events format
+func writeEvent(w gin.ResponseWriter, event *model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+	return err
+}
+
+// maskContacts masks the emails and phones in the data of event
+func maskContacts(event *model.Event) error {
+	var data interface{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return err
+	}
+	masked, err := json.Marshal(contactFields.Field("", data))
+	if err != nil {
+		return err
+	}
+	event.Data = masked
+	return nil
+}
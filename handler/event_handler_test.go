@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/eventstream"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// setupEventRouter returns a router streaming the given stored events. The
+// hub is only started when run is set.
+func setupEventRouter(t *testing.T, events []model.Event, run bool) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	outboxRepo := mock.NewMockOutboxRepository(ctrl)
+	outboxRepo.EXPECT().GetLatestPosition().Return(model.EventPosition{}, nil).AnyTimes()
+	outboxRepo.EXPECT().GetPosition(gomock.Any()).DoAndReturn(func(seq int64) (model.EventPosition, error) {
+		return model.EventPosition{TxID: seq, Seq: seq}, nil
+	}).AnyTimes()
+	outboxRepo.EXPECT().GetEventsAfter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(pos model.EventPosition, tenantID string, types []string, limit int) ([]model.Event, error) {
+			var after []model.Event
+			for _, e := range events {
+				if e.Position().After(pos) && (tenantID == "" || tenantID == e.TenantID) && (len(types) == 0 || types[0] == e.Type) {
+					after = append(after, e)
+				}
+			}
+			return after, nil
+		}).AnyTimes()
+
+	hub := eventstream.NewHub(outboxRepo, nil, eventstream.Options{PollInterval: time.Hour})
+	if run {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go hub.Run(ctx)
+		require.Eventually(t, func() bool {
+			_, err := hub.Subscribe(ctx, model.DefaultTenantID, nil, 0)
+			return err == nil
+		}, time.Second, time.Millisecond)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/events/stream", NewEventHandler(hub, 20*time.Millisecond).StreamEvents)
+	return router
+}
+
+func TestEventHandler_StreamEvents_InvalidRequest(t *testing.T) {
+	router := setupEventRouter(t, nil, true)
+
+	tests := []struct {
+		name        string
+		url         string
+		lastEventID string
+		wantStatus  int
+	}{
+		{name: "UnknownType", url: "/events/stream?types=car.updated,car.sold", wantStatus: http.StatusBadRequest},
+		{name: "InvalidLastEventID", url: "/events/stream", lastEventID: "abc", wantStatus: http.StatusBadRequest},
+		{name: "NegativeLastEventID", url: "/events/stream?last_event_id=-1", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestEventHandler_StreamEvents_Unavailable(t *testing.T) {
+	router := setupEventRouter(t, nil, false)
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/stream", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestEventHandler_StreamEvents(t *testing.T) {
+	events := []model.Event{
+		{Seq: 1, TxID: 1, ID: "e1", TenantID: model.DefaultTenantID, Type: model.EventCarCreated, AggregateID: "car1", Data: []byte(`{"id":"car1"}`)},
+		{Seq: 2, TxID: 2, ID: "e2", TenantID: model.DefaultTenantID, Type: model.EventCarUpdated, AggregateID: "car1", Data: []byte(`{"id":"car1"}`)},
+	}
+	server := httptest.NewServer(setupEventRouter(t, events, true))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/stream?types=car.updated", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The missed event is replayed, followed by heartbeats while idle
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if scanner.Text() == ": heartbeat" {
+			break
+		}
+	}
+	require.GreaterOrEqual(t, len(lines), 3)
+	assert.Equal(t, "id: 2", lines[0])
+	assert.Equal(t, "event: car.updated", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], `data: {"seq":2,"id":"e2","type":"car.updated"`), lines[2])
+}
+
+func TestEventHandler_StreamEvents_MasksContacts(t *testing.T) {
+	events := []model.Event{{Seq: 1, TxID: 1, ID: "e1", TenantID: model.DefaultTenantID, Type: model.EventSupplierUpdated,
+		AggregateType: "supplier", AggregateID: "supp1", Data: []byte(`{"id":"supp1","email":"sales@acme.example","phone":"555-987-6543"}`)}}
+	server := httptest.NewServer(setupEventRouter(t, events, true))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Anonymous callers see the contacts masked
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			assert.Contains(t, line, `"email":"s***@acme.example"`)
+			assert.Contains(t, line, `"phone":"555-***-6543"`)
+			return
+		}
+	}
+	t.Fatal("no event received")
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// expandFor returns expandUsecase for the request in c: scoped to its tenant,
+// and masking the contacts of the embedded customers and suppliers like their
+// own endpoints do
+func expandFor(c *gin.Context, expandUsecase usecase.ExpandUsecase) usecase.ExpandUsecase {
+	return expandUsecase.WithTenant(tenantID(c)).WithContactMask(masksContacts(c, "customers"), masksContacts(c, "suppliers"))
+}
+
+// parseExpand parses the expand query parameter of a request for resource.
+// It returns a nil Expand when the request has none, and writes a 400
+// response and returns false when it is invalid.
+func parseExpand(c *gin.Context, expandUsecase usecase.ExpandUsecase, resource string) (model.Expand, bool) {
+	raw := c.Query("expand")
+	if raw == "" {
+		return nil, true
+	}
+	expand, err := expandUsecase.ParseExpand(resource, raw)
+	if err != nil {
+		var appErr *appErrors.AppError
+		if !errors.As(err, &appErr) {
+			appErr = appErrors.Wrap(err, appErrors.ErrInvalid, err.Error())
+		}
+		respondError(c, http.StatusBadRequest, appErr)
+		return nil, false
+	}
+	return expand, true
+}
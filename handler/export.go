@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/exporter"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// streamExport writes a download of resource records in the format selected
+// by the "format" query parameter. stream is expected to read records from a
+// cursor and pass each one to write, so the response is produced row by row.
+// A non-nil fields restricts the export to those columns, in that order.
+func streamExport(c *gin.Context, resource string, sample interface{}, fields model.Fields, stream func(write func(record interface{}) error) error) {
+	format, err := exporter.ParseFormat(c.Query("format"))
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+
+	// Large exports outlive the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn().Err(err).Str("resource", resource).Msg("Failed to clear write deadline of export")
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", resource, time.Now().UTC().Format("20060102-150405"), format.Extension())
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	rows := 0
+	writer, err := exporter.NewWriter(c.Writer, format, sample, fields...)
+	if err == nil {
+		err = stream(func(record interface{}) error {
+			rows++
+			return writer.Write(record)
+		})
+		if err == nil {
+			err = writer.Close()
+		} else {
+			writer.Abort()
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	// Until the first byte is sent the failure can still be reported properly
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.export_failed", "resource", resource))
+		return
+	}
+	log.Error().Err(err).Str("resource", resource).Int("rows", rows).
+		Str("request_id", c.GetHeader("X-Request-ID")).Msg("Export aborted after the response started")
+	c.Abort()
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupExportRouter(t *testing.T) (*gin.Engine, *usecasemock.MockCarUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	carHandler := NewCarHandler(mockUsecase, usecasemock.NewMockExpandUsecase(ctrl))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/cars/export", carHandler.ExportCars)
+	return router, mockUsecase
+}
+
+func streamCars(cars ...model.Car) func(model.CarFilter, func(*model.Car) error) error {
+	return func(filter model.CarFilter, fn func(*model.Car) error) error {
+		for i := range cars {
+			if err := fn(&cars[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestCarHandler_ExportCars(t *testing.T) {
+	router, mockUsecase := setupExportRouter(t)
+
+	t.Run("CSVWithFilters", func(t *testing.T) {
+		mockUsecase.EXPECT().StreamCars(model.CarFilter{SupplierID: "supp1", MinPrice: 100}, gomock.Any()).
+			DoAndReturn(streamCars(model.Car{ID: "car1", Name: "Camry", SupplierID: "supp1", Price: 25000}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?supplier_id=supp1&min_price=100", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename=cars-\d{8}-\d{6}\.csv$`, w.Header().Get("Content-Disposition"))
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Equal(t, "id,name,supplier_id,price,trim_id,specs,created_at,created_by,updated_at,updated_by", lines[0])
+		assert.Equal(t, "car1,Camry,supp1,25000,,,,,,", lines[1])
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		mockUsecase.EXPECT().StreamCars(model.CarFilter{}, gomock.Any()).
+			DoAndReturn(streamCars(model.Car{ID: "car1"}, model.Car{ID: "car2"}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?format=ndjson", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), 2)
+	})
+
+	t.Run("SelectedFields", func(t *testing.T) {
+		mockUsecase.EXPECT().StreamCars(model.CarFilter{Fields: model.Fields{"price", "id"}}, gomock.Any()).
+			DoAndReturn(streamCars(model.Car{ID: "car1", Name: "Camry", Price: 25000}))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?format=ndjson&fields=price,id", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"price":25000,"id":"car1"}`+"\n", w.Body.String())
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?fields=id,vin", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?format=pdf", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export?min_price=cheap", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("OutlivesWriteTimeout", func(t *testing.T) {
+		mockUsecase.EXPECT().StreamCars(model.CarFilter{}, gomock.Any()).
+			DoAndReturn(func(filter model.CarFilter, fn func(*model.Car) error) error {
+				time.Sleep(200 * time.Millisecond)
+				return fn(&model.Car{ID: "car1"})
+			})
+		server := httptest.NewUnstartedServer(router)
+		server.Config.WriteTimeout = 50 * time.Millisecond
+		server.Start()
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/cars/export?format=ndjson")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"id":"car1"`)
+	})
+
+	t.Run("ErrorBeforeFirstByte", func(t *testing.T) {
+		mockUsecase.EXPECT().StreamCars(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/export", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+)
+
+// parseFields parses the fields query parameter of a request for records of
+// the same type as record. It returns nil when the request has none, and
+// writes a 400 response and returns false when it is invalid.
+func parseFields(c *gin.Context, record interface{}) (model.Fields, bool) {
+	raw, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, true
+	}
+	fields, err := model.ParseFields(raw, record)
+	if err == nil && len(fields) == 0 {
+		err = errEmptyFields
+	}
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// errEmptyFields rejects a fields parameter without any field, which would
+// otherwise silently select every field
+var errEmptyFields = errors.New("fields must name at least one field")
+
+// respondFields writes a 200 response with data, a record or a list of
+// records, trimmed to the selected fields and the expanded relations
+func respondFields(c *gin.Context, data interface{}, fields model.Fields, expand model.Expand) {
+	if fields == nil {
+		c.JSON(http.StatusOK, data)
+		return
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.encode_response"))
+		return
+	}
+	keep := func(key string) bool { return fields.Has(key) || expand.Has(key) }
+
+	if bytes.HasPrefix(body, []byte("[")) {
+		var records []map[string]json.RawMessage
+		if err := json.Unmarshal(body, &records); err == nil {
+			for _, record := range records {
+				trimRecord(record, keep)
+			}
+			c.JSON(http.StatusOK, records)
+			return
+		}
+	} else {
+		var record map[string]json.RawMessage
+		if err := json.Unmarshal(body, &record); err == nil {
+			trimRecord(record, keep)
+			c.JSON(http.StatusOK, record)
+			return
+		}
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func trimRecord(record map[string]json.RawMessage, keep func(key string) bool) {
+	for key := range record {
+		if !keep(key) {
+			delete(record, key)
+		}
+	}
+}
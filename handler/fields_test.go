@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCarHandler_Fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	carUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	carUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(carUsecase).AnyTimes()
+	expandUsecase := usecasemock.NewMockExpandUsecase(ctrl)
+	expandUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(expandUsecase).AnyTimes()
+	expandUsecase.EXPECT().WithContactMask(true, true).Return(expandUsecase).AnyTimes()
+	carHandler := NewCarHandler(carUsecase, expandUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/cars", carHandler.GetAllCars)
+	router.GET("/cars/:id", carHandler.GetCar)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("List", func(t *testing.T) {
+		carUsecase.EXPECT().GetAllCars(model.CarFilter{Fields: model.Fields{"id", "name", "price"}}).
+			Return([]model.Car{{ID: "car1", Name: "Camry", Price: 25000}}, nil)
+
+		w := get("/cars?fields=id,name,price")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"id":"car1","name":"Camry","price":25000}]`, w.Body.String())
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		carUsecase.EXPECT().GetCar("car1").Return(&model.Car{ID: "car1", Name: "Camry", Price: 25000}, nil)
+
+		w := get("/cars/car1?fields=name")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"name":"Camry"}`, w.Body.String())
+	})
+
+	t.Run("WithExpand", func(t *testing.T) {
+		expand := model.Expand{"supplier": {}}
+		expandUsecase.EXPECT().ParseExpand(model.ResourceCar, "supplier").Return(expand, nil)
+		// The supplier is looked up by supplier_id, so it is selected too
+		cars := []model.Car{{ID: "car1", Name: "Camry", SupplierID: "supp1"}}
+		carUsecase.EXPECT().GetAllCars(model.CarFilter{Fields: model.Fields{"name", "supplier_id"}}).Return(cars, nil)
+		expandUsecase.EXPECT().ExpandCars(gomock.Len(1), expand).Return([]*model.ExpandedCar{
+			{Car: &cars[0], Supplier: &model.ExpandedSupplier{Supplier: &model.Supplier{ID: "supp1", Name: "Acme"}}},
+		}, nil)
+
+		w := get("/cars?fields=name&expand=supplier")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"name":"Camry","supplier":{"id":"supp1","name":"Acme","address":"","phone":"","email":"",`+
+			`"created_at":"0001-01-01T00:00:00Z","created_by":"","updated_at":"0001-01-01T00:00:00Z","updated_by":""}}]`, w.Body.String())
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		w := get("/cars?fields=id,vin")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unknown field 'vin', expected one of id, name, supplier_id, price")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		w := get("/cars?fields=")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
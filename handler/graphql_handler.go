@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/graph"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the GraphQL endpoint
+type GraphQLHandler struct {
+	server *graph.Server
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(server *graph.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Query godoc
+// @Summary Run a GraphQL query
+// @Description Runs a GraphQL query over customers, suppliers, cars and customer-car relationships; nested records are fetched in batches. GET requests pass the query, operationName and JSON-encoded variables as query parameters. Queries nested more than GRAPHQL_MAX_DEPTH levels or more complex than GRAPHQL_MAX_COMPLEXITY are rejected with a QUERY_TOO_DEEP or QUERY_TOO_COMPLEX error. Errors of a valid request are reported in the errors field of a 200 response.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "GraphQL request"
+// @Success 200 {object} object "GraphQL response with data and errors"
+// @Failure 400 {object} model.Problem "Missing query or malformed request"
+// @Router /graphql [post]
+// @Router /graphql [get]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graph.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.graphql_variables"))
+				return
+			}
+		}
+		if req.Query == "" {
+			respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.graphql_query"))
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+
+	scope := graph.Scope{
+		TenantID:      tenantID(c),
+		MaskCustomers: masksContacts(c, "customers"),
+		MaskSuppliers: masksContacts(c, "suppliers"),
+	}
+	c.JSON(http.StatusOK, h.server.Execute(c.Request.Context(), scope, req))
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/GoodsChain/backend/graph"
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupGraphQLRouter(t *testing.T) (*gin.Engine, *usecasemock.MockCarUsecase) {
+	ctrl := gomock.NewController(t)
+	mockCustomerUsecase := usecasemock.NewMockCustomerUsecase(ctrl)
+	mockCustomerUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCustomerUsecase).AnyTimes()
+	mockCustomerUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCustomerUsecase).AnyTimes()
+	mockSupplierUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+	mockSupplierUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockSupplierUsecase).AnyTimes()
+	mockSupplierUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockSupplierUsecase).AnyTimes()
+	mockCarUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	mockCarUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCarUsecase).AnyTimes()
+	mockCarUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCarUsecase).AnyTimes()
+	mockCustomerCarUsecase := usecasemock.NewMockCustomerCarUsecase(ctrl)
+	mockCustomerCarUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCustomerCarUsecase).AnyTimes()
+	mockCustomerCarUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockCustomerCarUsecase).AnyTimes()
+	server, err := graph.NewServer(mockCustomerUsecase, mockSupplierUsecase, mockCarUsecase, mockCustomerCarUsecase, graph.Options{})
+	require.NoError(t, err)
+	graphQLHandler := NewGraphQLHandler(server)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/graphql", graphQLHandler.Query)
+	router.GET("/graphql", graphQLHandler.Query)
+	return router, mockCarUsecase
+}
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	router, mockCarUsecase := setupGraphQLRouter(t)
+
+	carsQuery := `query Cars($supplier: ID) { cars(supplierId: $supplier) { id price } }`
+	tests := []struct {
+		name       string
+		request    func() *http.Request
+		setup      func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "Post",
+			request: func() *http.Request {
+				body, _ := json.Marshal(map[string]any{"query": carsQuery, "variables": map[string]any{"supplier": "supp1"}})
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			},
+			setup: func() {
+				mockCarUsecase.EXPECT().GetAllCars(model.CarFilter{SupplierID: "supp1"}).Return([]model.Car{{ID: "car1", Price: 25000}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"cars":[{"id":"car1","price":25000}]}}`,
+		},
+		{
+			name: "Get",
+			request: func() *http.Request {
+				q := url.Values{"query": {carsQuery}, "operationName": {"Cars"}, "variables": {`{"supplier":"supp2"}`}}
+				return httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil)
+			},
+			setup: func() {
+				mockCarUsecase.EXPECT().GetAllCars(model.CarFilter{SupplierID: "supp2"}).Return([]model.Car{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"cars":[]}}`,
+		},
+		{
+			name: "QueryErrorsAreReportedInBody",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ cars(first: 1000) { id } }"}`))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"errors":[{"message":"first must be between 0 and 100","path":["cars"]}],"data":null}`,
+		},
+		{
+			name: "MissingQuery",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"variables":{}}`))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "MalformedBody",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":`))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetWithoutQuery",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/graphql", nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "GetWithInvalidVariables",
+			request: func() *http.Request {
+				q := url.Values{"query": {carsQuery}, "variables": {`[1]`}}
+				return httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			req := tt.request()
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
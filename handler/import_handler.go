@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/importer"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportHandler handles HTTP requests for spreadsheet imports
+type ImportHandler struct {
+	importUsecase usecase.ImportUsecase
+	maxFileSize   int64
+}
+
+// NewImportHandler creates a new ImportHandler. Uploads larger than
+// maxFileSize bytes are rejected.
+func NewImportHandler(uc usecase.ImportUsecase, maxFileSize int64) *ImportHandler {
+	return &ImportHandler{importUsecase: uc, maxFileSize: maxFileSize}
+}
+
+// ImportCustomers godoc
+// @Summary Import customers from CSV or XLSX
+// @Description Validates every row with the same rules as POST /customers. With dry_run=true only a validation report is returned; otherwise all rows are inserted in one transaction, or by a resumable background job when the file exceeds the chunk size.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file with a header row"
+// @Param format formData string false "File format (csv or xlsx), detected from the file name if omitted"
+// @Param sheet formData string false "Worksheet name for XLSX files, the first sheet if omitted"
+// @Param mapping formData string false "JSON object mapping fields to column headers, e.g. {\"name\":\"Họ tên\"}"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} model.ImportReport "Validation report (dry run)"
+// @Success 201 {object} model.ImportReport "All rows imported"
+// @Success 202 {object} model.ImportReport "Import job started"
+// @Failure 400 {object} model.Problem "Invalid file or mapping"
+// @Failure 422 {object} model.ImportReport "Rows failed validation or could not be inserted"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customers/import [post]
+func (h *ImportHandler) ImportCustomers(c *gin.Context) {
+	h.handleImport(c, "customers")
+}
+
+// ImportSuppliers godoc
+// @Summary Import suppliers from CSV or XLSX
+// @Description Validates every row with the same rules as POST /suppliers. With dry_run=true only a validation report is returned; otherwise all rows are inserted in one transaction, or by a resumable background job when the file exceeds the chunk size.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file with a header row"
+// @Param format formData string false "File format (csv or xlsx), detected from the file name if omitted"
+// @Param sheet formData string false "Worksheet name for XLSX files, the first sheet if omitted"
+// @Param mapping formData string false "JSON object mapping fields to column headers"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} model.ImportReport "Validation report (dry run)"
+// @Success 201 {object} model.ImportReport "All rows imported"
+// @Success 202 {object} model.ImportReport "Import job started"
+// @Failure 400 {object} model.Problem "Invalid file or mapping"
+// @Failure 422 {object} model.ImportReport "Rows failed validation or could not be inserted"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /suppliers/import [post]
+func (h *ImportHandler) ImportSuppliers(c *gin.Context) {
+	h.handleImport(c, "suppliers")
+}
+
+// ImportCars godoc
+// @Summary Import cars from CSV or XLSX
+// @Description Validates every row with the same rules as POST /cars. With dry_run=true only a validation report is returned; otherwise all rows are inserted in one transaction, or by a resumable background job when the file exceeds the chunk size.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file with a header row"
+// @Param format formData string false "File format (csv or xlsx), detected from the file name if omitted"
+// @Param sheet formData string false "Worksheet name for XLSX files, the first sheet if omitted"
+// @Param mapping formData string false "JSON object mapping fields to column headers, e.g. {\"price\":\"Giá\"}"
+// @Param dry_run query bool false "Only validate the file"
+// @Success 200 {object} model.ImportReport "Validation report (dry run)"
+// @Success 201 {object} model.ImportReport "All rows imported"
+// @Success 202 {object} model.ImportReport "Import job started"
+// @Failure 400 {object} model.Problem "Invalid file or mapping"
+// @Failure 422 {object} model.ImportReport "Rows failed validation or could not be inserted"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/import [post]
+func (h *ImportHandler) ImportCars(c *gin.Context) {
+	h.handleImport(c, "cars")
+}
+
+func (h *ImportHandler) handleImport(c *gin.Context, resource string) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.file_required", "cause", err.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+
+	format, err := importer.DetectFormat(c.PostForm("format"), fileHeader.Filename, content)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.import_mapping"))
+			return
+		}
+	}
+
+	dryRun := false
+	if raw := c.DefaultQuery("dry_run", c.PostForm("dry_run")); raw != "" {
+		if dryRun, err = strconv.ParseBool(raw); err != nil {
+			respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.import_dry_run"))
+			return
+		}
+	}
+
+	report, err := h.importUsecase.WithTenant(tenantID(c)).Import(&model.ImportRequest{
+		Resource: resource,
+		Content:  content,
+		Format:   string(format),
+		Sheet:    c.PostForm("sheet"),
+		Mapping:  mapping,
+		DryRun:   dryRun,
+		Actor:    actor(c),
+	})
+
+	var rowErr *usecase.ImportRowFailedError
+	switch {
+	case errors.Is(err, usecase.ErrInvalidImportFile):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	case errors.Is(err, usecase.ErrUnknownImportResource):
+		respondProblem(c, http.StatusNotFound, appErrors.ErrNotFound, err.Error())
+	case errors.Is(err, usecase.ErrImportRowsInvalid), errors.As(err, &rowErr):
+		c.JSON(http.StatusUnprocessableEntity, report)
+	case err != nil:
+		respondInternal(c, err)
+	case report.DryRun:
+		c.JSON(http.StatusOK, report)
+	case report.Job != nil:
+		c.JSON(http.StatusAccepted, report)
+	default:
+		c.JSON(http.StatusCreated, report)
+	}
+}
+
+// GetJob godoc
+// @Summary Get an import job
+// @Description Retrieves the status and progress of a chunked import job.
+// @Tags Imports
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} model.ImportJob "Import job"
+// @Failure 404 {object} model.Problem "Import job not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetJob(c *gin.Context) {
+	job, err := h.importUsecase.WithTenant(tenantID(c)).GetJob(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			respondNotFound(c, "Import job")
+			return
+		}
+		respondInternal(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// ResumeJob godoc
+// @Summary Resume an import job
+// @Description Restarts a failed or interrupted import job from the last committed chunk.
+// @Tags Imports
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 202 {object} model.ImportJob "Import job resumed"
+// @Failure 404 {object} model.Problem "Import job not found"
+// @Failure 409 {object} model.Problem "Import job is already running"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /imports/{id}/resume [post]
+func (h *ImportHandler) ResumeJob(c *gin.Context) {
+	job, err := h.importUsecase.WithTenant(tenantID(c)).ResumeJob(c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			respondNotFound(c, "Import job")
+		case errors.Is(err, usecase.ErrImportJobActive):
+			respondProblem(c, http.StatusConflict, appErrors.ErrConflict, err.Error())
+		default:
+			respondInternal(c, err)
+		}
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupImportRouter(t *testing.T, maxFileSize int64) (*gin.Engine, *usecasemock.MockImportUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockImportUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	importHandler := NewImportHandler(mockUsecase, maxFileSize)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/cars/import", importHandler.ImportCars)
+	router.GET("/imports/:id", importHandler.GetJob)
+	router.POST("/imports/:id/resume", importHandler.ResumeJob)
+	return router, mockUsecase
+}
+
+func newImportRequest(t *testing.T, url, filename, content string, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if filename != "" {
+		part, err := w.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	for k, v := range fields {
+		require.NoError(t, w.WriteField(k, v))
+	}
+	require.NoError(t, w.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, url, body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestImportHandler_ImportCars(t *testing.T) {
+	router, mockUsecase := setupImportRouter(t, 1<<20)
+	csv := "name,supplier_id,price\nCamry,supp1,25000\n"
+
+	t.Run("DryRun", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).DoAndReturn(func(req *model.ImportRequest) (*model.ImportReport, error) {
+			assert.Equal(t, "cars", req.Resource)
+			assert.Equal(t, "csv", req.Format)
+			assert.True(t, req.DryRun)
+			assert.Equal(t, map[string]string{"price": "Giá"}, req.Mapping)
+			assert.Equal(t, csv, string(req.Content))
+			return &model.ImportReport{Resource: "cars", DryRun: true, TotalRows: 1, ValidRows: 1}, nil
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import?dry_run=true", "cars.csv", csv, map[string]string{"mapping": `{"price":"Giá"}`}))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Imported", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(&model.ImportReport{Resource: "cars", TotalRows: 1, ValidRows: 1, ImportedRows: 1}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("JobStarted", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(&model.ImportReport{Job: &model.ImportJob{ID: "job1"}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("RowsInvalid", func(t *testing.T) {
+		report := &model.ImportReport{Errors: []model.ImportRowError{{Row: 2, Field: "price", Rule: "gt", Message: "price must be greater than 0"}}}
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(report, usecase.ErrImportRowsInvalid)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var got model.ImportReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, report.Errors, got.Errors)
+	})
+
+	t.Run("RowFailed", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(&model.ImportReport{}, &usecase.ImportRowFailedError{Row: 2, Err: errors.New("duplicate key")})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(nil, usecase.ErrInvalidImportFile)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("BadRequests", func(t *testing.T) {
+		requests := []*http.Request{
+			newImportRequest(t, "/cars/import", "", "", nil),
+			newImportRequest(t, "/cars/import", "cars.pdf", csv, nil),
+			newImportRequest(t, "/cars/import", "cars.csv", csv, map[string]string{"mapping": "[1]"}),
+			newImportRequest(t, "/cars/import?dry_run=maybe", "cars.csv", csv, nil),
+		}
+		for _, req := range requests {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("FileTooLarge", func(t *testing.T) {
+		router, _ := setupImportRouter(t, 64)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv+string(make([]byte, 128)), nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InternalError", func(t *testing.T) {
+		mockUsecase.EXPECT().Import(gomock.Any()).Return(nil, errors.New("db down"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/import", "cars.csv", csv, nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestImportHandler_Jobs(t *testing.T) {
+	router, mockUsecase := setupImportRouter(t, 1<<20)
+
+	t.Run("GetJob", func(t *testing.T) {
+		mockUsecase.EXPECT().GetJob("job1").Return(&model.ImportJob{ID: "job1", Status: model.ImportStatusRunning}, nil)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/imports/job1", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		mockUsecase.EXPECT().GetJob("missing").Return(nil, repository.ErrNotFound)
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, "/imports/missing", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ResumeJob", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			want int
+		}{
+			{nil, http.StatusAccepted},
+			{repository.ErrNotFound, http.StatusNotFound},
+			{usecase.ErrImportJobActive, http.StatusConflict},
+			{errors.New("db down"), http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			mockUsecase.EXPECT().ResumeJob("job1").Return(&model.ImportJob{ID: "job1"}, tt.err)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/imports/job1/resume", nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		}
+	})
+}
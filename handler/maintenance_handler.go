@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler handles HTTP requests for the maintenance records of owned vehicles
+type MaintenanceHandler struct {
+	maintenanceUsecase usecase.MaintenanceUsecase
+}
+
+// NewMaintenanceHandler creates a new MaintenanceHandler
+func NewMaintenanceHandler(uc usecase.MaintenanceUsecase) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenanceUsecase: uc}
+}
+
+// AddRecord godoc
+// @Summary Add a maintenance record
+// @Description Records service, repair or inspection work on the vehicle of a customer-car relationship. The cost is the total of the work items. The odometer reading may not be below that of an earlier record nor above that of a later one.
+// @Tags customer-cars
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer Car ID"
+// @Param record body model.MaintenanceRecord true "Maintenance record to be added"
+// @Success 201 {object} model.MaintenanceRecord "Successfully added maintenance record"
+// @Failure 400 {object} model.Problem "Invalid request payload or service date in the future"
+// @Failure 404 {object} model.Problem "Customer car relationship not found"
+// @Failure 409 {object} model.Problem "Odometer reading inconsistent with the mileage history"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customer-cars/{id}/maintenance [post]
+func (h *MaintenanceHandler) AddRecord(c *gin.Context) {
+	var record model.MaintenanceRecord
+	if err := c.ShouldBindJSON(&record); err != nil {
+		respondBindError(c, err, &record)
+		return
+	}
+	record.CreatedBy = actor(c)
+
+	if err := h.maintenanceUsecase.WithTenant(tenantID(c)).AddRecord(c.Param("id"), &record); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, record)
+}
+
+// GetRecords godoc
+// @Summary Get the maintenance records of an owned vehicle
+// @Description Lists the maintenance records of a customer-car relationship by service date and odometer reading.
+// @Tags customer-cars
+// @Produce json
+// @Param id path string true "Customer Car ID"
+// @Success 200 {array} model.MaintenanceRecord "Successfully retrieved maintenance records"
+// @Failure 404 {object} model.Problem "Customer car relationship not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customer-cars/{id}/maintenance [get]
+func (h *MaintenanceHandler) GetRecords(c *gin.Context) {
+	records, err := h.maintenanceUsecase.WithTenant(tenantID(c)).GetRecords(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+// GetServiceDue godoc
+// @Summary Get when an owned vehicle is due for service
+// @Description Computes the date and odometer reading the next service of the vehicle of a customer-car relationship is due at, from its latest service and the configured service interval.
+// @Tags customer-cars
+// @Produce json
+// @Param id path string true "Customer Car ID"
+// @Success 200 {object} model.ServiceDue "Next service due"
+// @Failure 404 {object} model.Problem "Customer car relationship not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customer-cars/{id}/service-due [get]
+func (h *MaintenanceHandler) GetServiceDue(c *gin.Context) {
+	due, err := h.maintenanceUsecase.WithTenant(tenantID(c)).GetServiceDue(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, due)
+}
+
+func (h *MaintenanceHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrInvalidValue),
+		errors.Is(err, repository.ErrReference):
+		respondNotFound(c, "Customer car relationship")
+	case errors.Is(err, usecase.ErrServiceDate):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	case errors.Is(err, usecase.ErrOdometer):
+		respondProblem(c, http.StatusConflict, appErrors.ErrConflict, err.Error())
+	default:
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.maintenance_failed"))
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupMaintenanceRouter(t *testing.T) (*gin.Engine, *usecasemock.MockMaintenanceUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockMaintenanceUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	maintenanceHandler := NewMaintenanceHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/customer-cars/:id/maintenance", maintenanceHandler.AddRecord)
+	router.GET("/customer-cars/:id/maintenance", maintenanceHandler.GetRecords)
+	router.GET("/customer-cars/:id/service-due", maintenanceHandler.GetServiceDue)
+	return router, mockUsecase
+}
+
+func TestMaintenanceHandler_AddRecord(t *testing.T) {
+	router, mockUsecase := setupMaintenanceRouter(t)
+	body := `{"kind":"service","service_date":"2024-05-02T00:00:00Z","odometer":42500,"workshop":"Downtown",
+		"work_items":[{"description":"Oil change","cost":8500}]}`
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().AddRecord("cc1", gomock.Any()).DoAndReturn(func(_ string, record *model.MaintenanceRecord) error {
+			assert.Equal(t, model.MaintenanceService, record.Kind)
+			assert.Equal(t, 42500, record.Odometer)
+			assert.Equal(t, model.WorkItems{{Description: "Oil change", Cost: 8500}}, record.WorkItems)
+			record.ID, record.CustomerCarID, record.Cost = "rec1", "cc1", 8500
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/customer-cars/cc1/maintenance", bytes.NewBufferString(body))
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"cost":8500`)
+	})
+
+	for name, invalid := range map[string]string{
+		"MissingWorkItems": `{"kind":"service","service_date":"2024-05-02T00:00:00Z","odometer":42500}`,
+		"UnknownKind":      `{"kind":"wash","service_date":"2024-05-02T00:00:00Z","odometer":1,"work_items":[{"description":"Wash"}]}`,
+		"NegativeOdometer": `{"kind":"repair","service_date":"2024-05-02T00:00:00Z","odometer":-1,"work_items":[{"description":"Tyre"}]}`,
+		"EmptyWorkItem":    `{"kind":"repair","service_date":"2024-05-02T00:00:00Z","odometer":1,"work_items":[{"cost":100}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/customer-cars/cc1/maintenance", bytes.NewBufferString(invalid))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"NotFound":        {repository.ErrNotFound, http.StatusNotFound},
+		"FutureDate":      {usecase.ErrServiceDate, http.StatusBadRequest},
+		"MileageDecrease": {fmt.Errorf("%w: 100 km", usecase.ErrOdometer), http.StatusConflict},
+		"Internal":        {assert.AnError, http.StatusInternalServerError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUsecase.EXPECT().AddRecord("cc1", gomock.Any()).Return(tc.err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/customer-cars/cc1/maintenance", bytes.NewBufferString(body))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestMaintenanceHandler_GetRecords(t *testing.T) {
+	router, mockUsecase := setupMaintenanceRouter(t)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().GetRecords("cc1").Return([]model.MaintenanceRecord{{ID: "rec1", CustomerCarID: "cc1"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/customer-cars/cc1/maintenance", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"rec1"`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().GetRecords("missing").Return(nil, repository.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/customer-cars/missing/maintenance", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestMaintenanceHandler_GetServiceDue(t *testing.T) {
+	router, mockUsecase := setupMaintenanceRouter(t)
+
+	t.Run("Success", func(t *testing.T) {
+		dueDate, dueOdometer := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), 57500
+		mockUsecase.EXPECT().GetServiceDue("cc1").Return(&model.ServiceDue{
+			CustomerCarID: "cc1", IntervalKM: 15000, IntervalMonths: 12, DueDate: &dueDate, DueOdometer: &dueOdometer,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/customer-cars/cc1/service-due", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"due_odometer":57500`)
+		assert.Contains(t, w.Body.String(), `"overdue":false`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().GetServiceDue("missing").Return(nil, repository.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/customer-cars/missing/service-due", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the headers and boundaries of a
+// multipart upload on top of the file itself
+const multipartOverhead = 1 << 20
+
+// MediaHandler handles HTTP requests for the photos and documents of cars
+type MediaHandler struct {
+	mediaUsecase usecase.MediaUsecase
+	maxFileSize  int64
+}
+
+// NewMediaHandler creates a new MediaHandler. Files larger than maxFileSize
+// bytes are rejected.
+func NewMediaHandler(uc usecase.MediaUsecase, maxFileSize int64) *MediaHandler {
+	return &MediaHandler{mediaUsecase: uc, maxFileSize: maxFileSize}
+}
+
+// UploadMedia godoc
+// @Summary Upload a photo or document of a car
+// @Description Stores a JPEG, PNG, GIF or WebP image, with a generated thumbnail, or a PDF document for a car. The type is sniffed from the content, not taken from the file name or the part's content type. The response carries signed download URLs that expire.
+// @Tags Cars
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Car ID"
+// @Param file formData file true "Image or PDF file"
+// @Success 201 {object} model.Media "Stored file with its download URLs"
+// @Failure 400 {object} model.Problem "Missing file or undecodable image"
+// @Failure 404 {object} model.Problem "Car not found"
+// @Failure 413 {object} model.Problem "File too large"
+// @Failure 415 {object} model.Problem "Unsupported file type"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/{id}/media [post]
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.respondTooLarge(c)
+			return
+		}
+		respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.file_required", "cause", err.Error()))
+		return
+	}
+	if fileHeader.Size > h.maxFileSize {
+		h.respondTooLarge(c)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		return
+	}
+
+	media, err := h.mediaUsecase.WithTenant(tenantID(c)).UploadMedia(&model.MediaUpload{
+		CarID:    c.Param("id"),
+		Filename: fileHeader.Filename,
+		Content:  content,
+		Actor:    actor(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrInvalidValue):
+			respondNotFound(c, "Car")
+		case errors.Is(err, usecase.ErrMediaType):
+			respondProblem(c, http.StatusUnsupportedMediaType, appErrors.ErrInvalid, err.Error())
+		case errors.Is(err, usecase.ErrInvalidImage):
+			respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+		default:
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.store_file"))
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, media)
+}
+
+// GetCarMedia godoc
+// @Summary Get the photos and documents of a car
+// @Description Lists the files of a car, oldest first, with fresh signed download URLs.
+// @Tags Cars
+// @Produce json
+// @Param id path string true "Car ID"
+// @Success 200 {array} model.Media "Files of the car"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/{id}/media [get]
+func (h *MediaHandler) GetCarMedia(c *gin.Context) {
+	media, err := h.mediaUsecase.WithTenant(tenantID(c)).GetCarMedia(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidValue) {
+			c.JSON(http.StatusOK, []model.Media{})
+			return
+		}
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_files"))
+		return
+	}
+	c.JSON(http.StatusOK, media)
+}
+
+// DeleteMedia godoc
+// @Summary Delete a photo or document of a car
+// @Description Deletes a file of a car with its thumbnail; download URLs handed out for it stop working.
+// @Tags Cars
+// @Produce json
+// @Param id path string true "Car ID"
+// @Param media_id path string true "Media ID"
+// @Success 200 {object} model.SuccessResponse "File deleted successfully"
+// @Failure 404 {object} model.Problem "File not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /cars/{id}/media/{media_id} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	if err := h.mediaUsecase.WithTenant(tenantID(c)).DeleteMedia(c.Param("id"), c.Param("media_id")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrInvalidValue) {
+			respondNotFound(c, "File")
+			return
+		}
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.delete_file"))
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "File deleted successfully"})
+}
+
+// DownloadMedia godoc
+// @Summary Download a photo or document
+// @Description Serves the file, or the thumbnail of an image, a signed URL from the media endpoints of a car points to. The signature authorizes the download, so no credentials are needed; URLs stop working when they expire.
+// @Tags Cars
+// @Produce image/jpeg,image/png,image/gif,image/webp,application/pdf
+// @Param id path string true "Media ID"
+// @Param tenant query string true "Tenant of the file"
+// @Param variant query string false "original (default) or thumbnail"
+// @Param expires query int true "Unix time the URL expires at"
+// @Param signature query string true "Signature of the URL"
+// @Success 200 {file} file "The file"
+// @Failure 403 {object} model.Problem "Invalid or expired URL"
+// @Failure 404 {object} model.Problem "File not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /media/{id} [get]
+func (h *MediaHandler) DownloadMedia(c *gin.Context) {
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		respondMessage(c, http.StatusForbidden, appErrors.ErrForbidden, i18n.M("error.media_link"))
+		return
+	}
+	file, err := h.mediaUsecase.OpenMedia(model.MediaLink{
+		TenantID:  c.Query("tenant"),
+		MediaID:   c.Param("id"),
+		Variant:   c.Query("variant"),
+		Expires:   expires,
+		Signature: c.Query("signature"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrMediaLink):
+			respondMessage(c, http.StatusForbidden, appErrors.ErrForbidden, i18n.M("error.media_link"))
+		case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrInvalidValue):
+			respondNotFound(c, "File")
+		default:
+			respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.read_file"))
+		}
+		return
+	}
+	defer file.Body.Close()
+
+	// Images are shown in place, documents downloaded; the sniffed type is
+	// authoritative
+	disposition := "attachment"
+	if file.Kind == model.MediaImage {
+		disposition = "inline"
+	}
+	maxAge := max(0, expires-time.Now().Unix())
+	c.DataFromReader(http.StatusOK, -1, file.ContentType, file.Body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": file.Filename}),
+		"Cache-Control":          fmt.Sprintf("private, max-age=%d", maxAge),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *MediaHandler) respondTooLarge(c *gin.Context) {
+	respondMessage(c, http.StatusRequestEntityTooLarge, appErrors.ErrInvalid,
+		i18n.M("error.file_too_large", "bytes", h.maxFileSize))
+}
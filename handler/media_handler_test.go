@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupMediaRouter(t *testing.T, maxFileSize int64) (*gin.Engine, *usecasemock.MockMediaUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockMediaUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	mediaHandler := NewMediaHandler(mockUsecase, maxFileSize)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/cars/:id/media", mediaHandler.UploadMedia)
+	router.GET("/cars/:id/media", mediaHandler.GetCarMedia)
+	router.DELETE("/cars/:id/media/:media_id", mediaHandler.DeleteMedia)
+	router.GET("/media/:id", mediaHandler.DownloadMedia)
+	return router, mockUsecase
+}
+
+func TestMediaHandler_UploadMedia(t *testing.T) {
+	router, mockUsecase := setupMediaRouter(t, 1024)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().UploadMedia(gomock.Any()).DoAndReturn(func(upload *model.MediaUpload) (*model.Media, error) {
+			assert.Equal(t, "car1", upload.CarID)
+			assert.Equal(t, "brochure.pdf", upload.Filename)
+			assert.Equal(t, "%PDF-1.4", string(upload.Content))
+			return &model.Media{ID: "m1", CarID: "car1", Kind: model.MediaDocument, URL: "/v1/media/m1?signature=s"}, nil
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/car1/media", "brochure.pdf", "%PDF-1.4", nil))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"/v1/media/m1?signature=s"`)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/car1/media", "", "", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest(t, "/cars/car1/media", "big.pdf", strings.Repeat("x", 2048), nil))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"UnsupportedType": {fmt.Errorf("%w: got text/plain", usecase.ErrMediaType), http.StatusUnsupportedMediaType},
+		"InvalidImage":    {usecase.ErrInvalidImage, http.StatusBadRequest},
+		"CarNotFound":     {repository.ErrNotFound, http.StatusNotFound},
+		"Internal":        {assert.AnError, http.StatusInternalServerError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUsecase.EXPECT().UploadMedia(gomock.Any()).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newImportRequest(t, "/cars/car1/media", "file.bin", "content", nil))
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestMediaHandler_GetCarMedia(t *testing.T) {
+	router, mockUsecase := setupMediaRouter(t, 1024)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().GetCarMedia("car1").Return([]model.Media{{ID: "m1", CarID: "car1"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/car1/media", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"m1"`)
+	})
+
+	t.Run("InvalidCarID", func(t *testing.T) {
+		mockUsecase.EXPECT().GetCarMedia("bad").Return(nil, repository.ErrInvalidValue)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars/bad/media", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+}
+
+func TestMediaHandler_DeleteMedia(t *testing.T) {
+	router, mockUsecase := setupMediaRouter(t, 1024)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().DeleteMedia("car1", "m1").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/cars/car1/media/m1", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().DeleteMedia("car1", "m2").Return(repository.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/cars/car1/media/m2", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestMediaHandler_DownloadMedia(t *testing.T) {
+	router, mockUsecase := setupMediaRouter(t, 1024)
+	expires := time.Now().Add(time.Minute).Unix()
+	query := fmt.Sprintf("?tenant=t1&variant=thumbnail&expires=%d&signature=sig", expires)
+
+	t.Run("Image", func(t *testing.T) {
+		mockUsecase.EXPECT().OpenMedia(model.MediaLink{
+			TenantID: "t1", MediaID: "m1", Variant: "thumbnail", Expires: expires, Signature: "sig",
+		}).Return(&usecase.MediaFile{
+			Filename:    "front-thumb.jpg",
+			ContentType: "image/jpeg",
+			Kind:        model.MediaImage,
+			Body:        io.NopCloser(strings.NewReader("jpeg")),
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/media/m1"+query, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "jpeg", w.Body.String())
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, `inline; filename=front-thumb.jpg`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.True(t, strings.HasPrefix(w.Header().Get("Cache-Control"), "private, max-age="))
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		mockUsecase.EXPECT().OpenMedia(gomock.Any()).Return(&usecase.MediaFile{
+			Filename:    "brochure 2024.pdf",
+			ContentType: "application/pdf",
+			Kind:        model.MediaDocument,
+			Body:        io.NopCloser(strings.NewReader("%PDF")),
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/media/m1"+query, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `attachment; filename="brochure 2024.pdf"`, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("MalformedExpiry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/media/m1?tenant=t1&expires=soon&signature=sig", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"InvalidLink": {usecase.ErrMediaLink, http.StatusForbidden},
+		"NotFound":    {repository.ErrNotFound, http.StatusNotFound},
+		"Internal":    {assert.AnError, http.StatusInternalServerError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUsecase.EXPECT().OpenMedia(gomock.Any()).Return(nil, tc.err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/media/m1"+query, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
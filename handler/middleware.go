@@ -8,7 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	appErrors "github.com/GoodsChain/backend/errors"
-	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/i18n"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -86,10 +86,11 @@ func ErrorHandlingMiddleware() gin.HandlerFunc {
 				Msg(errorMessage)
 
 			// Return standardized error response
-			c.JSON(responseStatusCode, model.ErrorResponse{
-				Code:    errorCode,
-				Message: errorMessage,
-			})
+			if appError != nil {
+				respondError(c, responseStatusCode, appError)
+			} else {
+				respondProblem(c, responseStatusCode, appErrors.ErrorCode(errorCode), errorMessage)
+			}
 			return // Stop further processing if error handled
 		}
 
@@ -114,10 +115,7 @@ func ErrorHandlingMiddleware() gin.HandlerFunc {
 				Str("userAgent", userAgent).
 				Msg("Resource not found")
 				
-			c.JSON(http.StatusNotFound, model.ErrorResponse{
-				Code:    string(appErrors.ErrNotFound),
-				Message: "Resource not found",
-			})
+			respondMessage(c, http.StatusNotFound, appErrors.ErrNotFound, i18n.M("error.route_not_found"))
 		}
 	}
 }
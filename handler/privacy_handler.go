@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler handles HTTP requests answering the data subject requests of customers
+type PrivacyHandler struct {
+	privacyUsecase usecase.PrivacyUsecase
+}
+
+// NewPrivacyHandler creates a new PrivacyHandler
+func NewPrivacyHandler(uc usecase.PrivacyUsecase) *PrivacyHandler {
+	return &PrivacyHandler{privacyUsecase: uc}
+}
+
+// ExportCustomerData godoc
+// @Summary Export all data held on a customer
+// @Description Returns the customer, their customer-car links, the linked cars and the audit trail of all of them as a JSON document or a ZIP archive with one JSON file each. The export is recorded as a customer.data_exported event. Requires the admin scope.
+// @Tags Customers
+// @Produce json,application/zip
+// @Param id path string true "Customer ID" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"
+// @Param format query string false "Bundle format: json (default) or zip"
+// @Success 200 {object} model.CustomerDataExport "All data held on the customer"
+// @Failure 400 {object} model.Problem "Invalid format"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 404 {object} model.Problem "Customer not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customers/{id}/data-export [get]
+func (h *PrivacyHandler) ExportCustomerData(c *gin.Context) {
+	id := c.Param("id")
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.export_format"))
+		return
+	}
+
+	export, err := h.privacyUsecase.WithTenant(tenantID(c)).ExportCustomerData(id, actor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			respondNotFound(c, "Customer")
+			return
+		}
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.export_customer_data"))
+		return
+	}
+
+	filename := "customer-" + id + "-data." + format
+	if format == "json" {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	archive, err := zipCustomerData(export)
+	if err != nil {
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.export_customer_data"))
+		return
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// zipCustomerData returns a ZIP archive of export with one JSON file per part
+func zipCustomerData(export *model.CustomerDataExport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"customer.json", export.Customer},
+		{"customer_cars.json", export.CustomerCars},
+		{"cars.json", export.Cars},
+		{"maintenance_records.json", export.MaintenanceRecords},
+		{"events.json", export.Events},
+		{"export.json", map[string]interface{}{"exported_at": export.ExportedAt}},
+	}
+	for _, file := range files {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EraseCustomer godoc
+// @Summary Erase the personal data of a customer
+// @Description Replaces the name, address, phone and email of the customer, and of the payloads of their recorded events, with pseudonyms. The customer keeps its ID, links and timestamps, so references and statistics stay intact. The erasure is recorded as a customer.erased event. Requires the admin scope.
+// @Tags Customers
+// @Produce json
+// @Param id path string true "Customer ID" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"
+// @Success 200 {object} model.Customer "The erased customer"
+// @Failure 403 {object} model.Problem "Missing admin scope"
+// @Failure 404 {object} model.Problem "Customer not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /customers/{id}/erase [post]
+func (h *PrivacyHandler) EraseCustomer(c *gin.Context) {
+	customer, err := h.privacyUsecase.WithTenant(tenantID(c)).EraseCustomer(c.Param("id"), actor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			respondNotFound(c, "Customer")
+			return
+		}
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.erase_customer"))
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
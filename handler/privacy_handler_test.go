@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupPrivacyRouter(t *testing.T) (*gin.Engine, *usecasemock.MockPrivacyUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockPrivacyUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant("tenant1").Return(mockUsecase).AnyTimes()
+	privacyHandler := NewPrivacyHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalUser, ID: "u1", Actor: "dpo", Scopes: []string{model.ScopeAdmin}, TenantID: "tenant1"})
+	})
+	router.GET("/customers/:id/data-export", privacyHandler.ExportCustomerData)
+	router.POST("/customers/:id/erase", privacyHandler.EraseCustomer)
+	return router, mockUsecase
+}
+
+func TestPrivacyHandler_ExportCustomerData(t *testing.T) {
+	router, mockUsecase := setupPrivacyRouter(t)
+	export := &model.CustomerDataExport{
+		ExportedAt:         time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		Customer:           &model.Customer{ID: "cust1", Name: "John", Email: "john@example.com"},
+		CustomerCars:       []*model.CustomerCar{{ID: "cc1", CarID: "car1", CustomerID: "cust1"}},
+		Cars:               []model.Car{{ID: "car1", Name: "Camry"}},
+		MaintenanceRecords: []model.MaintenanceRecord{{ID: "mr1", CustomerCarID: "cc1", Workshop: "Downtown"}},
+		Events:             []model.Event{{ID: "e1", Type: model.EventCustomerCreated, AggregateType: "customer", AggregateID: "cust1"}},
+	}
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		mockUsecase.EXPECT().ExportCustomerData("cust1", "dpo").Return(export, nil)
+
+		w := get("/customers/cust1/data-export")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `attachment; filename=customer-cust1-data.json`, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Body.String(), `"email":"john@example.com"`)
+		assert.Contains(t, w.Body.String(), `"customer_cars":[{"id":"cc1"`)
+		assert.Contains(t, w.Body.String(), `"maintenance_records":[{"id":"mr1"`)
+		assert.Contains(t, w.Body.String(), `"events":[{"id":"e1"`)
+	})
+
+	t.Run("ZIP", func(t *testing.T) {
+		mockUsecase.EXPECT().ExportCustomerData("cust1", "dpo").Return(export, nil)
+
+		w := get("/customers/cust1/data-export?format=zip")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		files := map[string]string{}
+		for _, f := range archive.File {
+			r, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			files[f.Name] = string(data)
+		}
+		assert.Len(t, files, 6)
+		assert.Contains(t, files["customer.json"], `"name": "John"`)
+		assert.Contains(t, files["cars.json"], `"Camry"`)
+		assert.Contains(t, files["maintenance_records.json"], `"workshop": "Downtown"`)
+		assert.Contains(t, files["events.json"], `"customer.created"`)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/customers/cust1/data-export?format=xml").Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().ExportCustomerData("missing", "dpo").Return(nil, repository.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, get("/customers/missing/data-export").Code)
+	})
+
+	t.Run("UsecaseError", func(t *testing.T) {
+		mockUsecase.EXPECT().ExportCustomerData("cust1", "dpo").Return(nil, errors.New("db down"))
+		assert.Equal(t, http.StatusInternalServerError, get("/customers/cust1/data-export").Code)
+	})
+}
+
+func TestPrivacyHandler_EraseCustomer(t *testing.T) {
+	router, mockUsecase := setupPrivacyRouter(t)
+	post := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().EraseCustomer("cust1", "dpo").
+			Return(&model.Customer{ID: "cust1", Name: "Erased customer", Email: "erased-cust1@erased.invalid"}, nil)
+
+		w := post("/customers/cust1/erase")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email":"erased-cust1@erased.invalid"`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUsecase.EXPECT().EraseCustomer("missing", "dpo").Return(nil, repository.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, post("/customers/missing/erase").Code)
+	})
+
+	t.Run("UsecaseError", func(t *testing.T) {
+		mockUsecase.EXPECT().EraseCustomer("cust1", "dpo").Return(nil, errors.New("db down"))
+		assert.Equal(t, http.StatusInternalServerError, post("/customers/cust1/erase").Code)
+	})
+}
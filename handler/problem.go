@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/redact"
+	"github.com/GoodsChain/backend/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// locale returns the language of the responses to the request in c, chosen
+// from its Accept-Language header
+func locale(c *gin.Context) string {
+	return i18n.Match(c.GetHeader("Accept-Language"))
+}
+
+// newProblem builds the problem details of an error of the request in c,
+// with its title in the language of the request. Emails and phones in detail,
+// such as those of database errors, are masked.
+func newProblem(c *gin.Context, status int, code appErrors.ErrorCode, detail string) model.Problem {
+	name := strings.ToLower(string(code))
+	if !i18n.Has("problem." + name) {
+		name = strings.ToLower(string(appErrors.ErrInternal))
+	}
+	return model.Problem{
+		Type:     "urn:goodschain:problem:" + strings.ReplaceAll(name, "_", "-"),
+		Title:    i18n.M("problem." + name).Localize(locale(c)),
+		Status:   status,
+		Detail:   redact.Default.String(detail),
+		Instance: c.GetHeader("X-Request-ID"),
+		Code:     string(code),
+	}
+}
+
+// newMessageProblem builds the problem details of an error described by msg,
+// a catalog message rendered in the language of the request
+func newMessageProblem(c *gin.Context, status int, code appErrors.ErrorCode, msg i18n.Message) model.Problem {
+	problem := newProblem(c, status, code, msg.Localize(locale(c)))
+	problem.DetailKey = msg.Key
+	problem.DetailParams = msg.Params
+	return problem
+}
+
+// writeProblem writes problem as an application/problem+json response
+func writeProblem(c *gin.Context, problem model.Problem) {
+	c.Header("Content-Type", model.ProblemContentType)
+	c.Header("Content-Language", locale(c))
+	c.JSON(problem.Status, problem)
+}
+
+// respondProblem writes an error response with the given status and code
+func respondProblem(c *gin.Context, status int, code appErrors.ErrorCode, detail string) {
+	writeProblem(c, newProblem(c, status, code, detail))
+}
+
+// respondMessage writes an error response with the given status and code,
+// described by a catalog message
+func respondMessage(c *gin.Context, status int, code appErrors.ErrorCode, msg i18n.Message) {
+	writeProblem(c, newMessageProblem(c, status, code, msg))
+}
+
+// respondInternal writes the 500 response of err with a generic detail, so
+// that database errors and the like never reach clients, and logs err for
+// the request in c
+func respondInternal(c *gin.Context, err error) {
+	log.Error().Err(err).Str("request_id", c.GetHeader("X-Request-ID")).
+		Str("method", c.Request.Method).Str("path", c.Request.URL.Path).
+		Msg("Request failed")
+	respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.internal"))
+}
+
+// respondNotFound writes the 404 response of a missing record of resource,
+// such as "Customer"
+func respondNotFound(c *gin.Context, resource string) {
+	respondMessage(c, http.StatusNotFound, appErrors.ErrNotFound,
+		i18n.M("error.resource_not_found", "resource", i18n.Resource(resource)))
+}
+
+// respondError writes the response of appErr, localizing its message when it
+// comes from the catalog
+func respondError(c *gin.Context, status int, appErr *appErrors.AppError) {
+	if appErr.Msg.IsZero() {
+		respondProblem(c, status, appErr.Code, appErr.Message)
+		return
+	}
+	respondMessage(c, status, appErr.Code, appErr.Msg)
+}
+
+// respondBindError writes the 400 response of a request that could not be
+// bound to obj, with one entry in errors per invalid field
+func respondBindError(c *gin.Context, err error, obj interface{}) {
+	fieldErrors := validation.FieldErrors(err, obj)
+	if len(fieldErrors) == 0 || fieldErrors[0].Field == "" {
+		// Not a field error, such as a malformed body; decoder messages name
+		// internal types, so they are only logged
+		log.Debug().Err(err).Str("request_id", c.GetHeader("X-Request-ID")).Msg("Malformed request")
+		respondMessage(c, http.StatusBadRequest, appErrors.ErrInvalid, i18n.M("error.malformed_body"))
+		return
+	}
+
+	msg := i18n.M("validation.invalid_fields", "count", len(fieldErrors))
+	if len(fieldErrors) == 1 {
+		msg = i18n.M("validation.invalid_field")
+	}
+	problem := newMessageProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, msg)
+	problem.Errors = validation.Localize(fieldErrors, locale(c))
+	writeProblem(c, problem)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProblemResponses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	carUsecase := usecasemock.NewMockCarUsecase(ctrl)
+	carUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(carUsecase).AnyTimes()
+	carHandler := NewCarHandler(carUsecase, usecasemock.NewMockExpandUsecase(ctrl))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/cars", carHandler.CreateCar)
+	router.GET("/cars", carHandler.GetAllCars)
+	router.GET("/cars/:id", carHandler.GetCar)
+
+	send := func(method, url, body string, headers ...string) (*httptest.ResponseRecorder, model.Problem) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "req-42")
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		router.ServeHTTP(w, req)
+
+		var problem model.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		return w, problem
+	}
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		w, problem := send(http.MethodPost, "/cars", `{"price": -1}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "urn:goodschain:problem:invalid-input", problem.Type)
+		assert.Equal(t, "Invalid input", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "INVALID_INPUT", problem.Code)
+		assert.Equal(t, "req-42", problem.Instance)
+		assert.Equal(t, "3 fields are invalid", problem.Detail)
+		assert.Equal(t, "validation.invalid_fields", problem.DetailKey)
+		assert.Equal(t, map[string]interface{}{"count": 3.0}, problem.DetailParams)
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Equal(t, []validation.FieldError{
+			{Field: "name", Rule: "required", Message: "name is required",
+				Key: "validation.required", Params: map[string]interface{}{"field": "name"}},
+			{Field: "supplier_id", Rule: "required", Message: "supplier_id is required",
+				Key: "validation.required", Params: map[string]interface{}{"field": "supplier_id"}},
+			{Field: "price", Rule: "gt", Message: "price must be greater than 0",
+				Key: "validation.gt", Params: map[string]interface{}{"field": "price", "param": "0"}},
+		}, problem.Errors)
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		_, problem := send(http.MethodPost, "/cars", `{"name": "Camry", "price": "cheap"}`)
+		assert.Equal(t, []validation.FieldError{
+			{Field: "price", Rule: "type", Message: "price must be of type int",
+				Key: "validation.type", Params: map[string]interface{}{"field": "price", "type": "int"}},
+		}, problem.Errors)
+	})
+
+	t.Run("MalformedBody", func(t *testing.T) {
+		w, problem := send(http.MethodPost, "/cars", `{"name":`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "Request body is malformed", problem.Detail)
+		assert.Equal(t, "error.malformed_body", problem.DetailKey)
+		assert.Empty(t, problem.Errors)
+
+		_, problem = send(http.MethodPost, "/cars", `{"name": "Camry",}`, "Accept-Language", "vi")
+		assert.Equal(t, "Nội dung yêu cầu không đúng định dạng", problem.Detail)
+	})
+
+	t.Run("QueryParameter", func(t *testing.T) {
+		_, problem := send(http.MethodGet, "/cars?min_price=-5", "")
+		assert.Equal(t, []validation.FieldError{
+			{Field: "min_price", Rule: "gte", Message: "min_price must be at least 0",
+				Key: "validation.gte", Params: map[string]interface{}{"field": "min_price", "param": "0"}},
+		}, problem.Errors)
+	})
+
+	t.Run("Vietnamese", func(t *testing.T) {
+		w, problem := send(http.MethodPost, "/cars", `{"name": "Camry", "supplier_id": "supp1", "price": -1}`,
+			"Accept-Language", "vi-VN,vi;q=0.9,en;q=0.8")
+
+		assert.Equal(t, "vi", w.Header().Get("Content-Language"))
+		assert.Equal(t, "Dữ liệu không hợp lệ", problem.Title)
+		assert.Equal(t, "1 trường không hợp lệ", problem.Detail)
+		assert.Equal(t, "validation.invalid_field", problem.DetailKey)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "price phải lớn hơn 0", problem.Errors[0].Message)
+		assert.Equal(t, "validation.gt", problem.Errors[0].Key, "keys are the same in every language")
+	})
+
+	t.Run("UnsupportedLanguage", func(t *testing.T) {
+		w, problem := send(http.MethodPost, "/cars", `{"name":`, "Accept-Language", "fr-FR")
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Equal(t, "Invalid input", problem.Title)
+	})
+
+	t.Run("LocalizedNotFound", func(t *testing.T) {
+		carUsecase.EXPECT().GetCar("car1").Return(nil, repository.ErrNotFound).Times(2)
+
+		_, problem := send(http.MethodGet, "/cars/car1", "")
+		assert.Equal(t, "Car not found", problem.Detail)
+		assert.Equal(t, "error.resource_not_found", problem.DetailKey)
+		assert.Equal(t, map[string]interface{}{"resource": map[string]interface{}{"key": "resource.car"}}, problem.DetailParams)
+
+		_, problem = send(http.MethodGet, "/cars/car1", "", "Accept-Language", "vi")
+		assert.Equal(t, "Không tìm thấy xe", problem.Detail)
+		assert.Equal(t, "error.resource_not_found", problem.DetailKey)
+	})
+
+	t.Run("InternalErrorCauseIsHidden", func(t *testing.T) {
+		carUsecase.EXPECT().CreateCar(gomock.Any()).
+			Return(errors.New(`pq: Key (email)=(john.doe@example.com) already exists`)).Times(2)
+
+		_, problem := send(http.MethodPost, "/cars", `{"name":"Camry","supplier_id":"supp1","price":30000}`)
+		assert.Equal(t, "Internal server error", problem.Detail)
+		assert.Equal(t, "error.internal", problem.DetailKey)
+
+		_, problem = send(http.MethodPost, "/cars", `{"name":"Camry","supplier_id":"supp1","price":30000}`, "Accept-Language", "vi")
+		assert.Equal(t, "Lỗi máy chủ nội bộ", problem.Detail)
+	})
+
+	t.Run("LocalizedFailure", func(t *testing.T) {
+		carUsecase.EXPECT().GetAllCars(gomock.Any()).Return(nil, errors.New("connection refused")).Times(2)
+
+		_, problem := send(http.MethodGet, "/cars", "")
+		assert.Equal(t, "Failed to retrieve cars", problem.Detail)
+		assert.Equal(t, "error.retrieve_cars", problem.DetailKey)
+
+		_, problem = send(http.MethodGet, "/cars", "", "Accept-Language", "vi")
+		assert.Equal(t, "Không thể lấy danh sách xe", problem.Detail)
+	})
+}
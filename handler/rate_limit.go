@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// ContextClientID is the context key of the ID of the authenticated client of
+// a request. Rate limits count the requests of each client; requests without
+// a client are counted by IP address as "ip:<address>".
+const ContextClientID = "client_id"
+
+// clientID returns the ID the requests in c are counted under
+func clientID(c *gin.Context) string {
+	if id := c.GetString(ContextClientID); id != "" {
+		return id
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimiter limits the requests of each client to each route group
+type RateLimiter struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewRateLimiter creates a new RateLimiter
+func NewRateLimiter(limiter *ratelimit.Limiter) *RateLimiter {
+	return &RateLimiter{limiter: limiter}
+}
+
+// Limit returns the middleware limiting the requests to group. It sets the
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy
+// headers, and rejects requests over the limit with 429 and Retry-After. A
+// nil RateLimiter does not limit.
+func (r *RateLimiter) Limit(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if r == nil {
+			return
+		}
+		result, err := r.limiter.Allow(clientID(c), group)
+		if err != nil {
+			// An unavailable store must not take the API down with it
+			log.Warn().Err(err).Str("group", group).Msg("Rate limit check failed, allowing request")
+			return
+		}
+		if result.Limit.IsZero() {
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		c.Header("RateLimit-Policy", strconv.Itoa(result.Limit.Requests)+";w="+ceilSeconds(result.Limit.Period))
+		if result.Allowed {
+			return
+		}
+
+		retryAfter := ceilSeconds(result.RetryAfter)
+		c.Header("Retry-After", retryAfter)
+		respondMessage(c, http.StatusTooManyRequests, appErrors.ErrRateLimited,
+			i18n.M("error.rate_limited", "seconds", retryAfter))
+		c.Abort()
+	}
+}
+
+// ceilSeconds formats d as a whole number of seconds, rounded up
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore is a rate limit store that cannot be reached
+type failingStore struct{}
+
+func (failingStore) Take(string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func newRateLimitedRouter(rateLimiter *RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if key := c.GetHeader("X-Test-Client"); key != "" {
+			c.Set(ContextClientID, key)
+		}
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/cars", rateLimiter.Limit("cars"), ok)
+	router.GET("/customers", rateLimiter.Limit("customers"), ok)
+	return router
+}
+
+func TestRateLimiter_Limit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Policy{
+		Default: ratelimit.Limit{Requests: 100, Period: time.Minute},
+		Groups:  map[string]ratelimit.Limit{"cars": {Requests: 2, Period: time.Minute}},
+		Clients: map[string]ratelimit.Limit{"key:internal": {}},
+	})
+	router := newRateLimitedRouter(NewRateLimiter(limiter))
+	get := func(path, client string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Test-Client", client)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/cars", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusNoContent, get("/cars", "").Code)
+
+	w = get("/cars", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	var problem model.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "RATE_LIMITED", problem.Code)
+	assert.Equal(t, "Too many requests", problem.Title)
+	assert.Equal(t, "Rate limit exceeded, retry in 30 seconds", problem.Detail)
+
+	// Other groups and authenticated clients have their own buckets
+	assert.Equal(t, http.StatusNoContent, get("/customers", "").Code)
+	assert.Equal(t, http.StatusNoContent, get("/cars", "key:partner").Code)
+
+	// Clients without a limit get no headers
+	w = get("/cars", "key:internal")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_Limit_StoreError(t *testing.T) {
+	limiter := ratelimit.NewLimiter(failingStore{}, ratelimit.Policy{Default: ratelimit.Limit{Requests: 1, Period: time.Second}})
+	router := newRateLimitedRouter(NewRateLimiter(limiter))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cars", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code, "requests are allowed when the store fails")
+}
+
+func TestRateLimiter_Limit_Nil(t *testing.T) {
+	router := newRateLimitedRouter(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cars", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_Limit_TrustedProxies(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Policy{
+		Default: ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+	router := newRateLimitedRouter(NewRateLimiter(limiter))
+	get := func(remoteAddr, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cars", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Without trusted proxies, clients cannot pick their address
+	require.NoError(t, router.SetTrustedProxies(nil))
+	assert.Equal(t, http.StatusNoContent, get("192.0.2.1:1234", "198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get("192.0.2.1:1234", "198.51.100.2"))
+
+	// Behind a trusted proxy, each forwarded client has its own bucket
+	require.NoError(t, router.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	assert.Equal(t, http.StatusNoContent, get("10.0.0.1:1234", "198.51.100.1"))
+	assert.Equal(t, http.StatusNoContent, get("10.0.0.1:1234", "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, get("10.0.0.1:1234", "198.51.100.2"))
+}
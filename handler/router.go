@@ -5,44 +5,134 @@ import "github.com/gin-gonic/gin"
 // InitRoutes sets up all the routes
 // Now accepts a RouterGroup instead of Engine to support API versioning
 func InitRoutes(router gin.IRouter, customerHandler *CustomerHandler, supplierHandler *SupplierHandler,
-	carHandler *CarHandler, customerCarHandler *CustomerCarHandler) {
+	carHandler *CarHandler, customerCarHandler *CustomerCarHandler, importHandler *ImportHandler,
+	batchHandler *BatchHandler, webhookHandler *WebhookHandler, eventHandler *EventHandler,
+	graphQLHandler *GraphQLHandler, searchHandler *SearchHandler, apiKeyHandler *APIKeyHandler,
+	authHandler *AuthHandler, privacyHandler *PrivacyHandler, catalogHandler *CatalogHandler,
+	mediaHandler *MediaHandler, maintenanceHandler *MaintenanceHandler, rateLimiter *RateLimiter, authenticator *Authenticator) {
 	// Note: global middleware should be registered at the engine level, not here
+	// Each group is rate limited and authorized on its own, after the principal
+	// is resolved so it is limited per client; nil middlewares do nothing
+	router = router.Group("", authenticator.Authenticate())
 
-	customerGroup := router.Group("/customers")
+	// Signed media URLs authorize their own downloads
+	router.GET("/media/:id", rateLimiter.Limit("media"), mediaHandler.DownloadMedia)
+
+	customerGroup := router.Group("/customers", rateLimiter.Limit("customers"), authenticator.Authorize("customers"))
 	{
 		customerGroup.POST("", customerHandler.CreateCustomer)
+		customerGroup.POST("/import", importHandler.ImportCustomers)
+		customerGroup.POST("/batch", batchHandler.BatchCustomers)
 		customerGroup.GET("", customerHandler.GetAllCustomers)
+		customerGroup.GET("/export", customerHandler.ExportCustomers)
 		customerGroup.GET("/:id", customerHandler.GetCustomer)
 		customerGroup.PUT("/:id", customerHandler.UpdateCustomer)
 		customerGroup.DELETE("/:id", customerHandler.DeleteCustomer)
-		customerGroup.GET("/:customer_id/cars", customerCarHandler.GetByCustomerID)
+		customerGroup.GET("/:id/cars", customerCarHandler.GetByCustomerID)
+		// Data subject requests also need the admin scope
+		customerGroup.GET("/:id/data-export", authenticator.Authorize("privacy"), privacyHandler.ExportCustomerData)
+		customerGroup.POST("/:id/erase", authenticator.Authorize("privacy"), privacyHandler.EraseCustomer)
 	}
 
-	supplierGroup := router.Group("/suppliers")
+	supplierGroup := router.Group("/suppliers", rateLimiter.Limit("suppliers"), authenticator.Authorize("suppliers"))
 	{
 		supplierGroup.POST("", supplierHandler.CreateSupplier)
+		supplierGroup.POST("/import", importHandler.ImportSuppliers)
+		supplierGroup.POST("/batch", batchHandler.BatchSuppliers)
 		supplierGroup.GET("", supplierHandler.GetAllSuppliers)
+		supplierGroup.GET("/export", supplierHandler.ExportSuppliers)
 		supplierGroup.GET("/:id", supplierHandler.GetSupplier)
 		supplierGroup.PUT("/:id", supplierHandler.UpdateSupplier)
 		supplierGroup.DELETE("/:id", supplierHandler.DeleteSupplier)
 	}
 
-	carGroup := router.Group("/cars")
+	carGroup := router.Group("/cars", rateLimiter.Limit("cars"), authenticator.Authorize("cars"))
 	{
 		carGroup.POST("", carHandler.CreateCar)
+		carGroup.POST("/import", importHandler.ImportCars)
+		carGroup.POST("/batch", batchHandler.BatchCars)
 		carGroup.GET("", carHandler.GetAllCars)
+		carGroup.GET("/export", carHandler.ExportCars)
+		carGroup.GET("/compare", carHandler.CompareCars)
 		carGroup.GET("/:id", carHandler.GetCar)
 		carGroup.PUT("/:id", carHandler.UpdateCar)
 		carGroup.DELETE("/:id", carHandler.DeleteCar)
-		carGroup.GET("/:car_id/customers", customerCarHandler.GetByCarID)
+		carGroup.GET("/:id/customers", customerCarHandler.GetByCarID)
+		carGroup.POST("/:id/media", mediaHandler.UploadMedia)
+		carGroup.GET("/:id/media", mediaHandler.GetCarMedia)
+		carGroup.DELETE("/:id/media/:media_id", mediaHandler.DeleteMedia)
+	}
+
+	catalogGroup := router.Group("/catalog", rateLimiter.Limit("catalog"), authenticator.Authorize("catalog"))
+	{
+		catalogGroup.GET("/schemas", catalogHandler.GetSchemas)
+		catalogGroup.POST("/brands", catalogHandler.CreateBrand)
+		catalogGroup.GET("/brands", catalogHandler.GetBrands)
+		catalogGroup.POST("/brands/:id/models", catalogHandler.CreateModel)
+		catalogGroup.GET("/brands/:id/models", catalogHandler.GetModels)
+		catalogGroup.POST("/models/:id/trims", catalogHandler.CreateTrim)
+		catalogGroup.GET("/models/:id/trims", catalogHandler.GetTrims)
 	}
 
-	customerCarGroup := router.Group("/customer-cars")
+	customerCarGroup := router.Group("/customer-cars", rateLimiter.Limit("customer-cars"), authenticator.Authorize("customer-cars"))
 	{
 		customerCarGroup.POST("", customerCarHandler.Create)
+		customerCarGroup.POST("/batch", batchHandler.BatchCustomerCars)
 		customerCarGroup.GET("", customerCarHandler.GetAll)
+		customerCarGroup.GET("/export", customerCarHandler.Export)
 		customerCarGroup.GET("/:id", customerCarHandler.GetByID)
 		customerCarGroup.PUT("/:id", customerCarHandler.Update)
 		customerCarGroup.DELETE("/:id", customerCarHandler.Delete)
+		customerCarGroup.POST("/:id/maintenance", maintenanceHandler.AddRecord)
+		customerCarGroup.GET("/:id/maintenance", maintenanceHandler.GetRecords)
+		customerCarGroup.GET("/:id/service-due", maintenanceHandler.GetServiceDue)
+	}
+
+	importGroup := router.Group("/imports", rateLimiter.Limit("imports"), authenticator.Authorize("imports"))
+	{
+		importGroup.GET("/:id", importHandler.GetJob)
+		importGroup.POST("/:id/resume", importHandler.ResumeJob)
+	}
+
+	webhookGroup := router.Group("/webhooks", rateLimiter.Limit("webhooks"), authenticator.Authorize("webhooks"))
+	{
+		webhookGroup.POST("", webhookHandler.CreateWebhook)
+		webhookGroup.GET("", webhookHandler.GetAllWebhooks)
+		webhookGroup.GET("/:id", webhookHandler.GetWebhook)
+		webhookGroup.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhookGroup.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhookGroup.GET("/:id/deliveries", webhookHandler.GetDeliveries)
+		webhookGroup.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+	}
+
+	eventGroup := router.Group("/events", rateLimiter.Limit("events"), authenticator.Authorize("events"))
+	{
+		eventGroup.GET("/stream", eventHandler.StreamEvents)
+	}
+
+	router.GET("/search", rateLimiter.Limit("search"), authenticator.Authorize("search"), searchHandler.Search)
+
+	graphQLGroup := router.Group("/graphql", rateLimiter.Limit("graphql"), authenticator.Authorize("graphql"))
+	{
+		graphQLGroup.POST("", graphQLHandler.Query)
+		graphQLGroup.GET("", graphQLHandler.Query)
+	}
+
+	apiKeyGroup := router.Group("/api-keys", rateLimiter.Limit("api-keys"), authenticator.Authorize("api-keys"))
+	{
+		apiKeyGroup.POST("", apiKeyHandler.CreateAPIKey)
+		apiKeyGroup.GET("", apiKeyHandler.GetAllAPIKeys)
+		apiKeyGroup.GET("/:id", apiKeyHandler.GetAPIKey)
+		apiKeyGroup.POST("/:id/rotate", apiKeyHandler.RotateAPIKey)
+		apiKeyGroup.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+	}
+
+	// Signing in needs no credentials; its rate limit slows down password guessing
+	authGroup := router.Group("/auth", rateLimiter.Limit("auth"))
+	{
+		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", authenticator.RequireUser(), authHandler.Logout)
+		authGroup.POST("/password", authenticator.RequireUser(), authHandler.ChangePassword)
 	}
 }
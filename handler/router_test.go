@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Gin panics when two routes of a group name a path segment differently
+	assert.NotPanics(t, func() {
+		InitRoutes(router.Group("/v1"), &CustomerHandler{}, &SupplierHandler{}, &CarHandler{}, &CustomerCarHandler{},
+			&ImportHandler{}, &BatchHandler{}, &WebhookHandler{}, &EventHandler{}, &GraphQLHandler{}, &SearchHandler{}, &APIKeyHandler{}, &AuthHandler{}, &PrivacyHandler{}, &CatalogHandler{}, &MediaHandler{}, &MaintenanceHandler{}, nil, nil)
+	})
+
+	routes := map[string]bool{}
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	assert.True(t, routes["GET /v1/customers/:id/cars"])
+	assert.True(t, routes["GET /v1/cars/:id/customers"])
+	assert.True(t, routes["GET /v1/search"])
+	assert.True(t, routes["POST /v1/graphql"])
+	assert.True(t, routes["POST /v1/api-keys/:id/rotate"])
+	assert.True(t, routes["POST /v1/auth/login"])
+	assert.True(t, routes["GET /v1/customers/:id/data-export"])
+	assert.True(t, routes["POST /v1/customers/:id/erase"])
+	assert.True(t, routes["GET /v1/cars/compare"])
+	assert.True(t, routes["POST /v1/catalog/brands/:id/models"])
+	assert.True(t, routes["GET /v1/catalog/models/:id/trims"])
+	assert.True(t, routes["POST /v1/cars/:id/media"])
+	assert.True(t, routes["DELETE /v1/cars/:id/media/:media_id"])
+	assert.True(t, routes["GET /v1/media/:id"])
+	assert.True(t, routes["POST /v1/customer-cars/:id/maintenance"])
+	assert.True(t, routes["GET /v1/customer-cars/:id/service-due"])
+}
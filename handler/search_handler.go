@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles HTTP requests searching across resources
+type SearchHandler struct {
+	searchUsecase usecase.SearchUsecase
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(uc usecase.SearchUsecase) *SearchHandler {
+	return &SearchHandler{searchUsecase: uc}
+}
+
+// Search godoc
+// @Summary Search customers, suppliers and cars
+// @Description Full-text search of customer names, of supplier names, phones, emails and addresses and of car names. Partial words, typos and missing Vietnamese diacritics still match. Results are ranked best first, with the matched words highlighted.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Words to search for" example:"ha noi"
+// @Param limit query int false "Maximum number of results, 1-100 (default 20)"
+// @Success 200 {array} model.SearchResult "Matching records, best first"
+// @Failure 400 {object} model.Problem "Missing or invalid query"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var query model.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, &query)
+		return
+	}
+
+	results, err := h.searchUsecase.WithTenant(tenantID(c)).Search(query)
+	if err != nil {
+		if errors.Is(err, usecase.ErrEmptySearch) {
+			respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+			return
+		}
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.search_failed"))
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchHandler_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockSearchUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	searchHandler := NewSearchHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/search", searchHandler.Search)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase.EXPECT().Search(model.SearchQuery{Q: "ha noi", Limit: 5}).Return([]model.SearchResult{
+			{Type: "supplier", ID: "supp1", Name: "Hà Nội Motors", Highlight: "<mark>Hà</mark> <mark>Nội</mark> Motors", Rank: 0.9},
+		}, nil)
+
+		w := get("/search?q=ha+noi&limit=5")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"type":"supplier","id":"supp1","name":"Hà Nội Motors",`+
+			`"highlight":"<mark>Hà</mark> <mark>Nội</mark> Motors","rank":0.9}]`, w.Body.String())
+	})
+
+	t.Run("MissingQuery", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/search").Code)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/search?q=an&limit=500").Code)
+	})
+
+	t.Run("NoTerms", func(t *testing.T) {
+		mockUsecase.EXPECT().Search(gomock.Any()).Return(nil, usecase.ErrEmptySearch)
+		w := get("/search?q=%25%25")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "letter or digit")
+	})
+
+	t.Run("UsecaseError", func(t *testing.T) {
+		mockUsecase.EXPECT().Search(gomock.Any()).Return(nil, errors.New("db down"))
+		assert.Equal(t, http.StatusInternalServerError, get("/search?q=camry").Code)
+	})
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	appErrors "github.com/GoodsChain/backend/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/GoodsChain/backend/usecase"
+	"github.com/GoodsChain/backend/i18n"
 	"github.com/GoodsChain/backend/model"
 	"net/http"
 	"github.com/google/uuid"
@@ -26,13 +28,13 @@ func NewSupplierHandler(supplierUsecase usecase.SupplierUsecase) *SupplierHandle
 // @Produce json
 // @Param supplier body model.Supplier true "Supplier object to be created"
 // @Success 201 {object} model.Supplier "Successfully created supplier"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /suppliers [post]
 func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
 	var supplier model.Supplier
 	if err := c.ShouldBindJSON(&supplier); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &supplier)
 		return
 	}
 
@@ -40,9 +42,12 @@ func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
 	if supplier.ID == "" {
 		supplier.ID = uuid.New().String()
 	}
+	if a := actor(c); a != "" {
+		supplier.CreatedBy, supplier.UpdatedBy = a, a
+	}
 
-	if err := h.supplierUsecase.CreateSupplier(&supplier); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.supplierUsecase.WithTenant(tenantID(c)).CreateSupplier(&supplier); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
@@ -51,22 +56,31 @@ func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
 
 // GetSupplier godoc
 // @Summary Get a supplier by ID
-// @Description Retrieves a supplier's details based on their unique ID.
+// @Description Retrieves a supplier's details based on their unique ID. Callers that may only read suppliers, such as viewers, receive masked emails and phones.
 // @Tags Suppliers
 // @Produce json
 // @Param id path string true "Supplier ID" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
 // @Success 200 {object} model.Supplier "Successfully retrieved supplier"
-// @Failure 404 {object} model.ErrorResponse "Supplier not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid fields"
+// @Failure 404 {object} model.Problem "Supplier not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /suppliers/{id} [get]
 func (h *SupplierHandler) GetSupplier(c *gin.Context) {
 	id := c.Param("id")
-	supplier, err := h.supplierUsecase.GetSupplier(id)
+	fields, ok := parseFields(c, model.Supplier{})
+	if !ok {
+		return
+	}
+	supplier, err := h.supplierUsecase.WithTenant(tenantID(c)).GetSupplier(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, model.ErrorResponse{Code: "not_found", Message: "Supplier not found"})
+		respondNotFound(c, "Supplier")
 		return
 	}
-	c.JSON(http.StatusOK, supplier)
+	if masksContacts(c, "suppliers") {
+		supplier.MaskContact()
+	}
+	respondFields(c, supplier, fields, nil)
 }
 
 // UpdateSupplier godoc
@@ -78,20 +92,23 @@ func (h *SupplierHandler) GetSupplier(c *gin.Context) {
 // @Param id path string true "Supplier ID" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8"
 // @Param supplier body model.Supplier true "Supplier object with updated details"
 // @Success 200 {object} model.SuccessResponse "Supplier updated successfully"
-// @Failure 400 {object} model.ErrorResponse "Invalid request payload"
-// @Failure 404 {object} model.ErrorResponse "Supplier not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 404 {object} model.Problem "Supplier not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /suppliers/{id} [put]
 func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
 	id := c.Param("id")
 	var supplier model.Supplier
 	if err := c.ShouldBindJSON(&supplier); err != nil {
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Code: "invalid_request", Message: err.Error()})
+		respondBindError(c, err, &supplier)
 		return
 	}
+	if a := actor(c); a != "" {
+		supplier.UpdatedBy = a
+	}
 
-	if err := h.supplierUsecase.UpdateSupplier(id, &supplier); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.supplierUsecase.WithTenant(tenantID(c)).UpdateSupplier(id, &supplier); err != nil {
+		respondInternal(c, err)
 		return
 	}
 
@@ -105,13 +122,13 @@ func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Supplier ID" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8"
 // @Success 200 {object} model.SuccessResponse "Supplier deleted successfully"
-// @Failure 404 {object} model.ErrorResponse "Supplier not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Failure 404 {object} model.Problem "Supplier not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /suppliers/{id} [delete]
 func (h *SupplierHandler) DeleteSupplier(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.supplierUsecase.DeleteSupplier(id); err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: err.Error()})
+	if err := h.supplierUsecase.WithTenant(tenantID(c)).DeleteSupplier(id); err != nil {
+		respondInternal(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Supplier deleted successfully"})
@@ -119,17 +136,77 @@ func (h *SupplierHandler) DeleteSupplier(c *gin.Context) {
 
 // GetAllSuppliers godoc
 // @Summary Get all suppliers
-// @Description Retrieves a list of all suppliers in the system.
+// @Description Retrieves a list of all suppliers in the system. Callers that may only read suppliers, such as viewers, receive masked emails and phones.
 // @Tags Suppliers
 // @Produce json
+// @Param q query string false "Full-text search of the name, phone, email and address, best matches first"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param email query string false "Exact email address, case-insensitive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Param fields query string false "Comma-separated fields to return, such as id,name; defaults to all"
 // @Success 200 {array} model.Supplier "Successfully retrieved list of suppliers"
-// @Failure 500 {object} model.ErrorResponse "Failed to retrieve suppliers"
+// @Failure 400 {object} model.Problem "Invalid filter or fields"
+// @Failure 500 {object} model.Problem "Failed to retrieve suppliers"
 // @Router /suppliers [get]
 func (h *SupplierHandler) GetAllSuppliers(c *gin.Context) {
-	suppliers, err := h.supplierUsecase.GetAllSuppliers()
+	var filter model.SupplierFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	fields, ok := parseFields(c, model.Supplier{})
+	if !ok {
+		return
+	}
+	filter.Fields = fields
+	suppliers, err := h.supplierUsecase.WithTenant(tenantID(c)).GetAllSuppliers(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Code: "internal_error", Message: "Failed to retrieve suppliers"})
+		respondMessage(c, http.StatusInternalServerError, appErrors.ErrInternal, i18n.M("error.retrieve_suppliers"))
+		return
+	}
+	if masksContacts(c, "suppliers") {
+		for _, supplier := range suppliers {
+			supplier.MaskContact()
+		}
+	}
+	respondFields(c, suppliers, fields, nil)
+}
+
+// ExportSuppliers godoc
+// @Summary Export suppliers
+// @Description Streams all suppliers matching the list filters as a CSV, NDJSON or XLSX download. Callers that may only read suppliers, such as viewers, receive masked emails and phones.
+// @Tags Suppliers
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format: csv (default), ndjson or xlsx"
+// @Param fields query string false "Comma-separated columns to export, in order; defaults to all"
+// @Param q query string false "Full-text search of the name, phone, email and address, best matches first"
+// @Param name query string false "Case-insensitive substring of the name"
+// @Param email query string false "Exact email address, case-insensitive"
+// @Param created_after query string false "Only records created at or after this time (RFC 3339)"
+// @Param created_before query string false "Only records created before this time (RFC 3339)"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} model.Problem "Invalid filter, format or fields"
+// @Failure 500 {object} model.Problem "Failed to export suppliers"
+// @Router /suppliers/export [get]
+func (h *SupplierHandler) ExportSuppliers(c *gin.Context) {
+	var filter model.SupplierFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+	fields, ok := parseFields(c, model.Supplier{})
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, suppliers)
+	filter.Fields = fields
+	mask := masksContacts(c, "suppliers")
+	streamExport(c, "suppliers", model.Supplier{}, fields, func(write func(record interface{}) error) error {
+		return h.supplierUsecase.WithTenant(tenantID(c)).StreamSuppliers(filter, func(supplier *model.Supplier) error {
+			if mask {
+				supplier.MaskContact()
+			}
+			return write(supplier)
+		})
+	})
 }
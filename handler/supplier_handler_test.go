@@ -9,7 +9,7 @@ import (
 	"testing"
 
 	"github.com/GoodsChain/backend/model"
-	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/mock/usecasemock"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +24,7 @@ func TestCreateSupplier(t *testing.T) {
 	tests := []struct {
 		name           string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockSupplierUsecase)
+		mockSetup      func(*usecasemock.MockSupplierUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -35,7 +35,7 @@ func TestCreateSupplier(t *testing.T) {
 				"email":   "supplier@example.com",
 				"address": "456 Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					CreateSupplier(gomock.Any()).
 					DoAndReturn(func(supplier *model.Supplier) error {
@@ -50,7 +50,7 @@ func TestCreateSupplier(t *testing.T) {
 				"email":   "supplier@example.com",
 				"address": "456 Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -61,7 +61,7 @@ func TestCreateSupplier(t *testing.T) {
 				"name":    "Test Supplier",
 				"address": "456 Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -72,7 +72,7 @@ func TestCreateSupplier(t *testing.T) {
 				"name":  "Test Supplier",
 				"email": "supplier@example.com",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -84,7 +84,7 @@ func TestCreateSupplier(t *testing.T) {
 				"email":   "not-a-valid-email",
 				"address": "456 Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				// No mock calls expected
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -96,15 +96,15 @@ func TestCreateSupplier(t *testing.T) {
 				"email":   "supplier@example.com",
 				"address": "456 Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					CreateSupplier(gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -116,7 +116,8 @@ func TestCreateSupplier(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockSupplierUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
@@ -149,7 +150,7 @@ func TestCreateSupplier(t *testing.T) {
 
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -163,8 +164,8 @@ func TestCreateSupplier(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -185,14 +186,14 @@ func TestGetSupplier(t *testing.T) {
 	tests := []struct {
 		name           string
 		supplierID     string
-		mockSetup      func(*mock.MockSupplierUsecase)
+		mockSetup      func(*usecasemock.MockSupplierUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:       "Success",
 			supplierID: supplier.ID,
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					GetSupplier(supplier.ID).
 					Return(supplier, nil)
@@ -202,15 +203,15 @@ func TestGetSupplier(t *testing.T) {
 		{
 			name:       "Not Found",
 			supplierID: "non-existent-id",
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					GetSupplier("non-existent-id").
 					Return(nil, errors.New("not found"))
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: gin.H{
-				"code": "not_found",
-				"message": "Supplier not found",
+				"code": "NOT_FOUND",
+				"detail": "Supplier not found",
 			},
 		},
 	}
@@ -222,7 +223,8 @@ func TestGetSupplier(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockSupplierUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
@@ -272,7 +274,7 @@ func TestUpdateSupplier(t *testing.T) {
 		name           string
 		supplierID     string
 		reqBody        map[string]interface{}
-		mockSetup      func(*mock.MockSupplierUsecase)
+		mockSetup      func(*usecasemock.MockSupplierUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
@@ -284,7 +286,7 @@ func TestUpdateSupplier(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "789 New Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					UpdateSupplier(gomock.Eq("supplier-id"), gomock.Any()).
 					Return(nil)
@@ -301,7 +303,7 @@ func TestUpdateSupplier(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "789 New Supplier Ave",
 			},
-			mockSetup:      func(mockUsecase *mock.MockSupplierUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockSupplierUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -312,7 +314,7 @@ func TestUpdateSupplier(t *testing.T) {
 				"email":   "invalid-email-format",
 				"address": "789 New Supplier Ave",
 			},
-			mockSetup:      func(mockUsecase *mock.MockSupplierUsecase) {},
+			mockSetup:      func(mockUsecase *usecasemock.MockSupplierUsecase) {},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -323,15 +325,15 @@ func TestUpdateSupplier(t *testing.T) {
 				"email":   "updated@example.com",
 				"address": "789 New Supplier Ave",
 			},
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					UpdateSupplier(gomock.Eq("supplier-id"), gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -343,7 +345,8 @@ func TestUpdateSupplier(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockSupplierUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
@@ -375,7 +378,7 @@ func TestUpdateSupplier(t *testing.T) {
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				if tt.expectedStatus == http.StatusBadRequest {
 					assert.Contains(t, gotBody, "code", "Code key missing for BadRequest")
-					assert.Contains(t, gotBody, "message", "Message key missing for BadRequest")
+					assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest")
 				} else {
 					expectedBodyBytes, _ := json.Marshal(tt.expectedBody)
 					var expectedBodyMap map[string]interface{}
@@ -388,8 +391,8 @@ func TestUpdateSupplier(t *testing.T) {
 				var gotBody map[string]interface{}
 				json.Unmarshal(w.Body.Bytes(), &gotBody)
 				assert.Contains(t, gotBody, "code", "Code key missing for BadRequest without specific body")
-				assert.Contains(t, gotBody, "message", "Message key missing for BadRequest without specific body")
-				assert.NotEmpty(t, gotBody["message"], "Error message should not be empty for BadRequest")
+				assert.Contains(t, gotBody, "detail", "Detail key missing for BadRequest without specific body")
+				assert.NotEmpty(t, gotBody["detail"], "Error message should not be empty for BadRequest")
 			}
 		})
 	}
@@ -403,14 +406,14 @@ func TestDeleteSupplier(t *testing.T) {
 	tests := []struct {
 		name           string
 		supplierID     string
-		mockSetup      func(*mock.MockSupplierUsecase)
+		mockSetup      func(*usecasemock.MockSupplierUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name:       "Success",
 			supplierID: "supplier-id",
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					DeleteSupplier(gomock.Eq("supplier-id")).
 					Return(nil)
@@ -423,15 +426,15 @@ func TestDeleteSupplier(t *testing.T) {
 		{
 			name:       "Usecase Error",
 			supplierID: "supplier-id",
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
 					DeleteSupplier(gomock.Eq("supplier-id")).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "database error",
+				"code": "INTERNAL_ERROR",
+				"detail": "Internal server error",
 			},
 		},
 	}
@@ -443,7 +446,8 @@ func TestDeleteSupplier(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockSupplierUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
@@ -503,30 +507,30 @@ func TestGetAllSuppliers(t *testing.T) {
 	// Test cases
 	tests := []struct {
 		name           string
-		mockSetup      func(*mock.MockSupplierUsecase)
+		mockSetup      func(*usecasemock.MockSupplierUsecase)
 		expectedStatus int
 		expectedBody   interface{}
 	}{
 		{
 			name: "Success",
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
-					GetAllSuppliers().
+					GetAllSuppliers(model.SupplierFilter{}).
 					Return(suppliers, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Usecase Error",
-			mockSetup: func(mockUsecase *mock.MockSupplierUsecase) {
+			mockSetup: func(mockUsecase *usecasemock.MockSupplierUsecase) {
 				mockUsecase.EXPECT().
-					GetAllSuppliers().
+					GetAllSuppliers(model.SupplierFilter{}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: gin.H{
-				"code": "internal_error",
-				"message": "Failed to retrieve suppliers",
+				"code": "INTERNAL_ERROR",
+				"detail": "Failed to retrieve suppliers",
 			},
 		},
 	}
@@ -538,7 +542,8 @@ func TestGetAllSuppliers(t *testing.T) {
 			defer ctrl.Finish()
 
 			// Create mock usecase
-			mockUsecase := mock.NewMockSupplierUsecase(ctrl)
+			mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+			mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
 			if tt.mockSetup != nil {
 				tt.mockSetup(mockUsecase)
 			}
@@ -578,3 +583,38 @@ func TestGetAllSuppliers(t *testing.T) {
 		})
 	}
 }
+
+func TestSupplierHandler_MaskContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockSupplierUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	supplierHandler := NewSupplierHandler(mockUsecase)
+	supplier := func() *model.Supplier {
+		return &model.Supplier{ID: "supp1", Name: "Supplier Inc.", Phone: "555-987-6543", Email: "contact@supplierinc.com"}
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/suppliers/:id", func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalUser, ID: "viewer", Scopes: []string{model.ScopeRead}})
+	}, supplierHandler.GetSupplier)
+	router.GET("/suppliers", func(c *gin.Context) {
+		c.Set(ContextPrincipal, &model.Principal{Type: model.PrincipalAPIKey, ID: "key", Scopes: []string{"suppliers:write"}})
+	}, supplierHandler.GetAllSuppliers)
+
+	// Viewers see masked contacts, writers see them in full
+	mockUsecase.EXPECT().GetSupplier("supp1").Return(supplier(), nil)
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/suppliers/supp1", nil)
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"email":"c***@supplierinc.com"`)
+	assert.Contains(t, rr.Body.String(), `"phone":"555-***-6543"`)
+
+	mockUsecase.EXPECT().GetAllSuppliers(gomock.Any()).Return([]*model.Supplier{supplier()}, nil)
+	rr = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/suppliers", nil)
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"email":"contact@supplierinc.com"`)
+}
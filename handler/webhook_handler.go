@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(uc usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{webhookUsecase: uc}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Registers an endpoint for the given event types. Each delivery is a JSON POST signed with HMAC-SHA256 over "<timestamp>.<body>" in the X-GoodsChain-Signature header. The secret is generated when omitted and only returned in this response.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body model.WebhookRequest true "Subscription; event_types are any of customer.created, customer.updated, customer.deleted, supplier.created, supplier.updated, supplier.deleted, car.created, car.updated, car.deleted, car.price_changed, customer_car.created, customer_car.updated and customer_car.deleted"
+// @Success 201 {object} model.WebhookSubscription "Successfully created subscription, including its secret"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+	req.Actor = actor(c)
+
+	sub, err := h.webhookUsecase.WithTenant(tenantID(c)).CreateWebhook(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, sub)
+}
+
+// GetAllWebhooks godoc
+// @Summary Get all webhook subscriptions
+// @Description Retrieves all webhook subscriptions. Secrets are not included.
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} model.WebhookSubscription "Successfully retrieved subscriptions"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetAllWebhooks(c *gin.Context) {
+	subs, err := h.webhookUsecase.WithTenant(tenantID(c)).GetAllWebhooks()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, subs)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook subscription by ID
+// @Description Retrieves a webhook subscription. The secret is not included.
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} model.WebhookSubscription "Successfully retrieved subscription"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	sub, err := h.webhookUsecase.WithTenant(tenantID(c)).GetWebhook(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Replaces the URL, event types and description of a subscription. A non-empty secret rotates the signing secret; an omitted active flag keeps the current value.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param webhook body model.WebhookRequest true "Subscription"
+// @Success 200 {object} model.WebhookSubscription "Successfully updated subscription"
+// @Failure 400 {object} model.Problem "Invalid request payload"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, &req)
+		return
+	}
+	req.Actor = actor(c)
+
+	sub, err := h.webhookUsecase.WithTenant(tenantID(c)).UpdateWebhook(c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Deletes a subscription together with its delivery log. Pending deliveries are dropped.
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} model.SuccessResponse "Webhook deleted successfully"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhookUsecase.WithTenant(tenantID(c)).DeleteWebhook(c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Webhook deleted successfully"})
+}
+
+// GetDeliveries godoc
+// @Summary Get the delivery log of a webhook subscription
+// @Description Lists the most recent deliveries with their attempts, last response and status. Dead deliveries exhausted their retries.
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param status query string false "Only deliveries with this status: pending, delivered or dead"
+// @Param limit query int false "Maximum number of deliveries, 1-200 (default 50)"
+// @Success 200 {array} model.WebhookDelivery "Deliveries, newest first"
+// @Failure 400 {object} model.Problem "Invalid filter"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	var filter model.DeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondBindError(c, err, &filter)
+		return
+	}
+
+	deliveries, err := h.webhookUsecase.WithTenant(tenantID(c)).GetDeliveries(c.Param("id"), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook event
+// @Description Queues the payload of a logged delivery to be sent again with a fresh retry budget. The original delivery stays in the log; the new one shares its event ID.
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 202 {object} model.WebhookDelivery "Redelivery queued"
+// @Failure 404 {object} model.Problem "Delivery not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	delivery, err := h.webhookUsecase.WithTenant(tenantID(c)).Redeliver(c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			respondNotFound(c, "Delivery")
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondNotFound(c, "Webhook")
+	case errors.Is(err, usecase.ErrInvalidWebhookURL):
+		respondProblem(c, http.StatusBadRequest, appErrors.ErrInvalid, err.Error())
+	default:
+		respondInternal(c, err)
+	}
+}
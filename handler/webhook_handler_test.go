@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock/usecasemock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupWebhookRouter(t *testing.T) (*gin.Engine, *usecasemock.MockWebhookUsecase) {
+	ctrl := gomock.NewController(t)
+	mockUsecase := usecasemock.NewMockWebhookUsecase(ctrl)
+	mockUsecase.EXPECT().WithTenant(model.DefaultTenantID).Return(mockUsecase).AnyTimes()
+	webhookHandler := NewWebhookHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/webhooks", webhookHandler.CreateWebhook)
+	router.GET("/webhooks/:id", webhookHandler.GetWebhook)
+	router.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+	router.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+	return router, mockUsecase
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	router, mockUsecase := setupWebhookRouter(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func()
+		wantStatus int
+	}{
+		{
+			name: "Success",
+			body: `{"url":"https://example.com/hook","event_types":["car.price_changed"]}`,
+			setup: func() {
+				mockUsecase.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(req *model.WebhookRequest) (*model.WebhookSubscription, error) {
+					assert.Equal(t, []string{"car.price_changed"}, req.EventTypes)
+					return &model.WebhookSubscription{ID: "sub1", URL: req.URL, Secret: "whsec_x"}, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{name: "UnknownEventType", body: `{"url":"https://example.com/hook","event_types":["car.sold"]}`, wantStatus: http.StatusBadRequest},
+		{name: "MissingEventTypes", body: `{"url":"https://example.com/hook"}`, wantStatus: http.StatusBadRequest},
+		{name: "ShortSecret", body: `{"url":"https://example.com/hook","event_types":["car.price_changed"],"secret":"abc"}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "InvalidScheme",
+			body: `{"url":"ftp://example.com/hook","event_types":["car.price_changed"]}`,
+			setup: func() {
+				mockUsecase.EXPECT().CreateWebhook(gomock.Any()).Return(nil, usecase.ErrInvalidWebhookURL)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_GetWebhook(t *testing.T) {
+	router, mockUsecase := setupWebhookRouter(t)
+
+	mockUsecase.EXPECT().GetWebhook("missing").Return(nil, repository.ErrNotFound)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks/missing", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockUsecase.EXPECT().DeleteWebhook("sub1").Return(errors.New("db error"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/webhooks/sub1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	router, mockUsecase := setupWebhookRouter(t)
+
+	mockUsecase.EXPECT().GetDeliveries("sub1", model.DeliveryFilter{Status: "dead", Limit: 10}).
+		Return([]model.WebhookDelivery{{ID: "d1", Status: "dead", Attempts: 8}}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks/sub1/deliveries?status=dead&limit=10", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var deliveries []model.WebhookDelivery
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+	assert.Equal(t, 8, deliveries[0].Attempts)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks/sub1/deliveries?status=lost", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookHandler_Redeliver(t *testing.T) {
+	router, mockUsecase := setupWebhookRouter(t)
+
+	mockUsecase.EXPECT().Redeliver("sub1", "d1").Return(&model.WebhookDelivery{ID: "d2", EventID: "e1", Status: "pending"}, nil)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks/sub1/deliveries/d1/redeliver", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	mockUsecase.EXPECT().Redeliver("sub1", "missing").Return(nil, repository.ErrNotFound)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/webhooks/sub1/deliveries/missing/redeliver", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "Delivery not found")
+}
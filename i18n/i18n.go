@@ -0,0 +1,138 @@
+// Package i18n holds the message catalog of the API and selects a language
+// from the Accept-Language header. Messages are identified by keys that are
+// the same in every language, so clients can also localize them themselves.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Supported languages
+const (
+	English    = "en"
+	Vietnamese = "vi"
+)
+
+// Default is the language of messages when the client accepts none of the
+// supported languages, and the fallback for keys missing from a catalog
+const Default = English
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs maps a language to its templates by message key
+var catalogs = mustLoad()
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Vietnamese})
+
+func mustLoad() map[string]map[string]string {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := make(map[string]map[string]string, len(files))
+	for _, f := range files {
+		data, err := localeFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", f.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(f.Name(), ".json")] = catalog
+	}
+	return catalogs
+}
+
+// Match returns the supported language that best matches an Accept-Language
+// header, or Default
+func Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+	return []string{English, Vietnamese}[index]
+}
+
+// Message is a localizable message: a key of the catalog and the values of
+// the {name} placeholders of its templates. A parameter may itself be a
+// Message, such as the name of a resource.
+type Message struct {
+	Key    string                 `json:"key" example:"error.not_found" description:"Message key, the same in every language"`
+	Params map[string]interface{} `json:"params,omitempty" swaggertype:"object" description:"Values of the placeholders of the message"`
+	// Fallback is the text used when no catalog has the key
+	Fallback string `json:"-"`
+}
+
+// M returns the message with key and params given as name, value pairs
+func M(key string, params ...interface{}) Message {
+	m := Message{Key: key}
+	if len(params) > 0 {
+		m.Params = make(map[string]interface{}, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			m.Params[fmt.Sprint(params[i])] = params[i+1]
+		}
+	}
+	return m
+}
+
+// Resource returns the localizable name of a resource such as "Customer car",
+// falling back to the name itself
+func Resource(name string) Message {
+	key := "resource." + strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+	return Message{Key: key, Fallback: name}
+}
+
+// Has reports whether the default catalog has key
+func Has(key string) bool {
+	_, ok := catalogs[Default][key]
+	return ok
+}
+
+// IsZero reports whether m has no key
+func (m Message) IsZero() bool {
+	return m.Key == ""
+}
+
+// Localize renders the message in lang, falling back to Default
+func (m Message) Localize(lang string) string {
+	template, ok := catalogs[lang][m.Key]
+	if !ok {
+		template, ok = catalogs[Default][m.Key]
+	}
+	if !ok {
+		if m.Fallback != "" {
+			return m.Fallback
+		}
+		template = m.Key
+	}
+	if len(m.Params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, 2*len(m.Params))
+	for name, value := range m.Params {
+		text := fmt.Sprint(value)
+		if nested, ok := value.(Message); ok {
+			text = nested.Localize(lang)
+		}
+		replacements = append(replacements, "{"+name+"}", text)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// String renders the message in Default
+func (m Message) String() string {
+	return m.Localize(Default)
+}
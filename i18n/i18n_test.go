@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogs_SameKeys(t *testing.T) {
+	keys := func(lang string) []string {
+		var keys []string
+		for key := range catalogs[lang] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	assert.NotEmpty(t, keys(English))
+	assert.Equal(t, keys(English), keys(Vietnamese))
+}
+
+func TestMatch(t *testing.T) {
+	tests := map[string]string{
+		"":                         English,
+		"vi":                       Vietnamese,
+		"vi-VN,vi;q=0.9,en;q=0.8":  Vietnamese,
+		"en-US,en;q=0.9,vi;q=0.5":  English,
+		"fr-FR":                    English,
+		"fr-FR,vi;q=0.5":           Vietnamese,
+		"not a language header!!!": English,
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, Match(header), header)
+	}
+}
+
+func TestMessage_Localize(t *testing.T) {
+	m := M("error.not_found", "resource", Resource("Customer car"), "id", 42)
+	assert.Equal(t, "Customer car with ID '42' not found", m.Localize(English))
+	assert.Equal(t, "Không tìm thấy xe của khách hàng có ID '42'", m.Localize(Vietnamese))
+	assert.Equal(t, m.Localize(English), m.String())
+
+	// Unknown resources and keys fall back to their name and key
+	assert.Equal(t, "Không tìm thấy Invoice có ID '1'", M("error.not_found", "resource", Resource("Invoice"), "id", 1).Localize(Vietnamese))
+	assert.Equal(t, "error.unknown", M("error.unknown").Localize(Vietnamese))
+	// Languages without a catalog use the default one
+	assert.Equal(t, "Resource not found", M("error.route_not_found").Localize("fr"))
+}
@@ -0,0 +1,172 @@
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/validation"
+)
+
+// Resource describes an importable entity
+type Resource struct {
+	// Name is the resource name used in URLs, e.g. "cars"
+	Name string
+	// Fields are the JSON field names that can be mapped from columns
+	Fields []string
+	// newRecord returns a pointer to a new, empty model
+	newRecord func() interface{}
+}
+
+// Importable resources keyed by name
+var resources = map[string]Resource{
+	"customers": {
+		Name:      "customers",
+		Fields:    []string{"id", "name", "address", "phone", "email"},
+		newRecord: func() interface{} { return &model.Customer{} },
+	},
+	"suppliers": {
+		Name:      "suppliers",
+		Fields:    []string{"id", "name", "address", "phone", "email"},
+		newRecord: func() interface{} { return &model.Supplier{} },
+	},
+	"cars": {
+		Name:      "cars",
+		Fields:    []string{"id", "name", "supplier_id", "price"},
+		newRecord: func() interface{} { return &model.Car{} },
+	},
+}
+
+// LookupResource returns the importable resource with the given name
+func LookupResource(name string) (Resource, bool) {
+	r, ok := resources[name]
+	return r, ok
+}
+
+// Mapping maps a model JSON field name to the column header supplying it
+type Mapping map[string]string
+
+// Record is a row converted to a model, e.g. *model.Car
+type Record struct {
+	Row   int
+	Value interface{}
+}
+
+// Build converts the table rows into records using the mapping. Fields
+// missing from the mapping are matched to a column with the same name. Rows
+// that fail conversion or validation are reported as row errors; an error is
+// returned only when the mapping itself is unusable.
+func (res Resource) Build(table *Table, mapping Mapping) ([]Record, []model.ImportRowError, error) {
+	columns, err := res.resolveColumns(table.Headers, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []Record
+	var rowErrors []model.ImportRowError
+	for i, row := range table.Rows {
+		rowNum := i + 2 // 1-based, after the header row
+		record := res.newRecord()
+		v := reflect.ValueOf(record).Elem()
+
+		var errs []model.ImportRowError
+		typeErrors := make(map[string]bool)
+		for field, col := range columns {
+			value := ""
+			if col < len(row) {
+				value = strings.TrimSpace(row[col])
+			}
+			if err := setField(v, field, value); err != nil {
+				typeErrors[field] = true
+				errs = append(errs, model.ImportRowError{Row: rowNum, Field: field, Rule: "type", Message: err.Error()})
+			}
+		}
+		for _, fe := range validation.Struct(record) {
+			// A value that could not be converted also fails its rules; report it once
+			if typeErrors[fe.Field] {
+				continue
+			}
+			errs = append(errs, model.ImportRowError{Row: rowNum, Field: fe.Field, Rule: fe.Rule, Message: fe.Message})
+		}
+
+		if len(errs) > 0 {
+			sort.SliceStable(errs, func(a, b int) bool { return errs[a].Field < errs[b].Field })
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		records = append(records, Record{Row: rowNum, Value: record})
+	}
+	return records, rowErrors, nil
+}
+
+// resolveColumns maps each importable field to a column index
+func (res Resource) resolveColumns(headers []string, mapping Mapping) (map[string]int, error) {
+	index := make(map[string]int, len(headers))
+	for i, h := range headers {
+		index[normalizeHeader(h)] = i
+	}
+
+	allowed := make(map[string]bool, len(res.Fields))
+	for _, f := range res.Fields {
+		allowed[f] = true
+	}
+	for field := range mapping {
+		if !allowed[field] {
+			return nil, fmt.Errorf("unknown field %q in mapping, expected one of: %s", field, strings.Join(res.Fields, ", "))
+		}
+	}
+
+	columns := make(map[string]int)
+	for _, field := range res.Fields {
+		header, mapped := mapping[field]
+		if !mapped {
+			header = field
+		}
+		col, ok := index[normalizeHeader(header)]
+		if !ok {
+			if mapped {
+				return nil, fmt.Errorf("column %q mapped to field %q not found", header, field)
+			}
+			continue
+		}
+		columns[field] = col
+	}
+	return columns, nil
+}
+
+// normalizeHeader makes header matching case- and separator-insensitive
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+}
+
+// setField assigns a cell value to the struct field with the given JSON name
+func setField(v reflect.Value, jsonName, value string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] != jsonName {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(value)
+		case reflect.Int, reflect.Int64:
+			if value == "" {
+				return nil
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s must be a whole number", jsonName)
+			}
+			f.SetInt(n)
+		default:
+			return fmt.Errorf("%s cannot be imported", jsonName)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown field %s", jsonName)
+}
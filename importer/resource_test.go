@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Build(t *testing.T) {
+	cars, ok := LookupResource("cars")
+	require.True(t, ok)
+
+	table := &Table{
+		Headers: []string{"Tên xe", "Supplier ID", "Giá"},
+		Rows: [][]string{
+			{"Camry", "supp1", "25000"},
+			{"", "supp1", "abc"},
+			{"Civic", "supp2", "-5"},
+		},
+	}
+	mapping := Mapping{"name": "Tên xe", "price": "Giá"}
+
+	records, rowErrors, err := cars.Build(table, mapping)
+	require.NoError(t, err)
+
+	require.Len(t, records, 1)
+	assert.Equal(t, 2, records[0].Row)
+	assert.Equal(t, &model.Car{Name: "Camry", SupplierID: "supp1", Price: 25000}, records[0].Value)
+
+	assert.Equal(t, []model.ImportRowError{
+		{Row: 3, Field: "name", Rule: "required", Message: "name is required"},
+		{Row: 3, Field: "price", Rule: "type", Message: "price must be a whole number"},
+		{Row: 4, Field: "price", Rule: "gt", Message: "price must be greater than 0"},
+	}, rowErrors)
+}
+
+func TestResource_BuildMappingErrors(t *testing.T) {
+	customers, ok := LookupResource("customers")
+	require.True(t, ok)
+	table := &Table{Headers: []string{"name", "email"}}
+
+	_, _, err := customers.Build(table, Mapping{"price": "name"})
+	assert.EqualError(t, err, `unknown field "price" in mapping, expected one of: id, name, address, phone, email`)
+
+	_, _, err = customers.Build(table, Mapping{"address": "Địa chỉ"})
+	assert.EqualError(t, err, `column "Địa chỉ" mapped to field "address" not found`)
+
+	_, ok = LookupResource("customer-cars")
+	assert.False(t, ok)
+}
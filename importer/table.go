@@ -0,0 +1,149 @@
+// Package importer parses CSV and XLSX spreadsheets into model records using
+// a column mapping, validating every row with the models' binding rules.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is the file format of an uploaded spreadsheet
+type Format string
+
+// Supported spreadsheet formats
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected csv or xlsx")
+
+// ErrEmptyFile is returned when a spreadsheet has no header row
+var ErrEmptyFile = errors.New("file has no header row")
+
+// Table is a parsed spreadsheet: a header row followed by data rows
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// DetectFormat determines the format from an explicit name, the file name
+// extension or, as a last resort, the content (XLSX files are ZIP archives)
+func DetectFormat(name, filename string, content []byte) (Format, error) {
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Ext(filename), ".")
+	}
+	switch strings.ToLower(name) {
+	case "csv":
+		return FormatCSV, nil
+	case "xlsx":
+		return FormatXLSX, nil
+	case "":
+		if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+			return FormatXLSX, nil
+		}
+		return FormatCSV, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Parse reads a CSV or XLSX file. For XLSX files sheet selects the worksheet;
+// the first sheet is used when it is empty.
+func Parse(content []byte, format Format, sheet string) (*Table, error) {
+	var records [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		records, err = parseCSV(content)
+	case FormatXLSX:
+		records, err = parseXLSX(content, sheet)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop fully blank rows, which spreadsheets commonly leave at the end
+	var rows [][]string
+	for _, record := range records {
+		if !isBlank(record) {
+			rows = append(rows, record)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	headers := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		headers[i] = strings.TrimSpace(h)
+	}
+	return &Table{Headers: headers, Rows: rows[1:]}, nil
+}
+
+func parseCSV(content []byte) ([][]string, error) {
+	// Strip the UTF-8 byte order mark Excel adds when saving as CSV
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return records, nil
+}
+
+func parseXLSX(content []byte, sheet string) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer f.Close()
+
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	if idx, err := f.GetSheetIndex(sheet); err != nil || idx < 0 {
+		return nil, fmt.Errorf("sheet %q not found", sheet)
+	}
+
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer rows.Close()
+
+	var records [][]string
+	for rows.Next() {
+		cols, err := rows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("invalid XLSX: %w", err)
+		}
+		records = append(records, cols)
+	}
+	if err := rows.Error(); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	return records, nil
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name, format, filename string
+		content                []byte
+		want                   Format
+		wantErr                bool
+	}{
+		{name: "explicit format wins", format: "XLSX", filename: "cars.csv", want: FormatXLSX},
+		{name: "from extension", filename: "cars.csv", want: FormatCSV},
+		{name: "sniff zip content", content: []byte("PK\x03\x04rest"), want: FormatXLSX},
+		{name: "default to csv", content: []byte("id,name"), want: FormatCSV},
+		{name: "unsupported", filename: "cars.pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat(tt.format, tt.filename, tt.content)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_CSV(t *testing.T) {
+	content := []byte("\xef\xbb\xbfname, price\nCamry,25000\n,\nCivic,22000\n\n")
+
+	table, err := Parse(content, FormatCSV, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "price"}, table.Headers)
+	assert.Equal(t, [][]string{{"Camry", "25000"}, {"Civic", "22000"}}, table.Rows)
+
+	_, err = Parse([]byte("\n\n"), FormatCSV, "")
+	assert.ErrorIs(t, err, ErrEmptyFile)
+
+	_, err = Parse([]byte("a,\"b\n"), FormatCSV, "")
+	assert.Error(t, err)
+}
+
+func TestParse_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	_, err := f.NewSheet("Cars")
+	require.NoError(t, err)
+	require.NoError(t, f.SetSheetRow("Cars", "A1", &[]interface{}{"name", "price"}))
+	require.NoError(t, f.SetSheetRow("Cars", "A2", &[]interface{}{"Camry", 25000}))
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+
+	table, err := Parse(buf.Bytes(), FormatXLSX, "Cars")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "price"}, table.Headers)
+	assert.Equal(t, [][]string{{"Camry", "25000"}}, table.Rows)
+
+	_, err = Parse(buf.Bytes(), FormatXLSX, "Missing")
+	assert.EqualError(t, err, `sheet "Missing" not found`)
+
+	_, err = Parse([]byte("not a zip"), FormatXLSX, "")
+	assert.Error(t, err)
+}
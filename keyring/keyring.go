@@ -0,0 +1,307 @@
+// Package keyring encrypts record fields at rest with AES-256-GCM envelope
+// encryption and computes blind indexes to look encrypted fields up by
+// equality.
+//
+// Every value is encrypted with its own random data key, which is in turn
+// encrypted with a key of the keyring. The stored value names the version of
+// that key, so values encrypted with earlier keys remain readable while they
+// are re-encrypted with the primary key. Values are bound to the context they
+// are stored in, such as a column of a record, so that a value copied to
+// another column or record fails to decrypt.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// keySize is the length of keys and data keys, selecting AES-256
+const keySize = 32
+
+// prefix starts every encrypted value: enc:v<version>:<data key>:<ciphertext>.
+// Values without it were stored before encryption and are read as they are.
+const prefix = "enc:"
+
+var (
+	// ErrUnknownKey is returned for values encrypted with a key version the
+	// keyring does not have
+	ErrUnknownKey = errors.New("value is encrypted with an unknown key version")
+	// ErrMalformed is returned for encrypted values that cannot be parsed or
+	// fail authentication
+	ErrMalformed = errors.New("malformed encrypted value")
+)
+
+// file is the JSON layout of a keyring file. Keys are base64 encoded and
+// named by their version.
+type file struct {
+	Primary  int               `json:"primary"`
+	Keys     map[string]string `json:"keys"`
+	IndexKey string            `json:"index_key"`
+}
+
+// Keyring holds the versioned keys that encrypt values and the key of their
+// blind indexes. The index key never changes, so rotation keeps indexes valid.
+type Keyring struct {
+	primary  int
+	keys     map[int][]byte
+	aeads    map[int]cipher.AEAD
+	indexKey []byte
+}
+
+// Generate returns a new keyring with a random key of version 1 and a random
+// index key
+func Generate() (*Keyring, error) {
+	indexKey, err := randomBytes(keySize)
+	if err != nil {
+		return nil, err
+	}
+	k := &Keyring{keys: map[int][]byte{}, aeads: map[int]cipher.AEAD{}, indexKey: indexKey}
+	if err := k.AddKey(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Load reads a keyring file
+func Load(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("keyring %s: %w", path, err)
+	}
+
+	k := &Keyring{primary: f.Primary, keys: map[int][]byte{}, aeads: map[int]cipher.AEAD{}}
+	if k.indexKey, err = decodeKey(f.IndexKey); err != nil {
+		return nil, fmt.Errorf("keyring %s: index key: %w", path, err)
+	}
+	for name, encoded := range f.Keys {
+		version, err := strconv.Atoi(name)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("keyring %s: invalid key version %q", path, name)
+		}
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring %s: key %d: %w", path, version, err)
+		}
+		if err := k.add(version, key); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := k.aeads[k.primary]; !ok {
+		return nil, fmt.Errorf("keyring %s: primary key %d is missing", path, k.primary)
+	}
+	return k, nil
+}
+
+// Save writes the keyring to path, readable by its owner only
+func (k *Keyring) Save(path string) error {
+	f := file{Primary: k.primary, Keys: map[string]string{}, IndexKey: base64.StdEncoding.EncodeToString(k.indexKey)}
+	for version, key := range k.keys {
+		f.Keys[strconv.Itoa(version)] = base64.StdEncoding.EncodeToString(key)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// AddKey adds a random key with the next version and makes it the primary
+// key. Values encrypted with the previous keys stay readable.
+func (k *Keyring) AddKey() error {
+	key, err := randomBytes(keySize)
+	if err != nil {
+		return err
+	}
+	version := 1
+	for v := range k.keys {
+		if v >= version {
+			version = v + 1
+		}
+	}
+	if err := k.add(version, key); err != nil {
+		return err
+	}
+	k.primary = version
+	return nil
+}
+
+func (k *Keyring) add(version int, key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("key %d: %w", version, err)
+	}
+	k.keys[version] = key
+	k.aeads[version] = aead
+	return nil
+}
+
+// KeyVersion returns the version of the primary key, which encrypts new values
+func (k *Keyring) KeyVersion() int {
+	return k.primary
+}
+
+// Encrypt encrypts plaintext with a new data key wrapped by the primary key,
+// authenticating context with it. Empty values stay empty.
+func (k *Keyring) Encrypt(plaintext, context string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	dataKey, err := randomBytes(keySize)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	tag := "v" + strconv.Itoa(k.primary)
+	// The version is authenticated with the data key, so it cannot be swapped
+	wrapped, err := seal(k.aeads[k.primary], dataKey, []byte(tag))
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(plaintext), []byte(context))
+	if err != nil {
+		return "", err
+	}
+	return prefix + tag + ":" + base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns the plaintext of a value returned by Encrypt for the same
+// context; values of another context are malformed. Values that were never
+// encrypted are returned as they are.
+func (k *Keyring) Decrypt(value, context string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "v") {
+		return "", ErrMalformed
+	}
+	version, err := strconv.Atoi(parts[0][1:])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	keyAEAD, ok := k.aeads[version]
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownKey, version)
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	dataKey, err := open(keyAEAD, wrapped, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", ErrMalformed
+	}
+	plaintext, err := open(aead, ciphertext, []byte(context))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns the HMAC-SHA256 of value, ignoring case and surrounding
+// spaces, so equal values can be found and kept unique without decrypting them
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(normalize(value)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Plaintext stores values unencrypted, for deployments without a keyring.
+// Its blind index is the normalized value itself.
+type Plaintext struct{}
+
+// KeyVersion returns 0, the version of unencrypted values
+func (Plaintext) KeyVersion() int { return 0 }
+
+// Encrypt returns plaintext unchanged
+func (Plaintext) Encrypt(plaintext, _ string) (string, error) { return plaintext, nil }
+
+// Decrypt returns value unchanged; encrypted values need the keyring
+func (Plaintext) Decrypt(value, _ string) (string, error) {
+	if strings.HasPrefix(value, prefix) {
+		return "", ErrUnknownKey
+	}
+	return value, nil
+}
+
+// BlindIndex returns value lowercased and without surrounding spaces
+func (Plaintext) BlindIndex(value string) string { return normalize(value) }
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which is prepended to the result
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	return plaintext, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
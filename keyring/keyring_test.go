@@ -0,0 +1,125 @@
+package keyring
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	k, err := Generate()
+	require.NoError(t, err)
+	assert.Equal(t, 1, k.KeyVersion())
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		value, err := k.Encrypt("john.doe@example.com", "customer:c1:email")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(value, "enc:v1:"))
+		assert.NotContains(t, value, "john.doe")
+
+		// Data keys and nonces are random, so equal values encrypt differently
+		other, err := k.Encrypt("john.doe@example.com", "customer:c1:email")
+		require.NoError(t, err)
+		assert.NotEqual(t, value, other)
+
+		plaintext, err := k.Decrypt(value, "customer:c1:email")
+		require.NoError(t, err)
+		assert.Equal(t, "john.doe@example.com", plaintext)
+	})
+
+	t.Run("EmptyAndUnencrypted", func(t *testing.T) {
+		value, err := k.Encrypt("", "customer:c1:email")
+		require.NoError(t, err)
+		assert.Empty(t, value)
+
+		plaintext, err := k.Decrypt("555-123-4567", "customer:c1:email")
+		require.NoError(t, err)
+		assert.Equal(t, "555-123-4567", plaintext)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		value, err := k.Encrypt("555-123-4567", "customer:c1:email")
+		require.NoError(t, err)
+		for _, tampered := range []string{
+			strings.Replace(value, "enc:v1:", "enc:v2:", 1),
+			value[:len(value)-2] + "AA",
+			"enc:v1:garbage",
+		} {
+			_, err := k.Decrypt(tampered, "customer:c1:email")
+			assert.Error(t, err, tampered)
+		}
+	})
+
+	t.Run("OtherContext", func(t *testing.T) {
+		// A value copied to another column or record does not decrypt there
+		value, err := k.Encrypt("555-123-4567", "customer:c1:phone")
+		require.NoError(t, err)
+		for _, context := range []string{"customer:c1:email", "customer:c2:phone", ""} {
+			_, err := k.Decrypt(value, context)
+			assert.ErrorIs(t, err, ErrMalformed, context)
+		}
+	})
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	k, err := Generate()
+	require.NoError(t, err)
+	old, err := k.Encrypt("123 Main St", "customer:c1:email")
+	require.NoError(t, err)
+	index := k.BlindIndex("John.Doe@example.com ")
+
+	require.NoError(t, k.AddKey())
+	assert.Equal(t, 2, k.KeyVersion())
+	value, err := k.Encrypt("123 Main St", "customer:c1:email")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(value, "enc:v2:"))
+
+	// Values of earlier keys stay readable and indexes stay valid
+	plaintext, err := k.Decrypt(old, "customer:c1:email")
+	require.NoError(t, err)
+	assert.Equal(t, "123 Main St", plaintext)
+	assert.Equal(t, index, k.BlindIndex("john.doe@example.com"))
+
+	// Keyrings without the key cannot read its values
+	other, err := Generate()
+	require.NoError(t, err)
+	_, err = other.Decrypt(value, "customer:c1:email")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.NotEqual(t, index, other.BlindIndex("john.doe@example.com"))
+}
+
+func TestKeyring_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	k, err := Generate()
+	require.NoError(t, err)
+	require.NoError(t, k.AddKey())
+	value, err := k.Encrypt("john.doe@example.com", "customer:c1:email")
+	require.NoError(t, err)
+	require.NoError(t, k.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded.KeyVersion())
+	plaintext, err := loaded.Decrypt(value, "customer:c1:email")
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe@example.com", plaintext)
+	assert.Equal(t, k.BlindIndex("john.doe@example.com"), loaded.BlindIndex("john.doe@example.com"))
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPlaintext(t *testing.T) {
+	var p Plaintext
+	value, err := p.Encrypt("John.Doe@example.com", "customer:c1:email")
+	require.NoError(t, err)
+	assert.Equal(t, "John.Doe@example.com", value)
+	assert.Equal(t, "john.doe@example.com", p.BlindIndex(" John.Doe@example.com"))
+	assert.Equal(t, 0, p.KeyVersion())
+
+	_, err = p.Decrypt("enc:v1:a:b", "customer:c1:email")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
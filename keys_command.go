@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/keyring"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+const keysUsage = `Usage: goodschain keys <command> [arguments]
+
+Commands:
+  generate --keyring FILE
+         Add a new primary key to a keyring file, creating the file when missing.
+  rotate [--batch-size 500]
+         Re-encrypt the customers not encrypted with the primary key of
+         PII_KEYRING_FILE, one batch per transaction.
+`
+
+// runKeys executes the `keys` subcommand
+func runKeys(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, keysUsage)
+		return 2
+	}
+	switch args[0] {
+	case "generate":
+		return runKeysGenerate(args[1:])
+	case "rotate":
+		return runKeysRotate(cfg, args[1:])
+	}
+	fmt.Fprint(os.Stderr, keysUsage)
+	return 2
+}
+
+// runKeysGenerate adds a key to a keyring file. Servers encrypt with it once
+// restarted; rotate then re-encrypts the existing rows.
+func runKeysGenerate(args []string) int {
+	fs := flag.NewFlagSet("keys generate", flag.ContinueOnError)
+	path := fs.String("keyring", "", "keyring file")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		log.Error().Msg("keys generate requires --keyring")
+		fs.Usage()
+		return 2
+	}
+
+	k, err := keyring.Load(*path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		k, err = keyring.Generate()
+	case err == nil:
+		err = k.AddKey()
+	}
+	if err == nil {
+		err = k.Save(*path)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("file", *path).Msg("Failed to generate key")
+		return 1
+	}
+
+	log.Info().Str("file", *path).Int("version", k.KeyVersion()).Msg("Key generated")
+	return 0
+}
+
+// runKeysRotate re-encrypts customers with the primary key in batches, so
+// each transaction only locks a batch of rows
+func runKeysRotate(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("keys rotate", flag.ContinueOnError)
+	batchSize := fs.Int("batch-size", 500, "customers re-encrypted per transaction")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if cfg.PIIKeyringFile == "" {
+		log.Error().Msg("keys rotate requires PII_KEYRING_FILE")
+		return 2
+	}
+
+	k, err := keyring.Load(cfg.PIIKeyringFile)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load PII keyring")
+		return 1
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open database")
+		return 1
+	}
+	defer db.Close()
+
+	customerRepo := repository.NewCustomerRepository(db, k)
+	transactor := repository.NewTransactor(db)
+	total := 0
+	for {
+		var n int
+		err := transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			var err error
+			n, err = customerRepo.WithTx(tx).Reencrypt(*batchSize)
+			return err
+		})
+		if err != nil {
+			log.Error().Err(err).Int("reencrypted", total).Msg("Failed to re-encrypt customers")
+			return 1
+		}
+		if n == 0 {
+			break
+		}
+		total += n
+		log.Info().Int("reencrypted", total).Msg("Re-encrypted batch")
+	}
+
+	log.Info().Int("reencrypted", total).Int("version", k.KeyVersion()).Msg("Customers encrypted with the primary key")
+	return 0
+}
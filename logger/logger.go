@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoodsChain/backend/redact"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -13,6 +14,7 @@ import (
 // It sets the log level based on the LOG_LEVEL environment variable.
 // Supported levels: "debug", "info", "warn", "error", "fatal", "panic".
 // Defaults to "info" if LOG_LEVEL is not set or invalid.
+// Emails, phones and other personal data are masked before entries are written.
 func InitLogger() {
 	logLevelStr := strings.ToLower(os.Getenv("LOG_LEVEL"))
 	var level zerolog.Level
@@ -40,7 +42,7 @@ func InitLogger() {
 	// In a production environment, you might want to use JSON output
 	// and send logs to a centralized logging system.
 	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
-	log.Logger = log.Output(output).With().Timestamp().Logger()
+	log.Logger = log.Output(redact.Default.Writer(output)).With().Timestamp().Logger()
 
 	log.Info().Msg("Logger initialized")
 	if logLevelStr != "" && level.String() != logLevelStr {
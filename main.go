@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,12 +16,21 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 
+	"github.com/GoodsChain/backend/blobstore"
 	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/eventstream"
+	"github.com/GoodsChain/backend/graph"
+	"github.com/GoodsChain/backend/grpcapi"
 	"github.com/GoodsChain/backend/handler"
 	"github.com/GoodsChain/backend/logger"
+	"github.com/GoodsChain/backend/ratelimit"
+	"github.com/GoodsChain/backend/relay"
 	"github.com/GoodsChain/backend/repository"
 	"github.com/GoodsChain/backend/usecase"
+	"github.com/GoodsChain/backend/webhook"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	_ "github.com/GoodsChain/backend/docs" // docs is generated by Swag CLI
 	swaggerFiles "github.com/swaggo/files"
@@ -40,16 +53,29 @@ func main() {
 	// Initialize logger
 	logger.InitLogger()
 
+	// Load configuration
+	cfg := config.LoadConfig()
+
+	// Dispatch to the requested subcommand; without one the binary serves the API
+	os.Exit(runCommand(cfg, os.Args[1:]))
+}
+
+// runServe starts the HTTP API server and blocks until it is shut down
+func runServe(cfg *config.Config, args []string) int {
 	// Initialize application context that can be cancelled
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-
-	// Load configuration
-	cfg := config.LoadConfig()
 	
 	// Set Gin mode based on environment
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
+
+	// Client IPs, which count the rate limits of anonymous requests, only come
+	// from X-Forwarded-For when a trusted proxy sent the request
+	if err := r.SetTrustedProxies(cfg.TrustedProxies()); err != nil {
+		log.Error().Err(err).Msg("Invalid API_TRUSTED_PROXIES")
+		return 1
+	}
 	
 	// Use our custom middleware instead of the default one
 	r.Use(gin.Recovery())
@@ -61,32 +87,219 @@ func main() {
 	// Swagger documentation route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Connect to database with enhanced connection pooling
-	db, err := connectDB(cfg)
+	// Customer PII is encrypted with the configured keyring
+	cipher, err := loadFieldCipher(cfg)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to connect to database")
+		log.Error().Err(err).Msg("Failed to load PII keyring")
+		return 1
 	}
 
-	// Initialize repositories, usecases, and handlers
-	customerRepo := repository.NewCustomerRepository(db)
-	customerUsecase := usecase.NewCustomerUsecase(customerRepo)
-	customerHandler := handler.NewCustomerHandler(customerUsecase)
+	// Connect to database and refuse to serve against an outdated schema
+	db, err := openDatabase(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open database")
+		return 1
+	}
 
+	// Initialize repositories, usecases, and handlers
+	customerRepo := repository.NewCustomerRepository(db, cipher)
 	supplierRepo := repository.NewSupplierRepository(db)
-	supplierUsecase := usecase.NewSupplierUsecase(supplierRepo)
+	carRepo := repository.NewCarRepository(db)
+	catalogRepo := repository.NewCatalogRepository(db)
+	customerCarRepo := repository.NewCustomerCarRepository(db)
+	// Domain changes append events to the outbox in their own transaction
+	transactor := repository.NewTransactor(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+
+	// Responses embed related records requested with ?expand=
+	expandUsecase := usecase.NewExpandUsecase(customerRepo, supplierRepo, carRepo, customerCarRepo, cfg.ExpandMaxDepth)
+
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, transactor, outboxRepo)
+	customerHandler := handler.NewCustomerHandler(customerUsecase, expandUsecase)
+
+	supplierUsecase := usecase.NewSupplierUsecase(supplierRepo, transactor, outboxRepo)
 	supplierHandler := handler.NewSupplierHandler(supplierUsecase)
 
-	carRepo := repository.NewCarRepository(db)
-	carUsecase := usecase.NewCarUsecase(carRepo)
-	carHandler := handler.NewCarHandler(carUsecase)
+	carUsecase := usecase.NewCarUsecase(carRepo, catalogRepo, transactor, outboxRepo)
+	carHandler := handler.NewCarHandler(carUsecase, expandUsecase)
 
-	// Initialize customer car repository, usecase, and handler
-	customerCarRepo := repository.NewCustomerCarRepository(db)
-	customerCarUsecase := usecase.NewCustomerCarUsecase(customerCarRepo)
-	customerCarHandler := handler.NewCustomerCarHandler(customerCarUsecase)
+	// Brands, models and trims of the car catalog
+	catalogUsecase := usecase.NewCatalogUsecase(catalogRepo)
+	catalogHandler := handler.NewCatalogHandler(catalogUsecase)
+
+	// Initialize customer car usecase and handler
+	customerCarUsecase := usecase.NewCustomerCarUsecase(customerCarRepo, transactor, outboxRepo)
+	customerCarHandler := handler.NewCustomerCarHandler(customerCarUsecase, expandUsecase)
+
+	// Service and repair history of owned vehicles
+	maintenanceRepo := repository.NewMaintenanceRepository(db)
+	maintenanceUsecase := usecase.NewMaintenanceUsecase(maintenanceRepo, customerCarRepo, transactor,
+		usecase.MaintenanceOptions{
+			IntervalKM:     cfg.MaintenanceIntervalKM,
+			IntervalMonths: cfg.MaintenanceIntervalMonths,
+		})
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceUsecase)
+
+	// Initialize the GraphQL server over the same usecases
+	graphServer, err := graph.NewServer(customerUsecase, supplierUsecase, carUsecase, customerCarUsecase, graph.Options{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load GraphQL schema")
+		return 1
+	}
+	graphQLHandler := handler.NewGraphQLHandler(graphServer)
+
+	// Initialize search across customers, suppliers and cars
+	searchUsecase := usecase.NewSearchUsecase(repository.NewSearchRepository(db))
+	searchHandler := handler.NewSearchHandler(searchUsecase)
+
+	// Data subject requests: customer data export and erasure
+	privacyUsecase := usecase.NewPrivacyUsecase(customerRepo, customerCarRepo, carRepo, maintenanceRepo, outboxRepo, transactor)
+	privacyHandler := handler.NewPrivacyHandler(privacyUsecase)
+
+	// Initialize spreadsheet import usecase and handler
+	importJobRepo := repository.NewImportJobRepository(db)
+	importUsecase := usecase.NewImportUsecase(transactor, customerRepo, supplierRepo, carRepo,
+		importJobRepo, outboxRepo, cfg.ImportChunkSize)
+	importHandler := handler.NewImportHandler(importUsecase, int64(cfg.ImportMaxFileSizeMB)<<20)
+
+	// Initialize batch usecase and handler
+	batchUsecase := usecase.NewBatchUsecase(transactor, customerRepo, supplierRepo, carRepo,
+		customerCarRepo, outboxRepo, cfg.BatchMaxOperations)
+	batchHandler := handler.NewBatchHandler(batchUsecase)
+
+	// Initialize webhook subscriptions and the background dispatcher
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, cfg.WebhookAllowPrivate)
+	webhookHandler := handler.NewWebhookHandler(webhookUsecase)
+	var stops []func()
+	if cfg.WebhookEnabled {
+		dispatcher := webhook.NewDispatcher(transactor, outboxRepo, webhookRepo,
+			webhook.NewClient(time.Duration(cfg.WebhookTimeout)*time.Second, cfg.WebhookAllowPrivate),
+			webhook.Options{
+				Interval:    time.Duration(cfg.WebhookDispatchInterval) * time.Second,
+				MaxAttempts: cfg.WebhookMaxAttempts,
+				BaseBackoff: time.Duration(cfg.WebhookBackoffBase) * time.Second,
+				MaxBackoff:  time.Duration(cfg.WebhookBackoffMax) * time.Second,
+			})
+		stops = append(stops, startWorker(ctx, dispatcher.Run))
+	}
+
+	// Relay outbox events to the configured publisher
+	if cfg.RelayPublisher != "" {
+		publisher, err := newPublisher(cfg)
+		if err != nil {
+			log.Error().Err(err).Str("publisher", cfg.RelayPublisher).Msg("Failed to create event publisher")
+			return 1
+		}
+		eventRelay := relay.NewRelay(transactor, outboxRepo, publisher, relay.Options{
+			Interval:  time.Duration(cfg.RelayInterval) * time.Second,
+			BatchSize: cfg.RelayBatchSize,
+		})
+		stops = append(stops, startWorker(ctx, eventRelay.Run), func() { publisher.Close() })
+	}
+
+	// Initialize the event stream; every instance listens for committed events
+	listener, err := eventstream.NewPQListener(cfg.GetDSN())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to listen for event notifications, falling back to polling")
+		listener = nil
+	}
+	hub := eventstream.NewHub(outboxRepo, listener, eventstream.Options{
+		PollInterval: time.Duration(cfg.EventStreamPollInterval) * time.Second,
+	})
+	eventHandler := handler.NewEventHandler(hub, time.Duration(cfg.EventStreamHeartbeat)*time.Second)
+	stops = append(stops, startWorker(ctx, hub.Run))
+	if listener != nil {
+		stops = append(stops, func() { listener.Close() })
+	}
+	stopWorkers := func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+
+	// Limit the request rate of each client to each route group
+	var rateLimiter *handler.RateLimiter
+	if cfg.RateLimitEnabled {
+		limiter, stopLimiter, err := newRateLimiter(ctx, cfg, db, transactor)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid rate limit configuration")
+			stopWorkers()
+			return 1
+		}
+		rateLimiter = handler.NewRateLimiter(limiter)
+		stops = append(stops, stopLimiter)
+	}
+
+	// Authenticate users by their access tokens and machine clients by their API keys
+	tokenSecret, err := newTokenSecret(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate token secret")
+		stopWorkers()
+		return 1
+	}
+	authUsecase := usecase.NewAuthUsecase(transactor, repository.NewUserRepository(db), repository.NewSessionRepository(db),
+		usecase.AuthOptions{
+			Secret:          tokenSecret,
+			AccessTokenTTL:  time.Duration(cfg.AuthAccessTokenTTL) * time.Second,
+			RefreshTokenTTL: time.Duration(cfg.AuthRefreshTokenTTL) * time.Second,
+			MaxFailedLogins: cfg.AuthMaxFailedLogins,
+			LockoutDuration: time.Duration(cfg.AuthLockoutDuration) * time.Second,
+		})
+	authHandler := handler.NewAuthHandler(authUsecase)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db))
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+	authenticator := handler.NewAuthenticator(apiKeyUsecase, authUsecase, cfg.AuthRequired)
+
+	// Serve the same usecases over gRPC, with their JSON gateway on the same
+	// port, authenticating calls like HTTP requests
+	if cfg.GRPCPort != "" {
+		grpcServer := grpcapi.NewServer(customerUsecase, supplierUsecase, carUsecase, customerCarUsecase, grpcapi.Options{
+			APIKeys:  apiKeyUsecase,
+			Auth:     authUsecase,
+			Required: cfg.AuthRequired,
+		})
+		stopGRPC, err := startGRPC(ctx, cfg, grpcServer)
+		if err != nil {
+			log.Error().Err(err).Str("port", cfg.GRPCPort).Msg("Failed to start gRPC server")
+			stopWorkers()
+			return 1
+		}
+		stops = append(stops, stopGRPC)
+	}
+
+	// Photos and documents of cars, served by signed download URLs
+	store, err := newBlobStore(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to initialize media store")
+		stopWorkers()
+		return 1
+	}
+	mediaSecret, err := newMediaSecret(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate media URL secret")
+		stopWorkers()
+		return 1
+	}
+	mediaUsecase := usecase.NewMediaUsecase(repository.NewMediaRepository(db), store, usecase.MediaOptions{
+		URLSecret:      mediaSecret,
+		URLTTL:         time.Duration(cfg.MediaURLTTL) * time.Second,
+		URLPrefix:      "/" + cfg.APIVersion + "/media",
+		ThumbnailSize:  cfg.MediaThumbnailSize,
+		MaxImagePixels: cfg.MediaMaxImagePixels,
+	})
+	mediaHandler := handler.NewMediaHandler(mediaUsecase, int64(cfg.MediaMaxFileSizeMB)<<20)
+	if cfg.MediaSweepInterval > 0 {
+		stops = append(stops, startWorker(ctx, sweepMedia(mediaUsecase, time.Duration(cfg.MediaSweepInterval)*time.Second)))
+	}
 
 	// Initialize routes with the versioned router
-	handler.InitRoutes(apiVersionGroup, customerHandler, supplierHandler, carHandler, customerCarHandler)
+	handler.InitRoutes(apiVersionGroup, customerHandler, supplierHandler, carHandler, customerCarHandler, importHandler,
+		batchHandler, webhookHandler, eventHandler, graphQLHandler, searchHandler, apiKeyHandler, authHandler,
+		privacyHandler, catalogHandler, mediaHandler, maintenanceHandler, rateLimiter, authenticator)
 
 	// Add health check endpoint at the root level
 	r.GET("/health", func(c *gin.Context) {
@@ -101,6 +314,8 @@ func main() {
 		WriteTimeout: time.Duration(cfg.APIWriteTimeout) * time.Second,
 		IdleTimeout:  time.Duration(cfg.APIIdleTimeout) * time.Second,
 	}
+	// End event streams on shutdown, they would otherwise hold it until the timeout
+	srv.RegisterOnShutdown(hub.Close)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -111,11 +326,194 @@ func main() {
 	}()
 
 	// Start the graceful shutdown handling
-	handleGracefulShutdown(ctx, srv, db, cfg)
+	handleGracefulShutdown(ctx, srv, db, cfg, stopWorkers)
+	return 0
+}
+
+// newTokenSecret returns the key signing access tokens. Without
+// AUTH_TOKEN_SECRET a random key is used, so tokens only work on this
+// instance until it restarts.
+func newTokenSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.AuthTokenSecret != "" {
+		return []byte(cfg.AuthTokenSecret), nil
+	}
+	log.Warn().Msg("AUTH_TOKEN_SECRET is not set, access tokens will not survive a restart or work on other instances")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// newMediaSecret returns the key signing media download URLs. Without
+// MEDIA_URL_SECRET a random key is used, so URLs only work on this instance
+// until it restarts.
+func newMediaSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.MediaURLSecret != "" {
+		return []byte(cfg.MediaURLSecret), nil
+	}
+	log.Warn().Msg("MEDIA_URL_SECRET is not set, media download URLs will not survive a restart or work on other instances")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// newBlobStore creates the media store selected by MEDIA_STORE
+func newBlobStore(cfg *config.Config) (blobstore.BlobStore, error) {
+	switch cfg.MediaStore {
+	case "local":
+		return blobstore.NewLocalStore(cfg.MediaLocalDir)
+	case "s3":
+		return blobstore.NewS3Store(blobstore.S3Options{
+			Endpoint:  cfg.MediaS3Endpoint,
+			Bucket:    cfg.MediaS3Bucket,
+			Region:    cfg.MediaS3Region,
+			AccessKey: cfg.MediaS3AccessKey,
+			SecretKey: cfg.MediaS3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown media store %q, expected local or s3", cfg.MediaStore)
+	}
+}
+
+// newPublisher creates the event publisher selected by RELAY_PUBLISHER
+func newPublisher(cfg *config.Config) (relay.Publisher, error) {
+	switch cfg.RelayPublisher {
+	case "stdout":
+		return relay.NewWriterPublisher(os.Stdout), nil
+	case "file":
+		return relay.NewFilePublisher(cfg.RelayFilePath)
+	case "nats":
+		return relay.NewNATSPublisher(cfg.NATSURL, cfg.NATSSubjectPrefix)
+	case "kafka":
+		return relay.NewKafkaPublisher(strings.Split(cfg.KafkaBrokers, ","), cfg.KafkaTopic), nil
+	default:
+		return nil, fmt.Errorf("unknown publisher %q, expected stdout, file, nats or kafka", cfg.RelayPublisher)
+	}
+}
+
+// newRateLimiter creates the rate limiter configured by the RATE_LIMIT_*
+// settings and returns a function that stops its background work
+func newRateLimiter(ctx context.Context, cfg *config.Config, db *sqlx.DB,
+	transactor repository.Transactor) (*ratelimit.Limiter, func(), error) {
+	var policy ratelimit.Policy
+	var err error
+	if policy.Default, err = ratelimit.ParseLimit(cfg.RateLimitDefault); err != nil {
+		return nil, nil, err
+	}
+	if policy.Groups, err = ratelimit.ParseLimits(cfg.RateLimitGroups); err != nil {
+		return nil, nil, err
+	}
+	if policy.Clients, err = ratelimit.ParseLimits(cfg.RateLimitClients); err != nil {
+		return nil, nil, err
+	}
+
+	switch cfg.RateLimitStore {
+	case "memory":
+		return ratelimit.NewLimiter(ratelimit.NewMemoryStore(), policy), func() {}, nil
+	case "postgres":
+		store := ratelimit.NewPostgresStore(transactor, repository.NewRateLimitRepository(db), ratelimit.PostgresOptions{})
+		return ratelimit.NewLimiter(store, policy), startWorker(ctx, store.Run), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown rate limit store %q, expected memory or postgres", cfg.RateLimitStore)
+	}
+}
+
+// startGRPC serves grpcServer, and the gateway translating JSON requests to
+// it, on GRPC_PORT and returns a function that stops serving
+func startGRPC(ctx context.Context, cfg *config.Config, grpcServer *grpc.Server) (stop func(), err error) {
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return nil, err
+	}
+	// The gateway calls the services through a client of the same port
+	conn, err := grpc.NewClient("localhost:"+cfg.GRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		lis.Close()
+		return nil, err
+	}
+	gateway, err := grpcapi.NewGateway(ctx, conn)
+	if err != nil {
+		conn.Close()
+		lis.Close()
+		return nil, err
+	}
+
+	// gRPC clients connect with HTTP/2 without TLS, gateway clients with either version
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Handler:     grpcapi.Handler(grpcServer, gateway),
+		Protocols:   protocols,
+		ReadTimeout: time.Duration(cfg.APIReadTimeout) * time.Second,
+		IdleTimeout: time.Duration(cfg.APIIdleTimeout) * time.Second,
+	}
+	go func() {
+		log.Info().Msgf("gRPC server starting on port %s", cfg.GRPCPort)
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("gRPC server failed")
+		}
+	}()
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.APIShutdownTimeout)*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("gRPC server forced to shutdown")
+		}
+		// End streams still open after the timeout
+		grpcServer.Stop()
+		conn.Close()
+	}, nil
+}
+
+// startWorker runs fn in a goroutine and returns a function that cancels it
+// and waits for it to return
+func startWorker(ctx context.Context, fn func(ctx context.Context)) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// mediaSweepBatch is the number of files of deleted media removed at a time
+const mediaSweepBatch = 100
+
+// sweepMedia returns a worker removing the stored files of deleted media
+// every interval, until its context is done
+func sweepMedia(media usecase.MediaUsecase, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			removed, err := media.RemoveOrphanedFiles(mediaSweepBatch)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to remove files of deleted media")
+				continue
+			}
+			if removed > 0 {
+				log.Debug().Int("removed", removed).Msg("Removed files of deleted media")
+			}
+		}
+	}
 }
 
 // handleGracefulShutdown manages the graceful shutdown process for the server
-func handleGracefulShutdown(ctx context.Context, srv *http.Server, db *sqlx.DB, cfg *config.Config) {
+func handleGracefulShutdown(ctx context.Context, srv *http.Server, db *sqlx.DB, cfg *config.Config, stopWorkers func()) {
 	// Set up channel to listen for signals
 	quit := make(chan os.Signal, 1)
 	// Listen for SIGINT and SIGTERM signals
@@ -137,6 +535,10 @@ func handleGracefulShutdown(ctx context.Context, srv *http.Server, db *sqlx.DB,
 		log.Error().Err(err).Msg("Server forced to shutdown")
 	}
 
+	// Stop background workers before the database they use is closed
+	log.Info().Msg("Stopping background workers...")
+	stopWorkers()
+
 	// Close database connection
 	log.Info().Msg("Closing database connection...")
 	if err := db.Close(); err != nil {
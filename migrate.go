@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/migrations"
+	"github.com/rs/zerolog/log"
+)
+
+const migrateUsage = `Usage: goodschain migrate <command> [arguments]
+
+Commands:
+  up              Apply all pending migrations
+  down [N]        Roll back N migrations (default 1)
+  down -all       Roll back all migrations
+  status          Show applied and pending migrations
+  force VERSION   Set the schema version and clear the dirty flag
+`
+
+// runMigrate executes the `migrate` subcommand and returns the process exit code
+func runMigrate(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	migrator, err := migrations.NewMigrator(cfg.GetDSN())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to initialize migrator")
+		return 1
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			log.Error().Err(err).Msg("Failed to apply migrations")
+			return 1
+		}
+		log.Info().Msg("Migrations applied")
+
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		all := fs.Bool("all", false, "roll back all migrations")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if *all {
+			err = migrator.DownAll()
+		} else {
+			steps := 1
+			if fs.NArg() > 0 {
+				if steps, err = strconv.Atoi(fs.Arg(0)); err != nil {
+					log.Error().Err(err).Str("steps", fs.Arg(0)).Msg("Invalid number of steps")
+					return 2
+				}
+			}
+			err = migrator.Down(steps)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to roll back migrations")
+			return 1
+		}
+		log.Info().Msg("Migrations rolled back")
+
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read migration status")
+			return 1
+		}
+		printMigrationStatus(status)
+
+	case "force":
+		if len(args) < 2 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Error().Err(err).Str("version", args[1]).Msg("Invalid version")
+			return 2
+		}
+		if err := migrator.Force(version); err != nil {
+			log.Error().Err(err).Msg("Failed to force migration version")
+			return 1
+		}
+		log.Info().Int("version", version).Msg("Migration version forced")
+
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	return 0
+}
+
+// printMigrationStatus writes a human-readable migration table to stdout
+func printMigrationStatus(status migrations.Status) {
+	fmt.Printf("Current version:  %d\n", status.Version)
+	fmt.Printf("Expected version: %d\n", status.ExpectedVersion)
+	fmt.Printf("Dirty:            %t\n\n", status.Dirty)
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Version <= status.Version {
+			state = "applied"
+		}
+		fmt.Printf("%04d  %-40s %s\n", m.Version, m.Name, state)
+	}
+}
+
+// ensureSchema applies pending migrations when auto-migrate is enabled and
+// refuses to continue if the database schema is behind the binary
+func ensureSchema(cfg *config.Config) error {
+	migrator, err := migrations.NewMigrator(cfg.GetDSN())
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if cfg.DBAutoMigrate {
+		log.Info().Msg("Applying pending database migrations")
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	if err := status.Check(); err != nil {
+		return fmt.Errorf("%w (run `goodschain migrate up` or set DB_AUTO_MIGRATE=true)", err)
+	}
+	if status.Version > status.ExpectedVersion {
+		log.Warn().
+			Uint("schema_version", status.Version).
+			Uint("expected_version", status.ExpectedVersion).
+			Msg("Database schema is newer than this binary")
+	}
+
+	log.Info().Uint("schema_version", status.Version).Msg("Database schema is up to date")
+	return nil
+}
@@ -0,0 +1,12 @@
+-- Local user accounts; "user" is a reserved word in PostgreSQL
+CREATE TABLE app_user (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  username VARCHAR(50) NOT NULL UNIQUE,
+  email VARCHAR(100) NOT NULL UNIQUE,
+  password_hash VARCHAR(255) NOT NULL,
+  role VARCHAR(20) NOT NULL DEFAULT 'viewer',
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50)
+);
@@ -0,0 +1,18 @@
+-- Chunked spreadsheet imports that can be resumed after a failure or restart
+CREATE TABLE import_job (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  resource VARCHAR(30) NOT NULL,
+  format VARCHAR(10) NOT NULL,
+  sheet VARCHAR(100) NOT NULL DEFAULT '',
+  mapping TEXT NOT NULL DEFAULT '{}',
+  payload BYTEA NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  total_rows INT NOT NULL DEFAULT 0,
+  processed_rows INT NOT NULL DEFAULT 0,
+  chunk_size INT NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50)
+);
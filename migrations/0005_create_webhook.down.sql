@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_subscription;
+DROP TABLE IF EXISTS outbox_event;
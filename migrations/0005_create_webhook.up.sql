@@ -0,0 +1,49 @@
+-- Domain events written in the same transaction as the change that caused
+-- them; seq gives the order in which they were committed
+CREATE TABLE outbox_event (
+  seq BIGSERIAL PRIMARY KEY,
+  id UUID NOT NULL UNIQUE,
+  event_type VARCHAR(100) NOT NULL,
+  aggregate_type VARCHAR(50) NOT NULL,
+  aggregate_id VARCHAR(50) NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  dispatched_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_event_undispatched_idx ON outbox_event (seq) WHERE dispatched_at IS NULL;
+
+-- Webhook endpoints and the event types they receive
+CREATE TABLE webhook_subscription (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  url TEXT NOT NULL,
+  event_types TEXT[] NOT NULL,
+  secret VARCHAR(100) NOT NULL,
+  description VARCHAR(255) NOT NULL DEFAULT '',
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50)
+);
+
+-- One row per event and subscription; also the delivery log
+CREATE TABLE webhook_delivery (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  subscription_id UUID NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+  event_id UUID NOT NULL,
+  event_type VARCHAR(100) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_attempt_at TIMESTAMPTZ,
+  response_status INT NOT NULL DEFAULT 0,
+  response_body TEXT NOT NULL DEFAULT '',
+  last_error TEXT NOT NULL DEFAULT '',
+  delivered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_delivery_subscription_idx ON webhook_delivery (subscription_id, created_at DESC);
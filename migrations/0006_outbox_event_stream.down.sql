@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS outbox_event_notify ON outbox_event;
+DROP FUNCTION IF EXISTS notify_outbox_event();
+DROP INDEX IF EXISTS outbox_event_stream_idx;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS txid;
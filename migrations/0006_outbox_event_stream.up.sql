@@ -0,0 +1,18 @@
+-- Transaction that wrote each event. Events are streamed in (txid, seq)
+-- order and only once every older transaction has finished, so a reader never
+-- moves past an event that is committed later with a lower seq.
+ALTER TABLE outbox_event ADD COLUMN txid XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX outbox_event_stream_idx ON outbox_event (txid, seq);
+
+-- Wake event stream listeners on every API instance; notifications are
+-- delivered when the writing transaction commits
+CREATE FUNCTION notify_outbox_event() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('outbox_event', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_event_notify AFTER INSERT ON outbox_event
+  FOR EACH STATEMENT EXECUTE FUNCTION notify_outbox_event();
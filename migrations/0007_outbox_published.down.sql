@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS outbox_event_unpublished_idx;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,5 @@
+-- Set by the relay once an event has been handed to the external publisher;
+-- independent of dispatched_at, which tracks the webhook dispatcher
+ALTER TABLE outbox_event ADD COLUMN published_at TIMESTAMPTZ;
+
+CREATE INDEX outbox_event_unpublished_idx ON outbox_event (txid, seq) WHERE published_at IS NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE car DROP COLUMN IF EXISTS search_text, DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE supplier DROP COLUMN IF EXISTS search_text, DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE customer DROP COLUMN IF EXISTS search_text, DROP COLUMN IF EXISTS search_vector;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS unaccent_simple;
+DROP FUNCTION IF EXISTS search_unaccent(text);
+DROP EXTENSION IF EXISTS pg_trgm;
+DROP EXTENSION IF EXISTS unaccent;
@@ -0,0 +1,54 @@
+-- Full-text and fuzzy search of customers, suppliers and cars. Matching
+-- ignores case and diacritics, so "ha noi" finds "Hà Nội".
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() is only STABLE since its dictionary could change; naming the
+-- dictionary explicitly makes it safe to use in generated columns and indexes
+CREATE FUNCTION search_unaccent(text) RETURNS text
+  LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+  AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+-- The simple configuration, with words stripped of their diacritics before
+-- they are lowercased. Queries use it too, so both sides are normalized alike.
+CREATE TEXT SEARCH CONFIGURATION unaccent_simple (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION unaccent_simple
+  ALTER MAPPING FOR word, hword, hword_part WITH unaccent, simple;
+
+-- search_vector holds the words for full-text matching, weighted so name
+-- matches rank first; search_text the normalized text for trigram similarity,
+-- which tolerates typos
+ALTER TABLE customer
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('unaccent_simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(phone, '') || ' ' || coalesce(email, '')), 'B') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(address, '')), 'C')
+  ) STORED,
+  ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+    search_unaccent(lower(coalesce(name, '') || ' ' || coalesce(phone, '') || ' ' || coalesce(email, '')))
+  ) STORED;
+
+ALTER TABLE supplier
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('unaccent_simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(phone, '') || ' ' || coalesce(email, '')), 'B') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(address, '')), 'C')
+  ) STORED,
+  ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+    search_unaccent(lower(coalesce(name, '') || ' ' || coalesce(phone, '') || ' ' || coalesce(email, '')))
+  ) STORED;
+
+ALTER TABLE car
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('unaccent_simple', coalesce(name, '')), 'A')
+  ) STORED,
+  ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+    search_unaccent(lower(coalesce(name, '')))
+  ) STORED;
+
+CREATE INDEX customer_search_vector_idx ON customer USING gin (search_vector);
+CREATE INDEX customer_search_text_idx ON customer USING gin (search_text gin_trgm_ops);
+CREATE INDEX supplier_search_vector_idx ON supplier USING gin (search_vector);
+CREATE INDEX supplier_search_text_idx ON supplier USING gin (search_text gin_trgm_ops);
+CREATE INDEX car_search_vector_idx ON car USING gin (search_vector);
+CREATE INDEX car_search_text_idx ON car USING gin (search_text gin_trgm_ops);
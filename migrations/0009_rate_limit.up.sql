@@ -0,0 +1,9 @@
+-- Token buckets of the rate limiter, shared by every API instance. Losing them
+-- only refills the buckets, so the table is not written to the WAL.
+CREATE UNLOGGED TABLE rate_limit_bucket (
+  key VARCHAR(255) PRIMARY KEY,
+  tokens DOUBLE PRECISION NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX rate_limit_bucket_updated_at_idx ON rate_limit_bucket (updated_at);
@@ -0,0 +1,16 @@
+-- Credentials of machine clients. Only the SHA-256 hash of a key is stored;
+-- its prefix, stored in clear, finds the row to compare it with.
+CREATE TABLE api_key (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name VARCHAR(100) NOT NULL,
+  prefix VARCHAR(16) NOT NULL UNIQUE,
+  key_hash VARCHAR(64) NOT NULL,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS refresh_token;
+DROP TABLE IF EXISTS auth_session;
+
+ALTER TABLE app_user
+  DROP COLUMN IF EXISTS locked_until,
+  DROP COLUMN IF EXISTS failed_logins;
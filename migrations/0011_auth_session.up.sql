@@ -0,0 +1,28 @@
+-- Failed logins since the last successful one; reaching the limit locks the
+-- account until locked_until and starts the count over
+ALTER TABLE app_user
+  ADD COLUMN failed_logins INT NOT NULL DEFAULT 0,
+  ADD COLUMN locked_until TIMESTAMPTZ;
+
+-- A login of a user. Access tokens name their session, so revoking it ends
+-- them before they expire.
+CREATE TABLE auth_session (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES app_user (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_auth_session_user_id ON auth_session (user_id);
+
+-- Refresh tokens of a session, each used once. Only their SHA-256 hash is
+-- stored; a used token presented again revokes its session.
+CREATE TABLE refresh_token (
+  token_hash VARCHAR(64) PRIMARY KEY,
+  session_id UUID NOT NULL REFERENCES auth_session (id) ON DELETE CASCADE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_refresh_token_session_id ON refresh_token (session_id);
@@ -0,0 +1,36 @@
+DROP POLICY IF EXISTS tenant_isolation ON customer_car;
+DROP POLICY IF EXISTS tenant_isolation ON customer;
+DROP POLICY IF EXISTS tenant_isolation ON car;
+DROP POLICY IF EXISTS tenant_isolation ON supplier;
+
+ALTER TABLE customer_car DISABLE ROW LEVEL SECURITY;
+ALTER TABLE customer DISABLE ROW LEVEL SECURITY;
+ALTER TABLE car DISABLE ROW LEVEL SECURITY;
+ALTER TABLE supplier DISABLE ROW LEVEL SECURITY;
+
+ALTER TABLE customer_car DROP CONSTRAINT IF EXISTS customer_car_cust_id_fkey,
+  ADD CONSTRAINT customer_car_cust_id_fkey FOREIGN KEY (cust_id) REFERENCES customer (id);
+ALTER TABLE customer_car DROP CONSTRAINT IF EXISTS customer_car_car_id_fkey,
+  ADD CONSTRAINT customer_car_car_id_fkey FOREIGN KEY (car_id) REFERENCES car (id);
+ALTER TABLE car DROP CONSTRAINT IF EXISTS car_supp_id_fkey,
+  ADD CONSTRAINT car_supp_id_fkey FOREIGN KEY (supp_id) REFERENCES supplier (id);
+
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS customer_tenant_id_id_key;
+ALTER TABLE car DROP CONSTRAINT IF EXISTS car_tenant_id_id_key;
+ALTER TABLE supplier DROP CONSTRAINT IF EXISTS supplier_tenant_id_id_key;
+
+-- Fails if two tenants share an email, which cannot be undone without losing data
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS customer_tenant_email_key, ADD CONSTRAINT customer_email_key UNIQUE (email);
+ALTER TABLE supplier DROP CONSTRAINT IF EXISTS supplier_tenant_email_key, ADD CONSTRAINT supplier_email_key UNIQUE (email);
+
+ALTER TABLE api_key DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE app_user DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_subscription DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE import_job DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE customer_car DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE customer DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE car DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE supplier DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenant;
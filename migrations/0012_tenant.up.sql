@@ -0,0 +1,62 @@
+-- Dealer organizations sharing the deployment. Every record belongs to one
+-- tenant; records that existed before tenants go to the default tenant.
+CREATE TABLE tenant (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name VARCHAR(100) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50)
+);
+
+INSERT INTO tenant (id, name, created_by, updated_by)
+VALUES ('00000000-0000-0000-0000-000000000000', 'default', 'system', 'system');
+
+ALTER TABLE supplier ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE car ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE customer ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE customer_car ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE import_job ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE webhook_subscription ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE outbox_event ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE app_user ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+ALTER TABLE api_key ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES tenant (id);
+
+-- Emails are unique within a tenant; two dealers may share a customer
+ALTER TABLE supplier DROP CONSTRAINT supplier_email_key, ADD CONSTRAINT supplier_tenant_email_key UNIQUE (tenant_id, email);
+ALTER TABLE customer DROP CONSTRAINT customer_email_key, ADD CONSTRAINT customer_tenant_email_key UNIQUE (tenant_id, email);
+
+-- References include the tenant, so a record can only reference records of
+-- its own tenant
+ALTER TABLE supplier ADD CONSTRAINT supplier_tenant_id_id_key UNIQUE (tenant_id, id);
+ALTER TABLE car ADD CONSTRAINT car_tenant_id_id_key UNIQUE (tenant_id, id);
+ALTER TABLE customer ADD CONSTRAINT customer_tenant_id_id_key UNIQUE (tenant_id, id);
+
+ALTER TABLE car DROP CONSTRAINT car_supp_id_fkey,
+  ADD CONSTRAINT car_supp_id_fkey FOREIGN KEY (tenant_id, supp_id) REFERENCES supplier (tenant_id, id);
+ALTER TABLE customer_car DROP CONSTRAINT customer_car_car_id_fkey,
+  ADD CONSTRAINT customer_car_car_id_fkey FOREIGN KEY (tenant_id, car_id) REFERENCES car (tenant_id, id);
+ALTER TABLE customer_car DROP CONSTRAINT customer_car_cust_id_fkey,
+  ADD CONSTRAINT customer_car_cust_id_fkey FOREIGN KEY (tenant_id, cust_id) REFERENCES customer (tenant_id, id);
+
+-- Lists are always read within a tenant, newest first
+CREATE INDEX idx_supplier_tenant_created_at ON supplier (tenant_id, created_at DESC);
+CREATE INDEX idx_car_tenant_created_at ON car (tenant_id, created_at DESC);
+CREATE INDEX idx_customer_tenant_created_at ON customer (tenant_id, created_at DESC);
+CREATE INDEX idx_customer_car_tenant_created_at ON customer_car (tenant_id, created_at DESC);
+CREATE INDEX idx_webhook_subscription_tenant_id ON webhook_subscription (tenant_id);
+CREATE INDEX idx_api_key_tenant_id ON api_key (tenant_id);
+
+-- Row-level security for roles other than the owner, such as a reporting
+-- role, which only see the tenant named by the app.tenant_id setting. The API
+-- connects as the owner, which bypasses the policies, and scopes its queries
+-- itself.
+ALTER TABLE supplier ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car ENABLE ROW LEVEL SECURITY;
+ALTER TABLE customer ENABLE ROW LEVEL SECURITY;
+ALTER TABLE customer_car ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON supplier USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON car USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON customer USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON customer_car USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
@@ -0,0 +1,26 @@
+-- Encrypted values are left as they are and must be decrypted before rolling
+-- back; the column types are only restored once they fit again.
+ALTER TABLE customer DROP COLUMN search_vector, DROP COLUMN search_text;
+ALTER TABLE customer
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('unaccent_simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(phone, '') || ' ' || coalesce(email, '')), 'B') ||
+    setweight(to_tsvector('unaccent_simple', coalesce(address, '')), 'C')
+  ) STORED,
+  ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+    search_unaccent(lower(coalesce(name, '') || ' ' || coalesce(phone, '') || ' ' || coalesce(email, '')))
+  ) STORED;
+
+CREATE INDEX customer_search_vector_idx ON customer USING gin (search_vector);
+CREATE INDEX customer_search_text_idx ON customer USING gin (search_text gin_trgm_ops);
+
+DROP INDEX IF EXISTS idx_customer_key_version;
+ALTER TABLE customer DROP CONSTRAINT IF EXISTS customer_tenant_email_index_key,
+  ADD CONSTRAINT customer_tenant_email_key UNIQUE (tenant_id, email);
+
+ALTER TABLE customer
+  DROP COLUMN IF EXISTS key_version,
+  DROP COLUMN IF EXISTS email_index,
+  ALTER COLUMN email TYPE VARCHAR(100),
+  ALTER COLUMN phone TYPE VARCHAR(20),
+  ALTER COLUMN address TYPE VARCHAR(100);
@@ -0,0 +1,32 @@
+-- The address, phone and email of customers are encrypted by the application,
+-- so their columns hold ciphertext of any length. email_index holds the blind
+-- index of the email, which replaces the email in lookups and uniqueness, and
+-- key_version the version of the key the row is encrypted with; 0 is
+-- unencrypted.
+ALTER TABLE customer
+  ALTER COLUMN address TYPE TEXT,
+  ALTER COLUMN phone TYPE TEXT,
+  ALTER COLUMN email TYPE TEXT,
+  ADD COLUMN email_index TEXT,
+  ADD COLUMN key_version INT NOT NULL DEFAULT 0;
+
+-- Existing rows are unencrypted, and the blind index of an unencrypted email
+-- is the email itself, normalized. `goodschain keys rotate` encrypts them.
+UPDATE customer SET email_index = lower(trim(email));
+
+ALTER TABLE customer DROP CONSTRAINT customer_tenant_email_key,
+  ADD CONSTRAINT customer_tenant_email_index_key UNIQUE (tenant_id, email_index);
+CREATE INDEX idx_customer_key_version ON customer (key_version);
+
+-- Ciphertext cannot be searched, so customers are searched by name only
+ALTER TABLE customer DROP COLUMN search_vector, DROP COLUMN search_text;
+ALTER TABLE customer
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('unaccent_simple', coalesce(name, '')), 'A')
+  ) STORED,
+  ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+    search_unaccent(lower(coalesce(name, '')))
+  ) STORED;
+
+CREATE INDEX customer_search_vector_idx ON customer USING gin (search_vector);
+CREATE INDEX customer_search_text_idx ON customer USING gin (search_text gin_trgm_ops);
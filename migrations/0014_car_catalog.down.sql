@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_car_specs;
+DROP INDEX IF EXISTS idx_car_trim_id;
+ALTER TABLE car DROP CONSTRAINT IF EXISTS car_trim_id_fkey, DROP COLUMN IF EXISTS specs, DROP COLUMN IF EXISTS trim_id;
+
+DROP TABLE IF EXISTS car_trim;
+DROP TABLE IF EXISTS car_model;
+DROP TABLE IF EXISTS car_brand;
@@ -0,0 +1,52 @@
+-- Catalog of brands, their models and the trims of each model. The category
+-- of a model selects the specification schema of the cars of its trims.
+CREATE TABLE car_brand (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenant (id),
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50),
+  UNIQUE (tenant_id, name),
+  UNIQUE (tenant_id, id)
+);
+
+CREATE TABLE car_model (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenant (id),
+  brand_id UUID NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  category VARCHAR(20) NOT NULL CHECK (category IN ('passenger', 'suv', 'pickup', 'van')),
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50),
+  UNIQUE (brand_id, name),
+  UNIQUE (tenant_id, id),
+  FOREIGN KEY (tenant_id, brand_id) REFERENCES car_brand (tenant_id, id)
+);
+
+CREATE TABLE car_trim (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenant (id),
+  model_id UUID NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  updated_at TIMESTAMPTZ DEFAULT now(),
+  updated_by VARCHAR(50),
+  UNIQUE (model_id, name),
+  UNIQUE (tenant_id, id),
+  FOREIGN KEY (tenant_id, model_id) REFERENCES car_model (tenant_id, id)
+);
+
+-- Cars may belong to a trim of their tenant and carry the specification
+-- attributes of its category, filtered by containment and by range
+ALTER TABLE car
+  ADD COLUMN trim_id UUID,
+  ADD COLUMN specs JSONB NOT NULL DEFAULT '{}',
+  ADD CONSTRAINT car_trim_id_fkey FOREIGN KEY (tenant_id, trim_id) REFERENCES car_trim (tenant_id, id);
+
+CREATE INDEX idx_car_trim_id ON car (trim_id);
+CREATE INDEX idx_car_specs ON car USING gin (specs jsonb_path_ops);
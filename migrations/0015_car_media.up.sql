@@ -0,0 +1,21 @@
+-- Photos and documents of cars. The files are kept in the blob store under
+-- storage_key; deleting a car deletes their metadata.
+CREATE TABLE car_media (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenant (id),
+  car_id UUID NOT NULL,
+  kind VARCHAR(20) NOT NULL CHECK (kind IN ('image', 'document')),
+  filename VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL,
+  width INTEGER,
+  height INTEGER,
+  checksum CHAR(64) NOT NULL,
+  storage_key VARCHAR(255) NOT NULL UNIQUE,
+  thumbnail_key VARCHAR(255) UNIQUE,
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  FOREIGN KEY (tenant_id, car_id) REFERENCES car (tenant_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_car_media_car_id ON car_media (car_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS maintenance_record;
+ALTER TABLE customer_car DROP CONSTRAINT IF EXISTS customer_car_tenant_id_id_key;
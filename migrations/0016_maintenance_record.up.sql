@@ -0,0 +1,25 @@
+-- Service and repair work on owned vehicles, recorded against the
+-- customer_car relationship; deleting the relationship deletes its records.
+ALTER TABLE customer_car ADD CONSTRAINT customer_car_tenant_id_id_key UNIQUE (tenant_id, id);
+
+CREATE TABLE maintenance_record (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tenant_id UUID NOT NULL REFERENCES tenant (id),
+  customer_car_id UUID NOT NULL,
+  kind VARCHAR(20) NOT NULL CHECK (kind IN ('service', 'repair', 'inspection')),
+  service_date DATE NOT NULL,
+  odometer INTEGER NOT NULL CHECK (odometer >= 0),
+  work_items JSONB NOT NULL DEFAULT '[]',
+  cost BIGINT NOT NULL DEFAULT 0 CHECK (cost >= 0),
+  workshop VARCHAR(255) NOT NULL DEFAULT '',
+  notes TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ DEFAULT now(),
+  created_by VARCHAR(50),
+  FOREIGN KEY (tenant_id, customer_car_id) REFERENCES customer_car (tenant_id, id) ON DELETE CASCADE
+);
+
+-- Records are read per vehicle in mileage order
+CREATE INDEX idx_maintenance_record_customer_car_id ON maintenance_record (customer_car_id, service_date, odometer);
+
+ALTER TABLE maintenance_record ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON maintenance_record USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
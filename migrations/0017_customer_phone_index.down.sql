@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_customer_tenant_phone_index;
+ALTER TABLE customer DROP COLUMN IF EXISTS phone_index;
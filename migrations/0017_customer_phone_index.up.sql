@@ -0,0 +1,9 @@
+-- phone_index holds the blind index of the digits of the phone of a customer,
+-- which finds customers by their encrypted phone.
+ALTER TABLE customer ADD COLUMN phone_index TEXT;
+
+-- The blind index of an unencrypted phone is its digits. Encrypted rows keep
+-- a NULL index until `goodschain keys rotate` rewrites them.
+UPDATE customer SET phone_index = regexp_replace(phone, '[^0-9]', '', 'g') WHERE key_version = 0;
+
+CREATE INDEX idx_customer_tenant_phone_index ON customer (tenant_id, phone_index);
@@ -0,0 +1,9 @@
+DROP POLICY IF EXISTS tenant_isolation ON car_media;
+DROP POLICY IF EXISTS tenant_isolation ON car_trim;
+DROP POLICY IF EXISTS tenant_isolation ON car_model;
+DROP POLICY IF EXISTS tenant_isolation ON car_brand;
+
+ALTER TABLE car_media DISABLE ROW LEVEL SECURITY;
+ALTER TABLE car_trim DISABLE ROW LEVEL SECURITY;
+ALTER TABLE car_model DISABLE ROW LEVEL SECURITY;
+ALTER TABLE car_brand DISABLE ROW LEVEL SECURITY;
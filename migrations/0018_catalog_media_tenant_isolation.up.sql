@@ -0,0 +1,12 @@
+-- Row-level security of the catalog and media tables, as for the other
+-- tables holding tenant data since 0012: roles other than the owner only see
+-- the tenant named by the app.tenant_id setting.
+ALTER TABLE car_brand ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car_model ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car_trim ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car_media ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON car_brand USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON car_model USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON car_trim USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
+CREATE POLICY tenant_isolation ON car_media USING (tenant_id = current_setting('app.tenant_id', true)::uuid);
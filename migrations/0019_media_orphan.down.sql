@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS car_media_orphan ON car_media;
+DROP FUNCTION IF EXISTS car_media_orphan();
+DROP TABLE IF EXISTS media_orphan;
@@ -0,0 +1,20 @@
+-- Keys of the stored files of deleted car media, whichever way they were
+-- deleted, including with their car. The server removes the files and then
+-- the keys.
+CREATE TABLE media_orphan (
+  storage_key TEXT PRIMARY KEY,
+  deleted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE FUNCTION car_media_orphan() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO media_orphan (storage_key) VALUES (OLD.storage_key) ON CONFLICT DO NOTHING;
+  IF OLD.thumbnail_key IS NOT NULL THEN
+    INSERT INTO media_orphan (storage_key) VALUES (OLD.thumbnail_key) ON CONFLICT DO NOTHING;
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER car_media_orphan AFTER DELETE ON car_media
+  FOR EACH ROW EXECUTE FUNCTION car_media_orphan();
@@ -0,0 +1,211 @@
+// Package migrations embeds the SQL schema migrations into the binary and
+// applies them with golang-migrate, using the same schema_migrations table as
+// the migrate CLI so both can be used against the same database.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/lib/pq"
+)
+
+// FS holds the embedded *.up.sql / *.down.sql migration files
+//
+//go:embed *.sql
+var FS embed.FS
+
+// migrationFileRegex matches golang-migrate file names, e.g. 0001_initial.up.sql
+var migrationFileRegex = regexp.MustCompile(`^([0-9]+)_(.*)\.(up|down)\.sql$`)
+
+// ErrSchemaBehind is returned by Check when the database has not been migrated
+// up to the version the binary expects
+var ErrSchemaBehind = errors.New("database schema is behind the expected version")
+
+// ErrSchemaDirty is returned by Check when a previous migration failed half-way
+var ErrSchemaDirty = errors.New("database schema is dirty")
+
+// Migration describes a single embedded migration
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// Status describes the migration state of a database
+type Status struct {
+	Version         uint // Currently applied version, 0 if none
+	Dirty           bool // Whether the last migration failed
+	ExpectedVersion uint // Latest version embedded in the binary
+	Migrations      []Migration
+}
+
+// Pending reports whether there are embedded migrations not yet applied
+func (s Status) Pending() bool {
+	return s.Version < s.ExpectedVersion
+}
+
+// List returns all embedded migrations ordered by version
+func List() ([]Migration, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool)
+	var migrations []Migration
+	for _, entry := range entries {
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+		if seen[uint(version)] {
+			continue
+		}
+		seen[uint(version)] = true
+		migrations = append(migrations, Migration{Version: uint(version), Name: matches[2]})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ExpectedVersion returns the highest migration version embedded in the binary
+func ExpectedVersion() (uint, error) {
+	migrations, err := List()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// Migrator applies the embedded migrations to a PostgreSQL database
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// NewMigrator opens a dedicated connection to the database identified by dsn
+// and prepares the embedded migrations. Close must be called when done.
+func NewMigrator(dsn string) (*Migrator, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	source, err := iofs.New(FS, ".")
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+	return &Migrator{m: m}, nil
+}
+
+// Close releases the migration source and database connection
+func (mg *Migrator) Close() error {
+	sourceErr, dbErr := mg.m.Close()
+	if sourceErr != nil {
+		return sourceErr
+	}
+	return dbErr
+}
+
+// Up applies all pending migrations. It is a no-op if the schema is current.
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the given number of migrations
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	if err := mg.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// DownAll rolls back every applied migration
+func (mg *Migrator) DownAll() error {
+	if err := mg.m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force sets the schema version without running any migration and clears the
+// dirty flag. It is used to recover after a failed migration was fixed by hand.
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Status returns the current and expected schema versions
+func (mg *Migrator) Status() (Status, error) {
+	migrations, err := List()
+	if err != nil {
+		return Status{}, err
+	}
+	status := Status{Migrations: migrations}
+	if len(migrations) > 0 {
+		status.ExpectedVersion = migrations[len(migrations)-1].Version
+	}
+
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, err
+	}
+	status.Version = version
+	status.Dirty = dirty
+	return status, nil
+}
+
+// Check returns an error if the database schema is dirty or older than the
+// version embedded in the binary
+func (mg *Migrator) Check() error {
+	status, err := mg.Status()
+	if err != nil {
+		return err
+	}
+	return status.Check()
+}
+
+// Check returns an error if the status is dirty or behind the expected version
+func (s Status) Check() error {
+	if s.Dirty {
+		return fmt.Errorf("%w at version %d", ErrSchemaDirty, s.Version)
+	}
+	if s.Pending() {
+		return fmt.Errorf("%w: database is at version %d, binary expects %d", ErrSchemaBehind, s.Version, s.ExpectedVersion)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	migrations, err := List()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	// Up and down files share a version and must only be listed once
+	assert.Equal(t, uint(1), migrations[0].Version)
+	assert.Equal(t, "initial", migrations[0].Name)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	migrations, err := List()
+	assert.NoError(t, err)
+
+	version, err := ExpectedVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, migrations[len(migrations)-1].Version, version)
+}
+
+func TestStatus_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  Status
+		wantErr error
+	}{
+		{name: "UpToDate", status: Status{Version: 2, ExpectedVersion: 2}},
+		{name: "Newer", status: Status{Version: 3, ExpectedVersion: 2}},
+		{name: "Behind", status: Status{Version: 1, ExpectedVersion: 2}, wantErr: ErrSchemaBehind},
+		{name: "Unmigrated", status: Status{Version: 0, ExpectedVersion: 2}, wantErr: ErrSchemaBehind},
+		{name: "Dirty", status: Status{Version: 2, ExpectedVersion: 2, Dirty: true}, wantErr: ErrSchemaDirty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.status.Check()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: APIKeyRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/api_key_repository_mock.go -package=mock github.com/GoodsChain/backend/repository APIKeyRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepository) Create(key *model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryMockRecorder) Create(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepository)(nil).Create), key)
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKeyRepository) GetAll() ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockAPIKeyRepository) GetByID(id string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByID), id)
+}
+
+// GetByPrefix mocks base method.
+func (m *MockAPIKeyRepository) GetByPrefix(prefix string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", prefix)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByPrefix(prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByPrefix), prefix)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepository) Revoke(id, updatedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id, updatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepositoryMockRecorder) Revoke(id, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepository)(nil).Revoke), id, updatedBy)
+}
+
+// Rotate mocks base method.
+func (m *MockAPIKeyRepository) Rotate(id string, key *model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", id, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockAPIKeyRepositoryMockRecorder) Rotate(id, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockAPIKeyRepository)(nil).Rotate), id, key)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockAPIKeyRepository) TouchLastUsed(id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockAPIKeyRepositoryMockRecorder) TouchLastUsed(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockAPIKeyRepository)(nil).TouchLastUsed), id, at)
+}
+
+// WithTenant mocks base method.
+func (m *MockAPIKeyRepository) WithTenant(tenantID string) repository.APIKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.APIKeyRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockAPIKeyRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockAPIKeyRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockAPIKeyRepository) WithTx(tx *sqlx.Tx) repository.APIKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.APIKeyRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockAPIKeyRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockAPIKeyRepository)(nil).WithTx), tx)
+}
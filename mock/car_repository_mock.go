@@ -13,6 +13,8 @@ import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,21 @@ func (m *MockCarRepository) EXPECT() *MockCarRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateBatch mocks base method.
+func (m *MockCarRepository) CreateBatch(cars []*model.Car) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", cars)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockCarRepositoryMockRecorder) CreateBatch(cars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCarRepository)(nil).CreateBatch), cars)
+}
+
 // CreateCar mocks base method.
 func (m *MockCarRepository) CreateCar(car *model.Car) error {
 	m.ctrl.T.Helper()
@@ -54,6 +71,21 @@ func (mr *MockCarRepositoryMockRecorder) CreateCar(car any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCar", reflect.TypeOf((*MockCarRepository)(nil).CreateCar), car)
 }
 
+// DeleteBatch mocks base method.
+func (m *MockCarRepository) DeleteBatch(ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch.
+func (mr *MockCarRepositoryMockRecorder) DeleteBatch(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockCarRepository)(nil).DeleteBatch), ids)
+}
+
 // DeleteCar mocks base method.
 func (m *MockCarRepository) DeleteCar(id string) error {
 	m.ctrl.T.Helper()
@@ -69,18 +101,18 @@ func (mr *MockCarRepositoryMockRecorder) DeleteCar(id any) *gomock.Call {
 }
 
 // GetAllCars mocks base method.
-func (m *MockCarRepository) GetAllCars() ([]model.Car, error) {
+func (m *MockCarRepository) GetAllCars(filter model.CarFilter) ([]model.Car, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCars")
+	ret := m.ctrl.Call(m, "GetAllCars", filter)
 	ret0, _ := ret[0].([]model.Car)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCars indicates an expected call of GetAllCars.
-func (mr *MockCarRepositoryMockRecorder) GetAllCars() *gomock.Call {
+func (mr *MockCarRepositoryMockRecorder) GetAllCars(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCars", reflect.TypeOf((*MockCarRepository)(nil).GetAllCars))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCars", reflect.TypeOf((*MockCarRepository)(nil).GetAllCars), filter)
 }
 
 // GetCarByID mocks base method.
@@ -98,6 +130,65 @@ func (mr *MockCarRepositoryMockRecorder) GetCarByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByID", reflect.TypeOf((*MockCarRepository)(nil).GetCarByID), id)
 }
 
+// GetCarsByIDs mocks base method.
+func (m *MockCarRepository) GetCarsByIDs(ids []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarsByIDs", ids)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsByIDs indicates an expected call of GetCarsByIDs.
+func (mr *MockCarRepositoryMockRecorder) GetCarsByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsByIDs", reflect.TypeOf((*MockCarRepository)(nil).GetCarsByIDs), ids)
+}
+
+// GetCarsBySupplierIDs mocks base method.
+func (m *MockCarRepository) GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarsBySupplierIDs", supplierIDs)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsBySupplierIDs indicates an expected call of GetCarsBySupplierIDs.
+func (mr *MockCarRepositoryMockRecorder) GetCarsBySupplierIDs(supplierIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsBySupplierIDs", reflect.TypeOf((*MockCarRepository)(nil).GetCarsBySupplierIDs), supplierIDs)
+}
+
+// StreamCars mocks base method.
+func (m *MockCarRepository) StreamCars(filter model.CarFilter, fn func(*model.Car) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCars", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCars indicates an expected call of StreamCars.
+func (mr *MockCarRepositoryMockRecorder) StreamCars(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCars", reflect.TypeOf((*MockCarRepository)(nil).StreamCars), filter, fn)
+}
+
+// UpdateBatch mocks base method.
+func (m *MockCarRepository) UpdateBatch(cars []*model.Car) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatch", cars)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBatch indicates an expected call of UpdateBatch.
+func (mr *MockCarRepositoryMockRecorder) UpdateBatch(cars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatch", reflect.TypeOf((*MockCarRepository)(nil).UpdateBatch), cars)
+}
+
 // UpdateCar mocks base method.
 func (m *MockCarRepository) UpdateCar(id string, car *model.Car) error {
 	m.ctrl.T.Helper()
@@ -111,3 +202,31 @@ func (mr *MockCarRepositoryMockRecorder) UpdateCar(id, car any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCar", reflect.TypeOf((*MockCarRepository)(nil).UpdateCar), id, car)
 }
+
+// WithTenant mocks base method.
+func (m *MockCarRepository) WithTenant(tenantID string) repository.CarRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.CarRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCarRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCarRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockCarRepository) WithTx(tx *sqlx.Tx) repository.CarRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.CarRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockCarRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockCarRepository)(nil).WithTx), tx)
+}
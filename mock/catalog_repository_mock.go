@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: CatalogRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/catalog_repository_mock.go -package=mock github.com/GoodsChain/backend/repository CatalogRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogRepository is a mock of CatalogRepository interface.
+type MockCatalogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogRepositoryMockRecorder is the mock recorder for MockCatalogRepository.
+type MockCatalogRepositoryMockRecorder struct {
+	mock *MockCatalogRepository
+}
+
+// NewMockCatalogRepository creates a new mock instance.
+func NewMockCatalogRepository(ctrl *gomock.Controller) *MockCatalogRepository {
+	mock := &MockCatalogRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepository) EXPECT() *MockCatalogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogRepository) CreateBrand(brand *model.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogRepositoryMockRecorder) CreateBrand(brand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogRepository)(nil).CreateBrand), brand)
+}
+
+// CreateModel mocks base method.
+func (m *MockCatalogRepository) CreateModel(carModel *model.CarModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", carModel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogRepositoryMockRecorder) CreateModel(carModel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogRepository)(nil).CreateModel), carModel)
+}
+
+// CreateTrim mocks base method.
+func (m *MockCatalogRepository) CreateTrim(trim *model.Trim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrim", trim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTrim indicates an expected call of CreateTrim.
+func (mr *MockCatalogRepositoryMockRecorder) CreateTrim(trim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrim", reflect.TypeOf((*MockCatalogRepository)(nil).CreateTrim), trim)
+}
+
+// GetBrands mocks base method.
+func (m *MockCatalogRepository) GetBrands() ([]model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrands")
+	ret0, _ := ret[0].([]model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrands indicates an expected call of GetBrands.
+func (mr *MockCatalogRepositoryMockRecorder) GetBrands() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrands", reflect.TypeOf((*MockCatalogRepository)(nil).GetBrands))
+}
+
+// GetModels mocks base method.
+func (m *MockCatalogRepository) GetModels(brandID string) ([]model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModels", brandID)
+	ret0, _ := ret[0].([]model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModels indicates an expected call of GetModels.
+func (mr *MockCatalogRepositoryMockRecorder) GetModels(brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockCatalogRepository)(nil).GetModels), brandID)
+}
+
+// GetTrimDetails mocks base method.
+func (m *MockCatalogRepository) GetTrimDetails(trimIDs []string) ([]model.TrimDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrimDetails", trimIDs)
+	ret0, _ := ret[0].([]model.TrimDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrimDetails indicates an expected call of GetTrimDetails.
+func (mr *MockCatalogRepositoryMockRecorder) GetTrimDetails(trimIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrimDetails", reflect.TypeOf((*MockCatalogRepository)(nil).GetTrimDetails), trimIDs)
+}
+
+// GetTrims mocks base method.
+func (m *MockCatalogRepository) GetTrims(modelID string) ([]model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrims", modelID)
+	ret0, _ := ret[0].([]model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrims indicates an expected call of GetTrims.
+func (mr *MockCatalogRepositoryMockRecorder) GetTrims(modelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrims", reflect.TypeOf((*MockCatalogRepository)(nil).GetTrims), modelID)
+}
+
+// WithTenant mocks base method.
+func (m *MockCatalogRepository) WithTenant(tenantID string) repository.CatalogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.CatalogRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCatalogRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCatalogRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockCatalogRepository) WithTx(tx *sqlx.Tx) repository.CatalogRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.CatalogRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockCatalogRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockCatalogRepository)(nil).WithTx), tx)
+}
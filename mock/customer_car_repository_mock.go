@@ -13,6 +13,8 @@ import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +56,21 @@ func (mr *MockCustomerCarRepositoryMockRecorder) Create(customerCar any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerCarRepository)(nil).Create), customerCar)
 }
 
+// CreateBatch mocks base method.
+func (m *MockCustomerCarRepository) CreateBatch(customerCars []*model.CustomerCar) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", customerCars)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockCustomerCarRepositoryMockRecorder) CreateBatch(customerCars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCustomerCarRepository)(nil).CreateBatch), customerCars)
+}
+
 // Delete mocks base method.
 func (m *MockCustomerCarRepository) Delete(id string) error {
 	m.ctrl.T.Helper()
@@ -68,19 +85,34 @@ func (mr *MockCustomerCarRepositoryMockRecorder) Delete(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerCarRepository)(nil).Delete), id)
 }
 
+// DeleteBatch mocks base method.
+func (m *MockCustomerCarRepository) DeleteBatch(ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch.
+func (mr *MockCustomerCarRepositoryMockRecorder) DeleteBatch(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockCustomerCarRepository)(nil).DeleteBatch), ids)
+}
+
 // GetAll mocks base method.
-func (m *MockCustomerCarRepository) GetAll() ([]*model.CustomerCar, error) {
+func (m *MockCustomerCarRepository) GetAll(filter model.CustomerCarFilter) ([]*model.CustomerCar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", filter)
 	ret0, _ := ret[0].([]*model.CustomerCar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockCustomerCarRepositoryMockRecorder) GetAll() *gomock.Call {
+func (mr *MockCustomerCarRepositoryMockRecorder) GetAll(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetAll), filter)
 }
 
 // GetByCarID mocks base method.
@@ -98,6 +130,21 @@ func (mr *MockCustomerCarRepositoryMockRecorder) GetByCarID(carID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCarID", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByCarID), carID)
 }
 
+// GetByCarIDs mocks base method.
+func (m *MockCustomerCarRepository) GetByCarIDs(carIDs []string) ([]*model.CustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCarIDs", carIDs)
+	ret0, _ := ret[0].([]*model.CustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCarIDs indicates an expected call of GetByCarIDs.
+func (mr *MockCustomerCarRepositoryMockRecorder) GetByCarIDs(carIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCarIDs", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByCarIDs), carIDs)
+}
+
 // GetByCustomerID mocks base method.
 func (m *MockCustomerCarRepository) GetByCustomerID(customerID string) ([]*model.CustomerCar, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +160,21 @@ func (mr *MockCustomerCarRepositoryMockRecorder) GetByCustomerID(customerID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomerID", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByCustomerID), customerID)
 }
 
+// GetByCustomerIDs mocks base method.
+func (m *MockCustomerCarRepository) GetByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCustomerIDs", customerIDs)
+	ret0, _ := ret[0].([]*model.CustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCustomerIDs indicates an expected call of GetByCustomerIDs.
+func (mr *MockCustomerCarRepositoryMockRecorder) GetByCustomerIDs(customerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomerIDs", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByCustomerIDs), customerIDs)
+}
+
 // GetByID mocks base method.
 func (m *MockCustomerCarRepository) GetByID(id string) (*model.CustomerCar, error) {
 	m.ctrl.T.Helper()
@@ -128,6 +190,35 @@ func (mr *MockCustomerCarRepositoryMockRecorder) GetByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByID), id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockCustomerCarRepository) GetByIDs(ids []string) ([]*model.CustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]*model.CustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockCustomerCarRepositoryMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockCustomerCarRepository)(nil).GetByIDs), ids)
+}
+
+// Stream mocks base method.
+func (m *MockCustomerCarRepository) Stream(filter model.CustomerCarFilter, fn func(*model.CustomerCar) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockCustomerCarRepositoryMockRecorder) Stream(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockCustomerCarRepository)(nil).Stream), filter, fn)
+}
+
 // Update mocks base method.
 func (m *MockCustomerCarRepository) Update(id string, customerCar *model.CustomerCar) error {
 	m.ctrl.T.Helper()
@@ -141,3 +232,46 @@ func (mr *MockCustomerCarRepositoryMockRecorder) Update(id, customerCar any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerCarRepository)(nil).Update), id, customerCar)
 }
+
+// UpdateBatch mocks base method.
+func (m *MockCustomerCarRepository) UpdateBatch(customerCars []*model.CustomerCar) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatch", customerCars)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBatch indicates an expected call of UpdateBatch.
+func (mr *MockCustomerCarRepositoryMockRecorder) UpdateBatch(customerCars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatch", reflect.TypeOf((*MockCustomerCarRepository)(nil).UpdateBatch), customerCars)
+}
+
+// WithTenant mocks base method.
+func (m *MockCustomerCarRepository) WithTenant(tenantID string) repository.CustomerCarRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.CustomerCarRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCustomerCarRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCustomerCarRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockCustomerCarRepository) WithTx(tx *sqlx.Tx) repository.CustomerCarRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.CustomerCarRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockCustomerCarRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockCustomerCarRepository)(nil).WithTx), tx)
+}
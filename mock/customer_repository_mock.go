@@ -13,6 +13,8 @@ import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +56,21 @@ func (mr *MockCustomerRepositoryMockRecorder) Create(customer any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomerRepository)(nil).Create), customer)
 }
 
+// CreateBatch mocks base method.
+func (m *MockCustomerRepository) CreateBatch(customers []*model.Customer) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", customers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockCustomerRepositoryMockRecorder) CreateBatch(customers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockCustomerRepository)(nil).CreateBatch), customers)
+}
+
 // Delete mocks base method.
 func (m *MockCustomerRepository) Delete(id string) error {
 	m.ctrl.T.Helper()
@@ -68,6 +85,21 @@ func (mr *MockCustomerRepositoryMockRecorder) Delete(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomerRepository)(nil).Delete), id)
 }
 
+// DeleteBatch mocks base method.
+func (m *MockCustomerRepository) DeleteBatch(ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch.
+func (mr *MockCustomerRepositoryMockRecorder) DeleteBatch(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockCustomerRepository)(nil).DeleteBatch), ids)
+}
+
 // Get mocks base method.
 func (m *MockCustomerRepository) Get(id string) (*model.Customer, error) {
 	m.ctrl.T.Helper()
@@ -84,18 +116,62 @@ func (mr *MockCustomerRepositoryMockRecorder) Get(id any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockCustomerRepository) GetAll() ([]*model.Customer, error) {
+func (m *MockCustomerRepository) GetAll(filter model.CustomerFilter) ([]*model.Customer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", filter)
 	ret0, _ := ret[0].([]*model.Customer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockCustomerRepositoryMockRecorder) GetAll() *gomock.Call {
+func (mr *MockCustomerRepositoryMockRecorder) GetAll(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomerRepository)(nil).GetAll), filter)
+}
+
+// GetByIDs mocks base method.
+func (m *MockCustomerRepository) GetByIDs(ids []string) ([]*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockCustomerRepositoryMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockCustomerRepository)(nil).GetByIDs), ids)
+}
+
+// Reencrypt mocks base method.
+func (m *MockCustomerRepository) Reencrypt(limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reencrypt", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reencrypt indicates an expected call of Reencrypt.
+func (mr *MockCustomerRepositoryMockRecorder) Reencrypt(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reencrypt", reflect.TypeOf((*MockCustomerRepository)(nil).Reencrypt), limit)
+}
+
+// Stream mocks base method.
+func (m *MockCustomerRepository) Stream(filter model.CustomerFilter, fn func(*model.Customer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockCustomerRepositoryMockRecorder) Stream(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockCustomerRepository)(nil).Stream), filter, fn)
 }
 
 // Update mocks base method.
@@ -111,3 +187,46 @@ func (mr *MockCustomerRepositoryMockRecorder) Update(id, customer any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomerRepository)(nil).Update), id, customer)
 }
+
+// UpdateBatch mocks base method.
+func (m *MockCustomerRepository) UpdateBatch(customers []*model.Customer) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatch", customers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBatch indicates an expected call of UpdateBatch.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateBatch(customers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatch", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateBatch), customers)
+}
+
+// WithTenant mocks base method.
+func (m *MockCustomerRepository) WithTenant(tenantID string) repository.CustomerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.CustomerRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCustomerRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCustomerRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockCustomerRepository) WithTx(tx *sqlx.Tx) repository.CustomerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.CustomerRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockCustomerRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockCustomerRepository)(nil).WithTx), tx)
+}
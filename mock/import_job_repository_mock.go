@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: ImportJobRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/import_job_repository_mock.go -package=mock github.com/GoodsChain/backend/repository ImportJobRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportJobRepository is a mock of ImportJobRepository interface.
+type MockImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImportJobRepositoryMockRecorder is the mock recorder for MockImportJobRepository.
+type MockImportJobRepositoryMockRecorder struct {
+	mock *MockImportJobRepository
+}
+
+// NewMockImportJobRepository creates a new mock instance.
+func NewMockImportJobRepository(ctrl *gomock.Controller) *MockImportJobRepository {
+	mock := &MockImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobRepository) EXPECT() *MockImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImportJobRepository) Create(job *model.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportJobRepositoryMockRecorder) Create(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportJobRepository)(nil).Create), job)
+}
+
+// GetByID mocks base method.
+func (m *MockImportJobRepository) GetByID(id string) (*model.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImportJobRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImportJobRepository)(nil).GetByID), id)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockImportJobRepository) UpdateProgress(id string, processedRows int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", id, processedRows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockImportJobRepositoryMockRecorder) UpdateProgress(id, processedRows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockImportJobRepository)(nil).UpdateProgress), id, processedRows)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockImportJobRepository) UpdateStatus(id, status, errMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, status, errMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockImportJobRepositoryMockRecorder) UpdateStatus(id, status, errMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockImportJobRepository)(nil).UpdateStatus), id, status, errMessage)
+}
+
+// WithTenant mocks base method.
+func (m *MockImportJobRepository) WithTenant(tenantID string) repository.ImportJobRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.ImportJobRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockImportJobRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockImportJobRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockImportJobRepository) WithTx(tx *sqlx.Tx) repository.ImportJobRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.ImportJobRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockImportJobRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockImportJobRepository)(nil).WithTx), tx)
+}
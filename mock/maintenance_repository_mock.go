@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: MaintenanceRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/maintenance_repository_mock.go -package=mock github.com/GoodsChain/backend/repository MaintenanceRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMaintenanceRepository is a mock of MaintenanceRepository interface.
+type MockMaintenanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceRepositoryMockRecorder is the mock recorder for MockMaintenanceRepository.
+type MockMaintenanceRepositoryMockRecorder struct {
+	mock *MockMaintenanceRepository
+}
+
+// NewMockMaintenanceRepository creates a new mock instance.
+func NewMockMaintenanceRepository(ctrl *gomock.Controller) *MockMaintenanceRepository {
+	mock := &MockMaintenanceRepository{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceRepository) EXPECT() *MockMaintenanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRecord mocks base method.
+func (m *MockMaintenanceRepository) CreateRecord(record *model.MaintenanceRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecord", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecord indicates an expected call of CreateRecord.
+func (mr *MockMaintenanceRepositoryMockRecorder) CreateRecord(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*MockMaintenanceRepository)(nil).CreateRecord), record)
+}
+
+// EraseCustomerRecords mocks base method.
+func (m *MockMaintenanceRepository) EraseCustomerRecords(customerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCustomerRecords", customerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseCustomerRecords indicates an expected call of EraseCustomerRecords.
+func (mr *MockMaintenanceRepositoryMockRecorder) EraseCustomerRecords(customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCustomerRecords", reflect.TypeOf((*MockMaintenanceRepository)(nil).EraseCustomerRecords), customerID)
+}
+
+// GetCustomerRecords mocks base method.
+func (m *MockMaintenanceRepository) GetCustomerRecords(customerID string) ([]model.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerRecords", customerID)
+	ret0, _ := ret[0].([]model.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerRecords indicates an expected call of GetCustomerRecords.
+func (mr *MockMaintenanceRepositoryMockRecorder) GetCustomerRecords(customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerRecords", reflect.TypeOf((*MockMaintenanceRepository)(nil).GetCustomerRecords), customerID)
+}
+
+// GetRecords mocks base method.
+func (m *MockMaintenanceRepository) GetRecords(customerCarID string) ([]model.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecords", customerCarID)
+	ret0, _ := ret[0].([]model.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecords indicates an expected call of GetRecords.
+func (mr *MockMaintenanceRepositoryMockRecorder) GetRecords(customerCarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecords", reflect.TypeOf((*MockMaintenanceRepository)(nil).GetRecords), customerCarID)
+}
+
+// LockCustomerCar mocks base method.
+func (m *MockMaintenanceRepository) LockCustomerCar(customerCarID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCustomerCar", customerCarID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCustomerCar indicates an expected call of LockCustomerCar.
+func (mr *MockMaintenanceRepositoryMockRecorder) LockCustomerCar(customerCarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCustomerCar", reflect.TypeOf((*MockMaintenanceRepository)(nil).LockCustomerCar), customerCarID)
+}
+
+// WithTenant mocks base method.
+func (m *MockMaintenanceRepository) WithTenant(tenantID string) repository.MaintenanceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.MaintenanceRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockMaintenanceRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockMaintenanceRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockMaintenanceRepository) WithTx(tx *sqlx.Tx) repository.MaintenanceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.MaintenanceRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockMaintenanceRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockMaintenanceRepository)(nil).WithTx), tx)
+}
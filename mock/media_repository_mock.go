@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: MediaRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/media_repository_mock.go -package=mock github.com/GoodsChain/backend/repository MediaRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMediaRepository is a mock of MediaRepository interface.
+type MockMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
+type MockMediaRepositoryMockRecorder struct {
+	mock *MockMediaRepository
+}
+
+// NewMockMediaRepository creates a new mock instance.
+func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
+	mock := &MockMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMedia mocks base method.
+func (m *MockMediaRepository) CreateMedia(media *model.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedia", media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockMediaRepositoryMockRecorder) CreateMedia(media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockMediaRepository)(nil).CreateMedia), media)
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaRepository) DeleteMedia(carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaRepositoryMockRecorder) DeleteMedia(carID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaRepository)(nil).DeleteMedia), carID, id)
+}
+
+// DeleteOrphanedFiles mocks base method.
+func (m *MockMediaRepository) DeleteOrphanedFiles(keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedFiles", keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrphanedFiles indicates an expected call of DeleteOrphanedFiles.
+func (mr *MockMediaRepositoryMockRecorder) DeleteOrphanedFiles(keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedFiles", reflect.TypeOf((*MockMediaRepository)(nil).DeleteOrphanedFiles), keys)
+}
+
+// GetMedia mocks base method.
+func (m *MockMediaRepository) GetMedia(id string) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", id)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockMediaRepositoryMockRecorder) GetMedia(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockMediaRepository)(nil).GetMedia), id)
+}
+
+// GetMediaByCarID mocks base method.
+func (m *MockMediaRepository) GetMediaByCarID(carID string) ([]model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaByCarID", carID)
+	ret0, _ := ret[0].([]model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaByCarID indicates an expected call of GetMediaByCarID.
+func (mr *MockMediaRepositoryMockRecorder) GetMediaByCarID(carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaByCarID", reflect.TypeOf((*MockMediaRepository)(nil).GetMediaByCarID), carID)
+}
+
+// GetOrphanedFiles mocks base method.
+func (m *MockMediaRepository) GetOrphanedFiles(limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanedFiles", limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanedFiles indicates an expected call of GetOrphanedFiles.
+func (mr *MockMediaRepositoryMockRecorder) GetOrphanedFiles(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanedFiles", reflect.TypeOf((*MockMediaRepository)(nil).GetOrphanedFiles), limit)
+}
+
+// WithTenant mocks base method.
+func (m *MockMediaRepository) WithTenant(tenantID string) repository.MediaRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.MediaRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockMediaRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockMediaRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockMediaRepository) WithTx(tx *sqlx.Tx) repository.MediaRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.MediaRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockMediaRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockMediaRepository)(nil).WithTx), tx)
+}
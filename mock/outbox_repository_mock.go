@@ -0,0 +1,237 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: OutboxRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/outbox_repository_mock.go -package=mock github.com/GoodsChain/backend/repository OutboxRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	json "encoding/json"
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOutboxRepository) Append(events ...*model.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOutboxRepositoryMockRecorder) Append(events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOutboxRepository)(nil).Append), events...)
+}
+
+// ClaimUndispatched mocks base method.
+func (m *MockOutboxRepository) ClaimUndispatched(limit int) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUndispatched", limit)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUndispatched indicates an expected call of ClaimUndispatched.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimUndispatched(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUndispatched", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimUndispatched), limit)
+}
+
+// GetCustomerEvents mocks base method.
+func (m *MockOutboxRepository) GetCustomerEvents(customerID string) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerEvents", customerID)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerEvents indicates an expected call of GetCustomerEvents.
+func (mr *MockOutboxRepositoryMockRecorder) GetCustomerEvents(customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerEvents", reflect.TypeOf((*MockOutboxRepository)(nil).GetCustomerEvents), customerID)
+}
+
+// GetEventsAfter mocks base method.
+func (m *MockOutboxRepository) GetEventsAfter(pos model.EventPosition, tenantID string, types []string, limit int) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsAfter", pos, tenantID, types, limit)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsAfter indicates an expected call of GetEventsAfter.
+func (mr *MockOutboxRepositoryMockRecorder) GetEventsAfter(pos, tenantID, types, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsAfter", reflect.TypeOf((*MockOutboxRepository)(nil).GetEventsAfter), pos, tenantID, types, limit)
+}
+
+// GetLatestPosition mocks base method.
+func (m *MockOutboxRepository) GetLatestPosition() (model.EventPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestPosition")
+	ret0, _ := ret[0].(model.EventPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestPosition indicates an expected call of GetLatestPosition.
+func (mr *MockOutboxRepositoryMockRecorder) GetLatestPosition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestPosition", reflect.TypeOf((*MockOutboxRepository)(nil).GetLatestPosition))
+}
+
+// GetPosition mocks base method.
+func (m *MockOutboxRepository) GetPosition(seq int64) (model.EventPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosition", seq)
+	ret0, _ := ret[0].(model.EventPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosition indicates an expected call of GetPosition.
+func (mr *MockOutboxRepositoryMockRecorder) GetPosition(seq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosition", reflect.TypeOf((*MockOutboxRepository)(nil).GetPosition), seq)
+}
+
+// GetUnpublished mocks base method.
+func (m *MockOutboxRepository) GetUnpublished(limit int) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpublished", limit)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpublished indicates an expected call of GetUnpublished.
+func (mr *MockOutboxRepositoryMockRecorder) GetUnpublished(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpublished", reflect.TypeOf((*MockOutboxRepository)(nil).GetUnpublished), limit)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockOutboxRepository) MarkDispatched(ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockOutboxRepositoryMockRecorder) MarkDispatched(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockOutboxRepository)(nil).MarkDispatched), ids)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxRepository) MarkPublished(ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxRepositoryMockRecorder) MarkPublished(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkPublished), ids)
+}
+
+// RedactEvents mocks base method.
+func (m *MockOutboxRepository) RedactEvents(aggregateType, aggregateID string, types []string, redacted json.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactEvents", aggregateType, aggregateID, types, redacted)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactEvents indicates an expected call of RedactEvents.
+func (mr *MockOutboxRepositoryMockRecorder) RedactEvents(aggregateType, aggregateID, types, redacted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactEvents", reflect.TypeOf((*MockOutboxRepository)(nil).RedactEvents), aggregateType, aggregateID, types, redacted)
+}
+
+// TryLockPublisher mocks base method.
+func (m *MockOutboxRepository) TryLockPublisher() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockPublisher")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLockPublisher indicates an expected call of TryLockPublisher.
+func (mr *MockOutboxRepositoryMockRecorder) TryLockPublisher() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockPublisher", reflect.TypeOf((*MockOutboxRepository)(nil).TryLockPublisher))
+}
+
+// WithTenant mocks base method.
+func (m *MockOutboxRepository) WithTenant(tenantID string) repository.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.OutboxRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockOutboxRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockOutboxRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockOutboxRepository) WithTx(tx *sqlx.Tx) repository.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.OutboxRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockOutboxRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockOutboxRepository)(nil).WithTx), tx)
+}
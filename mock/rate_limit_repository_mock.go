@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: RateLimitRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/rate_limit_repository_mock.go -package=mock github.com/GoodsChain/backend/repository RateLimitRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateLimitRepository is a mock of RateLimitRepository interface.
+type MockRateLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitRepositoryMockRecorder is the mock recorder for MockRateLimitRepository.
+type MockRateLimitRepositoryMockRecorder struct {
+	mock *MockRateLimitRepository
+}
+
+// NewMockRateLimitRepository creates a new mock instance.
+func NewMockRateLimitRepository(ctrl *gomock.Controller) *MockRateLimitRepository {
+	mock := &MockRateLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockRateLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitRepository) EXPECT() *MockRateLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteIdleBuckets mocks base method.
+func (m *MockRateLimitRepository) DeleteIdleBuckets(idle time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdleBuckets", idle)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIdleBuckets indicates an expected call of DeleteIdleBuckets.
+func (mr *MockRateLimitRepositoryMockRecorder) DeleteIdleBuckets(idle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdleBuckets", reflect.TypeOf((*MockRateLimitRepository)(nil).DeleteIdleBuckets), idle)
+}
+
+// LockBucket mocks base method.
+func (m *MockRateLimitRepository) LockBucket(key string, capacity float64) (*model.RateLimitBucket, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockBucket", key, capacity)
+	ret0, _ := ret[0].(*model.RateLimitBucket)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LockBucket indicates an expected call of LockBucket.
+func (mr *MockRateLimitRepositoryMockRecorder) LockBucket(key, capacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockBucket", reflect.TypeOf((*MockRateLimitRepository)(nil).LockBucket), key, capacity)
+}
+
+// SaveBucket mocks base method.
+func (m *MockRateLimitRepository) SaveBucket(bucket *model.RateLimitBucket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBucket", bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBucket indicates an expected call of SaveBucket.
+func (mr *MockRateLimitRepositoryMockRecorder) SaveBucket(bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBucket", reflect.TypeOf((*MockRateLimitRepository)(nil).SaveBucket), bucket)
+}
+
+// WithTx mocks base method.
+func (m *MockRateLimitRepository) WithTx(tx *sqlx.Tx) repository.RateLimitRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.RateLimitRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockRateLimitRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockRateLimitRepository)(nil).WithTx), tx)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: SearchRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/search_repository_mock.go -package=mock github.com/GoodsChain/backend/repository SearchRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchRepository is a mock of SearchRepository interface.
+type MockSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchRepositoryMockRecorder is the mock recorder for MockSearchRepository.
+type MockSearchRepositoryMockRecorder struct {
+	mock *MockSearchRepository
+}
+
+// NewMockSearchRepository creates a new mock instance.
+func NewMockSearchRepository(ctrl *gomock.Controller) *MockSearchRepository {
+	mock := &MockSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepository) EXPECT() *MockSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepository) Search(q string, limit int) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", q, limit)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepositoryMockRecorder) Search(q, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepository)(nil).Search), q, limit)
+}
+
+// WithTenant mocks base method.
+func (m *MockSearchRepository) WithTenant(tenantID string) repository.SearchRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.SearchRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockSearchRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockSearchRepository)(nil).WithTenant), tenantID)
+}
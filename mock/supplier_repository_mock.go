@@ -13,6 +13,8 @@ import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	repository "github.com/GoodsChain/backend/repository"
+	sqlx "github.com/jmoiron/sqlx"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,6 +56,21 @@ func (mr *MockSupplierRepositoryMockRecorder) Create(supplier any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierRepository)(nil).Create), supplier)
 }
 
+// CreateBatch mocks base method.
+func (m *MockSupplierRepository) CreateBatch(suppliers []*model.Supplier) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", suppliers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockSupplierRepositoryMockRecorder) CreateBatch(suppliers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockSupplierRepository)(nil).CreateBatch), suppliers)
+}
+
 // Delete mocks base method.
 func (m *MockSupplierRepository) Delete(id string) error {
 	m.ctrl.T.Helper()
@@ -68,6 +85,21 @@ func (mr *MockSupplierRepositoryMockRecorder) Delete(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierRepository)(nil).Delete), id)
 }
 
+// DeleteBatch mocks base method.
+func (m *MockSupplierRepository) DeleteBatch(ids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", ids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch.
+func (mr *MockSupplierRepositoryMockRecorder) DeleteBatch(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockSupplierRepository)(nil).DeleteBatch), ids)
+}
+
 // Get mocks base method.
 func (m *MockSupplierRepository) Get(id string) (*model.Supplier, error) {
 	m.ctrl.T.Helper()
@@ -84,18 +116,47 @@ func (mr *MockSupplierRepositoryMockRecorder) Get(id any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockSupplierRepository) GetAll() ([]*model.Supplier, error) {
+func (m *MockSupplierRepository) GetAll(filter model.SupplierFilter) ([]*model.Supplier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", filter)
 	ret0, _ := ret[0].([]*model.Supplier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockSupplierRepositoryMockRecorder) GetAll() *gomock.Call {
+func (mr *MockSupplierRepositoryMockRecorder) GetAll(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSupplierRepository)(nil).GetAll), filter)
+}
+
+// GetByIDs mocks base method.
+func (m *MockSupplierRepository) GetByIDs(ids []string) ([]*model.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]*model.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockSupplierRepositoryMockRecorder) GetByIDs(ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSupplierRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockSupplierRepository)(nil).GetByIDs), ids)
+}
+
+// Stream mocks base method.
+func (m *MockSupplierRepository) Stream(filter model.SupplierFilter, fn func(*model.Supplier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockSupplierRepositoryMockRecorder) Stream(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockSupplierRepository)(nil).Stream), filter, fn)
 }
 
 // Update mocks base method.
@@ -111,3 +172,46 @@ func (mr *MockSupplierRepositoryMockRecorder) Update(id, supplier any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierRepository)(nil).Update), id, supplier)
 }
+
+// UpdateBatch mocks base method.
+func (m *MockSupplierRepository) UpdateBatch(suppliers []*model.Supplier) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatch", suppliers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBatch indicates an expected call of UpdateBatch.
+func (mr *MockSupplierRepositoryMockRecorder) UpdateBatch(suppliers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatch", reflect.TypeOf((*MockSupplierRepository)(nil).UpdateBatch), suppliers)
+}
+
+// WithTenant mocks base method.
+func (m *MockSupplierRepository) WithTenant(tenantID string) repository.SupplierRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(repository.SupplierRepository)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockSupplierRepositoryMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockSupplierRepository)(nil).WithTenant), tenantID)
+}
+
+// WithTx mocks base method.
+func (m *MockSupplierRepository) WithTx(tx *sqlx.Tx) repository.SupplierRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(repository.SupplierRepository)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockSupplierRepositoryMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockSupplierRepository)(nil).WithTx), tx)
+}
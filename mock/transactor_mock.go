@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/repository (interfaces: Transactor)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/transactor_mock.go -package=mock github.com/GoodsChain/backend/repository Transactor
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	sqlx "github.com/jmoiron/sqlx"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinSavepoint mocks base method.
+func (m *MockTransactor) WithinSavepoint(tx *sqlx.Tx, fn func() error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinSavepoint", tx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinSavepoint indicates an expected call of WithinSavepoint.
+func (mr *MockTransactorMockRecorder) WithinSavepoint(tx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinSavepoint", reflect.TypeOf((*MockTransactor)(nil).WithinSavepoint), tx, fn)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(fn func(*sqlx.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), fn)
+}
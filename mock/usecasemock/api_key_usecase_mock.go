@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: APIKeyUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/api_key_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase APIKeyUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyUsecase is a mock of APIKeyUsecase interface.
+type MockAPIKeyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyUsecaseMockRecorder is the mock recorder for MockAPIKeyUsecase.
+type MockAPIKeyUsecaseMockRecorder struct {
+	mock *MockAPIKeyUsecase
+}
+
+// NewMockAPIKeyUsecase creates a new mock instance.
+func NewMockAPIKeyUsecase(ctrl *gomock.Controller) *MockAPIKeyUsecase {
+	mock := &MockAPIKeyUsecase{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyUsecase) EXPECT() *MockAPIKeyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeyUsecase) Authenticate(key string) (*model.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", key)
+	ret0, _ := ret[0].(*model.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyUsecaseMockRecorder) Authenticate(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeyUsecase)(nil).Authenticate), key)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockAPIKeyUsecase) GetAPIKey(id string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", id)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockAPIKeyUsecaseMockRecorder) GetAPIKey(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPIKeyUsecase)(nil).GetAPIKey), id)
+}
+
+// GetAllAPIKeys mocks base method.
+func (m *MockAPIKeyUsecase) GetAllAPIKeys() ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAPIKeys")
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAPIKeys indicates an expected call of GetAllAPIKeys.
+func (mr *MockAPIKeyUsecaseMockRecorder) GetAllAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAPIKeys", reflect.TypeOf((*MockAPIKeyUsecase)(nil).GetAllAPIKeys))
+}
+
+// IssueAPIKey mocks base method.
+func (m *MockAPIKeyUsecase) IssueAPIKey(req *model.APIKeyRequest) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueAPIKey", req)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueAPIKey indicates an expected call of IssueAPIKey.
+func (mr *MockAPIKeyUsecaseMockRecorder) IssueAPIKey(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueAPIKey", reflect.TypeOf((*MockAPIKeyUsecase)(nil).IssueAPIKey), req)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyUsecase) RevokeAPIKey(id, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyUsecaseMockRecorder) RevokeAPIKey(id, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyUsecase)(nil).RevokeAPIKey), id, actor)
+}
+
+// RotateAPIKey mocks base method.
+func (m *MockAPIKeyUsecase) RotateAPIKey(id, actor string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAPIKey", id, actor)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateAPIKey indicates an expected call of RotateAPIKey.
+func (mr *MockAPIKeyUsecaseMockRecorder) RotateAPIKey(id, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAPIKey", reflect.TypeOf((*MockAPIKeyUsecase)(nil).RotateAPIKey), id, actor)
+}
+
+// WithTenant mocks base method.
+func (m *MockAPIKeyUsecase) WithTenant(tenantID string) usecase.APIKeyUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.APIKeyUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockAPIKeyUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockAPIKeyUsecase)(nil).WithTenant), tenantID)
+}
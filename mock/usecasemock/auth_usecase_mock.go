@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: AuthUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/auth_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase AuthUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthUsecase is a mock of AuthUsecase interface.
+type MockAuthUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthUsecaseMockRecorder is the mock recorder for MockAuthUsecase.
+type MockAuthUsecaseMockRecorder struct {
+	mock *MockAuthUsecase
+}
+
+// NewMockAuthUsecase creates a new mock instance.
+func NewMockAuthUsecase(ctrl *gomock.Controller) *MockAuthUsecase {
+	mock := &MockAuthUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthUsecase) EXPECT() *MockAuthUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAuthUsecase) Authenticate(accessToken string) (*model.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", accessToken)
+	ret0, _ := ret[0].(*model.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAuthUsecaseMockRecorder) Authenticate(accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthUsecase)(nil).Authenticate), accessToken)
+}
+
+// ChangePassword mocks base method.
+func (m *MockAuthUsecase) ChangePassword(principal *model.Principal, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", principal, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthUsecaseMockRecorder) ChangePassword(principal, currentPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthUsecase)(nil).ChangePassword), principal, currentPassword, newPassword)
+}
+
+// Login mocks base method.
+func (m *MockAuthUsecase) Login(username, password string) (*model.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", username, password)
+	ret0, _ := ret[0].(*model.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthUsecaseMockRecorder) Login(username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUsecase)(nil).Login), username, password)
+}
+
+// Logout mocks base method.
+func (m *MockAuthUsecase) Logout(principal *model.Principal, all bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", principal, all)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUsecaseMockRecorder) Logout(principal, all any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUsecase)(nil).Logout), principal, all)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthUsecase) Refresh(refreshToken string) (*model.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshToken)
+	ret0, _ := ret[0].(*model.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthUsecaseMockRecorder) Refresh(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthUsecase)(nil).Refresh), refreshToken)
+}
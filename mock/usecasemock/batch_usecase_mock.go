@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: BatchUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/batch_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase BatchUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchUsecase is a mock of BatchUsecase interface.
+type MockBatchUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchUsecaseMockRecorder is the mock recorder for MockBatchUsecase.
+type MockBatchUsecaseMockRecorder struct {
+	mock *MockBatchUsecase
+}
+
+// NewMockBatchUsecase creates a new mock instance.
+func NewMockBatchUsecase(ctrl *gomock.Controller) *MockBatchUsecase {
+	mock := &MockBatchUsecase{ctrl: ctrl}
+	mock.recorder = &MockBatchUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchUsecase) EXPECT() *MockBatchUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockBatchUsecase) Execute(resource string, req *model.BatchRequest) (*model.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", resource, req)
+	ret0, _ := ret[0].(*model.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockBatchUsecaseMockRecorder) Execute(resource, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockBatchUsecase)(nil).Execute), resource, req)
+}
+
+// WithTenant mocks base method.
+func (m *MockBatchUsecase) WithTenant(tenantID string) usecase.BatchUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.BatchUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockBatchUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockBatchUsecase)(nil).WithTenant), tenantID)
+}
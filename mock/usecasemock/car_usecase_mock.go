@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: CarUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/car_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase CarUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCarUsecase is a mock of CarUsecase interface.
+type MockCarUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCarUsecaseMockRecorder is the mock recorder for MockCarUsecase.
+type MockCarUsecaseMockRecorder struct {
+	mock *MockCarUsecase
+}
+
+// NewMockCarUsecase creates a new mock instance.
+func NewMockCarUsecase(ctrl *gomock.Controller) *MockCarUsecase {
+	mock := &MockCarUsecase{ctrl: ctrl}
+	mock.recorder = &MockCarUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarUsecase) EXPECT() *MockCarUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CompareCars mocks base method.
+func (m *MockCarUsecase) CompareCars(ids []string) (*model.CarComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareCars", ids)
+	ret0, _ := ret[0].(*model.CarComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareCars indicates an expected call of CompareCars.
+func (mr *MockCarUsecaseMockRecorder) CompareCars(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareCars", reflect.TypeOf((*MockCarUsecase)(nil).CompareCars), ids)
+}
+
+// CreateCar mocks base method.
+func (m *MockCarUsecase) CreateCar(car *model.Car) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCar", car)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCar indicates an expected call of CreateCar.
+func (mr *MockCarUsecaseMockRecorder) CreateCar(car any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCar", reflect.TypeOf((*MockCarUsecase)(nil).CreateCar), car)
+}
+
+// DeleteCar mocks base method.
+func (m *MockCarUsecase) DeleteCar(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCar", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCar indicates an expected call of DeleteCar.
+func (mr *MockCarUsecaseMockRecorder) DeleteCar(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCar", reflect.TypeOf((*MockCarUsecase)(nil).DeleteCar), id)
+}
+
+// GetAllCars mocks base method.
+func (m *MockCarUsecase) GetAllCars(filter model.CarFilter) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCars", filter)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCars indicates an expected call of GetAllCars.
+func (mr *MockCarUsecaseMockRecorder) GetAllCars(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCars", reflect.TypeOf((*MockCarUsecase)(nil).GetAllCars), filter)
+}
+
+// GetCar mocks base method.
+func (m *MockCarUsecase) GetCar(id string) (*model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCar", id)
+	ret0, _ := ret[0].(*model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCar indicates an expected call of GetCar.
+func (mr *MockCarUsecaseMockRecorder) GetCar(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCar", reflect.TypeOf((*MockCarUsecase)(nil).GetCar), id)
+}
+
+// GetCarsByIDs mocks base method.
+func (m *MockCarUsecase) GetCarsByIDs(ids []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarsByIDs", ids)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsByIDs indicates an expected call of GetCarsByIDs.
+func (mr *MockCarUsecaseMockRecorder) GetCarsByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsByIDs", reflect.TypeOf((*MockCarUsecase)(nil).GetCarsByIDs), ids)
+}
+
+// GetCarsBySupplierIDs mocks base method.
+func (m *MockCarUsecase) GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarsBySupplierIDs", supplierIDs)
+	ret0, _ := ret[0].([]model.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarsBySupplierIDs indicates an expected call of GetCarsBySupplierIDs.
+func (mr *MockCarUsecaseMockRecorder) GetCarsBySupplierIDs(supplierIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsBySupplierIDs", reflect.TypeOf((*MockCarUsecase)(nil).GetCarsBySupplierIDs), supplierIDs)
+}
+
+// StreamCars mocks base method.
+func (m *MockCarUsecase) StreamCars(filter model.CarFilter, fn func(*model.Car) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCars", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCars indicates an expected call of StreamCars.
+func (mr *MockCarUsecaseMockRecorder) StreamCars(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCars", reflect.TypeOf((*MockCarUsecase)(nil).StreamCars), filter, fn)
+}
+
+// UpdateCar mocks base method.
+func (m *MockCarUsecase) UpdateCar(id string, car *model.Car) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCar", id, car)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCar indicates an expected call of UpdateCar.
+func (mr *MockCarUsecaseMockRecorder) UpdateCar(id, car any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCar", reflect.TypeOf((*MockCarUsecase)(nil).UpdateCar), id, car)
+}
+
+// WithTenant mocks base method.
+func (m *MockCarUsecase) WithTenant(tenantID string) usecase.CarUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.CarUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCarUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCarUsecase)(nil).WithTenant), tenantID)
+}
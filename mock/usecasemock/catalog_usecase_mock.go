@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: CatalogUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/catalog_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase CatalogUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogUsecase is a mock of CatalogUsecase interface.
+type MockCatalogUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogUsecaseMockRecorder is the mock recorder for MockCatalogUsecase.
+type MockCatalogUsecaseMockRecorder struct {
+	mock *MockCatalogUsecase
+}
+
+// NewMockCatalogUsecase creates a new mock instance.
+func NewMockCatalogUsecase(ctrl *gomock.Controller) *MockCatalogUsecase {
+	mock := &MockCatalogUsecase{ctrl: ctrl}
+	mock.recorder = &MockCatalogUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogUsecase) EXPECT() *MockCatalogUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogUsecase) CreateBrand(brand *model.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogUsecaseMockRecorder) CreateBrand(brand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogUsecase)(nil).CreateBrand), brand)
+}
+
+// CreateModel mocks base method.
+func (m *MockCatalogUsecase) CreateModel(carModel *model.CarModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", carModel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogUsecaseMockRecorder) CreateModel(carModel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogUsecase)(nil).CreateModel), carModel)
+}
+
+// CreateTrim mocks base method.
+func (m *MockCatalogUsecase) CreateTrim(trim *model.Trim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrim", trim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTrim indicates an expected call of CreateTrim.
+func (mr *MockCatalogUsecaseMockRecorder) CreateTrim(trim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrim", reflect.TypeOf((*MockCatalogUsecase)(nil).CreateTrim), trim)
+}
+
+// GetBrands mocks base method.
+func (m *MockCatalogUsecase) GetBrands() ([]model.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrands")
+	ret0, _ := ret[0].([]model.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrands indicates an expected call of GetBrands.
+func (mr *MockCatalogUsecaseMockRecorder) GetBrands() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrands", reflect.TypeOf((*MockCatalogUsecase)(nil).GetBrands))
+}
+
+// GetModels mocks base method.
+func (m *MockCatalogUsecase) GetModels(brandID string) ([]model.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModels", brandID)
+	ret0, _ := ret[0].([]model.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModels indicates an expected call of GetModels.
+func (mr *MockCatalogUsecaseMockRecorder) GetModels(brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockCatalogUsecase)(nil).GetModels), brandID)
+}
+
+// GetTrims mocks base method.
+func (m *MockCatalogUsecase) GetTrims(modelID string) ([]model.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrims", modelID)
+	ret0, _ := ret[0].([]model.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrims indicates an expected call of GetTrims.
+func (mr *MockCatalogUsecaseMockRecorder) GetTrims(modelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrims", reflect.TypeOf((*MockCatalogUsecase)(nil).GetTrims), modelID)
+}
+
+// WithTenant mocks base method.
+func (m *MockCatalogUsecase) WithTenant(tenantID string) usecase.CatalogUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.CatalogUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCatalogUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCatalogUsecase)(nil).WithTenant), tenantID)
+}
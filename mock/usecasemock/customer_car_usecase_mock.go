@@ -3,16 +3,17 @@
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock/customer_car_usecase_mock.go -package=mock github.com/GoodsChain/backend/usecase CustomerCarUsecase
+//	mockgen -destination=mock/usecasemock/customer_car_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase CustomerCarUsecase
 //
 
-// Package mock is a generated GoMock package.
-package mock
+// Package usecasemock is a generated GoMock package.
+package usecasemock
 
 import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,18 +70,18 @@ func (mr *MockCustomerCarUsecaseMockRecorder) DeleteCustomerCar(id any) *gomock.
 }
 
 // GetAllCustomerCars mocks base method.
-func (m *MockCustomerCarUsecase) GetAllCustomerCars() ([]*model.CustomerCar, error) {
+func (m *MockCustomerCarUsecase) GetAllCustomerCars(filter model.CustomerCarFilter) ([]*model.CustomerCar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCustomerCars")
+	ret := m.ctrl.Call(m, "GetAllCustomerCars", filter)
 	ret0, _ := ret[0].([]*model.CustomerCar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCustomerCars indicates an expected call of GetAllCustomerCars.
-func (mr *MockCustomerCarUsecaseMockRecorder) GetAllCustomerCars() *gomock.Call {
+func (mr *MockCustomerCarUsecaseMockRecorder) GetAllCustomerCars(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerCars", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetAllCustomerCars))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomerCars", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetAllCustomerCars), filter)
 }
 
 // GetCustomerCar mocks base method.
@@ -113,6 +114,21 @@ func (mr *MockCustomerCarUsecaseMockRecorder) GetCustomerCarsByCarID(carID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCarsByCarID", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetCustomerCarsByCarID), carID)
 }
 
+// GetCustomerCarsByCarIDs mocks base method.
+func (m *MockCustomerCarUsecase) GetCustomerCarsByCarIDs(carIDs []string) ([]*model.CustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerCarsByCarIDs", carIDs)
+	ret0, _ := ret[0].([]*model.CustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerCarsByCarIDs indicates an expected call of GetCustomerCarsByCarIDs.
+func (mr *MockCustomerCarUsecaseMockRecorder) GetCustomerCarsByCarIDs(carIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCarsByCarIDs", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetCustomerCarsByCarIDs), carIDs)
+}
+
 // GetCustomerCarsByCustomerID mocks base method.
 func (m *MockCustomerCarUsecase) GetCustomerCarsByCustomerID(customerID string) ([]*model.CustomerCar, error) {
 	m.ctrl.T.Helper()
@@ -128,6 +144,35 @@ func (mr *MockCustomerCarUsecaseMockRecorder) GetCustomerCarsByCustomerID(custom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCarsByCustomerID", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetCustomerCarsByCustomerID), customerID)
 }
 
+// GetCustomerCarsByCustomerIDs mocks base method.
+func (m *MockCustomerCarUsecase) GetCustomerCarsByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerCarsByCustomerIDs", customerIDs)
+	ret0, _ := ret[0].([]*model.CustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerCarsByCustomerIDs indicates an expected call of GetCustomerCarsByCustomerIDs.
+func (mr *MockCustomerCarUsecaseMockRecorder) GetCustomerCarsByCustomerIDs(customerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCarsByCustomerIDs", reflect.TypeOf((*MockCustomerCarUsecase)(nil).GetCustomerCarsByCustomerIDs), customerIDs)
+}
+
+// StreamCustomerCars mocks base method.
+func (m *MockCustomerCarUsecase) StreamCustomerCars(filter model.CustomerCarFilter, fn func(*model.CustomerCar) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCustomerCars", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCustomerCars indicates an expected call of StreamCustomerCars.
+func (mr *MockCustomerCarUsecaseMockRecorder) StreamCustomerCars(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCustomerCars", reflect.TypeOf((*MockCustomerCarUsecase)(nil).StreamCustomerCars), filter, fn)
+}
+
 // UpdateCustomerCar mocks base method.
 func (m *MockCustomerCarUsecase) UpdateCustomerCar(id string, customerCar *model.CustomerCar) error {
 	m.ctrl.T.Helper()
@@ -141,3 +186,17 @@ func (mr *MockCustomerCarUsecaseMockRecorder) UpdateCustomerCar(id, customerCar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomerCar", reflect.TypeOf((*MockCustomerCarUsecase)(nil).UpdateCustomerCar), id, customerCar)
 }
+
+// WithTenant mocks base method.
+func (m *MockCustomerCarUsecase) WithTenant(tenantID string) usecase.CustomerCarUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.CustomerCarUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCustomerCarUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCustomerCarUsecase)(nil).WithTenant), tenantID)
+}
@@ -3,16 +3,17 @@
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock/customer_usecase_mock.go -package=mock github.com/GoodsChain/backend/usecase CustomerUsecase
+//	mockgen -destination=mock/usecasemock/customer_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase CustomerUsecase
 //
 
-// Package mock is a generated GoMock package.
-package mock
+// Package usecasemock is a generated GoMock package.
+package usecasemock
 
 import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,18 +70,18 @@ func (mr *MockCustomerUsecaseMockRecorder) DeleteCustomer(id any) *gomock.Call {
 }
 
 // GetAllCustomers mocks base method.
-func (m *MockCustomerUsecase) GetAllCustomers() ([]*model.Customer, error) {
+func (m *MockCustomerUsecase) GetAllCustomers(filter model.CustomerFilter) ([]*model.Customer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCustomers")
+	ret := m.ctrl.Call(m, "GetAllCustomers", filter)
 	ret0, _ := ret[0].([]*model.Customer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCustomers indicates an expected call of GetAllCustomers.
-func (mr *MockCustomerUsecaseMockRecorder) GetAllCustomers() *gomock.Call {
+func (mr *MockCustomerUsecaseMockRecorder) GetAllCustomers(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomers", reflect.TypeOf((*MockCustomerUsecase)(nil).GetAllCustomers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCustomers", reflect.TypeOf((*MockCustomerUsecase)(nil).GetAllCustomers), filter)
 }
 
 // GetCustomer mocks base method.
@@ -98,6 +99,35 @@ func (mr *MockCustomerUsecaseMockRecorder) GetCustomer(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomerUsecase)(nil).GetCustomer), id)
 }
 
+// GetCustomersByIDs mocks base method.
+func (m *MockCustomerUsecase) GetCustomersByIDs(ids []string) ([]*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomersByIDs", ids)
+	ret0, _ := ret[0].([]*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomersByIDs indicates an expected call of GetCustomersByIDs.
+func (mr *MockCustomerUsecaseMockRecorder) GetCustomersByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomersByIDs", reflect.TypeOf((*MockCustomerUsecase)(nil).GetCustomersByIDs), ids)
+}
+
+// StreamCustomers mocks base method.
+func (m *MockCustomerUsecase) StreamCustomers(filter model.CustomerFilter, fn func(*model.Customer) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCustomers", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCustomers indicates an expected call of StreamCustomers.
+func (mr *MockCustomerUsecaseMockRecorder) StreamCustomers(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCustomers", reflect.TypeOf((*MockCustomerUsecase)(nil).StreamCustomers), filter, fn)
+}
+
 // UpdateCustomer mocks base method.
 func (m *MockCustomerUsecase) UpdateCustomer(id string, customer *model.Customer) error {
 	m.ctrl.T.Helper()
@@ -111,3 +141,17 @@ func (mr *MockCustomerUsecaseMockRecorder) UpdateCustomer(id, customer any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerUsecase)(nil).UpdateCustomer), id, customer)
 }
+
+// WithTenant mocks base method.
+func (m *MockCustomerUsecase) WithTenant(tenantID string) usecase.CustomerUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.CustomerUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCustomerUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCustomerUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: ExpandUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/expand_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase ExpandUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExpandUsecase is a mock of ExpandUsecase interface.
+type MockExpandUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpandUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockExpandUsecaseMockRecorder is the mock recorder for MockExpandUsecase.
+type MockExpandUsecaseMockRecorder struct {
+	mock *MockExpandUsecase
+}
+
+// NewMockExpandUsecase creates a new mock instance.
+func NewMockExpandUsecase(ctrl *gomock.Controller) *MockExpandUsecase {
+	mock := &MockExpandUsecase{ctrl: ctrl}
+	mock.recorder = &MockExpandUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExpandUsecase) EXPECT() *MockExpandUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ExpandCars mocks base method.
+func (m *MockExpandUsecase) ExpandCars(cars []*model.Car, expand model.Expand) ([]*model.ExpandedCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandCars", cars, expand)
+	ret0, _ := ret[0].([]*model.ExpandedCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandCars indicates an expected call of ExpandCars.
+func (mr *MockExpandUsecaseMockRecorder) ExpandCars(cars, expand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCars", reflect.TypeOf((*MockExpandUsecase)(nil).ExpandCars), cars, expand)
+}
+
+// ExpandCustomerCars mocks base method.
+func (m *MockExpandUsecase) ExpandCustomerCars(customerCars []*model.CustomerCar, expand model.Expand) ([]*model.ExpandedCustomerCar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandCustomerCars", customerCars, expand)
+	ret0, _ := ret[0].([]*model.ExpandedCustomerCar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandCustomerCars indicates an expected call of ExpandCustomerCars.
+func (mr *MockExpandUsecaseMockRecorder) ExpandCustomerCars(customerCars, expand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCustomerCars", reflect.TypeOf((*MockExpandUsecase)(nil).ExpandCustomerCars), customerCars, expand)
+}
+
+// ExpandCustomers mocks base method.
+func (m *MockExpandUsecase) ExpandCustomers(customers []*model.Customer, expand model.Expand) ([]*model.ExpandedCustomer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandCustomers", customers, expand)
+	ret0, _ := ret[0].([]*model.ExpandedCustomer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandCustomers indicates an expected call of ExpandCustomers.
+func (mr *MockExpandUsecaseMockRecorder) ExpandCustomers(customers, expand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCustomers", reflect.TypeOf((*MockExpandUsecase)(nil).ExpandCustomers), customers, expand)
+}
+
+// ExpandSuppliers mocks base method.
+func (m *MockExpandUsecase) ExpandSuppliers(suppliers []*model.Supplier, expand model.Expand) ([]*model.ExpandedSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandSuppliers", suppliers, expand)
+	ret0, _ := ret[0].([]*model.ExpandedSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandSuppliers indicates an expected call of ExpandSuppliers.
+func (mr *MockExpandUsecaseMockRecorder) ExpandSuppliers(suppliers, expand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandSuppliers", reflect.TypeOf((*MockExpandUsecase)(nil).ExpandSuppliers), suppliers, expand)
+}
+
+// ParseExpand mocks base method.
+func (m *MockExpandUsecase) ParseExpand(resource, raw string) (model.Expand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseExpand", resource, raw)
+	ret0, _ := ret[0].(model.Expand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseExpand indicates an expected call of ParseExpand.
+func (mr *MockExpandUsecaseMockRecorder) ParseExpand(resource, raw any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseExpand", reflect.TypeOf((*MockExpandUsecase)(nil).ParseExpand), resource, raw)
+}
+
+// WithContactMask mocks base method.
+func (m *MockExpandUsecase) WithContactMask(customers, suppliers bool) usecase.ExpandUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContactMask", customers, suppliers)
+	ret0, _ := ret[0].(usecase.ExpandUsecase)
+	return ret0
+}
+
+// WithContactMask indicates an expected call of WithContactMask.
+func (mr *MockExpandUsecaseMockRecorder) WithContactMask(customers, suppliers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContactMask", reflect.TypeOf((*MockExpandUsecase)(nil).WithContactMask), customers, suppliers)
+}
+
+// WithTenant mocks base method.
+func (m *MockExpandUsecase) WithTenant(tenantID string) usecase.ExpandUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.ExpandUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockExpandUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockExpandUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: ImportUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/import_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase ImportUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportUsecase is a mock of ImportUsecase interface.
+type MockImportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockImportUsecaseMockRecorder is the mock recorder for MockImportUsecase.
+type MockImportUsecaseMockRecorder struct {
+	mock *MockImportUsecase
+}
+
+// NewMockImportUsecase creates a new mock instance.
+func NewMockImportUsecase(ctrl *gomock.Controller) *MockImportUsecase {
+	mock := &MockImportUsecase{ctrl: ctrl}
+	mock.recorder = &MockImportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportUsecase) EXPECT() *MockImportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetJob mocks base method.
+func (m *MockImportUsecase) GetJob(id string) (*model.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", id)
+	ret0, _ := ret[0].(*model.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockImportUsecaseMockRecorder) GetJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockImportUsecase)(nil).GetJob), id)
+}
+
+// Import mocks base method.
+func (m *MockImportUsecase) Import(req *model.ImportRequest) (*model.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", req)
+	ret0, _ := ret[0].(*model.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockImportUsecaseMockRecorder) Import(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImportUsecase)(nil).Import), req)
+}
+
+// ResumeJob mocks base method.
+func (m *MockImportUsecase) ResumeJob(id string) (*model.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeJob", id)
+	ret0, _ := ret[0].(*model.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeJob indicates an expected call of ResumeJob.
+func (mr *MockImportUsecaseMockRecorder) ResumeJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeJob", reflect.TypeOf((*MockImportUsecase)(nil).ResumeJob), id)
+}
+
+// WithTenant mocks base method.
+func (m *MockImportUsecase) WithTenant(tenantID string) usecase.ImportUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.ImportUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockImportUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockImportUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: MaintenanceUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/maintenance_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase MaintenanceUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMaintenanceUsecase is a mock of MaintenanceUsecase interface.
+type MockMaintenanceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceUsecaseMockRecorder is the mock recorder for MockMaintenanceUsecase.
+type MockMaintenanceUsecaseMockRecorder struct {
+	mock *MockMaintenanceUsecase
+}
+
+// NewMockMaintenanceUsecase creates a new mock instance.
+func NewMockMaintenanceUsecase(ctrl *gomock.Controller) *MockMaintenanceUsecase {
+	mock := &MockMaintenanceUsecase{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceUsecase) EXPECT() *MockMaintenanceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddRecord mocks base method.
+func (m *MockMaintenanceUsecase) AddRecord(customerCarID string, record *model.MaintenanceRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecord", customerCarID, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecord indicates an expected call of AddRecord.
+func (mr *MockMaintenanceUsecaseMockRecorder) AddRecord(customerCarID, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecord", reflect.TypeOf((*MockMaintenanceUsecase)(nil).AddRecord), customerCarID, record)
+}
+
+// GetRecords mocks base method.
+func (m *MockMaintenanceUsecase) GetRecords(customerCarID string) ([]model.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecords", customerCarID)
+	ret0, _ := ret[0].([]model.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecords indicates an expected call of GetRecords.
+func (mr *MockMaintenanceUsecaseMockRecorder) GetRecords(customerCarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecords", reflect.TypeOf((*MockMaintenanceUsecase)(nil).GetRecords), customerCarID)
+}
+
+// GetServiceDue mocks base method.
+func (m *MockMaintenanceUsecase) GetServiceDue(customerCarID string) (*model.ServiceDue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceDue", customerCarID)
+	ret0, _ := ret[0].(*model.ServiceDue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceDue indicates an expected call of GetServiceDue.
+func (mr *MockMaintenanceUsecaseMockRecorder) GetServiceDue(customerCarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceDue", reflect.TypeOf((*MockMaintenanceUsecase)(nil).GetServiceDue), customerCarID)
+}
+
+// WithTenant mocks base method.
+func (m *MockMaintenanceUsecase) WithTenant(tenantID string) usecase.MaintenanceUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.MaintenanceUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockMaintenanceUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockMaintenanceUsecase)(nil).WithTenant), tenantID)
+}
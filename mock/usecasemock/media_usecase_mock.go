@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: MediaUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/media_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase MediaUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMediaUsecase is a mock of MediaUsecase interface.
+type MockMediaUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaUsecaseMockRecorder is the mock recorder for MockMediaUsecase.
+type MockMediaUsecaseMockRecorder struct {
+	mock *MockMediaUsecase
+}
+
+// NewMockMediaUsecase creates a new mock instance.
+func NewMockMediaUsecase(ctrl *gomock.Controller) *MockMediaUsecase {
+	mock := &MockMediaUsecase{ctrl: ctrl}
+	mock.recorder = &MockMediaUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaUsecase) EXPECT() *MockMediaUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaUsecase) DeleteMedia(carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaUsecaseMockRecorder) DeleteMedia(carID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaUsecase)(nil).DeleteMedia), carID, id)
+}
+
+// GetCarMedia mocks base method.
+func (m *MockMediaUsecase) GetCarMedia(carID string) ([]model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarMedia", carID)
+	ret0, _ := ret[0].([]model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarMedia indicates an expected call of GetCarMedia.
+func (mr *MockMediaUsecaseMockRecorder) GetCarMedia(carID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarMedia", reflect.TypeOf((*MockMediaUsecase)(nil).GetCarMedia), carID)
+}
+
+// OpenMedia mocks base method.
+func (m *MockMediaUsecase) OpenMedia(link model.MediaLink) (*usecase.MediaFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMedia", link)
+	ret0, _ := ret[0].(*usecase.MediaFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenMedia indicates an expected call of OpenMedia.
+func (mr *MockMediaUsecaseMockRecorder) OpenMedia(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMedia", reflect.TypeOf((*MockMediaUsecase)(nil).OpenMedia), link)
+}
+
+// RemoveOrphanedFiles mocks base method.
+func (m *MockMediaUsecase) RemoveOrphanedFiles(limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrphanedFiles", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveOrphanedFiles indicates an expected call of RemoveOrphanedFiles.
+func (mr *MockMediaUsecaseMockRecorder) RemoveOrphanedFiles(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrphanedFiles", reflect.TypeOf((*MockMediaUsecase)(nil).RemoveOrphanedFiles), limit)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaUsecase) UploadMedia(upload *model.MediaUpload) (*model.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", upload)
+	ret0, _ := ret[0].(*model.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaUsecaseMockRecorder) UploadMedia(upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaUsecase)(nil).UploadMedia), upload)
+}
+
+// WithTenant mocks base method.
+func (m *MockMediaUsecase) WithTenant(tenantID string) usecase.MediaUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.MediaUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockMediaUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockMediaUsecase)(nil).WithTenant), tenantID)
+}
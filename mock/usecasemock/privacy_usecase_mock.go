@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: PrivacyUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/privacy_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase PrivacyUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPrivacyUsecase is a mock of PrivacyUsecase interface.
+type MockPrivacyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPrivacyUsecaseMockRecorder is the mock recorder for MockPrivacyUsecase.
+type MockPrivacyUsecaseMockRecorder struct {
+	mock *MockPrivacyUsecase
+}
+
+// NewMockPrivacyUsecase creates a new mock instance.
+func NewMockPrivacyUsecase(ctrl *gomock.Controller) *MockPrivacyUsecase {
+	mock := &MockPrivacyUsecase{ctrl: ctrl}
+	mock.recorder = &MockPrivacyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacyUsecase) EXPECT() *MockPrivacyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// EraseCustomer mocks base method.
+func (m *MockPrivacyUsecase) EraseCustomer(id, actor string) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCustomer", id, actor)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseCustomer indicates an expected call of EraseCustomer.
+func (mr *MockPrivacyUsecaseMockRecorder) EraseCustomer(id, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCustomer", reflect.TypeOf((*MockPrivacyUsecase)(nil).EraseCustomer), id, actor)
+}
+
+// ExportCustomerData mocks base method.
+func (m *MockPrivacyUsecase) ExportCustomerData(id, actor string) (*model.CustomerDataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCustomerData", id, actor)
+	ret0, _ := ret[0].(*model.CustomerDataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCustomerData indicates an expected call of ExportCustomerData.
+func (mr *MockPrivacyUsecaseMockRecorder) ExportCustomerData(id, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCustomerData", reflect.TypeOf((*MockPrivacyUsecase)(nil).ExportCustomerData), id, actor)
+}
+
+// WithTenant mocks base method.
+func (m *MockPrivacyUsecase) WithTenant(tenantID string) usecase.PrivacyUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.PrivacyUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockPrivacyUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockPrivacyUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: SearchUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/search_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase SearchUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchUsecase is a mock of SearchUsecase interface.
+type MockSearchUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchUsecaseMockRecorder is the mock recorder for MockSearchUsecase.
+type MockSearchUsecaseMockRecorder struct {
+	mock *MockSearchUsecase
+}
+
+// NewMockSearchUsecase creates a new mock instance.
+func NewMockSearchUsecase(ctrl *gomock.Controller) *MockSearchUsecase {
+	mock := &MockSearchUsecase{ctrl: ctrl}
+	mock.recorder = &MockSearchUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchUsecase) EXPECT() *MockSearchUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchUsecase) Search(query model.SearchQuery) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchUsecaseMockRecorder) Search(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchUsecase)(nil).Search), query)
+}
+
+// WithTenant mocks base method.
+func (m *MockSearchUsecase) WithTenant(tenantID string) usecase.SearchUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.SearchUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockSearchUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockSearchUsecase)(nil).WithTenant), tenantID)
+}
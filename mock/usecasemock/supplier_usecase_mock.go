@@ -3,16 +3,17 @@
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock/supplier_usecase_mock.go -package=mock github.com/GoodsChain/backend/usecase SupplierUsecase
+//	mockgen -destination=mock/usecasemock/supplier_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase SupplierUsecase
 //
 
-// Package mock is a generated GoMock package.
-package mock
+// Package usecasemock is a generated GoMock package.
+package usecasemock
 
 import (
 	reflect "reflect"
 
 	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,18 +70,18 @@ func (mr *MockSupplierUsecaseMockRecorder) DeleteSupplier(id any) *gomock.Call {
 }
 
 // GetAllSuppliers mocks base method.
-func (m *MockSupplierUsecase) GetAllSuppliers() ([]*model.Supplier, error) {
+func (m *MockSupplierUsecase) GetAllSuppliers(filter model.SupplierFilter) ([]*model.Supplier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSuppliers")
+	ret := m.ctrl.Call(m, "GetAllSuppliers", filter)
 	ret0, _ := ret[0].([]*model.Supplier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllSuppliers indicates an expected call of GetAllSuppliers.
-func (mr *MockSupplierUsecaseMockRecorder) GetAllSuppliers() *gomock.Call {
+func (mr *MockSupplierUsecaseMockRecorder) GetAllSuppliers(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSuppliers", reflect.TypeOf((*MockSupplierUsecase)(nil).GetAllSuppliers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSuppliers", reflect.TypeOf((*MockSupplierUsecase)(nil).GetAllSuppliers), filter)
 }
 
 // GetSupplier mocks base method.
@@ -98,6 +99,35 @@ func (mr *MockSupplierUsecaseMockRecorder) GetSupplier(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockSupplierUsecase)(nil).GetSupplier), id)
 }
 
+// GetSuppliersByIDs mocks base method.
+func (m *MockSupplierUsecase) GetSuppliersByIDs(ids []string) ([]*model.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppliersByIDs", ids)
+	ret0, _ := ret[0].([]*model.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppliersByIDs indicates an expected call of GetSuppliersByIDs.
+func (mr *MockSupplierUsecaseMockRecorder) GetSuppliersByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliersByIDs", reflect.TypeOf((*MockSupplierUsecase)(nil).GetSuppliersByIDs), ids)
+}
+
+// StreamSuppliers mocks base method.
+func (m *MockSupplierUsecase) StreamSuppliers(filter model.SupplierFilter, fn func(*model.Supplier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSuppliers", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSuppliers indicates an expected call of StreamSuppliers.
+func (mr *MockSupplierUsecaseMockRecorder) StreamSuppliers(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSuppliers", reflect.TypeOf((*MockSupplierUsecase)(nil).StreamSuppliers), filter, fn)
+}
+
 // UpdateSupplier mocks base method.
 func (m *MockSupplierUsecase) UpdateSupplier(id string, supplier *model.Supplier) error {
 	m.ctrl.T.Helper()
@@ -111,3 +141,17 @@ func (mr *MockSupplierUsecaseMockRecorder) UpdateSupplier(id, supplier any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockSupplierUsecase)(nil).UpdateSupplier), id, supplier)
 }
+
+// WithTenant mocks base method.
+func (m *MockSupplierUsecase) WithTenant(tenantID string) usecase.SupplierUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.SupplierUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockSupplierUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockSupplierUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: UserUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/user_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase UserUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserUsecase is a mock of UserUsecase interface.
+type MockUserUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserUsecaseMockRecorder is the mock recorder for MockUserUsecase.
+type MockUserUsecaseMockRecorder struct {
+	mock *MockUserUsecase
+}
+
+// NewMockUserUsecase creates a new mock instance.
+func NewMockUserUsecase(ctrl *gomock.Controller) *MockUserUsecase {
+	mock := &MockUserUsecase{ctrl: ctrl}
+	mock.recorder = &MockUserUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserUsecase) CreateUser(user *model.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserUsecaseMockRecorder) CreateUser(user, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserUsecase)(nil).CreateUser), user, password)
+}
+
+// GetUser mocks base method.
+func (m *MockUserUsecase) GetUser(id string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserUsecaseMockRecorder) GetUser(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserUsecase)(nil).GetUser), id)
+}
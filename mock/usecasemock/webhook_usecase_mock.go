@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/GoodsChain/backend/usecase (interfaces: WebhookUsecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/usecasemock/webhook_usecase_mock.go -package=usecasemock github.com/GoodsChain/backend/usecase WebhookUsecase
+//
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	reflect "reflect"
+
+	model "github.com/GoodsChain/backend/model"
+	usecase "github.com/GoodsChain/backend/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookUsecase is a mock of WebhookUsecase interface.
+type MockWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookUsecaseMockRecorder is the mock recorder for MockWebhookUsecase.
+type MockWebhookUsecaseMockRecorder struct {
+	mock *MockWebhookUsecase
+}
+
+// NewMockWebhookUsecase creates a new mock instance.
+func NewMockWebhookUsecase(ctrl *gomock.Controller) *MockWebhookUsecase {
+	mock := &MockWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUsecase) EXPECT() *MockWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookUsecase) CreateWebhook(req *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", req)
+	ret0, _ := ret[0].(*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) CreateWebhook(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).CreateWebhook), req)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookUsecase) DeleteWebhook(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) DeleteWebhook(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).DeleteWebhook), id)
+}
+
+// GetAllWebhooks mocks base method.
+func (m *MockWebhookUsecase) GetAllWebhooks() ([]model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWebhooks")
+	ret0, _ := ret[0].([]model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWebhooks indicates an expected call of GetAllWebhooks.
+func (mr *MockWebhookUsecaseMockRecorder) GetAllWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWebhooks", reflect.TypeOf((*MockWebhookUsecase)(nil).GetAllWebhooks))
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookUsecase) GetDeliveries(id string, filter model.DeliveryFilter) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", id, filter)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookUsecaseMockRecorder) GetDeliveries(id, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookUsecase)(nil).GetDeliveries), id, filter)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookUsecase) GetWebhook(id string) (*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", id)
+	ret0, _ := ret[0].(*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) GetWebhook(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).GetWebhook), id)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookUsecase) Redeliver(id, deliveryID string) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", id, deliveryID)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookUsecaseMockRecorder) Redeliver(id, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookUsecase)(nil).Redeliver), id, deliveryID)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookUsecase) UpdateWebhook(id string, req *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", id, req)
+	ret0, _ := ret[0].(*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) UpdateWebhook(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).UpdateWebhook), id, req)
+}
+
+// WithTenant mocks base method.
+func (m *MockWebhookUsecase) WithTenant(tenantID string) usecase.WebhookUsecase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", tenantID)
+	ret0, _ := ret[0].(usecase.WebhookUsecase)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockWebhookUsecaseMockRecorder) WithTenant(tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockWebhookUsecase)(nil).WithTenant), tenantID)
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKey is a credential of a machine client, such as a dealer integration.
+// Only a hash of the key is stored; the key itself is returned once, when it
+// is issued or rotated.
+type APIKey struct {
+	ID         string         `json:"id" db:"id" example:"3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f" description:"Unique identifier for the key"`
+	TenantID   string         `json:"tenant_id" db:"tenant_id" example:"00000000-0000-0000-0000-000000000000" description:"Tenant the key acts for"`
+	Name       string         `json:"name" db:"name" example:"Dealer portal" description:"Name of the client using the key"`
+	Prefix     string         `json:"prefix" db:"prefix" example:"1a2b3c4d" description:"Public part of the key, to recognize it"`
+	Key        string         `json:"key,omitempty" db:"-" example:"gc_1a2b3c4d_5e6f..." description:"The key, only returned when it is issued or rotated"`
+	KeyHash    string         `json:"-" db:"key_hash"`
+	Scopes     pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string" example:"cars:read,customer-cars:write" description:"Scopes granted to the key"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty" db:"expires_at" format:"date-time" description:"Time after which the key is rejected"`
+	LastUsedAt *time.Time     `json:"last_used_at,omitempty" db:"last_used_at" format:"date-time" description:"Time the key last authenticated a request, to the minute"`
+	RevokedAt  *time.Time     `json:"revoked_at,omitempty" db:"revoked_at" format:"date-time" description:"Time the key was revoked"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the key was issued"`
+	CreatedBy  string         `json:"created_by" db:"created_by" example:"admin_user" description:"Identifier of the user/process that issued the key"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the key was last rotated or revoked"`
+	UpdatedBy  string         `json:"updated_by" db:"updated_by" example:"admin_user" description:"Identifier of the user/process that last rotated or revoked the key"`
+}
+
+// Active reports whether the key can authenticate requests at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIKeyRequest is the payload for issuing an API key
+type APIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"Dealer portal"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1" example:"cars:read"`
+	ExpiresAt *time.Time `json:"expires_at" format:"date-time" example:"2025-01-01T00:00:00Z"`
+	Actor     string     `json:"-"` // User or process issuing the key
+}
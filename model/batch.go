@@ -0,0 +1,56 @@
+package model
+
+import (
+	"encoding/json"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/validation"
+)
+
+// Batch operations
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// Batch modes
+const (
+	// BatchModeAtomic applies every operation or none of them
+	BatchModeAtomic = "atomic"
+	// BatchModeBestEffort applies the operations that succeed and reports the rest
+	BatchModeBestEffort = "best_effort"
+)
+
+// BatchRequest is a list of operations on a single resource
+type BatchRequest struct {
+	Mode       string           `json:"mode" binding:"omitempty,oneof=atomic best_effort" example:"atomic" description:"atomic (default) applies all operations or none; best_effort applies those that succeed"`
+	Operations []BatchOperation `json:"operations" binding:"required,min=1,dive" description:"Operations, applied in order"`
+	Actor      string           `json:"-"`
+}
+
+// BatchOperation creates, updates or deletes one record
+type BatchOperation struct {
+	Op   string          `json:"op" binding:"required,oneof=create update delete" example:"create" description:"Operation: create, update or delete"`
+	ID   string          `json:"id,omitempty" example:"0b8f2a4e-1c3d-4e5f-8a9b-0c1d2e3f4a5b" description:"Record ID; required for update and delete, generated for create if omitted"`
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object" description:"Record body for create and update, as for the single-record endpoints"`
+}
+
+// BatchItemResult is the outcome of one operation
+type BatchItemResult struct {
+	Index   int                     `json:"index" example:"0" description:"Position of the operation in the request"`
+	Op      string                  `json:"op" example:"create" description:"Operation"`
+	ID      string                  `json:"id,omitempty" example:"0b8f2a4e-1c3d-4e5f-8a9b-0c1d2e3f4a5b" description:"Record ID"`
+	Status  int                     `json:"status" example:"201" description:"HTTP status the operation would have had on its own"`
+	Code    appErrors.ErrorCode     `json:"code,omitempty" example:"NOT_FOUND" description:"Error code if the operation failed"`
+	Message string                  `json:"message,omitempty" example:"Car with ID '...' not found" description:"Error message if the operation failed"`
+	Errors  []validation.FieldError `json:"errors,omitempty" description:"Field errors for invalid data"`
+}
+
+// BatchResult reports the outcome of a batch request
+type BatchResult struct {
+	Mode      string            `json:"mode" example:"best_effort" description:"Mode the batch ran in"`
+	Succeeded int               `json:"succeeded" example:"98" description:"Number of operations applied"`
+	Failed    int               `json:"failed" example:"2" description:"Number of operations not applied"`
+	Results   []BatchItemResult `json:"results" description:"Per-operation results, in request order"`
+}
@@ -7,9 +7,12 @@ import (
 // Car represents a car in the system.
 type Car struct {
 	ID         string    `json:"id" db:"id" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Unique identifier for the car"`
+	TenantID   string    `json:"-" db:"tenant_id"`
 	Name       string    `json:"name" db:"name" binding:"required" example:"Toyota Camry" description:"Name of the car model"`
 	SupplierID string    `json:"supplier_id" db:"supp_id" binding:"required" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8" description:"Identifier of the supplier"`
 	Price      int       `json:"price" db:"price" binding:"required,gt=0" example:"25000" description:"Price of the car in the smallest currency unit (e.g., cents)"`
+	TrimID     *string   `json:"trim_id" db:"trim_id" example:"2d8b0c1a-3e4f-4a5b-8c1d-2e3f4a5b6c7d" description:"Identifier of the catalog trim of the car (optional)"`
+	Specs      Specs     `json:"specs" db:"specs" swaggertype:"object" description:"Specification attributes, following the schema of the category of the trim"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the car record was created"`
 	CreatedBy  string    `json:"created_by" db:"created_by" example:"admin_user" description:"Identifier of the user/process that created the car record"`
 	UpdatedAt  time.Time `json:"updated_at" db:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the car record was last updated"`
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+)
+
+// Brand is a car make, such as Toyota, at the top of the catalog
+type Brand struct {
+	ID        string    `db:"id" json:"id" example:"0b6f8f9e-1c2d-4e5f-8a9b-0c1d2e3f4a5b" description:"Unique identifier for the brand"`
+	TenantID  string    `db:"tenant_id" json:"-"`
+	Name      string    `db:"name" json:"name" binding:"required,max=100" example:"Toyota" description:"Name of the brand, unique per tenant"`
+	CreatedAt time.Time `db:"created_at" json:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the brand was created"`
+	CreatedBy string    `db:"created_by" json:"created_by" example:"admin_user" description:"Identifier of the user/process that created the brand"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the brand was last updated"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"admin_user" description:"Identifier of the user/process that last updated the brand"`
+}
+
+// CarModel is a model of a brand, such as Camry. Its category selects the
+// specification schema of its cars.
+type CarModel struct {
+	ID        string    `db:"id" json:"id" example:"1c7a9b0f-2d3e-4f5a-9b0c-1d2e3f4a5b6c" description:"Unique identifier for the model"`
+	TenantID  string    `db:"tenant_id" json:"-"`
+	BrandID   string    `db:"brand_id" json:"brand_id" example:"0b6f8f9e-1c2d-4e5f-8a9b-0c1d2e3f4a5b" description:"Identifier of the brand"`
+	Name      string    `db:"name" json:"name" binding:"required,max=100" example:"Camry" description:"Name of the model, unique per brand"`
+	Category  string    `db:"category" json:"category" binding:"required,oneof=passenger suv pickup van" example:"passenger" description:"Category of the model: passenger, suv, pickup or van"`
+	CreatedAt time.Time `db:"created_at" json:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the model was created"`
+	CreatedBy string    `db:"created_by" json:"created_by" example:"admin_user" description:"Identifier of the user/process that created the model"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the model was last updated"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"admin_user" description:"Identifier of the user/process that last updated the model"`
+}
+
+// Trim is a variant of a model, such as Camry XSE Hybrid, that cars belong to
+type Trim struct {
+	ID        string    `db:"id" json:"id" example:"2d8b0c1a-3e4f-4a5b-8c1d-2e3f4a5b6c7d" description:"Unique identifier for the trim"`
+	TenantID  string    `db:"tenant_id" json:"-"`
+	ModelID   string    `db:"model_id" json:"model_id" example:"1c7a9b0f-2d3e-4f5a-9b0c-1d2e3f4a5b6c" description:"Identifier of the model"`
+	Name      string    `db:"name" json:"name" binding:"required,max=100" example:"XSE Hybrid" description:"Name of the trim, unique per model"`
+	CreatedAt time.Time `db:"created_at" json:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the trim was created"`
+	CreatedBy string    `db:"created_by" json:"created_by" example:"admin_user" description:"Identifier of the user/process that created the trim"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the trim was last updated"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"admin_user" description:"Identifier of the user/process that last updated the trim"`
+}
+
+// TrimDetail names a trim together with its model and brand
+type TrimDetail struct {
+	TrimID   string `db:"trim_id" json:"trim_id"`
+	Trim     string `db:"trim" json:"trim" example:"XSE Hybrid"`
+	ModelID  string `db:"model_id" json:"model_id"`
+	Model    string `db:"model" json:"model" example:"Camry"`
+	Category string `db:"category" json:"category" example:"passenger"`
+	BrandID  string `db:"brand_id" json:"brand_id"`
+	Brand    string `db:"brand" json:"brand" example:"Toyota"`
+}
+
+// CarComparison lists cars side by side with one row per specification
+// attribute any of them has
+type CarComparison struct {
+	Cars  []ComparedCar    `json:"cars"`
+	Specs []SpecComparison `json:"specs"`
+}
+
+// ComparedCar is a car of a comparison with its catalog names, when it has a trim
+type ComparedCar struct {
+	*Car
+	Brand    string `json:"brand,omitempty" example:"Toyota"`
+	Model    string `json:"model,omitempty" example:"Camry"`
+	Category string `json:"category,omitempty" example:"passenger"`
+	Trim     string `json:"trim,omitempty" example:"XSE Hybrid"`
+}
+
+// SpecComparison is the row of one attribute in a comparison. Values are in
+// the order of the compared cars, null where a car lacks the attribute.
+type SpecComparison struct {
+	Attribute string        `json:"attribute" example:"seats"`
+	Unit      string        `json:"unit,omitempty" example:"kg"`
+	Values    []interface{} `json:"values" swaggertype:"array,object"`
+	Differs   bool          `json:"differs" description:"Whether the cars have different values"`
+}
@@ -2,11 +2,14 @@ package model
 
 import (
 	"time"
+
+	"github.com/GoodsChain/backend/redact"
 )
 
 // Customer represents a customer in the system.
 type Customer struct {
 	ID        string    `db:"id" json:"id" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8" description:"Unique identifier for the customer"`
+	TenantID  string    `db:"tenant_id" json:"-"`
 	Name      string    `db:"name" json:"name" binding:"required" example:"John Doe" description:"Name of the customer"`
 	Address   string    `db:"address" json:"address" binding:"required" example:"123 Main St, Anytown, USA" description:"Address of the customer"`
 	Phone     string    `db:"phone" json:"phone" example:"555-123-4567" description:"Phone number of the customer (optional)"`
@@ -16,3 +19,10 @@ type Customer struct {
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-01-16T11:00:00Z" format:"date-time" description:"Timestamp of when the customer was last updated"`
 	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"system_user" description:"Identifier of the user/process that last updated the customer"`
 }
+
+// MaskContact masks the email and phone of the customer, as in
+// "j***@example.com" and "555-***-4567", for callers that may not see them
+func (c *Customer) MaskContact() {
+	c.Email = redact.Email(c.Email)
+	c.Phone = redact.Phone(c.Phone)
+}
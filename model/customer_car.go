@@ -7,6 +7,7 @@ import (
 // CustomerCar represents a relationship between a customer and a car in the system.
 type CustomerCar struct {
 	ID        string    `json:"id" db:"id" example:"cc_01H9ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Unique identifier for the customer-car relationship"`
+	TenantID  string    `json:"-" db:"tenant_id"`
 	CarID     string    `json:"car_id" db:"car_id" binding:"required" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Identifier of the car"`
 	CustomerID string   `json:"customer_id" db:"cust_id" binding:"required" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8" description:"Identifier of the customer"`
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the record was created"`
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Domain event types. Created and updated events carry the record as written,
+// customers as a CustomerChange; deleted events carry an EntityDeleted.
+const (
+	EventCustomerCreated    = "customer.created"
+	EventCustomerUpdated    = "customer.updated"
+	EventCustomerDeleted    = "customer.deleted"
+	EventSupplierCreated    = "supplier.created"
+	EventSupplierUpdated    = "supplier.updated"
+	EventSupplierDeleted    = "supplier.deleted"
+	EventCarCreated         = "car.created"
+	EventCarUpdated         = "car.updated"
+	EventCarDeleted         = "car.deleted"
+	EventCarPriceChanged    = "car.price_changed"
+	EventCustomerCarCreated = "customer_car.created"
+	EventCustomerCarUpdated = "customer_car.updated"
+	EventCustomerCarDeleted = "customer_car.deleted"
+
+	// Data subject requests carry a CustomerPrivacyAction
+	EventCustomerDataExported = "customer.data_exported"
+	EventCustomerErased       = "customer.erased"
+)
+
+// EventTypes lists every event type that can be subscribed to
+var EventTypes = []string{
+	EventCustomerCreated, EventCustomerUpdated, EventCustomerDeleted,
+	EventCustomerDataExported, EventCustomerErased,
+	EventSupplierCreated, EventSupplierUpdated, EventSupplierDeleted,
+	EventCarCreated, EventCarUpdated, EventCarDeleted, EventCarPriceChanged,
+	EventCustomerCarCreated, EventCustomerCarUpdated, EventCustomerCarDeleted,
+}
+
+// IsEventType reports whether t is a known event type
+func IsEventType(t string) bool {
+	for _, eventType := range EventTypes {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a domain change recorded in the outbox in the same transaction as
+// the change itself.
+type Event struct {
+	Seq           int64           `json:"seq,omitempty" db:"seq" example:"42" description:"Position of the event in the outbox"`
+	ID            string          `json:"id" db:"id" example:"5f0c7a4e-2d3b-4c5a-9e8f-7a6b5c4d3e2f" description:"Unique identifier for the event"`
+	Type          string          `json:"type" db:"event_type" example:"car.price_changed" description:"Event type"`
+	AggregateType string          `json:"aggregate_type" db:"aggregate_type" example:"car" description:"Kind of record that changed"`
+	AggregateID   string          `json:"aggregate_id" db:"aggregate_id" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"ID of the record that changed"`
+	Data          json.RawMessage `json:"data" db:"payload" swaggertype:"object" description:"Event payload"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Time the change was made"`
+	TenantID      string          `json:"tenant_id,omitempty" db:"tenant_id" example:"00000000-0000-0000-0000-000000000000" description:"Tenant of the record that changed"`
+	// TxID is the writing transaction, used to stream events in commit-safe order
+	TxID int64 `json:"-" db:"txid"`
+}
+
+// EventPosition is a point in the event stream. Events are ordered by the
+// transaction that wrote them, then by sequence.
+type EventPosition struct {
+	TxID int64
+	Seq  int64
+}
+
+// Position returns the stream position of the event
+func (e *Event) Position() EventPosition {
+	return EventPosition{TxID: e.TxID, Seq: e.Seq}
+}
+
+// After reports whether p comes after other in the stream
+func (p EventPosition) After(other EventPosition) bool {
+	return p.TxID > other.TxID || (p.TxID == other.TxID && p.Seq > other.Seq)
+}
+
+// EntityDeleted is the payload of deleted events
+type EntityDeleted struct {
+	ID string `json:"id" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"ID of the deleted record"`
+}
+
+// CustomerChange is the payload of customer.created and customer.updated
+// events. The address, phone and email are left out: they are encrypted at
+// rest, but the outbox and webhook deliveries store payloads as they are.
+type CustomerChange struct {
+	ID        string    `json:"id" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8" description:"Unique identifier for the customer"`
+	Name      string    `json:"name" example:"John Doe" description:"Name of the customer"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-15T10:30:00Z" format:"date-time" description:"Timestamp of when the customer was created"`
+	CreatedBy string    `json:"created_by" example:"system_user" description:"Identifier of the user/process that created the customer"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-16T11:00:00Z" format:"date-time" description:"Timestamp of when the customer was last updated"`
+	UpdatedBy string    `json:"updated_by" example:"system_user" description:"Identifier of the user/process that last updated the customer"`
+}
+
+// CarPriceChange is the payload of car.price_changed events
+type CarPriceChange struct {
+	Car           *Car `json:"car" description:"Car after the change"`
+	PreviousPrice int  `json:"previous_price" example:"25000" description:"Price before the change"`
+}
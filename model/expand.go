@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Resources whose responses can embed related records
+const (
+	ResourceCustomer    = "customer"
+	ResourceSupplier    = "supplier"
+	ResourceCar         = "car"
+	ResourceCustomerCar = "customer_car"
+)
+
+// expandRelations lists, for each resource, the relations that can be
+// expanded and the resource they lead to
+var expandRelations = map[string]map[string]string{
+	ResourceCustomer:    {"cars": ResourceCar},
+	ResourceSupplier:    {"cars": ResourceCar},
+	ResourceCar:         {"supplier": ResourceSupplier, "customers": ResourceCustomer},
+	ResourceCustomerCar: {"car": ResourceCar, "customer": ResourceCustomer},
+}
+
+// expandKeys lists, for each resource, the field of a record each relation
+// is looked up by
+var expandKeys = map[string]map[string]string{
+	ResourceCustomer:    {"cars": "id"},
+	ResourceSupplier:    {"cars": "id"},
+	ResourceCar:         {"supplier": "supplier_id", "customers": "id"},
+	ResourceCustomerCar: {"car": "car_id", "customer": "customer_id"},
+}
+
+// Expand is the tree of relations to embed in a response, parsed from an
+// expand query parameter such as "car,car.supplier,customer". Each key is a
+// relation of the parent resource and its value the relations to expand
+// below it; a nested path implies its parents.
+type Expand map[string]Expand
+
+// Has reports whether relation is expanded
+func (e Expand) Has(relation string) bool {
+	_, ok := e[relation]
+	return ok
+}
+
+// Keys returns the fields of a record of resource the expanded relations are
+// looked up by, which must be selected even when the client did not ask for them
+func (e Expand) Keys(resource string) []string {
+	keys := make([]string, 0, len(e))
+	for _, relation := range ExpandRelations(resource) {
+		if e.Has(relation) {
+			keys = append(keys, expandKeys[resource][relation])
+		}
+	}
+	return keys
+}
+
+// ParseExpand parses a comma-separated list of relation paths of resource.
+// Paths must follow the relations of each resource and be at most maxDepth
+// relations long; a zero maxDepth does not limit them.
+func ParseExpand(resource, raw string, maxDepth int) (Expand, error) {
+	expand := Expand{}
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		relations := strings.Split(path, ".")
+		if maxDepth > 0 && len(relations) > maxDepth {
+			return nil, fmt.Errorf("expand path '%s' is nested deeper than %d levels", path, maxDepth)
+		}
+		node, current := expand, resource
+		for _, relation := range relations {
+			target, ok := expandRelations[current][relation]
+			if !ok {
+				return nil, fmt.Errorf("unknown expand path '%s': %s has no relation '%s', expected one of %s",
+					path, current, relation, strings.Join(ExpandRelations(current), ", "))
+			}
+			if node[relation] == nil {
+				node[relation] = Expand{}
+			}
+			node, current = node[relation], target
+		}
+	}
+	return expand, nil
+}
+
+// ExpandRelations returns the relations of resource that can be expanded, sorted
+func ExpandRelations(resource string) []string {
+	relations := make([]string, 0, len(expandRelations[resource]))
+	for relation := range expandRelations[resource] {
+		relations = append(relations, relation)
+	}
+	sort.Strings(relations)
+	return relations
+}
+
+// ExpandedCustomer is a customer with its expanded relations
+type ExpandedCustomer struct {
+	*Customer
+	Cars []*ExpandedCar `json:"cars,omitzero" description:"Cars owned by the customer, with expand=cars"`
+}
+
+// ExpandedSupplier is a supplier with its expanded relations
+type ExpandedSupplier struct {
+	*Supplier
+	Cars []*ExpandedCar `json:"cars,omitzero" description:"Cars of the supplier, with expand=cars"`
+}
+
+// ExpandedCar is a car with its expanded relations
+type ExpandedCar struct {
+	*Car
+	Supplier  *ExpandedSupplier   `json:"supplier,omitzero" description:"Supplier of the car, with expand=supplier"`
+	Customers []*ExpandedCustomer `json:"customers,omitzero" description:"Customers owning the car, with expand=customers"`
+}
+
+// ExpandedCustomerCar is a customer-car relationship with its expanded relations
+type ExpandedCustomerCar struct {
+	*CustomerCar
+	Car      *ExpandedCar      `json:"car,omitzero" description:"The car, with expand=car"`
+	Customer *ExpandedCustomer `json:"customer,omitzero" description:"The customer, with expand=customer"`
+}
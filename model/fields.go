@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Fields is a sparse fieldset: the JSON names of the fields of a record a
+// client asked for. A nil Fields selects every field.
+type Fields []string
+
+// ParseFields parses a comma-separated list of JSON field names of record, a
+// model struct. Only fields stored in a column, those with a db tag, can be
+// selected. An empty list returns nil.
+func ParseFields(raw string, record interface{}) (Fields, error) {
+	columns := fieldColumns(reflect.TypeOf(record))
+	var fields Fields
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || fields.Has(name) {
+			continue
+		}
+		if _, ok := columns.byName[name]; !ok {
+			return nil, fmt.Errorf("unknown field '%s', expected one of %s", name, strings.Join(columns.names, ", "))
+		}
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+// Has reports whether the field named name is selected
+func (f Fields) Has(name string) bool {
+	for _, field := range f {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// With returns the fieldset extended with names, for fields needed to serve
+// the request that the client did not ask for. A nil Fields stays nil.
+func (f Fields) With(names ...string) Fields {
+	if f == nil {
+		return nil
+	}
+	extended := append(Fields{}, f...)
+	for _, name := range names {
+		if !extended.Has(name) {
+			extended = append(extended, name)
+		}
+	}
+	return extended
+}
+
+// Columns returns the db columns of the selected fields of record, or nil
+// when every field is selected
+func (f Fields) Columns(record interface{}) []string {
+	if f == nil {
+		return nil
+	}
+	byName := fieldColumns(reflect.TypeOf(record)).byName
+	columns := make([]string, 0, len(f))
+	for _, name := range f {
+		if column, ok := byName[name]; ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// columnSet maps the JSON names of the stored fields of a struct to their columns
+type columnSet struct {
+	names  []string          // JSON names in declaration order
+	byName map[string]string // JSON name to db column
+}
+
+var columnSets sync.Map // reflect.Type to *columnSet
+
+func fieldColumns(t reflect.Type) *columnSet {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if set, ok := columnSets.Load(t); ok {
+		return set.(*columnSet)
+	}
+
+	set := &columnSet{byName: map[string]string{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		column := strings.Split(f.Tag.Get("db"), ",")[0]
+		if !f.IsExported() || name == "" || name == "-" || column == "" || column == "-" {
+			continue
+		}
+		set.names = append(set.names, name)
+		set.byName[name] = column
+	}
+	columnSets.Store(t, set)
+	return set
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// CreatedRange restricts a listing to records created in
+// [CreatedAfter, CreatedBefore). Zero values leave the bound open.
+type CreatedRange struct {
+	CreatedAfter  time.Time `form:"created_after" example:"2023-01-01T00:00:00Z" description:"Only records created at or after this time (RFC 3339)"`
+	CreatedBefore time.Time `form:"created_before" example:"2024-01-01T00:00:00Z" description:"Only records created before this time (RFC 3339)"`
+}
+
+// CustomerFilter holds the query parameters accepted when listing or exporting customers
+type CustomerFilter struct {
+	Query string `form:"q" example:"nguyen" description:"Full-text search of the name, best matches first, or an exact email or phone"`
+	Name  string `form:"name" example:"doe" description:"Case-insensitive substring of the name"`
+	Email string `form:"email" binding:"omitempty,email" example:"john.doe@example.com" description:"Exact email address, case-insensitive"`
+	CreatedRange
+	Fields Fields `form:"-" swaggerignore:"true"` // Columns to select, from the fields parameter; nil selects all
+}
+
+// SupplierFilter holds the query parameters accepted when listing or exporting suppliers
+type SupplierFilter struct {
+	Query string `form:"q" example:"ha noi" description:"Full-text search of the name, phone, email and address, best matches first"`
+	Name  string `form:"name" example:"motors" description:"Case-insensitive substring of the name"`
+	Email string `form:"email" binding:"omitempty,email" example:"contact@acmemotors.com" description:"Exact email address, case-insensitive"`
+	CreatedRange
+	Fields Fields `form:"-" swaggerignore:"true"` // Columns to select, from the fields parameter; nil selects all
+}
+
+// CarFilter holds the query parameters accepted when listing or exporting cars
+type CarFilter struct {
+	Query      string `form:"q" example:"camry" description:"Full-text search of the name, best matches first"`
+	Name       string `form:"name" example:"camry" description:"Case-insensitive substring of the name"`
+	SupplierID string `form:"supplier_id" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8" description:"Only cars from this supplier"`
+	MinPrice   int    `form:"min_price" binding:"omitempty,gte=0" example:"10000" description:"Minimum price, inclusive"`
+	MaxPrice   int    `form:"max_price" binding:"omitempty,gte=0" example:"50000" description:"Maximum price, inclusive"`
+	BrandID    string `form:"brand_id" example:"0b6f8f9e-1c2d-4e5f-8a9b-0c1d2e3f4a5b" description:"Only cars of this brand"`
+	ModelID    string `form:"model_id" example:"1c7a9b0f-2d3e-4f5a-9b0c-1d2e3f4a5b6c" description:"Only cars of this model"`
+	TrimID     string `form:"trim_id" example:"2d8b0c1a-3e4f-4a5b-8c1d-2e3f4a5b6c7d" description:"Only cars of this trim"`
+	CreatedRange
+	Specs  []SpecFilter `form:"-" swaggerignore:"true"` // Specification filters, from ParseSpecFilters
+	Fields Fields       `form:"-" swaggerignore:"true"` // Columns to select, from the fields parameter; nil selects all
+}
+
+// CustomerCarFilter holds the query parameters accepted when listing or exporting customer-car relationships
+type CustomerCarFilter struct {
+	CustomerID string `form:"customer_id" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8" description:"Only relationships of this customer"`
+	CarID      string `form:"car_id" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Only relationships of this car"`
+	CreatedRange
+	Fields Fields `form:"-" swaggerignore:"true"` // Columns to select, from the fields parameter; nil selects all
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// Import job statuses
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportRequest describes an uploaded spreadsheet to import.
+type ImportRequest struct {
+	Resource string            // Resource to import, e.g. "cars"
+	Content  []byte            // Raw file content
+	Format   string            // File format: csv or xlsx
+	Sheet    string            // Worksheet name for XLSX files, first sheet if empty
+	Mapping  map[string]string // Model JSON field name to column header
+	DryRun   bool              // Only validate, do not insert
+	Actor    string            // User or process performing the import
+}
+
+// ImportRowError describes a validation failure in one spreadsheet row.
+type ImportRowError struct {
+	Row     int    `json:"row" example:"3" description:"Spreadsheet row number (the header is row 1)"`
+	Field   string `json:"field,omitempty" example:"price" description:"JSON name of the invalid field"`
+	Rule    string `json:"rule,omitempty" example:"gt" description:"Validation rule that failed"`
+	Message string `json:"message" example:"price must be greater than 0" description:"Human-readable description of the failure"`
+}
+
+// ImportReport is the result of validating or importing a spreadsheet.
+type ImportReport struct {
+	Resource     string           `json:"resource" example:"cars" description:"Imported resource"`
+	DryRun       bool             `json:"dry_run" example:"true" description:"Whether rows were only validated"`
+	TotalRows    int              `json:"total_rows" example:"120" description:"Number of data rows in the file"`
+	ValidRows    int              `json:"valid_rows" example:"118" description:"Number of rows that passed validation"`
+	ImportedRows int              `json:"imported_rows" example:"0" description:"Number of rows inserted"`
+	Errors       []ImportRowError `json:"errors" description:"Per-row validation errors"`
+	Job          *ImportJob       `json:"job,omitempty" description:"Background job for large files"`
+}
+
+// ImportJob tracks a chunked import of a large file so it can be resumed.
+type ImportJob struct {
+	ID            string    `json:"id" db:"id" example:"0b8f2a4e-1c3d-4e5f-8a9b-0c1d2e3f4a5b" description:"Unique identifier for the import job"`
+	TenantID      string    `json:"-" db:"tenant_id"`
+	Resource      string    `json:"resource" db:"resource" example:"cars" description:"Imported resource"`
+	Format        string    `json:"format" db:"format" example:"xlsx" description:"File format (csv or xlsx)"`
+	Sheet         string    `json:"sheet,omitempty" db:"sheet" example:"Sheet1" description:"Worksheet name for XLSX files"`
+	Mapping       string    `json:"-" db:"mapping"`
+	Payload       []byte    `json:"-" db:"payload"`
+	Status        string    `json:"status" db:"status" example:"running" description:"Job status: running, completed or failed"`
+	TotalRows     int       `json:"total_rows" db:"total_rows" example:"25000" description:"Number of data rows in the file"`
+	ProcessedRows int       `json:"processed_rows" db:"processed_rows" example:"12000" description:"Number of rows committed so far"`
+	ChunkSize     int       `json:"chunk_size" db:"chunk_size" example:"500" description:"Number of rows committed per transaction"`
+	Error         string    `json:"error,omitempty" db:"error" example:"" description:"Error that stopped the job, if any"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the job was created"`
+	CreatedBy     string    `json:"created_by" db:"created_by" example:"admin_user" description:"Identifier of the user/process that created the job"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at" example:"2023-03-20T10:05:00Z" format:"date-time" description:"Timestamp of when the job was last updated"`
+	UpdatedBy     string    `json:"updated_by" db:"updated_by" example:"admin_user" description:"Identifier of the user/process that last updated the job"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Kinds of maintenance records
+const (
+	MaintenanceService    = "service"    // A scheduled service, which restarts the service interval
+	MaintenanceRepair     = "repair"     // Unscheduled work, such as replacing a part that failed
+	MaintenanceInspection = "inspection" // A check without work, such as a roadworthiness test
+)
+
+// MaintenanceRecord is service or repair work done on an owned vehicle,
+// recorded against its customer_car relationship
+type MaintenanceRecord struct {
+	ID            string    `db:"id" json:"id" example:"3e9c1d2b-4f5a-4b6c-9d2e-3f4a5b6c7d8e" description:"Unique identifier for the maintenance record"`
+	TenantID      string    `db:"tenant_id" json:"-"`
+	CustomerCarID string    `db:"customer_car_id" json:"customer_car_id" example:"cc_01H9ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Identifier of the customer-car relationship"`
+	Kind          string    `db:"kind" json:"kind" binding:"required,oneof=service repair inspection" example:"service" description:"service, repair or inspection; services restart the service interval"`
+	ServiceDate   time.Time `db:"service_date" json:"service_date" binding:"required" example:"2024-05-02T00:00:00Z" format:"date-time" description:"Day the work was done; the time of day is ignored"`
+	Odometer      int       `db:"odometer" json:"odometer" binding:"gte=0" example:"42500" description:"Odometer reading in kilometers"`
+	WorkItems     WorkItems `db:"work_items" json:"work_items" binding:"required,min=1,max=100,dive" description:"Work done"`
+	Cost          int       `db:"cost" json:"cost" example:"12500" description:"Total cost of the work items in the smallest currency unit (e.g., cents)"`
+	Workshop      string    `db:"workshop" json:"workshop" binding:"max=255" example:"Downtown Service Center" description:"Workshop that did the work"`
+	Notes         string    `db:"notes" json:"notes" binding:"max=2000" example:"Customer reported squeaking brakes" description:"Free-form notes"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at" example:"2024-05-02T10:00:00Z" format:"date-time" description:"Timestamp of when the record was created"`
+	CreatedBy     string    `db:"created_by" json:"created_by" example:"admin_user" description:"Identifier of the user/process that created the record"`
+}
+
+// WorkItem is a piece of work of a maintenance record
+type WorkItem struct {
+	Description string `json:"description" binding:"required,max=255" example:"Oil and filter change" description:"Work done"`
+	Cost        int    `json:"cost" binding:"gte=0" example:"8500" description:"Cost in the smallest currency unit (e.g., cents)"`
+}
+
+// WorkItems are the work items of a maintenance record, stored as a JSON array
+type WorkItems []WorkItem
+
+// Value implements driver.Valuer
+func (w WorkItems) Value() (driver.Value, error) {
+	if w == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]WorkItem(w))
+}
+
+// Scan implements sql.Scanner
+func (w *WorkItems) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*w = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into WorkItems", src)
+	}
+	return json.Unmarshal(data, w)
+}
+
+// ServiceDue is when an owned vehicle is due for its next service, by date
+// or by odometer reading, whichever comes first. Either is left out when its
+// interval is not configured.
+type ServiceDue struct {
+	CustomerCarID  string             `json:"customer_car_id" example:"cc_01H9ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Identifier of the customer-car relationship"`
+	LastService    *MaintenanceRecord `json:"last_service,omitempty" description:"Latest service; without one the interval starts with the ownership"`
+	Odometer       *int               `json:"odometer,omitempty" example:"48200" description:"Latest recorded odometer reading in kilometers"`
+	IntervalKM     int                `json:"interval_km,omitempty" example:"15000" description:"Kilometers between services"`
+	IntervalMonths int                `json:"interval_months,omitempty" example:"12" description:"Months between services"`
+	DueDate        *time.Time         `json:"due_date,omitempty" example:"2025-05-02T00:00:00Z" format:"date-time" description:"Day the next service is due"`
+	DueOdometer    *int               `json:"due_odometer,omitempty" example:"57500" description:"Odometer reading the next service is due at"`
+	Overdue        bool               `json:"overdue" example:"false" description:"Whether the due date has passed or the latest reading reached the due odometer reading"`
+}
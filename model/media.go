@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+)
+
+// Kinds of car media
+const (
+	MediaImage    = "image"    // A photo, served with a thumbnail
+	MediaDocument = "document" // A brochure or other PDF
+)
+
+// Variants of a media file a download URL can serve
+const (
+	MediaOriginal  = "original"
+	MediaThumbnail = "thumbnail"
+)
+
+// Media is a photo or document uploaded for a car. The file is kept in the
+// blob store; the URLs are signed download links that expire.
+type Media struct {
+	ID           string     `db:"id" json:"id" example:"5e1f2a3b-4c5d-4e6f-9a0b-1c2d3e4f5a6b" description:"Unique identifier for the media file"`
+	TenantID     string     `db:"tenant_id" json:"-"`
+	CarID        string     `db:"car_id" json:"car_id" example:"car_01H8ZJ5XQ8X5X8X5X8X5X8X5X8" description:"Identifier of the car"`
+	Kind         string     `db:"kind" json:"kind" example:"image" description:"image or document"`
+	Filename     string     `db:"filename" json:"filename" example:"front.jpg" description:"Name of the uploaded file"`
+	ContentType  string     `db:"content_type" json:"content_type" example:"image/jpeg" description:"Content type sniffed from the file"`
+	Size         int64      `db:"size" json:"size" example:"482113" description:"Size of the file in bytes"`
+	Width        *int       `db:"width" json:"width,omitempty" example:"1920" description:"Width of an image in pixels"`
+	Height       *int       `db:"height" json:"height,omitempty" example:"1080" description:"Height of an image in pixels"`
+	Checksum     string     `db:"checksum" json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" description:"SHA-256 of the file, hex encoded"`
+	StorageKey   string     `db:"storage_key" json:"-"`
+	ThumbnailKey *string    `db:"thumbnail_key" json:"-"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the file was uploaded"`
+	CreatedBy    string     `db:"created_by" json:"created_by" example:"admin_user" description:"Identifier of the user/process that uploaded the file"`
+	URL          string     `db:"-" json:"url" example:"/v1/media/5e1f2a3b-4c5d-4e6f-9a0b-1c2d3e4f5a6b?tenant=...&variant=original&expires=1700000000&signature=..." description:"Signed download URL of the file"`
+	ThumbnailURL string     `db:"-" json:"thumbnail_url,omitempty" description:"Signed download URL of the thumbnail of an image"`
+	URLExpiresAt *time.Time `db:"-" json:"url_expires_at,omitempty" format:"date-time" description:"When the download URLs expire"`
+}
+
+// MediaUpload is a file uploaded for a car
+type MediaUpload struct {
+	CarID    string
+	Filename string
+	Content  []byte
+	Actor    string
+}
+
+// MediaLink identifies the file a signed download URL serves
+type MediaLink struct {
+	TenantID  string
+	MediaID   string
+	Variant   string
+	Expires   int64 // Unix time the link expires at
+	Signature string
+}
@@ -0,0 +1,80 @@
+package model
+
+import "strings"
+
+// Principal types
+const (
+	PrincipalUser   = "user"
+	PrincipalAPIKey = "api_key"
+)
+
+// Scopes granted to principals. A resource scope such as "cars:read" grants
+// one route group; read and write grant every group, and write implies read.
+const (
+	ScopeAdmin = "admin" // Every operation, including the management of API keys
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// ScopeGroups are the route groups that resource scopes can name
+var ScopeGroups = []string{"customers", "suppliers", "cars", "customer-cars", "imports", "webhooks", "events", "search", "graphql", "catalog"}
+
+// IsScope reports whether scope can be granted
+func IsScope(scope string) bool {
+	switch scope {
+	case ScopeAdmin, ScopeRead, ScopeWrite:
+		return true
+	}
+	group, access, ok := strings.Cut(scope, ":")
+	if !ok || (access != ScopeRead && access != ScopeWrite) {
+		return false
+	}
+	for _, g := range ScopeGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal is the authenticated client of a request: a user or an API key
+type Principal struct {
+	Type   string   // PrincipalUser or PrincipalAPIKey
+	ID     string   // ID of the user or the API key
+	Actor  string   // Recorded in created_by and updated_by, at most 50 characters
+	Scopes []string // Scopes granted to the principal
+	// TenantID is the tenant whose records the principal can access
+	TenantID string
+	// SessionID is the login session of a user authenticated by an access token
+	SessionID string
+}
+
+// ClientID identifies the principal among all clients, such as "api_key:<id>"
+func (p *Principal) ClientID() string {
+	return p.Type + ":" + p.ID
+}
+
+// HasScope reports whether the principal is granted scope: admin, or a
+// resource scope such as "cars:read"
+func (p *Principal) HasScope(scope string) bool {
+	group, access, resource := strings.Cut(scope, ":")
+	for _, granted := range p.Scopes {
+		if granted == ScopeAdmin || granted == scope {
+			return true
+		}
+		if !resource {
+			continue
+		}
+		// write implies read
+		if granted == ScopeWrite || granted == group+":"+ScopeWrite || (access == ScopeRead && granted == ScopeRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// MasksContacts reports whether the emails and phones of the records of group
+// are masked for p: it may not write group, or p is nil for anonymous callers
+func MasksContacts(p *Principal, group string) bool {
+	return p == nil || !p.HasScope(group+":"+ScopeWrite)
+}
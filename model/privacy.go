@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// CustomerDataExport is all data held on a customer, answering their request
+// for access to it
+type CustomerDataExport struct {
+	ExportedAt         time.Time           `json:"exported_at" example:"2024-05-01T09:00:00Z" format:"date-time" description:"Time the export was made"`
+	Customer           *Customer           `json:"customer" description:"The customer"`
+	CustomerCars       []*CustomerCar      `json:"customer_cars" description:"Links of the customer to the cars they own"`
+	Cars               []Car               `json:"cars" description:"Cars linked to the customer"`
+	MaintenanceRecords []MaintenanceRecord `json:"maintenance_records" description:"Maintenance records of the cars linked to the customer"`
+	Events             []Event             `json:"events" description:"Audit trail: the recorded changes of the customer and of their links, oldest first"`
+}
+
+// CustomerPrivacyAction is the payload of customer.data_exported and
+// customer.erased events
+type CustomerPrivacyAction struct {
+	ID    string `json:"id" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8" description:"ID of the customer"`
+	Actor string `json:"actor" example:"api_key:5f0c7a4e" description:"Who exported or erased the data"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "github.com/GoodsChain/backend/validation"
+
+// ProblemContentType is the media type of error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response, an RFC 7807 problem details
+// object extended with a machine-readable code and field-level errors
+type Problem struct {
+	Type     string `json:"type" example:"urn:goodschain:problem:invalid-input" description:"URI identifying the kind of problem"`
+	Title    string `json:"title" example:"Invalid input" description:"Short summary of the kind of problem"`
+	Status   int    `json:"status" example:"400" description:"HTTP status code"`
+	Detail   string `json:"detail,omitempty" example:"The request has 1 invalid field" description:"Explanation specific to this occurrence"`
+	Instance string `json:"instance,omitempty" example:"1700000000000000000" description:"ID of the request, as sent in the X-Request-ID header"`
+	Code     string `json:"code" example:"INVALID_INPUT" description:"Error code for programmatic handling"`
+	// DetailKey and DetailParams identify the catalog message of Detail, for
+	// clients localizing it themselves
+	DetailKey    string                  `json:"detail_key,omitempty" example:"validation.invalid_field" description:"Message key of detail, the same in every language"`
+	DetailParams map[string]interface{}  `json:"detail_params,omitempty" swaggertype:"object" description:"Values of the placeholders of the detail message"`
+	Errors       []validation.FieldError `json:"errors,omitempty" description:"One entry per invalid field"`
+}
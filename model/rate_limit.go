@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// RateLimitBucket is the stored state of a token bucket of the rate limiter
+type RateLimitBucket struct {
+	Key       string    `db:"key"`
+	Tokens    float64   `db:"tokens"`     // Tokens left at UpdatedAt
+	UpdatedAt time.Time `db:"updated_at"` // Time of the last request
+}
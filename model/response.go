@@ -1,11 +1,5 @@
 package model
 
-// ErrorResponse represents a generic error response.
-type ErrorResponse struct {
-	Code    string `json:"code" example:"NOT_FOUND" description:"Error code for programmatic handling"`
-	Message string `json:"message" example:"Resource not found" description:"Human-readable error description"`
-}
-
 // SuccessResponse represents a generic success response.
 // Often used for operations like DELETE where no other data is returned.
 type SuccessResponse struct {
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchQuery holds the query parameters of a search across resources
+type SearchQuery struct {
+	Q     string `form:"q" binding:"required" example:"ha noi" description:"Words to search for; partial words, typos and missing diacritics still match"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20" description:"Maximum number of results, 1-100 (default 20)"`
+}
+
+// SearchResult is one record matching a search, best matches first
+type SearchResult struct {
+	Type      string  `json:"type" db:"type" example:"customer" description:"Resource of the record: customer, supplier or car"`
+	ID        string  `json:"id" db:"id" example:"cust_01H7ZCN4X8X5X8X5X8X5X8X5X8"`
+	Name      string  `json:"name" db:"name" example:"Nguyễn Văn An"`
+	Highlight string  `json:"highlight" db:"highlight" example:"<mark>Nguyễn</mark> Văn An 0912345678" description:"HTML-escaped text of the record with the matched words in <mark> tags"`
+	Rank      float64 `json:"rank" db:"rank" example:"0.92" description:"Relevance of the match; higher is better"`
+}
+
+// SearchTerms splits a search query into its words, dropping punctuation
+func SearchTerms(q string) []string {
+	return strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Session is a login of a user. It lasts until it is revoked by a logout, a
+// password change or the reuse of one of its refresh tokens.
+type Session struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// RefreshToken is a single-use token of a session that can be exchanged for
+// new tokens. Only its hash is stored.
+type RefreshToken struct {
+	TokenHash string     `db:"token_hash"`
+	SessionID string     `db:"session_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// LoginRequest is the payload for signing in with a password
+type LoginRequest struct {
+	Username string `json:"username" binding:"required,max=50" example:"jdoe"`
+	Password string `json:"password" binding:"required" example:"correct horse battery"`
+}
+
+// RefreshRequest is the payload for exchanging a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest is the optional payload for signing out
+type LogoutRequest struct {
+	All bool `json:"all" example:"false" description:"Sign out of every session of the user, not only the current one"`
+}
+
+// ChangePasswordRequest is the payload for changing the password of the signed-in user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8" example:"a new long passphrase"`
+}
+
+// TokenResponse holds the tokens issued by a login or a refresh
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token" description:"Bearer token for the Authorization header"`
+	TokenType        string    `json:"token_type" example:"Bearer"`
+	ExpiresIn        int       `json:"expires_in" example:"900" description:"Seconds until the access token expires"`
+	RefreshToken     string    `json:"refresh_token" description:"Single-use token for POST /auth/refresh"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at" format:"date-time" description:"Time the refresh token expires"`
+}
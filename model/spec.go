@@ -0,0 +1,289 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types of specification attributes
+const (
+	SpecNumber = "number" // A whole number, filterable by range
+	SpecText   = "text"   // Free text of at most 100 characters
+	SpecEnum   = "enum"   // One of a fixed set of values
+)
+
+// Car categories, each with its own specification schema
+const (
+	CategoryPassenger = "passenger"
+	CategorySUV       = "suv"
+	CategoryPickup    = "pickup"
+	CategoryVan       = "van"
+)
+
+// SpecAttribute is an attribute a car specification can have
+type SpecAttribute struct {
+	Name   string   `json:"name" example:"seats"`
+	Type   string   `json:"type" example:"number" description:"number, text or enum"`
+	Unit   string   `json:"unit,omitempty" example:"kg"`
+	Values []string `json:"values,omitempty" description:"Allowed values of an enum"`
+	Min    int      `json:"min,omitempty" description:"Smallest allowed number"`
+	Max    int      `json:"max,omitempty" description:"Largest allowed number"`
+}
+
+// SchemaAttribute is an attribute of a category schema
+type SchemaAttribute struct {
+	SpecAttribute
+	Required bool `json:"required"`
+}
+
+// SpecSchema lists the attributes the cars of a category can have
+type SpecSchema struct {
+	Category   string            `json:"category" example:"passenger"`
+	Attributes []SchemaAttribute `json:"attributes"`
+}
+
+// SpecAttributes are all the specification attributes, in the order
+// comparisons list them
+var SpecAttributes = []SpecAttribute{
+	{Name: "model_year", Type: SpecNumber, Min: 1900, Max: 2100},
+	{Name: "body_type", Type: SpecEnum, Values: []string{"sedan", "hatchback", "coupe", "wagon", "convertible", "suv", "crossover", "pickup", "van", "minivan"}},
+	{Name: "fuel", Type: SpecEnum, Values: []string{"gasoline", "diesel", "hybrid", "plug_in_hybrid", "electric"}},
+	{Name: "engine", Type: SpecText},
+	{Name: "power_hp", Type: SpecNumber, Unit: "hp", Min: 1, Max: 2000},
+	{Name: "transmission", Type: SpecEnum, Values: []string{"manual", "automatic", "cvt", "dct"}},
+	{Name: "drivetrain", Type: SpecEnum, Values: []string{"fwd", "rwd", "awd", "4wd"}},
+	{Name: "seats", Type: SpecNumber, Min: 1, Max: 20},
+	{Name: "doors", Type: SpecNumber, Min: 1, Max: 6},
+	{Name: "range_km", Type: SpecNumber, Unit: "km", Min: 1, Max: 2000},
+	{Name: "cargo_l", Type: SpecNumber, Unit: "l", Min: 0, Max: 20000},
+	{Name: "towing_kg", Type: SpecNumber, Unit: "kg", Min: 0, Max: 20000},
+	{Name: "payload_kg", Type: SpecNumber, Unit: "kg", Min: 0, Max: 10000},
+}
+
+// SpecSchemas are the schemas of the categories. Attributes marked with a
+// trailing "*" are required; body types are narrowed to the category.
+var SpecSchemas = []SpecSchema{
+	newSpecSchema(CategoryPassenger, []string{"sedan", "hatchback", "coupe", "wagon", "convertible"},
+		"model_year*", "body_type*", "fuel*", "seats*", "engine", "power_hp", "transmission", "drivetrain", "doors", "range_km", "cargo_l"),
+	newSpecSchema(CategorySUV, []string{"suv", "crossover"},
+		"model_year*", "body_type*", "fuel*", "seats*", "drivetrain*", "engine", "power_hp", "transmission", "doors", "range_km", "cargo_l", "towing_kg"),
+	newSpecSchema(CategoryPickup, []string{"pickup"},
+		"model_year*", "body_type*", "fuel*", "seats*", "drivetrain*", "payload_kg*", "engine", "power_hp", "transmission", "doors", "range_km", "towing_kg"),
+	newSpecSchema(CategoryVan, []string{"van", "minivan"},
+		"model_year*", "body_type*", "fuel*", "seats*", "cargo_l*", "engine", "power_hp", "transmission", "drivetrain", "doors", "range_km", "payload_kg"),
+}
+
+func newSpecSchema(category string, bodyTypes []string, names ...string) SpecSchema {
+	schema := SpecSchema{Category: category}
+	for _, name := range names {
+		required := strings.HasSuffix(name, "*")
+		attr, _ := SpecAttributeByName(strings.TrimSuffix(name, "*"))
+		if attr.Name == "body_type" {
+			attr.Values = bodyTypes
+		}
+		schema.Attributes = append(schema.Attributes, SchemaAttribute{SpecAttribute: attr, Required: required})
+	}
+	return schema
+}
+
+// SpecAttributeByName returns the specification attribute called name
+func SpecAttributeByName(name string) (SpecAttribute, bool) {
+	for _, attr := range SpecAttributes {
+		if attr.Name == name {
+			return attr, true
+		}
+	}
+	return SpecAttribute{}, false
+}
+
+// SpecSchemaOf returns the schema of category
+func SpecSchemaOf(category string) (SpecSchema, bool) {
+	for _, schema := range SpecSchemas {
+		if schema.Category == category {
+			return schema, true
+		}
+	}
+	return SpecSchema{}, false
+}
+
+// Specs are the specification attributes of a car by name, stored as a JSON
+// object. Numbers are float64 values, as decoded by encoding/json.
+type Specs map[string]interface{}
+
+// Value implements driver.Valuer, storing nil specs as an empty object
+func (s Specs) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]interface{}(s))
+}
+
+// Scan implements sql.Scanner
+func (s *Specs) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Specs", src)
+	}
+	return json.Unmarshal(data, s)
+}
+
+// Validate checks specs against the schema of category and returns them
+// with whole numbers as float64, as they are read back from the database
+func (schema SpecSchema) Validate(specs Specs) (Specs, error) {
+	var problems []string
+	normalized := Specs{}
+	for name, value := range specs {
+		var attr *SchemaAttribute
+		for i := range schema.Attributes {
+			if schema.Attributes[i].Name == name {
+				attr = &schema.Attributes[i]
+			}
+		}
+		if attr == nil {
+			problems = append(problems, fmt.Sprintf("%s is not an attribute of %s cars", name, schema.Category))
+			continue
+		}
+		v, err := attr.check(value)
+		if err != nil {
+			problems = append(problems, name+" "+err.Error())
+			continue
+		}
+		normalized[name] = v
+	}
+	for _, attr := range schema.Attributes {
+		if _, ok := specs[attr.Name]; attr.Required && !ok {
+			problems = append(problems, attr.Name+" is required for "+schema.Category+" cars")
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return normalized, nil
+}
+
+// check returns value, if it is valid for the attribute, as it is stored
+func (attr SpecAttribute) check(value interface{}) (interface{}, error) {
+	switch attr.Type {
+	case SpecNumber:
+		var n float64
+		switch v := value.(type) {
+		case float64:
+			n = v
+		case int:
+			n = float64(v)
+		default:
+			return nil, errors.New("must be a number")
+		}
+		if n != math.Trunc(n) {
+			return nil, errors.New("must be a whole number")
+		}
+		if n < float64(attr.Min) || n > float64(attr.Max) {
+			return nil, fmt.Errorf("must be between %d and %d", attr.Min, attr.Max)
+		}
+		return n, nil
+	case SpecEnum:
+		s, ok := value.(string)
+		if !ok || !attr.allows(s) {
+			return nil, fmt.Errorf("must be one of %s", strings.Join(attr.Values, ", "))
+		}
+		return s, nil
+	default:
+		s, ok := value.(string)
+		if !ok || strings.TrimSpace(s) == "" || len(s) > 100 {
+			return nil, errors.New("must be text of 1 to 100 characters")
+		}
+		return s, nil
+	}
+}
+
+func (attr SpecAttribute) allows(value string) bool {
+	for _, v := range attr.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Operators of specification filters
+const (
+	SpecEq  = "eq"  // Any of Values
+	SpecGte = "gte" // At least the single value
+	SpecLte = "lte" // At most the single value
+)
+
+// SpecFilter restricts a car listing by one specification attribute
+type SpecFilter struct {
+	Attribute string
+	Op        string
+	Values    []interface{} // Strings, or float64 for number attributes
+}
+
+// ParseSpecFilters parses the specification filters of a car listing from
+// query: "<attribute>=<value>[,<value>...]" matches any of the values, and
+// "<attribute>_gte=<n>" and "<attribute>_lte=<n>" bound number attributes.
+// Other parameters are ignored.
+func ParseSpecFilters(query url.Values) ([]SpecFilter, error) {
+	var filters []SpecFilter
+	for _, attr := range SpecAttributes {
+		if raw := query.Get(attr.Name); raw != "" {
+			filter := SpecFilter{Attribute: attr.Name, Op: SpecEq}
+			for _, s := range strings.Split(raw, ",") {
+				value, err := attr.parse(strings.TrimSpace(s))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", attr.Name, err)
+				}
+				filter.Values = append(filter.Values, value)
+			}
+			filters = append(filters, filter)
+		}
+		for _, op := range []string{SpecGte, SpecLte} {
+			raw := query.Get(attr.Name + "_" + op)
+			if raw == "" {
+				continue
+			}
+			if attr.Type != SpecNumber {
+				return nil, fmt.Errorf("%s_%s: only number attributes can be filtered by range", attr.Name, op)
+			}
+			value, err := attr.parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s_%s: %w", attr.Name, op, err)
+			}
+			filters = append(filters, SpecFilter{Attribute: attr.Name, Op: op, Values: []interface{}{value}})
+		}
+	}
+	return filters, nil
+}
+
+// parse parses a filter value of the attribute
+func (attr SpecAttribute) parse(s string) (interface{}, error) {
+	switch attr.Type {
+	case SpecNumber:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+		return float64(n), nil
+	case SpecEnum:
+		if !attr.allows(s) {
+			return nil, fmt.Errorf("must be one of %s", strings.Join(attr.Values, ", "))
+		}
+	}
+	return s, nil
+}
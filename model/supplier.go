@@ -2,11 +2,14 @@ package model
 
 import (
 	"time"
+
+	"github.com/GoodsChain/backend/redact"
 )
 
 // Supplier represents a supplier in the system.
 type Supplier struct {
 	ID        string    `db:"id" json:"id" example:"supp_01H7ZD00X8X5X8X5X8X5X8X5X8" description:"Unique identifier for the supplier"`
+	TenantID  string    `db:"tenant_id" json:"-"`
 	Name      string    `db:"name" json:"name" binding:"required" example:"Supplier Inc." description:"Name of the supplier"`
 	Address   string    `db:"address" json:"address" binding:"required" example:"456 Industrial Rd, Factory City, USA" description:"Address of the supplier"`
 	Phone     string    `db:"phone" json:"phone" example:"555-987-6543" description:"Phone number of the supplier (optional)"`
@@ -16,3 +19,10 @@ type Supplier struct {
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-02-11T14:45:00Z" format:"date-time" description:"Timestamp of when the supplier was last updated"`
 	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"system_user" description:"Identifier of the user/process that last updated the supplier"`
 }
+
+// MaskContact masks the email and phone of the supplier, as in
+// "j***@example.com" and "555-***-4567", for callers that may not see them
+func (s *Supplier) MaskContact() {
+	s.Email = redact.Email(s.Email)
+	s.Phone = redact.Phone(s.Phone)
+}
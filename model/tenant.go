@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// DefaultTenantID is the tenant of anonymous requests, and of the records
+// created before the deployment had tenants
+const DefaultTenantID = "00000000-0000-0000-0000-000000000000"
+
+// Tenant is a dealer organization. Its records are only visible to the users
+// and API keys of the tenant.
+type Tenant struct {
+	ID        string    `db:"id" json:"id" example:"7d2e4c1a-9b3f-4e6d-8a5c-1f0e2d3c4b5a" description:"Unique identifier for the tenant"`
+	Name      string    `db:"name" json:"name" example:"Saigon Motors" description:"Unique name of the tenant"`
+	CreatedAt time.Time `db:"created_at" json:"created_at" example:"2023-01-15T10:30:00Z" format:"date-time" description:"Timestamp of when the tenant was created"`
+	CreatedBy string    `db:"created_by" json:"created_by" example:"system" description:"Identifier of the user/process that created the tenant"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at" example:"2023-01-16T11:00:00Z" format:"date-time" description:"Timestamp of when the tenant was last updated"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by" example:"system" description:"Identifier of the user/process that last updated the tenant"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+// User roles
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// User represents a local user account in the system.
+type User struct {
+	ID           string     `db:"id" json:"id" example:"6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b" description:"Unique identifier for the user"`
+	TenantID     string     `db:"tenant_id" json:"tenant_id" example:"00000000-0000-0000-0000-000000000000" description:"Tenant the user belongs to"`
+	Username     string     `db:"username" json:"username" binding:"required,max=50" example:"jdoe" description:"Unique login name of the user"`
+	Email        string     `db:"email" json:"email" binding:"required,email" example:"jdoe@example.com" description:"Email address of the user"`
+	PasswordHash string     `db:"password_hash" json:"-"`
+	Role         string     `db:"role" json:"role" binding:"required,oneof=admin editor viewer" example:"viewer" description:"Role of the user (admin, editor or viewer)"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at" example:"2023-01-15T10:30:00Z" format:"date-time" description:"Timestamp of when the user was created"`
+	CreatedBy    string     `db:"created_by" json:"created_by" example:"system" description:"Identifier of the user/process that created the user"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at" example:"2023-01-16T11:00:00Z" format:"date-time" description:"Timestamp of when the user was last updated"`
+	UpdatedBy    string     `db:"updated_by" json:"updated_by" example:"system" description:"Identifier of the user/process that last updated the user"`
+	FailedLogins int        `db:"failed_logins" json:"-"`
+	LockedUntil  *time.Time `db:"locked_until" json:"-"`
+}
+
+// RoleScopes are the scopes granted to the users of each role
+var RoleScopes = map[string][]string{
+	RoleAdmin:  {ScopeAdmin},
+	RoleEditor: {ScopeWrite},
+	RoleViewer: {ScopeRead},
+}
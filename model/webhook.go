@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+// WebhookSubscription is an endpoint that receives events of the given types.
+type WebhookSubscription struct {
+	ID          string         `json:"id" db:"id" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d" description:"Unique identifier for the subscription"`
+	TenantID    string         `json:"-" db:"tenant_id"`
+	URL         string         `json:"url" db:"url" example:"https://dealer.example.com/hooks/goodschain" description:"Endpoint that receives POST requests"`
+	EventTypes  pq.StringArray `json:"event_types" db:"event_types" swaggertype:"array,string" example:"customer_car.created,car.price_changed" description:"Event types delivered to the endpoint"`
+	Secret      string         `json:"secret,omitempty" db:"secret" example:"whsec_3f9a..." description:"HMAC-SHA256 signing secret, only returned when the subscription is created"`
+	Description string         `json:"description" db:"description" example:"Dealer portal" description:"Free-form description"`
+	Active      bool           `json:"active" db:"active" example:"true" description:"Whether events are delivered"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at" example:"2023-03-20T10:00:00Z" format:"date-time" description:"Timestamp of when the subscription was created"`
+	CreatedBy   string         `json:"created_by" db:"created_by" example:"admin_user" description:"Identifier of the user/process that created the subscription"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at" example:"2023-03-21T11:30:00Z" format:"date-time" description:"Timestamp of when the subscription was last updated"`
+	UpdatedBy   string         `json:"updated_by" db:"updated_by" example:"admin_user" description:"Identifier of the user/process that last updated the subscription"`
+}
+
+// WebhookRequest is the payload for creating or updating a subscription.
+// A secret is generated when none is given on create; on update an empty
+// secret keeps the current one.
+type WebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048" example:"https://dealer.example.com/hooks/goodschain"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1,dive,oneof=customer.created customer.updated customer.deleted supplier.created supplier.updated supplier.deleted car.created car.updated car.deleted car.price_changed customer_car.created customer_car.updated customer_car.deleted" example:"customer_car.created"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=100" example:"a-long-random-shared-secret"`
+	Description string   `json:"description" binding:"max=255" example:"Dealer portal"`
+	Active      *bool    `json:"active" example:"true"`
+	Actor       string   `json:"-"` // User or process making the change
+}
+
+// DeliveryFilter selects entries of a subscription's delivery log
+type DeliveryFilter struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to one subscription.
+type WebhookDelivery struct {
+	ID             string          `json:"id" db:"id" example:"1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f" description:"Unique identifier for the delivery"`
+	SubscriptionID string          `json:"subscription_id" db:"subscription_id" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d" description:"Subscription the event is delivered to"`
+	EventID        string          `json:"event_id" db:"event_id" example:"5f0c7a4e-2d3b-4c5a-9e8f-7a6b5c4d3e2f" description:"Delivered event; redeliveries share it"`
+	EventType      string          `json:"event_type" db:"event_type" example:"car.price_changed" description:"Event type"`
+	Payload        json.RawMessage `json:"payload" db:"payload" swaggertype:"object" description:"Request body sent to the endpoint"`
+	Status         string          `json:"status" db:"status" example:"pending" description:"pending, delivered or dead"`
+	Attempts       int             `json:"attempts" db:"attempts" example:"2" description:"Number of attempts made"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at" format:"date-time" description:"Time of the next attempt while pending"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" db:"last_attempt_at" format:"date-time" description:"Time of the last attempt"`
+	ResponseStatus int             `json:"response_status" db:"response_status" example:"503" description:"HTTP status of the last attempt, 0 if no response"`
+	ResponseBody   string          `json:"response_body" db:"response_body" description:"Start of the last response body"`
+	LastError      string          `json:"last_error" db:"last_error" description:"Error of the last failed attempt"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at" format:"date-time" description:"Time of the successful attempt"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at" format:"date-time" description:"Time the delivery was queued"`
+}
+
+// WebhookDispatch is a claimed delivery together with its endpoint.
+type WebhookDispatch struct {
+	WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
@@ -0,0 +1,706 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: goodschain/v1/car.proto
+
+package goodschainv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Car struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SupplierId string                 `protobuf:"bytes,3,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	// Price in the smallest currency unit
+	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Car) Reset() {
+	*x = Car{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Car) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Car) ProtoMessage() {}
+
+func (x *Car) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Car.ProtoReflect.Descriptor instead.
+func (*Car) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Car) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Car) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Car) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+func (x *Car) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Car) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Car) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Car) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Car) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           *Car                   `protobuf:"bytes,1,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCarRequest) Reset() {
+	*x = CreateCarRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCarRequest) ProtoMessage() {}
+
+func (x *CreateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCarRequest.ProtoReflect.Descriptor instead.
+func (*CreateCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCarRequest) GetCar() *Car {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type GetCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCarRequest) Reset() {
+	*x = GetCarRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCarRequest) ProtoMessage() {}
+
+func (x *GetCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCarRequest.ProtoReflect.Descriptor instead.
+func (*GetCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetCarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCarsRequest) Reset() {
+	*x = BatchGetCarsRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCarsRequest) ProtoMessage() {}
+
+func (x *BatchGetCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCarsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCarsRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetCarsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetCarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cars          []*Car                 `protobuf:"bytes,1,rep,name=cars,proto3" json:"cars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCarsResponse) Reset() {
+	*x = BatchGetCarsResponse{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCarsResponse) ProtoMessage() {}
+
+func (x *BatchGetCarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCarsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCarsResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetCarsResponse) GetCars() []*Car {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+type ListCarsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive substring of the name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Only cars from this supplier
+	SupplierId string `protobuf:"bytes,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	// Minimum price, inclusive; zero leaves it open
+	MinPrice int64 `protobuf:"varint,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	// Maximum price, inclusive; zero leaves it open
+	MaxPrice      int64         `protobuf:"varint,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	Created       *CreatedRange `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCarsRequest) Reset() {
+	*x = ListCarsRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCarsRequest) ProtoMessage() {}
+
+func (x *ListCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCarsRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCarsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetCreated() *CreatedRange {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type ListCarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cars          []*Car                 `protobuf:"bytes,1,rep,name=cars,proto3" json:"cars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCarsResponse) Reset() {
+	*x = ListCarsResponse{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCarsResponse) ProtoMessage() {}
+
+func (x *ListCarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCarsResponse.ProtoReflect.Descriptor instead.
+func (*ListCarsResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCarsResponse) GetCars() []*Car {
+	if x != nil {
+		return x.Cars
+	}
+	return nil
+}
+
+type ListSupplierCarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SupplierIds   []string               `protobuf:"bytes,1,rep,name=supplier_ids,json=supplierIds,proto3" json:"supplier_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSupplierCarsRequest) Reset() {
+	*x = ListSupplierCarsRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSupplierCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSupplierCarsRequest) ProtoMessage() {}
+
+func (x *ListSupplierCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSupplierCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListSupplierCarsRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSupplierCarsRequest) GetSupplierIds() []string {
+	if x != nil {
+		return x.SupplierIds
+	}
+	return nil
+}
+
+type UpdateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Car           *Car                   `protobuf:"bytes,2,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCarRequest) Reset() {
+	*x = UpdateCarRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCarRequest) ProtoMessage() {}
+
+func (x *UpdateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCarRequest) GetCar() *Car {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type DeleteCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCarRequest) Reset() {
+	*x = DeleteCarRequest{}
+	mi := &file_goodschain_v1_car_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCarRequest) ProtoMessage() {}
+
+func (x *DeleteCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_car_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_car_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_goodschain_v1_car_proto protoreflect.FileDescriptor
+
+const file_goodschain_v1_car_proto_rawDesc = "" +
+	"\n" +
+	"\x17goodschain/v1/car.proto\x12\rgoodschain.v1\x1a\x1agoodschain/v1/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x02\n" +
+	"\x03Car\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsupplier_id\x18\x03 \x01(\tR\n" +
+	"supplierId\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\"8\n" +
+	"\x10CreateCarRequest\x12$\n" +
+	"\x03car\x18\x01 \x01(\v2\x12.goodschain.v1.CarR\x03car\"\x1f\n" +
+	"\rGetCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x13BatchGetCarsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\">\n" +
+	"\x14BatchGetCarsResponse\x12&\n" +
+	"\x04cars\x18\x01 \x03(\v2\x12.goodschain.v1.CarR\x04cars\"\xb7\x01\n" +
+	"\x0fListCarsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\tR\n" +
+	"supplierId\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x03R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x03R\bmaxPrice\x125\n" +
+	"\acreated\x18\x05 \x01(\v2\x1b.goodschain.v1.CreatedRangeR\acreated\":\n" +
+	"\x10ListCarsResponse\x12&\n" +
+	"\x04cars\x18\x01 \x03(\v2\x12.goodschain.v1.CarR\x04cars\"<\n" +
+	"\x17ListSupplierCarsRequest\x12!\n" +
+	"\fsupplier_ids\x18\x01 \x03(\tR\vsupplierIds\"H\n" +
+	"\x10UpdateCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\x03car\x18\x02 \x01(\v2\x12.goodschain.v1.CarR\x03car\"\"\n" +
+	"\x10DeleteCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x9e\x06\n" +
+	"\n" +
+	"CarService\x12W\n" +
+	"\tCreateCar\x12\x1f.goodschain.v1.CreateCarRequest\x1a\x12.goodschain.v1.Car\"\x15\x82\xd3\xe4\x93\x02\x0f:\x03car\"\b/v1/cars\x12Q\n" +
+	"\x06GetCar\x12\x1c.goodschain.v1.GetCarRequest\x1a\x12.goodschain.v1.Car\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/cars/{id}\x12r\n" +
+	"\fBatchGetCars\x12\".goodschain.v1.BatchGetCarsRequest\x1a#.goodschain.v1.BatchGetCarsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/cars:batchGet\x12]\n" +
+	"\bListCars\x12\x1e.goodschain.v1.ListCarsRequest\x1a\x1f.goodschain.v1.ListCarsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/cars\x12[\n" +
+	"\n" +
+	"StreamCars\x12\x1e.goodschain.v1.ListCarsRequest\x1a\x12.goodschain.v1.Car\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/cars:stream0\x01\x12y\n" +
+	"\x10ListSupplierCars\x12&.goodschain.v1.ListSupplierCarsRequest\x1a\x1f.goodschain.v1.ListCarsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/cars:bySuppliers\x12\\\n" +
+	"\tUpdateCar\x12\x1f.goodschain.v1.UpdateCarRequest\x1a\x12.goodschain.v1.Car\"\x1a\x82\xd3\xe4\x93\x02\x14:\x03car\x1a\r/v1/cars/{id}\x12[\n" +
+	"\tDeleteCar\x12\x1f.goodschain.v1.DeleteCarRequest\x1a\x16.google.protobuf.Empty\"\x15\x82\xd3\xe4\x93\x02\x0f*\r/v1/cars/{id}B@Z>github.com/GoodsChain/backend/proto/goodschain/v1;goodschainv1b\x06proto3"
+
+var (
+	file_goodschain_v1_car_proto_rawDescOnce sync.Once
+	file_goodschain_v1_car_proto_rawDescData []byte
+)
+
+func file_goodschain_v1_car_proto_rawDescGZIP() []byte {
+	file_goodschain_v1_car_proto_rawDescOnce.Do(func() {
+		file_goodschain_v1_car_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goodschain_v1_car_proto_rawDesc), len(file_goodschain_v1_car_proto_rawDesc)))
+	})
+	return file_goodschain_v1_car_proto_rawDescData
+}
+
+var file_goodschain_v1_car_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_goodschain_v1_car_proto_goTypes = []any{
+	(*Car)(nil),                     // 0: goodschain.v1.Car
+	(*CreateCarRequest)(nil),        // 1: goodschain.v1.CreateCarRequest
+	(*GetCarRequest)(nil),           // 2: goodschain.v1.GetCarRequest
+	(*BatchGetCarsRequest)(nil),     // 3: goodschain.v1.BatchGetCarsRequest
+	(*BatchGetCarsResponse)(nil),    // 4: goodschain.v1.BatchGetCarsResponse
+	(*ListCarsRequest)(nil),         // 5: goodschain.v1.ListCarsRequest
+	(*ListCarsResponse)(nil),        // 6: goodschain.v1.ListCarsResponse
+	(*ListSupplierCarsRequest)(nil), // 7: goodschain.v1.ListSupplierCarsRequest
+	(*UpdateCarRequest)(nil),        // 8: goodschain.v1.UpdateCarRequest
+	(*DeleteCarRequest)(nil),        // 9: goodschain.v1.DeleteCarRequest
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+	(*CreatedRange)(nil),            // 11: goodschain.v1.CreatedRange
+	(*emptypb.Empty)(nil),           // 12: google.protobuf.Empty
+}
+var file_goodschain_v1_car_proto_depIdxs = []int32{
+	10, // 0: goodschain.v1.Car.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: goodschain.v1.Car.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: goodschain.v1.CreateCarRequest.car:type_name -> goodschain.v1.Car
+	0,  // 3: goodschain.v1.BatchGetCarsResponse.cars:type_name -> goodschain.v1.Car
+	11, // 4: goodschain.v1.ListCarsRequest.created:type_name -> goodschain.v1.CreatedRange
+	0,  // 5: goodschain.v1.ListCarsResponse.cars:type_name -> goodschain.v1.Car
+	0,  // 6: goodschain.v1.UpdateCarRequest.car:type_name -> goodschain.v1.Car
+	1,  // 7: goodschain.v1.CarService.CreateCar:input_type -> goodschain.v1.CreateCarRequest
+	2,  // 8: goodschain.v1.CarService.GetCar:input_type -> goodschain.v1.GetCarRequest
+	3,  // 9: goodschain.v1.CarService.BatchGetCars:input_type -> goodschain.v1.BatchGetCarsRequest
+	5,  // 10: goodschain.v1.CarService.ListCars:input_type -> goodschain.v1.ListCarsRequest
+	5,  // 11: goodschain.v1.CarService.StreamCars:input_type -> goodschain.v1.ListCarsRequest
+	7,  // 12: goodschain.v1.CarService.ListSupplierCars:input_type -> goodschain.v1.ListSupplierCarsRequest
+	8,  // 13: goodschain.v1.CarService.UpdateCar:input_type -> goodschain.v1.UpdateCarRequest
+	9,  // 14: goodschain.v1.CarService.DeleteCar:input_type -> goodschain.v1.DeleteCarRequest
+	0,  // 15: goodschain.v1.CarService.CreateCar:output_type -> goodschain.v1.Car
+	0,  // 16: goodschain.v1.CarService.GetCar:output_type -> goodschain.v1.Car
+	4,  // 17: goodschain.v1.CarService.BatchGetCars:output_type -> goodschain.v1.BatchGetCarsResponse
+	6,  // 18: goodschain.v1.CarService.ListCars:output_type -> goodschain.v1.ListCarsResponse
+	0,  // 19: goodschain.v1.CarService.StreamCars:output_type -> goodschain.v1.Car
+	6,  // 20: goodschain.v1.CarService.ListSupplierCars:output_type -> goodschain.v1.ListCarsResponse
+	0,  // 21: goodschain.v1.CarService.UpdateCar:output_type -> goodschain.v1.Car
+	12, // 22: goodschain.v1.CarService.DeleteCar:output_type -> google.protobuf.Empty
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_goodschain_v1_car_proto_init() }
+func file_goodschain_v1_car_proto_init() {
+	if File_goodschain_v1_car_proto != nil {
+		return
+	}
+	file_goodschain_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goodschain_v1_car_proto_rawDesc), len(file_goodschain_v1_car_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_goodschain_v1_car_proto_goTypes,
+		DependencyIndexes: file_goodschain_v1_car_proto_depIdxs,
+		MessageInfos:      file_goodschain_v1_car_proto_msgTypes,
+	}.Build()
+	File_goodschain_v1_car_proto = out.File
+	file_goodschain_v1_car_proto_goTypes = nil
+	file_goodschain_v1_car_proto_depIdxs = nil
+}
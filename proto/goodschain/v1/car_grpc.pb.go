@@ -0,0 +1,414 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: goodschain/v1/car.proto
+
+package goodschainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CarService_CreateCar_FullMethodName        = "/goodschain.v1.CarService/CreateCar"
+	CarService_GetCar_FullMethodName           = "/goodschain.v1.CarService/GetCar"
+	CarService_BatchGetCars_FullMethodName     = "/goodschain.v1.CarService/BatchGetCars"
+	CarService_ListCars_FullMethodName         = "/goodschain.v1.CarService/ListCars"
+	CarService_StreamCars_FullMethodName       = "/goodschain.v1.CarService/StreamCars"
+	CarService_ListSupplierCars_FullMethodName = "/goodschain.v1.CarService/ListSupplierCars"
+	CarService_UpdateCar_FullMethodName        = "/goodschain.v1.CarService/UpdateCar"
+	CarService_DeleteCar_FullMethodName        = "/goodschain.v1.CarService/DeleteCar"
+)
+
+// CarServiceClient is the client API for CarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CarService manages cars. It mirrors usecase.CarUsecase.
+type CarServiceClient interface {
+	// CreateCar creates a car; the ID is generated when empty.
+	CreateCar(ctx context.Context, in *CreateCarRequest, opts ...grpc.CallOption) (*Car, error)
+	// GetCar returns one car.
+	GetCar(ctx context.Context, in *GetCarRequest, opts ...grpc.CallOption) (*Car, error)
+	// BatchGetCars returns the cars with the given IDs; unknown IDs are left out.
+	BatchGetCars(ctx context.Context, in *BatchGetCarsRequest, opts ...grpc.CallOption) (*BatchGetCarsResponse, error)
+	// ListCars returns the cars matching the filter, newest first.
+	ListCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (*ListCarsResponse, error)
+	// StreamCars sends the cars matching the filter one at a time, for
+	// listings too large for one response.
+	StreamCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Car], error)
+	// ListSupplierCars returns the cars of the given suppliers, newest first.
+	ListSupplierCars(ctx context.Context, in *ListSupplierCarsRequest, opts ...grpc.CallOption) (*ListCarsResponse, error)
+	// UpdateCar replaces the details of a car.
+	UpdateCar(ctx context.Context, in *UpdateCarRequest, opts ...grpc.CallOption) (*Car, error)
+	// DeleteCar deletes a car.
+	DeleteCar(ctx context.Context, in *DeleteCarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type carServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCarServiceClient(cc grpc.ClientConnInterface) CarServiceClient {
+	return &carServiceClient{cc}
+}
+
+func (c *carServiceClient) CreateCar(ctx context.Context, in *CreateCarRequest, opts ...grpc.CallOption) (*Car, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_CreateCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) GetCar(ctx context.Context, in *GetCarRequest, opts ...grpc.CallOption) (*Car, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_GetCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) BatchGetCars(ctx context.Context, in *BatchGetCarsRequest, opts ...grpc.CallOption) (*BatchGetCarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetCarsResponse)
+	err := c.cc.Invoke(ctx, CarService_BatchGetCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) ListCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (*ListCarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCarsResponse)
+	err := c.cc.Invoke(ctx, CarService_ListCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) StreamCars(ctx context.Context, in *ListCarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Car], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CarService_ServiceDesc.Streams[0], CarService_StreamCars_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListCarsRequest, Car]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CarService_StreamCarsClient = grpc.ServerStreamingClient[Car]
+
+func (c *carServiceClient) ListSupplierCars(ctx context.Context, in *ListSupplierCarsRequest, opts ...grpc.CallOption) (*ListCarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCarsResponse)
+	err := c.cc.Invoke(ctx, CarService_ListSupplierCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) UpdateCar(ctx context.Context, in *UpdateCarRequest, opts ...grpc.CallOption) (*Car, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Car)
+	err := c.cc.Invoke(ctx, CarService_UpdateCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carServiceClient) DeleteCar(ctx context.Context, in *DeleteCarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CarService_DeleteCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarServiceServer is the server API for CarService service.
+// All implementations must embed UnimplementedCarServiceServer
+// for forward compatibility.
+//
+// CarService manages cars. It mirrors usecase.CarUsecase.
+type CarServiceServer interface {
+	// CreateCar creates a car; the ID is generated when empty.
+	CreateCar(context.Context, *CreateCarRequest) (*Car, error)
+	// GetCar returns one car.
+	GetCar(context.Context, *GetCarRequest) (*Car, error)
+	// BatchGetCars returns the cars with the given IDs; unknown IDs are left out.
+	BatchGetCars(context.Context, *BatchGetCarsRequest) (*BatchGetCarsResponse, error)
+	// ListCars returns the cars matching the filter, newest first.
+	ListCars(context.Context, *ListCarsRequest) (*ListCarsResponse, error)
+	// StreamCars sends the cars matching the filter one at a time, for
+	// listings too large for one response.
+	StreamCars(*ListCarsRequest, grpc.ServerStreamingServer[Car]) error
+	// ListSupplierCars returns the cars of the given suppliers, newest first.
+	ListSupplierCars(context.Context, *ListSupplierCarsRequest) (*ListCarsResponse, error)
+	// UpdateCar replaces the details of a car.
+	UpdateCar(context.Context, *UpdateCarRequest) (*Car, error)
+	// DeleteCar deletes a car.
+	DeleteCar(context.Context, *DeleteCarRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCarServiceServer()
+}
+
+// UnimplementedCarServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCarServiceServer struct{}
+
+func (UnimplementedCarServiceServer) CreateCar(context.Context, *CreateCarRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCar not implemented")
+}
+func (UnimplementedCarServiceServer) GetCar(context.Context, *GetCarRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCar not implemented")
+}
+func (UnimplementedCarServiceServer) BatchGetCars(context.Context, *BatchGetCarsRequest) (*BatchGetCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetCars not implemented")
+}
+func (UnimplementedCarServiceServer) ListCars(context.Context, *ListCarsRequest) (*ListCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCars not implemented")
+}
+func (UnimplementedCarServiceServer) StreamCars(*ListCarsRequest, grpc.ServerStreamingServer[Car]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCars not implemented")
+}
+func (UnimplementedCarServiceServer) ListSupplierCars(context.Context, *ListSupplierCarsRequest) (*ListCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupplierCars not implemented")
+}
+func (UnimplementedCarServiceServer) UpdateCar(context.Context, *UpdateCarRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCar not implemented")
+}
+func (UnimplementedCarServiceServer) DeleteCar(context.Context, *DeleteCarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCar not implemented")
+}
+func (UnimplementedCarServiceServer) mustEmbedUnimplementedCarServiceServer() {}
+func (UnimplementedCarServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeCarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CarServiceServer will
+// result in compilation errors.
+type UnsafeCarServiceServer interface {
+	mustEmbedUnimplementedCarServiceServer()
+}
+
+func RegisterCarServiceServer(s grpc.ServiceRegistrar, srv CarServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCarServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CarService_ServiceDesc, srv)
+}
+
+func _CarService_CreateCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).CreateCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_CreateCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).CreateCar(ctx, req.(*CreateCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_GetCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).GetCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_GetCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).GetCar(ctx, req.(*GetCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_BatchGetCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).BatchGetCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_BatchGetCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).BatchGetCars(ctx, req.(*BatchGetCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_ListCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).ListCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_ListCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).ListCars(ctx, req.(*ListCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_StreamCars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCarsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CarServiceServer).StreamCars(m, &grpc.GenericServerStream[ListCarsRequest, Car]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CarService_StreamCarsServer = grpc.ServerStreamingServer[Car]
+
+func _CarService_ListSupplierCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSupplierCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).ListSupplierCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_ListSupplierCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).ListSupplierCars(ctx, req.(*ListSupplierCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_UpdateCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).UpdateCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_UpdateCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).UpdateCar(ctx, req.(*UpdateCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarService_DeleteCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarServiceServer).DeleteCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarService_DeleteCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarServiceServer).DeleteCar(ctx, req.(*DeleteCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CarService_ServiceDesc is the grpc.ServiceDesc for CarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goodschain.v1.CarService",
+	HandlerType: (*CarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCar",
+			Handler:    _CarService_CreateCar_Handler,
+		},
+		{
+			MethodName: "GetCar",
+			Handler:    _CarService_GetCar_Handler,
+		},
+		{
+			MethodName: "BatchGetCars",
+			Handler:    _CarService_BatchGetCars_Handler,
+		},
+		{
+			MethodName: "ListCars",
+			Handler:    _CarService_ListCars_Handler,
+		},
+		{
+			MethodName: "ListSupplierCars",
+			Handler:    _CarService_ListSupplierCars_Handler,
+		},
+		{
+			MethodName: "UpdateCar",
+			Handler:    _CarService_UpdateCar_Handler,
+		},
+		{
+			MethodName: "DeleteCar",
+			Handler:    _CarService_DeleteCar_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCars",
+			Handler:       _CarService_StreamCars_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "goodschain/v1/car.proto",
+}
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: goodschain/v1/common.proto
+
+package goodschainv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CreatedRange restricts a listing to records created in
+// [created_after, created_before). Unset bounds are open.
+type CreatedRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatedRange) Reset() {
+	*x = CreatedRange{}
+	mi := &file_goodschain_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatedRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatedRange) ProtoMessage() {}
+
+func (x *CreatedRange) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatedRange.ProtoReflect.Descriptor instead.
+func (*CreatedRange) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreatedRange) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *CreatedRange) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+var File_goodschain_v1_common_proto protoreflect.FileDescriptor
+
+const file_goodschain_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x1agoodschain/v1/common.proto\x12\rgoodschain.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\x01\n" +
+	"\fCreatedRange\x12?\n" +
+	"\rcreated_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBeforeB@Z>github.com/GoodsChain/backend/proto/goodschain/v1;goodschainv1b\x06proto3"
+
+var (
+	file_goodschain_v1_common_proto_rawDescOnce sync.Once
+	file_goodschain_v1_common_proto_rawDescData []byte
+)
+
+func file_goodschain_v1_common_proto_rawDescGZIP() []byte {
+	file_goodschain_v1_common_proto_rawDescOnce.Do(func() {
+		file_goodschain_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goodschain_v1_common_proto_rawDesc), len(file_goodschain_v1_common_proto_rawDesc)))
+	})
+	return file_goodschain_v1_common_proto_rawDescData
+}
+
+var file_goodschain_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_goodschain_v1_common_proto_goTypes = []any{
+	(*CreatedRange)(nil),          // 0: goodschain.v1.CreatedRange
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_goodschain_v1_common_proto_depIdxs = []int32{
+	1, // 0: goodschain.v1.CreatedRange.created_after:type_name -> google.protobuf.Timestamp
+	1, // 1: goodschain.v1.CreatedRange.created_before:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_goodschain_v1_common_proto_init() }
+func file_goodschain_v1_common_proto_init() {
+	if File_goodschain_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goodschain_v1_common_proto_rawDesc), len(file_goodschain_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_goodschain_v1_common_proto_goTypes,
+		DependencyIndexes: file_goodschain_v1_common_proto_depIdxs,
+		MessageInfos:      file_goodschain_v1_common_proto_msgTypes,
+	}.Build()
+	File_goodschain_v1_common_proto = out.File
+	file_goodschain_v1_common_proto_goTypes = nil
+	file_goodschain_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,639 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: goodschain/v1/customer.proto
+
+package goodschainv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Customer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,9,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Customer) Reset() {
+	*x = Customer{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customer) ProtoMessage() {}
+
+func (x *Customer) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customer.ProtoReflect.Descriptor instead.
+func (*Customer) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Customer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Customer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Customer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Customer) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Customer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Customer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Customer) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Customer) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Customer) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type GetCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerRequest) Reset() {
+	*x = GetCustomerRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerRequest) ProtoMessage() {}
+
+func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCustomerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetCustomersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCustomersRequest) Reset() {
+	*x = BatchGetCustomersRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCustomersRequest) ProtoMessage() {}
+
+func (x *BatchGetCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCustomersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetCustomersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetCustomersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*Customer            `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCustomersResponse) Reset() {
+	*x = BatchGetCustomersResponse{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCustomersResponse) ProtoMessage() {}
+
+func (x *BatchGetCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCustomersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetCustomersResponse) GetCustomers() []*Customer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type ListCustomersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive substring of the name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Exact email address, case-insensitive
+	Email         string        `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Created       *CreatedRange `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomersRequest) Reset() {
+	*x = ListCustomersRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersRequest) ProtoMessage() {}
+
+func (x *ListCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCustomersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListCustomersRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListCustomersRequest) GetCreated() *CreatedRange {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type ListCustomersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*Customer            `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomersResponse) Reset() {
+	*x = ListCustomersResponse{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersResponse) ProtoMessage() {}
+
+func (x *ListCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCustomersResponse) GetCustomers() []*Customer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type UpdateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Customer      *Customer              `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerRequest) Reset() {
+	*x = UpdateCustomerRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateCustomerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type DeleteCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerRequest) Reset() {
+	*x = DeleteCustomerRequest{}
+	mi := &file_goodschain_v1_customer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteCustomerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_goodschain_v1_customer_proto protoreflect.FileDescriptor
+
+const file_goodschain_v1_customer_proto_rawDesc = "" +
+	"\n" +
+	"\x1cgoodschain/v1/customer.proto\x12\rgoodschain.v1\x1a\x1agoodschain/v1/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x02\n" +
+	"\bCustomer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\t \x01(\tR\tupdatedBy\"L\n" +
+	"\x15CreateCustomerRequest\x123\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x17.goodschain.v1.CustomerR\bcustomer\"$\n" +
+	"\x12GetCustomerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x18BatchGetCustomersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"R\n" +
+	"\x19BatchGetCustomersResponse\x125\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x17.goodschain.v1.CustomerR\tcustomers\"w\n" +
+	"\x14ListCustomersRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x125\n" +
+	"\acreated\x18\x03 \x01(\v2\x1b.goodschain.v1.CreatedRangeR\acreated\"N\n" +
+	"\x15ListCustomersResponse\x125\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x17.goodschain.v1.CustomerR\tcustomers\"\\\n" +
+	"\x15UpdateCustomerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\bcustomer\x18\x02 \x01(\v2\x17.goodschain.v1.CustomerR\bcustomer\"'\n" +
+	"\x15DeleteCustomerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xba\x06\n" +
+	"\x0fCustomerService\x12p\n" +
+	"\x0eCreateCustomer\x12$.goodschain.v1.CreateCustomerRequest\x1a\x17.goodschain.v1.Customer\"\x1f\x82\xd3\xe4\x93\x02\x19:\bcustomer\"\r/v1/customers\x12e\n" +
+	"\vGetCustomer\x12!.goodschain.v1.GetCustomerRequest\x1a\x17.goodschain.v1.Customer\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/customers/{id}\x12\x86\x01\n" +
+	"\x11BatchGetCustomers\x12'.goodschain.v1.BatchGetCustomersRequest\x1a(.goodschain.v1.BatchGetCustomersResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/customers:batchGet\x12q\n" +
+	"\rListCustomers\x12#.goodschain.v1.ListCustomersRequest\x1a$.goodschain.v1.ListCustomersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/customers\x12o\n" +
+	"\x0fStreamCustomers\x12#.goodschain.v1.ListCustomersRequest\x1a\x17.goodschain.v1.Customer\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/customers:stream0\x01\x12u\n" +
+	"\x0eUpdateCustomer\x12$.goodschain.v1.UpdateCustomerRequest\x1a\x17.goodschain.v1.Customer\"$\x82\xd3\xe4\x93\x02\x1e:\bcustomer\x1a\x12/v1/customers/{id}\x12j\n" +
+	"\x0eDeleteCustomer\x12$.goodschain.v1.DeleteCustomerRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/customers/{id}B@Z>github.com/GoodsChain/backend/proto/goodschain/v1;goodschainv1b\x06proto3"
+
+var (
+	file_goodschain_v1_customer_proto_rawDescOnce sync.Once
+	file_goodschain_v1_customer_proto_rawDescData []byte
+)
+
+func file_goodschain_v1_customer_proto_rawDescGZIP() []byte {
+	file_goodschain_v1_customer_proto_rawDescOnce.Do(func() {
+		file_goodschain_v1_customer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goodschain_v1_customer_proto_rawDesc), len(file_goodschain_v1_customer_proto_rawDesc)))
+	})
+	return file_goodschain_v1_customer_proto_rawDescData
+}
+
+var file_goodschain_v1_customer_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_goodschain_v1_customer_proto_goTypes = []any{
+	(*Customer)(nil),                  // 0: goodschain.v1.Customer
+	(*CreateCustomerRequest)(nil),     // 1: goodschain.v1.CreateCustomerRequest
+	(*GetCustomerRequest)(nil),        // 2: goodschain.v1.GetCustomerRequest
+	(*BatchGetCustomersRequest)(nil),  // 3: goodschain.v1.BatchGetCustomersRequest
+	(*BatchGetCustomersResponse)(nil), // 4: goodschain.v1.BatchGetCustomersResponse
+	(*ListCustomersRequest)(nil),      // 5: goodschain.v1.ListCustomersRequest
+	(*ListCustomersResponse)(nil),     // 6: goodschain.v1.ListCustomersResponse
+	(*UpdateCustomerRequest)(nil),     // 7: goodschain.v1.UpdateCustomerRequest
+	(*DeleteCustomerRequest)(nil),     // 8: goodschain.v1.DeleteCustomerRequest
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
+	(*CreatedRange)(nil),              // 10: goodschain.v1.CreatedRange
+	(*emptypb.Empty)(nil),             // 11: google.protobuf.Empty
+}
+var file_goodschain_v1_customer_proto_depIdxs = []int32{
+	9,  // 0: goodschain.v1.Customer.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: goodschain.v1.Customer.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: goodschain.v1.CreateCustomerRequest.customer:type_name -> goodschain.v1.Customer
+	0,  // 3: goodschain.v1.BatchGetCustomersResponse.customers:type_name -> goodschain.v1.Customer
+	10, // 4: goodschain.v1.ListCustomersRequest.created:type_name -> goodschain.v1.CreatedRange
+	0,  // 5: goodschain.v1.ListCustomersResponse.customers:type_name -> goodschain.v1.Customer
+	0,  // 6: goodschain.v1.UpdateCustomerRequest.customer:type_name -> goodschain.v1.Customer
+	1,  // 7: goodschain.v1.CustomerService.CreateCustomer:input_type -> goodschain.v1.CreateCustomerRequest
+	2,  // 8: goodschain.v1.CustomerService.GetCustomer:input_type -> goodschain.v1.GetCustomerRequest
+	3,  // 9: goodschain.v1.CustomerService.BatchGetCustomers:input_type -> goodschain.v1.BatchGetCustomersRequest
+	5,  // 10: goodschain.v1.CustomerService.ListCustomers:input_type -> goodschain.v1.ListCustomersRequest
+	5,  // 11: goodschain.v1.CustomerService.StreamCustomers:input_type -> goodschain.v1.ListCustomersRequest
+	7,  // 12: goodschain.v1.CustomerService.UpdateCustomer:input_type -> goodschain.v1.UpdateCustomerRequest
+	8,  // 13: goodschain.v1.CustomerService.DeleteCustomer:input_type -> goodschain.v1.DeleteCustomerRequest
+	0,  // 14: goodschain.v1.CustomerService.CreateCustomer:output_type -> goodschain.v1.Customer
+	0,  // 15: goodschain.v1.CustomerService.GetCustomer:output_type -> goodschain.v1.Customer
+	4,  // 16: goodschain.v1.CustomerService.BatchGetCustomers:output_type -> goodschain.v1.BatchGetCustomersResponse
+	6,  // 17: goodschain.v1.CustomerService.ListCustomers:output_type -> goodschain.v1.ListCustomersResponse
+	0,  // 18: goodschain.v1.CustomerService.StreamCustomers:output_type -> goodschain.v1.Customer
+	0,  // 19: goodschain.v1.CustomerService.UpdateCustomer:output_type -> goodschain.v1.Customer
+	11, // 20: goodschain.v1.CustomerService.DeleteCustomer:output_type -> google.protobuf.Empty
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_goodschain_v1_customer_proto_init() }
+func file_goodschain_v1_customer_proto_init() {
+	if File_goodschain_v1_customer_proto != nil {
+		return
+	}
+	file_goodschain_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goodschain_v1_customer_proto_rawDesc), len(file_goodschain_v1_customer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_goodschain_v1_customer_proto_goTypes,
+		DependencyIndexes: file_goodschain_v1_customer_proto_depIdxs,
+		MessageInfos:      file_goodschain_v1_customer_proto_msgTypes,
+	}.Build()
+	File_goodschain_v1_customer_proto = out.File
+	file_goodschain_v1_customer_proto_goTypes = nil
+	file_goodschain_v1_customer_proto_depIdxs = nil
+}
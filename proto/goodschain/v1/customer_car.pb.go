@@ -0,0 +1,585 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: goodschain/v1/customer_car.proto
+
+package goodschainv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CustomerCar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CarId         string                 `protobuf:"bytes,2,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,7,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerCar) Reset() {
+	*x = CustomerCar{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerCar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerCar) ProtoMessage() {}
+
+func (x *CustomerCar) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerCar.ProtoReflect.Descriptor instead.
+func (*CustomerCar) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomerCar) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CustomerCar) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+func (x *CustomerCar) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CustomerCar) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CustomerCar) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CustomerCar) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CustomerCar) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateCustomerCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerCar   *CustomerCar           `protobuf:"bytes,1,opt,name=customer_car,json=customerCar,proto3" json:"customer_car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerCarRequest) Reset() {
+	*x = CreateCustomerCarRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerCarRequest) ProtoMessage() {}
+
+func (x *CreateCustomerCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerCarRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCustomerCarRequest) GetCustomerCar() *CustomerCar {
+	if x != nil {
+		return x.CustomerCar
+	}
+	return nil
+}
+
+type GetCustomerCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerCarRequest) Reset() {
+	*x = GetCustomerCarRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerCarRequest) ProtoMessage() {}
+
+func (x *GetCustomerCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerCarRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCustomerCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCustomerCarsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only relationships of this customer
+	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// Only relationships of this car
+	CarId         string        `protobuf:"bytes,2,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"`
+	Created       *CreatedRange `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerCarsRequest) Reset() {
+	*x = ListCustomerCarsRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerCarsRequest) ProtoMessage() {}
+
+func (x *ListCustomerCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomerCarsRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCustomerCarsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ListCustomerCarsRequest) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+func (x *ListCustomerCarsRequest) GetCreated() *CreatedRange {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type ListCustomerCarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerCars  []*CustomerCar         `protobuf:"bytes,1,rep,name=customer_cars,json=customerCars,proto3" json:"customer_cars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerCarsResponse) Reset() {
+	*x = ListCustomerCarsResponse{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerCarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerCarsResponse) ProtoMessage() {}
+
+func (x *ListCustomerCarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerCarsResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomerCarsResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCustomerCarsResponse) GetCustomerCars() []*CustomerCar {
+	if x != nil {
+		return x.CustomerCars
+	}
+	return nil
+}
+
+type BatchListCustomerCarsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exactly one of customer_ids and car_ids must be set
+	CustomerIds   []string `protobuf:"bytes,1,rep,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	CarIds        []string `protobuf:"bytes,2,rep,name=car_ids,json=carIds,proto3" json:"car_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchListCustomerCarsRequest) Reset() {
+	*x = BatchListCustomerCarsRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchListCustomerCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchListCustomerCarsRequest) ProtoMessage() {}
+
+func (x *BatchListCustomerCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchListCustomerCarsRequest.ProtoReflect.Descriptor instead.
+func (*BatchListCustomerCarsRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchListCustomerCarsRequest) GetCustomerIds() []string {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *BatchListCustomerCarsRequest) GetCarIds() []string {
+	if x != nil {
+		return x.CarIds
+	}
+	return nil
+}
+
+type UpdateCustomerCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerCar   *CustomerCar           `protobuf:"bytes,2,opt,name=customer_car,json=customerCar,proto3" json:"customer_car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerCarRequest) Reset() {
+	*x = UpdateCustomerCarRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerCarRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerCarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCustomerCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCustomerCarRequest) GetCustomerCar() *CustomerCar {
+	if x != nil {
+		return x.CustomerCar
+	}
+	return nil
+}
+
+type DeleteCustomerCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerCarRequest) Reset() {
+	*x = DeleteCustomerCarRequest{}
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerCarRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_customer_car_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerCarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerCarRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_customer_car_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCustomerCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_goodschain_v1_customer_car_proto protoreflect.FileDescriptor
+
+const file_goodschain_v1_customer_car_proto_rawDesc = "" +
+	"\n" +
+	" goodschain/v1/customer_car.proto\x12\rgoodschain.v1\x1a\x1agoodschain/v1/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x02\n" +
+	"\vCustomerCar\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06car_id\x18\x02 \x01(\tR\x05carId\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\tR\tupdatedBy\"Y\n" +
+	"\x18CreateCustomerCarRequest\x12=\n" +
+	"\fcustomer_car\x18\x01 \x01(\v2\x1a.goodschain.v1.CustomerCarR\vcustomerCar\"'\n" +
+	"\x15GetCustomerCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x88\x01\n" +
+	"\x17ListCustomerCarsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x15\n" +
+	"\x06car_id\x18\x02 \x01(\tR\x05carId\x125\n" +
+	"\acreated\x18\x03 \x01(\v2\x1b.goodschain.v1.CreatedRangeR\acreated\"[\n" +
+	"\x18ListCustomerCarsResponse\x12?\n" +
+	"\rcustomer_cars\x18\x01 \x03(\v2\x1a.goodschain.v1.CustomerCarR\fcustomerCars\"Z\n" +
+	"\x1cBatchListCustomerCarsRequest\x12!\n" +
+	"\fcustomer_ids\x18\x01 \x03(\tR\vcustomerIds\x12\x17\n" +
+	"\acar_ids\x18\x02 \x03(\tR\x06carIds\"i\n" +
+	"\x18UpdateCustomerCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\fcustomer_car\x18\x02 \x01(\v2\x1a.goodschain.v1.CustomerCarR\vcustomerCar\"*\n" +
+	"\x18DeleteCustomerCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x9e\a\n" +
+	"\x12CustomerCarService\x12\x81\x01\n" +
+	"\x11CreateCustomerCar\x12'.goodschain.v1.CreateCustomerCarRequest\x1a\x1a.goodschain.v1.CustomerCar\"'\x82\xd3\xe4\x93\x02!:\fcustomer_car\"\x11/v1/customer-cars\x12r\n" +
+	"\x0eGetCustomerCar\x12$.goodschain.v1.GetCustomerCarRequest\x1a\x1a.goodschain.v1.CustomerCar\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/customer-cars/{id}\x12~\n" +
+	"\x10ListCustomerCars\x12&.goodschain.v1.ListCustomerCarsRequest\x1a'.goodschain.v1.ListCustomerCarsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/customer-cars\x12|\n" +
+	"\x12StreamCustomerCars\x12&.goodschain.v1.ListCustomerCarsRequest\x1a\x1a.goodschain.v1.CustomerCar\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/customer-cars:stream0\x01\x12\x92\x01\n" +
+	"\x15BatchListCustomerCars\x12+.goodschain.v1.BatchListCustomerCarsRequest\x1a'.goodschain.v1.ListCustomerCarsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/customer-cars:batchList\x12\x86\x01\n" +
+	"\x11UpdateCustomerCar\x12'.goodschain.v1.UpdateCustomerCarRequest\x1a\x1a.goodschain.v1.CustomerCar\",\x82\xd3\xe4\x93\x02&:\fcustomer_car\x1a\x16/v1/customer-cars/{id}\x12t\n" +
+	"\x11DeleteCustomerCar\x12'.goodschain.v1.DeleteCustomerCarRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/customer-cars/{id}B@Z>github.com/GoodsChain/backend/proto/goodschain/v1;goodschainv1b\x06proto3"
+
+var (
+	file_goodschain_v1_customer_car_proto_rawDescOnce sync.Once
+	file_goodschain_v1_customer_car_proto_rawDescData []byte
+)
+
+func file_goodschain_v1_customer_car_proto_rawDescGZIP() []byte {
+	file_goodschain_v1_customer_car_proto_rawDescOnce.Do(func() {
+		file_goodschain_v1_customer_car_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goodschain_v1_customer_car_proto_rawDesc), len(file_goodschain_v1_customer_car_proto_rawDesc)))
+	})
+	return file_goodschain_v1_customer_car_proto_rawDescData
+}
+
+var file_goodschain_v1_customer_car_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_goodschain_v1_customer_car_proto_goTypes = []any{
+	(*CustomerCar)(nil),                  // 0: goodschain.v1.CustomerCar
+	(*CreateCustomerCarRequest)(nil),     // 1: goodschain.v1.CreateCustomerCarRequest
+	(*GetCustomerCarRequest)(nil),        // 2: goodschain.v1.GetCustomerCarRequest
+	(*ListCustomerCarsRequest)(nil),      // 3: goodschain.v1.ListCustomerCarsRequest
+	(*ListCustomerCarsResponse)(nil),     // 4: goodschain.v1.ListCustomerCarsResponse
+	(*BatchListCustomerCarsRequest)(nil), // 5: goodschain.v1.BatchListCustomerCarsRequest
+	(*UpdateCustomerCarRequest)(nil),     // 6: goodschain.v1.UpdateCustomerCarRequest
+	(*DeleteCustomerCarRequest)(nil),     // 7: goodschain.v1.DeleteCustomerCarRequest
+	(*timestamppb.Timestamp)(nil),        // 8: google.protobuf.Timestamp
+	(*CreatedRange)(nil),                 // 9: goodschain.v1.CreatedRange
+	(*emptypb.Empty)(nil),                // 10: google.protobuf.Empty
+}
+var file_goodschain_v1_customer_car_proto_depIdxs = []int32{
+	8,  // 0: goodschain.v1.CustomerCar.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: goodschain.v1.CustomerCar.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: goodschain.v1.CreateCustomerCarRequest.customer_car:type_name -> goodschain.v1.CustomerCar
+	9,  // 3: goodschain.v1.ListCustomerCarsRequest.created:type_name -> goodschain.v1.CreatedRange
+	0,  // 4: goodschain.v1.ListCustomerCarsResponse.customer_cars:type_name -> goodschain.v1.CustomerCar
+	0,  // 5: goodschain.v1.UpdateCustomerCarRequest.customer_car:type_name -> goodschain.v1.CustomerCar
+	1,  // 6: goodschain.v1.CustomerCarService.CreateCustomerCar:input_type -> goodschain.v1.CreateCustomerCarRequest
+	2,  // 7: goodschain.v1.CustomerCarService.GetCustomerCar:input_type -> goodschain.v1.GetCustomerCarRequest
+	3,  // 8: goodschain.v1.CustomerCarService.ListCustomerCars:input_type -> goodschain.v1.ListCustomerCarsRequest
+	3,  // 9: goodschain.v1.CustomerCarService.StreamCustomerCars:input_type -> goodschain.v1.ListCustomerCarsRequest
+	5,  // 10: goodschain.v1.CustomerCarService.BatchListCustomerCars:input_type -> goodschain.v1.BatchListCustomerCarsRequest
+	6,  // 11: goodschain.v1.CustomerCarService.UpdateCustomerCar:input_type -> goodschain.v1.UpdateCustomerCarRequest
+	7,  // 12: goodschain.v1.CustomerCarService.DeleteCustomerCar:input_type -> goodschain.v1.DeleteCustomerCarRequest
+	0,  // 13: goodschain.v1.CustomerCarService.CreateCustomerCar:output_type -> goodschain.v1.CustomerCar
+	0,  // 14: goodschain.v1.CustomerCarService.GetCustomerCar:output_type -> goodschain.v1.CustomerCar
+	4,  // 15: goodschain.v1.CustomerCarService.ListCustomerCars:output_type -> goodschain.v1.ListCustomerCarsResponse
+	0,  // 16: goodschain.v1.CustomerCarService.StreamCustomerCars:output_type -> goodschain.v1.CustomerCar
+	4,  // 17: goodschain.v1.CustomerCarService.BatchListCustomerCars:output_type -> goodschain.v1.ListCustomerCarsResponse
+	0,  // 18: goodschain.v1.CustomerCarService.UpdateCustomerCar:output_type -> goodschain.v1.CustomerCar
+	10, // 19: goodschain.v1.CustomerCarService.DeleteCustomerCar:output_type -> google.protobuf.Empty
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_goodschain_v1_customer_car_proto_init() }
+func file_goodschain_v1_customer_car_proto_init() {
+	if File_goodschain_v1_customer_car_proto != nil {
+		return
+	}
+	file_goodschain_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goodschain_v1_customer_car_proto_rawDesc), len(file_goodschain_v1_customer_car_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_goodschain_v1_customer_car_proto_goTypes,
+		DependencyIndexes: file_goodschain_v1_customer_car_proto_depIdxs,
+		MessageInfos:      file_goodschain_v1_customer_car_proto_msgTypes,
+	}.Build()
+	File_goodschain_v1_customer_car_proto = out.File
+	file_goodschain_v1_customer_car_proto_goTypes = nil
+	file_goodschain_v1_customer_car_proto_depIdxs = nil
+}
@@ -0,0 +1,380 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: goodschain/v1/customer_car.proto
+
+package goodschainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CustomerCarService_CreateCustomerCar_FullMethodName     = "/goodschain.v1.CustomerCarService/CreateCustomerCar"
+	CustomerCarService_GetCustomerCar_FullMethodName        = "/goodschain.v1.CustomerCarService/GetCustomerCar"
+	CustomerCarService_ListCustomerCars_FullMethodName      = "/goodschain.v1.CustomerCarService/ListCustomerCars"
+	CustomerCarService_StreamCustomerCars_FullMethodName    = "/goodschain.v1.CustomerCarService/StreamCustomerCars"
+	CustomerCarService_BatchListCustomerCars_FullMethodName = "/goodschain.v1.CustomerCarService/BatchListCustomerCars"
+	CustomerCarService_UpdateCustomerCar_FullMethodName     = "/goodschain.v1.CustomerCarService/UpdateCustomerCar"
+	CustomerCarService_DeleteCustomerCar_FullMethodName     = "/goodschain.v1.CustomerCarService/DeleteCustomerCar"
+)
+
+// CustomerCarServiceClient is the client API for CustomerCarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CustomerCarService manages the relationships between customers and cars.
+// It mirrors usecase.CustomerCarUsecase.
+type CustomerCarServiceClient interface {
+	// CreateCustomerCar creates a relationship; the ID is generated when empty.
+	CreateCustomerCar(ctx context.Context, in *CreateCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error)
+	// GetCustomerCar returns one relationship.
+	GetCustomerCar(ctx context.Context, in *GetCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error)
+	// ListCustomerCars returns the relationships matching the filter, newest
+	// first.
+	ListCustomerCars(ctx context.Context, in *ListCustomerCarsRequest, opts ...grpc.CallOption) (*ListCustomerCarsResponse, error)
+	// StreamCustomerCars sends the relationships matching the filter one at a
+	// time, for listings too large for one response.
+	StreamCustomerCars(ctx context.Context, in *ListCustomerCarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CustomerCar], error)
+	// BatchListCustomerCars returns the relationships of the given customers
+	// and cars, newest first.
+	BatchListCustomerCars(ctx context.Context, in *BatchListCustomerCarsRequest, opts ...grpc.CallOption) (*ListCustomerCarsResponse, error)
+	// UpdateCustomerCar replaces the customer and car of a relationship.
+	UpdateCustomerCar(ctx context.Context, in *UpdateCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error)
+	// DeleteCustomerCar deletes a relationship.
+	DeleteCustomerCar(ctx context.Context, in *DeleteCustomerCarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type customerCarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCustomerCarServiceClient(cc grpc.ClientConnInterface) CustomerCarServiceClient {
+	return &customerCarServiceClient{cc}
+}
+
+func (c *customerCarServiceClient) CreateCustomerCar(ctx context.Context, in *CreateCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerCar)
+	err := c.cc.Invoke(ctx, CustomerCarService_CreateCustomerCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerCarServiceClient) GetCustomerCar(ctx context.Context, in *GetCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerCar)
+	err := c.cc.Invoke(ctx, CustomerCarService_GetCustomerCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerCarServiceClient) ListCustomerCars(ctx context.Context, in *ListCustomerCarsRequest, opts ...grpc.CallOption) (*ListCustomerCarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomerCarsResponse)
+	err := c.cc.Invoke(ctx, CustomerCarService_ListCustomerCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerCarServiceClient) StreamCustomerCars(ctx context.Context, in *ListCustomerCarsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CustomerCar], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CustomerCarService_ServiceDesc.Streams[0], CustomerCarService_StreamCustomerCars_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListCustomerCarsRequest, CustomerCar]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CustomerCarService_StreamCustomerCarsClient = grpc.ServerStreamingClient[CustomerCar]
+
+func (c *customerCarServiceClient) BatchListCustomerCars(ctx context.Context, in *BatchListCustomerCarsRequest, opts ...grpc.CallOption) (*ListCustomerCarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomerCarsResponse)
+	err := c.cc.Invoke(ctx, CustomerCarService_BatchListCustomerCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerCarServiceClient) UpdateCustomerCar(ctx context.Context, in *UpdateCustomerCarRequest, opts ...grpc.CallOption) (*CustomerCar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerCar)
+	err := c.cc.Invoke(ctx, CustomerCarService_UpdateCustomerCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerCarServiceClient) DeleteCustomerCar(ctx context.Context, in *DeleteCustomerCarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CustomerCarService_DeleteCustomerCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CustomerCarServiceServer is the server API for CustomerCarService service.
+// All implementations must embed UnimplementedCustomerCarServiceServer
+// for forward compatibility.
+//
+// CustomerCarService manages the relationships between customers and cars.
+// It mirrors usecase.CustomerCarUsecase.
+type CustomerCarServiceServer interface {
+	// CreateCustomerCar creates a relationship; the ID is generated when empty.
+	CreateCustomerCar(context.Context, *CreateCustomerCarRequest) (*CustomerCar, error)
+	// GetCustomerCar returns one relationship.
+	GetCustomerCar(context.Context, *GetCustomerCarRequest) (*CustomerCar, error)
+	// ListCustomerCars returns the relationships matching the filter, newest
+	// first.
+	ListCustomerCars(context.Context, *ListCustomerCarsRequest) (*ListCustomerCarsResponse, error)
+	// StreamCustomerCars sends the relationships matching the filter one at a
+	// time, for listings too large for one response.
+	StreamCustomerCars(*ListCustomerCarsRequest, grpc.ServerStreamingServer[CustomerCar]) error
+	// BatchListCustomerCars returns the relationships of the given customers
+	// and cars, newest first.
+	BatchListCustomerCars(context.Context, *BatchListCustomerCarsRequest) (*ListCustomerCarsResponse, error)
+	// UpdateCustomerCar replaces the customer and car of a relationship.
+	UpdateCustomerCar(context.Context, *UpdateCustomerCarRequest) (*CustomerCar, error)
+	// DeleteCustomerCar deletes a relationship.
+	DeleteCustomerCar(context.Context, *DeleteCustomerCarRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCustomerCarServiceServer()
+}
+
+// UnimplementedCustomerCarServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCustomerCarServiceServer struct{}
+
+func (UnimplementedCustomerCarServiceServer) CreateCustomerCar(context.Context, *CreateCustomerCarRequest) (*CustomerCar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomerCar not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) GetCustomerCar(context.Context, *GetCustomerCarRequest) (*CustomerCar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerCar not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) ListCustomerCars(context.Context, *ListCustomerCarsRequest) (*ListCustomerCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomerCars not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) StreamCustomerCars(*ListCustomerCarsRequest, grpc.ServerStreamingServer[CustomerCar]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCustomerCars not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) BatchListCustomerCars(context.Context, *BatchListCustomerCarsRequest) (*ListCustomerCarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchListCustomerCars not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) UpdateCustomerCar(context.Context, *UpdateCustomerCarRequest) (*CustomerCar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomerCar not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) DeleteCustomerCar(context.Context, *DeleteCustomerCarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomerCar not implemented")
+}
+func (UnimplementedCustomerCarServiceServer) mustEmbedUnimplementedCustomerCarServiceServer() {}
+func (UnimplementedCustomerCarServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCustomerCarServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CustomerCarServiceServer will
+// result in compilation errors.
+type UnsafeCustomerCarServiceServer interface {
+	mustEmbedUnimplementedCustomerCarServiceServer()
+}
+
+func RegisterCustomerCarServiceServer(s grpc.ServiceRegistrar, srv CustomerCarServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCustomerCarServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CustomerCarService_ServiceDesc, srv)
+}
+
+func _CustomerCarService_CreateCustomerCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCustomerCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).CreateCustomerCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_CreateCustomerCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).CreateCustomerCar(ctx, req.(*CreateCustomerCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerCarService_GetCustomerCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).GetCustomerCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_GetCustomerCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).GetCustomerCar(ctx, req.(*GetCustomerCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerCarService_ListCustomerCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomerCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).ListCustomerCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_ListCustomerCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).ListCustomerCars(ctx, req.(*ListCustomerCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerCarService_StreamCustomerCars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCustomerCarsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CustomerCarServiceServer).StreamCustomerCars(m, &grpc.GenericServerStream[ListCustomerCarsRequest, CustomerCar]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CustomerCarService_StreamCustomerCarsServer = grpc.ServerStreamingServer[CustomerCar]
+
+func _CustomerCarService_BatchListCustomerCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchListCustomerCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).BatchListCustomerCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_BatchListCustomerCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).BatchListCustomerCars(ctx, req.(*BatchListCustomerCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerCarService_UpdateCustomerCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomerCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).UpdateCustomerCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_UpdateCustomerCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).UpdateCustomerCar(ctx, req.(*UpdateCustomerCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomerCarService_DeleteCustomerCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomerCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerCarServiceServer).DeleteCustomerCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomerCarService_DeleteCustomerCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerCarServiceServer).DeleteCustomerCar(ctx, req.(*DeleteCustomerCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CustomerCarService_ServiceDesc is the grpc.ServiceDesc for CustomerCarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CustomerCarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goodschain.v1.CustomerCarService",
+	HandlerType: (*CustomerCarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCustomerCar",
+			Handler:    _CustomerCarService_CreateCustomerCar_Handler,
+		},
+		{
+			MethodName: "GetCustomerCar",
+			Handler:    _CustomerCarService_GetCustomerCar_Handler,
+		},
+		{
+			MethodName: "ListCustomerCars",
+			Handler:    _CustomerCarService_ListCustomerCars_Handler,
+		},
+		{
+			MethodName: "BatchListCustomerCars",
+			Handler:    _CustomerCarService_BatchListCustomerCars_Handler,
+		},
+		{
+			MethodName: "UpdateCustomerCar",
+			Handler:    _CustomerCarService_UpdateCustomerCar_Handler,
+		},
+		{
+			MethodName: "DeleteCustomerCar",
+			Handler:    _CustomerCarService_DeleteCustomerCar_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCustomerCars",
+			Handler:       _CustomerCarService_StreamCustomerCars_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "goodschain/v1/customer_car.proto",
+}
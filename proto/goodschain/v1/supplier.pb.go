@@ -0,0 +1,639 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: goodschain/v1/supplier.proto
+
+package goodschainv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Supplier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,9,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Supplier) Reset() {
+	*x = Supplier{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Supplier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Supplier) ProtoMessage() {}
+
+func (x *Supplier) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Supplier.ProtoReflect.Descriptor instead.
+func (*Supplier) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Supplier) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Supplier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Supplier) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Supplier) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Supplier) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Supplier) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Supplier) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Supplier) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Supplier) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSupplierRequest) Reset() {
+	*x = CreateSupplierRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSupplierRequest) ProtoMessage() {}
+
+func (x *CreateSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSupplierRequest.ProtoReflect.Descriptor instead.
+func (*CreateSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateSupplierRequest) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+type GetSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupplierRequest) Reset() {
+	*x = GetSupplierRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplierRequest) ProtoMessage() {}
+
+func (x *GetSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupplierRequest.ProtoReflect.Descriptor instead.
+func (*GetSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BatchGetSuppliersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetSuppliersRequest) Reset() {
+	*x = BatchGetSuppliersRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetSuppliersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetSuppliersRequest) ProtoMessage() {}
+
+func (x *BatchGetSuppliersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetSuppliersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetSuppliersRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetSuppliersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetSuppliersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppliers     []*Supplier            `protobuf:"bytes,1,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetSuppliersResponse) Reset() {
+	*x = BatchGetSuppliersResponse{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetSuppliersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetSuppliersResponse) ProtoMessage() {}
+
+func (x *BatchGetSuppliersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetSuppliersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetSuppliersResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetSuppliersResponse) GetSuppliers() []*Supplier {
+	if x != nil {
+		return x.Suppliers
+	}
+	return nil
+}
+
+type ListSuppliersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive substring of the name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Exact email address, case-insensitive
+	Email         string        `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Created       *CreatedRange `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersRequest) Reset() {
+	*x = ListSuppliersRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersRequest) ProtoMessage() {}
+
+func (x *ListSuppliersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersRequest.ProtoReflect.Descriptor instead.
+func (*ListSuppliersRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSuppliersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListSuppliersRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListSuppliersRequest) GetCreated() *CreatedRange {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type ListSuppliersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suppliers     []*Supplier            `protobuf:"bytes,1,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersResponse) Reset() {
+	*x = ListSuppliersResponse{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersResponse) ProtoMessage() {}
+
+func (x *ListSuppliersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersResponse.ProtoReflect.Descriptor instead.
+func (*ListSuppliersResponse) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSuppliersResponse) GetSuppliers() []*Supplier {
+	if x != nil {
+		return x.Suppliers
+	}
+	return nil
+}
+
+type UpdateSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Supplier      *Supplier              `protobuf:"bytes,2,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSupplierRequest) Reset() {
+	*x = UpdateSupplierRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSupplierRequest) ProtoMessage() {}
+
+func (x *UpdateSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSupplierRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+type DeleteSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSupplierRequest) Reset() {
+	*x = DeleteSupplierRequest{}
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSupplierRequest) ProtoMessage() {}
+
+func (x *DeleteSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goodschain_v1_supplier_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSupplierRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_goodschain_v1_supplier_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_goodschain_v1_supplier_proto protoreflect.FileDescriptor
+
+const file_goodschain_v1_supplier_proto_rawDesc = "" +
+	"\n" +
+	"\x1cgoodschain/v1/supplier.proto\x12\rgoodschain.v1\x1a\x1agoodschain/v1/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x02\n" +
+	"\bSupplier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\t \x01(\tR\tupdatedBy\"L\n" +
+	"\x15CreateSupplierRequest\x123\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x17.goodschain.v1.SupplierR\bsupplier\"$\n" +
+	"\x12GetSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x18BatchGetSuppliersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"R\n" +
+	"\x19BatchGetSuppliersResponse\x125\n" +
+	"\tsuppliers\x18\x01 \x03(\v2\x17.goodschain.v1.SupplierR\tsuppliers\"w\n" +
+	"\x14ListSuppliersRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x125\n" +
+	"\acreated\x18\x03 \x01(\v2\x1b.goodschain.v1.CreatedRangeR\acreated\"N\n" +
+	"\x15ListSuppliersResponse\x125\n" +
+	"\tsuppliers\x18\x01 \x03(\v2\x17.goodschain.v1.SupplierR\tsuppliers\"\\\n" +
+	"\x15UpdateSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\bsupplier\x18\x02 \x01(\v2\x17.goodschain.v1.SupplierR\bsupplier\"'\n" +
+	"\x15DeleteSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xba\x06\n" +
+	"\x0fSupplierService\x12p\n" +
+	"\x0eCreateSupplier\x12$.goodschain.v1.CreateSupplierRequest\x1a\x17.goodschain.v1.Supplier\"\x1f\x82\xd3\xe4\x93\x02\x19:\bsupplier\"\r/v1/suppliers\x12e\n" +
+	"\vGetSupplier\x12!.goodschain.v1.GetSupplierRequest\x1a\x17.goodschain.v1.Supplier\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/suppliers/{id}\x12\x86\x01\n" +
+	"\x11BatchGetSuppliers\x12'.goodschain.v1.BatchGetSuppliersRequest\x1a(.goodschain.v1.BatchGetSuppliersResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/suppliers:batchGet\x12q\n" +
+	"\rListSuppliers\x12#.goodschain.v1.ListSuppliersRequest\x1a$.goodschain.v1.ListSuppliersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/suppliers\x12o\n" +
+	"\x0fStreamSuppliers\x12#.goodschain.v1.ListSuppliersRequest\x1a\x17.goodschain.v1.Supplier\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/suppliers:stream0\x01\x12u\n" +
+	"\x0eUpdateSupplier\x12$.goodschain.v1.UpdateSupplierRequest\x1a\x17.goodschain.v1.Supplier\"$\x82\xd3\xe4\x93\x02\x1e:\bsupplier\x1a\x12/v1/suppliers/{id}\x12j\n" +
+	"\x0eDeleteSupplier\x12$.goodschain.v1.DeleteSupplierRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/suppliers/{id}B@Z>github.com/GoodsChain/backend/proto/goodschain/v1;goodschainv1b\x06proto3"
+
+var (
+	file_goodschain_v1_supplier_proto_rawDescOnce sync.Once
+	file_goodschain_v1_supplier_proto_rawDescData []byte
+)
+
+func file_goodschain_v1_supplier_proto_rawDescGZIP() []byte {
+	file_goodschain_v1_supplier_proto_rawDescOnce.Do(func() {
+		file_goodschain_v1_supplier_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goodschain_v1_supplier_proto_rawDesc), len(file_goodschain_v1_supplier_proto_rawDesc)))
+	})
+	return file_goodschain_v1_supplier_proto_rawDescData
+}
+
+var file_goodschain_v1_supplier_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_goodschain_v1_supplier_proto_goTypes = []any{
+	(*Supplier)(nil),                  // 0: goodschain.v1.Supplier
+	(*CreateSupplierRequest)(nil),     // 1: goodschain.v1.CreateSupplierRequest
+	(*GetSupplierRequest)(nil),        // 2: goodschain.v1.GetSupplierRequest
+	(*BatchGetSuppliersRequest)(nil),  // 3: goodschain.v1.BatchGetSuppliersRequest
+	(*BatchGetSuppliersResponse)(nil), // 4: goodschain.v1.BatchGetSuppliersResponse
+	(*ListSuppliersRequest)(nil),      // 5: goodschain.v1.ListSuppliersRequest
+	(*ListSuppliersResponse)(nil),     // 6: goodschain.v1.ListSuppliersResponse
+	(*UpdateSupplierRequest)(nil),     // 7: goodschain.v1.UpdateSupplierRequest
+	(*DeleteSupplierRequest)(nil),     // 8: goodschain.v1.DeleteSupplierRequest
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
+	(*CreatedRange)(nil),              // 10: goodschain.v1.CreatedRange
+	(*emptypb.Empty)(nil),             // 11: google.protobuf.Empty
+}
+var file_goodschain_v1_supplier_proto_depIdxs = []int32{
+	9,  // 0: goodschain.v1.Supplier.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: goodschain.v1.Supplier.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: goodschain.v1.CreateSupplierRequest.supplier:type_name -> goodschain.v1.Supplier
+	0,  // 3: goodschain.v1.BatchGetSuppliersResponse.suppliers:type_name -> goodschain.v1.Supplier
+	10, // 4: goodschain.v1.ListSuppliersRequest.created:type_name -> goodschain.v1.CreatedRange
+	0,  // 5: goodschain.v1.ListSuppliersResponse.suppliers:type_name -> goodschain.v1.Supplier
+	0,  // 6: goodschain.v1.UpdateSupplierRequest.supplier:type_name -> goodschain.v1.Supplier
+	1,  // 7: goodschain.v1.SupplierService.CreateSupplier:input_type -> goodschain.v1.CreateSupplierRequest
+	2,  // 8: goodschain.v1.SupplierService.GetSupplier:input_type -> goodschain.v1.GetSupplierRequest
+	3,  // 9: goodschain.v1.SupplierService.BatchGetSuppliers:input_type -> goodschain.v1.BatchGetSuppliersRequest
+	5,  // 10: goodschain.v1.SupplierService.ListSuppliers:input_type -> goodschain.v1.ListSuppliersRequest
+	5,  // 11: goodschain.v1.SupplierService.StreamSuppliers:input_type -> goodschain.v1.ListSuppliersRequest
+	7,  // 12: goodschain.v1.SupplierService.UpdateSupplier:input_type -> goodschain.v1.UpdateSupplierRequest
+	8,  // 13: goodschain.v1.SupplierService.DeleteSupplier:input_type -> goodschain.v1.DeleteSupplierRequest
+	0,  // 14: goodschain.v1.SupplierService.CreateSupplier:output_type -> goodschain.v1.Supplier
+	0,  // 15: goodschain.v1.SupplierService.GetSupplier:output_type -> goodschain.v1.Supplier
+	4,  // 16: goodschain.v1.SupplierService.BatchGetSuppliers:output_type -> goodschain.v1.BatchGetSuppliersResponse
+	6,  // 17: goodschain.v1.SupplierService.ListSuppliers:output_type -> goodschain.v1.ListSuppliersResponse
+	0,  // 18: goodschain.v1.SupplierService.StreamSuppliers:output_type -> goodschain.v1.Supplier
+	0,  // 19: goodschain.v1.SupplierService.UpdateSupplier:output_type -> goodschain.v1.Supplier
+	11, // 20: goodschain.v1.SupplierService.DeleteSupplier:output_type -> google.protobuf.Empty
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_goodschain_v1_supplier_proto_init() }
+func file_goodschain_v1_supplier_proto_init() {
+	if File_goodschain_v1_supplier_proto != nil {
+		return
+	}
+	file_goodschain_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goodschain_v1_supplier_proto_rawDesc), len(file_goodschain_v1_supplier_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_goodschain_v1_supplier_proto_goTypes,
+		DependencyIndexes: file_goodschain_v1_supplier_proto_depIdxs,
+		MessageInfos:      file_goodschain_v1_supplier_proto_msgTypes,
+	}.Build()
+	File_goodschain_v1_supplier_proto = out.File
+	file_goodschain_v1_supplier_proto_goTypes = nil
+	file_goodschain_v1_supplier_proto_depIdxs = nil
+}
@@ -0,0 +1,376 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: goodschain/v1/supplier.proto
+
+package goodschainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SupplierService_CreateSupplier_FullMethodName    = "/goodschain.v1.SupplierService/CreateSupplier"
+	SupplierService_GetSupplier_FullMethodName       = "/goodschain.v1.SupplierService/GetSupplier"
+	SupplierService_BatchGetSuppliers_FullMethodName = "/goodschain.v1.SupplierService/BatchGetSuppliers"
+	SupplierService_ListSuppliers_FullMethodName     = "/goodschain.v1.SupplierService/ListSuppliers"
+	SupplierService_StreamSuppliers_FullMethodName   = "/goodschain.v1.SupplierService/StreamSuppliers"
+	SupplierService_UpdateSupplier_FullMethodName    = "/goodschain.v1.SupplierService/UpdateSupplier"
+	SupplierService_DeleteSupplier_FullMethodName    = "/goodschain.v1.SupplierService/DeleteSupplier"
+)
+
+// SupplierServiceClient is the client API for SupplierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SupplierService manages suppliers. It mirrors usecase.SupplierUsecase.
+type SupplierServiceClient interface {
+	// CreateSupplier creates a supplier; the ID is generated when empty.
+	CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	// GetSupplier returns one supplier.
+	GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	// BatchGetSuppliers returns the suppliers with the given IDs; unknown IDs
+	// are left out.
+	BatchGetSuppliers(ctx context.Context, in *BatchGetSuppliersRequest, opts ...grpc.CallOption) (*BatchGetSuppliersResponse, error)
+	// ListSuppliers returns the suppliers matching the filter, newest first.
+	ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*ListSuppliersResponse, error)
+	// StreamSuppliers sends the suppliers matching the filter one at a time,
+	// for listings too large for one response.
+	StreamSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Supplier], error)
+	// UpdateSupplier replaces the details of a supplier.
+	UpdateSupplier(ctx context.Context, in *UpdateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error)
+	// DeleteSupplier deletes a supplier.
+	DeleteSupplier(ctx context.Context, in *DeleteSupplierRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type supplierServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupplierServiceClient(cc grpc.ClientConnInterface) SupplierServiceClient {
+	return &supplierServiceClient{cc}
+}
+
+func (c *supplierServiceClient) CreateSupplier(ctx context.Context, in *CreateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, SupplierService_CreateSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) GetSupplier(ctx context.Context, in *GetSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, SupplierService_GetSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) BatchGetSuppliers(ctx context.Context, in *BatchGetSuppliersRequest, opts ...grpc.CallOption) (*BatchGetSuppliersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetSuppliersResponse)
+	err := c.cc.Invoke(ctx, SupplierService_BatchGetSuppliers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) ListSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (*ListSuppliersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuppliersResponse)
+	err := c.cc.Invoke(ctx, SupplierService_ListSuppliers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) StreamSuppliers(ctx context.Context, in *ListSuppliersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Supplier], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SupplierService_ServiceDesc.Streams[0], SupplierService_StreamSuppliers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSuppliersRequest, Supplier]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplierService_StreamSuppliersClient = grpc.ServerStreamingClient[Supplier]
+
+func (c *supplierServiceClient) UpdateSupplier(ctx context.Context, in *UpdateSupplierRequest, opts ...grpc.CallOption) (*Supplier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Supplier)
+	err := c.cc.Invoke(ctx, SupplierService_UpdateSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplierServiceClient) DeleteSupplier(ctx context.Context, in *DeleteSupplierRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SupplierService_DeleteSupplier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SupplierServiceServer is the server API for SupplierService service.
+// All implementations must embed UnimplementedSupplierServiceServer
+// for forward compatibility.
+//
+// SupplierService manages suppliers. It mirrors usecase.SupplierUsecase.
+type SupplierServiceServer interface {
+	// CreateSupplier creates a supplier; the ID is generated when empty.
+	CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error)
+	// GetSupplier returns one supplier.
+	GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error)
+	// BatchGetSuppliers returns the suppliers with the given IDs; unknown IDs
+	// are left out.
+	BatchGetSuppliers(context.Context, *BatchGetSuppliersRequest) (*BatchGetSuppliersResponse, error)
+	// ListSuppliers returns the suppliers matching the filter, newest first.
+	ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error)
+	// StreamSuppliers sends the suppliers matching the filter one at a time,
+	// for listings too large for one response.
+	StreamSuppliers(*ListSuppliersRequest, grpc.ServerStreamingServer[Supplier]) error
+	// UpdateSupplier replaces the details of a supplier.
+	UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error)
+	// DeleteSupplier deletes a supplier.
+	DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSupplierServiceServer()
+}
+
+// UnimplementedSupplierServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSupplierServiceServer struct{}
+
+func (UnimplementedSupplierServiceServer) CreateSupplier(context.Context, *CreateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) GetSupplier(context.Context, *GetSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) BatchGetSuppliers(context.Context, *BatchGetSuppliersRequest) (*BatchGetSuppliersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetSuppliers not implemented")
+}
+func (UnimplementedSupplierServiceServer) ListSuppliers(context.Context, *ListSuppliersRequest) (*ListSuppliersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppliers not implemented")
+}
+func (UnimplementedSupplierServiceServer) StreamSuppliers(*ListSuppliersRequest, grpc.ServerStreamingServer[Supplier]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSuppliers not implemented")
+}
+func (UnimplementedSupplierServiceServer) UpdateSupplier(context.Context, *UpdateSupplierRequest) (*Supplier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) DeleteSupplier(context.Context, *DeleteSupplierRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSupplier not implemented")
+}
+func (UnimplementedSupplierServiceServer) mustEmbedUnimplementedSupplierServiceServer() {}
+func (UnimplementedSupplierServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeSupplierServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupplierServiceServer will
+// result in compilation errors.
+type UnsafeSupplierServiceServer interface {
+	mustEmbedUnimplementedSupplierServiceServer()
+}
+
+func RegisterSupplierServiceServer(s grpc.ServiceRegistrar, srv SupplierServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSupplierServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SupplierService_ServiceDesc, srv)
+}
+
+func _SupplierService_CreateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_CreateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).CreateSupplier(ctx, req.(*CreateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_GetSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_GetSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).GetSupplier(ctx, req.(*GetSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_BatchGetSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).BatchGetSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_BatchGetSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).BatchGetSuppliers(ctx, req.(*BatchGetSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_ListSuppliers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppliersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).ListSuppliers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_ListSuppliers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).ListSuppliers(ctx, req.(*ListSuppliersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_StreamSuppliers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSuppliersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SupplierServiceServer).StreamSuppliers(m, &grpc.GenericServerStream[ListSuppliersRequest, Supplier]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SupplierService_StreamSuppliersServer = grpc.ServerStreamingServer[Supplier]
+
+func _SupplierService_UpdateSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).UpdateSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_UpdateSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).UpdateSupplier(ctx, req.(*UpdateSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplierService_DeleteSupplier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSupplierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplierServiceServer).DeleteSupplier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupplierService_DeleteSupplier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplierServiceServer).DeleteSupplier(ctx, req.(*DeleteSupplierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SupplierService_ServiceDesc is the grpc.ServiceDesc for SupplierService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SupplierService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goodschain.v1.SupplierService",
+	HandlerType: (*SupplierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSupplier",
+			Handler:    _SupplierService_CreateSupplier_Handler,
+		},
+		{
+			MethodName: "GetSupplier",
+			Handler:    _SupplierService_GetSupplier_Handler,
+		},
+		{
+			MethodName: "BatchGetSuppliers",
+			Handler:    _SupplierService_BatchGetSuppliers_Handler,
+		},
+		{
+			MethodName: "ListSuppliers",
+			Handler:    _SupplierService_ListSuppliers_Handler,
+		},
+		{
+			MethodName: "UpdateSupplier",
+			Handler:    _SupplierService_UpdateSupplier_Handler,
+		},
+		{
+			MethodName: "DeleteSupplier",
+			Handler:    _SupplierService_DeleteSupplier_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSuppliers",
+			Handler:       _SupplierService_StreamSuppliers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "goodschain/v1/supplier.proto",
+}
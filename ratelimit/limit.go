@@ -0,0 +1,119 @@
+// Package ratelimit limits the request rate of API clients with token
+// buckets. A bucket holds up to Requests tokens, refilled at Requests per
+// Period; each request takes one token and is rejected when none is left, so
+// a client can burst up to the full limit and is then held to the average rate.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is the rate of a token bucket: Requests per Period, in bursts of at
+// most Requests. The zero Limit does not limit.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// IsZero reports whether l does not limit
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// String formats l as ParseLimit accepts it, such as "60/1m0s"
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// perSecond is the refill rate of the bucket of l
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// periodUnits are the shorthands of a period of one unit
+var periodUnits = map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour}
+
+// ParseLimit parses a limit written as "<requests>/<period>", where period is
+// a duration such as "30s" or "1m", or a unit among s, m and h: "60/m" allows
+// 60 requests per minute. "0" and the empty string parse as the zero Limit.
+func ParseLimit(raw string) (Limit, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || raw == "0" {
+		return Limit{}, nil
+	}
+	requests, period, ok := strings.Cut(raw, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit '%s', expected <requests>/<period> such as 60/m", raw)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit '%s': requests must be a non-negative integer", raw)
+	}
+	period = strings.TrimSpace(period)
+	d, ok := periodUnits[period]
+	if !ok {
+		if d, err = time.ParseDuration(period); err != nil || d <= 0 {
+			return Limit{}, fmt.Errorf("invalid rate limit '%s': period must be s, m, h or a positive duration", raw)
+		}
+	}
+	return Limit{Requests: n, Period: d}, nil
+}
+
+// ParseLimits parses a comma-separated list of name=limit pairs, such as
+// "cars=60/m,search=30/m"
+func ParseLimits(raw string) (map[string]Limit, error) {
+	limits := map[string]Limit{}
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid rate limit '%s', expected <name>=<requests>/<period>", pair)
+		}
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+	return limits, nil
+}
+
+// Result is the outcome of a request against a bucket
+type Result struct {
+	Limit      Limit
+	Allowed    bool
+	Remaining  int           // Whole tokens left after the request
+	Reset      time.Duration // Time until the bucket is full again
+	RetryAfter time.Duration // Time until the next token, when the request was rejected
+}
+
+// take takes a token from a bucket of limit holding tokens at updatedAt and
+// returns the tokens left at now
+func take(limit Limit, tokens float64, updatedAt, now time.Time) (float64, Result) {
+	capacity, rate := float64(limit.Requests), limit.perSecond()
+	if elapsed := now.Sub(updatedAt).Seconds(); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed*rate)
+	}
+
+	result := Result{Limit: limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = seconds((capacity - tokens) / rate)
+	return tokens, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Limit
+		wantErr string
+	}{
+		{raw: "60/m", want: Limit{Requests: 60, Period: time.Minute}},
+		{raw: " 10 / 30s ", want: Limit{Requests: 10, Period: 30 * time.Second}},
+		{raw: "1000/h", want: Limit{Requests: 1000, Period: time.Hour}},
+		{raw: "", want: Limit{}},
+		{raw: "0", want: Limit{}},
+		{raw: "60", wantErr: "invalid rate limit '60', expected <requests>/<period> such as 60/m"},
+		{raw: "many/m", wantErr: "invalid rate limit 'many/m': requests must be a non-negative integer"},
+		{raw: "60/week", wantErr: "invalid rate limit '60/week': period must be s, m, h or a positive duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseLimit(tt.raw)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("cars=60/m, search=30/m,,ip:192.0.2.1=0")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Limit{
+		"cars":         {Requests: 60, Period: time.Minute},
+		"search":       {Requests: 30, Period: time.Minute},
+		"ip:192.0.2.1": {},
+	}, limits)
+
+	_, err = ParseLimits("cars")
+	assert.EqualError(t, err, "invalid rate limit 'cars', expected <name>=<requests>/<period>")
+	_, err = ParseLimits("cars=fast")
+	assert.Error(t, err)
+}
+
+func TestTake(t *testing.T) {
+	limit := Limit{Requests: 2, Period: 2 * time.Second} // one token per second
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tokens, result := take(limit, 2, start, start)
+	assert.Equal(t, Result{Limit: limit, Allowed: true, Remaining: 1, Reset: time.Second}, result)
+
+	tokens, result = take(limit, tokens, start, start)
+	assert.Equal(t, Result{Limit: limit, Allowed: true, Remaining: 0, Reset: 2 * time.Second}, result)
+
+	// Empty: rejected until the next token
+	tokens, result = take(limit, tokens, start, start.Add(250*time.Millisecond))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 750*time.Millisecond, result.RetryAfter)
+	assert.Equal(t, 1750*time.Millisecond, result.Reset)
+	assert.InDelta(t, 0.25, tokens, 1e-9, "a rejected request does not take a token")
+
+	// Refills up to the capacity only
+	_, result = take(limit, tokens, start, start.Add(time.Hour))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+// Store keeps the token buckets of a Limiter
+type Store interface {
+	// Take takes a token from the bucket with key, created full if it does not exist
+	Take(key string, limit Limit) (Result, error)
+}
+
+// Policy selects the limit of a request from its client and its route group
+type Policy struct {
+	Default Limit            // Limit of every client in every group without a more specific limit
+	Groups  map[string]Limit // Limits by route group, such as "cars"
+	Clients map[string]Limit // Limits by client ID, overriding the group limits
+}
+
+// Limit returns the limit of requests of client to group
+func (p Policy) Limit(client, group string) Limit {
+	if limit, ok := p.Clients[client]; ok {
+		return limit
+	}
+	if limit, ok := p.Groups[group]; ok {
+		return limit
+	}
+	return p.Default
+}
+
+// Limiter counts the requests of each client to each route group in its own
+// bucket, so a client exhausting the limit of one group can still use the others
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+// NewLimiter creates a Limiter keeping its buckets in store
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+// Allow takes a token for a request of client to group. Requests without a
+// limit are allowed with a zero Result.Limit.
+func (l *Limiter) Allow(client, group string) (Result, error) {
+	limit := l.policy.Limit(client, group)
+	if limit.IsZero() {
+		return Result{Allowed: true}, nil
+	}
+	return l.store.Take(group+"|"+client, limit)
+}
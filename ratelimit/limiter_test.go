@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPolicy_Limit(t *testing.T) {
+	policy := Policy{
+		Default: Limit{Requests: 100, Period: time.Minute},
+		Groups:  map[string]Limit{"cars": {Requests: 10, Period: time.Minute}},
+		Clients: map[string]Limit{"key:partner": {Requests: 1000, Period: time.Minute}, "key:internal": {}},
+	}
+	assert.Equal(t, Limit{Requests: 100, Period: time.Minute}, policy.Limit("ip:192.0.2.1", "customers"))
+	assert.Equal(t, Limit{Requests: 10, Period: time.Minute}, policy.Limit("ip:192.0.2.1", "cars"))
+	assert.Equal(t, Limit{Requests: 1000, Period: time.Minute}, policy.Limit("key:partner", "cars"))
+	assert.True(t, policy.Limit("key:internal", "cars").IsZero(), "a zero client limit lifts the limits")
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	limiter := NewLimiter(store, Policy{
+		Default: Limit{Requests: 2, Period: time.Minute},
+		Clients: map[string]Limit{"key:internal": {}},
+	})
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow("ip:192.0.2.1", "cars")
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+	result, err := limiter.Allow("ip:192.0.2.1", "cars")
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+	// Other groups and clients have their own buckets
+	result, _ = limiter.Allow("ip:192.0.2.1", "customers")
+	assert.True(t, result.Allowed)
+	result, _ = limiter.Allow("ip:192.0.2.2", "cars")
+	assert.True(t, result.Allowed)
+
+	// Unlimited clients are not counted
+	for i := 0; i < 5; i++ {
+		result, _ = limiter.Allow("key:internal", "cars")
+		assert.Equal(t, Result{Allowed: true}, result)
+	}
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 5, Period: time.Minute}
+
+	_, _ = store.Take("stale", limit)
+	now = now.Add(time.Minute)
+	for i := 1; i < sweepEvery; i++ {
+		_, _ = store.Take("busy", Limit{Requests: 1, Period: time.Hour})
+	}
+	assert.NotContains(t, store.buckets, "stale", "a refilled bucket is removed")
+	assert.Contains(t, store.buckets, "busy")
+}
+
+func TestPostgresStore_Take(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	repo := mock.NewMockRateLimitRepository(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	store := NewPostgresStore(transactor, repo, PostgresOptions{})
+	limit := Limit{Requests: 60, Period: time.Minute}
+
+	t.Run("Allowed", func(t *testing.T) {
+		updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		now := updatedAt.Add(1500 * time.Millisecond)
+		repo.EXPECT().LockBucket("cars|ip:192.0.2.1", 60.0).
+			Return(&model.RateLimitBucket{Key: "cars|ip:192.0.2.1", Tokens: 0.5, UpdatedAt: updatedAt}, now, nil)
+		repo.EXPECT().SaveBucket(&model.RateLimitBucket{Key: "cars|ip:192.0.2.1", Tokens: 1, UpdatedAt: now}).Return(nil)
+
+		result, err := store.Take("cars|ip:192.0.2.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.EXPECT().LockBucket("cars|ip:192.0.2.1", 60.0).
+			Return(&model.RateLimitBucket{Key: "cars|ip:192.0.2.1", Tokens: 0.25, UpdatedAt: now}, now, nil)
+		repo.EXPECT().SaveBucket(gomock.Any()).Return(nil)
+
+		result, err := store.Take("cars|ip:192.0.2.1", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 750*time.Millisecond, result.RetryAfter)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		repo.EXPECT().LockBucket(gomock.Any(), gomock.Any()).Return(nil, time.Time{}, errors.New("database error"))
+
+		_, err := store.Take("cars|ip:192.0.2.1", limit)
+		assert.EqualError(t, err, "database error")
+	})
+}
@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// sweepEvery is the number of requests between removals of the full buckets
+// of a MemoryStore
+const sweepEvery = 1024
+
+type memoryBucket struct {
+	limit     Limit
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore keeps buckets in the memory of the process. Each instance of
+// the API then limits clients on its own; use a PostgresStore to share the
+// limits of several instances.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	buckets  map[string]*memoryBucket
+	requests int
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: map[string]*memoryBucket{}}
+}
+
+// Take takes a token from the bucket with key
+func (s *MemoryStore) Take(key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.requests++
+	if s.requests%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(limit.Requests), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+	var result Result
+	bucket.tokens, result = take(limit, bucket.tokens, bucket.updatedAt, now)
+	bucket.limit, bucket.updatedAt = limit, now
+	return result, nil
+}
+
+// sweep removes the buckets that have refilled, which are the same as new ones
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.updatedAt) >= bucket.limit.Period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// PostgresOptions configures a PostgresStore
+type PostgresOptions struct {
+	PruneInterval time.Duration // Time between deletions of idle buckets, default 10m
+	IdleTTL       time.Duration // Time without requests after which a bucket is deleted, default 24h
+}
+
+func (o PostgresOptions) withDefaults() PostgresOptions {
+	if o.PruneInterval <= 0 {
+		o.PruneInterval = 10 * time.Minute
+	}
+	if o.IdleTTL <= 0 {
+		o.IdleTTL = 24 * time.Hour
+	}
+	return o
+}
+
+// PostgresStore keeps buckets in the database, so every instance of the API
+// enforces the same limits. Each request locks its bucket for one short
+// transaction and is timed with the clock of the database.
+type PostgresStore struct {
+	transactor repository.Transactor
+	repo       repository.RateLimitRepository
+	opts       PostgresOptions
+}
+
+// NewPostgresStore creates a new PostgresStore; zero options take their defaults.
+// Deleting a bucket refills it, so IdleTTL should exceed the longest period of the policy.
+func NewPostgresStore(transactor repository.Transactor, repo repository.RateLimitRepository, opts PostgresOptions) *PostgresStore {
+	return &PostgresStore{transactor: transactor, repo: repo, opts: opts.withDefaults()}
+}
+
+// Take takes a token from the bucket with key
+func (s *PostgresStore) Take(key string, limit Limit) (Result, error) {
+	var result Result
+	err := s.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := s.repo.WithTx(tx)
+		bucket, now, err := repo.LockBucket(key, float64(limit.Requests))
+		if err != nil {
+			return err
+		}
+		bucket.Tokens, result = take(limit, bucket.Tokens, bucket.UpdatedAt, now)
+		bucket.UpdatedAt = now
+		return repo.SaveBucket(bucket)
+	})
+	return result, err
+}
+
+// Run deletes idle buckets every PruneInterval until ctx is cancelled
+func (s *PostgresStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.PruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		deleted, err := s.repo.DeleteIdleBuckets(s.opts.IdleTTL)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to delete idle rate limit buckets")
+			continue
+		}
+		if deleted > 0 {
+			log.Debug().Int64("deleted", deleted).Msg("Deleted idle rate limit buckets")
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package redact masks personal data, such as emails and phone numbers, in
+// log entries and API responses.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Mask replaces a sensitive value with a masked one
+type Mask func(value string) string
+
+// Rule masks sensitive values. A rule with a Field masks the whole string
+// value of every field whose name contains Field, case-insensitively; a rule
+// with a Pattern masks every match of Pattern in any string.
+type Rule struct {
+	Field   string
+	Pattern *regexp.Regexp
+	Mask    Mask
+}
+
+// Patterns of the personal data found in free text, such as the messages of
+// database errors. Phones are either grouped by separators or a run of 9 to 11
+// digits, such as 0912345678; longer runs, such as timestamps, are left alone.
+var (
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	PhonePattern = regexp.MustCompile(`(\+\d{1,3}[\s.\-]?)?(\(\d{3}\)|\b\d{3})[\s.\-]\d{3}[\s.\-]\d{4}\b|\+?\b\d{9,11}\b`)
+)
+
+// DefaultRules mask emails and phones wherever they appear, and the whole
+// value of address and credential fields
+var DefaultRules = []Rule{
+	{Field: "email", Mask: Email},
+	{Field: "phone", Mask: Phone},
+	{Field: "address", Mask: Redacted},
+	{Field: "password", Mask: Redacted},
+	{Field: "secret", Mask: Redacted},
+	{Field: "token", Mask: Redacted},
+	{Field: "authorization", Mask: Redacted},
+	{Pattern: EmailPattern, Mask: Email},
+	{Pattern: PhonePattern, Mask: Phone},
+}
+
+// Default is the Redactor of the DefaultRules
+var Default = New(DefaultRules...)
+
+// Redactor applies a set of rules
+type Redactor struct {
+	fields   []Rule
+	patterns []Rule
+}
+
+// New creates a Redactor applying rules
+func New(rules ...Rule) *Redactor {
+	r := &Redactor{}
+	for _, rule := range rules {
+		if rule.Pattern != nil {
+			r.patterns = append(r.patterns, rule)
+		} else if rule.Field != "" {
+			rule.Field = strings.ToLower(rule.Field)
+			r.fields = append(r.fields, rule)
+		}
+	}
+	return r
+}
+
+// String returns s with every match of the pattern rules masked
+func (r *Redactor) String(s string) string {
+	for _, rule := range r.patterns {
+		s = rule.Pattern.ReplaceAllStringFunc(s, rule.Mask)
+	}
+	return s
+}
+
+// Field returns value, the value of the field named key, masked by the first
+// field rule matching key, or by the pattern rules otherwise. Objects and
+// arrays, as decoded by encoding/json, are masked recursively.
+func (r *Redactor) Field(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		lower := strings.ToLower(key)
+		for _, rule := range r.fields {
+			if strings.Contains(lower, rule.Field) {
+				return rule.Mask(v)
+			}
+		}
+		return r.String(v)
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = r.Field(k, item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.Field(key, item)
+		}
+	}
+	return value
+}
+
+// Writer returns a writer masking the JSON log entries written to it, such
+// as those of zerolog, before passing them on to out. Entries that are not
+// JSON objects are masked as text.
+func (r *Redactor) Writer(out io.Writer) io.Writer {
+	return &writer{redactor: r, out: out}
+}
+
+type writer struct {
+	redactor *Redactor
+	out      io.Writer
+}
+
+// Write masks p, one log entry, and writes it to the underlying writer
+func (w *writer) Write(p []byte) (int, error) {
+	var entry map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if err := dec.Decode(&entry); err != nil || entry == nil {
+		if _, err := io.WriteString(w.out, w.redactor.String(string(p))); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(w.redactor.Field("", entry)); err != nil {
+		return 0, err
+	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Email masks the local part of an email but its first character, as in
+// "j***@example.com"
+func Email(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return Redacted(email)
+	}
+	local := []rune(email[:at])
+	if len(local) == 0 {
+		return "***" + email[at:]
+	}
+	return string(local[0]) + "***" + email[at:]
+}
+
+// Phone masks the digits of a phone but its first three and last four,
+// whatever their grouping, as in "555-***-4567" and "+84 9****5678". Short
+// phones keep fewer, so that at least three digits are always masked.
+func Phone(phone string) string {
+	digits := 0
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return Redacted(phone)
+	}
+
+	tail := max(0, min(4, digits-3))
+	head := max(0, min(3, digits-tail-3))
+	masked := []rune(phone)
+	digit := 0
+	for i, r := range masked {
+		if !unicode.IsDigit(r) {
+			continue
+		}
+		digit++
+		if digit > head && digit <= digits-tail {
+			masked[i] = '*'
+		}
+	}
+	return string(masked)
+}
+
+// Redacted replaces a non-empty value entirely
+func Redacted(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
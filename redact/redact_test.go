@@ -0,0 +1,89 @@
+package redact
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail(t *testing.T) {
+	assert.Equal(t, "j***@example.com", Email("john.doe@example.com"))
+	assert.Equal(t, "j***@example.com", Email("j***@example.com"))
+	assert.Equal(t, "***@example.com", Email("@example.com"))
+	assert.Equal(t, "[REDACTED]", Email("not an email"))
+	assert.Equal(t, "", Email(""))
+}
+
+func TestPhone(t *testing.T) {
+	assert.Equal(t, "555-***-4567", Phone("555-123-4567"))
+	assert.Equal(t, "+1 (55*) ***-4567", Phone("+1 (555) 123-4567"))
+	assert.Equal(t, "555***4567", Phone("5551234567"))
+	// Two groups mask across the separator
+	assert.Equal(t, "***-4567", Phone("555-4567"))
+	assert.Equal(t, "024 ***3456", Phone("024 3123456"))
+	assert.Equal(t, "+84 9****5678", Phone("+84 912345678"))
+	assert.Equal(t, "***7", Phone("4567"))
+	assert.Equal(t, "[REDACTED]", Phone("n/a"))
+	assert.Equal(t, "", Phone(""))
+}
+
+func TestRedactor_String(t *testing.T) {
+	msg := `pq: duplicate key value violates unique constraint "customer_email_key": Key (email)=(john.doe@example.com) already exists`
+	assert.Equal(t, `pq: duplicate key value violates unique constraint "customer_email_key": Key (email)=(j***@example.com) already exists`,
+		Default.String(msg))
+	assert.Equal(t, "call 555-***-4567 or (555) ***-4567", Default.String("call 555-123-4567 or (555) 123-4567"))
+	assert.Equal(t, "Key (phone)=(091***5678) already exists", Default.String("Key (phone)=(0912345678) already exists"))
+	assert.Equal(t, "call +849****5678", Default.String("call +84912345678"))
+
+	// IDs, timestamps and UUIDs are not phones
+	for _, s := range []string{"1715600000000000000", "2024-05-01T09:00:00Z", "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"} {
+		assert.Equal(t, s, Default.String(s))
+	}
+}
+
+func TestRedactor_Field(t *testing.T) {
+	record := map[string]interface{}{
+		"id":       "cust1",
+		"email":    "john.doe@example.com",
+		"phone":    "555-123-4567",
+		"address":  "1 Main St",
+		"password": "hunter2",
+		"note":     "reach me at jane@example.org",
+		"contacts": []interface{}{map[string]interface{}{"Email": "a@b.io"}},
+		"count":    3,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"id":       "cust1",
+		"email":    "j***@example.com",
+		"phone":    "555-***-4567",
+		"address":  "[REDACTED]",
+		"password": "[REDACTED]",
+		"note":     "reach me at j***@example.org",
+		"contacts": []interface{}{map[string]interface{}{"Email": "a***@b.io"}},
+		"count":    3,
+	}, Default.Field("", record))
+
+	custom := New(Rule{Field: "vin", Mask: Redacted}, Rule{Pattern: regexp.MustCompile(`secret-\d+`), Mask: Redacted})
+	assert.Equal(t, "[REDACTED]", custom.Field("car_vin", "1HGCM82633A004352"))
+	assert.Equal(t, "code [REDACTED]", custom.Field("note", "code secret-42"))
+}
+
+func TestRedactor_Writer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(Default.Writer(&buf))
+
+	logger.Error().Str("request_id", "1715600000000000000").Str("customer_email", "john.doe@example.com").Int("status", 500).
+		Msg(`Key (email)=(john.doe@example.com) already exists`)
+	assert.JSONEq(t, `{"level":"error","request_id":"1715600000000000000","customer_email":"j***@example.com","status":500,
+		"message":"Key (email)=(j***@example.com) already exists"}`, buf.String())
+	assert.NotContains(t, buf.String(), "john.doe")
+
+	buf.Reset()
+	n, err := Default.Writer(&buf).Write([]byte("plain text 555-123-4567\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 24, n)
+	assert.Equal(t, "plain text 555-***-4567\n", buf.String())
+}
@@ -0,0 +1,63 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaWriter is the part of kafka.Writer used by kafkaPublisher
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type kafkaPublisher struct {
+	writer kafkaWriter
+}
+
+// NewKafkaPublisher publishes events to topic, keyed by aggregate ID so all
+// events of an aggregate land in the same partition, in order. A batch is
+// reported as published once every broker replica has acknowledged it.
+func NewKafkaPublisher(brokers []string, topic string) Publisher {
+	return newKafkaPublisher(&kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 10 * time.Millisecond,
+	})
+}
+
+func newKafkaPublisher(writer kafkaWriter) *kafkaPublisher {
+	return &kafkaPublisher{writer: writer}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, events []model.Event) error {
+	msgs := make([]kafka.Message, len(events))
+	for i := range events {
+		event := &events[i]
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		msgs[i] = kafka.Message{
+			Key:   []byte(event.AggregateID),
+			Value: data,
+			Headers: []kafka.Header{
+				{Key: HeaderEventType, Value: []byte(event.Type)},
+				{Key: HeaderAggregateType, Value: []byte(event.AggregateType)},
+				{Key: HeaderAggregateID, Value: []byte(event.AggregateID)},
+			},
+			Time: event.CreatedAt,
+		}
+	}
+	return p.writer.WriteMessages(ctx, msgs...)
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
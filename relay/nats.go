@@ -0,0 +1,76 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Message headers set by the NATS and Kafka publishers
+const (
+	HeaderEventType     = "GoodsChain-Event-Type"
+	HeaderAggregateType = "GoodsChain-Aggregate-Type"
+	HeaderAggregateID   = "GoodsChain-Aggregate-ID"
+)
+
+// jetStreamPublisher is the part of jetstream.JetStream used by natsPublisher
+type jetStreamPublisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+type natsPublisher struct {
+	js            jetStreamPublisher
+	subjectPrefix string
+	close         func()
+}
+
+// NewNATSPublisher publishes events to NATS JetStream on the subject
+// "<subjectPrefix>.<event type>", e.g. goodschain.events.car.updated. A
+// stream must capture these subjects. Every event is acknowledged by the
+// stream before the next one is sent, and its ID is set as the message ID so
+// JetStream drops duplicates within the stream's duplicate window.
+func NewNATSPublisher(url, subjectPrefix string) (Publisher, error) {
+	nc, err := nats.Connect(url, nats.Name("goodschain-relay"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return newNATSPublisher(js, subjectPrefix, nc.Close), nil
+}
+
+func newNATSPublisher(js jetStreamPublisher, subjectPrefix string, close func()) *natsPublisher {
+	return &natsPublisher{js: js, subjectPrefix: subjectPrefix, close: close}
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, events []model.Event) error {
+	for i := range events {
+		event := &events[i]
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		msg := nats.NewMsg(p.subjectPrefix + "." + event.Type)
+		msg.Data = data
+		msg.Header.Set(HeaderEventType, event.Type)
+		msg.Header.Set(HeaderAggregateType, event.AggregateType)
+		msg.Header.Set(HeaderAggregateID, event.AggregateID)
+		msg.Header.Set(jetstream.MsgIDHeader, event.ID)
+		if _, err := p.js.PublishMsg(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	p.close()
+	return nil
+}
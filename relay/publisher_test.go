@@ -0,0 +1,163 @@
+package relay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publisherEvents() []model.Event {
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return []model.Event{
+		{Seq: 1, ID: "e1", Type: model.EventCarCreated, AggregateType: "car", AggregateID: "car1",
+			Data: json.RawMessage(`{"id":"car1"}`), CreatedAt: created},
+		{Seq: 2, ID: "e2", Type: model.EventCustomerUpdated, AggregateType: "customer", AggregateID: "cust1",
+			Data: json.RawMessage(`{"id":"cust1"}`), CreatedAt: created},
+	}
+}
+
+func decodeLines(t *testing.T, data []byte) []model.Event {
+	var events []model.Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event model.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := NewWriterPublisher(&buf)
+	require.NoError(t, publisher.Publish(context.Background(), publisherEvents()))
+
+	events := decodeLines(t, buf.Bytes())
+	require.Len(t, events, 2)
+	assert.Equal(t, "e1", events[0].ID)
+	assert.Equal(t, model.EventCustomerUpdated, events[1].Type)
+	assert.NoError(t, publisher.Close())
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	for range 2 {
+		// Reopening appends to the file
+		publisher, err := NewFilePublisher(path)
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(context.Background(), publisherEvents()[:1]))
+		require.NoError(t, publisher.Close())
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, decodeLines(t, data), 2)
+}
+
+// fakeJetStream records published messages and fails from failAt on
+type fakeJetStream struct {
+	msgs   []*nats.Msg
+	failAt int
+}
+
+func (f *fakeJetStream) PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	if f.failAt > 0 && len(f.msgs)+1 >= f.failAt {
+		return nil, errors.New("no responders")
+	}
+	f.msgs = append(f.msgs, msg)
+	return &jetstream.PubAck{Sequence: uint64(len(f.msgs))}, nil
+}
+
+func TestNATSPublisher(t *testing.T) {
+	t.Run("PublishesInOrder", func(t *testing.T) {
+		js := &fakeJetStream{}
+		closed := false
+		publisher := newNATSPublisher(js, "goodschain.events", func() { closed = true })
+		require.NoError(t, publisher.Publish(context.Background(), publisherEvents()))
+
+		require.Len(t, js.msgs, 2)
+		assert.Equal(t, "goodschain.events.car.created", js.msgs[0].Subject)
+		assert.Equal(t, "goodschain.events.customer.updated", js.msgs[1].Subject)
+		assert.Equal(t, "e1", js.msgs[0].Header.Get(jetstream.MsgIDHeader))
+		assert.Equal(t, "e2", js.msgs[1].Header.Get(jetstream.MsgIDHeader))
+		assert.Equal(t, "car", js.msgs[0].Header.Get(HeaderAggregateType))
+		assert.Equal(t, "car1", js.msgs[0].Header.Get(HeaderAggregateID))
+		assert.Equal(t, model.EventCarCreated, js.msgs[0].Header.Get(HeaderEventType))
+
+		var event model.Event
+		require.NoError(t, json.Unmarshal(js.msgs[1].Data, &event))
+		assert.Equal(t, "e2", event.ID)
+
+		require.NoError(t, publisher.Close())
+		assert.True(t, closed)
+	})
+
+	t.Run("StopsAtFirstError", func(t *testing.T) {
+		js := &fakeJetStream{failAt: 1}
+		publisher := newNATSPublisher(js, "goodschain.events", func() {})
+		assert.Error(t, publisher.Publish(context.Background(), publisherEvents()))
+		assert.Empty(t, js.msgs)
+	})
+}
+
+// fakeKafkaWriter records written messages
+type fakeKafkaWriter struct {
+	msgs   []kafka.Message
+	err    error
+	closed bool
+}
+
+func (f *fakeKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.msgs = append(f.msgs, msgs...)
+	return nil
+}
+
+func (f *fakeKafkaWriter) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	t.Run("KeysByAggregateID", func(t *testing.T) {
+		writer := &fakeKafkaWriter{}
+		publisher := newKafkaPublisher(writer)
+		events := publisherEvents()
+		require.NoError(t, publisher.Publish(context.Background(), events))
+
+		require.Len(t, writer.msgs, 2)
+		assert.Equal(t, "car1", string(writer.msgs[0].Key))
+		assert.Equal(t, "cust1", string(writer.msgs[1].Key))
+		assert.Equal(t, events[0].CreatedAt, writer.msgs[0].Time)
+		assert.Contains(t, writer.msgs[0].Headers, kafka.Header{Key: HeaderEventType, Value: []byte(model.EventCarCreated)})
+		assert.Contains(t, writer.msgs[1].Headers, kafka.Header{Key: HeaderAggregateType, Value: []byte("customer")})
+
+		var event model.Event
+		require.NoError(t, json.Unmarshal(writer.msgs[0].Value, &event))
+		assert.Equal(t, "e1", event.ID)
+
+		require.NoError(t, publisher.Close())
+		assert.True(t, writer.closed)
+	})
+
+	t.Run("WriteFailed", func(t *testing.T) {
+		writer := &fakeKafkaWriter{err: errors.New("leader not available")}
+		publisher := newKafkaPublisher(writer)
+		assert.EqualError(t, publisher.Publish(context.Background(), publisherEvents()), "leader not available")
+	})
+}
@@ -0,0 +1,117 @@
+// Package relay publishes the events recorded in the transactional outbox to
+// external systems. Delivery is at least once: an event is marked published
+// only after the publisher accepted it, so a crash in between publishes it
+// again. Events are published in commit order by a single relay at a time,
+// which keeps the events of each aggregate in order.
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// Publisher ships events to another system. Publish returns nil only when
+// every event has been accepted, in the order given. After an error the whole
+// batch is published again, so consumers must tolerate duplicates; the event
+// ID identifies them.
+type Publisher interface {
+	Publish(ctx context.Context, events []model.Event) error
+	Close() error
+}
+
+// Options tunes a Relay; zero values select the defaults
+type Options struct {
+	Interval  time.Duration // Time between polls when the outbox is drained, default 1s
+	BatchSize int           // Events published per transaction, default 100
+}
+
+func (o Options) withDefaults() Options {
+	if o.Interval <= 0 {
+		o.Interval = time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	return o
+}
+
+// Relay moves events from the outbox to a Publisher
+type Relay struct {
+	transactor repository.Transactor
+	outboxRepo repository.OutboxRepository
+	publisher  Publisher
+	opts       Options
+}
+
+// NewRelay creates a relay publishing the events of outboxRepo to publisher
+func NewRelay(transactor repository.Transactor, outboxRepo repository.OutboxRepository,
+	publisher Publisher, opts Options) *Relay {
+	return &Relay{
+		transactor: transactor,
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		opts:       opts.withDefaults(),
+	}
+}
+
+// Run publishes new events every Interval until ctx is cancelled. A full
+// batch is followed immediately by the next one.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to publish outbox events")
+		}
+		if err == nil && published == r.opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce publishes the next batch of unpublished events and returns how many
+// were published. Nothing is published while another relay holds the lock.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	published := 0
+	err := r.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		outboxRepo := r.outboxRepo.WithTx(tx)
+		locked, err := outboxRepo.TryLockPublisher()
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := outboxRepo.GetUnpublished(r.opts.BatchSize)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		if err := r.publisher.Publish(ctx, events); err != nil {
+			return err
+		}
+
+		ids := make([]string, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		if err := outboxRepo.MarkPublished(ids); err != nil {
+			return err
+		}
+		published = len(events)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return published, nil
+}
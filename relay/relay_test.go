@@ -0,0 +1,159 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakePublisher records published batches and fails while err is set
+type fakePublisher struct {
+	mu      sync.Mutex
+	batches [][]model.Event
+	err     error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, events []model.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.batches = append(p.batches, events)
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	return nil
+}
+
+func (p *fakePublisher) published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ids []string
+	for _, batch := range p.batches {
+		for _, event := range batch {
+			ids = append(ids, event.ID)
+		}
+	}
+	return ids
+}
+
+func newTestRelay(t *testing.T, publisher Publisher, opts Options) (*Relay, *mock.MockOutboxRepository) {
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	outboxRepo := mock.NewMockOutboxRepository(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	outboxRepo.EXPECT().WithTx(gomock.Any()).Return(outboxRepo).AnyTimes()
+	return NewRelay(transactor, outboxRepo, publisher, opts), outboxRepo
+}
+
+func testEvents(ids ...string) []model.Event {
+	events := make([]model.Event, len(ids))
+	for i, id := range ids {
+		events[i] = model.Event{ID: id, Type: model.EventCarUpdated, AggregateType: "car", AggregateID: "car1"}
+	}
+	return events
+}
+
+func TestRelay_RunOnce(t *testing.T) {
+	t.Run("PublishesAndMarks", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		relay, outboxRepo := newTestRelay(t, publisher, Options{BatchSize: 10})
+		gomock.InOrder(
+			outboxRepo.EXPECT().TryLockPublisher().Return(true, nil),
+			outboxRepo.EXPECT().GetUnpublished(10).Return(testEvents("e1", "e2"), nil),
+			outboxRepo.EXPECT().MarkPublished([]string{"e1", "e2"}).Return(nil),
+		)
+
+		published, err := relay.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []string{"e1", "e2"}, publisher.published())
+	})
+
+	t.Run("NothingToPublish", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		relay, outboxRepo := newTestRelay(t, publisher, Options{})
+		outboxRepo.EXPECT().TryLockPublisher().Return(true, nil)
+		outboxRepo.EXPECT().GetUnpublished(100).Return([]model.Event{}, nil)
+
+		published, err := relay.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, published)
+		assert.Empty(t, publisher.batches)
+	})
+
+	t.Run("LockHeldElsewhere", func(t *testing.T) {
+		publisher := &fakePublisher{}
+		relay, outboxRepo := newTestRelay(t, publisher, Options{})
+		outboxRepo.EXPECT().TryLockPublisher().Return(false, nil)
+
+		published, err := relay.RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, published)
+	})
+
+	t.Run("PublishFailedLeavesEventsUnpublished", func(t *testing.T) {
+		publisher := &fakePublisher{err: errors.New("broker down")}
+		relay, outboxRepo := newTestRelay(t, publisher, Options{})
+		outboxRepo.EXPECT().TryLockPublisher().Return(true, nil)
+		outboxRepo.EXPECT().GetUnpublished(100).Return(testEvents("e1"), nil)
+		// MarkPublished is not expected
+
+		_, err := relay.RunOnce(context.Background())
+		assert.EqualError(t, err, "broker down")
+	})
+}
+
+func TestRelay_Run(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay, outboxRepo := newTestRelay(t, publisher, Options{Interval: 10 * time.Millisecond, BatchSize: 2})
+	outboxRepo.EXPECT().TryLockPublisher().Return(true, nil).AnyTimes()
+	outboxRepo.EXPECT().MarkPublished(gomock.Any()).Return(nil).AnyTimes()
+
+	// A full batch is followed by the next one without waiting; a failed batch
+	// is published again on the next poll
+	failed := make(chan struct{})
+	gomock.InOrder(
+		outboxRepo.EXPECT().GetUnpublished(2).Return(testEvents("e1", "e2"), nil),
+		outboxRepo.EXPECT().GetUnpublished(2).DoAndReturn(func(int) ([]model.Event, error) {
+			publisher.mu.Lock()
+			publisher.err = errors.New("broker down")
+			publisher.mu.Unlock()
+			return testEvents("e3"), nil
+		}),
+		outboxRepo.EXPECT().GetUnpublished(2).DoAndReturn(func(int) ([]model.Event, error) {
+			publisher.mu.Lock()
+			publisher.err = nil
+			publisher.mu.Unlock()
+			close(failed)
+			return testEvents("e3"), nil
+		}),
+		outboxRepo.EXPECT().GetUnpublished(2).Return([]model.Event{}, nil).AnyTimes(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	<-failed
+	require.Eventually(t, func() bool { return len(publisher.published()) == 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, []string{"e1", "e2", "e3"}, publisher.published())
+}
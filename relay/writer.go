@@ -0,0 +1,56 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/GoodsChain/backend/model"
+)
+
+// writerPublisher writes events as newline-delimited JSON
+type writerPublisher struct {
+	mu    sync.Mutex
+	w     io.Writer
+	sync  func() error
+	close func() error
+}
+
+// NewWriterPublisher writes every event as a JSON line to w, e.g. os.Stdout
+func NewWriterPublisher(w io.Writer) Publisher {
+	return &writerPublisher{
+		w:     w,
+		sync:  func() error { return nil },
+		close: func() error { return nil },
+	}
+}
+
+// NewFilePublisher appends every event as a JSON line to the file at path,
+// creating it if needed. Each batch is synced to disk before it is reported
+// as published.
+func NewFilePublisher(path string) (Publisher, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &writerPublisher{w: f, sync: f.Sync, close: f.Close}, nil
+}
+
+func (p *writerPublisher) Publish(ctx context.Context, events []model.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	enc := json.NewEncoder(p.w)
+	for i := range events {
+		if err := enc.Encode(&events[i]); err != nil {
+			return err
+		}
+	}
+	return p.sync()
+}
+
+func (p *writerPublisher) Close() error {
+	return p.close()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// APIKeyRepository defines the interface for API key data operations. Keys
+// are managed within a tenant; GetByPrefix and TouchLastUsed, which
+// authenticate requests, work across tenants.
+type APIKeyRepository interface {
+	Create(key *model.APIKey) error
+	GetByID(id string) (*model.APIKey, error)
+	GetByPrefix(prefix string) (*model.APIKey, error)
+	GetAll() ([]model.APIKey, error)
+	Rotate(id string, key *model.APIKey) error
+	Revoke(id, updatedBy string) error
+	TouchLastUsed(id string, at time.Time) error
+	WithTx(tx *sqlx.Tx) APIKeyRepository
+	WithTenant(tenantID string) APIKeyRepository
+}
+
+type apiKeyRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *apiKeyRepository) WithTx(tx *sqlx.Tx) APIKeyRepository {
+	return &apiKeyRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only manages the API keys
+// of tenantID
+func (r *apiKeyRepository) WithTenant(tenantID string) APIKeyRepository {
+	return &apiKeyRepository{db: r.db, tenantID: tenantID}
+}
+
+const apiKeyColumns = `id, tenant_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at,
+	created_at, created_by, updated_at, updated_by`
+
+// Create adds a new API key
+func (r *apiKeyRepository) Create(key *model.APIKey) error {
+	key.TenantID = r.tenantID
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = key.CreatedAt
+
+	query := `INSERT INTO api_key (id, tenant_id, name, prefix, key_hash, scopes, expires_at, created_at, created_by,
+	          updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(query, key.ID, key.TenantID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt,
+		key.CreatedAt, key.CreatedBy, key.UpdatedAt, key.UpdatedBy)
+	return err
+}
+
+// GetByID retrieves an API key by its ID
+func (r *apiKeyRepository) GetByID(id string) (*model.APIKey, error) {
+	return r.getOne(`SELECT `+apiKeyColumns+` FROM api_key WHERE id = $1 AND tenant_id = $2`, id, r.tenantID)
+}
+
+// GetByPrefix retrieves the API key with the given prefix, revoked or not,
+// whatever its tenant
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*model.APIKey, error) {
+	return r.getOne(`SELECT `+apiKeyColumns+` FROM api_key WHERE prefix = $1`, prefix)
+}
+
+func (r *apiKeyRepository) getOne(query string, args ...interface{}) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := r.db.Get(&key, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetAll retrieves all API keys, newest first
+func (r *apiKeyRepository) GetAll() ([]model.APIKey, error) {
+	keys := []model.APIKey{}
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE tenant_id = $1 ORDER BY created_at DESC, id`
+	if err := r.db.Select(&keys, query, r.tenantID); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rotate replaces the prefix and hash of an API key that is not revoked
+func (r *apiKeyRepository) Rotate(id string, key *model.APIKey) error {
+	key.UpdatedAt = time.Now()
+
+	query := `UPDATE api_key SET prefix = $1, key_hash = $2, updated_at = $3, updated_by = $4
+	          WHERE id = $5 AND tenant_id = $6 AND revoked_at IS NULL`
+	result, err := r.db.Exec(query, key.Prefix, key.KeyHash, key.UpdatedAt, key.UpdatedBy, id, r.tenantID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// Revoke marks an API key that is not yet revoked as revoked; the row is
+// kept so the actors recorded by the key can still be traced
+func (r *apiKeyRepository) Revoke(id, updatedBy string) error {
+	query := `UPDATE api_key SET revoked_at = now(), updated_at = now(), updated_by = $1
+	          WHERE id = $2 AND tenant_id = $3 AND revoked_at IS NULL`
+	result, err := r.db.Exec(query, updatedBy, id, r.tenantID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// TouchLastUsed records when an API key last authenticated a request
+func (r *apiKeyRepository) TouchLastUsed(id string, at time.Time) error {
+	_, err := r.db.Exec(`UPDATE api_key SET last_used_at = $1 WHERE id = $2`, at, id)
+	return err
+}
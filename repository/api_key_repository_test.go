@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockAPIKeyRepo(t *testing.T) (APIKeyRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewAPIKeyRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+var apiKeyColumnNames = []string{"id", "tenant_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at",
+	"revoked_at", "created_at", "created_by", "updated_at", "updated_by"}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	repo, mock := newMockAPIKeyRepo(t)
+	repo = repo.WithTenant("tenant1")
+	key := &model.APIKey{ID: "key-1", Name: "erp", Prefix: "0a1b2c3d", KeyHash: "hash",
+		Scopes: pq.StringArray{"cars:read"}, CreatedBy: "admin", UpdatedBy: "admin"}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO api_key (id, tenant_id, name, prefix, key_hash, scopes, expires_at, created_at, created_by,`)).
+		WithArgs("key-1", "tenant1", "erp", "0a1b2c3d", "hash", key.Scopes, key.ExpiresAt,
+			sqlmock.AnyArg(), "admin", sqlmock.AnyArg(), "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Create(key))
+	assert.False(t, key.CreatedAt.IsZero())
+	assert.Equal(t, "tenant1", key.TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_GetByPrefix(t *testing.T) {
+	repo, mock := newMockAPIKeyRepo(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`FROM api_key WHERE prefix = $1`)
+
+	mock.ExpectQuery(query).WithArgs("0a1b2c3d").
+		WillReturnRows(sqlmock.NewRows(apiKeyColumnNames).
+			AddRow("key-1", "tenant1", "erp", "0a1b2c3d", "hash", "{cars:read,search:read}", nil, now, nil, now, "admin", now, "admin"))
+	key, err := repo.GetByPrefix("0a1b2c3d")
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", key.ID)
+	assert.Equal(t, pq.StringArray{"cars:read", "search:read"}, key.Scopes)
+	assert.Equal(t, &now, key.LastUsedAt)
+	assert.Nil(t, key.ExpiresAt)
+	assert.Equal(t, "tenant1", key.TenantID)
+
+	mock.ExpectQuery(query).WithArgs("ffffffff").WillReturnRows(sqlmock.NewRows(apiKeyColumnNames))
+	_, err = repo.GetByPrefix("ffffffff")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_Rotate(t *testing.T) {
+	repo, mock := newMockAPIKeyRepo(t)
+	key := &model.APIKey{Prefix: "0a1b2c3d", KeyHash: "hash", UpdatedBy: "admin"}
+	query := regexp.QuoteMeta(`UPDATE api_key SET prefix = $1, key_hash = $2, updated_at = $3, updated_by = $4
+	          WHERE id = $5 AND tenant_id = $6 AND revoked_at IS NULL`)
+
+	mock.ExpectExec(query).WithArgs("0a1b2c3d", "hash", sqlmock.AnyArg(), "admin", "key-1", model.DefaultTenantID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Rotate("key-1", key))
+
+	// Revoked and missing keys are not rotated
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Rotate("key-2", key), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	repo, mock := newMockAPIKeyRepo(t)
+	query := regexp.QuoteMeta(`UPDATE api_key SET revoked_at = now(), updated_at = now(), updated_by = $1
+	          WHERE id = $2 AND tenant_id = $3 AND revoked_at IS NULL`)
+
+	mock.ExpectExec(query).WithArgs("admin", "key-1", model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Revoke("key-1", "admin"))
+
+	mock.ExpectExec(query).WithArgs("admin", "key-1", model.DefaultTenantID).WillReturnError(errors.New("database error"))
+	assert.EqualError(t, repo.Revoke("key-1", "admin"), "database error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_TouchLastUsed(t *testing.T) {
+	repo, mock := newMockAPIKeyRepo(t)
+	at := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE api_key SET last_used_at = $1 WHERE id = $2`)).
+		WithArgs(at, "key-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.TouchLastUsed("key-1", at))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
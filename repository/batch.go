@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Constraint errors returned by batch writes. The driver message is kept in
+// the wrapped error text.
+var (
+	// ErrDuplicate is returned when a write violates a unique constraint
+	ErrDuplicate = errors.New("duplicate value violates a unique constraint")
+	// ErrReference is returned when a write references a missing record or
+	// deletes a record that is still referenced
+	ErrReference = errors.New("referenced record is missing or still in use")
+	// ErrInvalidValue is returned when a value cannot be stored, e.g. a malformed UUID
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// translateError maps PostgreSQL constraint and data errors to repository errors
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == "23505": // unique_violation
+		return fmt.Errorf("%w: %s", ErrDuplicate, pqErr.Message)
+	case pqErr.Code == "23503": // foreign_key_violation
+		return fmt.Errorf("%w: %s", ErrReference, pqErr.Message)
+	case pqErr.Code == "23502", pqErr.Code.Class() == "22": // not_null_violation, data exceptions
+		return fmt.Errorf("%w: %s", ErrInvalidValue, pqErr.Message)
+	default:
+		return err
+	}
+}
+
+// placeholders appends "($n, $n+1, ...)" for row to b, casting each
+// parameter when casts is not nil
+func placeholders(b *strings.Builder, args []interface{}, row []interface{}, casts []string) []interface{} {
+	b.WriteString("(")
+	for i, v := range row {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		args = append(args, v)
+		b.WriteString("$" + strconv.Itoa(len(args)))
+		if casts != nil {
+			b.WriteString("::" + casts[i])
+		}
+	}
+	b.WriteString(")")
+	return args
+}
+
+// insertRows inserts all rows with one multi-row INSERT and returns the IDs
+// of the rows written. Rows that conflict with a unique constraint are
+// skipped and their IDs are missing from the result. The first column must be id.
+func insertRows(db DBTX, table string, columns []string, rows [][]interface{}) ([]string, error) {
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES ")
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		args = placeholders(&b, args, row, nil)
+	}
+	b.WriteString(" ON CONFLICT DO NOTHING RETURNING id")
+
+	ids := []string{}
+	if err := db.Select(&ids, b.String(), args...); err != nil {
+		return nil, translateError(err)
+	}
+	return ids, nil
+}
+
+// updateRows updates all rows of tenantID with one UPDATE ... FROM (VALUES ...)
+// and returns the IDs of the rows found. The first column must be id; types
+// are the SQL types of the columns, needed to type the VALUES list.
+func updateRows(db DBTX, table, tenantID string, columns, types []string, rows [][]interface{}) ([]string, error) {
+	var b strings.Builder
+	b.WriteString("UPDATE " + table + " AS t SET ")
+	for i, col := range columns[1:] {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(col + " = v." + col)
+	}
+	b.WriteString(" FROM (VALUES ")
+	args := make([]interface{}, 0, len(rows)*len(columns)+1)
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		args = placeholders(&b, args, row, types)
+	}
+	args = append(args, tenantID)
+	b.WriteString(") AS v(" + strings.Join(columns, ", ") + ") WHERE t.id = v.id AND t.tenant_id = $" +
+		strconv.Itoa(len(args)) + " RETURNING t.id")
+
+	ids := []string{}
+	if err := db.Select(&ids, b.String(), args...); err != nil {
+		return nil, translateError(err)
+	}
+	return ids, nil
+}
+
+// deleteRows deletes the rows of tenantID with the given IDs and returns the IDs found
+func deleteRows(db DBTX, table, tenantID string, ids []string) ([]string, error) {
+	deleted := []string{}
+	query := "DELETE FROM " + table + " WHERE id = ANY($1::uuid[]) AND tenant_id = $2 RETURNING id"
+	if err := db.Select(&deleted, query, pq.Array(ids), tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/keyring"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockBatchDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return sqlx.NewDb(mockDB, "sqlmock"), mock
+}
+
+func TestCarRepository_CreateBatch(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCarRepository(db).WithTenant("tenant1")
+	cars := []*model.Car{
+		{ID: "car1", Name: "Camry", SupplierID: "supp1", Price: 25000, CreatedBy: "sync", UpdatedBy: "sync"},
+		{ID: "car2", Name: "Civic", SupplierID: "supp1", Price: 22000, CreatedBy: "sync", UpdatedBy: "sync"},
+	}
+
+	query := regexp.QuoteMeta(`INSERT INTO car (id, tenant_id, name, supp_id, price, created_at, created_by, updated_at, updated_by) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9), ($10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT DO NOTHING RETURNING id`)
+	mock.ExpectQuery(query).
+		WithArgs("car1", "tenant1", "Camry", "supp1", 25000, AnyTime{}, "sync", AnyTime{}, "sync",
+			"car2", "tenant1", "Civic", "supp1", 22000, AnyTime{}, "sync", AnyTime{}, "sync").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("car2"))
+
+	ids, err := repo.CreateBatch(cars)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"car2"}, ids)
+	assert.False(t, cars[0].CreatedAt.IsZero())
+	assert.Equal(t, "tenant1", cars[1].TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Constraint violations are translated
+	mock.ExpectQuery("INSERT INTO car").WillReturnError(&pq.Error{Code: "23503", Message: `insert or update on table "car" violates foreign key constraint "car_supp_id_fkey"`})
+	_, err = repo.CreateBatch(cars)
+	assert.ErrorIs(t, err, ErrReference)
+	assert.Contains(t, err.Error(), "car_supp_id_fkey")
+}
+
+func TestCustomerRepository_UpdateBatch(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
+	customers := []*model.Customer{{ID: "cust1", Name: "John", Address: "1 Main St", Email: "john@example.com", UpdatedBy: "sync"}}
+
+	query := regexp.QuoteMeta(`UPDATE customer AS t SET name = v.name, address = v.address, phone = v.phone, email = v.email, ` +
+		`email_index = v.email_index, phone_index = v.phone_index, key_version = v.key_version, updated_at = v.updated_at, updated_by = v.updated_by ` +
+		`FROM (VALUES ($1::uuid, $2::varchar, $3::text, $4::text, $5::text, $6::text, $7::text, $8::int, $9::timestamptz, $10::varchar)) ` +
+		`AS v(id, name, address, phone, email, email_index, phone_index, key_version, updated_at, updated_by) ` +
+		`WHERE t.id = v.id AND t.tenant_id = $11 RETURNING t.id`)
+	mock.ExpectQuery(query).
+		WithArgs("cust1", "John", "1 Main St", "", "john@example.com", "john@example.com", "", 0, AnyTime{}, "sync", model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cust1"))
+
+	ids, err := repo.UpdateBatch(customers)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cust1"}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectQuery("UPDATE customer").WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint \"customer_email_key\""})
+	_, err = repo.UpdateBatch(customers)
+	assert.ErrorIs(t, err, ErrDuplicate)
+}
+
+func TestCustomerCarRepository_DeleteBatch(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCustomerCarRepository(db)
+
+	query := regexp.QuoteMeta(`DELETE FROM customer_car WHERE id = ANY($1::uuid[]) AND tenant_id = $2 RETURNING id`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"cc1", "cc2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cc1"))
+
+	ids, err := repo.DeleteBatch([]string{"cc1", "cc2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cc1"}, ids)
+
+	mock.ExpectQuery(query).WillReturnError(&pq.Error{Code: "22P02", Message: "invalid input syntax for type uuid"})
+	_, err = repo.DeleteBatch([]string{"not-a-uuid"})
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("connection reset"))
+	_, err = repo.DeleteBatch([]string{"cc1"})
+	assert.EqualError(t, err, "connection reset")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCarRepository_GetCarsByIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCarRepository(db)
+
+	query := regexp.QuoteMeta(`FROM car WHERE id = ANY($1::uuid[]) AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"car1", "car2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "supp_id", "price"}).AddRow("car1", "Camry", "supp1", 25000))
+	cars, err := repo.GetCarsByIDs([]string{"car1", "car2"})
+	assert.NoError(t, err)
+	assert.Len(t, cars, 1)
+	assert.Equal(t, 25000, cars[0].Price)
+
+	mock.ExpectQuery(query).WillReturnError(&pq.Error{Code: "22P02", Message: "invalid input syntax for type uuid"})
+	_, err = repo.GetCarsByIDs([]string{"bad"})
+	assert.ErrorIs(t, err, ErrInvalidValue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerRepository_GetByIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
+
+	query := regexp.QuoteMeta(`FROM customer WHERE id = ANY($1::uuid[]) AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"cust1", "cust2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_by"}).AddRow("cust1", "Alice", "alice"))
+	customers, err := repo.GetByIDs([]string{"cust1", "cust2"})
+	assert.NoError(t, err)
+	assert.Len(t, customers, 1)
+	assert.Equal(t, "alice", customers[0].CreatedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSupplierRepository_GetByIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewSupplierRepository(db)
+
+	query := regexp.QuoteMeta(`FROM supplier WHERE id = ANY($1::uuid[]) AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"supp1"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_by"}).AddRow("supp1", "Toyota", "bob"))
+	suppliers, err := repo.GetByIDs([]string{"supp1"})
+	assert.NoError(t, err)
+	assert.Len(t, suppliers, 1)
+	assert.Equal(t, "bob", suppliers[0].CreatedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCarRepository_GetCarsBySupplierIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCarRepository(db)
+
+	query := regexp.QuoteMeta(`WHERE supp_id = ANY($1::uuid[]) AND tenant_id = $2 ORDER BY created_at DESC, id`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"supp1", "supp2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "supp_id"}).AddRow("car1", "supp1").AddRow("car2", "supp2"))
+	cars, err := repo.GetCarsBySupplierIDs([]string{"supp1", "supp2"})
+	assert.NoError(t, err)
+	assert.Len(t, cars, 2)
+	assert.Equal(t, "supp2", cars[1].SupplierID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerCarRepository_GetByCustomerIDsAndCarIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCustomerCarRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer_car WHERE cust_id = ANY($1::uuid[]) AND tenant_id = $2`)).WithArgs(pq.Array([]string{"cust1"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "car_id", "cust_id"}).AddRow("cc1", "car1", "cust1"))
+	customerCars, err := repo.GetByCustomerIDs([]string{"cust1"})
+	assert.NoError(t, err)
+	assert.Len(t, customerCars, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer_car WHERE car_id = ANY($1::uuid[]) AND tenant_id = $2`)).WithArgs(pq.Array([]string{"car1", "car2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "car_id", "cust_id"}).AddRow("cc1", "car1", "cust1").AddRow("cc2", "car2", "cust1"))
+	customerCars, err = repo.GetByCarIDs([]string{"car1", "car2"})
+	assert.NoError(t, err)
+	assert.Len(t, customerCars, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerCarRepository_GetByIDs(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewCustomerCarRepository(db)
+
+	query := regexp.QuoteMeta(`FROM customer_car WHERE id = ANY($1::uuid[]) AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(pq.Array([]string{"cc1", "cc2"}), model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "car_id", "cust_id", "created_by"}).AddRow("cc1", "car1", "cust1", "alice"))
+	customerCars, err := repo.GetByIDs([]string{"cc1", "cc2"})
+	assert.NoError(t, err)
+	assert.Len(t, customerCars, 1)
+	assert.Equal(t, "alice", customerCars[0].CreatedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,24 +7,47 @@ import (
 
 	"github.com/GoodsChain/backend/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // CarRepository defines the interface for car data operations
 type CarRepository interface {
 	CreateCar(car *model.Car) error
 	GetCarByID(id string) (*model.Car, error)
-	GetAllCars() ([]model.Car, error)
+	GetCarsByIDs(ids []string) ([]model.Car, error)
+	GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error)
+	GetAllCars(filter model.CarFilter) ([]model.Car, error)
+	StreamCars(filter model.CarFilter, fn func(car *model.Car) error) error
 	UpdateCar(id string, car *model.Car) error
 	DeleteCar(id string) error
+	CreateBatch(cars []*model.Car) ([]string, error)
+	UpdateBatch(cars []*model.Car) ([]string, error)
+	DeleteBatch(ids []string) ([]string, error)
+	WithTx(tx *sqlx.Tx) CarRepository
+	WithTenant(tenantID string) CarRepository
 }
 
 type carRepository struct {
-	db *sqlx.DB
+	db       DBTX
+	tenantID string
 }
 
+const carColumns = "id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by"
+
 // NewCarRepository creates a new instance of CarRepository
 func NewCarRepository(db *sqlx.DB) CarRepository {
-	return &carRepository{db: db}
+	return &carRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *carRepository) WithTx(tx *sqlx.Tx) CarRepository {
+	return &carRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// cars of tenantID
+func (r *carRepository) WithTenant(tenantID string) CarRepository {
+	return &carRepository{db: r.db, tenantID: tenantID}
 }
 
 // CreateCar adds a new car to the database
@@ -34,18 +57,19 @@ func (r *carRepository) CreateCar(car *model.Car) error {
 	car.CreatedAt = time.Now()
 	car.UpdatedAt = time.Now()
 	// CreatedBy and UpdatedBy should be set by the application/usecase layer
+	car.TenantID = r.tenantID
 
-	query := `INSERT INTO car (id, name, supp_id, price, created_at, created_by, updated_at, updated_by)
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err := r.db.Exec(query, car.ID, car.Name, car.SupplierID, car.Price, car.CreatedAt, car.CreatedBy, car.UpdatedAt, car.UpdatedBy)
+	query := `INSERT INTO car (id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(query, car.ID, car.TenantID, car.Name, car.SupplierID, car.Price, car.TrimID, car.Specs, car.CreatedAt, car.CreatedBy, car.UpdatedAt, car.UpdatedBy)
 	return err
 }
 
 // GetCarByID retrieves a car by its ID
 func (r *carRepository) GetCarByID(id string) (*model.Car, error) {
 	var car model.Car
-	query := `SELECT id, name, supp_id, price, created_at, created_by, updated_at, updated_by FROM car WHERE id = $1`
-	err := r.db.Get(&car, query, id)
+	query := `SELECT ` + carColumns + ` FROM car WHERE id = $1 AND tenant_id = $2`
+	err := r.db.Get(&car, query, id, r.tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound // Consider a shared ErrNotFound
@@ -55,24 +79,92 @@ func (r *carRepository) GetCarByID(id string) (*model.Car, error) {
 	return &car, nil
 }
 
-// GetAllCars retrieves all cars from the database
-func (r *carRepository) GetAllCars() ([]model.Car, error) {
+// GetCarsByIDs retrieves the cars with the given IDs; IDs that do not exist
+// are left out of the result
+func (r *carRepository) GetCarsByIDs(ids []string) ([]model.Car, error) {
+	cars := []model.Car{}
+	query := `SELECT ` + carColumns + ` FROM car WHERE id = ANY($1::uuid[]) AND tenant_id = $2`
+	if err := r.db.Select(&cars, query, pq.Array(ids), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return cars, nil
+}
+
+// GetCarsBySupplierIDs retrieves the cars of all the given suppliers, newest first
+func (r *carRepository) GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error) {
+	cars := []model.Car{}
+	query := `SELECT ` + carColumns + ` FROM car
+		WHERE supp_id = ANY($1::uuid[]) AND tenant_id = $2 ORDER BY created_at DESC, id`
+	if err := r.db.Select(&cars, query, pq.Array(supplierIDs), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return cars, nil
+}
+
+// GetAllCars retrieves all cars matching filter from the database
+func (r *carRepository) GetAllCars(filter model.CarFilter) ([]model.Car, error) {
 	var cars []model.Car
-	query := `SELECT id, name, supp_id, price, created_at, created_by, updated_at, updated_by FROM car ORDER BY created_at DESC`
-	err := r.db.Select(&cars, query)
+	query, args := carListQuery(r.tenantID, filter)
+	err := r.db.Select(&cars, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	return cars, nil
 }
 
+// StreamCars calls fn for every car matching filter, reading one row at a time
+func (r *carRepository) StreamCars(filter model.CarFilter, fn func(car *model.Car) error) error {
+	query, args := carListQuery(r.tenantID, filter)
+	return streamRows(r.db, query, args, func(rows *sqlx.Rows) error {
+		var car model.Car
+		if err := rows.StructScan(&car); err != nil {
+			return err
+		}
+		return fn(&car)
+	})
+}
+
+// carListQuery builds the query shared by GetAllCars and StreamCars
+func carListQuery(tenantID string, filter model.CarFilter) (string, []interface{}) {
+	var c conditions
+	c.add("tenant_id = ?", tenantID)
+	rank := c.addSearch(filter.Query)
+	c.addContains("name", filter.Name)
+	if filter.SupplierID != "" {
+		c.add("supp_id = ?", filter.SupplierID)
+	}
+	if filter.MinPrice > 0 {
+		c.add("price >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		c.add("price <= ?", filter.MaxPrice)
+	}
+	if filter.BrandID != "" {
+		c.add(`trim_id IN (SELECT t.id FROM car_trim t JOIN car_model m ON m.id = t.model_id WHERE m.brand_id = ?)`, filter.BrandID)
+	}
+	if filter.ModelID != "" {
+		c.add(`trim_id IN (SELECT id FROM car_trim WHERE model_id = ?)`, filter.ModelID)
+	}
+	if filter.TrimID != "" {
+		c.add("trim_id = ?", filter.TrimID)
+	}
+	c.addSpecs(filter.Specs)
+	c.addCreatedRange(filter.CreatedRange)
+
+	columns := selectList(filter.Fields, model.Car{}, carColumns)
+	query := `SELECT ` + columns + ` FROM car` +
+		c.where() + orderBy(rank)
+	return query, c.args
+}
+
 // UpdateCar updates an existing car's information
 func (r *carRepository) UpdateCar(id string, car *model.Car) error {
 	car.UpdatedAt = time.Now()
 	// UpdatedBy should be set by the application/usecase layer
 
-	query := `UPDATE car SET name = $1, supp_id = $2, price = $3, updated_at = $4, updated_by = $5 WHERE id = $6`
-	result, err := r.db.Exec(query, car.Name, car.SupplierID, car.Price, car.UpdatedAt, car.UpdatedBy, id)
+	query := `UPDATE car SET name = $1, supp_id = $2, price = $3, trim_id = $4, specs = $5, updated_at = $6, updated_by = $7
+		WHERE id = $8 AND tenant_id = $9`
+	result, err := r.db.Exec(query, car.Name, car.SupplierID, car.Price, car.TrimID, car.Specs, car.UpdatedAt, car.UpdatedBy, id, r.tenantID)
 	if err != nil {
 		return err
 	}
@@ -88,8 +180,8 @@ func (r *carRepository) UpdateCar(id string, car *model.Car) error {
 
 // DeleteCar removes a car from the database by its ID
 func (r *carRepository) DeleteCar(id string) error {
-	query := `DELETE FROM car WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	query := `DELETE FROM car WHERE id = $1 AND tenant_id = $2`
+	result, err := r.db.Exec(query, id, r.tenantID)
 	if err != nil {
 		return err
 	}
@@ -106,3 +198,35 @@ func (r *carRepository) DeleteCar(id string) error {
 // ErrNotFound is a common error for "record not found"
 // This should ideally be in a shared package or defined per repository if specific errors are needed.
 var ErrNotFound = errors.New("requested record not found")
+
+// CreateBatch inserts cars with a single statement and returns the IDs
+// written; rows that duplicate an existing ID are skipped
+func (r *carRepository) CreateBatch(cars []*model.Car) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(cars))
+	for i, car := range cars {
+		car.TenantID = r.tenantID
+		car.CreatedAt, car.UpdatedAt = now, now
+		rows[i] = []interface{}{car.ID, car.TenantID, car.Name, car.SupplierID, car.Price, car.CreatedAt, car.CreatedBy, car.UpdatedAt, car.UpdatedBy}
+	}
+	columns := []string{"id", "tenant_id", "name", "supp_id", "price", "created_at", "created_by", "updated_at", "updated_by"}
+	return insertRows(r.db, "car", columns, rows)
+}
+
+// UpdateBatch updates cars with a single statement and returns the IDs found
+func (r *carRepository) UpdateBatch(cars []*model.Car) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(cars))
+	for i, car := range cars {
+		car.UpdatedAt = now
+		rows[i] = []interface{}{car.ID, car.Name, car.SupplierID, car.Price, car.UpdatedAt, car.UpdatedBy}
+	}
+	columns := []string{"id", "name", "supp_id", "price", "updated_at", "updated_by"}
+	types := []string{"uuid", "varchar", "uuid", "integer", "timestamptz", "varchar"}
+	return updateRows(r.db, "car", r.tenantID, columns, types, rows)
+}
+
+// DeleteBatch deletes cars by ID with a single statement and returns the IDs found
+func (r *carRepository) DeleteBatch(ids []string) ([]string, error) {
+	return deleteRows(r.db, "car", r.tenantID, ids)
+}
@@ -46,14 +46,15 @@ func TestCarRepository_CreateCar(t *testing.T) {
 		UpdatedBy:  "test_user",
 	}
 
-	query := regexp.QuoteMeta(`INSERT INTO car (id, name, supp_id, price, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)
+	query := regexp.QuoteMeta(`INSERT INTO car (id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by)`)
 
 	mock.ExpectExec(query).
-		WithArgs(testCar.ID, testCar.Name, testCar.SupplierID, testCar.Price, AnyTime{}, testCar.CreatedBy, AnyTime{}, testCar.UpdatedBy).
+		WithArgs(testCar.ID, model.DefaultTenantID, testCar.Name, testCar.SupplierID, testCar.Price, testCar.TrimID, testCar.Specs, AnyTime{}, testCar.CreatedBy, AnyTime{}, testCar.UpdatedBy).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repo.CreateCar(testCar)
 	assert.NoError(t, err)
+	assert.Equal(t, model.DefaultTenantID, testCar.TenantID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -74,8 +75,8 @@ func TestCarRepository_GetCarByID(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "supp_id", "price", "created_at", "created_by", "updated_at", "updated_by"}).
 		AddRow(expectedCar.ID, expectedCar.Name, expectedCar.SupplierID, expectedCar.Price, expectedCar.CreatedAt, expectedCar.CreatedBy, expectedCar.UpdatedAt, expectedCar.UpdatedBy)
 
-	query := regexp.QuoteMeta(`SELECT id, name, supp_id, price, created_at, created_by, updated_at, updated_by FROM car WHERE id = $1`)
-	mock.ExpectQuery(query).WithArgs(carID).WillReturnRows(rows)
+	query := regexp.QuoteMeta(`SELECT id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by FROM car WHERE id = $1 AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(carID, model.DefaultTenantID).WillReturnRows(rows)
 
 	car, err := repo.GetCarByID(carID)
 	assert.NoError(t, err)
@@ -85,7 +86,7 @@ func TestCarRepository_GetCarByID(t *testing.T) {
 
 	// Test Not Found
 	notFoundID := uuid.New().String()
-	mock.ExpectQuery(query).WithArgs(notFoundID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(query).WithArgs(notFoundID, model.DefaultTenantID).WillReturnError(sql.ErrNoRows)
 	car, err = repo.GetCarByID(notFoundID)
 	assert.ErrorIs(t, err, ErrNotFound)
 	assert.Nil(t, car)
@@ -101,17 +102,17 @@ func TestCarRepository_GetAllCars(t *testing.T) {
 		AddRow(car1.ID, car1.Name, car1.SupplierID, car1.Price, time.Now(), "user", time.Now(), "user").
 		AddRow(car2.ID, car2.Name, car2.SupplierID, car2.Price, time.Now(), "user", time.Now(), "user")
 
-	query := regexp.QuoteMeta(`SELECT id, name, supp_id, price, created_at, created_by, updated_at, updated_by FROM car ORDER BY created_at DESC`)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query := regexp.QuoteMeta(`SELECT id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by FROM car WHERE tenant_id = $1 ORDER BY created_at DESC`)
+	mock.ExpectQuery(query).WithArgs(model.DefaultTenantID).WillReturnRows(rows)
 
-	cars, err := repo.GetAllCars()
+	cars, err := repo.GetAllCars(model.CarFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, cars, 2)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test empty result
 	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "supp_id", "price", "created_at", "created_by", "updated_at", "updated_by"}))
-	cars, err = repo.GetAllCars()
+	cars, err = repo.GetAllCars(model.CarFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, cars, 0)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -127,9 +128,10 @@ func TestCarRepository_UpdateCar(t *testing.T) {
 		UpdatedBy:  "updater_user",
 	}
 
-	query := regexp.QuoteMeta(`UPDATE car SET name = $1, supp_id = $2, price = $3, updated_at = $4, updated_by = $5 WHERE id = $6`)
+	query := regexp.QuoteMeta(`UPDATE car SET name = $1, supp_id = $2, price = $3, trim_id = $4, specs = $5, updated_at = $6, updated_by = $7
+		WHERE id = $8 AND tenant_id = $9`)
 	mock.ExpectExec(query).
-		WithArgs(updatedCar.Name, updatedCar.SupplierID, updatedCar.Price, AnyTime{}, updatedCar.UpdatedBy, carID).
+		WithArgs(updatedCar.Name, updatedCar.SupplierID, updatedCar.Price, updatedCar.TrimID, updatedCar.Specs, AnyTime{}, updatedCar.UpdatedBy, carID, model.DefaultTenantID).
 		WillReturnResult(sqlmock.NewResult(0, 1)) // 0 for lastInsertId, 1 for rowsAffected
 
 	err := repo.UpdateCar(carID, updatedCar)
@@ -139,7 +141,7 @@ func TestCarRepository_UpdateCar(t *testing.T) {
 	// Test Not Found
 	notFoundID := uuid.New().String()
 	mock.ExpectExec(query).
-		WithArgs(updatedCar.Name, updatedCar.SupplierID, updatedCar.Price, AnyTime{}, updatedCar.UpdatedBy, notFoundID).
+		WithArgs(updatedCar.Name, updatedCar.SupplierID, updatedCar.Price, updatedCar.TrimID, updatedCar.Specs, AnyTime{}, updatedCar.UpdatedBy, notFoundID, model.DefaultTenantID).
 		WillReturnResult(sqlmock.NewResult(0, 0)) // 0 rows affected
 	err = repo.UpdateCar(notFoundID, updatedCar)
 	assert.ErrorIs(t, err, ErrNotFound)
@@ -150,8 +152,8 @@ func TestCarRepository_DeleteCar(t *testing.T) {
 	repo, mock := newMockCarRepo(t)
 	carID := uuid.New().String()
 
-	query := regexp.QuoteMeta(`DELETE FROM car WHERE id = $1`)
-	mock.ExpectExec(query).WithArgs(carID).WillReturnResult(sqlmock.NewResult(0, 1))
+	query := regexp.QuoteMeta(`DELETE FROM car WHERE id = $1 AND tenant_id = $2`)
+	mock.ExpectExec(query).WithArgs(carID, model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := repo.DeleteCar(carID)
 	assert.NoError(t, err)
@@ -159,8 +161,24 @@ func TestCarRepository_DeleteCar(t *testing.T) {
 
 	// Test Not Found
 	notFoundID := uuid.New().String()
-	mock.ExpectExec(query).WithArgs(notFoundID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(query).WithArgs(notFoundID, model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 0))
 	err = repo.DeleteCar(notFoundID)
 	assert.ErrorIs(t, err, ErrNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestCarRepository_WithTenant(t *testing.T) {
+	repo, mock := newMockCarRepo(t)
+	carID := uuid.New().String()
+
+	// Cars of other tenants are not found
+	query := regexp.QuoteMeta(`FROM car WHERE id = $1 AND tenant_id = $2`)
+	mock.ExpectQuery(query).WithArgs(carID, "tenant2").WillReturnError(sql.ErrNoRows)
+	_, err := repo.WithTenant("tenant2").GetCarByID(carID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM car WHERE id = $1 AND tenant_id = $2`)).
+		WithArgs(carID, "tenant2").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.WithTenant("tenant2").DeleteCar(carID), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
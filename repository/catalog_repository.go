@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CatalogRepository defines the interface for the brands, models and trims
+// of the car catalog
+type CatalogRepository interface {
+	CreateBrand(brand *model.Brand) error
+	GetBrands() ([]model.Brand, error)
+	CreateModel(carModel *model.CarModel) error
+	GetModels(brandID string) ([]model.CarModel, error)
+	CreateTrim(trim *model.Trim) error
+	GetTrims(modelID string) ([]model.Trim, error)
+	GetTrimDetails(trimIDs []string) ([]model.TrimDetail, error)
+	WithTx(tx *sqlx.Tx) CatalogRepository
+	WithTenant(tenantID string) CatalogRepository
+}
+
+type catalogRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+const (
+	brandColumns = "id, tenant_id, name, created_at, created_by, updated_at, updated_by"
+	modelColumns = "id, tenant_id, brand_id, name, category, created_at, created_by, updated_at, updated_by"
+	trimColumns  = "id, tenant_id, model_id, name, created_at, created_by, updated_at, updated_by"
+)
+
+// NewCatalogRepository creates a new instance of CatalogRepository
+func NewCatalogRepository(db *sqlx.DB) CatalogRepository {
+	return &catalogRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *catalogRepository) WithTx(tx *sqlx.Tx) CatalogRepository {
+	return &catalogRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// catalog of tenantID
+func (r *catalogRepository) WithTenant(tenantID string) CatalogRepository {
+	return &catalogRepository{db: r.db, tenantID: tenantID}
+}
+
+// CreateBrand adds a new brand; a duplicate name returns ErrDuplicate
+func (r *catalogRepository) CreateBrand(brand *model.Brand) error {
+	brand.TenantID = r.tenantID
+	brand.CreatedAt = time.Now()
+	brand.UpdatedAt = brand.CreatedAt
+
+	query := `INSERT INTO car_brand (id, tenant_id, name, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.Exec(query, brand.ID, brand.TenantID, brand.Name, brand.CreatedAt, brand.CreatedBy, brand.UpdatedAt, brand.UpdatedBy)
+	return translateError(err)
+}
+
+// GetBrands retrieves all brands by name
+func (r *catalogRepository) GetBrands() ([]model.Brand, error) {
+	brands := []model.Brand{}
+	query := `SELECT ` + brandColumns + ` FROM car_brand WHERE tenant_id = $1 ORDER BY name`
+	if err := r.db.Select(&brands, query, r.tenantID); err != nil {
+		return nil, err
+	}
+	return brands, nil
+}
+
+// CreateModel adds a new model to its brand. It returns ErrNotFound when the
+// brand does not exist and ErrDuplicate when the brand has a model of that name.
+func (r *catalogRepository) CreateModel(carModel *model.CarModel) error {
+	carModel.TenantID = r.tenantID
+	carModel.CreatedAt = time.Now()
+	carModel.UpdatedAt = carModel.CreatedAt
+
+	query := `INSERT INTO car_model (id, tenant_id, brand_id, name, category, created_at, created_by, updated_at, updated_by)
+		SELECT $1::uuid, $2::uuid, $3::uuid, $4, $5, $6::timestamptz, $7, $8::timestamptz, $9
+		WHERE EXISTS (SELECT 1 FROM car_brand WHERE id = $3 AND tenant_id = $2)`
+	result, err := r.db.Exec(query, carModel.ID, carModel.TenantID, carModel.BrandID, carModel.Name, carModel.Category,
+		carModel.CreatedAt, carModel.CreatedBy, carModel.UpdatedAt, carModel.UpdatedBy)
+	return insertedOne(result, err)
+}
+
+// GetModels retrieves the models of a brand by name
+func (r *catalogRepository) GetModels(brandID string) ([]model.CarModel, error) {
+	models := []model.CarModel{}
+	query := `SELECT ` + modelColumns + ` FROM car_model WHERE brand_id = $1 AND tenant_id = $2 ORDER BY name`
+	if err := r.db.Select(&models, query, brandID, r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return models, nil
+}
+
+// CreateTrim adds a new trim to its model. It returns ErrNotFound when the
+// model does not exist and ErrDuplicate when the model has a trim of that name.
+func (r *catalogRepository) CreateTrim(trim *model.Trim) error {
+	trim.TenantID = r.tenantID
+	trim.CreatedAt = time.Now()
+	trim.UpdatedAt = trim.CreatedAt
+
+	query := `INSERT INTO car_trim (id, tenant_id, model_id, name, created_at, created_by, updated_at, updated_by)
+		SELECT $1::uuid, $2::uuid, $3::uuid, $4, $5::timestamptz, $6, $7::timestamptz, $8
+		WHERE EXISTS (SELECT 1 FROM car_model WHERE id = $3 AND tenant_id = $2)`
+	result, err := r.db.Exec(query, trim.ID, trim.TenantID, trim.ModelID, trim.Name,
+		trim.CreatedAt, trim.CreatedBy, trim.UpdatedAt, trim.UpdatedBy)
+	return insertedOne(result, err)
+}
+
+// GetTrims retrieves the trims of a model by name
+func (r *catalogRepository) GetTrims(modelID string) ([]model.Trim, error) {
+	trims := []model.Trim{}
+	query := `SELECT ` + trimColumns + ` FROM car_trim WHERE model_id = $1 AND tenant_id = $2 ORDER BY name`
+	if err := r.db.Select(&trims, query, modelID, r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return trims, nil
+}
+
+// GetTrimDetails retrieves the trims with the given IDs with their model and
+// brand; IDs that do not exist are left out of the result
+func (r *catalogRepository) GetTrimDetails(trimIDs []string) ([]model.TrimDetail, error) {
+	details := []model.TrimDetail{}
+	query := `SELECT t.id AS trim_id, t.name AS trim, m.id AS model_id, m.name AS model, m.category,
+			b.id AS brand_id, b.name AS brand
+		FROM car_trim t
+		JOIN car_model m ON m.id = t.model_id
+		JOIN car_brand b ON b.id = m.brand_id
+		WHERE t.id = ANY($1::uuid[]) AND t.tenant_id = $2`
+	if err := r.db.Select(&details, query, pq.Array(trimIDs), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return details, nil
+}
+
+// insertedOne translates the result of an INSERT ... SELECT ... WHERE EXISTS
+// of one row: ErrNotFound when the row it depends on does not exist
+func insertedOne(result sql.Result, err error) error {
+	if err != nil {
+		return translateError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
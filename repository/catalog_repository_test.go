@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockCatalogRepo(t *testing.T) (CatalogRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return NewCatalogRepository(sqlx.NewDb(mockDB, "sqlmock")).WithTenant("tenant1"), mock
+}
+
+func TestCatalogRepository_Brands(t *testing.T) {
+	repo, mock := newMockCatalogRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO car_brand (id, tenant_id, name, created_at, created_by, updated_at, updated_by)`)).
+		WithArgs("brand1", "tenant1", "Toyota", AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	brand := &model.Brand{ID: "brand1", Name: "Toyota", CreatedBy: "admin", UpdatedBy: "admin"}
+	require.NoError(t, repo.CreateBrand(brand))
+	assert.Equal(t, "tenant1", brand.TenantID)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO car_brand`)).
+		WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+	assert.ErrorIs(t, repo.CreateBrand(&model.Brand{ID: "brand2", Name: "Toyota"}), ErrDuplicate)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + brandColumns + ` FROM car_brand WHERE tenant_id = $1 ORDER BY name`)).
+		WithArgs("tenant1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("brand1", "Toyota").AddRow("brand2", "Honda"))
+	brands, err := repo.GetBrands()
+	require.NoError(t, err)
+	assert.Len(t, brands, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCatalogRepository_Models(t *testing.T) {
+	repo, mock := newMockCatalogRepo(t)
+	query := regexp.QuoteMeta(`INSERT INTO car_model (id, tenant_id, brand_id, name, category, created_at, created_by, updated_at, updated_by)`) +
+		`.*` + regexp.QuoteMeta(`WHERE EXISTS (SELECT 1 FROM car_brand WHERE id = $3 AND tenant_id = $2)`)
+
+	mock.ExpectExec(query).
+		WithArgs("model1", "tenant1", "brand1", "Camry", model.CategoryPassenger, AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.CreateModel(&model.CarModel{ID: "model1", BrandID: "brand1", Name: "Camry",
+		Category: model.CategoryPassenger, CreatedBy: "admin", UpdatedBy: "admin"}))
+
+	// Brands of other tenants are not found
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.CreateModel(&model.CarModel{ID: "model2", BrandID: "other", Name: "Civic"}), ErrNotFound)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car_model WHERE brand_id = $1 AND tenant_id = $2 ORDER BY name`)).
+		WithArgs("brand1", "tenant1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "brand_id", "name", "category"}).AddRow("model1", "brand1", "Camry", "passenger"))
+	models, err := repo.GetModels("brand1")
+	require.NoError(t, err)
+	assert.Equal(t, "Camry", models[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCatalogRepository_Trims(t *testing.T) {
+	repo, mock := newMockCatalogRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO car_trim (id, tenant_id, model_id, name, created_at, created_by, updated_at, updated_by)`)).
+		WithArgs("trim1", "tenant1", "model1", "XSE Hybrid", AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.CreateTrim(&model.Trim{ID: "trim1", ModelID: "model1", Name: "XSE Hybrid", CreatedBy: "admin", UpdatedBy: "admin"}))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car_trim WHERE model_id = $1 AND tenant_id = $2 ORDER BY name`)).
+		WithArgs("model1", "tenant1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "model_id", "name"}).AddRow("trim1", "model1", "XSE Hybrid"))
+	trims, err := repo.GetTrims("model1")
+	require.NoError(t, err)
+	assert.Len(t, trims, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE t.id = ANY($1::uuid[]) AND t.tenant_id = $2`)).
+		WithArgs(pq.Array([]string{"trim1"}), "tenant1").
+		WillReturnRows(sqlmock.NewRows([]string{"trim_id", "trim", "model_id", "model", "category", "brand_id", "brand"}).
+			AddRow("trim1", "XSE Hybrid", "model1", "Camry", "passenger", "brand1", "Toyota"))
+	details, err := repo.GetTrimDetails([]string{"trim1"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.TrimDetail{{TrimID: "trim1", Trim: "XSE Hybrid", ModelID: "model1", Model: "Camry",
+		Category: "passenger", BrandID: "brand1", Brand: "Toyota"}}, details)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCarRepository_Specs(t *testing.T) {
+	repo, mock := newMockCarRepo(t)
+
+	// Specs are stored as JSON and read back with numbers as float64
+	trimID := "trim1"
+	specs := model.Specs{"fuel": "hybrid", "seats": float64(7)}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO car`)).
+		WithArgs("car1", model.DefaultTenantID, "Sienna", "supp1", 40000, "trim1", []byte(`{"fuel":"hybrid","seats":7}`),
+			AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.CreateCar(&model.Car{ID: "car1", Name: "Sienna", SupplierID: "supp1", Price: 40000,
+		TrimID: &trimID, Specs: specs, CreatedBy: "admin", UpdatedBy: "admin"}))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car WHERE id = $1 AND tenant_id = $2`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "trim_id", "specs", "created_at"}).
+			AddRow("car1", "trim1", []byte(`{"fuel":"hybrid","seats":7}`), time.Now()))
+	car, err := repo.GetCarByID("car1")
+	require.NoError(t, err)
+	assert.Equal(t, "trim1", *car.TrimID)
+	assert.Equal(t, specs, car.Specs)
+
+	// Cars without a trim have no specs
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car WHERE id = $1 AND tenant_id = $2`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "trim_id", "specs"}).AddRow("car2", nil, []byte(`{}`)))
+	car, err = repo.GetCarByID("car2")
+	require.NoError(t, err)
+	assert.Nil(t, car.TrimID)
+	assert.Empty(t, car.Specs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
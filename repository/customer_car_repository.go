@@ -7,26 +7,50 @@ import (
 
 	"github.com/GoodsChain/backend/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // CustomerCarRepository defines the interface for customer_car data operations
 type CustomerCarRepository interface {
 	Create(customerCar *model.CustomerCar) error
 	GetByID(id string) (*model.CustomerCar, error)
-	GetAll() ([]*model.CustomerCar, error)
+	GetByIDs(ids []string) ([]*model.CustomerCar, error)
+	GetAll(filter model.CustomerCarFilter) ([]*model.CustomerCar, error)
+	Stream(filter model.CustomerCarFilter, fn func(customerCar *model.CustomerCar) error) error
 	GetByCustomerID(customerID string) ([]*model.CustomerCar, error)
 	GetByCarID(carID string) ([]*model.CustomerCar, error)
+	GetByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error)
+	GetByCarIDs(carIDs []string) ([]*model.CustomerCar, error)
 	Update(id string, customerCar *model.CustomerCar) error
 	Delete(id string) error
+	CreateBatch(customerCars []*model.CustomerCar) ([]string, error)
+	UpdateBatch(customerCars []*model.CustomerCar) ([]string, error)
+	DeleteBatch(ids []string) ([]string, error)
+	WithTx(tx *sqlx.Tx) CustomerCarRepository
+	WithTenant(tenantID string) CustomerCarRepository
 }
 
 type customerCarRepository struct {
-	db *sqlx.DB
+	db       DBTX
+	tenantID string
 }
 
+const customerCarColumns = "id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by"
+
 // NewCustomerCarRepository creates a new instance of CustomerCarRepository
 func NewCustomerCarRepository(db *sqlx.DB) CustomerCarRepository {
-	return &customerCarRepository{db: db}
+	return &customerCarRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *customerCarRepository) WithTx(tx *sqlx.Tx) CustomerCarRepository {
+	return &customerCarRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// customer_car relationships of tenantID
+func (r *customerCarRepository) WithTenant(tenantID string) CustomerCarRepository {
+	return &customerCarRepository{db: r.db, tenantID: tenantID}
 }
 
 // Create adds a new customer_car relationship to the database
@@ -34,10 +58,11 @@ func (r *customerCarRepository) Create(customerCar *model.CustomerCar) error {
 	customerCar.CreatedAt = time.Now()
 	customerCar.UpdatedAt = time.Now()
 	// CreatedBy and UpdatedBy should be set by the application/usecase layer
+	customerCar.TenantID = r.tenantID
 
-	query := `INSERT INTO customer_car (id, car_id, cust_id, created_at, created_by, updated_at, updated_by)
-              VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.Exec(query, customerCar.ID, customerCar.CarID, customerCar.CustomerID, 
+	query := `INSERT INTO customer_car (id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(query, customerCar.ID, customerCar.TenantID, customerCar.CarID, customerCar.CustomerID, 
 		customerCar.CreatedAt, customerCar.CreatedBy, customerCar.UpdatedAt, customerCar.UpdatedBy)
 	return err
 }
@@ -45,9 +70,9 @@ func (r *customerCarRepository) Create(customerCar *model.CustomerCar) error {
 // GetByID retrieves a customer_car relationship by its ID
 func (r *customerCarRepository) GetByID(id string) (*model.CustomerCar, error) {
 	var customerCar model.CustomerCar
-	query := `SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by 
-	          FROM customer_car WHERE id = $1`
-	err := r.db.Get(&customerCar, query, id)
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE id = $1 AND tenant_id = $2`
+	err := r.db.Get(&customerCar, query, id, r.tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -57,24 +82,65 @@ func (r *customerCarRepository) GetByID(id string) (*model.CustomerCar, error) {
 	return &customerCar, nil
 }
 
-// GetAll retrieves all customer_car relationships from the database
-func (r *customerCarRepository) GetAll() ([]*model.CustomerCar, error) {
+// GetByIDs retrieves the customer_car relationships with the given IDs; IDs
+// that do not exist are left out of the result
+func (r *customerCarRepository) GetByIDs(ids []string) ([]*model.CustomerCar, error) {
+	customerCars := []*model.CustomerCar{}
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE id = ANY($1::uuid[]) AND tenant_id = $2`
+	if err := r.db.Select(&customerCars, query, pq.Array(ids), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return customerCars, nil
+}
+
+// GetAll retrieves all customer_car relationships matching filter from the database
+func (r *customerCarRepository) GetAll(filter model.CustomerCarFilter) ([]*model.CustomerCar, error) {
 	var customerCars []*model.CustomerCar
-	query := `SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by 
-	          FROM customer_car ORDER BY created_at DESC`
-	err := r.db.Select(&customerCars, query)
+	query, args := customerCarListQuery(r.tenantID, filter)
+	err := r.db.Select(&customerCars, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	return customerCars, nil
 }
 
+// Stream calls fn for every customer_car relationship matching filter, reading one row at a time
+func (r *customerCarRepository) Stream(filter model.CustomerCarFilter, fn func(customerCar *model.CustomerCar) error) error {
+	query, args := customerCarListQuery(r.tenantID, filter)
+	return streamRows(r.db, query, args, func(rows *sqlx.Rows) error {
+		var customerCar model.CustomerCar
+		if err := rows.StructScan(&customerCar); err != nil {
+			return err
+		}
+		return fn(&customerCar)
+	})
+}
+
+// customerCarListQuery builds the query shared by GetAll and Stream
+func customerCarListQuery(tenantID string, filter model.CustomerCarFilter) (string, []interface{}) {
+	var c conditions
+	c.add("tenant_id = ?", tenantID)
+	if filter.CustomerID != "" {
+		c.add("cust_id = ?", filter.CustomerID)
+	}
+	if filter.CarID != "" {
+		c.add("car_id = ?", filter.CarID)
+	}
+	c.addCreatedRange(filter.CreatedRange)
+
+	columns := selectList(filter.Fields, model.CustomerCar{}, customerCarColumns)
+	query := `SELECT ` + columns + `
+	          FROM customer_car` + c.where() + ` ORDER BY created_at DESC, id`
+	return query, c.args
+}
+
 // GetByCustomerID retrieves all customer_car relationships for a specific customer
 func (r *customerCarRepository) GetByCustomerID(customerID string) ([]*model.CustomerCar, error) {
 	var customerCars []*model.CustomerCar
-	query := `SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by 
-	          FROM customer_car WHERE cust_id = $1 ORDER BY created_at DESC`
-	err := r.db.Select(&customerCars, query, customerID)
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE cust_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+	err := r.db.Select(&customerCars, query, customerID, r.tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,23 +150,46 @@ func (r *customerCarRepository) GetByCustomerID(customerID string) ([]*model.Cus
 // GetByCarID retrieves all customer_car relationships for a specific car
 func (r *customerCarRepository) GetByCarID(carID string) ([]*model.CustomerCar, error) {
 	var customerCars []*model.CustomerCar
-	query := `SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by 
-	          FROM customer_car WHERE car_id = $1 ORDER BY created_at DESC`
-	err := r.db.Select(&customerCars, query, carID)
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE car_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+	err := r.db.Select(&customerCars, query, carID, r.tenantID)
 	if err != nil {
 		return nil, err
 	}
 	return customerCars, nil
 }
 
+// GetByCustomerIDs retrieves the customer_car relationships of all the given customers
+func (r *customerCarRepository) GetByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error) {
+	customerCars := []*model.CustomerCar{}
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE cust_id = ANY($1::uuid[]) AND tenant_id = $2 ORDER BY created_at DESC, id`
+	if err := r.db.Select(&customerCars, query, pq.Array(customerIDs), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return customerCars, nil
+}
+
+// GetByCarIDs retrieves the customer_car relationships of all the given cars
+func (r *customerCarRepository) GetByCarIDs(carIDs []string) ([]*model.CustomerCar, error) {
+	customerCars := []*model.CustomerCar{}
+	query := `SELECT ` + customerCarColumns + `
+	          FROM customer_car WHERE car_id = ANY($1::uuid[]) AND tenant_id = $2 ORDER BY created_at DESC, id`
+	if err := r.db.Select(&customerCars, query, pq.Array(carIDs), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return customerCars, nil
+}
+
 // Update updates an existing customer_car relationship
 func (r *customerCarRepository) Update(id string, customerCar *model.CustomerCar) error {
 	customerCar.UpdatedAt = time.Now()
 	// UpdatedBy should be set by the application/usecase layer
 
-	query := `UPDATE customer_car SET car_id = $1, cust_id = $2, updated_at = $3, updated_by = $4 WHERE id = $5`
+	query := `UPDATE customer_car SET car_id = $1, cust_id = $2, updated_at = $3, updated_by = $4
+	          WHERE id = $5 AND tenant_id = $6`
 	result, err := r.db.Exec(query, customerCar.CarID, customerCar.CustomerID, 
-		customerCar.UpdatedAt, customerCar.UpdatedBy, id)
+		customerCar.UpdatedAt, customerCar.UpdatedBy, id, r.tenantID)
 	if err != nil {
 		return err
 	}
@@ -116,8 +205,8 @@ func (r *customerCarRepository) Update(id string, customerCar *model.CustomerCar
 
 // Delete removes a customer_car relationship from the database by its ID
 func (r *customerCarRepository) Delete(id string) error {
-	query := `DELETE FROM customer_car WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	query := `DELETE FROM customer_car WHERE id = $1 AND tenant_id = $2`
+	result, err := r.db.Exec(query, id, r.tenantID)
 	if err != nil {
 		return err
 	}
@@ -130,3 +219,37 @@ func (r *customerCarRepository) Delete(id string) error {
 	}
 	return nil
 }
+
+// CreateBatch inserts customer_car relationships with a single statement and
+// returns the IDs written; rows that duplicate an existing ID or
+// customer-car pair are skipped
+func (r *customerCarRepository) CreateBatch(customerCars []*model.CustomerCar) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(customerCars))
+	for i, customerCar := range customerCars {
+		customerCar.TenantID = r.tenantID
+		customerCar.CreatedAt, customerCar.UpdatedAt = now, now
+		rows[i] = []interface{}{customerCar.ID, customerCar.TenantID, customerCar.CarID, customerCar.CustomerID,
+			customerCar.CreatedAt, customerCar.CreatedBy, customerCar.UpdatedAt, customerCar.UpdatedBy}
+	}
+	columns := []string{"id", "tenant_id", "car_id", "cust_id", "created_at", "created_by", "updated_at", "updated_by"}
+	return insertRows(r.db, "customer_car", columns, rows)
+}
+
+// UpdateBatch updates customer_car relationships with a single statement and returns the IDs found
+func (r *customerCarRepository) UpdateBatch(customerCars []*model.CustomerCar) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(customerCars))
+	for i, customerCar := range customerCars {
+		customerCar.UpdatedAt = now
+		rows[i] = []interface{}{customerCar.ID, customerCar.CarID, customerCar.CustomerID, customerCar.UpdatedAt, customerCar.UpdatedBy}
+	}
+	columns := []string{"id", "car_id", "cust_id", "updated_at", "updated_by"}
+	types := []string{"uuid", "uuid", "uuid", "timestamptz", "varchar"}
+	return updateRows(r.db, "customer_car", r.tenantID, columns, types, rows)
+}
+
+// DeleteBatch deletes customer_car relationships by ID with a single statement and returns the IDs found
+func (r *customerCarRepository) DeleteBatch(ids []string) ([]string, error) {
+	return deleteRows(r.db, "customer_car", r.tenantID, ids)
+}
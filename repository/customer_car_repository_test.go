@@ -33,8 +33,8 @@ func TestCustomerCarCreate(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO customer_car \\(id, car_id, cust_id, created_at, created_by, updated_at, updated_by\\)").
-			WithArgs(customerCar.ID, customerCar.CarID, customerCar.CustomerID,
+		mock.ExpectExec("INSERT INTO customer_car \\(id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by\\)").
+			WithArgs(customerCar.ID, model.DefaultTenantID, customerCar.CarID, customerCar.CustomerID,
 				sqlmock.AnyArg(), customerCar.CreatedBy, sqlmock.AnyArg(), customerCar.UpdatedBy).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -49,7 +49,7 @@ func TestCustomerCarCreate(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mock.ExpectExec("INSERT INTO customer_car").
-			WithArgs(customerCar.ID, customerCar.CarID, customerCar.CustomerID,
+			WithArgs(customerCar.ID, model.DefaultTenantID, customerCar.CarID, customerCar.CustomerID,
 				sqlmock.AnyArg(), customerCar.CreatedBy, sqlmock.AnyArg(), customerCar.UpdatedBy).
 			WillReturnError(expectedErr)
 
@@ -74,8 +74,8 @@ func TestCustomerCarGetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "car_id", "cust_id", "created_at", "created_by", "updated_at", "updated_by"}).
 			AddRow(customerCarID, "car123", "cust123", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customerCar, err := repo.GetByID(customerCarID)
@@ -91,8 +91,8 @@ func TestCustomerCarGetByID(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnError(sql.ErrNoRows)
 
 		customerCar, err := repo.GetByID(customerCarID)
@@ -106,8 +106,8 @@ func TestCustomerCarGetByID(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		customerCar, err := repo.GetByID(customerCarID)
@@ -132,10 +132,11 @@ func TestCustomerCarGetAll(t *testing.T) {
 			AddRow("cc123", "car123", "cust123", createdAt, "admin", updatedAt, "admin").
 			AddRow("cc456", "car456", "cust456", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car ORDER BY created_at DESC").
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		customerCars, err := repo.GetAll()
+		customerCars, err := repo.GetAll(model.CustomerCarFilter{})
 		assert.NoError(t, err)
 		assert.Len(t, customerCars, 2)
 		assert.Equal(t, "cc123", customerCars[0].ID)
@@ -149,10 +150,11 @@ func TestCustomerCarGetAll(t *testing.T) {
 	t.Run("Success With No Results", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "car_id", "cust_id", "created_at", "created_by", "updated_at", "updated_by"})
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car ORDER BY created_at DESC").
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		customerCars, err := repo.GetAll()
+		customerCars, err := repo.GetAll(model.CustomerCarFilter{})
 		assert.NoError(t, err)
 		assert.Empty(t, customerCars)
 
@@ -163,10 +165,11 @@ func TestCustomerCarGetAll(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car ORDER BY created_at DESC").
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
-		customerCars, err := repo.GetAll()
+		customerCars, err := repo.GetAll(model.CustomerCarFilter{})
 		assert.Equal(t, expectedErr, err)
 		assert.Nil(t, customerCars)
 
@@ -189,8 +192,8 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 			AddRow("cc123", "car123", customerID, createdAt, "admin", updatedAt, "admin").
 			AddRow("cc456", "car456", customerID, createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(customerID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customerCars, err := repo.GetByCustomerID(customerID)
@@ -207,8 +210,8 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 	t.Run("Success With No Results", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "car_id", "cust_id", "created_at", "created_by", "updated_at", "updated_by"})
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(customerID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customerCars, err := repo.GetByCustomerID(customerID)
@@ -222,8 +225,8 @@ func TestCustomerCarGetByCustomerID(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(customerID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE cust_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		customerCars, err := repo.GetByCustomerID(customerID)
@@ -249,8 +252,8 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 			AddRow("cc123", carID, "cust123", createdAt, "admin", updatedAt, "admin").
 			AddRow("cc456", carID, "cust456", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(carID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(carID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customerCars, err := repo.GetByCarID(carID)
@@ -267,8 +270,8 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 	t.Run("Success With No Results", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "car_id", "cust_id", "created_at", "created_by", "updated_at", "updated_by"})
 
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(carID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(carID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customerCars, err := repo.GetByCarID(carID)
@@ -282,8 +285,8 @@ func TestCustomerCarGetByCarID(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 ORDER BY created_at DESC").
-			WithArgs(carID).
+		mock.ExpectQuery("SELECT id, tenant_id, car_id, cust_id, created_at, created_by, updated_at, updated_by FROM customer_car WHERE car_id = \\$1 AND tenant_id = \\$2 ORDER BY created_at DESC").
+			WithArgs(carID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		customerCars, err := repo.GetByCarID(carID)
@@ -309,8 +312,8 @@ func TestCustomerCarUpdate(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5").
-			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID).
+		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5 AND tenant_id = \\$6").
+			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Update(customerCarID, customerCar)
@@ -322,8 +325,8 @@ func TestCustomerCarUpdate(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5").
-			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID).
+		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5 AND tenant_id = \\$6").
+			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err := repo.Update(customerCarID, customerCar)
@@ -336,8 +339,8 @@ func TestCustomerCarUpdate(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5").
-			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID).
+		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5 AND tenant_id = \\$6").
+			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Update(customerCarID, customerCar)
@@ -350,8 +353,8 @@ func TestCustomerCarUpdate(t *testing.T) {
 
 	t.Run("Result Error", func(t *testing.T) {
 		expectedErr := errors.New("result error")
-		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5").
-			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID).
+		mock.ExpectExec("UPDATE customer_car SET car_id = \\$1, cust_id = \\$2, updated_at = \\$3, updated_by = \\$4 WHERE id = \\$5 AND tenant_id = \\$6").
+			WithArgs(customerCar.CarID, customerCar.CustomerID, sqlmock.AnyArg(), customerCar.UpdatedBy, customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewErrorResult(expectedErr))
 
 		err := repo.Update(customerCarID, customerCar)
@@ -370,8 +373,8 @@ func TestCustomerCarDelete(t *testing.T) {
 	customerCarID := "cc123"
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Delete(customerCarID)
@@ -383,8 +386,8 @@ func TestCustomerCarDelete(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err := repo.Delete(customerCarID)
@@ -397,8 +400,8 @@ func TestCustomerCarDelete(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Delete(customerCarID)
@@ -411,8 +414,8 @@ func TestCustomerCarDelete(t *testing.T) {
 
 	t.Run("Result Error", func(t *testing.T) {
 		expectedErr := errors.New("result error")
-		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1").
-			WithArgs(customerCarID).
+		mock.ExpectExec("DELETE FROM customer_car WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerCarID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewErrorResult(expectedErr))
 
 		err := repo.Delete(customerCarID)
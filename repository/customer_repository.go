@@ -1,59 +1,313 @@
 package repository
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/GoodsChain/backend/model"
+	"github.com/lib/pq"
 )
 
 type CustomerRepository interface {
 	Create(customer *model.Customer) error
 	Get(id string) (*model.Customer, error)
+	GetByIDs(ids []string) ([]*model.Customer, error)
 	Update(id string, customer *model.Customer) error
 	Delete(id string) error
-	GetAll() ([]*model.Customer, error)
+	GetAll(filter model.CustomerFilter) ([]*model.Customer, error)
+	Stream(filter model.CustomerFilter, fn func(customer *model.Customer) error) error
+	CreateBatch(customers []*model.Customer) ([]string, error)
+	UpdateBatch(customers []*model.Customer) ([]string, error)
+	DeleteBatch(ids []string) ([]string, error)
+	WithTx(tx *sqlx.Tx) CustomerRepository
+	WithTenant(tenantID string) CustomerRepository
+	Reencrypt(limit int) (int, error)
 }
 
 type customerRepository struct {
-	db *sqlx.DB
+	db       DBTX
+	tenantID string
+	cipher   FieldCipher
 }
 
-func (r *customerRepository) GetAll() ([]*model.Customer, error) {
+const customerColumns = "id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by"
+
+func (r *customerRepository) GetAll(filter model.CustomerFilter) ([]*model.Customer, error) {
 	var customers []*model.Customer
-	if err := r.db.Select(&customers, "SELECT * FROM customer"); err != nil { // Using singular form to match table name
+	query, args := customerListQuery(r.tenantID, filter, r.cipher)
+	if err := r.db.Select(&customers, query, args...); err != nil {
 		return nil, err
 	}
+	for _, customer := range customers {
+		if err := r.decrypt(customer); err != nil {
+			return nil, err
+		}
+	}
 	return customers, nil
 }
 
-func NewCustomerRepository(db *sqlx.DB) CustomerRepository {
+// Stream calls fn for every customer matching filter, reading one row at a time
+func (r *customerRepository) Stream(filter model.CustomerFilter, fn func(customer *model.Customer) error) error {
+	query, args := customerListQuery(r.tenantID, filter, r.cipher)
+	return streamRows(r.db, query, args, func(rows *sqlx.Rows) error {
+		var customer model.Customer
+		if err := rows.StructScan(&customer); err != nil {
+			return err
+		}
+		if err := r.decrypt(&customer); err != nil {
+			return err
+		}
+		return fn(&customer)
+	})
+}
+
+// Searches that are a whole email or phone, such as "john.doe@example.com"
+// or "+84 912 345 678"
+var (
+	emailQuery = regexp.MustCompile(`^[^\s@]+@[^\s@]+$`)
+	phoneQuery = regexp.MustCompile(`^\+?[\d(][\d\s().\-]{5,}\d$`)
+)
+
+// customerListQuery builds the query shared by GetAll and Stream. Emails are
+// matched by their blind index, computed with cipher. The encrypted fields
+// cannot be searched, so a q that is an email or a phone is matched exactly
+// by its blind index, and any other q searches names.
+func customerListQuery(tenantID string, filter model.CustomerFilter, cipher FieldCipher) (string, []interface{}) {
+	var c conditions
+	c.add("tenant_id = ?", tenantID)
+	var rank string
+	switch q := strings.TrimSpace(filter.Query); {
+	case emailQuery.MatchString(q):
+		c.add("email_index = ?", cipher.BlindIndex(q))
+	case phoneQuery.MatchString(q):
+		c.add("phone_index = ?", cipher.BlindIndex(phoneDigits(q)))
+	default:
+		rank = c.addSearch(filter.Query)
+	}
+	c.addContains("name", filter.Name)
+	if filter.Email != "" {
+		c.add("email_index = ?", cipher.BlindIndex(filter.Email))
+	}
+	c.addCreatedRange(filter.CreatedRange)
+
+	// The ID is always read, as the encrypted fields are bound to it
+	columns := selectList(filter.Fields.With("id"), model.Customer{}, customerColumns)
+	query := `SELECT ` + columns + `
+		FROM customer` + c.where() + orderBy(rank)
+	return query, c.args
+}
+
+// NewCustomerRepository creates a customer repository storing the address,
+// phone and email of customers encrypted with cipher
+func NewCustomerRepository(db *sqlx.DB, cipher FieldCipher) CustomerRepository {
 	return &customerRepository{
-		db: db,
+		db:       db,
+		tenantID: model.DefaultTenantID,
+		cipher:   cipher,
+	}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *customerRepository) WithTx(tx *sqlx.Tx) CustomerRepository {
+	return &customerRepository{db: tx, tenantID: r.tenantID, cipher: r.cipher}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// customers of tenantID
+func (r *customerRepository) WithTenant(tenantID string) CustomerRepository {
+	return &customerRepository{db: r.db, tenantID: tenantID, cipher: r.cipher}
+}
+
+// customerPII holds the stored form of the personal fields of a customer
+type customerPII struct {
+	address, phone, email  string
+	emailIndex, phoneIndex string
+	keyVersion             int
+}
+
+// encrypt returns the personal fields of the customer with id encrypted with
+// the current key and bound to their column, together with the blind indexes
+// of the email and of the digits of the phone
+func (r *customerRepository) encrypt(id string, customer *model.Customer) (customerPII, error) {
+	pii := customerPII{
+		emailIndex: r.cipher.BlindIndex(customer.Email),
+		phoneIndex: r.cipher.BlindIndex(phoneDigits(customer.Phone)),
+		keyVersion: r.cipher.KeyVersion(),
+	}
+	var err error
+	if pii.address, err = r.cipher.Encrypt(customer.Address, piiContext(id, "address")); err != nil {
+		return pii, err
+	}
+	if pii.phone, err = r.cipher.Encrypt(customer.Phone, piiContext(id, "phone")); err != nil {
+		return pii, err
+	}
+	pii.email, err = r.cipher.Encrypt(customer.Email, piiContext(id, "email"))
+	return pii, err
+}
+
+// piiContext names the column of a customer a personal field is stored in
+func piiContext(id, column string) string {
+	return "customer:" + id + ":" + column
+}
+
+// phoneDigits returns the digits of phone, so that its blind index does not
+// depend on how it is written
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// decrypt replaces the stored personal fields of customer with their plaintext
+func (r *customerRepository) decrypt(customer *model.Customer) error {
+	fields := []struct {
+		value  *string
+		column string
+	}{{&customer.Address, "address"}, {&customer.Phone, "phone"}, {&customer.Email, "email"}}
+	for _, field := range fields {
+		plaintext, err := r.cipher.Decrypt(*field.value, piiContext(customer.ID, field.column))
+		if err != nil {
+			return fmt.Errorf("customer %s: %w", customer.ID, err)
+		}
+		*field.value = plaintext
 	}
+	return nil
 }
 
 func (r *customerRepository) Create(customer *model.Customer) error {
-	query := `INSERT INTO customer (id, name, address, phone, email, created_by, updated_by) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.Exec(query, customer.ID, customer.Name, customer.Address, customer.Phone, customer.Email, "admin", "admin")
+	customer.TenantID = r.tenantID
+	pii, err := r.encrypt(customer.ID, customer)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO customer (id, tenant_id, name, address, phone, email, email_index, phone_index, key_version, created_by, updated_by) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err = r.db.Exec(query, customer.ID, customer.TenantID, customer.Name, pii.address, pii.phone, pii.email,
+		pii.emailIndex, pii.phoneIndex, pii.keyVersion, customer.CreatedBy, customer.UpdatedBy)
 	return err
 }
 
 func (r *customerRepository) Get(id string) (*model.Customer, error) {
 	var customer model.Customer
-	query := `SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by 
-		FROM customer WHERE id = $1`
-	err := r.db.Get(&customer, query, id)
-	return &customer, err
+	query := `SELECT ` + customerColumns + `
+		FROM customer WHERE id = $1 AND tenant_id = $2`
+	if err := r.db.Get(&customer, query, id, r.tenantID); err != nil {
+		return &customer, err
+	}
+	return &customer, r.decrypt(&customer)
+}
+
+// GetByIDs retrieves the customers with the given IDs; IDs that do not exist
+// are left out of the result
+func (r *customerRepository) GetByIDs(ids []string) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+	query := `SELECT ` + customerColumns + `
+		FROM customer WHERE id = ANY($1::uuid[]) AND tenant_id = $2`
+	if err := r.db.Select(&customers, query, pq.Array(ids), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	for _, customer := range customers {
+		if err := r.decrypt(customer); err != nil {
+			return nil, err
+		}
+	}
+	return customers, nil
 }
 
 func (r *customerRepository) Update(id string, customer *model.Customer) error {
-	query := `UPDATE customer SET name = $1, address = $2, phone = $3, email = $4, updated_by = $5, updated_at = now() 
-		WHERE id = $6`
-	_, err := r.db.Exec(query, customer.Name, customer.Address, customer.Phone, customer.Email, "admin", id)
+	pii, err := r.encrypt(id, customer)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE customer SET name = $1, address = $2, phone = $3, email = $4, email_index = $5, phone_index = $6,
+		key_version = $7, updated_by = $8, updated_at = now() WHERE id = $9 AND tenant_id = $10`
+	_, err = r.db.Exec(query, customer.Name, pii.address, pii.phone, pii.email, pii.emailIndex, pii.phoneIndex,
+		pii.keyVersion, customer.UpdatedBy, id, r.tenantID)
 	return err
 }
 
 func (r *customerRepository) Delete(id string) error {
-	_, err := r.db.Exec("DELETE FROM customer WHERE id = $1", id)
+	_, err := r.db.Exec("DELETE FROM customer WHERE id = $1 AND tenant_id = $2", id, r.tenantID)
 	return err
 }
+
+// CreateBatch inserts customers with a single statement and returns the IDs
+// written; rows that duplicate an existing ID or email are skipped
+func (r *customerRepository) CreateBatch(customers []*model.Customer) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(customers))
+	for i, customer := range customers {
+		customer.TenantID = r.tenantID
+		customer.CreatedAt, customer.UpdatedAt = now, now
+		pii, err := r.encrypt(customer.ID, customer)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = []interface{}{customer.ID, customer.TenantID, customer.Name, pii.address, pii.phone, pii.email, pii.emailIndex,
+			pii.phoneIndex, pii.keyVersion, customer.CreatedAt, customer.CreatedBy, customer.UpdatedAt, customer.UpdatedBy}
+	}
+	columns := []string{"id", "tenant_id", "name", "address", "phone", "email", "email_index", "phone_index", "key_version",
+		"created_at", "created_by", "updated_at", "updated_by"}
+	return insertRows(r.db, "customer", columns, rows)
+}
+
+// UpdateBatch updates customers with a single statement and returns the IDs found
+func (r *customerRepository) UpdateBatch(customers []*model.Customer) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(customers))
+	for i, customer := range customers {
+		customer.UpdatedAt = now
+		pii, err := r.encrypt(customer.ID, customer)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = []interface{}{customer.ID, customer.Name, pii.address, pii.phone, pii.email, pii.emailIndex, pii.phoneIndex,
+			pii.keyVersion, customer.UpdatedAt, customer.UpdatedBy}
+	}
+	columns := []string{"id", "name", "address", "phone", "email", "email_index", "phone_index", "key_version", "updated_at", "updated_by"}
+	types := []string{"uuid", "varchar", "text", "text", "text", "text", "text", "int", "timestamptz", "varchar"}
+	return updateRows(r.db, "customer", r.tenantID, columns, types, rows)
+}
+
+// DeleteBatch deletes customers by ID with a single statement and returns the IDs found
+func (r *customerRepository) DeleteBatch(ids []string) ([]string, error) {
+	return deleteRows(r.db, "customer", r.tenantID, ids)
+}
+
+// Reencrypt rewrites up to limit customers of every tenant whose personal
+// fields are not encrypted with the current key, such as after a key was
+// added or encryption was enabled, or that lack a phone blind index, and
+// returns the number rewritten. Run it
+// in a transaction: the rows stay locked until it ends and concurrent runs
+// skip them.
+func (r *customerRepository) Reencrypt(limit int) (int, error) {
+	var customers []*model.Customer
+	query := `SELECT id, address, phone, email FROM customer WHERE key_version <> $1 OR phone_index IS NULL
+		ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED`
+	if err := r.db.Select(&customers, query, r.cipher.KeyVersion(), limit); err != nil {
+		return 0, err
+	}
+
+	for _, customer := range customers {
+		if err := r.decrypt(customer); err != nil {
+			return 0, err
+		}
+		pii, err := r.encrypt(customer.ID, customer)
+		if err != nil {
+			return 0, err
+		}
+		_, err = r.db.Exec(`UPDATE customer SET address = $1, phone = $2, email = $3, email_index = $4, phone_index = $5,
+			key_version = $6 WHERE id = $7`, pii.address, pii.phone, pii.email, pii.emailIndex, pii.phoneIndex, pii.keyVersion,
+			customer.ID)
+		if err != nil {
+			return 0, translateError(err)
+		}
+	}
+	return len(customers), nil
+}
@@ -1,13 +1,20 @@
 package repository
 
 import (
+	"database/sql/driver"
 	"errors"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/keyring"
 	"github.com/GoodsChain/backend/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Helper function to create a new mock database for testing
@@ -23,7 +30,7 @@ func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
 
 func TestNewCustomerRepository(t *testing.T) {
 	db, _ := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	if repo == nil {
 		t.Fatal("Expected repository to be created, got nil")
@@ -32,19 +39,21 @@ func TestNewCustomerRepository(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	db, mock := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	customer := &model.Customer{
-		ID:      "cust123",
-		Name:    "Test Customer",
-		Address: "123 Test St",
-		Phone:   "+1234567890",
-		Email:   "test@example.com",
+		ID:        "cust123",
+		Name:      "Test Customer",
+		Address:   "123 Test St",
+		Phone:     "+1234567890",
+		Email:     "test@example.com",
+		CreatedBy: "jane",
+		UpdatedBy: "jane",
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO customer \\(id, name, address, phone, email, created_by, updated_by\\)").
-			WithArgs(customer.ID, customer.Name, customer.Address, customer.Phone, customer.Email, "admin", "admin").
+		mock.ExpectExec("INSERT INTO customer \\(id, tenant_id, name, address, phone, email, email_index, phone_index, key_version, created_by, updated_by\\)").
+			WithArgs(customer.ID, model.DefaultTenantID, customer.Name, customer.Address, customer.Phone, customer.Email, customer.Email, "1234567890", 0, customer.CreatedBy, customer.UpdatedBy).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repo.Create(customer)
@@ -60,7 +69,7 @@ func TestCreate(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mock.ExpectExec("INSERT INTO customer").
-			WithArgs(customer.ID, customer.Name, customer.Address, customer.Phone, customer.Email, "admin", "admin").
+			WithArgs(customer.ID, model.DefaultTenantID, customer.Name, customer.Address, customer.Phone, customer.Email, customer.Email, "1234567890", 0, customer.CreatedBy, customer.UpdatedBy).
 			WillReturnError(expectedErr)
 
 		err := repo.Create(customer)
@@ -76,7 +85,7 @@ func TestCreate(t *testing.T) {
 
 func TestGet(t *testing.T) {
 	db, mock := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	customerID := "cust123"
 	createdAt := time.Now()
@@ -86,8 +95,8 @@ func TestGet(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"}).
 			AddRow(customerID, "Test Customer", "123 Test St", "+1234567890", "test@example.com", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE id = \\$1").
-			WithArgs(customerID).
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		customer, err := repo.Get(customerID)
@@ -110,8 +119,8 @@ func TestGet(t *testing.T) {
 
 	t.Run("Not Found", func(t *testing.T) {
 		expectedErr := errors.New("no rows in result set")
-		mock.ExpectQuery("SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE id = \\$1").
-			WithArgs(customerID).
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		customer, err := repo.Get(customerID)
@@ -131,20 +140,21 @@ func TestGet(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	db, mock := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	customerID := "cust123"
 	customer := &model.Customer{
-		ID:      customerID,
-		Name:    "Updated Customer",
-		Address: "456 New St",
-		Phone:   "+9876543210",
-		Email:   "updated@example.com",
+		ID:        customerID,
+		Name:      "Updated Customer",
+		Address:   "456 New St",
+		Phone:     "+9876543210",
+		Email:     "updated@example.com",
+		UpdatedBy: "jane",
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("UPDATE customer SET name = \\$1, address = \\$2, phone = \\$3, email = \\$4, updated_by = \\$5, updated_at = now\\(\\) WHERE id = \\$6").
-			WithArgs(customer.Name, customer.Address, customer.Phone, customer.Email, "admin", customerID).
+		mock.ExpectExec("UPDATE customer SET name = \\$1, address = \\$2, phone = \\$3, email = \\$4, email_index = \\$5, phone_index = \\$6, key_version = \\$7, updated_by = \\$8, updated_at = now\\(\\) WHERE id = \\$9 AND tenant_id = \\$10").
+			WithArgs(customer.Name, customer.Address, customer.Phone, customer.Email, customer.Email, "9876543210", 0, customer.UpdatedBy, customerID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Update(customerID, customer)
@@ -160,7 +170,7 @@ func TestUpdate(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mock.ExpectExec("UPDATE customer SET").
-			WithArgs(customer.Name, customer.Address, customer.Phone, customer.Email, "admin", customerID).
+			WithArgs(customer.Name, customer.Address, customer.Phone, customer.Email, customer.Email, "9876543210", 0, customer.UpdatedBy, customerID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Update(customerID, customer)
@@ -176,13 +186,13 @@ func TestUpdate(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	db, mock := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	customerID := "cust123"
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM customer WHERE id = \\$1").
-			WithArgs(customerID).
+		mock.ExpectExec("DELETE FROM customer WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Delete(customerID)
@@ -197,8 +207,8 @@ func TestDelete(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectExec("DELETE FROM customer WHERE id = \\$1").
-			WithArgs(customerID).
+		mock.ExpectExec("DELETE FROM customer WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(customerID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Delete(customerID)
@@ -214,7 +224,7 @@ func TestDelete(t *testing.T) {
 
 func TestGetAll(t *testing.T) {
 	db, mock := newMockDB(t)
-	repo := NewCustomerRepository(db)
+	repo := NewCustomerRepository(db, keyring.Plaintext{})
 
 	createdAt := time.Now()
 	updatedAt := time.Now()
@@ -224,10 +234,11 @@ func TestGetAll(t *testing.T) {
 			AddRow("cust123", "Customer 1", "123 Test St", "+1234567890", "cust1@example.com", createdAt, "admin", updatedAt, "admin").
 			AddRow("cust456", "Customer 2", "456 Test St", "+0987654321", "cust2@example.com", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT \\* FROM customer").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		customers, err := repo.GetAll()
+		customers, err := repo.GetAll(model.CustomerFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -248,10 +259,11 @@ func TestGetAll(t *testing.T) {
 	t.Run("Success With No Results", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"})
 
-		mock.ExpectQuery("SELECT \\* FROM customer").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		customers, err := repo.GetAll()
+		customers, err := repo.GetAll(model.CustomerFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -271,10 +283,11 @@ func TestGetAll(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT \\* FROM customer").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM customer WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
-		customers, err := repo.GetAll()
+		customers, err := repo.GetAll(model.CustomerFilter{})
 		if err != expectedErr {
 			t.Errorf("Expected error %v, got %v", expectedErr, err)
 		}
@@ -288,3 +301,127 @@ func TestGetAll(t *testing.T) {
 		}
 	})
 }
+
+// Encrypted matches values encrypted with key version Version
+type Encrypted struct {
+	Version int
+}
+
+// Match satisfies sqlmock.Argument interface
+func (e Encrypted) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, "enc:v"+strconv.Itoa(e.Version)+":")
+}
+
+func TestCustomerRepository_Encryption(t *testing.T) {
+	k, err := keyring.Generate()
+	require.NoError(t, err)
+	db, mock := newMockDB(t)
+	repo := NewCustomerRepository(db, k)
+	customer := &model.Customer{ID: "cust1", Name: "John", Address: "1 Main St", Phone: "555-123-4567", Email: "john@example.com",
+		CreatedBy: "system", UpdatedBy: "system"}
+	index := k.BlindIndex("john@example.com")
+
+	// Personal fields are written encrypted, together with the blind indexes of
+	// the email and of the digits of the phone
+	mock.ExpectExec("INSERT INTO customer").
+		WithArgs("cust1", model.DefaultTenantID, "John", Encrypted{1}, Encrypted{1}, Encrypted{1}, index, k.BlindIndex("5551234567"), 1,
+			customer.CreatedBy, customer.UpdatedBy).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Create(customer))
+
+	// and read decrypted
+	columns := []string{"id", "name", "address", "phone", "email"}
+	address, _ := k.Encrypt("1 Main St", "customer:cust1:address")
+	email, _ := k.Encrypt("john@example.com", "customer:cust1:email")
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE id = $1 AND tenant_id = $2`)).
+		WithArgs("cust1", model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", address, "", email))
+	got, err := repo.Get("cust1")
+	require.NoError(t, err)
+	assert.Equal(t, "1 Main St", got.Address)
+	assert.Equal(t, "", got.Phone)
+	assert.Equal(t, "john@example.com", got.Email)
+
+	// Emails are looked up by their blind index, ignoring case
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE tenant_id = $1 AND email_index = $2`)).
+		WithArgs(model.DefaultTenantID, index).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", address, "", email))
+	customers, err := repo.GetAll(model.CustomerFilter{Email: "John@Example.com"})
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+	assert.Equal(t, "john@example.com", customers[0].Email)
+
+	// A search that is an email or a phone is matched by its blind index
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE tenant_id = $1 AND email_index = $2 ORDER BY created_at DESC, id`)).
+		WithArgs(model.DefaultTenantID, index).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", address, "", email))
+	customers, err = repo.GetAll(model.CustomerFilter{Query: " john@example.com "})
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE tenant_id = $1 AND phone_index = $2 ORDER BY created_at DESC, id`)).
+		WithArgs(model.DefaultTenantID, k.BlindIndex("5551234567")).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", address, "", email))
+	customers, err = repo.GetAll(model.CustomerFilter{Query: "(555) 123-4567"})
+	require.NoError(t, err)
+	require.Len(t, customers, 1)
+
+	// Values of a key the keyring does not have cannot be read
+	other, err := keyring.Generate()
+	require.NoError(t, err)
+	require.NoError(t, other.AddKey())
+	foreign, _ := other.Encrypt("john@example.com", "customer:cust1:email")
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE id = $1`)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", address, "", foreign))
+	_, err = repo.Get("cust1")
+	assert.ErrorIs(t, err, keyring.ErrUnknownKey)
+
+	// Values are bound to their customer and column, so swapping them fails
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE id = $1`)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust1", "John", email, "", address))
+	_, err = repo.Get("cust1")
+	assert.ErrorIs(t, err, keyring.ErrMalformed)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE id = $1`)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("cust2", "Jane", address, "", email))
+	_, err = repo.Get("cust2")
+	assert.ErrorIs(t, err, keyring.ErrMalformed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerRepository_Reencrypt(t *testing.T) {
+	k, err := keyring.Generate()
+	require.NoError(t, err)
+	address, _ := k.Encrypt("1 Main St", "customer:cust1:address")
+	email, _ := k.Encrypt("john@example.com", "customer:cust1:email")
+	require.NoError(t, k.AddKey())
+
+	db, mock := newMockDB(t)
+	repo := NewCustomerRepository(db, k)
+	update := regexp.QuoteMeta(`UPDATE customer SET address = $1, phone = $2, email = $3, email_index = $4, phone_index = $5, key_version = $6 WHERE id = $7`)
+
+	// Rows of an earlier key, unencrypted rows and rows without a phone blind
+	// index are all rewritten with the primary key
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, address, phone, email FROM customer WHERE key_version <> $1 OR phone_index IS NULL ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(2, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address", "phone", "email"}).
+			AddRow("cust1", address, "", email).
+			AddRow("cust2", "2 High St", "555-765-4321", "Jane@example.com"))
+	mock.ExpectExec(update).
+		WithArgs(Encrypted{2}, "", Encrypted{2}, k.BlindIndex("john@example.com"), k.BlindIndex(""), 2, "cust1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(update).
+		WithArgs(Encrypted{2}, Encrypted{2}, Encrypted{2}, k.BlindIndex("jane@example.com"), k.BlindIndex("5557654321"), 2, "cust2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := repo.Reencrypt(100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	mock.ExpectQuery(`FROM customer WHERE key_version <> \$1`).WithArgs(2, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address", "phone", "email"}))
+	n, err = repo.Reencrypt(100)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
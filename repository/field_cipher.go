@@ -0,0 +1,14 @@
+package repository
+
+// FieldCipher encrypts the personal fields of records at rest and computes
+// blind indexes, which find and keep encrypted values unique by equality
+type FieldCipher interface {
+	// KeyVersion returns the version of the key new values are encrypted
+	// with; 0 when values are stored unencrypted
+	KeyVersion() int
+	// Encrypt and Decrypt bind values to context, which names the record
+	// and field they are stored in, so they cannot be moved to another
+	Encrypt(plaintext, context string) (string, error)
+	Decrypt(value, context string) (string, error)
+	BlindIndex(value string) string
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// conditions accumulates the WHERE clause of a filtered query
+type conditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+// add appends a clause, replacing its "?" placeholder with the next $n
+func (c *conditions) add(clause string, arg interface{}) {
+	c.args = append(c.args, arg)
+	c.clauses = append(c.clauses, strings.Replace(clause, "?", "$"+strconv.Itoa(len(c.args)), 1))
+}
+
+// addContains matches column against a case-insensitive substring
+func (c *conditions) addContains(column, value string) {
+	if value == "" {
+		return
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	c.add(column+" ILIKE ?", "%"+escaped+"%")
+}
+
+// addCreatedRange restricts the created_at column to the range
+func (c *conditions) addCreatedRange(r model.CreatedRange) {
+	if !r.CreatedAfter.IsZero() {
+		c.add("created_at >= ?", r.CreatedAfter)
+	}
+	if !r.CreatedBefore.IsZero() {
+		c.add("created_at < ?", r.CreatedBefore)
+	}
+}
+
+// addSpecs restricts the specs column by filters. Single values are matched
+// by containment, so the GIN index of the column applies.
+func (c *conditions) addSpecs(filters []model.SpecFilter) {
+	for _, f := range filters {
+		// Attribute names come from model.SpecAttributes, never from the request
+		switch {
+		case f.Op == model.SpecEq && len(f.Values) == 1:
+			contains, _ := json.Marshal(map[string]interface{}{f.Attribute: f.Values[0]})
+			c.add("specs @> ?::jsonb", string(contains))
+		case f.Op == model.SpecEq:
+			values := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				values[i] = fmt.Sprint(v)
+			}
+			c.add("specs->>'"+f.Attribute+"' = ANY(?)", pq.StringArray(values))
+		case f.Op == model.SpecGte:
+			c.add("(specs->>'"+f.Attribute+"')::numeric >= ?", f.Values[0])
+		case f.Op == model.SpecLte:
+			c.add("(specs->>'"+f.Attribute+"')::numeric <= ?", f.Values[0])
+		}
+	}
+}
+
+// searchConfig is the text search configuration of the search_vector columns,
+// which ignores case and diacritics
+const searchConfig = "unaccent_simple"
+
+// searchTSQuery builds a tsquery matching records with every term of q, where
+// the last letters of each term may be missing, or "" when q has no terms
+func searchTSQuery(q string) string {
+	terms := model.SearchTerms(q)
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// addSearch matches the search_vector and search_text columns of a table
+// against q, either by its words or by trigram similarity to tolerate typos.
+// It returns the expression to rank the matches by, or "" when q has no terms.
+func (c *conditions) addSearch(q string) string {
+	tsquery := searchTSQuery(q)
+	if tsquery == "" {
+		return ""
+	}
+	c.args = append(c.args, tsquery, q)
+	query := "to_tsquery('" + searchConfig + "', $" + strconv.Itoa(len(c.args)-1) + ")"
+	text := "search_unaccent(lower($" + strconv.Itoa(len(c.args)) + "))"
+	c.clauses = append(c.clauses, "(search_vector @@ "+query+" OR "+text+" <% search_text)")
+	return "ts_rank(search_vector, " + query + ") + word_similarity(" + text + ", search_text)"
+}
+
+// orderBy returns the ORDER BY clause of a list: the best search matches
+// first when rank is set, then the newest records
+func orderBy(rank string) string {
+	if rank != "" {
+		return " ORDER BY " + rank + " DESC, created_at DESC, id"
+	}
+	return " ORDER BY created_at DESC, id"
+}
+
+// selectList returns the column list of a query for record: the columns of
+// fields, or all when fields selects every field
+func selectList(fields model.Fields, record interface{}, all string) string {
+	if columns := fields.Columns(record); len(columns) > 0 {
+		return strings.Join(columns, ", ")
+	}
+	return all
+}
+
+// where returns the WHERE clause, or an empty string without conditions
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+// streamRows runs query and calls fn for each row as it is read from the
+// cursor, so memory use does not grow with the size of the result
+func streamRows(db DBTX, query string, args []interface{}, fn func(rows *sqlx.Rows) error) error {
+	rows, err := db.Queryx(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/keyring"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarListQuery(t *testing.T) {
+	after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	query, args := carListQuery("tenant1", model.CarFilter{
+		Name:         "50%_off",
+		SupplierID:   "supp1",
+		MinPrice:     10000,
+		MaxPrice:     50000,
+		CreatedRange: model.CreatedRange{CreatedAfter: after},
+	})
+
+	assert.Equal(t, `SELECT id, tenant_id, name, supp_id, price, trim_id, specs, created_at, created_by, updated_at, updated_by FROM car`+
+		` WHERE tenant_id = $1 AND name ILIKE $2 AND supp_id = $3 AND price >= $4 AND price <= $5 AND created_at >= $6`+
+		` ORDER BY created_at DESC, id`, query)
+	assert.Equal(t, []interface{}{"tenant1", `%50\%\_off%`, "supp1", 10000, 50000, after}, args)
+
+	// Without filters the query is still limited to the tenant
+	query, args = carListQuery("tenant1", model.CarFilter{})
+	assert.Contains(t, query, "WHERE tenant_id = $1 ORDER BY")
+	assert.Equal(t, []interface{}{"tenant1"}, args)
+
+	// A sparse fieldset narrows the columns to the db names of the fields
+	query, _ = carListQuery("tenant1", model.CarFilter{Fields: model.Fields{"id", "name", "supplier_id"}})
+	assert.Equal(t, `SELECT id, name, supp_id FROM car WHERE tenant_id = $1 ORDER BY created_at DESC, id`, query)
+
+	// Catalog and specification filters
+	query, args = carListQuery("tenant1", model.CarFilter{
+		BrandID: "brand1",
+		ModelID: "model1",
+		TrimID:  "trim1",
+		Specs: []model.SpecFilter{
+			{Attribute: "fuel", Op: model.SpecEq, Values: []interface{}{"hybrid"}},
+			{Attribute: "body_type", Op: model.SpecEq, Values: []interface{}{"suv", "crossover"}},
+			{Attribute: "seats", Op: model.SpecGte, Values: []interface{}{float64(7)}},
+			{Attribute: "model_year", Op: model.SpecLte, Values: []interface{}{float64(2024)}},
+		},
+	})
+	assert.Contains(t, query, ` WHERE tenant_id = $1`+
+		` AND trim_id IN (SELECT t.id FROM car_trim t JOIN car_model m ON m.id = t.model_id WHERE m.brand_id = $2)`+
+		` AND trim_id IN (SELECT id FROM car_trim WHERE model_id = $3) AND trim_id = $4`+
+		` AND specs @> $5::jsonb AND specs->>'body_type' = ANY($6)`+
+		` AND (specs->>'seats')::numeric >= $7 AND (specs->>'model_year')::numeric <= $8 ORDER BY`)
+	assert.Equal(t, []interface{}{"tenant1", "brand1", "model1", "trim1", `{"fuel":"hybrid"}`,
+		pq.StringArray{"suv", "crossover"}, float64(7), float64(2024)}, args)
+}
+
+func TestCarRepository_StreamCars(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	repo := NewCarRepository(sqlx.NewDb(mockDB, "sqlmock"))
+	columns := []string{"id", "name", "supp_id", "price", "created_at", "created_by", "updated_at", "updated_by"}
+	query := regexp.QuoteMeta(`FROM car WHERE tenant_id = $1 AND supp_id = $2 ORDER BY created_at DESC, id`)
+
+	mock.ExpectQuery(query).WithArgs(model.DefaultTenantID, "supp1").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("car1", "Camry", "supp1", 25000, time.Now(), "admin", time.Now(), "admin").
+		AddRow("car2", "Corolla", "supp1", 20000, time.Now(), "admin", time.Now(), "admin"))
+
+	var names []string
+	err = repo.StreamCars(model.CarFilter{SupplierID: "supp1"}, func(car *model.Car) error {
+		names = append(names, car.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Camry", "Corolla"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// An error from the callback stops the iteration
+	mock.ExpectQuery(query).WithArgs(model.DefaultTenantID, "supp1").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("car1", "Camry", "supp1", 25000, time.Now(), "admin", time.Now(), "admin").
+		AddRow("car2", "Corolla", "supp1", 20000, time.Now(), "admin", time.Now(), "admin"))
+	calls := 0
+	err = repo.StreamCars(model.CarFilter{SupplierID: "supp1"}, func(car *model.Car) error {
+		calls++
+		return errors.New("client went away")
+	})
+	assert.EqualError(t, err, "client went away")
+	assert.Equal(t, 1, calls)
+
+	// Test DB error
+	mock.ExpectQuery(query).WillReturnError(errors.New("db down"))
+	err = repo.StreamCars(model.CarFilter{SupplierID: "supp1"}, func(car *model.Car) error { return nil })
+	assert.EqualError(t, err, "db down")
+}
+
+func TestCustomerRepository_Stream(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	repo := NewCustomerRepository(sqlx.NewDb(mockDB, "sqlmock"), keyring.Plaintext{})
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM customer WHERE tenant_id = $1 AND email_index = $2 AND created_at < $3`)).
+		WithArgs(model.DefaultTenantID, "john@example.com", before).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"}).
+			AddRow("cust1", "John", "1 Main St", "", "john@example.com", time.Now(), "admin", time.Now(), "admin"))
+
+	var ids []string
+	err = repo.Stream(model.CustomerFilter{Email: "John@Example.com", CreatedRange: model.CreatedRange{CreatedBefore: before}},
+		func(customer *model.Customer) error {
+			ids = append(ids, customer.ID)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cust1"}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCustomerListQuery_Search(t *testing.T) {
+	query, args := customerListQuery("tenant1", model.CustomerFilter{Query: "Hà Nội, 09", Email: "a@example.com"}, keyring.Plaintext{})
+
+	assert.Equal(t, `SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by
+		FROM customer WHERE tenant_id = $1`+
+		` AND (search_vector @@ to_tsquery('unaccent_simple', $2) OR search_unaccent(lower($3)) <% search_text)`+
+		` AND email_index = $4`+
+		` ORDER BY ts_rank(search_vector, to_tsquery('unaccent_simple', $2)) + word_similarity(search_unaccent(lower($3)), search_text) DESC,`+
+		` created_at DESC, id`, query)
+	assert.Equal(t, []interface{}{"tenant1", "Hà:* & Nội:* & 09:*", "Hà Nội, 09", "a@example.com"}, args)
+
+	// A query of punctuation alone does not filter
+	query, args = customerListQuery("tenant1", model.CustomerFilter{Query: " ?! "}, keyring.Plaintext{})
+	assert.NotContains(t, query, "search_vector")
+	assert.Equal(t, []interface{}{"tenant1"}, args)
+
+	// Sparse fieldsets still read the ID the encrypted fields are bound to
+	query, _ = customerListQuery("tenant1", model.CustomerFilter{Fields: model.Fields{"email"}}, keyring.Plaintext{})
+	assert.Contains(t, query, "SELECT email, id\n")
+}
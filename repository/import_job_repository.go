@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ImportJobRepository defines the interface for import job data operations
+type ImportJobRepository interface {
+	Create(job *model.ImportJob) error
+	GetByID(id string) (*model.ImportJob, error)
+	UpdateProgress(id string, processedRows int) error
+	UpdateStatus(id string, status string, errMessage string) error
+	WithTx(tx *sqlx.Tx) ImportJobRepository
+	WithTenant(tenantID string) ImportJobRepository
+}
+
+type importJobRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+// NewImportJobRepository creates a new instance of ImportJobRepository
+func NewImportJobRepository(db *sqlx.DB) ImportJobRepository {
+	return &importJobRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *importJobRepository) WithTx(tx *sqlx.Tx) ImportJobRepository {
+	return &importJobRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// import jobs of tenantID
+func (r *importJobRepository) WithTenant(tenantID string) ImportJobRepository {
+	return &importJobRepository{db: r.db, tenantID: tenantID}
+}
+
+// Create stores a new import job including the uploaded file
+func (r *importJobRepository) Create(job *model.ImportJob) error {
+	job.TenantID = r.tenantID
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = time.Now()
+
+	query := `INSERT INTO import_job (id, tenant_id, resource, format, sheet, mapping, payload, status, total_rows,
+              processed_rows, chunk_size, error, created_at, created_by, updated_at, updated_by)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+	_, err := r.db.Exec(query, job.ID, job.TenantID, job.Resource, job.Format, job.Sheet, job.Mapping, job.Payload, job.Status,
+		job.TotalRows, job.ProcessedRows, job.ChunkSize, job.Error, job.CreatedAt, job.CreatedBy, job.UpdatedAt, job.UpdatedBy)
+	return err
+}
+
+// GetByID retrieves an import job by its ID
+func (r *importJobRepository) GetByID(id string) (*model.ImportJob, error) {
+	var job model.ImportJob
+	query := `SELECT id, tenant_id, resource, format, sheet, mapping, payload, status, total_rows, processed_rows,
+	          chunk_size, error, created_at, created_by, updated_at, updated_by FROM import_job WHERE id = $1 AND tenant_id = $2`
+	err := r.db.Get(&job, query, id, r.tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateProgress records the number of rows committed so far
+func (r *importJobRepository) UpdateProgress(id string, processedRows int) error {
+	query := `UPDATE import_job SET processed_rows = $1, updated_at = $2 WHERE id = $3 AND tenant_id = $4`
+	return r.execOne(query, processedRows, time.Now(), id, r.tenantID)
+}
+
+// UpdateStatus sets the job status and the error that stopped it, if any
+func (r *importJobRepository) UpdateStatus(id string, status string, errMessage string) error {
+	query := `UPDATE import_job SET status = $1, error = $2, updated_at = $3 WHERE id = $4 AND tenant_id = $5`
+	return r.execOne(query, status, errMessage, time.Now(), id, r.tenantID)
+}
+
+func (r *importJobRepository) execOne(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockImportJobRepo(t *testing.T) (ImportJobRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewImportJobRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+func TestImportJobRepository_Create(t *testing.T) {
+	repo, mock := newMockImportJobRepo(t)
+	repo = repo.WithTenant("tenant1")
+	job := &model.ImportJob{
+		ID:        "job1",
+		Resource:  "cars",
+		Format:    "csv",
+		Mapping:   "{}",
+		Payload:   []byte("name,price"),
+		Status:    model.ImportStatusRunning,
+		TotalRows: 1000,
+		ChunkSize: 500,
+		CreatedBy: "import",
+		UpdatedBy: "import",
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO import_job`)).
+		WithArgs(job.ID, "tenant1", job.Resource, job.Format, job.Sheet, job.Mapping, job.Payload, job.Status, job.TotalRows,
+			job.ProcessedRows, job.ChunkSize, job.Error, AnyTime{}, job.CreatedBy, AnyTime{}, job.UpdatedBy).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.Create(job)
+	assert.NoError(t, err)
+	assert.False(t, job.CreatedAt.IsZero())
+	assert.Equal(t, "tenant1", job.TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_GetByID(t *testing.T) {
+	repo, mock := newMockImportJobRepo(t)
+	columns := []string{"id", "resource", "format", "sheet", "mapping", "payload", "status", "total_rows", "processed_rows",
+		"chunk_size", "error", "created_at", "created_by", "updated_at", "updated_by"}
+	query := regexp.QuoteMeta(`FROM import_job WHERE id = $1 AND tenant_id = $2`)
+
+	mock.ExpectQuery(query).WithArgs("job1", model.DefaultTenantID).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("job1", "cars", "csv", "", "{}", []byte("name"), model.ImportStatusFailed, 1000, 500, 500,
+			"row 512: duplicate key", time.Now(), "import", time.Now(), "import"))
+
+	job, err := repo.GetByID("job1")
+	assert.NoError(t, err)
+	assert.Equal(t, 500, job.ProcessedRows)
+	assert.Equal(t, []byte("name"), job.Payload)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test Not Found
+	mock.ExpectQuery(query).WithArgs("missing", model.DefaultTenantID).WillReturnError(sql.ErrNoRows)
+	job, err = repo.GetByID("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, job)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_Update(t *testing.T) {
+	repo, mock := newMockImportJobRepo(t)
+
+	progress := regexp.QuoteMeta(`UPDATE import_job SET processed_rows = $1, updated_at = $2 WHERE id = $3 AND tenant_id = $4`)
+	mock.ExpectExec(progress).WithArgs(500, AnyTime{}, "job1", model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UpdateProgress("job1", 500))
+
+	status := regexp.QuoteMeta(`UPDATE import_job SET status = $1, error = $2, updated_at = $3 WHERE id = $4 AND tenant_id = $5`)
+	mock.ExpectExec(status).WithArgs(model.ImportStatusFailed, "boom", AnyTime{}, "job1", model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UpdateStatus("job1", model.ImportStatusFailed, "boom"))
+
+	// Test Not Found
+	mock.ExpectExec(status).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.UpdateStatus("missing", model.ImportStatusRunning, ""), ErrNotFound)
+
+	// Test DB error
+	mock.ExpectExec(progress).WillReturnError(errors.New("db down"))
+	assert.EqualError(t, repo.UpdateProgress("job1", 1000), "db down")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
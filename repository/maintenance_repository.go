@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// MaintenanceRepository defines the interface for the maintenance records of owned vehicles
+type MaintenanceRepository interface {
+	CreateRecord(record *model.MaintenanceRecord) error
+	GetRecords(customerCarID string) ([]model.MaintenanceRecord, error)
+	GetCustomerRecords(customerID string) ([]model.MaintenanceRecord, error)
+	EraseCustomerRecords(customerID string) error
+	LockCustomerCar(customerCarID string) error
+	WithTx(tx *sqlx.Tx) MaintenanceRepository
+	WithTenant(tenantID string) MaintenanceRepository
+}
+
+type maintenanceRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+const maintenanceColumns = "id, tenant_id, customer_car_id, kind, service_date, odometer, work_items, cost, workshop, notes, created_at, created_by"
+
+// NewMaintenanceRepository creates a new instance of MaintenanceRepository
+func NewMaintenanceRepository(db *sqlx.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *maintenanceRepository) WithTx(tx *sqlx.Tx) MaintenanceRepository {
+	return &maintenanceRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// maintenance records of tenantID
+func (r *maintenanceRepository) WithTenant(tenantID string) MaintenanceRepository {
+	return &maintenanceRepository{db: r.db, tenantID: tenantID}
+}
+
+// CreateRecord adds a maintenance record of a customer_car relationship
+func (r *maintenanceRepository) CreateRecord(record *model.MaintenanceRecord) error {
+	record.TenantID = r.tenantID
+	record.CreatedAt = time.Now()
+
+	query := `INSERT INTO maintenance_record (` + maintenanceColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err := r.db.Exec(query, record.ID, record.TenantID, record.CustomerCarID, record.Kind, record.ServiceDate,
+		record.Odometer, record.WorkItems, record.Cost, record.Workshop, record.Notes, record.CreatedAt, record.CreatedBy)
+	return translateError(err)
+}
+
+// GetRecords retrieves the maintenance records of a customer_car
+// relationship in mileage order: by service date, then odometer reading
+func (r *maintenanceRepository) GetRecords(customerCarID string) ([]model.MaintenanceRecord, error) {
+	records := []model.MaintenanceRecord{}
+	query := `SELECT ` + maintenanceColumns + ` FROM maintenance_record
+		WHERE customer_car_id = $1 AND tenant_id = $2 ORDER BY service_date, odometer, created_at`
+	if err := r.db.Select(&records, query, customerCarID, r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return records, nil
+}
+
+// GetCustomerRecords retrieves the maintenance records of every car linked to
+// a customer, in mileage order per relationship
+func (r *maintenanceRepository) GetCustomerRecords(customerID string) ([]model.MaintenanceRecord, error) {
+	records := []model.MaintenanceRecord{}
+	query := `SELECT ` + maintenanceColumns + ` FROM maintenance_record
+		WHERE tenant_id = $2 AND customer_car_id IN (SELECT id FROM customer_car WHERE cust_id = $1 AND tenant_id = $2)
+		ORDER BY customer_car_id, service_date, odometer, created_at`
+	if err := r.db.Select(&records, query, customerID, r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return records, nil
+}
+
+// EraseCustomerRecords clears the workshop and notes of the maintenance
+// records of every car linked to a customer. The kind, dates, odometer
+// readings and work items describe the vehicle and are kept.
+func (r *maintenanceRepository) EraseCustomerRecords(customerID string) error {
+	query := `UPDATE maintenance_record SET workshop = '', notes = ''
+		WHERE tenant_id = $2 AND customer_car_id IN (SELECT id FROM customer_car WHERE cust_id = $1 AND tenant_id = $2)`
+	_, err := r.db.Exec(query, customerID, r.tenantID)
+	return translateError(err)
+}
+
+// LockCustomerCar locks a customer_car relationship until the transaction
+// ends, so its records are added one at a time; it returns ErrNotFound when
+// the relationship does not exist
+func (r *maintenanceRepository) LockCustomerCar(customerCarID string) error {
+	var id string
+	query := `SELECT id FROM customer_car WHERE id = $1 AND tenant_id = $2 FOR UPDATE`
+	if err := r.db.Get(&id, query, customerCarID, r.tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return translateError(err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockMaintenanceRepo(t *testing.T) (MaintenanceRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return NewMaintenanceRepository(sqlx.NewDb(mockDB, "sqlmock")).WithTenant("tenant1"), mock
+}
+
+func TestMaintenanceRepository_CreateRecord(t *testing.T) {
+	repo, mock := newMockMaintenanceRepo(t)
+	query := regexp.QuoteMeta(`INSERT INTO maintenance_record (` + maintenanceColumns + `)`)
+	date := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(query).
+		WithArgs("rec1", "tenant1", "cc1", model.MaintenanceService, date, 42500,
+			[]byte(`[{"description":"Oil change","cost":8500}]`), 8500, "Downtown", "", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	record := &model.MaintenanceRecord{ID: "rec1", CustomerCarID: "cc1", Kind: model.MaintenanceService, ServiceDate: date,
+		Odometer: 42500, WorkItems: model.WorkItems{{Description: "Oil change", Cost: 8500}}, Cost: 8500, Workshop: "Downtown", CreatedBy: "admin"}
+	require.NoError(t, repo.CreateRecord(record))
+	assert.Equal(t, "tenant1", record.TenantID)
+
+	// Relationships of other tenants violate the foreign key
+	mock.ExpectExec(query).WillReturnError(&pq.Error{Code: "23503"})
+	assert.ErrorIs(t, repo.CreateRecord(&model.MaintenanceRecord{ID: "rec2", CustomerCarID: "other"}), ErrReference)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintenanceRepository_GetRecords(t *testing.T) {
+	repo, mock := newMockMaintenanceRepo(t)
+	columns := []string{"id", "customer_car_id", "kind", "service_date", "odometer", "work_items", "cost"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM maintenance_record
+		WHERE customer_car_id = $1 AND tenant_id = $2 ORDER BY service_date, odometer, created_at`)).
+		WithArgs("cc1", "tenant1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("rec1", "cc1", "service", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), 42500, []byte(`[{"description":"Oil change","cost":8500}]`), 8500).
+			AddRow("rec2", "cc1", "repair", time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), 48200, []byte(`[]`), 0))
+	records, err := repo.GetRecords("cc1")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, model.WorkItems{{Description: "Oil change", Cost: 8500}}, records[0].WorkItems)
+	assert.Equal(t, 48200, records[1].Odometer)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintenanceRepository_GetCustomerRecords(t *testing.T) {
+	repo, mock := newMockMaintenanceRepo(t)
+	columns := []string{"id", "customer_car_id", "kind", "workshop", "notes"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM maintenance_record
+		WHERE tenant_id = $2 AND customer_car_id IN (SELECT id FROM customer_car WHERE cust_id = $1 AND tenant_id = $2)`)).
+		WithArgs("cust1", "tenant1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("rec1", "cc1", "repair", "Downtown", "Brakes squeak"))
+	records, err := repo.GetCustomerRecords("cust1")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "Brakes squeak", records[0].Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintenanceRepository_EraseCustomerRecords(t *testing.T) {
+	repo, mock := newMockMaintenanceRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE maintenance_record SET workshop = '', notes = ''
+		WHERE tenant_id = $2 AND customer_car_id IN (SELECT id FROM customer_car WHERE cust_id = $1 AND tenant_id = $2)`)).
+		WithArgs("cust1", "tenant1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	require.NoError(t, repo.EraseCustomerRecords("cust1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintenanceRepository_LockCustomerCar(t *testing.T) {
+	repo, mock := newMockMaintenanceRepo(t)
+	query := regexp.QuoteMeta(`SELECT id FROM customer_car WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)
+
+	mock.ExpectQuery(query).WithArgs("cc1", "tenant1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cc1"))
+	assert.NoError(t, repo.LockCustomerCar("cc1"))
+
+	mock.ExpectQuery(query).WithArgs("missing", "tenant1").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	assert.ErrorIs(t, repo.LockCustomerCar("missing"), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
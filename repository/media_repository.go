@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// MediaRepository defines the interface for the metadata of car media
+type MediaRepository interface {
+	CreateMedia(media *model.Media) error
+	GetMedia(id string) (*model.Media, error)
+	GetMediaByCarID(carID string) ([]model.Media, error)
+	DeleteMedia(carID, id string) error
+	GetOrphanedFiles(limit int) ([]string, error)
+	DeleteOrphanedFiles(keys []string) error
+	WithTx(tx *sqlx.Tx) MediaRepository
+	WithTenant(tenantID string) MediaRepository
+}
+
+type mediaRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+const mediaColumns = "id, tenant_id, car_id, kind, filename, content_type, size, width, height, checksum, storage_key, thumbnail_key, created_at, created_by"
+
+// NewMediaRepository creates a new instance of MediaRepository
+func NewMediaRepository(db *sqlx.DB) MediaRepository {
+	return &mediaRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *mediaRepository) WithTx(tx *sqlx.Tx) MediaRepository {
+	return &mediaRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// media of tenantID
+func (r *mediaRepository) WithTenant(tenantID string) MediaRepository {
+	return &mediaRepository{db: r.db, tenantID: tenantID}
+}
+
+// CreateMedia adds the metadata of a media file; it returns ErrNotFound when
+// the car does not exist
+func (r *mediaRepository) CreateMedia(media *model.Media) error {
+	media.TenantID = r.tenantID
+	media.CreatedAt = time.Now()
+
+	query := `INSERT INTO car_media (` + mediaColumns + `)
+		SELECT $1::uuid, $2::uuid, $3::uuid, $4, $5, $6, $7::bigint, $8::integer, $9::integer, $10, $11, $12, $13::timestamptz, $14
+		WHERE EXISTS (SELECT 1 FROM car WHERE id = $3 AND tenant_id = $2)`
+	result, err := r.db.Exec(query, media.ID, media.TenantID, media.CarID, media.Kind, media.Filename, media.ContentType,
+		media.Size, media.Width, media.Height, media.Checksum, media.StorageKey, media.ThumbnailKey, media.CreatedAt, media.CreatedBy)
+	return insertedOne(result, err)
+}
+
+// GetMedia retrieves the metadata of a media file by its ID
+func (r *mediaRepository) GetMedia(id string) (*model.Media, error) {
+	var media model.Media
+	query := `SELECT ` + mediaColumns + ` FROM car_media WHERE id = $1 AND tenant_id = $2`
+	if err := r.db.Get(&media, query, id, r.tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, translateError(err)
+	}
+	return &media, nil
+}
+
+// GetMediaByCarID retrieves the media of a car, oldest first
+func (r *mediaRepository) GetMediaByCarID(carID string) ([]model.Media, error) {
+	media := []model.Media{}
+	query := `SELECT ` + mediaColumns + ` FROM car_media WHERE car_id = $1 AND tenant_id = $2 ORDER BY created_at, id`
+	if err := r.db.Select(&media, query, carID, r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return media, nil
+}
+
+// DeleteMedia removes the metadata of a media file of a car
+func (r *mediaRepository) DeleteMedia(carID, id string) error {
+	query := `DELETE FROM car_media WHERE id = $1 AND car_id = $2 AND tenant_id = $3`
+	result, err := r.db.Exec(query, id, carID, r.tenantID)
+	if err != nil {
+		return translateError(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetOrphanedFiles retrieves up to limit storage keys of the files of deleted
+// media of every tenant, oldest first
+func (r *mediaRepository) GetOrphanedFiles(limit int) ([]string, error) {
+	keys := []string{}
+	query := `SELECT storage_key FROM media_orphan ORDER BY deleted_at, storage_key LIMIT $1`
+	if err := r.db.Select(&keys, query, limit); err != nil {
+		return nil, translateError(err)
+	}
+	return keys, nil
+}
+
+// DeleteOrphanedFiles forgets the storage keys of files that were removed
+func (r *mediaRepository) DeleteOrphanedFiles(keys []string) error {
+	_, err := r.db.Exec(`DELETE FROM media_orphan WHERE storage_key = ANY($1)`, pq.Array(keys))
+	return translateError(err)
+}
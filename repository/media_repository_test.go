@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockMediaRepo(t *testing.T) (MediaRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return NewMediaRepository(sqlx.NewDb(mockDB, "sqlmock")).WithTenant("tenant1"), mock
+}
+
+func TestMediaRepository_CreateMedia(t *testing.T) {
+	repo, mock := newMockMediaRepo(t)
+	query := regexp.QuoteMeta(`INSERT INTO car_media (`+mediaColumns+`)`) + `.*` +
+		regexp.QuoteMeta(`WHERE EXISTS (SELECT 1 FROM car WHERE id = $3 AND tenant_id = $2)`)
+	width, height, thumb := 640, 480, "tenant1/cars/car1/media1-thumb.jpg"
+
+	mock.ExpectExec(query).
+		WithArgs("media1", "tenant1", "car1", model.MediaImage, "front.jpg", "image/jpeg", int64(2048), &width, &height,
+			"abc", "tenant1/cars/car1/media1", &thumb, AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	media := &model.Media{ID: "media1", CarID: "car1", Kind: model.MediaImage, Filename: "front.jpg", ContentType: "image/jpeg",
+		Size: 2048, Width: &width, Height: &height, Checksum: "abc", StorageKey: "tenant1/cars/car1/media1", ThumbnailKey: &thumb, CreatedBy: "admin"}
+	require.NoError(t, repo.CreateMedia(media))
+	assert.Equal(t, "tenant1", media.TenantID)
+
+	// Cars of other tenants are not found
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.CreateMedia(&model.Media{ID: "media2", CarID: "other"}), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaRepository_Get(t *testing.T) {
+	repo, mock := newMockMediaRepo(t)
+	columns := []string{"id", "car_id", "kind", "filename", "storage_key", "thumbnail_key", "width", "created_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT `+mediaColumns+` FROM car_media WHERE id = $1 AND tenant_id = $2`)).
+		WithArgs("media1", "tenant1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("media1", "car1", "document", "brochure.pdf", "k1", nil, nil, time.Now()))
+	media, err := repo.GetMedia("media1")
+	require.NoError(t, err)
+	assert.Equal(t, "brochure.pdf", media.Filename)
+	assert.Nil(t, media.ThumbnailKey)
+	assert.Nil(t, media.Width)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car_media WHERE id = $1`)).WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetMedia("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM car_media WHERE car_id = $1 AND tenant_id = $2 ORDER BY created_at, id`)).
+		WithArgs("car1", "tenant1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("media1", "car1", "image", "front.jpg", "k1", "k1-thumb", 640, time.Now()).
+			AddRow("media2", "car1", "document", "brochure.pdf", "k2", nil, nil, time.Now()))
+	all, err := repo.GetMediaByCarID("car1")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "k1-thumb", *all[0].ThumbnailKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaRepository_DeleteMedia(t *testing.T) {
+	repo, mock := newMockMediaRepo(t)
+	query := regexp.QuoteMeta(`DELETE FROM car_media WHERE id = $1 AND car_id = $2 AND tenant_id = $3`)
+
+	mock.ExpectExec(query).WithArgs("media1", "car1", "tenant1").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DeleteMedia("car1", "media1"))
+
+	mock.ExpectExec(query).WithArgs("media1", "car2", "tenant1").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.DeleteMedia("car2", "media1"), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaRepository_OrphanedFiles(t *testing.T) {
+	repo, mock := newMockMediaRepo(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT storage_key FROM media_orphan ORDER BY deleted_at, storage_key LIMIT $1`)).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("k1").AddRow("k1-thumb"))
+	keys, err := repo.GetOrphanedFiles(100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"k1", "k1-thumb"}, keys)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM media_orphan WHERE storage_key = ANY($1)`)).
+		WithArgs(pq.Array([]string{"k1", "k1-thumb"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.NoError(t, repo.DeleteOrphanedFiles(keys))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
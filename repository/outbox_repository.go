@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// OutboxRepository defines the interface for the transactional event outbox.
+// Events must be appended with a repository bound to the transaction of the
+// change they describe, so they are committed, or rolled back, together, and
+// to the tenant of the change. Events are read across tenants.
+type OutboxRepository interface {
+	Append(events ...*model.Event) error
+	ClaimUndispatched(limit int) ([]model.Event, error)
+	MarkDispatched(ids []string) error
+	GetPosition(seq int64) (model.EventPosition, error)
+	GetLatestPosition() (model.EventPosition, error)
+	GetEventsAfter(pos model.EventPosition, tenantID string, types []string, limit int) ([]model.Event, error)
+	TryLockPublisher() (bool, error)
+	GetUnpublished(limit int) ([]model.Event, error)
+	MarkPublished(ids []string) error
+	GetCustomerEvents(customerID string) ([]model.Event, error)
+	RedactEvents(aggregateType, aggregateID string, types []string, redacted json.RawMessage) error
+	WithTx(tx *sqlx.Tx) OutboxRepository
+	WithTenant(tenantID string) OutboxRepository
+}
+
+type outboxRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository
+func NewOutboxRepository(db *sqlx.DB) OutboxRepository {
+	return &outboxRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *outboxRepository) WithTx(tx *sqlx.Tx) OutboxRepository {
+	return &outboxRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that appends the events of tenantID
+func (r *outboxRepository) WithTenant(tenantID string) OutboxRepository {
+	return &outboxRepository{db: r.db, tenantID: tenantID}
+}
+
+// Append stores events with one multi-row INSERT
+func (r *outboxRepository) Append(events ...*model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO outbox_event (id, tenant_id, event_type, aggregate_type, aggregate_id, payload, created_at) VALUES ")
+	args := make([]interface{}, 0, len(events)*7)
+	now := time.Now()
+	for i, e := range events {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = now
+		}
+		e.TenantID = r.tenantID
+		args = placeholders(&b, args, []interface{}{e.ID, e.TenantID, e.Type, e.AggregateType, e.AggregateID, []byte(e.Data), e.CreatedAt}, nil)
+	}
+	_, err := r.db.Exec(b.String(), args...)
+	return err
+}
+
+// ClaimUndispatched returns the oldest events not yet dispatched, in commit
+// order, and locks them until the transaction ends. Events locked by another
+// dispatcher are skipped. Must run in a transaction.
+func (r *outboxRepository) ClaimUndispatched(limit int) ([]model.Event, error) {
+	events := []model.Event{}
+	query := `SELECT seq, id, tenant_id, event_type, aggregate_type, aggregate_id, payload, created_at FROM outbox_event
+	          WHERE dispatched_at IS NULL ORDER BY seq LIMIT $1 FOR UPDATE SKIP LOCKED`
+	if err := r.db.Select(&events, query, limit); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkDispatched records that the events have been handed to the dispatcher
+func (r *outboxRepository) MarkDispatched(ids []string) error {
+	query := `UPDATE outbox_event SET dispatched_at = $1 WHERE id = ANY($2::uuid[])`
+	_, err := r.db.Exec(query, time.Now(), pq.Array(ids))
+	return err
+}
+
+// streamColumns are the columns read by the event stream queries
+const streamColumns = `seq, txid::text::bigint AS txid, id, tenant_id, event_type, aggregate_type, aggregate_id, payload, created_at`
+
+// stableCondition limits the stream to events whose transaction is older than
+// every running one. Sequence numbers are taken at insert time, so a running
+// transaction may still commit an event ordered before those already visible.
+const stableCondition = `txid < pg_snapshot_xmin(pg_current_snapshot())`
+
+// GetPosition returns the stream position of the event with sequence seq
+func (r *outboxRepository) GetPosition(seq int64) (model.EventPosition, error) {
+	var pos model.EventPosition
+	query := `SELECT txid::text::bigint, seq FROM outbox_event WHERE seq = $1`
+	if err := r.db.QueryRowx(query, seq).Scan(&pos.TxID, &pos.Seq); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pos, ErrNotFound
+		}
+		return pos, err
+	}
+	return pos, nil
+}
+
+// GetLatestPosition returns the position of the last event that can be
+// streamed, or the zero position when there is none
+func (r *outboxRepository) GetLatestPosition() (model.EventPosition, error) {
+	var pos model.EventPosition
+	query := `SELECT txid::text::bigint, seq FROM outbox_event WHERE ` + stableCondition + `
+	          ORDER BY txid DESC, seq DESC LIMIT 1`
+	if err := r.db.QueryRowx(query).Scan(&pos.TxID, &pos.Seq); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return pos, err
+	}
+	return pos, nil
+}
+
+// GetEventsAfter returns up to limit events after pos in stream order. Only
+// events of tenantID are returned unless it is empty, and only events of the
+// given types unless types is empty.
+func (r *outboxRepository) GetEventsAfter(pos model.EventPosition, tenantID string, types []string, limit int) ([]model.Event, error) {
+	events := []model.Event{}
+	query := `SELECT ` + streamColumns + ` FROM outbox_event
+	          WHERE (txid, seq) > ($1::text::xid8, $2) AND ` + stableCondition + `
+	          AND (COALESCE(cardinality($3::text[]), 0) = 0 OR event_type = ANY($3))
+	          AND tenant_id = COALESCE(NULLIF($5, '')::uuid, tenant_id)
+	          ORDER BY txid, seq LIMIT $4`
+	if err := r.db.Select(&events, query, pos.TxID, pos.Seq, pq.Array(types), limit, tenantID); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// publisherLockKey identifies the advisory lock held by the event relay
+const publisherLockKey = 0x6f7574626f78 // "outbox"
+
+// TryLockPublisher takes the relay's advisory lock for the rest of the
+// transaction, so a single relay publishes at a time and events keep their
+// order. Returns false when another transaction holds it. Must run in a
+// transaction.
+func (r *outboxRepository) TryLockPublisher() (bool, error) {
+	var locked bool
+	if err := r.db.QueryRowx(`SELECT pg_try_advisory_xact_lock($1)`, publisherLockKey).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// GetUnpublished returns the oldest events not yet published, in stream order
+func (r *outboxRepository) GetUnpublished(limit int) ([]model.Event, error) {
+	events := []model.Event{}
+	query := `SELECT ` + streamColumns + ` FROM outbox_event
+	          WHERE published_at IS NULL AND ` + stableCondition + `
+	          ORDER BY txid, seq LIMIT $1`
+	if err := r.db.Select(&events, query, limit); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkPublished records that the events have been accepted by the publisher
+func (r *outboxRepository) MarkPublished(ids []string) error {
+	query := `UPDATE outbox_event SET published_at = $1 WHERE id = ANY($2::uuid[])`
+	_, err := r.db.Exec(query, time.Now(), pq.Array(ids))
+	return err
+}
+
+// GetCustomerEvents returns the events of the customer with customerID and of
+// its customer-car links, deleted links included, in stream order. Events are
+// only read within the tenant of the repository.
+func (r *outboxRepository) GetCustomerEvents(customerID string) ([]model.Event, error) {
+	events := []model.Event{}
+	query := `SELECT ` + streamColumns + ` FROM outbox_event
+	          WHERE tenant_id = $1 AND ((aggregate_type = 'customer' AND aggregate_id = $2)
+	          OR (aggregate_type = 'customer_car' AND aggregate_id IN (
+	              SELECT aggregate_id FROM outbox_event
+	              WHERE tenant_id = $1 AND aggregate_type = 'customer_car' AND payload->>'customer_id' = $2)))
+	          ORDER BY txid, seq`
+	if err := r.db.Select(&events, query, r.tenantID, customerID); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// RedactEvents overwrites the fields named in redacted in the payloads of the
+// events of an aggregate with one of the given types, and in the webhook
+// deliveries queued from them. Events that were already published or
+// delivered are not recalled.
+func (r *outboxRepository) RedactEvents(aggregateType, aggregateID string, types []string, redacted json.RawMessage) error {
+	query := `UPDATE outbox_event SET payload = payload || $4::jsonb
+	          WHERE tenant_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND event_type = ANY($5)`
+	if _, err := r.db.Exec(query, r.tenantID, aggregateType, aggregateID, []byte(redacted), pq.Array(types)); err != nil {
+		return err
+	}
+	query = `UPDATE webhook_delivery SET payload = jsonb_set(payload, '{data}', (payload->'data') || $4::jsonb)
+	         WHERE event_id IN (SELECT id FROM outbox_event
+	         WHERE tenant_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND event_type = ANY($5))`
+	_, err := r.db.Exec(query, r.tenantID, aggregateType, aggregateID, []byte(redacted), pq.Array(types))
+	return err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository_Append(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db).WithTenant("tenant1")
+
+	// Nothing to write
+	assert.NoError(t, repo.Append())
+
+	events := []*model.Event{
+		{ID: "e1", Type: model.EventCustomerCarCreated, AggregateType: "customer_car", AggregateID: "cc1", Data: json.RawMessage(`{"id":"cc1"}`)},
+		{ID: "e2", Type: model.EventCarPriceChanged, AggregateType: "car", AggregateID: "car1", Data: json.RawMessage(`{}`)},
+	}
+	query := regexp.QuoteMeta(`INSERT INTO outbox_event (id, tenant_id, event_type, aggregate_type, aggregate_id, payload, created_at) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7), ($8, $9, $10, $11, $12, $13, $14)`)
+	mock.ExpectExec(query).
+		WithArgs("e1", "tenant1", model.EventCustomerCarCreated, "customer_car", "cc1", []byte(`{"id":"cc1"}`), AnyTime{},
+			"e2", "tenant1", model.EventCarPriceChanged, "car", "car1", []byte(`{}`), AnyTime{}).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.Append(events...))
+	assert.False(t, events[0].CreatedAt.IsZero())
+	assert.Equal(t, "tenant1", events[1].TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_ClaimUndispatched(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "tenant_id", "event_type", "aggregate_type", "aggregate_id", "payload", "created_at"}).
+		AddRow("e1", "tenant1", model.EventCarPriceChanged, "car", "car1", []byte(`{"previous_price":1}`), time.Now())
+	mock.ExpectQuery(`SELECT (.+) FROM outbox_event WHERE dispatched_at IS NULL ORDER BY seq LIMIT \$1 FOR UPDATE SKIP LOCKED`).
+		WithArgs(10).WillReturnRows(rows)
+
+	events, err := repo.ClaimUndispatched(10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.JSONEq(t, `{"previous_price":1}`, string(events[0].Data))
+	assert.Equal(t, "tenant1", events[0].TenantID)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox_event SET dispatched_at = $1 WHERE id = ANY($2::uuid[])`)).
+		WithArgs(AnyTime{}, pq.Array([]string{"e1"})).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.MarkDispatched([]string{"e1"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetPosition(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	query := regexp.QuoteMeta(`SELECT txid::text::bigint, seq FROM outbox_event WHERE seq = $1`)
+	mock.ExpectQuery(query).WithArgs(int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"txid", "seq"}).AddRow(int64(900), int64(42)))
+	pos, err := repo.GetPosition(42)
+	assert.NoError(t, err)
+	assert.Equal(t, model.EventPosition{TxID: 900, Seq: 42}, pos)
+
+	mock.ExpectQuery(query).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"txid", "seq"}))
+	_, err = repo.GetPosition(7)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetLatestPosition(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	query := regexp.QuoteMeta(`WHERE txid < pg_snapshot_xmin(pg_current_snapshot())`) + `\s+` +
+		regexp.QuoteMeta(`ORDER BY txid DESC, seq DESC LIMIT 1`)
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"txid", "seq"}).AddRow(int64(900), int64(42)))
+	pos, err := repo.GetLatestPosition()
+	assert.NoError(t, err)
+	assert.Equal(t, model.EventPosition{TxID: 900, Seq: 42}, pos)
+
+	// An empty outbox starts at the zero position
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"txid", "seq"}))
+	pos, err = repo.GetLatestPosition()
+	assert.NoError(t, err)
+	assert.Equal(t, model.EventPosition{}, pos)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetEventsAfter(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	rows := sqlmock.NewRows([]string{"seq", "txid", "id", "tenant_id", "event_type", "aggregate_type", "aggregate_id", "payload", "created_at"}).
+		AddRow(int64(43), int64(901), "e1", "tenant1", model.EventCarUpdated, "car", "car1", []byte(`{"id":"car1"}`), time.Now())
+	query := `WHERE \(txid, seq\) > \(\$1::text::xid8, \$2\) AND txid < pg_snapshot_xmin\(pg_current_snapshot\(\)\)(.+)` +
+		regexp.QuoteMeta(`AND tenant_id = COALESCE(NULLIF($5, '')::uuid, tenant_id)`) + `\s+ORDER BY txid, seq LIMIT \$4`
+	mock.ExpectQuery(query).
+		WithArgs(int64(900), int64(42), pq.Array([]string{model.EventCarUpdated}), 100, "tenant1").WillReturnRows(rows)
+
+	events, err := repo.GetEventsAfter(model.EventPosition{TxID: 900, Seq: 42}, "tenant1", []string{model.EventCarUpdated}, 100)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, model.EventPosition{TxID: 901, Seq: 43}, events[0].Position())
+	assert.Equal(t, "tenant1", events[0].TenantID)
+
+	// An empty tenant reads the events of every tenant
+	mock.ExpectQuery(query).WithArgs(int64(901), int64(43), pq.Array([]string(nil)), 100, "").
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	events, err = repo.GetEventsAfter(model.EventPosition{TxID: 901, Seq: 43}, "", nil, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_TryLockPublisher(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	query := regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)
+	mock.ExpectQuery(query).WithArgs(publisherLockKey).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	locked, err := repo.TryLockPublisher()
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	mock.ExpectQuery(query).WithArgs(publisherLockKey).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	locked, err = repo.TryLockPublisher()
+	assert.NoError(t, err)
+	assert.False(t, locked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetUnpublished(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	rows := sqlmock.NewRows([]string{"seq", "txid", "id", "event_type", "aggregate_type", "aggregate_id", "payload", "created_at"}).
+		AddRow(int64(7), int64(900), "e1", model.EventCarCreated, "car", "car1", []byte(`{"id":"car1"}`), time.Now()).
+		AddRow(int64(8), int64(900), "e2", model.EventCarUpdated, "car", "car1", []byte(`{"id":"car1"}`), time.Now())
+	mock.ExpectQuery(`WHERE published_at IS NULL AND txid < pg_snapshot_xmin\(pg_current_snapshot\(\)\)\s+ORDER BY txid, seq LIMIT \$1`).
+		WithArgs(100).WillReturnRows(rows)
+
+	events, err := repo.GetUnpublished(100)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "e1", events[0].ID)
+	assert.Equal(t, "car1", events[1].AggregateID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox_event SET published_at = $1 WHERE id = ANY($2::uuid[])`)).
+		WithArgs(sqlmock.AnyArg(), pq.Array([]string{"e1", "e2"})).WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.NoError(t, repo.MarkPublished([]string{"e1", "e2"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetCustomerEvents(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db).WithTenant("tenant1")
+
+	rows := sqlmock.NewRows([]string{"seq", "txid", "id", "event_type", "aggregate_type", "aggregate_id", "payload", "created_at"}).
+		AddRow(int64(1), int64(900), "e1", model.EventCustomerCreated, "customer", "cust1", []byte(`{"id":"cust1"}`), time.Now()).
+		AddRow(int64(2), int64(901), "e2", model.EventCustomerCarDeleted, "customer_car", "cc1", []byte(`{"id":"cc1"}`), time.Now())
+	// Links are found by the customer in their payloads, so deleted ones are included
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE tenant_id = $1 AND aggregate_type = 'customer_car' AND payload->>'customer_id' = $2`)).
+		WithArgs("tenant1", "cust1").WillReturnRows(rows)
+
+	events, err := repo.GetCustomerEvents("cust1")
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "cc1", events[1].AggregateID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_RedactEvents(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewOutboxRepository(db).WithTenant("tenant1")
+	redacted := json.RawMessage(`{"email":"erased"}`)
+	types := pq.Array([]string{model.EventCustomerCreated, model.EventCustomerUpdated})
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE outbox_event SET payload = payload || $4::jsonb`)).
+		WithArgs("tenant1", "customer", "cust1", []byte(redacted), types).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_delivery SET payload = jsonb_set(payload, '{data}', (payload->'data') || $4::jsonb)`)).
+		WithArgs("tenant1", "customer", "cust1", []byte(redacted), types).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.RedactEvents("customer", "cust1", []string{model.EventCustomerCreated, model.EventCustomerUpdated}, redacted)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// RateLimitRepository stores the token buckets of the rate limiter. A bucket
+// must be locked and saved in the same transaction.
+type RateLimitRepository interface {
+	LockBucket(key string, capacity float64) (*model.RateLimitBucket, time.Time, error)
+	SaveBucket(bucket *model.RateLimitBucket) error
+	DeleteIdleBuckets(idle time.Duration) (int64, error)
+	WithTx(tx *sqlx.Tx) RateLimitRepository
+}
+
+type rateLimitRepository struct {
+	db DBTX
+}
+
+// NewRateLimitRepository creates a new instance of RateLimitRepository
+func NewRateLimitRepository(db *sqlx.DB) RateLimitRepository {
+	return &rateLimitRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *rateLimitRepository) WithTx(tx *sqlx.Tx) RateLimitRepository {
+	return &rateLimitRepository{db: tx}
+}
+
+// LockBucket locks the bucket with key until the end of the transaction,
+// creating it full with capacity tokens when it does not exist. It returns the
+// current time of the database, so every instance measures time with the same clock.
+func (r *rateLimitRepository) LockBucket(key string, capacity float64) (*model.RateLimitBucket, time.Time, error) {
+	// The no-op update of an existing bucket locks it like SELECT ... FOR UPDATE
+	query := `INSERT INTO rate_limit_bucket (key, tokens, updated_at) VALUES ($1, $2, now())
+	          ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key
+	          RETURNING key, tokens, updated_at, now() AS now`
+	var row struct {
+		model.RateLimitBucket
+		Now time.Time `db:"now"`
+	}
+	if err := r.db.Get(&row, query, key, capacity); err != nil {
+		return nil, time.Time{}, err
+	}
+	return &row.RateLimitBucket, row.Now, nil
+}
+
+// SaveBucket stores the tokens of a locked bucket
+func (r *rateLimitRepository) SaveBucket(bucket *model.RateLimitBucket) error {
+	_, err := r.db.Exec(`UPDATE rate_limit_bucket SET tokens = $2, updated_at = $3 WHERE key = $1`,
+		bucket.Key, bucket.Tokens, bucket.UpdatedAt)
+	return err
+}
+
+// DeleteIdleBuckets deletes the buckets without requests for longer than idle
+// and returns how many were deleted
+func (r *rateLimitRepository) DeleteIdleBuckets(idle time.Duration) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM rate_limit_bucket WHERE updated_at < now() - $1 * interval '1 second'`,
+		idle.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
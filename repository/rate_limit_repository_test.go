@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockRateLimitRepo(t *testing.T) (RateLimitRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewRateLimitRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+func TestRateLimitRepository_LockBucket(t *testing.T) {
+	repo, mock := newMockRateLimitRepo(t)
+	updatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := updatedAt.Add(3 * time.Second)
+
+	query := regexp.QuoteMeta(`INSERT INTO rate_limit_bucket (key, tokens, updated_at) VALUES ($1, $2, now()) ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key RETURNING key, tokens, updated_at, now() AS now`)
+	mock.ExpectQuery(query).WithArgs("cars|ip:192.0.2.1", 60.0).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "updated_at", "now"}).
+			AddRow("cars|ip:192.0.2.1", 12.5, updatedAt, now))
+
+	bucket, gotNow, err := repo.LockBucket("cars|ip:192.0.2.1", 60)
+	require.NoError(t, err)
+	assert.Equal(t, &model.RateLimitBucket{Key: "cars|ip:192.0.2.1", Tokens: 12.5, UpdatedAt: updatedAt}, bucket)
+	assert.Equal(t, now, gotNow)
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("database error"))
+	_, _, err = repo.LockBucket("cars|ip:192.0.2.1", 60)
+	assert.EqualError(t, err, "database error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRepository_SaveBucket(t *testing.T) {
+	repo, mock := newMockRateLimitRepo(t)
+	bucket := &model.RateLimitBucket{Key: "cars|ip:192.0.2.1", Tokens: 11.5, UpdatedAt: time.Now()}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE rate_limit_bucket SET tokens = $2, updated_at = $3 WHERE key = $1`)).
+		WithArgs(bucket.Key, bucket.Tokens, bucket.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SaveBucket(bucket))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRepository_DeleteIdleBuckets(t *testing.T) {
+	repo, mock := newMockRateLimitRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM rate_limit_bucket WHERE updated_at < now() - $1 * interval '1 second'`)).
+		WithArgs(3600.0).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	deleted, err := repo.DeleteIdleBuckets(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
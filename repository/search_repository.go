@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"html"
+	"strings"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchRepository defines the interface for searching customers, suppliers
+// and cars together
+type SearchRepository interface {
+	Search(q string, limit int) ([]model.SearchResult, error)
+	WithTenant(tenantID string) SearchRepository
+}
+
+type searchRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+// NewSearchRepository creates a new instance of SearchRepository
+func NewSearchRepository(db *sqlx.DB) SearchRepository {
+	return &searchRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTenant returns a copy of the repository that only searches the records
+// of tenantID
+func (r *searchRepository) WithTenant(tenantID string) SearchRepository {
+	return &searchRepository{db: r.db, tenantID: tenantID}
+}
+
+// Private use characters delimit the highlighted words until the text has
+// been escaped, so record values cannot inject markup
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// searchQuery ranks the matches of each table, keeps the best ones and only
+// then builds their highlighted text, which is the costly part.
+// Highlights only show names: results are returned to callers that may not
+// see the contacts of the records.
+// $1 is the tsquery, $2 the raw query, $3 the ts_headline options, $4 the limit
+// and $5 the tenant.
+const searchQuery = `WITH q AS (
+	SELECT to_tsquery('` + searchConfig + `', $1) AS query, search_unaccent(lower($2)) AS text
+), matches AS (
+	SELECT 'customer' AS type, id, coalesce(name, '') AS name,
+		coalesce(name, '') AS document,
+		ts_rank(search_vector, q.query) + word_similarity(q.text, search_text) AS rank
+	FROM customer, q WHERE tenant_id = $5 AND (search_vector @@ q.query OR q.text <% search_text)
+	UNION ALL
+	SELECT 'supplier', id, coalesce(name, ''), coalesce(name, ''),
+		ts_rank(search_vector, q.query) + word_similarity(q.text, search_text)
+	FROM supplier, q WHERE tenant_id = $5 AND (search_vector @@ q.query OR q.text <% search_text)
+	UNION ALL
+	SELECT 'car', id, coalesce(name, ''), coalesce(name, ''),
+		ts_rank(search_vector, q.query) + word_similarity(q.text, search_text)
+	FROM car, q WHERE tenant_id = $5 AND (search_vector @@ q.query OR q.text <% search_text)
+	ORDER BY rank DESC, type, id
+	LIMIT $4
+)
+SELECT type, id, name, rank, ts_headline('` + searchConfig + `', document, q.query, $3) AS highlight
+FROM matches, q
+ORDER BY rank DESC, type, id`
+
+// Search returns the customers, suppliers and cars best matching q, with
+// their matched words highlighted
+func (r *searchRepository) Search(q string, limit int) ([]model.SearchResult, error) {
+	tsquery := searchTSQuery(q)
+	if tsquery == "" {
+		return []model.SearchResult{}, nil
+	}
+
+	options := "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	results := []model.SearchResult{}
+	if err := r.db.Select(&results, searchQuery, tsquery, q, options, limit, r.tenantID); err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Highlight = highlightTags.Replace(html.EscapeString(results[i].Highlight))
+	}
+	return results, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepository_Search(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	repo := NewSearchRepository(sqlx.NewDb(mockDB, "sqlmock")).WithTenant("tenant1")
+	query := regexp.QuoteMeta(`SELECT type, id, name, rank, ts_headline('unaccent_simple', document, q.query, $3) AS highlight`)
+	options := "StartSel=\uE000, StopSel=\uE001, HighlightAll=true"
+
+	t.Run("RankedAndHighlighted", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs("ha:* & noi:*", "ha noi", options, 20, "tenant1").
+			WillReturnRows(sqlmock.NewRows([]string{"type", "id", "name", "rank", "highlight"}).
+				AddRow("supplier", "supp1", "Hà Nội <Motors>", 0.9, "\uE000Hà\uE001 \uE000Nội\uE001 <Motors>").
+				AddRow("customer", "cust1", "An", 0.3, "An"))
+
+		results, err := repo.Search("ha noi", 20)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "supplier", results[0].Type)
+		// Record values are escaped, only the matches are marked up
+		assert.Equal(t, "<mark>Hà</mark> <mark>Nội</mark> &lt;Motors&gt;", results[0].Highlight)
+		assert.Equal(t, "An", results[1].Highlight)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoTerms", func(t *testing.T) {
+		results, err := repo.Search("--", 20)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(errors.New("db down"))
+		_, err := repo.Search("camry", 20)
+		assert.EqualError(t, err, "db down")
+	})
+}
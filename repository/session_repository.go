@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// SessionRepository defines the interface for login session and refresh token data operations
+type SessionRepository interface {
+	CreateSession(session *model.Session) error
+	GetSession(id string) (*model.Session, error)
+	RevokeSession(id string) error
+	RevokeUserSessions(userID string) error
+	CreateRefreshToken(token *model.RefreshToken) error
+	GetRefreshToken(tokenHash string) (*model.RefreshToken, error)
+	UseRefreshToken(tokenHash string) error
+	WithTx(tx *sqlx.Tx) SessionRepository
+}
+
+type sessionRepository struct {
+	db DBTX
+}
+
+// NewSessionRepository creates a new instance of SessionRepository
+func NewSessionRepository(db *sqlx.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *sessionRepository) WithTx(tx *sqlx.Tx) SessionRepository {
+	return &sessionRepository{db: tx}
+}
+
+// CreateSession adds a new session
+func (r *sessionRepository) CreateSession(session *model.Session) error {
+	query := `INSERT INTO auth_session (id, user_id) VALUES ($1, $2) RETURNING created_at`
+	return r.db.Get(&session.CreatedAt, query, session.ID, session.UserID)
+}
+
+// GetSession retrieves a session by its ID
+func (r *sessionRepository) GetSession(id string) (*model.Session, error) {
+	var session model.Session
+	query := `SELECT id, user_id, created_at, revoked_at FROM auth_session WHERE id = $1`
+	if err := r.db.Get(&session, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RevokeSession revokes a session, ending its access and refresh tokens
+func (r *sessionRepository) RevokeSession(id string) error {
+	_, err := r.db.Exec(`UPDATE auth_session SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`, id)
+	return err
+}
+
+// RevokeUserSessions revokes every session of a user
+func (r *sessionRepository) RevokeUserSessions(userID string) error {
+	_, err := r.db.Exec(`UPDATE auth_session SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	return err
+}
+
+// CreateRefreshToken adds a new refresh token to its session
+func (r *sessionRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	query := `INSERT INTO refresh_token (token_hash, session_id, expires_at) VALUES ($1, $2, $3) RETURNING created_at`
+	return r.db.Get(&token.CreatedAt, query, token.TokenHash, token.SessionID, token.ExpiresAt)
+}
+
+// GetRefreshToken retrieves a refresh token by its hash, used or not
+func (r *sessionRepository) GetRefreshToken(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	query := `SELECT token_hash, session_id, expires_at, created_at, used_at FROM refresh_token WHERE token_hash = $1`
+	if err := r.db.Get(&token, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UseRefreshToken marks a refresh token as used. Of concurrent uses of the
+// same token only one succeeds; the others get ErrNotFound.
+func (r *sessionRepository) UseRefreshToken(tokenHash string) error {
+	result, err := r.db.Exec(`UPDATE refresh_token SET used_at = now() WHERE token_hash = $1 AND used_at IS NULL`, tokenHash)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockSessionRepo(t *testing.T) (SessionRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewSessionRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+func TestSessionRepository_CreateSession(t *testing.T) {
+	repo, mock := newMockSessionRepo(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO auth_session (id, user_id) VALUES ($1, $2) RETURNING created_at`)).
+		WithArgs("session-1", "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+
+	session := &model.Session{ID: "session-1", UserID: "user-1"}
+	require.NoError(t, repo.CreateSession(session))
+	assert.Equal(t, now, session.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepository_GetSession(t *testing.T) {
+	repo, mock := newMockSessionRepo(t)
+	query := regexp.QuoteMeta(`SELECT id, user_id, created_at, revoked_at FROM auth_session WHERE id = $1`)
+	now := time.Now()
+
+	mock.ExpectQuery(query).WithArgs("session-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "created_at", "revoked_at"}).AddRow("session-1", "user-1", now, now))
+	session, err := repo.GetSession("session-1")
+	require.NoError(t, err)
+	assert.Equal(t, &now, session.RevokedAt)
+
+	mock.ExpectQuery(query).WithArgs("missing").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.GetSession("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepository_RevokeUserSessions(t *testing.T) {
+	repo, mock := newMockSessionRepo(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE auth_session SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`)).
+		WithArgs("user-1").WillReturnResult(sqlmock.NewResult(0, 3))
+
+	assert.NoError(t, repo.RevokeUserSessions("user-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepository_UseRefreshToken(t *testing.T) {
+	repo, mock := newMockSessionRepo(t)
+	query := regexp.QuoteMeta(`UPDATE refresh_token SET used_at = now() WHERE token_hash = $1 AND used_at IS NULL`)
+
+	mock.ExpectExec(query).WithArgs("hash").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UseRefreshToken("hash"))
+
+	// Already used, by this request or a concurrent one
+	mock.ExpectExec(query).WithArgs("hash").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.UseRefreshToken("hash"), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,59 +1,160 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/GoodsChain/backend/model"
+	"github.com/lib/pq"
 )
 
 type SupplierRepository interface {
 	Create(supplier *model.Supplier) error
 	Get(id string) (*model.Supplier, error)
+	GetByIDs(ids []string) ([]*model.Supplier, error)
 	Update(id string, supplier *model.Supplier) error
 	Delete(id string) error
-	GetAll() ([]*model.Supplier, error)
+	GetAll(filter model.SupplierFilter) ([]*model.Supplier, error)
+	Stream(filter model.SupplierFilter, fn func(supplier *model.Supplier) error) error
+	CreateBatch(suppliers []*model.Supplier) ([]string, error)
+	UpdateBatch(suppliers []*model.Supplier) ([]string, error)
+	DeleteBatch(ids []string) ([]string, error)
+	WithTx(tx *sqlx.Tx) SupplierRepository
+	WithTenant(tenantID string) SupplierRepository
 }
 
 type supplierRepository struct {
-	db *sqlx.DB
+	db       DBTX
+	tenantID string
 }
 
+const supplierColumns = "id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by"
+
 func NewSupplierRepository(db *sqlx.DB) SupplierRepository {
 	return &supplierRepository{
-		db: db,
+		db:       db,
+		tenantID: model.DefaultTenantID,
 	}
 }
 
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *supplierRepository) WithTx(tx *sqlx.Tx) SupplierRepository {
+	return &supplierRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// suppliers of tenantID
+func (r *supplierRepository) WithTenant(tenantID string) SupplierRepository {
+	return &supplierRepository{db: r.db, tenantID: tenantID}
+}
+
 func (r *supplierRepository) Create(supplier *model.Supplier) error {
-	query := `INSERT INTO supplier (id, name, address, phone, email, created_by, updated_by) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.Exec(query, supplier.ID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", "admin")
+	supplier.TenantID = r.tenantID
+	query := `INSERT INTO supplier (id, tenant_id, name, address, phone, email, created_by, updated_by) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(query, supplier.ID, supplier.TenantID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.CreatedBy, supplier.UpdatedBy)
 	return err
 }
 
 func (r *supplierRepository) Get(id string) (*model.Supplier, error) {
 	var supplier model.Supplier
-	query := `SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by 
-		FROM supplier WHERE id = $1`
-	err := r.db.Get(&supplier, query, id)
+	query := `SELECT ` + supplierColumns + `
+		FROM supplier WHERE id = $1 AND tenant_id = $2`
+	err := r.db.Get(&supplier, query, id, r.tenantID)
 	return &supplier, err
 }
 
+// GetByIDs retrieves the suppliers with the given IDs; IDs that do not exist
+// are left out of the result
+func (r *supplierRepository) GetByIDs(ids []string) ([]*model.Supplier, error) {
+	suppliers := []*model.Supplier{}
+	query := `SELECT ` + supplierColumns + `
+		FROM supplier WHERE id = ANY($1::uuid[]) AND tenant_id = $2`
+	if err := r.db.Select(&suppliers, query, pq.Array(ids), r.tenantID); err != nil {
+		return nil, translateError(err)
+	}
+	return suppliers, nil
+}
+
 func (r *supplierRepository) Update(id string, supplier *model.Supplier) error {
 	query := `UPDATE supplier SET name = $1, address = $2, phone = $3, email = $4, updated_by = $5, updated_at = now() 
-		WHERE id = $6`
-	_, err := r.db.Exec(query, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", id)
+		WHERE id = $6 AND tenant_id = $7`
+	_, err := r.db.Exec(query, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.UpdatedBy, id, r.tenantID)
 	return err
 }
 
 func (r *supplierRepository) Delete(id string) error {
-	_, err := r.db.Exec("DELETE FROM supplier WHERE id = $1", id)
+	_, err := r.db.Exec("DELETE FROM supplier WHERE id = $1 AND tenant_id = $2", id, r.tenantID)
 	return err
 }
 
-func (r *supplierRepository) GetAll() ([]*model.Supplier, error) {
+func (r *supplierRepository) GetAll(filter model.SupplierFilter) ([]*model.Supplier, error) {
 	var suppliers []*model.Supplier
-	if err := r.db.Select(&suppliers, "SELECT * FROM supplier"); err != nil {
+	query, args := supplierListQuery(r.tenantID, filter)
+	if err := r.db.Select(&suppliers, query, args...); err != nil {
 		return nil, err
 	}
 	return suppliers, nil
 }
+
+// Stream calls fn for every supplier matching filter, reading one row at a time
+func (r *supplierRepository) Stream(filter model.SupplierFilter, fn func(supplier *model.Supplier) error) error {
+	query, args := supplierListQuery(r.tenantID, filter)
+	return streamRows(r.db, query, args, func(rows *sqlx.Rows) error {
+		var supplier model.Supplier
+		if err := rows.StructScan(&supplier); err != nil {
+			return err
+		}
+		return fn(&supplier)
+	})
+}
+
+// supplierListQuery builds the query shared by GetAll and Stream
+func supplierListQuery(tenantID string, filter model.SupplierFilter) (string, []interface{}) {
+	var c conditions
+	c.add("tenant_id = ?", tenantID)
+	rank := c.addSearch(filter.Query)
+	c.addContains("name", filter.Name)
+	if filter.Email != "" {
+		c.add("LOWER(email) = LOWER(?)", filter.Email)
+	}
+	c.addCreatedRange(filter.CreatedRange)
+
+	columns := selectList(filter.Fields, model.Supplier{}, supplierColumns)
+	query := `SELECT ` + columns + `
+		FROM supplier` + c.where() + orderBy(rank)
+	return query, c.args
+}
+
+// CreateBatch inserts suppliers with a single statement and returns the IDs
+// written; rows that duplicate an existing ID or email are skipped
+func (r *supplierRepository) CreateBatch(suppliers []*model.Supplier) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(suppliers))
+	for i, supplier := range suppliers {
+		supplier.TenantID = r.tenantID
+		supplier.CreatedAt, supplier.UpdatedAt = now, now
+		rows[i] = []interface{}{supplier.ID, supplier.TenantID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email,
+			supplier.CreatedAt, supplier.CreatedBy, supplier.UpdatedAt, supplier.UpdatedBy}
+	}
+	columns := []string{"id", "tenant_id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"}
+	return insertRows(r.db, "supplier", columns, rows)
+}
+
+// UpdateBatch updates suppliers with a single statement and returns the IDs found
+func (r *supplierRepository) UpdateBatch(suppliers []*model.Supplier) ([]string, error) {
+	now := time.Now()
+	rows := make([][]interface{}, len(suppliers))
+	for i, supplier := range suppliers {
+		supplier.UpdatedAt = now
+		rows[i] = []interface{}{supplier.ID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.UpdatedAt, supplier.UpdatedBy}
+	}
+	columns := []string{"id", "name", "address", "phone", "email", "updated_at", "updated_by"}
+	types := []string{"uuid", "varchar", "varchar", "varchar", "varchar", "timestamptz", "varchar"}
+	return updateRows(r.db, "supplier", r.tenantID, columns, types, rows)
+}
+
+// DeleteBatch deletes suppliers by ID with a single statement and returns the IDs found
+func (r *supplierRepository) DeleteBatch(ids []string) ([]string, error) {
+	return deleteRows(r.db, "supplier", r.tenantID, ids)
+}
@@ -23,16 +23,18 @@ func TestSupplierCreate(t *testing.T) {
 	repo := NewSupplierRepository(db)
 
 	supplier := &model.Supplier{
-		ID:      "supp123",
-		Name:    "Test Supplier",
-		Address: "123 Test St",
-		Phone:   "+1234567890",
-		Email:   "supplier@example.com",
+		ID:        "supp123",
+		Name:      "Test Supplier",
+		Address:   "123 Test St",
+		Phone:     "+1234567890",
+		Email:     "supplier@example.com",
+		CreatedBy: "jane",
+		UpdatedBy: "jane",
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO supplier \\(id, name, address, phone, email, created_by, updated_by\\)").
-			WithArgs(supplier.ID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", "admin").
+		mock.ExpectExec("INSERT INTO supplier \\(id, tenant_id, name, address, phone, email, created_by, updated_by\\)").
+			WithArgs(supplier.ID, model.DefaultTenantID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.CreatedBy, supplier.UpdatedBy).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repo.Create(supplier)
@@ -48,7 +50,7 @@ func TestSupplierCreate(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mock.ExpectExec("INSERT INTO supplier").
-			WithArgs(supplier.ID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", "admin").
+			WithArgs(supplier.ID, model.DefaultTenantID, supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.CreatedBy, supplier.UpdatedBy).
 			WillReturnError(expectedErr)
 
 		err := repo.Create(supplier)
@@ -74,8 +76,8 @@ func TestSupplierGet(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"}).
 			AddRow(supplierID, "Test Supplier", "123 Test St", "+1234567890", "supplier@example.com", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE id = \\$1").
-			WithArgs(supplierID).
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(supplierID, model.DefaultTenantID).
 			WillReturnRows(rows)
 
 		supplier, err := repo.Get(supplierID)
@@ -98,8 +100,8 @@ func TestSupplierGet(t *testing.T) {
 
 	t.Run("Not Found", func(t *testing.T) {
 		expectedErr := errors.New("no rows in result set")
-		mock.ExpectQuery("SELECT id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE id = \\$1").
-			WithArgs(supplierID).
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(supplierID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		supplier, err := repo.Get(supplierID)
@@ -123,16 +125,17 @@ func TestSupplierUpdate(t *testing.T) {
 
 	supplierID := "supp123"
 	supplier := &model.Supplier{
-		ID:      supplierID,
-		Name:    "Updated Supplier",
-		Address: "456 New St",
-		Phone:   "+9876543210",
-		Email:   "updated@example.com",
+		ID:        supplierID,
+		Name:      "Updated Supplier",
+		Address:   "456 New St",
+		Phone:     "+9876543210",
+		Email:     "updated@example.com",
+		UpdatedBy: "jane",
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("UPDATE supplier SET name = \\$1, address = \\$2, phone = \\$3, email = \\$4, updated_by = \\$5, updated_at = now\\(\\) WHERE id = \\$6").
-			WithArgs(supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", supplierID).
+		mock.ExpectExec("UPDATE supplier SET name = \\$1, address = \\$2, phone = \\$3, email = \\$4, updated_by = \\$5, updated_at = now\\(\\) WHERE id = \\$6 AND tenant_id = \\$7").
+			WithArgs(supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.UpdatedBy, supplierID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Update(supplierID, supplier)
@@ -148,7 +151,7 @@ func TestSupplierUpdate(t *testing.T) {
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mock.ExpectExec("UPDATE supplier SET").
-			WithArgs(supplier.Name, supplier.Address, supplier.Phone, supplier.Email, "admin", supplierID).
+			WithArgs(supplier.Name, supplier.Address, supplier.Phone, supplier.Email, supplier.UpdatedBy, supplierID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Update(supplierID, supplier)
@@ -169,8 +172,8 @@ func TestSupplierDelete(t *testing.T) {
 	supplierID := "supp123"
 
 	t.Run("Success", func(t *testing.T) {
-		mock.ExpectExec("DELETE FROM supplier WHERE id = \\$1").
-			WithArgs(supplierID).
+		mock.ExpectExec("DELETE FROM supplier WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(supplierID, model.DefaultTenantID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Delete(supplierID)
@@ -185,8 +188,8 @@ func TestSupplierDelete(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectExec("DELETE FROM supplier WHERE id = \\$1").
-			WithArgs(supplierID).
+		mock.ExpectExec("DELETE FROM supplier WHERE id = \\$1 AND tenant_id = \\$2").
+			WithArgs(supplierID, model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
 		err := repo.Delete(supplierID)
@@ -212,10 +215,11 @@ func TestSupplierGetAll(t *testing.T) {
 			AddRow("supp123", "Supplier 1", "123 Test St", "+1234567890", "supp1@example.com", createdAt, "admin", updatedAt, "admin").
 			AddRow("supp456", "Supplier 2", "456 Test St", "+0987654321", "supp2@example.com", createdAt, "admin", updatedAt, "admin")
 
-		mock.ExpectQuery("SELECT \\* FROM supplier").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		suppliers, err := repo.GetAll()
+		suppliers, err := repo.GetAll(model.SupplierFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -236,10 +240,11 @@ func TestSupplierGetAll(t *testing.T) {
 	t.Run("Success With No Results", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "address", "phone", "email", "created_at", "created_by", "updated_at", "updated_by"})
 
-		mock.ExpectQuery("SELECT \\* FROM supplier").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnRows(rows)
 
-		suppliers, err := repo.GetAll()
+		suppliers, err := repo.GetAll(model.SupplierFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -257,10 +262,11 @@ func TestSupplierGetAll(t *testing.T) {
 
 	t.Run("Database Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mock.ExpectQuery("SELECT \\* FROM supplier").
+		mock.ExpectQuery("SELECT id, tenant_id, name, address, phone, email, created_at, created_by, updated_at, updated_by FROM supplier WHERE tenant_id = \\$1 ORDER BY created_at DESC").
+			WithArgs(model.DefaultTenantID).
 			WillReturnError(expectedErr)
 
-		suppliers, err := repo.GetAll()
+		suppliers, err := repo.GetAll(model.SupplierFilter{})
 		if err != expectedErr {
 			t.Errorf("Expected error %v, got %v", expectedErr, err)
 		}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// TenantRepository defines the interface for tenant data operations
+type TenantRepository interface {
+	Create(tenant *model.Tenant) error
+	GetByID(id string) (*model.Tenant, error)
+	GetAll() ([]model.Tenant, error)
+}
+
+type tenantRepository struct {
+	db DBTX
+}
+
+// NewTenantRepository creates a new instance of TenantRepository
+func NewTenantRepository(db *sqlx.DB) TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+const tenantColumns = `id, name, created_at, created_by, updated_at, updated_by`
+
+// Create adds a new tenant; a duplicate name returns ErrDuplicate
+func (r *tenantRepository) Create(tenant *model.Tenant) error {
+	tenant.CreatedAt = time.Now()
+	tenant.UpdatedAt = tenant.CreatedAt
+
+	query := `INSERT INTO tenant (id, name, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.Exec(query, tenant.ID, tenant.Name, tenant.CreatedAt, tenant.CreatedBy, tenant.UpdatedAt, tenant.UpdatedBy)
+	return translateError(err)
+}
+
+// GetByID retrieves a tenant by its ID
+func (r *tenantRepository) GetByID(id string) (*model.Tenant, error) {
+	var tenant model.Tenant
+	if err := r.db.Get(&tenant, `SELECT `+tenantColumns+` FROM tenant WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetAll retrieves all tenants by name
+func (r *tenantRepository) GetAll() ([]model.Tenant, error) {
+	tenants := []model.Tenant{}
+	if err := r.db.Select(&tenants, `SELECT `+tenantColumns+` FROM tenant ORDER BY name`); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockTenantRepo(t *testing.T) (TenantRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewTenantRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+func TestTenantRepository_Create(t *testing.T) {
+	repo, mock := newMockTenantRepo(t)
+	tenant := &model.Tenant{ID: "tenant1", Name: "acme", CreatedBy: "admin", UpdatedBy: "admin"}
+	query := regexp.QuoteMeta(`INSERT INTO tenant (id, name, created_at, created_by, updated_at, updated_by)`)
+
+	mock.ExpectExec(query).WithArgs("tenant1", "acme", AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.Create(tenant))
+	assert.False(t, tenant.CreatedAt.IsZero())
+
+	mock.ExpectExec(query).WillReturnError(&pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "tenant_name_key"`})
+	assert.ErrorIs(t, repo.Create(tenant), ErrDuplicate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantRepository_Get(t *testing.T) {
+	repo, mock := newMockTenantRepo(t)
+	columns := []string{"id", "name", "created_at", "created_by", "updated_at", "updated_by"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM tenant WHERE id = $1`)).WithArgs("tenant1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("tenant1", "acme", time.Now(), "admin", time.Now(), "admin"))
+	tenant, err := repo.GetByID("tenant1")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant.Name)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM tenant WHERE id = $1`)).WithArgs("missing").WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetByID("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM tenant ORDER BY name`)).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("tenant1", "acme", time.Now(), "admin", time.Now(), "admin").
+			AddRow(model.DefaultTenantID, "default", time.Now(), "system", time.Now(), "system"))
+	tenants, err := repo.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, tenants, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBTX is implemented by both *sqlx.DB and *sqlx.Tx so the same repository
+// code can run inside or outside a transaction
+type DBTX interface {
+	sqlx.Ext
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+// Transactor runs a function inside a database transaction
+type Transactor interface {
+	// WithinTransaction commits if fn returns nil and rolls back otherwise,
+	// including when fn panics
+	WithinTransaction(fn func(tx *sqlx.Tx) error) error
+	// WithinSavepoint runs fn inside a savepoint of tx and rolls back to it
+	// if fn returns an error, leaving the rest of the transaction usable
+	WithinSavepoint(tx *sqlx.Tx, fn func() error) error
+}
+
+type transactor struct {
+	db *sqlx.DB
+}
+
+// NewTransactor creates a new instance of Transactor
+func NewTransactor(db *sqlx.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTransaction runs fn in a new transaction
+func (t *transactor) WithinTransaction(fn func(tx *sqlx.Tx) error) (err error) {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// WithinSavepoint runs fn in a savepoint of tx
+func (t *transactor) WithinSavepoint(tx *sqlx.Tx, fn func() error) error {
+	if _, err := tx.Exec("SAVEPOINT sp"); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT sp"); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	_, err := tx.Exec("RELEASE SAVEPOINT sp")
+	return err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockTransactor(t *testing.T) (Transactor, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewTransactor(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+func TestTransactor_WithinTransaction(t *testing.T) {
+	t.Run("Commit", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM car").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			return NewCarRepository(nil).WithTx(tx).DeleteCar("car1")
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RollbackOnError", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			return errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RollbackOnPanic", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+				panic("boom")
+			})
+		})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("BeginError", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+		err := transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			t.Fatal("fn must not be called")
+			return nil
+		})
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestTransactor_WithinSavepoint(t *testing.T) {
+	transactor, mock := newMockTransactor(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		assert.NoError(t, transactor.WithinSavepoint(tx, func() error { return nil }))
+		// A failed savepoint leaves the transaction usable
+		assert.EqualError(t, transactor.WithinSavepoint(tx, func() error { return errors.New("duplicate") }), "duplicate")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
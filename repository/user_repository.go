@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// UserRepository defines the interface for user account data operations.
+// Usernames are unique across tenants, so accounts are looked up without one.
+type UserRepository interface {
+	Create(user *model.User) error
+	GetByID(id string) (*model.User, error)
+	GetByUsername(username string) (*model.User, error)
+	RecordFailedLogin(id string, maxFailures int, lockFor time.Duration) (*time.Time, error)
+	ResetFailedLogins(id string) error
+	UpdatePassword(id, passwordHash, updatedBy string) error
+	WithTx(tx *sqlx.Tx) UserRepository
+}
+
+type userRepository struct {
+	db DBTX
+}
+
+// NewUserRepository creates a new instance of UserRepository
+func NewUserRepository(db *sqlx.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *userRepository) WithTx(tx *sqlx.Tx) UserRepository {
+	return &userRepository{db: tx}
+}
+
+const userColumns = `id, tenant_id, username, email, password_hash, role, created_at, created_by, updated_at, updated_by,
+	failed_logins, locked_until`
+
+// Create adds a new user account to the database
+func (r *userRepository) Create(user *model.User) error {
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	query := `INSERT INTO app_user (id, tenant_id, username, email, password_hash, role, created_at, created_by, updated_at,
+              updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.db.Exec(query, user.ID, user.TenantID, user.Username, user.Email, user.PasswordHash, user.Role,
+		user.CreatedAt, user.CreatedBy, user.UpdatedAt, user.UpdatedBy)
+	return err
+}
+
+// GetByID retrieves a user account by its ID
+func (r *userRepository) GetByID(id string) (*model.User, error) {
+	return r.getOne(`SELECT `+userColumns+` FROM app_user WHERE id = $1`, id)
+}
+
+// GetByUsername retrieves a user account by its unique username
+func (r *userRepository) GetByUsername(username string) (*model.User, error) {
+	return r.getOne(`SELECT `+userColumns+` FROM app_user WHERE username = $1`, username)
+}
+
+func (r *userRepository) getOne(query string, arg interface{}) (*model.User, error) {
+	var user model.User
+	err := r.db.Get(&user, query, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// RecordFailedLogin counts a failed login of a user. The failure that reaches
+// maxFailures locks the account for lockFor and starts the count over; the
+// returned time is the end of the lock, or nil when the account is not locked.
+func (r *userRepository) RecordFailedLogin(id string, maxFailures int, lockFor time.Duration) (*time.Time, error) {
+	query := `UPDATE app_user SET
+	              failed_logins = CASE WHEN failed_logins + 1 >= $1 THEN 0 ELSE failed_logins + 1 END,
+	              locked_until = CASE WHEN failed_logins + 1 >= $1 THEN now() + $2 * interval '1 second' ELSE locked_until END
+	          WHERE id = $3
+	          RETURNING locked_until`
+	var lockedUntil *time.Time
+	if err := r.db.Get(&lockedUntil, query, maxFailures, lockFor.Seconds(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return lockedUntil, nil
+}
+
+// ResetFailedLogins clears the failed logins and lock of a user after a successful login
+func (r *userRepository) ResetFailedLogins(id string) error {
+	_, err := r.db.Exec(`UPDATE app_user SET failed_logins = 0, locked_until = NULL WHERE id = $1`, id)
+	return err
+}
+
+// UpdatePassword replaces the password hash of a user
+func (r *userRepository) UpdatePassword(id, passwordHash, updatedBy string) error {
+	query := `UPDATE app_user SET password_hash = $1, updated_at = now(), updated_by = $2 WHERE id = $3`
+	result, err := r.db.Exec(query, passwordHash, updatedBy, id)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockUserRepo(t *testing.T) (UserRepository, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return NewUserRepository(sqlx.NewDb(mockDB, "sqlmock")), mock
+}
+
+var userColumnNames = []string{"id", "tenant_id", "username", "email", "password_hash", "role", "created_at", "created_by", "updated_at", "updated_by",
+	"failed_logins", "locked_until"}
+
+func TestUserRepository_Create(t *testing.T) {
+	repo, mock := newMockUserRepo(t)
+	user := &model.User{
+		ID:           uuid.New().String(),
+		TenantID:     model.DefaultTenantID,
+		Username:     "jdoe",
+		Email:        "jdoe@example.com",
+		PasswordHash: "$argon2id$hash",
+		Role:         model.RoleAdmin,
+		CreatedBy:    "system",
+		UpdatedBy:    "system",
+	}
+
+	query := regexp.QuoteMeta(`INSERT INTO app_user (id, tenant_id, username, email, password_hash, role, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`)
+	mock.ExpectExec(query).
+		WithArgs(user.ID, user.TenantID, user.Username, user.Email, user.PasswordHash, user.Role, AnyTime{}, user.CreatedBy, AnyTime{}, user.UpdatedBy).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.Create(user)
+	assert.NoError(t, err)
+	assert.False(t, user.CreatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test DB error
+	mock.ExpectExec(query).WillReturnError(errors.New("duplicate key"))
+	err = repo.Create(user)
+	assert.EqualError(t, err, "duplicate key")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetByID(t *testing.T) {
+	repo, mock := newMockUserRepo(t)
+	userID := uuid.New().String()
+
+	query := regexp.QuoteMeta(`SELECT id, tenant_id, username, email, password_hash, role, created_at, created_by, updated_at, updated_by, failed_logins, locked_until FROM app_user WHERE id = $1`)
+	mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(sqlmock.NewRows(userColumnNames).
+		AddRow(userID, model.DefaultTenantID, "jdoe", "jdoe@example.com", "hash", model.RoleViewer, time.Now(), "system", time.Now(), "system", 0, nil))
+
+	user, err := repo.GetByID(userID)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	assert.Equal(t, "hash", user.PasswordHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test Not Found
+	mock.ExpectQuery(query).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	user, err = repo.GetByID("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, user)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetByUsername(t *testing.T) {
+	repo, mock := newMockUserRepo(t)
+
+	query := regexp.QuoteMeta(`SELECT id, tenant_id, username, email, password_hash, role, created_at, created_by, updated_at, updated_by, failed_logins, locked_until FROM app_user WHERE username = $1`)
+	mock.ExpectQuery(query).WithArgs("jdoe").WillReturnRows(sqlmock.NewRows(userColumnNames).
+		AddRow(uuid.New().String(), model.DefaultTenantID, "jdoe", "jdoe@example.com", "hash", model.RoleViewer, time.Now(), "system", time.Now(), "system", 0, nil))
+
+	user, err := repo.GetByUsername("jdoe")
+	assert.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test DB error
+	mock.ExpectQuery(query).WithArgs("jdoe").WillReturnError(errors.New("connection refused"))
+	user, err = repo.GetByUsername("jdoe")
+	assert.EqualError(t, err, "connection refused")
+	assert.Nil(t, user)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_RecordFailedLogin(t *testing.T) {
+	repo, mock := newMockUserRepo(t)
+	query := regexp.QuoteMeta(`UPDATE app_user SET`)
+
+	mock.ExpectQuery(query).WithArgs(5, 900.0, "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"locked_until"}).AddRow(nil))
+	lockedUntil, err := repo.RecordFailedLogin("user-1", 5, 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, lockedUntil)
+
+	// The fifth failure locks the account
+	until := time.Now().Add(15 * time.Minute)
+	mock.ExpectQuery(query).WithArgs(5, 900.0, "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"locked_until"}).AddRow(until))
+	lockedUntil, err = repo.RecordFailedLogin("user-1", 5, 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, &until, lockedUntil)
+
+	mock.ExpectQuery(query).WithArgs(5, 900.0, "missing").WillReturnRows(sqlmock.NewRows([]string{"locked_until"}))
+	_, err = repo.RecordFailedLogin("missing", 5, 15*time.Minute)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	repo, mock := newMockUserRepo(t)
+	query := regexp.QuoteMeta(`UPDATE app_user SET password_hash = $1, updated_at = now(), updated_by = $2 WHERE id = $3`)
+
+	mock.ExpectExec(query).WithArgs("$argon2id$new", "jdoe", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UpdatePassword("user-1", "$argon2id$new", "jdoe"))
+
+	mock.ExpectExec(query).WithArgs("$argon2id$new", "jdoe", "missing").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.UpdatePassword("missing", "$argon2id$new", "jdoe"), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
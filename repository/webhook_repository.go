@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// WebhookRepository defines the interface for webhook subscription and
+// delivery data operations. Subscriptions and their delivery logs are read and
+// written within a tenant; the dispatcher methods, QueueDeliveries,
+// ClaimDueDeliveries and RecordAttempt, work across tenants.
+type WebhookRepository interface {
+	Create(sub *model.WebhookSubscription) error
+	GetByID(id string) (*model.WebhookSubscription, error)
+	GetAll() ([]model.WebhookSubscription, error)
+	Update(id string, sub *model.WebhookSubscription) error
+	Delete(id string) error
+	QueueDeliveries(event *model.Event, payload []byte) (int64, error)
+	ClaimDueDeliveries(limit int, lease time.Duration) ([]model.WebhookDispatch, error)
+	RecordAttempt(delivery *model.WebhookDelivery) error
+	GetDeliveries(subscriptionID, status string, limit int) ([]model.WebhookDelivery, error)
+	Redeliver(subscriptionID, deliveryID string) (*model.WebhookDelivery, error)
+	WithTx(tx *sqlx.Tx) WebhookRepository
+	WithTenant(tenantID string) WebhookRepository
+}
+
+type webhookRepository struct {
+	db       DBTX
+	tenantID string
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository
+func NewWebhookRepository(db *sqlx.DB) WebhookRepository {
+	return &webhookRepository{db: db, tenantID: model.DefaultTenantID}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *webhookRepository) WithTx(tx *sqlx.Tx) WebhookRepository {
+	return &webhookRepository{db: tx, tenantID: r.tenantID}
+}
+
+// WithTenant returns a copy of the repository that only reads and writes the
+// subscriptions of tenantID
+func (r *webhookRepository) WithTenant(tenantID string) WebhookRepository {
+	return &webhookRepository{db: r.db, tenantID: tenantID}
+}
+
+// subscriptionColumns omits the secret, which is only returned on create
+const subscriptionColumns = `id, tenant_id, url, event_types, description, active, created_at, created_by, updated_at, updated_by`
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_attempt_at, response_status, response_body, last_error, delivered_at, created_at`
+
+// Create adds a new webhook subscription
+func (r *webhookRepository) Create(sub *model.WebhookSubscription) error {
+	sub.TenantID = r.tenantID
+	sub.CreatedAt = time.Now()
+	sub.UpdatedAt = time.Now()
+
+	query := `INSERT INTO webhook_subscription (id, tenant_id, url, event_types, secret, description, active, created_at,
+	          created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(query, sub.ID, sub.TenantID, sub.URL, sub.EventTypes, sub.Secret, sub.Description, sub.Active,
+		sub.CreatedAt, sub.CreatedBy, sub.UpdatedAt, sub.UpdatedBy)
+	return err
+}
+
+// GetByID retrieves a webhook subscription by its ID, without its secret
+func (r *webhookRepository) GetByID(id string) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscription WHERE id = $1 AND tenant_id = $2`
+	err := r.db.Get(&sub, query, id, r.tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// GetAll retrieves all webhook subscriptions, without their secrets
+func (r *webhookRepository) GetAll() ([]model.WebhookSubscription, error) {
+	subs := []model.WebhookSubscription{}
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscription WHERE tenant_id = $1 ORDER BY created_at DESC, id`
+	if err := r.db.Select(&subs, query, r.tenantID); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// Update modifies a webhook subscription; an empty secret keeps the current one
+func (r *webhookRepository) Update(id string, sub *model.WebhookSubscription) error {
+	sub.UpdatedAt = time.Now()
+
+	query := `UPDATE webhook_subscription SET url = $1, event_types = $2, secret = COALESCE(NULLIF($3, ''), secret),
+	          description = $4, active = $5, updated_at = $6, updated_by = $7 WHERE id = $8 AND tenant_id = $9`
+	result, err := r.db.Exec(query, sub.URL, sub.EventTypes, sub.Secret, sub.Description, sub.Active,
+		sub.UpdatedAt, sub.UpdatedBy, id, r.tenantID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// Delete removes a webhook subscription and its delivery log
+func (r *webhookRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM webhook_subscription WHERE id = $1 AND tenant_id = $2`, id, r.tenantID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// QueueDeliveries queues payload for every active subscription of the
+// event's tenant to the event's type and returns the number of deliveries queued
+func (r *webhookRepository) QueueDeliveries(event *model.Event, payload []byte) (int64, error) {
+	query := `INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload, status, next_attempt_at)
+	          SELECT id, $1, $2, $3, $4, $5 FROM webhook_subscription
+	          WHERE active AND $2 = ANY(event_types) AND tenant_id = $6`
+	result, err := r.db.Exec(query, event.ID, event.Type, payload, model.DeliveryStatusPending, time.Now(), event.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ClaimDueDeliveries returns pending deliveries whose next attempt is due,
+// together with their endpoint. The next attempt of each claimed delivery is
+// pushed back by lease so other dispatchers leave it alone while it is sent;
+// if the dispatcher dies, the delivery becomes due again when the lease ends.
+func (r *webhookRepository) ClaimDueDeliveries(limit int, lease time.Duration) ([]model.WebhookDispatch, error) {
+	dispatches := []model.WebhookDispatch{}
+	now := time.Now()
+	query := `UPDATE webhook_delivery AS d SET next_attempt_at = $1
+	          FROM webhook_subscription AS s
+	          WHERE s.id = d.subscription_id AND s.active AND d.id IN (
+	            SELECT id FROM webhook_delivery WHERE status = $2 AND next_attempt_at <= $3
+	            ORDER BY next_attempt_at LIMIT $4 FOR UPDATE SKIP LOCKED)
+	          RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	            d.next_attempt_at, d.last_attempt_at, d.response_status, d.response_body, d.last_error,
+	            d.delivered_at, d.created_at, s.url, s.secret`
+	if err := r.db.Select(&dispatches, query, now.Add(lease), model.DeliveryStatusPending, now, limit); err != nil {
+		return nil, err
+	}
+	return dispatches, nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt
+func (r *webhookRepository) RecordAttempt(d *model.WebhookDelivery) error {
+	query := `UPDATE webhook_delivery SET status = $1, attempts = $2, next_attempt_at = $3, last_attempt_at = $4,
+	          response_status = $5, response_body = $6, last_error = $7, delivered_at = $8 WHERE id = $9`
+	result, err := r.db.Exec(query, d.Status, d.Attempts, d.NextAttemptAt, d.LastAttemptAt, d.ResponseStatus,
+		d.ResponseBody, d.LastError, d.DeliveredAt, d.ID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// GetDeliveries returns the most recent deliveries of a subscription,
+// optionally only those with the given status
+func (r *webhookRepository) GetDeliveries(subscriptionID, status string, limit int) ([]model.WebhookDelivery, error) {
+	deliveries := []model.WebhookDelivery{}
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_delivery
+	          WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+	          AND subscription_id IN (SELECT id FROM webhook_subscription WHERE tenant_id = $4)
+	          ORDER BY created_at DESC, id LIMIT $3`
+	if err := r.db.Select(&deliveries, query, subscriptionID, status, limit, r.tenantID); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Redeliver queues a new delivery of the same event and payload as an
+// existing delivery of the subscription; the original stays in the log
+func (r *webhookRepository) Redeliver(subscriptionID, deliveryID string) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	query := `INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload, status, next_attempt_at)
+	          SELECT subscription_id, event_id, event_type, payload, $1, $2 FROM webhook_delivery
+	          WHERE id = $3 AND subscription_id = $4
+	          AND subscription_id IN (SELECT id FROM webhook_subscription WHERE tenant_id = $5)
+	          RETURNING ` + deliveryColumns
+	err := r.db.Get(&delivery, query, model.DeliveryStatusPending, time.Now(), deliveryID, subscriptionID, r.tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// expectRows returns ErrNotFound when a statement affected no rows
+func expectRows(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
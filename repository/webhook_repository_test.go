@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRepository_Create(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db).WithTenant("tenant1")
+	sub := &model.WebhookSubscription{ID: "sub1", URL: "https://example.com/hook",
+		EventTypes: pq.StringArray{model.EventCarPriceChanged}, Secret: "s3cret", Active: true, CreatedBy: "admin", UpdatedBy: "admin"}
+
+	mock.ExpectExec(`INSERT INTO webhook_subscription`).
+		WithArgs("sub1", "tenant1", "https://example.com/hook", sub.EventTypes, "s3cret", "", true, AnyTime{}, "admin", AnyTime{}, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Create(sub))
+	assert.Equal(t, "tenant1", sub.TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_GetByID(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db)
+	columns := []string{"id", "url", "event_types", "description", "active", "created_at", "created_by", "updated_at", "updated_by"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, tenant_id, url, event_types, description, active, created_at, created_by, updated_at, updated_by FROM webhook_subscription WHERE id = $1 AND tenant_id = $2`)).
+		WithArgs("sub1", model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("sub1", "https://example.com/hook", "{car.price_changed,customer_car.created}",
+			"", true, time.Now(), "admin", time.Now(), "admin"))
+	sub, err := repo.GetByID("sub1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"car.price_changed", "customer_car.created"}, []string(sub.EventTypes))
+	assert.Empty(t, sub.Secret)
+
+	mock.ExpectQuery(`FROM webhook_subscription WHERE id = \$1 AND tenant_id = \$2`).WithArgs("missing", model.DefaultTenantID).WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetByID("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_Update(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db)
+	sub := &model.WebhookSubscription{URL: "https://example.com/v2", EventTypes: pq.StringArray{model.EventCarPriceChanged}, UpdatedBy: "admin"}
+
+	query := regexp.QuoteMeta(`secret = COALESCE(NULLIF($3, ''), secret)`)
+	mock.ExpectExec(query).WithArgs(sub.URL, sub.EventTypes, "", "", false, AnyTime{}, "admin", "sub1", model.DefaultTenantID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Update("sub1", sub))
+
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Update("missing", sub), ErrNotFound)
+
+	mock.ExpectExec(`DELETE FROM webhook_subscription WHERE id = \$1 AND tenant_id = \$2`).WithArgs("missing", model.DefaultTenantID).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Delete("missing"), ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_QueueDeliveries(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db)
+	event := &model.Event{ID: "e1", TenantID: "tenant1", Type: model.EventCustomerCarCreated}
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT id, $1, $2, $3, $4, $5 FROM webhook_subscription WHERE active AND $2 = ANY(event_types) AND tenant_id = $6`)).
+		WithArgs("e1", model.EventCustomerCarCreated, []byte(`{}`), model.DeliveryStatusPending, AnyTime{}, "tenant1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	queued, err := repo.QueueDeliveries(event, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), queued)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db)
+	columns := []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at",
+		"last_attempt_at", "response_status", "response_body", "last_error", "delivered_at", "created_at", "url", "secret"}
+
+	mock.ExpectQuery(`UPDATE webhook_delivery AS d SET next_attempt_at = \$1(.+)FOR UPDATE SKIP LOCKED(.+)RETURNING`).
+		WithArgs(AnyTime{}, model.DeliveryStatusPending, AnyTime{}, 20).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("d1", "sub1", "e1", model.EventCarPriceChanged, []byte(`{}`),
+			model.DeliveryStatusPending, 1, time.Now(), time.Now(), 503, "unavailable", "endpoint responded with status 503",
+			nil, time.Now(), "https://example.com/hook", "s3cret"))
+
+	dispatches, err := repo.ClaimDueDeliveries(20, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, dispatches, 1)
+	assert.Equal(t, "d1", dispatches[0].ID)
+	assert.Equal(t, 1, dispatches[0].Attempts)
+	assert.Nil(t, dispatches[0].DeliveredAt)
+	assert.Equal(t, "https://example.com/hook", dispatches[0].URL)
+	assert.Equal(t, "s3cret", dispatches[0].Secret)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_Redeliver(t *testing.T) {
+	db, mock := newMockBatchDB(t)
+	repo := NewWebhookRepository(db)
+	columns := []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at",
+		"last_attempt_at", "response_status", "response_body", "last_error", "delivered_at", "created_at"}
+
+	query := `INSERT INTO webhook_delivery (.+) SELECT subscription_id, event_id, event_type, payload, \$1, \$2 FROM webhook_delivery`
+	mock.ExpectQuery(query).WithArgs(model.DeliveryStatusPending, AnyTime{}, "d1", "sub1", model.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("d2", "sub1", "e1", model.EventCarPriceChanged, []byte(`{}`),
+			model.DeliveryStatusPending, 0, time.Now(), nil, 0, "", "", nil, time.Now()))
+	delivery, err := repo.Redeliver("sub1", "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "d2", delivery.ID)
+	assert.Equal(t, "e1", delivery.EventID)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.Redeliver("sub1", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("db error"))
+	_, err = repo.Redeliver("sub1", "d1")
+	assert.EqualError(t, err, "db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const tenantUsage = `Usage: goodschain tenant <command> [arguments]
+
+Commands:
+  create --name NAME
+         Create a tenant and print its ID.
+  list   List the tenants.
+`
+
+// runTenant executes the `tenant` subcommand
+func runTenant(cfg *config.Config, args []string) int {
+	if len(args) == 0 || (args[0] != "create" && args[0] != "list") {
+		fmt.Fprint(os.Stderr, tenantUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("tenant "+args[0], flag.ContinueOnError)
+	name := fs.String("name", "", "unique name of the tenant")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if args[0] == "create" && *name == "" {
+		log.Error().Msg("A tenant name is required")
+		return 2
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open database")
+		return 1
+	}
+	defer db.Close()
+
+	tenantRepo := repository.NewTenantRepository(db)
+	if args[0] == "list" {
+		tenants, err := tenantRepo.GetAll()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list tenants")
+			return 1
+		}
+		for _, tenant := range tenants {
+			fmt.Printf("%s\t%s\n", tenant.ID, tenant.Name)
+		}
+		return 0
+	}
+
+	tenant := &model.Tenant{ID: uuid.New().String(), Name: *name, CreatedBy: "cli", UpdatedBy: "cli"}
+	if err := tenantRepo.Create(tenant); err != nil {
+		log.Error().Err(err).Str("name", tenant.Name).Msg("Failed to create tenant")
+		return 1
+	}
+
+	log.Info().Str("id", tenant.ID).Str("name", tenant.Name).Msg("Tenant created")
+	fmt.Println(tenant.ID)
+	return 0
+}
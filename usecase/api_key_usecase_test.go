@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/auth"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyUsecase_IssueAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockAPIKeyRepository(ctrl)
+	uc := NewAPIKeyUsecase(mockRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(key *model.APIKey) error {
+			assert.NotEmpty(t, key.ID)
+			assert.Equal(t, "system", key.CreatedBy)
+			// Duplicate scopes are dropped
+			assert.Equal(t, []string{"cars:read"}, []string(key.Scopes))
+			assert.True(t, auth.VerifyAPIKey(key.KeyHash, key.Key))
+			return nil
+		})
+
+		key, err := uc.IssueAPIKey(&model.APIKeyRequest{Name: "erp", Scopes: []string{"cars:read", "cars:read"}})
+		require.NoError(t, err)
+		prefix, err := auth.APIKeyPrefix(key.Key)
+		require.NoError(t, err)
+		assert.Equal(t, key.Prefix, prefix)
+	})
+
+	t.Run("UnknownScope", func(t *testing.T) {
+		_, err := uc.IssueAPIKey(&model.APIKeyRequest{Name: "erp", Scopes: []string{"trucks:read"}})
+		assert.ErrorIs(t, err, ErrUnknownScope)
+	})
+
+	t.Run("ExpiresInThePast", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		_, err := uc.IssueAPIKey(&model.APIKeyRequest{Name: "erp", Scopes: []string{model.ScopeRead}, ExpiresAt: &expiresAt})
+		assert.ErrorIs(t, err, ErrAPIKeyExpiry)
+	})
+}
+
+func TestAPIKeyUsecase_RotateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockAPIKeyRepository(ctrl)
+	uc := NewAPIKeyUsecase(mockRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		current := &model.APIKey{ID: "key-1", Prefix: "0a1b2c3d", KeyHash: "old"}
+		mockRepo.EXPECT().GetByID("key-1").Return(current, nil)
+		mockRepo.EXPECT().Rotate("key-1", gomock.Any()).DoAndReturn(func(id string, key *model.APIKey) error {
+			assert.NotEqual(t, "old", key.KeyHash)
+			assert.Equal(t, "api_key:admin-key", key.UpdatedBy)
+			return nil
+		})
+
+		key, err := uc.RotateAPIKey("key-1", "api_key:admin-key")
+		require.NoError(t, err)
+		assert.True(t, auth.VerifyAPIKey(key.KeyHash, key.Key))
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockRepo.EXPECT().GetByID("key-1").Return(&model.APIKey{ID: "key-1", RevokedAt: &revokedAt}, nil)
+
+		_, err := uc.RotateAPIKey("key-1", "")
+		assert.ErrorIs(t, err, ErrAPIKeyRevoked)
+	})
+}
+
+func TestAPIKeyUsecase_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockAPIKeyRepository(ctrl)
+	uc := NewAPIKeyUsecase(mockRepo)
+
+	mockRepo.EXPECT().GetByID("key-1").Return(&model.APIKey{ID: "key-1"}, nil)
+	mockRepo.EXPECT().Revoke("key-1", "system").Return(nil)
+	assert.NoError(t, uc.RevokeAPIKey("key-1", ""))
+
+	mockRepo.EXPECT().GetByID("key-2").Return(nil, repository.ErrNotFound)
+	assert.ErrorIs(t, uc.RevokeAPIKey("key-2", ""), repository.ErrNotFound)
+}
+
+func TestAPIKeyUsecase_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockAPIKeyRepository(ctrl)
+	uc := NewAPIKeyUsecase(mockRepo)
+
+	raw, prefix, err := auth.NewAPIKey()
+	require.NoError(t, err)
+	stored := func() *model.APIKey {
+		return &model.APIKey{ID: "key-1", TenantID: "tenant1", Prefix: prefix, KeyHash: auth.HashAPIKey(raw), Scopes: []string{"cars:write"}}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(stored(), nil)
+		mockRepo.EXPECT().TouchLastUsed("key-1", gomock.Any()).Return(nil)
+
+		principal, err := uc.Authenticate(raw)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Principal{Type: model.PrincipalAPIKey, ID: "key-1", Actor: "api_key:key-1",
+			Scopes: []string{"cars:write"}, TenantID: "tenant1"}, principal)
+	})
+
+	t.Run("RecentlyUsed", func(t *testing.T) {
+		key := stored()
+		lastUsedAt := time.Now().Add(-time.Second)
+		key.LastUsedAt = &lastUsedAt
+		// Not touched again within lastUsedResolution
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(key, nil)
+
+		_, err := uc.Authenticate(raw)
+		assert.NoError(t, err)
+	})
+
+	t.Run("TouchFailureIgnored", func(t *testing.T) {
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(stored(), nil)
+		mockRepo.EXPECT().TouchLastUsed("key-1", gomock.Any()).Return(errors.New("database error"))
+
+		_, err := uc.Authenticate(raw)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := uc.Authenticate("not-a-key")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("UnknownPrefix", func(t *testing.T) {
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(nil, repository.ErrNotFound)
+
+		_, err := uc.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		other, _, err := auth.NewAPIKey()
+		require.NoError(t, err)
+		key := stored()
+		key.KeyHash = auth.HashAPIKey(other)
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(key, nil)
+
+		_, err = uc.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		key := stored()
+		expiresAt := time.Now().Add(-time.Minute)
+		key.ExpiresAt = &expiresAt
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(key, nil)
+
+		_, err := uc.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		key := stored()
+		revokedAt := time.Now()
+		key.RevokedAt = &revokedAt
+		mockRepo.EXPECT().GetByPrefix(prefix).Return(key, nil)
+
+		_, err := uc.Authenticate(raw)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+}
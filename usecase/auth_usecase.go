@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GoodsChain/backend/auth"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// Authentication errors
+var (
+	// ErrInvalidLogin is returned by Login for unknown usernames and wrong passwords alike
+	ErrInvalidLogin = errors.New("invalid username or password")
+	// ErrInvalidToken is returned for access and refresh tokens that are
+	// malformed, expired, used or of a revoked session
+	ErrInvalidToken = errors.New("invalid or expired token")
+	// ErrWrongPassword is returned when changing a password with a wrong current password
+	ErrWrongPassword = errors.New("current password is incorrect")
+)
+
+// AccountLockedError is returned by Login while an account is locked after
+// repeated failed logins. It is an ErrInvalidLogin, so that clients cannot
+// tell locked accounts, and thereby existing usernames, from others.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("account is locked until %s", e.Until.Format(time.RFC3339))
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrInvalidLogin
+}
+
+// AuthOptions configures token issuance and account lockout
+type AuthOptions struct {
+	Secret          []byte        // Key signing the access tokens
+	AccessTokenTTL  time.Duration // Lifetime of access tokens
+	RefreshTokenTTL time.Duration // Lifetime of each refresh token
+	MaxFailedLogins int           // Failed logins that lock an account; 0 never locks
+	LockoutDuration time.Duration // How long an account stays locked
+}
+
+// dummyHash is verified for unknown usernames, so they take as long to
+// reject as wrong passwords and cannot be told apart by timing
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("not-the-password-of-anyone")
+	return hash
+})
+
+// AuthUsecase defines the interface for password login and token business logic
+type AuthUsecase interface {
+	Login(username, password string) (*model.TokenResponse, error)
+	Refresh(refreshToken string) (*model.TokenResponse, error)
+	Logout(principal *model.Principal, all bool) error
+	ChangePassword(principal *model.Principal, currentPassword, newPassword string) error
+	Authenticate(accessToken string) (*model.Principal, error)
+}
+
+type authUsecase struct {
+	transactor  repository.Transactor
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	opts        AuthOptions
+}
+
+// NewAuthUsecase creates a new instance of AuthUsecase
+func NewAuthUsecase(transactor repository.Transactor, userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository, opts AuthOptions) AuthUsecase {
+	return &authUsecase{transactor: transactor, userRepo: userRepo, sessionRepo: sessionRepo, opts: opts}
+}
+
+// Login checks the password of a user and starts a session. Failed logins
+// count towards the lockout of the account.
+func (u *authUsecase) Login(username, password string) (*model.TokenResponse, error) {
+	user, err := u.userRepo.GetByUsername(username)
+	if errors.Is(err, repository.ErrNotFound) {
+		_, _ = auth.VerifyPassword(dummyHash(), password)
+		return nil, ErrInvalidLogin
+	} else if err != nil {
+		return nil, err
+	}
+	if user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		// Checked anyway so that locked accounts answer as slowly as others
+		_, _ = auth.VerifyPassword(user.PasswordHash, password)
+		log.Warn().Str("user_id", user.ID).Time("locked_until", *user.LockedUntil).Msg("Login to locked account")
+		return nil, &AccountLockedError{Until: *user.LockedUntil}
+	}
+
+	ok, err := auth.VerifyPassword(user.PasswordHash, password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if u.opts.MaxFailedLogins <= 0 {
+			return nil, ErrInvalidLogin
+		}
+		lockedUntil, err := u.userRepo.RecordFailedLogin(user.ID, u.opts.MaxFailedLogins, u.opts.LockoutDuration)
+		if err != nil {
+			return nil, err
+		}
+		if lockedUntil != nil {
+			log.Warn().Str("user_id", user.ID).Time("locked_until", *lockedUntil).Msg("Locked account after failed logins")
+			return nil, &AccountLockedError{Until: *lockedUntil}
+		}
+		return nil, ErrInvalidLogin
+	}
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := u.userRepo.ResetFailedLogins(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var tokens *model.TokenResponse
+	err = u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		session := &model.Session{ID: uuid.New().String(), UserID: user.ID}
+		if err := u.sessionRepo.WithTx(tx).CreateSession(session); err != nil {
+			return err
+		}
+		tokens, err = u.issueTokens(tx, user, session.ID)
+		return err
+	})
+	return tokens, err
+}
+
+// Refresh exchanges a refresh token for new tokens of the same session. A
+// refresh token can only be used once; using it again means it leaked, so
+// its session is revoked.
+func (u *authUsecase) Refresh(refreshToken string) (*model.TokenResponse, error) {
+	hash := auth.HashRefreshToken(refreshToken)
+	token, err := u.sessionRepo.GetRefreshToken(hash)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	session, err := u.sessionRepo.GetSession(token.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+	if token.UsedAt != nil {
+		if err := u.sessionRepo.RevokeSession(session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+	if !time.Now().Before(token.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	// Tokens carry the current username and role
+	user, err := u.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens *model.TokenResponse
+	err = u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := u.sessionRepo.WithTx(tx).UseRefreshToken(hash); errors.Is(err, repository.ErrNotFound) {
+			// Used by a concurrent request
+			return ErrInvalidToken
+		} else if err != nil {
+			return err
+		}
+		tokens, err = u.issueTokens(tx, user, session.ID)
+		return err
+	})
+	return tokens, err
+}
+
+// Logout revokes the session of a user, or all of them
+func (u *authUsecase) Logout(principal *model.Principal, all bool) error {
+	if all {
+		return u.sessionRepo.RevokeUserSessions(principal.ID)
+	}
+	return u.sessionRepo.RevokeSession(principal.SessionID)
+}
+
+// ChangePassword replaces the password of a user after checking the current
+// one, and revokes all sessions of the user
+func (u *authUsecase) ChangePassword(principal *model.Principal, currentPassword, newPassword string) error {
+	user, err := u.userRepo.GetByID(principal.ID)
+	if err != nil {
+		return err
+	}
+	ok, err := auth.VerifyPassword(user.PasswordHash, currentPassword)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWrongPassword
+	}
+	hash, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := u.userRepo.WithTx(tx).UpdatePassword(user.ID, hash, user.Username); err != nil {
+			return err
+		}
+		return u.sessionRepo.WithTx(tx).RevokeUserSessions(user.ID)
+	})
+}
+
+// Authenticate returns the principal of an access token whose session is
+// not revoked
+func (u *authUsecase) Authenticate(accessToken string) (*model.Principal, error) {
+	claims, err := auth.ParseToken(u.opts.Secret, accessToken, time.Now())
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	session, err := u.sessionRepo.GetSession(claims.SessionID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+	return &model.Principal{
+		Type:      model.PrincipalUser,
+		ID:        claims.Subject,
+		Actor:     claims.Name,
+		Scopes:    model.RoleScopes[claims.Role],
+		SessionID: claims.SessionID,
+		TenantID:  claims.TenantID,
+	}, nil
+}
+
+// issueTokens signs an access token of user for the session and adds a new
+// refresh token to it in tx
+func (u *authUsecase) issueTokens(tx *sqlx.Tx, user *model.User, sessionID string) (*model.TokenResponse, error) {
+	now := time.Now()
+	accessToken, err := auth.SignToken(u.opts.Secret, auth.Claims{
+		Subject:   user.ID,
+		Name:      user.Username,
+		Role:      user.Role,
+		SessionID: sessionID,
+		TenantID:  user.TenantID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(u.opts.AccessTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	token := &model.RefreshToken{
+		TokenHash: auth.HashRefreshToken(refreshToken),
+		SessionID: sessionID,
+		ExpiresAt: now.Add(u.opts.RefreshTokenTTL),
+	}
+	if err := u.sessionRepo.WithTx(tx).CreateRefreshToken(token); err != nil {
+		return nil, err
+	}
+	return &model.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(u.opts.AccessTokenTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/auth"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testAuthOptions = AuthOptions{
+	Secret:          []byte("a-secret-of-at-least-32-characters!"),
+	AccessTokenTTL:  15 * time.Minute,
+	RefreshTokenTTL: 24 * time.Hour,
+	MaxFailedLogins: 5,
+	LockoutDuration: 15 * time.Minute,
+}
+
+func newTestAuthUsecase(t *testing.T) (AuthUsecase, *mock.MockUserRepository, *mock.MockSessionRepository) {
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	userRepo := mock.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().WithTx(gomock.Any()).Return(userRepo).AnyTimes()
+	sessionRepo := mock.NewMockSessionRepository(ctrl)
+	sessionRepo.EXPECT().WithTx(gomock.Any()).Return(sessionRepo).AnyTimes()
+	return NewAuthUsecase(transactor, userRepo, sessionRepo, testAuthOptions), userRepo, sessionRepo
+}
+
+func TestAuthUsecase_Login(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery")
+	require.NoError(t, err)
+	user := func() *model.User {
+		return &model.User{ID: "user-1", TenantID: "tenant1", Username: "jdoe", Role: model.RoleEditor, PasswordHash: hash}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		uc, userRepo, sessionRepo := newTestAuthUsecase(t)
+		locked := user()
+		locked.FailedLogins = 2
+		userRepo.EXPECT().GetByUsername("jdoe").Return(locked, nil)
+		userRepo.EXPECT().ResetFailedLogins("user-1").Return(nil)
+		var sessionID string
+		sessionRepo.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(session *model.Session) error {
+			assert.Equal(t, "user-1", session.UserID)
+			sessionID = session.ID
+			return nil
+		})
+		sessionRepo.EXPECT().CreateRefreshToken(gomock.Any()).DoAndReturn(func(token *model.RefreshToken) error {
+			assert.Equal(t, sessionID, token.SessionID)
+			return nil
+		})
+
+		tokens, err := uc.Login("jdoe", "correct horse battery")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", tokens.TokenType)
+		assert.Equal(t, 900, tokens.ExpiresIn)
+		claims, err := auth.ParseToken(testAuthOptions.Secret, tokens.AccessToken, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, auth.Claims{Subject: "user-1", Name: "jdoe", Role: model.RoleEditor, SessionID: sessionID,
+			TenantID: "tenant1", IssuedAt: claims.IssuedAt, ExpiresAt: claims.IssuedAt + 900}, *claims)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		uc, userRepo, _ := newTestAuthUsecase(t)
+		userRepo.EXPECT().GetByUsername("nobody").Return(nil, repository.ErrNotFound)
+
+		_, err := uc.Login("nobody", "correct horse battery")
+		assert.ErrorIs(t, err, ErrInvalidLogin)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		uc, userRepo, _ := newTestAuthUsecase(t)
+		userRepo.EXPECT().GetByUsername("jdoe").Return(user(), nil)
+		userRepo.EXPECT().RecordFailedLogin("user-1", 5, 15*time.Minute).Return(nil, nil)
+
+		_, err := uc.Login("jdoe", "wrong password")
+		assert.ErrorIs(t, err, ErrInvalidLogin)
+	})
+
+	t.Run("WrongPasswordLocks", func(t *testing.T) {
+		uc, userRepo, _ := newTestAuthUsecase(t)
+		until := time.Now().Add(15 * time.Minute)
+		userRepo.EXPECT().GetByUsername("jdoe").Return(user(), nil)
+		userRepo.EXPECT().RecordFailedLogin("user-1", 5, 15*time.Minute).Return(&until, nil)
+
+		_, err := uc.Login("jdoe", "wrong password")
+		var lockedErr *AccountLockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.Equal(t, until, lockedErr.Until)
+		assert.ErrorIs(t, err, ErrInvalidLogin)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		uc, userRepo, _ := newTestAuthUsecase(t)
+		locked := user()
+		until := time.Now().Add(time.Minute)
+		locked.LockedUntil = &until
+		// Not even the right password gets in
+		userRepo.EXPECT().GetByUsername("jdoe").Return(locked, nil)
+
+		_, err := uc.Login("jdoe", "correct horse battery")
+		var lockedErr *AccountLockedError
+		assert.ErrorAs(t, err, &lockedErr)
+		assert.ErrorIs(t, err, ErrInvalidLogin)
+	})
+}
+
+func TestAuthUsecase_Refresh(t *testing.T) {
+	user := &model.User{ID: "user-1", Username: "jdoe", Role: model.RoleViewer}
+	hash := auth.HashRefreshToken("refresh-token")
+
+	t.Run("Success", func(t *testing.T) {
+		uc, userRepo, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetRefreshToken(hash).Return(&model.RefreshToken{TokenHash: hash, SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", UserID: "user-1"}, nil)
+		userRepo.EXPECT().GetByID("user-1").Return(user, nil)
+		sessionRepo.EXPECT().UseRefreshToken(hash).Return(nil)
+		sessionRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
+
+		tokens, err := uc.Refresh("refresh-token")
+		require.NoError(t, err)
+		assert.NotEqual(t, "refresh-token", tokens.RefreshToken)
+	})
+
+	t.Run("ReuseRevokesSession", func(t *testing.T) {
+		uc, _, sessionRepo := newTestAuthUsecase(t)
+		usedAt := time.Now().Add(-time.Minute)
+		sessionRepo.EXPECT().GetRefreshToken(hash).Return(&model.RefreshToken{TokenHash: hash, SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", UserID: "user-1"}, nil)
+		sessionRepo.EXPECT().RevokeSession("session-1").Return(nil)
+
+		_, err := uc.Refresh("refresh-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("ConcurrentUse", func(t *testing.T) {
+		uc, userRepo, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetRefreshToken(hash).Return(&model.RefreshToken{TokenHash: hash, SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", UserID: "user-1"}, nil)
+		userRepo.EXPECT().GetByID("user-1").Return(user, nil)
+		sessionRepo.EXPECT().UseRefreshToken(hash).Return(repository.ErrNotFound)
+
+		_, err := uc.Refresh("refresh-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		uc, _, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetRefreshToken(hash).Return(&model.RefreshToken{TokenHash: hash, SessionID: "session-1",
+			ExpiresAt: time.Now().Add(-time.Second)}, nil)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", UserID: "user-1"}, nil)
+
+		_, err := uc.Refresh("refresh-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		uc, _, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetRefreshToken(hash).Return(nil, repository.ErrNotFound)
+
+		_, err := uc.Refresh("refresh-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestAuthUsecase_Logout(t *testing.T) {
+	uc, _, sessionRepo := newTestAuthUsecase(t)
+	principal := &model.Principal{Type: model.PrincipalUser, ID: "user-1", SessionID: "session-1"}
+
+	sessionRepo.EXPECT().RevokeSession("session-1").Return(nil)
+	assert.NoError(t, uc.Logout(principal, false))
+
+	sessionRepo.EXPECT().RevokeUserSessions("user-1").Return(errors.New("database error"))
+	assert.EqualError(t, uc.Logout(principal, true), "database error")
+}
+
+func TestAuthUsecase_ChangePassword(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery")
+	require.NoError(t, err)
+	principal := &model.Principal{Type: model.PrincipalUser, ID: "user-1", SessionID: "session-1"}
+	user := &model.User{ID: "user-1", Username: "jdoe", PasswordHash: hash}
+
+	t.Run("Success", func(t *testing.T) {
+		uc, userRepo, sessionRepo := newTestAuthUsecase(t)
+		userRepo.EXPECT().GetByID("user-1").Return(user, nil)
+		userRepo.EXPECT().UpdatePassword("user-1", gomock.Any(), "jdoe").DoAndReturn(func(id, newHash, updatedBy string) error {
+			ok, err := auth.VerifyPassword(newHash, "a new long passphrase")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			return nil
+		})
+		sessionRepo.EXPECT().RevokeUserSessions("user-1").Return(nil)
+
+		assert.NoError(t, uc.ChangePassword(principal, "correct horse battery", "a new long passphrase"))
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		uc, userRepo, _ := newTestAuthUsecase(t)
+		userRepo.EXPECT().GetByID("user-1").Return(user, nil)
+
+		err := uc.ChangePassword(principal, "wrong password", "a new long passphrase")
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+}
+
+func TestAuthUsecase_Authenticate(t *testing.T) {
+	now := time.Now()
+	token, err := auth.SignToken(testAuthOptions.Secret, auth.Claims{Subject: "user-1", Name: "jdoe",
+		Role: model.RoleViewer, SessionID: "session-1", TenantID: "tenant1", IssuedAt: now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix()})
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		uc, _, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", UserID: "user-1"}, nil)
+
+		principal, err := uc.Authenticate(token)
+		require.NoError(t, err)
+		assert.Equal(t, &model.Principal{Type: model.PrincipalUser, ID: "user-1", Actor: "jdoe",
+			Scopes: []string{model.ScopeRead}, SessionID: "session-1", TenantID: "tenant1"}, principal)
+	})
+
+	t.Run("RevokedSession", func(t *testing.T) {
+		uc, _, sessionRepo := newTestAuthUsecase(t)
+		sessionRepo.EXPECT().GetSession("session-1").Return(&model.Session{ID: "session-1", RevokedAt: &now}, nil)
+
+		_, err := uc.Authenticate(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("BadSignature", func(t *testing.T) {
+		uc, _, _ := newTestAuthUsecase(t)
+
+		_, err := uc.Authenticate(token + "x")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -0,0 +1,621 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/validation"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Batch errors
+var (
+	// ErrUnknownBatchResource is returned for resources without batch support
+	ErrUnknownBatchResource = errors.New("resource does not support batch operations")
+	// ErrBatchTooLarge is returned when a batch has more operations than allowed
+	ErrBatchTooLarge = errors.New("too many operations in batch")
+)
+
+// errBatchRolledBack rolls back an atomic batch in which an operation failed
+var errBatchRolledBack = errors.New("batch rolled back")
+
+// maxBatchRun caps the rows per statement, keeping well below the PostgreSQL
+// limit of 65535 parameters
+const maxBatchRun = 500
+
+// BatchUsecase defines the interface for bulk create, update and delete
+type BatchUsecase interface {
+	Execute(resource string, req *model.BatchRequest) (*model.BatchResult, error)
+	WithTenant(tenantID string) BatchUsecase
+}
+
+type batchUsecase struct {
+	transactor      repository.Transactor
+	customerRepo    repository.CustomerRepository
+	supplierRepo    repository.SupplierRepository
+	carRepo         repository.CarRepository
+	customerCarRepo repository.CustomerCarRepository
+	outboxRepo      repository.OutboxRepository
+	resources       map[string]batchResource
+	maxOperations   int
+}
+
+// batchResource adapts a repository to batch execution. Records are pointers
+// to the resource model, e.g. *model.Car.
+type batchResource struct {
+	label string
+	// aggregate is the event aggregate type; when set, created, updated and
+	// deleted events are recorded for the operations applied
+	aggregate string
+	newRecord func() interface{}
+	// setMeta sets the ID and audit fields of a record
+	setMeta  func(record interface{}, id, actor string, create bool)
+	recordID func(record interface{}) string
+	create   func(tx *sqlx.Tx, records []interface{}) ([]string, error)
+	update   func(tx *sqlx.Tx, records []interface{}) ([]string, error)
+	delete   func(tx *sqlx.Tx, ids []string) ([]string, error)
+}
+
+// NewBatchUsecase creates a new instance of BatchUsecase. Batches with more
+// than maxOperations operations are rejected. Domain events of the applied
+// operations are appended to outboxRepo in the batch transaction.
+func NewBatchUsecase(transactor repository.Transactor, customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository, carRepo repository.CarRepository,
+	customerCarRepo repository.CustomerCarRepository, outboxRepo repository.OutboxRepository,
+	maxOperations int) BatchUsecase {
+	if maxOperations <= 0 {
+		maxOperations = 1000
+	}
+	return &batchUsecase{
+		transactor:      transactor,
+		customerRepo:    customerRepo,
+		supplierRepo:    supplierRepo,
+		carRepo:         carRepo,
+		customerCarRepo: customerCarRepo,
+		outboxRepo:      outboxRepo,
+		maxOperations:   maxOperations,
+		resources: map[string]batchResource{
+			"customers":     customerBatchResource(customerRepo),
+			"suppliers":     supplierBatchResource(supplierRepo),
+			"cars":          carBatchResource(carRepo, outboxRepo),
+			"customer-cars": customerCarBatchResource(customerCarRepo),
+		},
+	}
+}
+
+// WithTenant returns a copy of the usecase that only writes the records of tenantID
+func (u *batchUsecase) WithTenant(tenantID string) BatchUsecase {
+	return NewBatchUsecase(u.transactor, u.customerRepo.WithTenant(tenantID), u.supplierRepo.WithTenant(tenantID),
+		u.carRepo.WithTenant(tenantID), u.customerCarRepo.WithTenant(tenantID), u.outboxRepo.WithTenant(tenantID),
+		u.maxOperations)
+}
+
+// batchItem tracks one operation through validation and execution
+type batchItem struct {
+	index  int
+	op     string
+	id     string
+	record interface{}
+	err    *appErrors.AppError
+	fields []validation.FieldError
+}
+
+// Execute validates every operation and applies them in order in one
+// transaction. Consecutive operations of the same kind are written with a
+// single statement; if that statement fails, its operations are retried one
+// by one to find the failing ones. In atomic mode any failure rolls back the
+// whole batch; in best-effort mode the successful operations are committed.
+func (u *batchUsecase) Execute(resourceName string, req *model.BatchRequest) (*model.BatchResult, error) {
+	res, ok := u.resources[resourceName]
+	if !ok {
+		return nil, ErrUnknownBatchResource
+	}
+	if len(req.Operations) > u.maxOperations {
+		return nil, fmt.Errorf("%w: %d operations, at most %d allowed", ErrBatchTooLarge, len(req.Operations), u.maxOperations)
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = model.BatchModeAtomic
+	}
+	actor := req.Actor
+	if actor == "" {
+		actor = "system"
+	}
+
+	items := make([]*batchItem, len(req.Operations))
+	invalid := false
+	for i, op := range req.Operations {
+		items[i] = prepareBatchItem(res, i, op, actor)
+		invalid = invalid || items[i].err != nil
+	}
+	if invalid && mode == model.BatchModeAtomic {
+		return batchResult(mode, items, true), nil
+	}
+
+	err := u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		for _, run := range batchRuns(items) {
+			if err := u.executeBatchRun(tx, res, run); err != nil {
+				return err
+			}
+			if mode == model.BatchModeAtomic && hasFailed(run) {
+				return errBatchRolledBack
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRolledBack) {
+		return batchResult(mode, items, true), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return batchResult(mode, items, false), nil
+}
+
+// prepareBatchItem decodes and validates one operation
+func prepareBatchItem(res batchResource, index int, op model.BatchOperation, actor string) *batchItem {
+	item := &batchItem{index: index, op: op.Op, id: strings.TrimSpace(op.ID)}
+
+	if op.Op == model.BatchOpDelete {
+		if item.id == "" {
+			item.err = appErrors.NewInvalidInput("id is required for delete")
+		}
+		return item
+	}
+	if op.Op == model.BatchOpUpdate && item.id == "" {
+		item.err = appErrors.NewInvalidInput("id is required for update")
+		return item
+	}
+	if len(op.Data) == 0 || string(op.Data) == "null" {
+		item.err = appErrors.NewInvalidInput("data is required for " + op.Op)
+		return item
+	}
+
+	record := res.newRecord()
+	if err := json.Unmarshal(op.Data, record); err != nil {
+		item.err = appErrors.NewInvalidInput("data is not a valid " + res.label + ": " + err.Error())
+		return item
+	}
+	if fieldErrors := validation.Struct(record); len(fieldErrors) > 0 {
+		item.err = appErrors.NewInvalidInput(res.label + " failed validation")
+		item.fields = fieldErrors
+		return item
+	}
+
+	create := op.Op == model.BatchOpCreate
+	if dataID := res.recordID(record); create && item.id == "" {
+		item.id = dataID
+	} else if dataID != "" && dataID != item.id {
+		item.err = appErrors.NewInvalidInput("id in data does not match the operation id")
+		return item
+	}
+	if item.id == "" {
+		item.id = uuid.New().String()
+	}
+	res.setMeta(record, item.id, actor, create)
+	item.record = record
+	return item
+}
+
+// batchRuns groups consecutive valid operations of the same kind. A run ends
+// early when an ID repeats, so later operations on a record see earlier ones.
+func batchRuns(items []*batchItem) [][]*batchItem {
+	var runs [][]*batchItem
+	var run []*batchItem
+	ids := make(map[string]bool)
+	for _, item := range items {
+		if item.err != nil {
+			continue
+		}
+		if len(run) > 0 && (run[0].op != item.op || ids[item.id] || len(run) == maxBatchRun) {
+			runs = append(runs, run)
+			run = nil
+			ids = make(map[string]bool)
+		}
+		run = append(run, item)
+		ids[item.id] = true
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// executeBatchRun writes a run with one statement inside a savepoint. When
+// the statement fails, each operation is retried in its own savepoint so the
+// failure is attributed to the operations that caused it. Only database
+// errors outside the operations themselves are returned.
+func (u *batchUsecase) executeBatchRun(tx *sqlx.Tx, res batchResource, run []*batchItem) error {
+	err := u.transactor.WithinSavepoint(tx, func() error {
+		return u.applyBatchRun(tx, res, run)
+	})
+	if err == nil || !isOperationError(err) {
+		return err
+	}
+	if len(run) == 1 {
+		run[0].err = batchError(err)
+		return nil
+	}
+
+	for _, item := range run {
+		single := []*batchItem{item}
+		err := u.transactor.WithinSavepoint(tx, func() error {
+			return u.applyBatchRun(tx, res, single)
+		})
+		if err != nil {
+			if !isOperationError(err) {
+				return err
+			}
+			item.err = batchError(err)
+		}
+	}
+	return nil
+}
+
+// applyBatchRun writes the run, marks operations whose record was not found,
+// or not inserted because of a conflict, and records the events of the others
+func (u *batchUsecase) applyBatchRun(tx *sqlx.Tx, res batchResource, run []*batchItem) error {
+	var ids []string
+	var err error
+	switch run[0].op {
+	case model.BatchOpCreate:
+		records := make([]interface{}, len(run))
+		for i, item := range run {
+			records[i] = item.record
+		}
+		ids, err = res.create(tx, records)
+	case model.BatchOpUpdate:
+		records := make([]interface{}, len(run))
+		for i, item := range run {
+			records[i] = item.record
+		}
+		ids, err = res.update(tx, records)
+	case model.BatchOpDelete:
+		keys := make([]string, len(run))
+		for i, item := range run {
+			keys[i] = item.id
+		}
+		ids, err = res.delete(tx, keys)
+	default:
+		err = fmt.Errorf("unknown batch operation %q", run[0].op)
+	}
+	if err != nil {
+		return err
+	}
+
+	written := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		written[strings.ToLower(id)] = true
+	}
+	var events []*model.Event
+	for _, item := range run {
+		if written[strings.ToLower(item.id)] {
+			if res.aggregate == "" {
+				continue
+			}
+			event, err := entityEvent(res.aggregate, batchActions[item.op], item.id, item.record)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+			continue
+		}
+		if item.op == model.BatchOpCreate {
+			item.err = appErrors.New(appErrors.ErrAlreadyExists, fmt.Sprintf("%s with ID '%s' or the same unique fields already exists", res.label, item.id))
+		} else {
+			item.err = appErrors.NewNotFound(res.label, item.id)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return u.outboxRepo.WithTx(tx).Append(events...)
+}
+
+// batchActions maps batch operations to the actions of their events
+var batchActions = map[string]string{
+	model.BatchOpCreate: actionCreated,
+	model.BatchOpUpdate: actionUpdated,
+	model.BatchOpDelete: actionDeleted,
+}
+
+// isOperationError reports whether err was caused by the data written rather
+// than by the database connection
+func isOperationError(err error) bool {
+	return errors.Is(err, repository.ErrDuplicate) || errors.Is(err, repository.ErrReference) ||
+		errors.Is(err, repository.ErrInvalidValue)
+}
+
+// batchError converts a write error into the error reported for an operation
+func batchError(err error) *appErrors.AppError {
+	switch {
+	case errors.Is(err, repository.ErrDuplicate):
+		return appErrors.Wrap(err, appErrors.ErrAlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrReference), errors.Is(err, repository.ErrInvalidValue):
+		return appErrors.Wrap(err, appErrors.ErrInvalid, err.Error())
+	default:
+		return appErrors.NewInternalError(err)
+	}
+}
+
+func hasFailed(items []*batchItem) bool {
+	for _, item := range items {
+		if item.err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// batchResult builds the response; when rolledBack is set, operations that
+// did not fail themselves are reported as aborted
+func batchResult(mode string, items []*batchItem, rolledBack bool) *model.BatchResult {
+	result := &model.BatchResult{Mode: mode, Results: make([]model.BatchItemResult, len(items))}
+	for i, item := range items {
+		r := model.BatchItemResult{Index: item.index, Op: item.op, ID: item.id}
+		switch {
+		case item.err != nil:
+			r.Status, r.Code, r.Message, r.Errors = item.err.HTTPCode, item.err.Code, item.err.Message, item.fields
+		case rolledBack:
+			aborted := appErrors.New(appErrors.ErrAborted, "Not applied because another operation in the batch failed")
+			r.Status, r.Code, r.Message = aborted.HTTPCode, aborted.Code, aborted.Message
+		case item.op == model.BatchOpCreate:
+			r.Status = http.StatusCreated
+		default:
+			r.Status = http.StatusOK
+		}
+
+		if r.Code == "" {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+		result.Results[i] = r
+	}
+	return result
+}
+
+func customerBatchResource(repo repository.CustomerRepository) batchResource {
+	return batchResource{
+		label:     "Customer",
+		aggregate: aggregateCustomer,
+		newRecord: func() interface{} { return &model.Customer{} },
+		setMeta: func(record interface{}, id, actor string, create bool) {
+			c := record.(*model.Customer)
+			c.ID, c.UpdatedBy = id, actor
+			if create {
+				c.CreatedBy = actor
+			}
+		},
+		recordID: func(record interface{}) string { return record.(*model.Customer).ID },
+		create: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			customers := make([]*model.Customer, len(records))
+			for i, r := range records {
+				customers[i] = r.(*model.Customer)
+			}
+			return repo.WithTx(tx).CreateBatch(customers)
+		},
+		update: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			customers := make([]*model.Customer, len(records))
+			ids := make([]string, len(records))
+			for i, r := range records {
+				customers[i] = r.(*model.Customer)
+				ids[i] = customers[i].ID
+			}
+
+			// Read the creation fields first so the update events carry the whole record
+			customerRepo := repo.WithTx(tx)
+			current, err := customerRepo.GetByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			updated, err := customerRepo.UpdateBatch(customers)
+			if err != nil {
+				return nil, err
+			}
+			for _, old := range current {
+				for _, customer := range customers {
+					if strings.EqualFold(customer.ID, old.ID) {
+						customer.CreatedAt, customer.CreatedBy = old.CreatedAt, old.CreatedBy
+					}
+				}
+			}
+			return updated, nil
+		},
+		delete: func(tx *sqlx.Tx, ids []string) ([]string, error) {
+			return repo.WithTx(tx).DeleteBatch(ids)
+		},
+	}
+}
+
+func supplierBatchResource(repo repository.SupplierRepository) batchResource {
+	return batchResource{
+		label:     "Supplier",
+		aggregate: aggregateSupplier,
+		newRecord: func() interface{} { return &model.Supplier{} },
+		setMeta: func(record interface{}, id, actor string, create bool) {
+			s := record.(*model.Supplier)
+			s.ID, s.UpdatedBy = id, actor
+			if create {
+				s.CreatedBy = actor
+			}
+		},
+		recordID: func(record interface{}) string { return record.(*model.Supplier).ID },
+		create: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			suppliers := make([]*model.Supplier, len(records))
+			for i, r := range records {
+				suppliers[i] = r.(*model.Supplier)
+			}
+			return repo.WithTx(tx).CreateBatch(suppliers)
+		},
+		update: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			suppliers := make([]*model.Supplier, len(records))
+			ids := make([]string, len(records))
+			for i, r := range records {
+				suppliers[i] = r.(*model.Supplier)
+				ids[i] = suppliers[i].ID
+			}
+
+			// Read the creation fields first so the update events carry the whole record
+			supplierRepo := repo.WithTx(tx)
+			current, err := supplierRepo.GetByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			updated, err := supplierRepo.UpdateBatch(suppliers)
+			if err != nil {
+				return nil, err
+			}
+			for _, old := range current {
+				for _, supplier := range suppliers {
+					if strings.EqualFold(supplier.ID, old.ID) {
+						supplier.CreatedAt, supplier.CreatedBy = old.CreatedAt, old.CreatedBy
+					}
+				}
+			}
+			return updated, nil
+		},
+		delete: func(tx *sqlx.Tx, ids []string) ([]string, error) {
+			return repo.WithTx(tx).DeleteBatch(ids)
+		},
+	}
+}
+
+func carBatchResource(repo repository.CarRepository, outboxRepo repository.OutboxRepository) batchResource {
+	return batchResource{
+		label:     "Car",
+		aggregate: aggregateCar,
+		newRecord: func() interface{} { return &model.Car{} },
+		setMeta: func(record interface{}, id, actor string, create bool) {
+			c := record.(*model.Car)
+			c.ID, c.UpdatedBy = id, actor
+			if create {
+				c.CreatedBy = actor
+			}
+		},
+		recordID: func(record interface{}) string { return record.(*model.Car).ID },
+		create: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			cars := make([]*model.Car, len(records))
+			for i, r := range records {
+				cars[i] = r.(*model.Car)
+			}
+			return repo.WithTx(tx).CreateBatch(cars)
+		},
+		update: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			cars := make([]*model.Car, len(records))
+			ids := make([]string, len(records))
+			for i, r := range records {
+				cars[i] = r.(*model.Car)
+				ids[i] = cars[i].ID
+			}
+
+			// Read the current prices first to record price changes
+			carRepo := repo.WithTx(tx)
+			current, err := carRepo.GetCarsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			updated, err := carRepo.UpdateBatch(cars)
+			if err != nil {
+				return nil, err
+			}
+
+			previous := make(map[string]model.Car, len(current))
+			for _, car := range current {
+				previous[strings.ToLower(car.ID)] = car
+			}
+			var events []*model.Event
+			for _, id := range updated {
+				old, ok := previous[strings.ToLower(id)]
+				car := findCar(cars, id)
+				if !ok || car == nil {
+					continue
+				}
+				car.CreatedAt, car.CreatedBy = old.CreatedAt, old.CreatedBy
+				if old.Price == car.Price {
+					continue
+				}
+				event, err := carPriceChangedEvent(car, old.Price)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+			return updated, outboxRepo.WithTx(tx).Append(events...)
+		},
+		delete: func(tx *sqlx.Tx, ids []string) ([]string, error) {
+			return repo.WithTx(tx).DeleteBatch(ids)
+		},
+	}
+}
+
+func customerCarBatchResource(repo repository.CustomerCarRepository) batchResource {
+	return batchResource{
+		label:     "Customer car",
+		aggregate: aggregateCustomerCar,
+		newRecord: func() interface{} { return &model.CustomerCar{} },
+		setMeta: func(record interface{}, id, actor string, create bool) {
+			cc := record.(*model.CustomerCar)
+			cc.ID, cc.UpdatedBy = id, actor
+			if create {
+				cc.CreatedBy = actor
+			}
+		},
+		recordID: func(record interface{}) string { return record.(*model.CustomerCar).ID },
+		create: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			customerCars := make([]*model.CustomerCar, len(records))
+			for i, r := range records {
+				customerCars[i] = r.(*model.CustomerCar)
+			}
+			return repo.WithTx(tx).CreateBatch(customerCars)
+		},
+		update: func(tx *sqlx.Tx, records []interface{}) ([]string, error) {
+			customerCars := make([]*model.CustomerCar, len(records))
+			ids := make([]string, len(records))
+			for i, r := range records {
+				customerCars[i] = r.(*model.CustomerCar)
+				ids[i] = customerCars[i].ID
+			}
+
+			// Read the creation fields first so the update events carry the whole record
+			customerCarRepo := repo.WithTx(tx)
+			current, err := customerCarRepo.GetByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			updated, err := customerCarRepo.UpdateBatch(customerCars)
+			if err != nil {
+				return nil, err
+			}
+			for _, old := range current {
+				for _, customerCar := range customerCars {
+					if strings.EqualFold(customerCar.ID, old.ID) {
+						customerCar.CreatedAt, customerCar.CreatedBy = old.CreatedAt, old.CreatedBy
+					}
+				}
+			}
+			return updated, nil
+		},
+		delete: func(tx *sqlx.Tx, ids []string) ([]string, error) {
+			return repo.WithTx(tx).DeleteBatch(ids)
+		},
+	}
+}
+
+// findCar returns the car with the given ID, compared case-insensitively
+func findCar(cars []*model.Car, id string) *model.Car {
+	for _, car := range cars {
+		if strings.EqualFold(car.ID, id) {
+			return car
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type batchMocks struct {
+	carRepo         *mock.MockCarRepository
+	customerCarRepo *mock.MockCustomerCarRepository
+	outboxRepo      *mock.MockOutboxRepository
+}
+
+func newTestBatchUsecase(t *testing.T, maxOperations int) (BatchUsecase, batchMocks) {
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	m := batchMocks{
+		carRepo:         mock.NewMockCarRepository(ctrl),
+		customerCarRepo: mock.NewMockCustomerCarRepository(ctrl),
+		outboxRepo:      mock.NewMockOutboxRepository(ctrl),
+	}
+	uc := NewBatchUsecase(transactor, mock.NewMockCustomerRepository(ctrl), mock.NewMockSupplierRepository(ctrl),
+		m.carRepo, m.customerCarRepo, m.outboxRepo, maxOperations)
+
+	// Transactions and savepoints run the callback directly
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	transactor.EXPECT().WithinSavepoint(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *sqlx.Tx, fn func() error) error {
+		return fn()
+	}).AnyTimes()
+	m.carRepo.EXPECT().WithTx(gomock.Any()).Return(m.carRepo).AnyTimes()
+	m.customerCarRepo.EXPECT().WithTx(gomock.Any()).Return(m.customerCarRepo).AnyTimes()
+	m.outboxRepo.EXPECT().WithTx(gomock.Any()).Return(m.outboxRepo).AnyTimes()
+	return uc, m
+}
+
+func carOp(op, id, name string, price int) model.BatchOperation {
+	data, _ := json.Marshal(map[string]interface{}{"name": name, "supplier_id": "supp1", "price": price})
+	return model.BatchOperation{Op: op, ID: id, Data: data}
+}
+
+func carIDs(cars []*model.Car) []string {
+	ids := make([]string, len(cars))
+	for i, car := range cars {
+		ids[i] = car.ID
+	}
+	return ids
+}
+
+func TestBatchUsecase_Execute(t *testing.T) {
+	t.Run("UnknownResource", func(t *testing.T) {
+		uc, _ := newTestBatchUsecase(t, 10)
+		_, err := uc.Execute("users", &model.BatchRequest{Operations: []model.BatchOperation{{Op: "delete", ID: "u1"}}})
+		assert.ErrorIs(t, err, ErrUnknownBatchResource)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		uc, _ := newTestBatchUsecase(t, 1)
+		_, err := uc.Execute("cars", &model.BatchRequest{Operations: []model.BatchOperation{
+			{Op: "delete", ID: "car1"}, {Op: "delete", ID: "car2"},
+		}})
+		assert.ErrorIs(t, err, ErrBatchTooLarge)
+	})
+
+	t.Run("AtomicSuccess", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		gomock.InOrder(
+			m.carRepo.EXPECT().CreateBatch(gomock.Any()).DoAndReturn(func(cars []*model.Car) ([]string, error) {
+				assert.Equal(t, []string{"car1", "car2"}, carIDs(cars))
+				assert.Equal(t, "alice", cars[0].CreatedBy)
+				assert.Equal(t, "alice", cars[0].UpdatedBy)
+				return []string{"car1", "car2"}, nil
+			}),
+			m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+				require.Len(t, events, 2)
+				assert.Equal(t, model.EventCarCreated, events[0].Type)
+				assert.Equal(t, "car2", events[1].AggregateID)
+				return nil
+			}),
+			m.carRepo.EXPECT().GetCarsByIDs([]string{"car1"}).Return([]model.Car{{ID: "car1", Price: 25000, CreatedBy: "alice"}}, nil),
+			m.carRepo.EXPECT().UpdateBatch(gomock.Any()).DoAndReturn(func(cars []*model.Car) ([]string, error) {
+				assert.Equal(t, []string{"car1"}, carIDs(cars))
+				assert.Empty(t, cars[0].CreatedBy)
+				return []string{"car1"}, nil
+			}),
+			m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+				require.Len(t, events, 1)
+				assert.Equal(t, model.EventCarPriceChanged, events[0].Type)
+				assert.Equal(t, "car1", events[0].AggregateID)
+				return nil
+			}),
+			m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+				require.Len(t, events, 1)
+				assert.Equal(t, model.EventCarUpdated, events[0].Type)
+				assert.JSONEq(t, `"alice"`, string(mustJSONField(t, events[0].Data, "created_by")))
+				return nil
+			}),
+			m.carRepo.EXPECT().DeleteBatch([]string{"car3"}).Return([]string{"car3"}, nil),
+			m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+				require.Len(t, events, 1)
+				assert.Equal(t, model.EventCarDeleted, events[0].Type)
+				return nil
+			}),
+		)
+
+		result, err := uc.Execute("cars", &model.BatchRequest{Actor: "alice", Operations: []model.BatchOperation{
+			carOp("create", "car1", "Camry", 25000),
+			carOp("create", "car2", "Civic", 22000),
+			carOp("update", "car1", "Camry Hybrid", 27000),
+			{Op: "delete", ID: "car3"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, model.BatchModeAtomic, result.Mode)
+		assert.Equal(t, 4, result.Succeeded)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, http.StatusCreated, result.Results[0].Status)
+		assert.Equal(t, http.StatusOK, result.Results[3].Status)
+	})
+
+	t.Run("RepeatedIDStartsNewRun", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		m.carRepo.EXPECT().DeleteBatch([]string{"car1"}).Return([]string{"car1"}, nil).Times(2)
+		m.outboxRepo.EXPECT().Append(gomock.Any()).Return(nil).Times(2)
+
+		result, err := uc.Execute("cars", &model.BatchRequest{Mode: model.BatchModeBestEffort, Operations: []model.BatchOperation{
+			{Op: "delete", ID: "car1"}, {Op: "delete", ID: "car1"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Succeeded)
+	})
+
+	t.Run("AtomicInvalidOperation", func(t *testing.T) {
+		uc, _ := newTestBatchUsecase(t, 10)
+		// Nothing is written when an operation fails validation
+		result, err := uc.Execute("cars", &model.BatchRequest{Operations: []model.BatchOperation{
+			carOp("create", "car1", "Camry", 25000),
+			carOp("create", "car2", "", -1),
+			{Op: "update", ID: "car3"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Succeeded)
+		assert.Equal(t, 3, result.Failed)
+		assert.Equal(t, appErrors.ErrAborted, result.Results[0].Code)
+		assert.Equal(t, http.StatusFailedDependency, result.Results[0].Status)
+		assert.Equal(t, appErrors.ErrInvalid, result.Results[1].Code)
+		assert.Len(t, result.Results[1].Errors, 2)
+		assert.Equal(t, "Car failed validation", result.Results[1].Message)
+		assert.Equal(t, "data is required for update", result.Results[2].Message)
+	})
+
+	t.Run("AtomicRollback", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		m.carRepo.EXPECT().CreateBatch(gomock.Any()).Return([]string{"car1"}, nil)
+		m.outboxRepo.EXPECT().Append(gomock.Any()).Return(nil)
+
+		result, err := uc.Execute("cars", &model.BatchRequest{Operations: []model.BatchOperation{
+			carOp("create", "car1", "Camry", 25000),
+			carOp("create", "car2", "Civic", 22000),
+			{Op: "delete", ID: "car3"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Failed)
+		assert.Equal(t, appErrors.ErrAborted, result.Results[0].Code)
+		assert.Equal(t, appErrors.ErrAlreadyExists, result.Results[1].Code)
+		assert.Equal(t, http.StatusConflict, result.Results[1].Status)
+		assert.Equal(t, appErrors.ErrAborted, result.Results[2].Code)
+	})
+
+	t.Run("BestEffortRetriesOneByOne", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		fkErr := fmt.Errorf("%w: violates foreign key constraint", repository.ErrReference)
+		gomock.InOrder(
+			m.carRepo.EXPECT().CreateBatch(gomock.Len(2)).Return(nil, fkErr),
+			m.carRepo.EXPECT().CreateBatch(gomock.Len(1)).Return([]string{"car1"}, nil),
+			m.outboxRepo.EXPECT().Append(gomock.Len(1)).Return(nil),
+			m.carRepo.EXPECT().CreateBatch(gomock.Len(1)).Return(nil, fkErr),
+			m.carRepo.EXPECT().DeleteBatch([]string{"car3"}).Return([]string{}, nil),
+		)
+
+		result, err := uc.Execute("cars", &model.BatchRequest{Mode: model.BatchModeBestEffort, Operations: []model.BatchOperation{
+			carOp("create", "car1", "Camry", 25000),
+			carOp("create", "car2", "Civic", 22000),
+			{Op: "delete", ID: "car3"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, http.StatusCreated, result.Results[0].Status)
+		assert.Equal(t, appErrors.ErrInvalid, result.Results[1].Code)
+		assert.Equal(t, appErrors.ErrNotFound, result.Results[2].Code)
+	})
+
+	t.Run("CustomerCarEvents", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		link := func(id string) model.BatchOperation {
+			return model.BatchOperation{Op: "create", ID: id, Data: json.RawMessage(`{"car_id":"car1","customer_id":"cust1"}`)}
+		}
+		// Only links actually inserted get an event
+		m.customerCarRepo.EXPECT().CreateBatch(gomock.Len(2)).Return([]string{"cc2"}, nil)
+		m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, model.EventCustomerCarCreated, events[0].Type)
+			assert.Equal(t, "cc2", events[0].AggregateID)
+			return nil
+		})
+
+		result, err := uc.Execute("customer-cars", &model.BatchRequest{Mode: model.BatchModeBestEffort,
+			Operations: []model.BatchOperation{link("cc1"), link("cc2")}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, appErrors.ErrAlreadyExists, result.Results[0].Code)
+	})
+
+	t.Run("CustomerCarUpdateEvents", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		gomock.InOrder(
+			m.customerCarRepo.EXPECT().GetByIDs([]string{"cc1"}).Return([]*model.CustomerCar{{ID: "cc1", CreatedBy: "bob"}}, nil),
+			m.customerCarRepo.EXPECT().UpdateBatch(gomock.Len(1)).Return([]string{"cc1"}, nil),
+			m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+				require.Len(t, events, 1)
+				assert.Equal(t, model.EventCustomerCarUpdated, events[0].Type)
+				// The event carries the creation fields read before the update
+				assert.JSONEq(t, `"bob"`, string(mustJSONField(t, events[0].Data, "created_by")))
+				return nil
+			}),
+		)
+
+		result, err := uc.Execute("customer-cars", &model.BatchRequest{Operations: []model.BatchOperation{
+			{Op: "update", ID: "cc1", Data: json.RawMessage(`{"car_id":"car2","customer_id":"cust1"}`)},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		uc, m := newTestBatchUsecase(t, 10)
+		m.carRepo.EXPECT().DeleteBatch(gomock.Any()).Return(nil, errors.New("connection reset"))
+
+		_, err := uc.Execute("cars", &model.BatchRequest{Operations: []model.BatchOperation{{Op: "delete", ID: "car1"}}})
+		assert.EqualError(t, err, "connection reset")
+	})
+}
@@ -1,28 +1,59 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/GoodsChain/backend/model"
 	"github.com/GoodsChain/backend/repository"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	// "time" // Not strictly needed here if repository handles timestamps
 )
 
+// MaxComparedCars is the most cars CompareCars compares at once
+const MaxComparedCars = 5
+
+var (
+	// ErrInvalidSpecs is returned when the trim or the specs of a car do not
+	// fit the catalog
+	ErrInvalidSpecs = errors.New("invalid car specification")
+	// ErrComparedCars is returned when a comparison has too few or too many cars
+	ErrComparedCars = fmt.Errorf("compare 2 to %d different cars", MaxComparedCars)
+)
+
 // CarUsecase defines the interface for car business logic
 type CarUsecase interface {
 	CreateCar(car *model.Car) error
 	GetCar(id string) (*model.Car, error)
-	GetAllCars() ([]model.Car, error)
+	GetCarsByIDs(ids []string) ([]model.Car, error)
+	GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error)
+	GetAllCars(filter model.CarFilter) ([]model.Car, error)
+	StreamCars(filter model.CarFilter, fn func(car *model.Car) error) error
 	UpdateCar(id string, car *model.Car) error
 	DeleteCar(id string) error
+	CompareCars(ids []string) (*model.CarComparison, error)
+	WithTenant(tenantID string) CarUsecase
 }
 
 type carUsecase struct {
-	carRepo repository.CarRepository
+	carRepo     repository.CarRepository
+	catalogRepo repository.CatalogRepository
+	transactor  repository.Transactor
+	outboxRepo  repository.OutboxRepository
 }
 
-// NewCarUsecase creates a new instance of CarUsecase
-func NewCarUsecase(carRepo repository.CarRepository) CarUsecase {
-	return &carUsecase{carRepo: carRepo}
+// NewCarUsecase creates a new instance of CarUsecase. The specs of cars are
+// checked against the schema of the category of their trim in catalogRepo.
+func NewCarUsecase(carRepo repository.CarRepository, catalogRepo repository.CatalogRepository,
+	transactor repository.Transactor, outboxRepo repository.OutboxRepository) CarUsecase {
+	return &carUsecase{carRepo: carRepo, catalogRepo: catalogRepo, transactor: transactor, outboxRepo: outboxRepo}
+}
+
+// WithTenant returns a copy of the usecase that only manages the cars of tenantID
+func (uc *carUsecase) WithTenant(tenantID string) CarUsecase {
+	return &carUsecase{carRepo: uc.carRepo.WithTenant(tenantID), catalogRepo: uc.catalogRepo.WithTenant(tenantID),
+		transactor: uc.transactor, outboxRepo: uc.outboxRepo.WithTenant(tenantID)}
 }
 
 // CreateCar handles the business logic for creating a new car
@@ -44,7 +75,19 @@ func (uc *carUsecase) CreateCar(car *model.Car) error {
 		car.UpdatedBy = "system" // Or get from context
 	}
 
-	return uc.carRepo.CreateCar(car)
+	return uc.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := checkSpecs(uc.catalogRepo.WithTx(tx), car); err != nil {
+			return err
+		}
+		if err := uc.carRepo.WithTx(tx).CreateCar(car); err != nil {
+			return err
+		}
+		event, err := entityEvent(aggregateCar, actionCreated, car.ID, car)
+		if err != nil {
+			return err
+		}
+		return uc.outboxRepo.WithTx(tx).Append(event)
+	})
 }
 
 // GetCar retrieves a car by its ID
@@ -52,12 +95,30 @@ func (uc *carUsecase) GetCar(id string) (*model.Car, error) {
 	return uc.carRepo.GetCarByID(id)
 }
 
-// GetAllCars retrieves all cars
-func (uc *carUsecase) GetAllCars() ([]model.Car, error) {
-	return uc.carRepo.GetAllCars()
+// GetCarsByIDs retrieves the cars with the given IDs; missing IDs are left out
+func (uc *carUsecase) GetCarsByIDs(ids []string) ([]model.Car, error) {
+	return uc.carRepo.GetCarsByIDs(ids)
+}
+
+// GetCarsBySupplierIDs retrieves the cars of all the given suppliers
+func (uc *carUsecase) GetCarsBySupplierIDs(supplierIDs []string) ([]model.Car, error) {
+	return uc.carRepo.GetCarsBySupplierIDs(supplierIDs)
 }
 
-// UpdateCar handles the business logic for updating an existing car
+// GetAllCars retrieves all cars matching filter
+func (uc *carUsecase) GetAllCars(filter model.CarFilter) ([]model.Car, error) {
+	return uc.carRepo.GetAllCars(filter)
+}
+
+// StreamCars calls fn for every car matching filter without loading them all into memory
+func (uc *carUsecase) StreamCars(filter model.CarFilter, fn func(car *model.Car) error) error {
+	return uc.carRepo.StreamCars(filter, fn)
+}
+
+// UpdateCar handles the business logic for updating an existing car. Its
+// trim and specs are replaced like its other fields. A
+// car.updated event is recorded in the same transaction, preceded by a
+// car.price_changed event when the price differs from the stored one.
 func (uc *carUsecase) UpdateCar(id string, car *model.Car) error {
 	// Ensure UpdatedBy is set
 	if car.UpdatedBy == "" {
@@ -65,13 +126,136 @@ func (uc *carUsecase) UpdateCar(id string, car *model.Car) error {
 	}
 	// car.UpdatedAt = time.Now() // Repository handles this
 
-	// Optional: Could fetch existing car to ensure it exists before update,
-	// or to merge fields if partial updates are allowed.
-	// For now, repository's UpdateCar handles not-found error.
-	return uc.carRepo.UpdateCar(id, car)
+	return uc.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		carRepo := uc.carRepo.WithTx(tx)
+		current, err := carRepo.GetCarByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkSpecs(uc.catalogRepo.WithTx(tx), car); err != nil {
+			return err
+		}
+		if err := carRepo.UpdateCar(id, car); err != nil {
+			return err
+		}
+
+		car.ID, car.CreatedAt, car.CreatedBy = id, current.CreatedAt, current.CreatedBy
+		var events []*model.Event
+		if current.Price != car.Price {
+			event, err := carPriceChangedEvent(car, current.Price)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		event, err := entityEvent(aggregateCar, actionUpdated, id, car)
+		if err != nil {
+			return err
+		}
+		return uc.outboxRepo.WithTx(tx).Append(append(events, event)...)
+	})
 }
 
-// DeleteCar handles the business logic for deleting a car
+// DeleteCar handles the business logic for deleting a car and records a
+// car.deleted event in the same transaction
 func (uc *carUsecase) DeleteCar(id string) error {
-	return uc.carRepo.DeleteCar(id)
+	return uc.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := uc.carRepo.WithTx(tx).DeleteCar(id); err != nil {
+			return err
+		}
+		event, err := entityEvent(aggregateCar, actionDeleted, id, nil)
+		if err != nil {
+			return err
+		}
+		return uc.outboxRepo.WithTx(tx).Append(event)
+	})
+}
+
+// checkSpecs checks the specs of car against the schema of the category of
+// its trim, and stores them as they are read back. Cars without a trim have
+// no specs.
+func checkSpecs(catalogRepo repository.CatalogRepository, car *model.Car) error {
+	if car.TrimID == nil || *car.TrimID == "" {
+		car.TrimID = nil
+		if len(car.Specs) > 0 {
+			return fmt.Errorf("%w: specs require a trim_id", ErrInvalidSpecs)
+		}
+		return nil
+	}
+
+	details, err := catalogRepo.GetTrimDetails([]string{*car.TrimID})
+	if err != nil && !errors.Is(err, repository.ErrInvalidValue) {
+		return err
+	}
+	if len(details) == 0 {
+		return fmt.Errorf("%w: trim %s does not exist", ErrInvalidSpecs, *car.TrimID)
+	}
+	schema, _ := model.SpecSchemaOf(details[0].Category)
+	specs, err := schema.Validate(car.Specs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpecs, err)
+	}
+	car.Specs = specs
+	return nil
+}
+
+// CompareCars returns the cars with ids side by side, in that order, with one
+// row per specification attribute any of them has. A missing car returns
+// repository.ErrNotFound.
+func (uc *carUsecase) CompareCars(ids []string) (*model.CarComparison, error) {
+	ids = uniqueStrings(ids)
+	if len(ids) < 2 || len(ids) > MaxComparedCars {
+		return nil, ErrComparedCars
+	}
+	cars, err := uc.carRepo.GetCarsByIDs(ids)
+	if err != nil && !errors.Is(err, repository.ErrInvalidValue) {
+		return nil, err
+	}
+	byID := make(map[string]*model.Car, len(cars))
+	var trimIDs []string
+	for i := range cars {
+		byID[cars[i].ID] = &cars[i]
+		if cars[i].TrimID != nil {
+			trimIDs = append(trimIDs, *cars[i].TrimID)
+		}
+	}
+	details := map[string]model.TrimDetail{}
+	if len(trimIDs) > 0 {
+		found, err := uc.catalogRepo.GetTrimDetails(uniqueStrings(trimIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, detail := range found {
+			details[detail.TrimID] = detail
+		}
+	}
+
+	comparison := &model.CarComparison{Specs: []model.SpecComparison{}}
+	for _, id := range ids {
+		car, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: car %s", repository.ErrNotFound, id)
+		}
+		compared := model.ComparedCar{Car: car}
+		if car.TrimID != nil {
+			detail := details[*car.TrimID]
+			compared.Brand, compared.Model, compared.Category, compared.Trim = detail.Brand, detail.Model, detail.Category, detail.Trim
+		}
+		comparison.Cars = append(comparison.Cars, compared)
+	}
+
+	for _, attr := range model.SpecAttributes {
+		row := model.SpecComparison{Attribute: attr.Name, Unit: attr.Unit, Values: make([]interface{}, len(comparison.Cars))}
+		present := false
+		for i, car := range comparison.Cars {
+			if value, ok := car.Specs[attr.Name]; ok {
+				row.Values[i], present = value, true
+			}
+			row.Differs = row.Differs || fmt.Sprint(row.Values[i]) != fmt.Sprint(row.Values[0])
+		}
+		if present {
+			comparison.Specs = append(comparison.Specs, row)
+		}
+	}
+	return comparison, nil
 }
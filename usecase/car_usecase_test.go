@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	// "time" // Not needed for these tests as repo mock handles time
@@ -10,15 +11,46 @@ import (
 	"github.com/GoodsChain/backend/repository" // For repository.ErrNotFound
 	"go.uber.org/mock/gomock"                 // Corrected import path
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestCarUsecase returns a CarUsecase whose transactions run directly
+// against the returned mocks
+func newTestCarUsecase(ctrl *gomock.Controller) (CarUsecase, *mock.MockCarRepository, *mock.MockOutboxRepository) {
+	uc, mockCarRepo, _, mockOutbox := newTestCarCatalogUsecase(ctrl)
+	return uc, mockCarRepo, mockOutbox
+}
+
+// newTestCarCatalogUsecase is newTestCarUsecase that also returns the mock of the catalog
+func newTestCarCatalogUsecase(ctrl *gomock.Controller) (CarUsecase, *mock.MockCarRepository, *mock.MockCatalogRepository, *mock.MockOutboxRepository) {
+	mockCarRepo := mock.NewMockCarRepository(ctrl)
+	mockCatalog := mock.NewMockCatalogRepository(ctrl)
+	mockOutbox := mock.NewMockOutboxRepository(ctrl)
+	transactor := mock.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockCarRepo.EXPECT().WithTx(gomock.Any()).Return(mockCarRepo).AnyTimes()
+	mockCatalog.EXPECT().WithTx(gomock.Any()).Return(mockCatalog).AnyTimes()
+	mockOutbox.EXPECT().WithTx(gomock.Any()).Return(mockOutbox).AnyTimes()
+	return NewCarUsecase(mockCarRepo, mockCatalog, transactor, mockOutbox), mockCarRepo, mockCatalog, mockOutbox
+}
+
+// mustJSONField returns the raw value of a top-level field of a JSON object
+func mustJSONField(t *testing.T, data []byte, field string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	return fields[field]
+}
+
 func TestCarUsecase_CreateCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockCarRepo := mock.NewMockCarRepository(ctrl)
-	uc := NewCarUsecase(mockCarRepo)
+	uc, mockCarRepo, mockOutbox := newTestCarUsecase(ctrl)
 
 	car := &model.Car{Name: "Test Car", SupplierID: "supp1", Price: 10000}
 	expectedCar := *car
@@ -34,6 +66,12 @@ func TestCarUsecase_CreateCar(t *testing.T) {
 			assert.Equal(t, "system", c.UpdatedBy) // Default value
 			return nil
 		}).Times(1)
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		assert.Len(t, events, 1)
+		assert.Equal(t, model.EventCarCreated, events[0].Type)
+		assert.Equal(t, car.ID, events[0].AggregateID)
+		return nil
+	}).Times(1)
 
 	err := uc.CreateCar(car)
 	assert.NoError(t, err)
@@ -51,8 +89,7 @@ func TestCarUsecase_GetCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockCarRepo := mock.NewMockCarRepository(ctrl)
-	uc := NewCarUsecase(mockCarRepo)
+	uc, mockCarRepo, _ := newTestCarUsecase(ctrl)
 
 	carID := uuid.New().String()
 	expectedCar := &model.Car{ID: carID, Name: "Found Car"}
@@ -83,8 +120,7 @@ func TestCarUsecase_GetAllCars(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockCarRepo := mock.NewMockCarRepository(ctrl)
-	uc := NewCarUsecase(mockCarRepo)
+	uc, mockCarRepo, _ := newTestCarUsecase(ctrl)
 
 	expectedCars := []model.Car{
 		{ID: uuid.New().String(), Name: "Car 1"},
@@ -92,36 +128,75 @@ func TestCarUsecase_GetAllCars(t *testing.T) {
 	}
 
 	// Test case 1: Successful retrieval
-	mockCarRepo.EXPECT().GetAllCars().Return(expectedCars, nil).Times(1)
-	cars, err := uc.GetAllCars()
+	mockCarRepo.EXPECT().GetAllCars(model.CarFilter{}).Return(expectedCars, nil).Times(1)
+	cars, err := uc.GetAllCars(model.CarFilter{})
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCars, cars)
 
 	// Test case 2: Empty list
-	mockCarRepo.EXPECT().GetAllCars().Return([]model.Car{}, nil).Times(1)
-	cars, err = uc.GetAllCars()
+	mockCarRepo.EXPECT().GetAllCars(model.CarFilter{}).Return([]model.Car{}, nil).Times(1)
+	cars, err = uc.GetAllCars(model.CarFilter{})
 	assert.NoError(t, err)
 	assert.Empty(t, cars)
 
 	// Test case 3: Repository error
 	repoErr := errors.New("db query failed")
-	mockCarRepo.EXPECT().GetAllCars().Return(nil, repoErr).Times(1)
-	cars, err = uc.GetAllCars()
+	mockCarRepo.EXPECT().GetAllCars(model.CarFilter{}).Return(nil, repoErr).Times(1)
+	cars, err = uc.GetAllCars(model.CarFilter{})
 	assert.EqualError(t, err, "db query failed")
 	assert.Nil(t, cars)
 }
 
+func TestCarUsecase_StreamCars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockCarRepo, _ := newTestCarUsecase(ctrl)
+	filter := model.CarFilter{SupplierID: "supp1"}
+
+	mockCarRepo.EXPECT().StreamCars(filter, gomock.Any()).DoAndReturn(
+		func(f model.CarFilter, fn func(*model.Car) error) error {
+			return fn(&model.Car{ID: "car1"})
+		}).Times(1)
+
+	var ids []string
+	err := uc.StreamCars(filter, func(car *model.Car) error {
+		ids = append(ids, car.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"car1"}, ids)
+}
+
+func TestCarUsecase_GetCarsByIDsAndSupplierIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockCarRepo, _ := newTestCarUsecase(ctrl)
+	cars := []model.Car{{ID: "car1", SupplierID: "supp1"}}
+
+	mockCarRepo.EXPECT().GetCarsByIDs([]string{"car1", "car2"}).Return(cars, nil)
+	result, err := uc.GetCarsByIDs([]string{"car1", "car2"})
+	assert.NoError(t, err)
+	assert.Equal(t, cars, result)
+
+	mockCarRepo.EXPECT().GetCarsBySupplierIDs([]string{"supp1"}).Return(nil, errors.New("db error"))
+	_, err = uc.GetCarsBySupplierIDs([]string{"supp1"})
+	assert.EqualError(t, err, "db error")
+}
+
 func TestCarUsecase_UpdateCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockCarRepo := mock.NewMockCarRepository(ctrl)
-	uc := NewCarUsecase(mockCarRepo)
+	uc, mockCarRepo, mockOutbox := newTestCarUsecase(ctrl)
 
 	carID := uuid.New().String()
-	carToUpdate := &model.Car{Name: "Updated Car Name"}
+	carToUpdate := &model.Car{Name: "Updated Car Name", Price: 10000}
+	current := &model.Car{ID: carID, Name: "Car Name", Price: 10000, CreatedBy: "admin"}
 
-	// Test case 1: Successful update
+	// Test case 1: Successful update, price unchanged so only car.updated is recorded
+	mockCarRepo.EXPECT().GetCarByID(carID).Return(current, nil).Times(1)
 	mockCarRepo.EXPECT().UpdateCar(carID, gomock.Any()).DoAndReturn(
 		func(id string, c *model.Car) error {
 			assert.Equal(t, carID, id)
@@ -129,19 +204,42 @@ func TestCarUsecase_UpdateCar(t *testing.T) {
 			assert.Equal(t, "system", c.UpdatedBy) // Default value
 			return nil
 		}).Times(1)
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		assert.Len(t, events, 1)
+		assert.Equal(t, model.EventCarUpdated, events[0].Type)
+		assert.JSONEq(t, `"admin"`, string(mustJSONField(t, events[0].Data, "created_by")))
+		return nil
+	}).Times(1)
 	err := uc.UpdateCar(carID, carToUpdate)
 	assert.NoError(t, err)
 
-	// Test case 2: Car not found by repository
+	// Test case 2: Price changed, car.price_changed precedes car.updated
+	repricedCar := &model.Car{Name: "Updated Car Name", Price: 12000}
+	mockCarRepo.EXPECT().GetCarByID(carID).Return(current, nil).Times(1)
+	mockCarRepo.EXPECT().UpdateCar(carID, repricedCar).Return(nil).Times(1)
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		assert.Len(t, events, 2)
+		assert.Equal(t, model.EventCarPriceChanged, events[0].Type)
+		assert.Equal(t, carID, events[0].AggregateID)
+		assert.JSONEq(t, `10000`, string(mustJSONField(t, events[0].Data, "previous_price")))
+		assert.Equal(t, model.EventCarUpdated, events[1].Type)
+		return nil
+	}).Times(1)
+	err = uc.UpdateCar(carID, repricedCar)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", repricedCar.CreatedBy)
+
+	// Test case 3: Car not found by repository
 	notFoundID := uuid.New().String()
-	mockCarRepo.EXPECT().UpdateCar(notFoundID, gomock.Any()).Return(repository.ErrNotFound).Times(1)
+	mockCarRepo.EXPECT().GetCarByID(notFoundID).Return(nil, repository.ErrNotFound).Times(1)
 	err = uc.UpdateCar(notFoundID, carToUpdate)
 	assert.ErrorIs(t, err, repository.ErrNotFound)
 
-	// Test case 3: Other repository error
+	// Test case 4: Other repository error
 	errorID := uuid.New().String()
 	repoErr := errors.New("update failed")
 	carWithUser := &model.Car{Name: "Updated Car Name", UpdatedBy: "user1"}
+	mockCarRepo.EXPECT().GetCarByID(errorID).Return(current, nil).Times(1)
 	mockCarRepo.EXPECT().UpdateCar(errorID, carWithUser).Return(repoErr).Times(1)
 	err = uc.UpdateCar(errorID, carWithUser)
 	assert.EqualError(t, err, "update failed")
@@ -151,13 +249,18 @@ func TestCarUsecase_DeleteCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockCarRepo := mock.NewMockCarRepository(ctrl)
-	uc := NewCarUsecase(mockCarRepo)
+	uc, mockCarRepo, mockOutbox := newTestCarUsecase(ctrl)
 
 	carID := uuid.New().String()
 
-	// Test case 1: Successful deletion
+	// Test case 1: Successful deletion records car.deleted
 	mockCarRepo.EXPECT().DeleteCar(carID).Return(nil).Times(1)
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		assert.Len(t, events, 1)
+		assert.Equal(t, model.EventCarDeleted, events[0].Type)
+		assert.JSONEq(t, `{"id":"`+carID+`"}`, string(events[0].Data))
+		return nil
+	}).Times(1)
 	err := uc.DeleteCar(carID)
 	assert.NoError(t, err)
 
@@ -174,3 +277,99 @@ func TestCarUsecase_DeleteCar(t *testing.T) {
 	err = uc.DeleteCar(errorID)
 	assert.EqualError(t, err, "delete failed")
 }
+
+func TestCarUsecase_Specs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, mockCarRepo, mockCatalog, mockOutbox := newTestCarCatalogUsecase(ctrl)
+	trimID := "trim1"
+	van := []model.TrimDetail{{TrimID: "trim1", Trim: "XLE", Model: "Sienna", Category: model.CategoryVan, Brand: "Toyota"}}
+
+	t.Run("Valid", func(t *testing.T) {
+		car := &model.Car{Name: "Sienna XLE", SupplierID: "supp1", Price: 40000, TrimID: &trimID, Specs: model.Specs{
+			"model_year": float64(2024), "body_type": "minivan", "fuel": "hybrid", "seats": float64(8), "cargo_l": float64(2129),
+		}}
+		mockCatalog.EXPECT().GetTrimDetails([]string{"trim1"}).Return(van, nil)
+		mockCarRepo.EXPECT().CreateCar(car).Return(nil)
+		mockOutbox.EXPECT().Append(gomock.Any()).Return(nil)
+		assert.NoError(t, uc.CreateCar(car))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		car := &model.Car{Name: "Sienna XLE", SupplierID: "supp1", Price: 40000, TrimID: &trimID, Specs: model.Specs{
+			"model_year": float64(2024), "body_type": "sedan", "fuel": "hybrid", "seats": 7.5, "towing_kg": float64(1500),
+		}}
+		mockCatalog.EXPECT().GetTrimDetails([]string{"trim1"}).Return(van, nil)
+		err := uc.CreateCar(car)
+		assert.ErrorIs(t, err, ErrInvalidSpecs)
+		assert.EqualError(t, err, "invalid car specification: body_type must be one of van, minivan; "+
+			"cargo_l is required for van cars; seats must be a whole number; towing_kg is not an attribute of van cars")
+	})
+
+	t.Run("UnknownTrim", func(t *testing.T) {
+		mockCatalog.EXPECT().GetTrimDetails([]string{"trim1"}).Return([]model.TrimDetail{}, nil)
+		err := uc.CreateCar(&model.Car{Name: "Sienna", TrimID: &trimID})
+		assert.ErrorIs(t, err, ErrInvalidSpecs)
+	})
+
+	t.Run("SpecsWithoutTrim", func(t *testing.T) {
+		mockCarRepo.EXPECT().GetCarByID("car1").Return(&model.Car{ID: "car1"}, nil)
+		err := uc.UpdateCar("car1", &model.Car{Name: "Sienna", Specs: model.Specs{"seats": float64(8)}})
+		assert.ErrorIs(t, err, ErrInvalidSpecs)
+	})
+}
+
+func TestCarUsecase_CompareCars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, mockCarRepo, mockCatalog, _ := newTestCarCatalogUsecase(ctrl)
+	hybrid, gasoline := "trim1", "trim2"
+
+	t.Run("Success", func(t *testing.T) {
+		mockCarRepo.EXPECT().GetCarsByIDs([]string{"car1", "car2", "car3"}).Return([]model.Car{
+			{ID: "car2", Name: "Highlander", TrimID: &gasoline, Specs: model.Specs{"fuel": "gasoline", "seats": float64(8)}},
+			{ID: "car3", Name: "Old car"},
+			{ID: "car1", Name: "Sienna", TrimID: &hybrid, Specs: model.Specs{"fuel": "hybrid", "seats": float64(8), "cargo_l": float64(2129)}},
+		}, nil)
+		mockCatalog.EXPECT().GetTrimDetails(gomock.InAnyOrder([]string{"trim1", "trim2"})).Return([]model.TrimDetail{
+			{TrimID: "trim1", Trim: "XLE", Model: "Sienna", Category: model.CategoryVan, Brand: "Toyota"},
+			{TrimID: "trim2", Trim: "XSE", Model: "Highlander", Category: model.CategorySUV, Brand: "Toyota"},
+		}, nil)
+
+		comparison, err := uc.CompareCars([]string{"car1", "car2", "car3", "car1"})
+		assert.NoError(t, err)
+		assert.Len(t, comparison.Cars, 3)
+		assert.Equal(t, "car1", comparison.Cars[0].ID)
+		assert.Equal(t, "Sienna", comparison.Cars[0].Model)
+		assert.Equal(t, "XSE", comparison.Cars[1].Trim)
+		assert.Empty(t, comparison.Cars[2].Brand)
+		assert.Equal(t, []model.SpecComparison{
+			{Attribute: "fuel", Values: []interface{}{"hybrid", "gasoline", nil}, Differs: true},
+			{Attribute: "seats", Values: []interface{}{float64(8), float64(8), nil}, Differs: true},
+			{Attribute: "cargo_l", Unit: "l", Values: []interface{}{float64(2129), nil, nil}, Differs: true},
+		}, comparison.Specs)
+	})
+
+	t.Run("SameSpecs", func(t *testing.T) {
+		mockCarRepo.EXPECT().GetCarsByIDs([]string{"car1", "car2"}).Return([]model.Car{
+			{ID: "car1", Specs: model.Specs{"seats": float64(5)}},
+			{ID: "car2", Specs: model.Specs{"seats": float64(5)}},
+		}, nil)
+		comparison, err := uc.CompareCars([]string{"car1", "car2"})
+		assert.NoError(t, err)
+		assert.False(t, comparison.Specs[0].Differs)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		mockCarRepo.EXPECT().GetCarsByIDs([]string{"car1", "missing"}).Return([]model.Car{{ID: "car1"}}, nil)
+		_, err := uc.CompareCars([]string{"car1", "missing"})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		_, err := uc.CompareCars([]string{"car1", "car1"})
+		assert.ErrorIs(t, err, ErrComparedCars)
+		_, err = uc.CompareCars([]string{"1", "2", "3", "4", "5", "6"})
+		assert.ErrorIs(t, err, ErrComparedCars)
+	})
+}
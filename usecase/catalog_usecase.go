@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+)
+
+// CatalogUsecase manages the brands, models and trims of the car catalog
+type CatalogUsecase interface {
+	CreateBrand(brand *model.Brand) error
+	GetBrands() ([]model.Brand, error)
+	CreateModel(carModel *model.CarModel) error
+	GetModels(brandID string) ([]model.CarModel, error)
+	CreateTrim(trim *model.Trim) error
+	GetTrims(modelID string) ([]model.Trim, error)
+	WithTenant(tenantID string) CatalogUsecase
+}
+
+type catalogUsecase struct {
+	catalogRepo repository.CatalogRepository
+}
+
+// NewCatalogUsecase creates a new instance of CatalogUsecase
+func NewCatalogUsecase(catalogRepo repository.CatalogRepository) CatalogUsecase {
+	return &catalogUsecase{catalogRepo: catalogRepo}
+}
+
+// WithTenant returns a copy of the usecase that only manages the catalog of tenantID
+func (u *catalogUsecase) WithTenant(tenantID string) CatalogUsecase {
+	return &catalogUsecase{catalogRepo: u.catalogRepo.WithTenant(tenantID)}
+}
+
+// CreateBrand adds a new brand with a generated ID
+func (u *catalogUsecase) CreateBrand(brand *model.Brand) error {
+	brand.ID = uuid.New().String()
+	brand.CreatedBy, brand.UpdatedBy = auditActor(brand.CreatedBy), auditActor(brand.UpdatedBy)
+	return u.catalogRepo.CreateBrand(brand)
+}
+
+// GetBrands retrieves all brands
+func (u *catalogUsecase) GetBrands() ([]model.Brand, error) {
+	return u.catalogRepo.GetBrands()
+}
+
+// CreateModel adds a new model with a generated ID to its brand; a missing
+// brand returns repository.ErrNotFound
+func (u *catalogUsecase) CreateModel(carModel *model.CarModel) error {
+	carModel.ID = uuid.New().String()
+	carModel.CreatedBy, carModel.UpdatedBy = auditActor(carModel.CreatedBy), auditActor(carModel.UpdatedBy)
+	return u.catalogRepo.CreateModel(carModel)
+}
+
+// GetModels retrieves the models of a brand
+func (u *catalogUsecase) GetModels(brandID string) ([]model.CarModel, error) {
+	return u.catalogRepo.GetModels(brandID)
+}
+
+// CreateTrim adds a new trim with a generated ID to its model; a missing
+// model returns repository.ErrNotFound
+func (u *catalogUsecase) CreateTrim(trim *model.Trim) error {
+	trim.ID = uuid.New().String()
+	trim.CreatedBy, trim.UpdatedBy = auditActor(trim.CreatedBy), auditActor(trim.UpdatedBy)
+	return u.catalogRepo.CreateTrim(trim)
+}
+
+// GetTrims retrieves the trims of a model
+func (u *catalogUsecase) GetTrims(modelID string) ([]model.Trim, error) {
+	return u.catalogRepo.GetTrims(modelID)
+}
+
+// auditActor returns actor, or "system" for changes without one
+func auditActor(actor string) string {
+	if actor == "" {
+		return "system"
+	}
+	return actor
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCatalogUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockCatalogRepository(ctrl)
+	mockRepo.EXPECT().WithTenant("tenant1").Return(mockRepo)
+	uc := NewCatalogUsecase(mockRepo).WithTenant("tenant1")
+
+	t.Run("Brand", func(t *testing.T) {
+		brand := &model.Brand{Name: "Toyota"}
+		mockRepo.EXPECT().CreateBrand(brand).Return(nil)
+		assert.NoError(t, uc.CreateBrand(brand))
+		assert.NotEmpty(t, brand.ID)
+		assert.Equal(t, "system", brand.CreatedBy)
+	})
+
+	t.Run("ModelOfMissingBrand", func(t *testing.T) {
+		carModel := &model.CarModel{BrandID: "missing", Name: "Sienna", Category: model.CategoryVan, CreatedBy: "admin", UpdatedBy: "admin"}
+		mockRepo.EXPECT().CreateModel(carModel).Return(repository.ErrNotFound)
+		assert.ErrorIs(t, uc.CreateModel(carModel), repository.ErrNotFound)
+		assert.Equal(t, "admin", carModel.CreatedBy)
+	})
+
+	t.Run("Trim", func(t *testing.T) {
+		trim := &model.Trim{ModelID: "model1", Name: "XLE"}
+		mockRepo.EXPECT().CreateTrim(trim).Return(nil)
+		assert.NoError(t, uc.CreateTrim(trim))
+		assert.NotEmpty(t, trim.ID)
+	})
+}
@@ -4,31 +4,52 @@ import (
 	"github.com/GoodsChain/backend/model"
 	"github.com/GoodsChain/backend/repository"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
 // CustomerCarUsecase defines the interface for customer car business logic
 type CustomerCarUsecase interface {
 	CreateCustomerCar(customerCar *model.CustomerCar) error
 	GetCustomerCar(id string) (*model.CustomerCar, error)
-	GetAllCustomerCars() ([]*model.CustomerCar, error)
+	GetAllCustomerCars(filter model.CustomerCarFilter) ([]*model.CustomerCar, error)
+	StreamCustomerCars(filter model.CustomerCarFilter, fn func(customerCar *model.CustomerCar) error) error
 	GetCustomerCarsByCustomerID(customerID string) ([]*model.CustomerCar, error)
 	GetCustomerCarsByCarID(carID string) ([]*model.CustomerCar, error)
+	GetCustomerCarsByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error)
+	GetCustomerCarsByCarIDs(carIDs []string) ([]*model.CustomerCar, error)
 	UpdateCustomerCar(id string, customerCar *model.CustomerCar) error
 	DeleteCustomerCar(id string) error
+	WithTenant(tenantID string) CustomerCarUsecase
 }
 
 type customerCarUsecase struct {
 	customerCarRepo repository.CustomerCarRepository
+	transactor      repository.Transactor
+	outboxRepo      repository.OutboxRepository
 }
 
 // NewCustomerCarUsecase creates a new instance of CustomerCarUsecase
-func NewCustomerCarUsecase(customerCarRepo repository.CustomerCarRepository) CustomerCarUsecase {
+func NewCustomerCarUsecase(customerCarRepo repository.CustomerCarRepository, transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository) CustomerCarUsecase {
 	return &customerCarUsecase{
 		customerCarRepo: customerCarRepo,
+		transactor:      transactor,
+		outboxRepo:      outboxRepo,
 	}
 }
 
-// CreateCustomerCar handles the business logic for creating a new customer car relationship
+// WithTenant returns a copy of the usecase that only manages the customer
+// cars of tenantID
+func (uc *customerCarUsecase) WithTenant(tenantID string) CustomerCarUsecase {
+	return &customerCarUsecase{
+		customerCarRepo: uc.customerCarRepo.WithTenant(tenantID),
+		transactor:      uc.transactor,
+		outboxRepo:      uc.outboxRepo.WithTenant(tenantID),
+	}
+}
+
+// CreateCustomerCar handles the business logic for creating a new customer car
+// relationship and records a customer_car.created event in the same transaction
 func (u *customerCarUsecase) CreateCustomerCar(customerCar *model.CustomerCar) error {
 	// Generate UUID if not provided
 	if customerCar.ID == "" {
@@ -43,7 +64,16 @@ func (u *customerCarUsecase) CreateCustomerCar(customerCar *model.CustomerCar) e
 		customerCar.UpdatedBy = customerCar.CreatedBy
 	}
 
-	return u.customerCarRepo.Create(customerCar)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := u.customerCarRepo.WithTx(tx).Create(customerCar); err != nil {
+			return err
+		}
+		event, err := entityEvent(aggregateCustomerCar, actionCreated, customerCar.ID, customerCar)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.WithTx(tx).Append(event)
+	})
 }
 
 // GetCustomerCar retrieves a customer car relationship by ID
@@ -51,9 +81,15 @@ func (u *customerCarUsecase) GetCustomerCar(id string) (*model.CustomerCar, erro
 	return u.customerCarRepo.GetByID(id)
 }
 
-// GetAllCustomerCars retrieves all customer car relationships
-func (u *customerCarUsecase) GetAllCustomerCars() ([]*model.CustomerCar, error) {
-	return u.customerCarRepo.GetAll()
+// GetAllCustomerCars retrieves all customer car relationships matching filter
+func (u *customerCarUsecase) GetAllCustomerCars(filter model.CustomerCarFilter) ([]*model.CustomerCar, error) {
+	return u.customerCarRepo.GetAll(filter)
+}
+
+// StreamCustomerCars calls fn for every customer car relationship matching filter
+// without loading them all into memory
+func (u *customerCarUsecase) StreamCustomerCars(filter model.CustomerCarFilter, fn func(customerCar *model.CustomerCar) error) error {
+	return u.customerCarRepo.Stream(filter, fn)
 }
 
 // GetCustomerCarsByCustomerID retrieves all car relationships for a specific customer
@@ -66,17 +102,54 @@ func (u *customerCarUsecase) GetCustomerCarsByCarID(carID string) ([]*model.Cust
 	return u.customerCarRepo.GetByCarID(carID)
 }
 
-// UpdateCustomerCar updates an existing customer car relationship
+// GetCustomerCarsByCustomerIDs retrieves the car relationships of all the given customers
+func (u *customerCarUsecase) GetCustomerCarsByCustomerIDs(customerIDs []string) ([]*model.CustomerCar, error) {
+	return u.customerCarRepo.GetByCustomerIDs(customerIDs)
+}
+
+// GetCustomerCarsByCarIDs retrieves the customer relationships of all the given cars
+func (u *customerCarUsecase) GetCustomerCarsByCarIDs(carIDs []string) ([]*model.CustomerCar, error) {
+	return u.customerCarRepo.GetByCarIDs(carIDs)
+}
+
+// UpdateCustomerCar updates an existing customer car relationship and records
+// a customer_car.updated event in the same transaction
 func (u *customerCarUsecase) UpdateCustomerCar(id string, customerCar *model.CustomerCar) error {
 	// Set default value for updated_by if not provided
 	if customerCar.UpdatedBy == "" {
 		customerCar.UpdatedBy = "system"
 	}
 
-	return u.customerCarRepo.Update(id, customerCar)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.customerCarRepo.WithTx(tx)
+		current, err := repo.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if err := repo.Update(id, customerCar); err != nil {
+			return err
+		}
+
+		customerCar.ID, customerCar.CreatedAt, customerCar.CreatedBy = id, current.CreatedAt, current.CreatedBy
+		event, err := entityEvent(aggregateCustomerCar, actionUpdated, id, customerCar)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.WithTx(tx).Append(event)
+	})
 }
 
-// DeleteCustomerCar removes a customer car relationship by ID
+// DeleteCustomerCar removes a customer car relationship by ID and records a
+// customer_car.deleted event in the same transaction
 func (u *customerCarUsecase) DeleteCustomerCar(id string) error {
-	return u.customerCarRepo.Delete(id)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		if err := u.customerCarRepo.WithTx(tx).Delete(id); err != nil {
+			return err
+		}
+		event, err := entityEvent(aggregateCustomerCar, actionDeleted, id, nil)
+		if err != nil {
+			return err
+		}
+		return u.outboxRepo.WithTx(tx).Append(event)
+	})
 }
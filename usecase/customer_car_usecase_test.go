@@ -5,18 +5,42 @@ import (
 	"testing"
 
 	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
 	mock_repository "github.com/GoodsChain/backend/mock"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+// newTestCustomerCarUsecase returns a CustomerCarUsecase whose transactions
+// run directly against the returned mocks
+func newTestCustomerCarUsecase(ctrl *gomock.Controller) (CustomerCarUsecase, *mock_repository.MockCustomerCarRepository, *mock_repository.MockOutboxRepository) {
+	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
+	mockOutbox := mock_repository.NewMockOutboxRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockOutbox.EXPECT().WithTx(gomock.Any()).Return(mockOutbox).AnyTimes()
+	return NewCustomerCarUsecase(mockRepo, transactor, mockOutbox), mockRepo, mockOutbox
+}
+
 func TestCreateCustomerCar(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerCarUsecase(ctrl)
+	
+	// Every successful create records a customer_car.created event
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		assert.Len(t, events, 1)
+		assert.Equal(t, model.EventCustomerCarCreated, events[0].Type)
+		assert.Equal(t, "customer_car", events[0].AggregateType)
+		assert.NotEmpty(t, events[0].AggregateID)
+		return nil
+	}).Times(3)
 	
 	customerCar := &model.CustomerCar{
 		ID:         "",
@@ -92,8 +116,7 @@ func TestGetCustomerCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerCarUsecase(ctrl)
 	
 	customerCar := &model.CustomerCar{
 		ID:         "cc123",
@@ -124,8 +147,7 @@ func TestGetAllCustomerCars(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerCarUsecase(ctrl)
 	
 	customerCars := []*model.CustomerCar{
 		{ID: "cc123", CarID: "car123", CustomerID: "cust123"},
@@ -133,18 +155,18 @@ func TestGetAllCustomerCars(t *testing.T) {
 	}
 	
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().GetAll().Return(customerCars, nil)
+		mockRepo.EXPECT().GetAll(model.CustomerCarFilter{}).Return(customerCars, nil)
 		
-		result, err := usecase.GetAllCustomerCars()
+		result, err := usecase.GetAllCustomerCars(model.CustomerCarFilter{})
 		assert.NoError(t, err)
 		assert.Equal(t, customerCars, result)
 	})
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mockRepo.EXPECT().GetAll().Return(nil, expectedErr)
+		mockRepo.EXPECT().GetAll(model.CustomerCarFilter{}).Return(nil, expectedErr)
 		
-		result, err := usecase.GetAllCustomerCars()
+		result, err := usecase.GetAllCustomerCars(model.CustomerCarFilter{})
 		assert.Equal(t, expectedErr, err)
 		assert.Nil(t, result)
 	})
@@ -155,8 +177,7 @@ func TestGetCustomerCarsByCustomerID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerCarUsecase(ctrl)
 	
 	customerID := "cust123"
 	customerCars := []*model.CustomerCar{
@@ -187,8 +208,7 @@ func TestGetCustomerCarsByCarID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerCarUsecase(ctrl)
 	
 	carID := "car123"
 	customerCars := []*model.CustomerCar{
@@ -214,15 +234,37 @@ func TestGetCustomerCarsByCarID(t *testing.T) {
 	})
 }
 
+func TestGetCustomerCarsByCustomerIDsAndCarIDs(t *testing.T) {
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	
+	usecase, mockRepo, _ := newTestCustomerCarUsecase(ctrl)
+	
+	customerCars := []*model.CustomerCar{
+		{ID: "cc123", CarID: "car123", CustomerID: "cust123"},
+	}
+	
+	mockRepo.EXPECT().GetByCustomerIDs([]string{"cust123", "cust456"}).Return(customerCars, nil)
+	result, err := usecase.GetCustomerCarsByCustomerIDs([]string{"cust123", "cust456"})
+	assert.NoError(t, err)
+	assert.Equal(t, customerCars, result)
+	
+	mockRepo.EXPECT().GetByCarIDs([]string{"car123"}).Return(customerCars, nil)
+	result, err = usecase.GetCustomerCarsByCarIDs([]string{"car123"})
+	assert.NoError(t, err)
+	assert.Equal(t, customerCars, result)
+}
+
 func TestUpdateCustomerCar(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerCarUsecase(ctrl)
 	
 	customerCarID := "cc123"
+	current := &model.CustomerCar{ID: customerCarID, CarID: "car123", CustomerID: "cust123", CreatedBy: "creator"}
 	customerCar := &model.CustomerCar{
 		ID:         customerCarID,
 		CarID:      "car456",
@@ -230,15 +272,23 @@ func TestUpdateCustomerCar(t *testing.T) {
 	}
 	
 	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID(customerCarID).Return(current, nil)
 		mockRepo.EXPECT().
 			Update(customerCarID, gomock.Any()).
 			DoAndReturn(func(id string, cc *model.CustomerCar) error {
 				assert.Equal(t, "system", cc.UpdatedBy)
 				return nil
 			})
+		mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+			assert.Len(t, events, 1)
+			assert.Equal(t, model.EventCustomerCarUpdated, events[0].Type)
+			assert.Equal(t, customerCarID, events[0].AggregateID)
+			return nil
+		})
 		
 		err := usecase.UpdateCustomerCar(customerCarID, customerCar)
 		assert.NoError(t, err)
+		assert.Equal(t, "creator", customerCar.CreatedBy)
 	})
 	
 	t.Run("Success with Provided UpdatedBy", func(t *testing.T) {
@@ -249,12 +299,14 @@ func TestUpdateCustomerCar(t *testing.T) {
 			UpdatedBy:  "admin",
 		}
 		
+		mockRepo.EXPECT().GetByID(customerCarID).Return(current, nil)
 		mockRepo.EXPECT().
 			Update(customerCarID, gomock.Any()).
 			DoAndReturn(func(id string, cc *model.CustomerCar) error {
 				assert.Equal(t, "admin", cc.UpdatedBy)
 				return nil
 			})
+		mockOutbox.EXPECT().Append(gomock.Any()).Return(nil)
 		
 		err := usecase.UpdateCustomerCar(customerCarID, customerCarWithUpdater)
 		assert.NoError(t, err)
@@ -262,11 +314,19 @@ func TestUpdateCustomerCar(t *testing.T) {
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("update error")
+		mockRepo.EXPECT().GetByID(customerCarID).Return(current, nil)
 		mockRepo.EXPECT().Update(customerCarID, gomock.Any()).Return(expectedErr)
 		
 		err := usecase.UpdateCustomerCar(customerCarID, customerCar)
 		assert.Equal(t, expectedErr, err)
 	})
+	
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID("missing").Return(nil, repository.ErrNotFound)
+		
+		err := usecase.UpdateCustomerCar("missing", customerCar)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
 }
 
 func TestDeleteCustomerCar(t *testing.T) {
@@ -274,13 +334,17 @@ func TestDeleteCustomerCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerCarRepository(ctrl)
-	usecase := NewCustomerCarUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerCarUsecase(ctrl)
 	
 	customerCarID := "cc123"
 	
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().Delete(customerCarID).Return(nil)
+		mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+			assert.Len(t, events, 1)
+			assert.Equal(t, model.EventCustomerCarDeleted, events[0].Type)
+			return nil
+		})
 		
 		err := usecase.DeleteCustomerCar(customerCarID)
 		assert.NoError(t, err)
@@ -3,42 +3,116 @@ package usecase
 import (
 	"github.com/GoodsChain/backend/repository"
 	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
 )
 
 type CustomerUsecase interface {
 	CreateCustomer(customer *model.Customer) error
 	GetCustomer(id string) (*model.Customer, error)
+	GetCustomersByIDs(ids []string) ([]*model.Customer, error)
 	UpdateCustomer(id string, customer *model.Customer) error
 	DeleteCustomer(id string) error
-	GetAllCustomers() ([]*model.Customer, error)
+	GetAllCustomers(filter model.CustomerFilter) ([]*model.Customer, error)
+	StreamCustomers(filter model.CustomerFilter, fn func(customer *model.Customer) error) error
+	WithTenant(tenantID string) CustomerUsecase
 }
 
 type customerUsecase struct {
 	customerRepo repository.CustomerRepository
+	transactor   repository.Transactor
+	outboxRepo   repository.OutboxRepository
 }
 
-func NewCustomerUsecase(customerRepo repository.CustomerRepository) CustomerUsecase {
+// NewCustomerUsecase creates a new instance of CustomerUsecase. Changes record
+// customer.created, customer.updated and customer.deleted events in outboxRepo in
+// the transaction of the change.
+func NewCustomerUsecase(customerRepo repository.CustomerRepository, transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository) CustomerUsecase {
 	return &customerUsecase{
 		customerRepo: customerRepo,
+		transactor:   transactor,
+		outboxRepo:   outboxRepo,
+	}
+}
+
+// WithTenant returns a copy of the usecase that only manages the customers of tenantID
+func (u *customerUsecase) WithTenant(tenantID string) CustomerUsecase {
+	return &customerUsecase{
+		customerRepo: u.customerRepo.WithTenant(tenantID),
+		transactor:   u.transactor,
+		outboxRepo:   u.outboxRepo.WithTenant(tenantID),
 	}
 }
 
 func (u *customerUsecase) CreateCustomer(customer *model.Customer) error {
-	return u.customerRepo.Create(customer)
+	customer.CreatedBy = auditActor(customer.CreatedBy)
+	customer.UpdatedBy = auditActor(customer.UpdatedBy)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.customerRepo.WithTx(tx)
+		if err := repo.Create(customer); err != nil {
+			return err
+		}
+		// Read the row back, the database sets the audit fields
+		created, err := repo.Get(customer.ID)
+		if err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionCreated, created.ID, created)
+	})
 }
 
 func (u *customerUsecase) GetCustomer(id string) (*model.Customer, error) {
 	return u.customerRepo.Get(id)
 }
 
+// GetCustomersByIDs returns the customers with the given IDs; missing IDs are left out
+func (u *customerUsecase) GetCustomersByIDs(ids []string) ([]*model.Customer, error) {
+	return u.customerRepo.GetByIDs(ids)
+}
+
+// UpdateCustomer fails without updating anything when the customer does not exist
 func (u *customerUsecase) UpdateCustomer(id string, customer *model.Customer) error {
-	return u.customerRepo.Update(id, customer)
+	customer.UpdatedBy = auditActor(customer.UpdatedBy)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.customerRepo.WithTx(tx)
+		if err := repo.Update(id, customer); err != nil {
+			return err
+		}
+		updated, err := repo.Get(id)
+		if err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionUpdated, id, updated)
+	})
 }
 
+// DeleteCustomer fails when the customer does not exist
 func (u *customerUsecase) DeleteCustomer(id string) error {
-	return u.customerRepo.Delete(id)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.customerRepo.WithTx(tx)
+		if _, err := repo.Get(id); err != nil {
+			return err
+		}
+		if err := repo.Delete(id); err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionDeleted, id, nil)
+	})
 }
 
-func (u *customerUsecase) GetAllCustomers() ([]*model.Customer, error) {
-	return u.customerRepo.GetAll()
+func (u *customerUsecase) GetAllCustomers(filter model.CustomerFilter) ([]*model.Customer, error) {
+	return u.customerRepo.GetAll(filter)
+}
+
+func (u *customerUsecase) StreamCustomers(filter model.CustomerFilter, fn func(customer *model.Customer) error) error {
+	return u.customerRepo.Stream(filter, fn)
+}
+
+// appendEvent records a customer change event in tx
+func (u *customerUsecase) appendEvent(tx *sqlx.Tx, action, id string, customer *model.Customer) error {
+	event, err := entityEvent(aggregateCustomer, action, id, customer)
+	if err != nil {
+		return err
+	}
+	return u.outboxRepo.WithTx(tx).Append(event)
 }
@@ -2,34 +2,72 @@ package usecase
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/GoodsChain/backend/model"
 	mock_repository "github.com/GoodsChain/backend/mock"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/mock/gomock"
 )
 
+// newTestCustomerUsecase returns a CustomerUsecase whose transactions run
+// directly against the returned mocks
+func newTestCustomerUsecase(ctrl *gomock.Controller) (CustomerUsecase, *mock_repository.MockCustomerRepository, *mock_repository.MockOutboxRepository) {
+	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
+	mockOutbox := mock_repository.NewMockOutboxRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockOutbox.EXPECT().WithTx(gomock.Any()).Return(mockOutbox).AnyTimes()
+	return NewCustomerUsecase(mockRepo, transactor, mockOutbox), mockRepo, mockOutbox
+}
+
+// expectCustomerEvent expects a single event of eventType for customer 1
+func expectCustomerEvent(t *testing.T, mockOutbox *mock_repository.MockOutboxRepository, eventType string) {
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		if len(events) != 1 || events[0].Type != eventType || events[0].AggregateType != "customer" || events[0].AggregateID != "1" {
+			t.Errorf("Expected one %s event for customer 1, got %v", eventType, events)
+		}
+		for _, field := range []string{"address", "phone", "email"} {
+			if strings.Contains(string(events[0].Data), `"`+field+`"`) {
+				t.Errorf("Expected no %s in the event payload, got %s", field, events[0].Data)
+			}
+		}
+		return nil
+	})
+}
+
 func TestCreateCustomer(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
-	usecase := NewCustomerUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerUsecase(ctrl)
 	
 	customer := &model.Customer{
-		ID:   "1",
-		Name: "Test Customer",
+		ID:      "1",
+		Name:    "Test Customer",
+		Address: "1 Main St",
+		Phone:   "0912345678",
+		Email:   "test@example.com",
 	}
 	
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().Create(customer).Return(nil)
+		mockRepo.EXPECT().Get("1").Return(customer, nil)
+		expectCustomerEvent(t, mockOutbox, model.EventCustomerCreated)
 		
 		err := usecase.CreateCustomer(customer)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
+		if customer.CreatedBy != "system" || customer.UpdatedBy != "system" {
+			t.Errorf("Expected the system actor, got %q and %q", customer.CreatedBy, customer.UpdatedBy)
+		}
 	})
 	
 	t.Run("Repository Error", func(t *testing.T) {
@@ -48,8 +86,7 @@ func TestGetCustomer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
-	usecase := NewCustomerUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerUsecase(ctrl)
 	
 	customer := &model.Customer{
 		ID:   "1",
@@ -83,13 +120,31 @@ func TestGetCustomer(t *testing.T) {
 	})
 }
 
+func TestGetCustomersByIDs(t *testing.T) {
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	
+	usecase, mockRepo, _ := newTestCustomerUsecase(ctrl)
+	
+	customers := []*model.Customer{{ID: "1"}}
+	mockRepo.EXPECT().GetByIDs([]string{"1", "2"}).Return(customers, nil)
+	
+	result, err := usecase.GetCustomersByIDs([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("Expected 1 customer, got %d", len(result))
+	}
+}
+
 func TestUpdateCustomer(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
-	usecase := NewCustomerUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerUsecase(ctrl)
 	
 	customer := &model.Customer{
 		ID:   "1",
@@ -99,6 +154,8 @@ func TestUpdateCustomer(t *testing.T) {
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().Update("1", customer).Return(nil)
+		mockRepo.EXPECT().Get("1").Return(customer, nil)
+		expectCustomerEvent(t, mockOutbox, model.EventCustomerUpdated)
 		
 		err := usecase.UpdateCustomer("1", customer)
 		if err != nil {
@@ -122,12 +179,13 @@ func TestDeleteCustomer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
-	usecase := NewCustomerUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestCustomerUsecase(ctrl)
 	
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().Get("1").Return(&model.Customer{ID: "1"}, nil)
 		mockRepo.EXPECT().Delete("1").Return(nil)
+		expectCustomerEvent(t, mockOutbox, model.EventCustomerDeleted)
 		
 		err := usecase.DeleteCustomer("1")
 		if err != nil {
@@ -137,6 +195,7 @@ func TestDeleteCustomer(t *testing.T) {
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("delete error")
+		mockRepo.EXPECT().Get("1").Return(&model.Customer{ID: "1"}, nil)
 		mockRepo.EXPECT().Delete("1").Return(expectedErr)
 		
 		err := usecase.DeleteCustomer("1")
@@ -144,6 +203,16 @@ func TestDeleteCustomer(t *testing.T) {
 			t.Errorf("Expected %v, got %v", expectedErr, err)
 		}
 	})
+	
+	t.Run("Not Found", func(t *testing.T) {
+		expectedErr := errors.New("not found")
+		mockRepo.EXPECT().Get("1").Return(nil, expectedErr)
+		
+		err := usecase.DeleteCustomer("1")
+		if err != expectedErr {
+			t.Errorf("Expected %v, got %v", expectedErr, err)
+		}
+	})
 }
 
 func TestGetAllCustomers(t *testing.T) {
@@ -151,8 +220,7 @@ func TestGetAllCustomers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockCustomerRepository(ctrl)
-	usecase := NewCustomerUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestCustomerUsecase(ctrl)
 	
 	customers := []*model.Customer{
 		{ID: "1", Name: "Customer 1"},
@@ -161,9 +229,9 @@ func TestGetAllCustomers(t *testing.T) {
 	
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().GetAll().Return(customers, nil)
+		mockRepo.EXPECT().GetAll(model.CustomerFilter{}).Return(customers, nil)
 		
-		result, err := usecase.GetAllCustomers()
+		result, err := usecase.GetAllCustomers(model.CustomerFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -174,9 +242,9 @@ func TestGetAllCustomers(t *testing.T) {
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mockRepo.EXPECT().GetAll().Return(nil, expectedErr)
+		mockRepo.EXPECT().GetAll(model.CustomerFilter{}).Return(nil, expectedErr)
 		
-		result, err := usecase.GetAllCustomers()
+		result, err := usecase.GetAllCustomers(model.CustomerFilter{})
 		if err != expectedErr {
 			t.Errorf("Expected %v, got %v", expectedErr, err)
 		}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"encoding/json"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/google/uuid"
+)
+
+// Aggregate types of domain events
+const (
+	aggregateCustomer    = "customer"
+	aggregateSupplier    = "supplier"
+	aggregateCar         = "car"
+	aggregateCustomerCar = "customer_car"
+)
+
+// Actions of entity change events; the event type is "<aggregate>.<action>"
+const (
+	actionCreated = "created"
+	actionUpdated = "updated"
+	actionDeleted = "deleted"
+)
+
+// newEvent builds an outbox event for a change to an aggregate; data is
+// encoded as the event payload
+func newEvent(eventType, aggregateType, aggregateID string, data interface{}) (*model.Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Event{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Data:          payload,
+	}, nil
+}
+
+// entityEvent builds a created, updated or deleted event for a record. Deleted
+// events carry only the ID, so record may be nil, and customer events leave
+// out the personal fields that are encrypted at rest.
+func entityEvent(aggregateType, action, id string, record interface{}) (*model.Event, error) {
+	if action == actionDeleted {
+		record = model.EntityDeleted{ID: id}
+	} else if customer, ok := record.(*model.Customer); ok {
+		record = model.CustomerChange{ID: customer.ID, Name: customer.Name, CreatedAt: customer.CreatedAt,
+			CreatedBy: customer.CreatedBy, UpdatedAt: customer.UpdatedAt, UpdatedBy: customer.UpdatedBy}
+	}
+	return newEvent(aggregateType+"."+action, aggregateType, id, record)
+}
+
+// carPriceChangedEvent builds the event for a car whose price changed from previousPrice
+func carPriceChangedEvent(car *model.Car, previousPrice int) (*model.Event, error) {
+	return newEvent(model.EventCarPriceChanged, aggregateCar, car.ID, model.CarPriceChange{Car: car, PreviousPrice: previousPrice})
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+)
+
+// ExpandUsecase embeds related records in customers, suppliers, cars and
+// customer-car relationships. Every expanded relation is fetched with one
+// batched query per level, whatever the number of records.
+type ExpandUsecase interface {
+	ParseExpand(resource, raw string) (model.Expand, error)
+	ExpandCustomers(customers []*model.Customer, expand model.Expand) ([]*model.ExpandedCustomer, error)
+	ExpandSuppliers(suppliers []*model.Supplier, expand model.Expand) ([]*model.ExpandedSupplier, error)
+	ExpandCars(cars []*model.Car, expand model.Expand) ([]*model.ExpandedCar, error)
+	ExpandCustomerCars(customerCars []*model.CustomerCar, expand model.Expand) ([]*model.ExpandedCustomerCar, error)
+	WithTenant(tenantID string) ExpandUsecase
+	WithContactMask(customers, suppliers bool) ExpandUsecase
+}
+
+type expandUsecase struct {
+	customerRepo    repository.CustomerRepository
+	supplierRepo    repository.SupplierRepository
+	carRepo         repository.CarRepository
+	customerCarRepo repository.CustomerCarRepository
+	maxDepth        int
+	maskCustomers   bool
+	maskSuppliers   bool
+}
+
+// NewExpandUsecase creates a new instance of ExpandUsecase accepting expand
+// paths of at most maxDepth relations
+func NewExpandUsecase(customerRepo repository.CustomerRepository, supplierRepo repository.SupplierRepository,
+	carRepo repository.CarRepository, customerCarRepo repository.CustomerCarRepository, maxDepth int) ExpandUsecase {
+	return &expandUsecase{
+		customerRepo:    customerRepo,
+		supplierRepo:    supplierRepo,
+		carRepo:         carRepo,
+		customerCarRepo: customerCarRepo,
+		maxDepth:        maxDepth,
+	}
+}
+
+// WithTenant returns a copy of the usecase that only embeds the records of tenantID
+func (u *expandUsecase) WithTenant(tenantID string) ExpandUsecase {
+	return &expandUsecase{
+		customerRepo:    u.customerRepo.WithTenant(tenantID),
+		supplierRepo:    u.supplierRepo.WithTenant(tenantID),
+		carRepo:         u.carRepo.WithTenant(tenantID),
+		customerCarRepo: u.customerCarRepo.WithTenant(tenantID),
+		maxDepth:        u.maxDepth,
+		maskCustomers:   u.maskCustomers,
+		maskSuppliers:   u.maskSuppliers,
+	}
+}
+
+// WithContactMask returns a copy of the usecase that masks the emails and
+// phones of the customers it embeds when customers is set, and of the
+// suppliers when suppliers is set
+func (u *expandUsecase) WithContactMask(customers, suppliers bool) ExpandUsecase {
+	masked := *u
+	masked.maskCustomers, masked.maskSuppliers = customers, suppliers
+	return &masked
+}
+
+// ParseExpand parses the expand parameter of a request for resource
+func (u *expandUsecase) ParseExpand(resource, raw string) (model.Expand, error) {
+	expand, err := model.ParseExpand(resource, raw, u.maxDepth)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrInvalid, err.Error())
+	}
+	return expand, nil
+}
+
+// ExpandCustomers embeds the cars owned by each customer
+func (u *expandUsecase) ExpandCustomers(customers []*model.Customer, expand model.Expand) ([]*model.ExpandedCustomer, error) {
+	expanded := make([]*model.ExpandedCustomer, len(customers))
+	for i, customer := range customers {
+		expanded[i] = &model.ExpandedCustomer{Customer: customer}
+	}
+
+	if expand.Has("cars") {
+		links, err := u.customerCarRepo.GetByCustomerIDs(unique(customers, func(c *model.Customer) string { return c.ID }))
+		if err != nil {
+			return nil, err
+		}
+		cars, err := u.carsByID(unique(links, func(cc *model.CustomerCar) string { return cc.CarID }), expand["cars"])
+		if err != nil {
+			return nil, err
+		}
+		owned := make(map[string][]*model.ExpandedCar, len(customers))
+		for _, link := range links {
+			if car, ok := cars[link.CarID]; ok {
+				owned[link.CustomerID] = append(owned[link.CustomerID], car)
+			}
+		}
+		for _, customer := range expanded {
+			customer.Cars = nonNil(owned[customer.ID])
+		}
+	}
+	return expanded, nil
+}
+
+// ExpandSuppliers embeds the cars of each supplier
+func (u *expandUsecase) ExpandSuppliers(suppliers []*model.Supplier, expand model.Expand) ([]*model.ExpandedSupplier, error) {
+	expanded := make([]*model.ExpandedSupplier, len(suppliers))
+	for i, supplier := range suppliers {
+		expanded[i] = &model.ExpandedSupplier{Supplier: supplier}
+	}
+
+	if expand.Has("cars") {
+		records, err := u.carRepo.GetCarsBySupplierIDs(unique(suppliers, func(s *model.Supplier) string { return s.ID }))
+		if err != nil {
+			return nil, err
+		}
+		cars, err := u.ExpandCars(carPointers(records), expand["cars"])
+		if err != nil {
+			return nil, err
+		}
+		supplied := make(map[string][]*model.ExpandedCar, len(suppliers))
+		for _, car := range cars {
+			supplied[car.SupplierID] = append(supplied[car.SupplierID], car)
+		}
+		for _, supplier := range expanded {
+			supplier.Cars = nonNil(supplied[supplier.ID])
+		}
+	}
+	return expanded, nil
+}
+
+// ExpandCars embeds the supplier and the customers of each car
+func (u *expandUsecase) ExpandCars(cars []*model.Car, expand model.Expand) ([]*model.ExpandedCar, error) {
+	expanded := make([]*model.ExpandedCar, len(cars))
+	for i, car := range cars {
+		expanded[i] = &model.ExpandedCar{Car: car}
+	}
+
+	if expand.Has("supplier") {
+		suppliers, err := u.suppliersByID(unique(cars, func(c *model.Car) string { return c.SupplierID }), expand["supplier"])
+		if err != nil {
+			return nil, err
+		}
+		for _, car := range expanded {
+			car.Supplier = suppliers[car.SupplierID]
+		}
+	}
+
+	if expand.Has("customers") {
+		links, err := u.customerCarRepo.GetByCarIDs(unique(cars, func(c *model.Car) string { return c.ID }))
+		if err != nil {
+			return nil, err
+		}
+		customers, err := u.customersByID(unique(links, func(cc *model.CustomerCar) string { return cc.CustomerID }), expand["customers"])
+		if err != nil {
+			return nil, err
+		}
+		owners := make(map[string][]*model.ExpandedCustomer, len(cars))
+		for _, link := range links {
+			if customer, ok := customers[link.CustomerID]; ok {
+				owners[link.CarID] = append(owners[link.CarID], customer)
+			}
+		}
+		for _, car := range expanded {
+			car.Customers = nonNil(owners[car.ID])
+		}
+	}
+	return expanded, nil
+}
+
+// ExpandCustomerCars embeds the car and the customer of each relationship
+func (u *expandUsecase) ExpandCustomerCars(customerCars []*model.CustomerCar, expand model.Expand) ([]*model.ExpandedCustomerCar, error) {
+	expanded := make([]*model.ExpandedCustomerCar, len(customerCars))
+	for i, customerCar := range customerCars {
+		expanded[i] = &model.ExpandedCustomerCar{CustomerCar: customerCar}
+	}
+
+	if expand.Has("car") {
+		cars, err := u.carsByID(unique(customerCars, func(cc *model.CustomerCar) string { return cc.CarID }), expand["car"])
+		if err != nil {
+			return nil, err
+		}
+		for _, customerCar := range expanded {
+			customerCar.Car = cars[customerCar.CarID]
+		}
+	}
+
+	if expand.Has("customer") {
+		customers, err := u.customersByID(unique(customerCars, func(cc *model.CustomerCar) string { return cc.CustomerID }), expand["customer"])
+		if err != nil {
+			return nil, err
+		}
+		for _, customerCar := range expanded {
+			customerCar.Customer = customers[customerCar.CustomerID]
+		}
+	}
+	return expanded, nil
+}
+
+// customersByID fetches and expands the customers with the given IDs
+func (u *expandUsecase) customersByID(ids []string, expand model.Expand) (map[string]*model.ExpandedCustomer, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	records, err := u.customerRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if u.maskCustomers {
+		for _, customer := range records {
+			customer.MaskContact()
+		}
+	}
+	customers, err := u.ExpandCustomers(records, expand)
+	if err != nil {
+		return nil, err
+	}
+	return byID(customers, func(c *model.ExpandedCustomer) string { return c.ID }), nil
+}
+
+// suppliersByID fetches and expands the suppliers with the given IDs
+func (u *expandUsecase) suppliersByID(ids []string, expand model.Expand) (map[string]*model.ExpandedSupplier, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	records, err := u.supplierRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if u.maskSuppliers {
+		for _, supplier := range records {
+			supplier.MaskContact()
+		}
+	}
+	suppliers, err := u.ExpandSuppliers(records, expand)
+	if err != nil {
+		return nil, err
+	}
+	return byID(suppliers, func(s *model.ExpandedSupplier) string { return s.ID }), nil
+}
+
+// carsByID fetches and expands the cars with the given IDs
+func (u *expandUsecase) carsByID(ids []string, expand model.Expand) (map[string]*model.ExpandedCar, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	records, err := u.carRepo.GetCarsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	cars, err := u.ExpandCars(carPointers(records), expand)
+	if err != nil {
+		return nil, err
+	}
+	return byID(cars, func(c *model.ExpandedCar) string { return c.ID }), nil
+}
+
+// unique returns the distinct non-empty keys of records, in order
+func unique[V any](records []V, key func(V) string) []string {
+	seen := make(map[string]bool, len(records))
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		k := key(record)
+		if k != "" && !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func byID[V any](records []V, key func(V) string) map[string]V {
+	m := make(map[string]V, len(records))
+	for _, record := range records {
+		m[key(record)] = record
+	}
+	return m
+}
+
+// nonNil returns records, or an empty slice so an expanded relation without
+// records is rendered as []
+func nonNil[V any](records []V) []V {
+	if records == nil {
+		return []V{}
+	}
+	return records
+}
+
+// carPointers adapts the cars returned by the car repository to pointers
+func carPointers(cars []model.Car) []*model.Car {
+	pointers := make([]*model.Car, len(cars))
+	for i := range cars {
+		pointers[i] = &cars[i]
+	}
+	return pointers
+}
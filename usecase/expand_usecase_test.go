@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	appErrors "github.com/GoodsChain/backend/errors"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type expandMocks struct {
+	customerRepo    *mock.MockCustomerRepository
+	supplierRepo    *mock.MockSupplierRepository
+	carRepo         *mock.MockCarRepository
+	customerCarRepo *mock.MockCustomerCarRepository
+}
+
+func newTestExpandUsecase(t *testing.T, maxDepth int) (ExpandUsecase, expandMocks) {
+	ctrl := gomock.NewController(t)
+	m := expandMocks{
+		customerRepo:    mock.NewMockCustomerRepository(ctrl),
+		supplierRepo:    mock.NewMockSupplierRepository(ctrl),
+		carRepo:         mock.NewMockCarRepository(ctrl),
+		customerCarRepo: mock.NewMockCustomerCarRepository(ctrl),
+	}
+	return NewExpandUsecase(m.customerRepo, m.supplierRepo, m.carRepo, m.customerCarRepo, maxDepth), m
+}
+
+func TestExpandUsecase_ParseExpand(t *testing.T) {
+	uc, _ := newTestExpandUsecase(t, 2)
+
+	tests := []struct {
+		name     string
+		resource string
+		raw      string
+		want     model.Expand
+		wantErr  string
+	}{
+		{
+			name:     "Nested Paths",
+			resource: model.ResourceCustomerCar,
+			raw:      "car, car.supplier,customer",
+			want:     model.Expand{"car": {"supplier": {}}, "customer": {}},
+		},
+		{
+			name:     "Nested Path Implies Parent",
+			resource: model.ResourceCustomer,
+			raw:      "cars.supplier",
+			want:     model.Expand{"cars": {"supplier": {}}},
+		},
+		{
+			name:     "Unknown Relation",
+			resource: model.ResourceCar,
+			raw:      "owner",
+			wantErr:  "unknown expand path 'owner': car has no relation 'owner', expected one of customers, supplier",
+		},
+		{
+			name:     "Unknown Nested Relation",
+			resource: model.ResourceCustomerCar,
+			raw:      "car.customer",
+			wantErr:  "unknown expand path 'car.customer': car has no relation 'customer', expected one of customers, supplier",
+		},
+		{
+			name:     "Too Deep",
+			resource: model.ResourceCustomerCar,
+			raw:      "car.supplier.cars",
+			wantErr:  "expand path 'car.supplier.cars' is nested deeper than 2 levels",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.ParseExpand(tt.resource, tt.raw)
+			if tt.wantErr != "" {
+				var appErr *appErrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, appErrors.ErrInvalid, appErr.Code)
+				assert.Equal(t, tt.wantErr, appErr.Message)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandUsecase_ExpandCustomerCars(t *testing.T) {
+	t.Run("Batches Each Level", func(t *testing.T) {
+		uc, m := newTestExpandUsecase(t, 3)
+		customerCars := []*model.CustomerCar{
+			{ID: "cc1", CarID: "car1", CustomerID: "cust1"},
+			{ID: "cc2", CarID: "car2", CustomerID: "cust1"},
+			{ID: "cc3", CarID: "car1", CustomerID: "cust2"},
+		}
+
+		// One query per relation, with every distinct ID
+		m.carRepo.EXPECT().GetCarsByIDs([]string{"car1", "car2"}).Return([]model.Car{
+			{ID: "car1", Name: "Camry", SupplierID: "supp1"},
+			{ID: "car2", Name: "Civic", SupplierID: "supp1"},
+		}, nil)
+		m.supplierRepo.EXPECT().GetByIDs([]string{"supp1"}).Return([]*model.Supplier{{ID: "supp1", Name: "Acme"}}, nil)
+		m.customerRepo.EXPECT().GetByIDs([]string{"cust1", "cust2"}).Return([]*model.Customer{
+			{ID: "cust1", Name: "John"},
+			{ID: "cust2", Name: "Jane"},
+		}, nil)
+
+		expand, err := uc.ParseExpand(model.ResourceCustomerCar, "car,car.supplier,customer")
+		require.NoError(t, err)
+		expanded, err := uc.ExpandCustomerCars(customerCars, expand)
+		require.NoError(t, err)
+
+		require.Len(t, expanded, 3)
+		assert.Equal(t, "Camry", expanded[0].Car.Name)
+		assert.Equal(t, "Acme", expanded[0].Car.Supplier.Name)
+		assert.Equal(t, "Civic", expanded[1].Car.Name)
+		assert.Equal(t, "John", expanded[1].Customer.Name)
+		assert.Equal(t, "Jane", expanded[2].Customer.Name)
+	})
+
+	t.Run("Masks Contacts", func(t *testing.T) {
+		uc, m := newTestExpandUsecase(t, 3)
+		customerCars := []*model.CustomerCar{{ID: "cc1", CarID: "car1", CustomerID: "cust1"}}
+		m.carRepo.EXPECT().GetCarsByIDs([]string{"car1"}).Return([]model.Car{{ID: "car1", SupplierID: "supp1"}}, nil)
+		m.supplierRepo.EXPECT().GetByIDs([]string{"supp1"}).Return([]*model.Supplier{
+			{ID: "supp1", Email: "sales@acme.example", Phone: "555-987-6543"},
+		}, nil)
+		m.customerRepo.EXPECT().GetByIDs([]string{"cust1"}).Return([]*model.Customer{
+			{ID: "cust1", Email: "john@example.com", Phone: "555-123-4567"},
+		}, nil)
+
+		expanded, err := uc.WithContactMask(true, false).ExpandCustomerCars(customerCars,
+			model.Expand{"car": {"supplier": {}}, "customer": {}})
+		require.NoError(t, err)
+		assert.Equal(t, "j***@example.com", expanded[0].Customer.Email)
+		assert.Equal(t, "555-***-4567", expanded[0].Customer.Phone)
+		assert.Equal(t, "sales@acme.example", expanded[0].Car.Supplier.Email)
+	})
+
+	t.Run("Missing Related Record", func(t *testing.T) {
+		uc, m := newTestExpandUsecase(t, 3)
+		customerCars := []*model.CustomerCar{{ID: "cc1", CarID: "car1", CustomerID: "cust1"}}
+		m.carRepo.EXPECT().GetCarsByIDs([]string{"car1"}).Return(nil, nil)
+
+		expanded, err := uc.ExpandCustomerCars(customerCars, model.Expand{"car": {}})
+		require.NoError(t, err)
+		assert.Nil(t, expanded[0].Car)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		uc, m := newTestExpandUsecase(t, 3)
+		customerCars := []*model.CustomerCar{{ID: "cc1", CarID: "car1", CustomerID: "cust1"}}
+		m.customerRepo.EXPECT().GetByIDs([]string{"cust1"}).Return(nil, errors.New("database error"))
+
+		_, err := uc.ExpandCustomerCars(customerCars, model.Expand{"customer": {}})
+		assert.EqualError(t, err, "database error")
+	})
+}
+
+func TestExpandUsecase_ExpandCustomers(t *testing.T) {
+	uc, m := newTestExpandUsecase(t, 3)
+	customers := []*model.Customer{{ID: "cust1"}, {ID: "cust2"}}
+
+	m.customerCarRepo.EXPECT().GetByCustomerIDs([]string{"cust1", "cust2"}).Return([]*model.CustomerCar{
+		{ID: "cc1", CustomerID: "cust1", CarID: "car1"},
+		{ID: "cc2", CustomerID: "cust1", CarID: "car2"},
+	}, nil)
+	m.carRepo.EXPECT().GetCarsByIDs([]string{"car1", "car2"}).Return([]model.Car{
+		{ID: "car2", Name: "Civic"},
+		{ID: "car1", Name: "Camry"},
+	}, nil)
+
+	expanded, err := uc.ExpandCustomers(customers, model.Expand{"cars": {}})
+	require.NoError(t, err)
+
+	require.Len(t, expanded[0].Cars, 2)
+	assert.Equal(t, "car1", expanded[0].Cars[0].ID, "cars follow the order of the relationships")
+	assert.Equal(t, "car2", expanded[0].Cars[1].ID)
+
+	// A customer without cars has an empty list rather than none
+	body, err := json.Marshal(expanded[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"cars":[]`)
+}
+
+func TestExpandUsecase_ExpandCars(t *testing.T) {
+	uc, m := newTestExpandUsecase(t, 3)
+	cars := []*model.Car{{ID: "car1", SupplierID: "supp1"}}
+
+	m.customerCarRepo.EXPECT().GetByCarIDs([]string{"car1"}).Return([]*model.CustomerCar{
+		{ID: "cc1", CustomerID: "cust1", CarID: "car1"},
+	}, nil)
+	m.customerRepo.EXPECT().GetByIDs([]string{"cust1"}).Return([]*model.Customer{{ID: "cust1", Name: "John"}}, nil)
+
+	expanded, err := uc.ExpandCars(cars, model.Expand{"customers": {}})
+	require.NoError(t, err)
+	require.Len(t, expanded[0].Customers, 1)
+	assert.Equal(t, "John", expanded[0].Customers[0].Name)
+	assert.Nil(t, expanded[0].Supplier)
+
+	// Relations that were not expanded are left out of the response
+	body, err := json.Marshal(expanded[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), `"supplier"`)
+	assert.Contains(t, string(body), `"supplier_id":"supp1"`)
+}
+
+func TestExpandUsecase_ExpandSuppliers(t *testing.T) {
+	uc, m := newTestExpandUsecase(t, 3)
+	suppliers := []*model.Supplier{{ID: "supp1"}, {ID: "supp2"}}
+
+	m.carRepo.EXPECT().GetCarsBySupplierIDs([]string{"supp1", "supp2"}).Return([]model.Car{
+		{ID: "car1", SupplierID: "supp2"},
+		{ID: "car2", SupplierID: "supp2"},
+	}, nil)
+
+	expanded, err := uc.ExpandSuppliers(suppliers, model.Expand{"cars": {}})
+	require.NoError(t, err)
+	assert.Empty(t, expanded[0].Cars)
+	require.Len(t, expanded[1].Cars, 2)
+	assert.Equal(t, "car1", expanded[1].Cars[0].ID)
+}
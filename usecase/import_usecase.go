@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/GoodsChain/backend/importer"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// Import errors
+var (
+	// ErrUnknownImportResource is returned for resources that cannot be imported
+	ErrUnknownImportResource = errors.New("resource cannot be imported")
+	// ErrInvalidImportFile is returned when the file or column mapping cannot be used
+	ErrInvalidImportFile = errors.New("invalid import file")
+	// ErrImportRowsInvalid is returned with a report when rows fail validation in commit mode
+	ErrImportRowsInvalid = errors.New("import rows failed validation")
+	// ErrImportJobActive is returned when resuming a job that is still running
+	ErrImportJobActive = errors.New("import job is already running")
+)
+
+// ImportRowFailedError is returned when a valid row cannot be inserted, e.g.
+// because it references a missing supplier or duplicates a unique email
+type ImportRowFailedError struct {
+	Row int
+	Err error
+}
+
+// Error implements the error interface
+func (e *ImportRowFailedError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the underlying database error
+func (e *ImportRowFailedError) Unwrap() error {
+	return e.Err
+}
+
+// ImportUsecase defines the interface for spreadsheet import business logic
+type ImportUsecase interface {
+	Import(req *model.ImportRequest) (*model.ImportReport, error)
+	GetJob(id string) (*model.ImportJob, error)
+	ResumeJob(id string) (*model.ImportJob, error)
+	WithTenant(tenantID string) ImportUsecase
+}
+
+type importUsecase struct {
+	transactor   repository.Transactor
+	customerRepo repository.CustomerRepository
+	supplierRepo repository.SupplierRepository
+	carRepo      repository.CarRepository
+	jobRepo      repository.ImportJobRepository
+	outboxRepo   repository.OutboxRepository
+	chunkSize    int
+
+	// runJob executes a background job; tests replace it to run synchronously
+	runJob func(fn func())
+
+	// mu and running are shared by the tenant copies of the usecase, as job
+	// IDs are unique across tenants
+	mu      *sync.Mutex
+	running map[string]bool
+}
+
+// NewImportUsecase creates a new instance of ImportUsecase. Files with more
+// valid rows than chunkSize are imported by a resumable background job that
+// commits chunkSize rows per transaction. A created event is recorded in
+// outboxRepo for every row, in the transaction that inserts it.
+func NewImportUsecase(transactor repository.Transactor, customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository, carRepo repository.CarRepository,
+	jobRepo repository.ImportJobRepository, outboxRepo repository.OutboxRepository, chunkSize int) ImportUsecase {
+	if chunkSize <= 0 {
+		chunkSize = 500
+	}
+	return &importUsecase{
+		transactor:   transactor,
+		customerRepo: customerRepo,
+		supplierRepo: supplierRepo,
+		carRepo:      carRepo,
+		jobRepo:      jobRepo,
+		outboxRepo:   outboxRepo,
+		chunkSize:    chunkSize,
+		runJob:       func(fn func()) { go fn() },
+		mu:           &sync.Mutex{},
+		running:      make(map[string]bool),
+	}
+}
+
+// WithTenant returns a copy of the usecase that only imports into and reads
+// the jobs of tenantID
+func (u *importUsecase) WithTenant(tenantID string) ImportUsecase {
+	scoped := *u
+	scoped.customerRepo = u.customerRepo.WithTenant(tenantID)
+	scoped.supplierRepo = u.supplierRepo.WithTenant(tenantID)
+	scoped.carRepo = u.carRepo.WithTenant(tenantID)
+	scoped.jobRepo = u.jobRepo.WithTenant(tenantID)
+	scoped.outboxRepo = u.outboxRepo.WithTenant(tenantID)
+	return &scoped
+}
+
+// Import validates the file and, unless it is a dry run, inserts every row.
+// Small files are inserted in a single transaction; larger ones are handed to
+// a background job whose progress is returned in the report.
+func (u *importUsecase) Import(req *model.ImportRequest) (*model.ImportReport, error) {
+	resource, ok := importer.LookupResource(req.Resource)
+	if !ok {
+		return nil, ErrUnknownImportResource
+	}
+
+	actor := req.Actor
+	if actor == "" {
+		actor = "system"
+	}
+
+	records, report, err := u.build(resource, req.Content, importer.Format(req.Format), req.Sheet, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = req.DryRun
+
+	if req.DryRun {
+		return report, nil
+	}
+	if len(report.Errors) > 0 {
+		return report, ErrImportRowsInvalid
+	}
+
+	if len(records) > u.chunkSize {
+		mapping, err := json.Marshal(req.Mapping)
+		if err != nil {
+			return nil, err
+		}
+		job := &model.ImportJob{
+			ID:        uuid.New().String(),
+			Resource:  resource.Name,
+			Format:    req.Format,
+			Sheet:     req.Sheet,
+			Mapping:   string(mapping),
+			Payload:   req.Content,
+			Status:    model.ImportStatusRunning,
+			TotalRows: len(records),
+			ChunkSize: u.chunkSize,
+			CreatedBy: actor,
+			UpdatedBy: actor,
+		}
+		if err := u.jobRepo.Create(job); err != nil {
+			return nil, err
+		}
+		u.start(job)
+		report.Job = job
+		return report, nil
+	}
+
+	err = u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		return u.insert(tx, records, actor)
+	})
+	if err != nil {
+		var rowErr *ImportRowFailedError
+		if errors.As(err, &rowErr) {
+			report.Errors = append(report.Errors, model.ImportRowError{Row: rowErr.Row, Message: rowErr.Err.Error()})
+		}
+		return report, err
+	}
+	report.ImportedRows = len(records)
+	return report, nil
+}
+
+// GetJob retrieves an import job by ID
+func (u *importUsecase) GetJob(id string) (*model.ImportJob, error) {
+	return u.jobRepo.GetByID(id)
+}
+
+// ResumeJob restarts a failed or interrupted job from its last committed chunk
+func (u *importUsecase) ResumeJob(id string) (*model.ImportJob, error) {
+	job, err := u.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == model.ImportStatusCompleted {
+		return job, nil
+	}
+	if u.isRunning(job.ID) {
+		return job, ErrImportJobActive
+	}
+
+	if err := u.jobRepo.UpdateStatus(job.ID, model.ImportStatusRunning, ""); err != nil {
+		return nil, err
+	}
+	job.Status = model.ImportStatusRunning
+	job.Error = ""
+	u.start(job)
+	return job, nil
+}
+
+// build parses and validates the file into records and a report
+func (u *importUsecase) build(resource importer.Resource, content []byte, format importer.Format,
+	sheet string, mapping importer.Mapping) ([]importer.Record, *model.ImportReport, error) {
+	table, err := importer.Parse(content, format, sheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	records, rowErrors, err := resource.Build(table, mapping)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	if rowErrors == nil {
+		rowErrors = []model.ImportRowError{}
+	}
+
+	return records, &model.ImportReport{
+		Resource:  resource.Name,
+		TotalRows: len(table.Rows),
+		ValidRows: len(records),
+		Errors:    rowErrors,
+	}, nil
+}
+
+// insert writes records through the repositories bound to tx and records
+// their created events
+func (u *importUsecase) insert(tx *sqlx.Tx, records []importer.Record, actor string) error {
+	customerRepo := u.customerRepo.WithTx(tx)
+	supplierRepo := u.supplierRepo.WithTx(tx)
+	carRepo := u.carRepo.WithTx(tx)
+
+	events := make([]*model.Event, 0, len(records))
+	for _, record := range records {
+		var aggregate, id string
+		var err error
+		switch v := record.Value.(type) {
+		case *model.Customer:
+			if v.ID == "" {
+				v.ID = uuid.New().String()
+			}
+			v.CreatedBy, v.UpdatedBy = actor, actor
+			aggregate, id = aggregateCustomer, v.ID
+			err = customerRepo.Create(v)
+		case *model.Supplier:
+			if v.ID == "" {
+				v.ID = uuid.New().String()
+			}
+			v.CreatedBy, v.UpdatedBy = actor, actor
+			aggregate, id = aggregateSupplier, v.ID
+			err = supplierRepo.Create(v)
+		case *model.Car:
+			if v.ID == "" {
+				v.ID = uuid.New().String()
+			}
+			v.CreatedBy, v.UpdatedBy = actor, actor
+			aggregate, id = aggregateCar, v.ID
+			err = carRepo.CreateCar(v)
+		default:
+			err = fmt.Errorf("unsupported record type %T", v)
+		}
+		if err != nil {
+			return &ImportRowFailedError{Row: record.Row, Err: err}
+		}
+
+		event, err := entityEvent(aggregate, actionCreated, id, record.Value)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return u.outboxRepo.WithTx(tx).Append(events...)
+}
+
+// start runs the job in the background unless it is already running
+func (u *importUsecase) start(job *model.ImportJob) {
+	u.mu.Lock()
+	if u.running[job.ID] {
+		u.mu.Unlock()
+		return
+	}
+	u.running[job.ID] = true
+	u.mu.Unlock()
+
+	// The background job works on its own copy; the caller returns job to the client
+	background := *job
+	u.runJob(func() {
+		defer func() {
+			u.mu.Lock()
+			delete(u.running, background.ID)
+			u.mu.Unlock()
+		}()
+		u.process(&background)
+	})
+}
+
+func (u *importUsecase) isRunning(id string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.running[id]
+}
+
+// process commits the remaining rows of a job chunk by chunk. Progress is
+// stored in the same transaction as each chunk, so a resumed job never
+// inserts a row twice.
+func (u *importUsecase) process(job *model.ImportJob) {
+	logger := log.With().Str("import_job_id", job.ID).Str("resource", job.Resource).Logger()
+
+	fail := func(err error) {
+		logger.Error().Err(err).Int("processed_rows", job.ProcessedRows).Msg("Import job failed")
+		if updateErr := u.jobRepo.UpdateStatus(job.ID, model.ImportStatusFailed, err.Error()); updateErr != nil {
+			logger.Error().Err(updateErr).Msg("Failed to record import job failure")
+		}
+	}
+
+	resource, ok := importer.LookupResource(job.Resource)
+	if !ok {
+		fail(ErrUnknownImportResource)
+		return
+	}
+	var mapping importer.Mapping
+	if err := json.Unmarshal([]byte(job.Mapping), &mapping); err != nil {
+		fail(err)
+		return
+	}
+	records, report, err := u.build(resource, job.Payload, importer.Format(job.Format), job.Sheet, mapping)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if len(report.Errors) > 0 {
+		fail(ErrImportRowsInvalid)
+		return
+	}
+
+	for start := job.ProcessedRows; start < len(records); start += job.ChunkSize {
+		end := start + job.ChunkSize
+		if end > len(records) {
+			end = len(records)
+		}
+		err := u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			if err := u.insert(tx, records[start:end], job.CreatedBy); err != nil {
+				return err
+			}
+			return u.jobRepo.WithTx(tx).UpdateProgress(job.ID, end)
+		})
+		if err != nil {
+			fail(err)
+			return
+		}
+		job.ProcessedRows = end
+		logger.Debug().Int("processed_rows", end).Int("total_rows", len(records)).Msg("Import chunk committed")
+	}
+
+	if err := u.jobRepo.UpdateStatus(job.ID, model.ImportStatusCompleted, ""); err != nil {
+		logger.Error().Err(err).Msg("Failed to mark import job completed")
+		return
+	}
+	job.Status = model.ImportStatusCompleted
+	logger.Info().Int("rows", len(records)).Msg("Import job completed")
+}
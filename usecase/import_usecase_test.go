@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type importMocks struct {
+	transactor   *mock.MockTransactor
+	customerRepo *mock.MockCustomerRepository
+	supplierRepo *mock.MockSupplierRepository
+	carRepo      *mock.MockCarRepository
+	jobRepo      *mock.MockImportJobRepository
+	outboxRepo   *mock.MockOutboxRepository
+}
+
+func newTestImportUsecase(t *testing.T, chunkSize int) (*importUsecase, importMocks) {
+	ctrl := gomock.NewController(t)
+	m := importMocks{
+		transactor:   mock.NewMockTransactor(ctrl),
+		customerRepo: mock.NewMockCustomerRepository(ctrl),
+		supplierRepo: mock.NewMockSupplierRepository(ctrl),
+		carRepo:      mock.NewMockCarRepository(ctrl),
+		jobRepo:      mock.NewMockImportJobRepository(ctrl),
+		outboxRepo:   mock.NewMockOutboxRepository(ctrl),
+	}
+	uc := NewImportUsecase(m.transactor, m.customerRepo, m.supplierRepo, m.carRepo, m.jobRepo, m.outboxRepo, chunkSize).(*importUsecase)
+	uc.runJob = func(fn func()) { fn() } // Run background jobs synchronously
+
+	// Transactions run the callback with a nil tx; WithTx returns the same mocks
+	m.transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	m.customerRepo.EXPECT().WithTx(gomock.Any()).Return(m.customerRepo).AnyTimes()
+	m.supplierRepo.EXPECT().WithTx(gomock.Any()).Return(m.supplierRepo).AnyTimes()
+	m.carRepo.EXPECT().WithTx(gomock.Any()).Return(m.carRepo).AnyTimes()
+	m.jobRepo.EXPECT().WithTx(gomock.Any()).Return(m.jobRepo).AnyTimes()
+	m.outboxRepo.EXPECT().WithTx(gomock.Any()).Return(m.outboxRepo).AnyTimes()
+	return uc, m
+}
+
+const carsCSV = "name,supplier_id,price\nCamry,supp1,25000\nCivic,supp1,22000\nAccord,supp2,27000\n"
+
+func TestImportUsecase_Import(t *testing.T) {
+	t.Run("UnknownResource", func(t *testing.T) {
+		uc, _ := newTestImportUsecase(t, 10)
+		_, err := uc.Import(&model.ImportRequest{Resource: "users", Content: []byte(carsCSV), Format: "csv"})
+		assert.ErrorIs(t, err, ErrUnknownImportResource)
+	})
+
+	t.Run("InvalidMapping", func(t *testing.T) {
+		uc, _ := newTestImportUsecase(t, 10)
+		_, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(carsCSV), Format: "csv",
+			Mapping: map[string]string{"price": "Giá"}})
+		assert.ErrorIs(t, err, ErrInvalidImportFile)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		uc, _ := newTestImportUsecase(t, 10)
+		content := carsCSV + "Broken,,0\n"
+
+		report, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(content), Format: "csv", DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 4, report.TotalRows)
+		assert.Equal(t, 3, report.ValidRows)
+		assert.Equal(t, 0, report.ImportedRows)
+		assert.Len(t, report.Errors, 2)
+		assert.Equal(t, 5, report.Errors[0].Row)
+	})
+
+	t.Run("RowsInvalid", func(t *testing.T) {
+		uc, _ := newTestImportUsecase(t, 10)
+		report, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(carsCSV + ",supp1,1\n"), Format: "csv"})
+		assert.ErrorIs(t, err, ErrImportRowsInvalid)
+		assert.Len(t, report.Errors, 1)
+	})
+
+	t.Run("SingleTransaction", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 10)
+		m.carRepo.EXPECT().CreateCar(gomock.Any()).DoAndReturn(func(car *model.Car) error {
+			assert.NotEmpty(t, car.ID)
+			assert.Equal(t, "import", car.CreatedBy)
+			return nil
+		}).Times(3)
+		// All created cars are recorded with one append in the import transaction
+		m.outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+			require.Len(t, events, 3)
+			for _, event := range events {
+				assert.Equal(t, model.EventCarCreated, event.Type)
+				assert.NotEmpty(t, event.AggregateID)
+			}
+			return nil
+		})
+
+		report, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(carsCSV), Format: "csv", Actor: "import"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.ImportedRows)
+		assert.Nil(t, report.Job)
+	})
+
+	t.Run("RecordsActorOfSuppliers", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 10)
+		m.supplierRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(supplier *model.Supplier) error {
+			assert.Equal(t, "import", supplier.CreatedBy)
+			assert.Equal(t, "import", supplier.UpdatedBy)
+			return nil
+		})
+		m.outboxRepo.EXPECT().Append(gomock.Any()).Return(nil)
+
+		report, err := uc.Import(&model.ImportRequest{Resource: "suppliers", Format: "csv", Actor: "import",
+			Content: []byte("name,address,phone,email\nAcme,1 Main St,555-123-4567,sales@acme.example\n")})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.ImportedRows)
+	})
+
+	t.Run("RowFailed", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 10)
+		gomock.InOrder(
+			m.carRepo.EXPECT().CreateCar(gomock.Any()).Return(nil),
+			m.carRepo.EXPECT().CreateCar(gomock.Any()).Return(errors.New("violates foreign key constraint")),
+		)
+
+		report, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(carsCSV), Format: "csv"})
+		var rowErr *ImportRowFailedError
+		require.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 3, rowErr.Row)
+		assert.Equal(t, 0, report.ImportedRows)
+		assert.Equal(t, []model.ImportRowError{{Row: 3, Message: "violates foreign key constraint"}}, report.Errors)
+	})
+
+	t.Run("ChunkedJob", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		var jobID string
+		m.jobRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(job *model.ImportJob) error {
+			jobID = job.ID
+			assert.Equal(t, model.ImportStatusRunning, job.Status)
+			assert.Equal(t, 3, job.TotalRows)
+			assert.Equal(t, 2, job.ChunkSize)
+			return nil
+		})
+		m.carRepo.EXPECT().CreateCar(gomock.Any()).Return(nil).Times(3)
+		m.outboxRepo.EXPECT().Append(gomock.Any()).Return(nil).Times(2) // One per chunk
+		gomock.InOrder(
+			m.jobRepo.EXPECT().UpdateProgress(gomock.Any(), 2).Return(nil),
+			m.jobRepo.EXPECT().UpdateProgress(gomock.Any(), 3).Return(nil),
+			m.jobRepo.EXPECT().UpdateStatus(gomock.Any(), model.ImportStatusCompleted, "").Return(nil),
+		)
+
+		report, err := uc.Import(&model.ImportRequest{Resource: "cars", Content: []byte(carsCSV), Format: "csv"})
+		require.NoError(t, err)
+		require.NotNil(t, report.Job)
+		assert.Equal(t, jobID, report.Job.ID)
+		assert.False(t, uc.isRunning(jobID))
+	})
+}
+
+func TestImportUsecase_ResumeJob(t *testing.T) {
+	failedJob := func() *model.ImportJob {
+		return &model.ImportJob{ID: "job1", Resource: "cars", Format: "csv", Mapping: "null", Payload: []byte(carsCSV),
+			Status: model.ImportStatusFailed, TotalRows: 3, ProcessedRows: 2, ChunkSize: 2, Error: "db down", CreatedBy: "import"}
+	}
+
+	t.Run("ResumesFromLastChunk", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		m.jobRepo.EXPECT().GetByID("job1").Return(failedJob(), nil)
+		m.jobRepo.EXPECT().UpdateStatus("job1", model.ImportStatusRunning, "").Return(nil)
+		// Only the third row is left
+		m.carRepo.EXPECT().CreateCar(gomock.Any()).DoAndReturn(func(car *model.Car) error {
+			assert.Equal(t, "Accord", car.Name)
+			return nil
+		}).Times(1)
+		m.outboxRepo.EXPECT().Append(gomock.Len(1)).Return(nil)
+		m.jobRepo.EXPECT().UpdateProgress("job1", 3).Return(nil)
+		m.jobRepo.EXPECT().UpdateStatus("job1", model.ImportStatusCompleted, "").Return(nil)
+
+		job, err := uc.ResumeJob("job1")
+		require.NoError(t, err)
+		assert.Equal(t, model.ImportStatusRunning, job.Status)
+		assert.Empty(t, job.Error)
+	})
+
+	t.Run("FailsAgain", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		m.jobRepo.EXPECT().GetByID("job1").Return(failedJob(), nil)
+		m.jobRepo.EXPECT().UpdateStatus("job1", model.ImportStatusRunning, "").Return(nil)
+		m.carRepo.EXPECT().CreateCar(gomock.Any()).Return(errors.New("db down"))
+		m.jobRepo.EXPECT().UpdateStatus("job1", model.ImportStatusFailed, "row 4: db down").Return(nil)
+
+		_, err := uc.ResumeJob("job1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		completed := failedJob()
+		completed.Status = model.ImportStatusCompleted
+		m.jobRepo.EXPECT().GetByID("job1").Return(completed, nil)
+
+		job, err := uc.ResumeJob("job1")
+		require.NoError(t, err)
+		assert.Equal(t, model.ImportStatusCompleted, job.Status)
+	})
+
+	t.Run("AlreadyRunning", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		uc.running["job1"] = true
+		m.jobRepo.EXPECT().GetByID("job1").Return(failedJob(), nil)
+
+		_, err := uc.ResumeJob("job1")
+		assert.ErrorIs(t, err, ErrImportJobActive)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		uc, m := newTestImportUsecase(t, 2)
+		m.jobRepo.EXPECT().GetByID("missing").Return(nil, repository.ErrNotFound)
+
+		_, err := uc.ResumeJob("missing")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Maintenance errors
+var (
+	// ErrServiceDate is returned for maintenance records dated in the future
+	ErrServiceDate = errors.New("service date cannot be in the future")
+	// ErrOdometer is returned for odometer readings that would make the
+	// mileage of a vehicle go down over time
+	ErrOdometer = errors.New("odometer reading is inconsistent with the mileage history")
+)
+
+// MaintenanceOptions configures the service interval of owned vehicles; a
+// zero interval is not applied
+type MaintenanceOptions struct {
+	IntervalKM     int // Kilometers between services
+	IntervalMonths int // Months between services
+}
+
+// MaintenanceUsecase defines the interface for the maintenance records of owned vehicles
+type MaintenanceUsecase interface {
+	AddRecord(customerCarID string, record *model.MaintenanceRecord) error
+	GetRecords(customerCarID string) ([]model.MaintenanceRecord, error)
+	GetServiceDue(customerCarID string) (*model.ServiceDue, error)
+	WithTenant(tenantID string) MaintenanceUsecase
+}
+
+type maintenanceUsecase struct {
+	maintenanceRepo repository.MaintenanceRepository
+	customerCarRepo repository.CustomerCarRepository
+	transactor      repository.Transactor
+	opts            MaintenanceOptions
+	now             func() time.Time
+}
+
+// NewMaintenanceUsecase creates a new instance of MaintenanceUsecase
+func NewMaintenanceUsecase(maintenanceRepo repository.MaintenanceRepository, customerCarRepo repository.CustomerCarRepository,
+	transactor repository.Transactor, opts MaintenanceOptions) MaintenanceUsecase {
+	return &maintenanceUsecase{
+		maintenanceRepo: maintenanceRepo,
+		customerCarRepo: customerCarRepo,
+		transactor:      transactor,
+		opts:            opts,
+		now:             time.Now,
+	}
+}
+
+// WithTenant returns a copy of the usecase that only manages the maintenance
+// records of tenantID
+func (u *maintenanceUsecase) WithTenant(tenantID string) MaintenanceUsecase {
+	return &maintenanceUsecase{
+		maintenanceRepo: u.maintenanceRepo.WithTenant(tenantID),
+		customerCarRepo: u.customerCarRepo.WithTenant(tenantID),
+		transactor:      u.transactor,
+		opts:            u.opts,
+		now:             u.now,
+	}
+}
+
+// AddRecord records maintenance of the vehicle of a customer_car
+// relationship. The odometer reading must not be below that of an earlier
+// record nor above that of a later one; records are added one at a time per
+// vehicle so concurrent ones cannot break that. A missing relationship
+// returns repository.ErrNotFound.
+func (u *maintenanceUsecase) AddRecord(customerCarID string, record *model.MaintenanceRecord) error {
+	if _, err := uuid.Parse(customerCarID); err != nil {
+		return repository.ErrNotFound
+	}
+	record.ID = uuid.New().String()
+	record.CustomerCarID = customerCarID
+	record.CreatedBy = auditActor(record.CreatedBy)
+	record.ServiceDate = dateOf(record.ServiceDate)
+	if record.ServiceDate.After(dateOf(u.now())) {
+		return ErrServiceDate
+	}
+	record.Cost = 0
+	for _, item := range record.WorkItems {
+		record.Cost += item.Cost
+	}
+
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.maintenanceRepo.WithTx(tx)
+		if err := repo.LockCustomerCar(customerCarID); err != nil {
+			return err
+		}
+		records, err := repo.GetRecords(customerCarID)
+		if err != nil {
+			return err
+		}
+		if err := checkOdometer(records, record); err != nil {
+			return err
+		}
+		return repo.CreateRecord(record)
+	})
+}
+
+// GetRecords retrieves the maintenance records of a customer_car
+// relationship in mileage order
+func (u *maintenanceUsecase) GetRecords(customerCarID string) ([]model.MaintenanceRecord, error) {
+	if _, err := u.customerCar(customerCarID); err != nil {
+		return nil, err
+	}
+	return u.maintenanceRepo.GetRecords(customerCarID)
+}
+
+// GetServiceDue computes when the vehicle of a customer_car relationship is
+// due for service. The interval starts at the latest service, or without one
+// at the start of the ownership and the first recorded odometer reading.
+func (u *maintenanceUsecase) GetServiceDue(customerCarID string) (*model.ServiceDue, error) {
+	customerCar, err := u.customerCar(customerCarID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := u.maintenanceRepo.GetRecords(customerCarID)
+	if err != nil {
+		return nil, err
+	}
+
+	due := &model.ServiceDue{
+		CustomerCarID:  customerCarID,
+		IntervalKM:     u.opts.IntervalKM,
+		IntervalMonths: u.opts.IntervalMonths,
+	}
+	fromDate, fromOdometer := dateOf(customerCar.CreatedAt), 0
+	if len(records) > 0 {
+		fromOdometer = records[0].Odometer
+		latest := records[len(records)-1].Odometer
+		due.Odometer = &latest
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Kind == model.MaintenanceService {
+			due.LastService = &records[i]
+			fromDate, fromOdometer = records[i].ServiceDate, records[i].Odometer
+			break
+		}
+	}
+
+	if u.opts.IntervalMonths > 0 {
+		dueDate := fromDate.AddDate(0, u.opts.IntervalMonths, 0)
+		due.DueDate = &dueDate
+		due.Overdue = !dateOf(u.now()).Before(dueDate)
+	}
+	if u.opts.IntervalKM > 0 {
+		dueOdometer := fromOdometer + u.opts.IntervalKM
+		due.DueOdometer = &dueOdometer
+		due.Overdue = due.Overdue || (due.Odometer != nil && *due.Odometer >= dueOdometer)
+	}
+	return due, nil
+}
+
+// customerCar retrieves a customer_car relationship; IDs that are not UUIDs
+// name none
+func (u *maintenanceUsecase) customerCar(id string) (*model.CustomerCar, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, repository.ErrNotFound
+	}
+	return u.customerCarRepo.GetByID(id)
+}
+
+// checkOdometer returns ErrOdometer when the reading of record is below that
+// of a record of an earlier day or above that of a later day
+func checkOdometer(records []model.MaintenanceRecord, record *model.MaintenanceRecord) error {
+	for _, r := range records {
+		switch {
+		case r.ServiceDate.Before(record.ServiceDate) && r.Odometer > record.Odometer:
+			return fmt.Errorf("%w: %d km on %s is below the %d km recorded on %s", ErrOdometer,
+				record.Odometer, record.ServiceDate.Format(time.DateOnly), r.Odometer, r.ServiceDate.Format(time.DateOnly))
+		case r.ServiceDate.After(record.ServiceDate) && r.Odometer < record.Odometer:
+			return fmt.Errorf("%w: %d km on %s is above the %d km recorded on %s", ErrOdometer,
+				record.Odometer, record.ServiceDate.Format(time.DateOnly), r.Odometer, r.ServiceDate.Format(time.DateOnly))
+		}
+	}
+	return nil
+}
+
+// dateOf returns the day of t as midnight UTC, as DATE columns are read
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
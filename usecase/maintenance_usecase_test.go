@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testCustomerCarID = "6f1d2c3b-4a5e-4f6a-8b7c-9d0e1f2a3b4c"
+
+// newTestMaintenanceUsecase returns a MaintenanceUsecase at noon on
+// 2024-06-15 whose transactions run directly against the returned mocks
+func newTestMaintenanceUsecase(ctrl *gomock.Controller, opts MaintenanceOptions) (MaintenanceUsecase,
+	*mock.MockMaintenanceRepository, *mock.MockCustomerCarRepository) {
+	mockRepo := mock.NewMockMaintenanceRepository(ctrl)
+	mockCustomerCarRepo := mock.NewMockCustomerCarRepository(ctrl)
+	transactor := mock.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+
+	uc := NewMaintenanceUsecase(mockRepo, mockCustomerCarRepo, transactor, opts).(*maintenanceUsecase)
+	uc.now = func() time.Time { return time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC) }
+	return uc, mockRepo, mockCustomerCarRepo
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMaintenanceUsecase_AddRecord(t *testing.T) {
+	history := []model.MaintenanceRecord{
+		{ID: "r1", Kind: model.MaintenanceService, ServiceDate: day(2023, 6, 1), Odometer: 15000},
+		{ID: "r2", Kind: model.MaintenanceRepair, ServiceDate: day(2024, 1, 10), Odometer: 24000},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, _ := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{})
+		mockRepo.EXPECT().LockCustomerCar(testCustomerCarID).Return(nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return(history, nil)
+		mockRepo.EXPECT().CreateRecord(gomock.Any()).Return(nil)
+
+		record := &model.MaintenanceRecord{
+			Kind:        model.MaintenanceService,
+			ServiceDate: time.Date(2024, 6, 14, 17, 30, 0, 0, time.UTC),
+			Odometer:    30000,
+			WorkItems:   model.WorkItems{{Description: "Oil change", Cost: 8500}, {Description: "Brake pads", Cost: 12000}},
+		}
+		require.NoError(t, uc.AddRecord(testCustomerCarID, record))
+		assert.NotEmpty(t, record.ID)
+		assert.Equal(t, testCustomerCarID, record.CustomerCarID)
+		assert.Equal(t, day(2024, 6, 14), record.ServiceDate)
+		assert.Equal(t, 20500, record.Cost)
+		assert.Equal(t, "system", record.CreatedBy)
+	})
+
+	t.Run("BackdatedBetweenRecords", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, _ := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{})
+		mockRepo.EXPECT().LockCustomerCar(testCustomerCarID).Return(nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return(history, nil)
+		mockRepo.EXPECT().CreateRecord(gomock.Any()).Return(nil)
+
+		record := &model.MaintenanceRecord{Kind: model.MaintenanceInspection, ServiceDate: day(2023, 9, 1), Odometer: 19000}
+		assert.NoError(t, uc.AddRecord(testCustomerCarID, record))
+	})
+
+	for name, record := range map[string]*model.MaintenanceRecord{
+		"BelowEarlierRecord": {Kind: model.MaintenanceRepair, ServiceDate: day(2024, 6, 1), Odometer: 23000},
+		"AboveLaterRecord":   {Kind: model.MaintenanceRepair, ServiceDate: day(2023, 12, 1), Odometer: 25000},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc, mockRepo, _ := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{})
+			mockRepo.EXPECT().LockCustomerCar(testCustomerCarID).Return(nil)
+			mockRepo.EXPECT().GetRecords(testCustomerCarID).Return(history, nil)
+
+			assert.ErrorIs(t, uc.AddRecord(testCustomerCarID, record), ErrOdometer)
+		})
+	}
+
+	t.Run("FutureDate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, _, _ := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{})
+
+		record := &model.MaintenanceRecord{Kind: model.MaintenanceService, ServiceDate: day(2024, 6, 16), Odometer: 30000}
+		assert.ErrorIs(t, uc.AddRecord(testCustomerCarID, record), ErrServiceDate)
+	})
+
+	t.Run("MissingCustomerCar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, _ := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{})
+		mockRepo.EXPECT().LockCustomerCar(testCustomerCarID).Return(repository.ErrNotFound)
+
+		record := &model.MaintenanceRecord{Kind: model.MaintenanceService, ServiceDate: day(2024, 6, 1), Odometer: 30000}
+		assert.ErrorIs(t, uc.AddRecord(testCustomerCarID, record), repository.ErrNotFound)
+		assert.ErrorIs(t, uc.AddRecord("not-a-uuid", record), repository.ErrNotFound)
+	})
+}
+
+func TestMaintenanceUsecase_GetServiceDue(t *testing.T) {
+	opts := MaintenanceOptions{IntervalKM: 15000, IntervalMonths: 12}
+	owned := &model.CustomerCar{ID: testCustomerCarID, CreatedAt: time.Date(2023, 3, 20, 10, 0, 0, 0, time.UTC)}
+
+	t.Run("FromLatestService", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, mockCustomerCarRepo := newTestMaintenanceUsecase(ctrl, opts)
+		mockCustomerCarRepo.EXPECT().GetByID(testCustomerCarID).Return(owned, nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return([]model.MaintenanceRecord{
+			{ID: "r1", Kind: model.MaintenanceService, ServiceDate: day(2023, 6, 1), Odometer: 5000},
+			{ID: "r2", Kind: model.MaintenanceService, ServiceDate: day(2023, 11, 5), Odometer: 12000},
+			{ID: "r3", Kind: model.MaintenanceRepair, ServiceDate: day(2024, 2, 1), Odometer: 18000},
+		}, nil)
+
+		due, err := uc.GetServiceDue(testCustomerCarID)
+		require.NoError(t, err)
+		require.NotNil(t, due.LastService)
+		assert.Equal(t, "r2", due.LastService.ID)
+		assert.Equal(t, 18000, *due.Odometer)
+		assert.Equal(t, day(2024, 11, 5), *due.DueDate)
+		assert.Equal(t, 27000, *due.DueOdometer)
+		assert.False(t, due.Overdue)
+	})
+
+	t.Run("OverdueByMileage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, mockCustomerCarRepo := newTestMaintenanceUsecase(ctrl, opts)
+		mockCustomerCarRepo.EXPECT().GetByID(testCustomerCarID).Return(owned, nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return([]model.MaintenanceRecord{
+			{ID: "r1", Kind: model.MaintenanceService, ServiceDate: day(2024, 1, 10), Odometer: 20000},
+			{ID: "r2", Kind: model.MaintenanceInspection, ServiceDate: day(2024, 6, 1), Odometer: 35000},
+		}, nil)
+
+		due, err := uc.GetServiceDue(testCustomerCarID)
+		require.NoError(t, err)
+		assert.Equal(t, 35000, *due.DueOdometer)
+		assert.True(t, due.Overdue)
+	})
+
+	t.Run("WithoutService", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, mockCustomerCarRepo := newTestMaintenanceUsecase(ctrl, opts)
+		mockCustomerCarRepo.EXPECT().GetByID(testCustomerCarID).Return(owned, nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return([]model.MaintenanceRecord{
+			{ID: "r1", Kind: model.MaintenanceRepair, ServiceDate: day(2023, 8, 1), Odometer: 41000},
+		}, nil)
+
+		due, err := uc.GetServiceDue(testCustomerCarID)
+		require.NoError(t, err)
+		assert.Nil(t, due.LastService)
+		assert.Equal(t, day(2024, 3, 20), *due.DueDate)
+		assert.Equal(t, 56000, *due.DueOdometer)
+		assert.True(t, due.Overdue)
+	})
+
+	t.Run("MonthsOnly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, mockRepo, mockCustomerCarRepo := newTestMaintenanceUsecase(ctrl, MaintenanceOptions{IntervalMonths: 24})
+		mockCustomerCarRepo.EXPECT().GetByID(testCustomerCarID).Return(owned, nil)
+		mockRepo.EXPECT().GetRecords(testCustomerCarID).Return([]model.MaintenanceRecord{}, nil)
+
+		due, err := uc.GetServiceDue(testCustomerCarID)
+		require.NoError(t, err)
+		assert.Nil(t, due.Odometer)
+		assert.Nil(t, due.DueOdometer)
+		assert.Equal(t, day(2025, 3, 20), *due.DueDate)
+		assert.False(t, due.Overdue)
+	})
+
+	t.Run("MissingCustomerCar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		uc, _, mockCustomerCarRepo := newTestMaintenanceUsecase(ctrl, opts)
+		mockCustomerCarRepo.EXPECT().GetByID(testCustomerCarID).Return(nil, repository.ErrNotFound)
+
+		_, err := uc.GetServiceDue(testCustomerCarID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
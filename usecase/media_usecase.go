@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Decoders of the accepted image types
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/GoodsChain/backend/blobstore"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Media errors
+var (
+	// ErrMediaType is returned for uploads whose content is not an accepted
+	// image or document type
+	ErrMediaType = errors.New("unsupported media type, upload a JPEG, PNG, GIF or WebP image or a PDF document")
+	// ErrInvalidImage is returned for images that cannot be decoded or are too large to decode
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrMediaLink is returned for download links with a wrong signature or that expired
+	ErrMediaLink = errors.New("invalid or expired download link")
+)
+
+// mediaKinds are the accepted content types, as sniffed by
+// http.DetectContentType, by the kind of media they are
+var mediaKinds = map[string]string{
+	"image/jpeg":      model.MediaImage,
+	"image/png":       model.MediaImage,
+	"image/gif":       model.MediaImage,
+	"image/webp":      model.MediaImage,
+	"application/pdf": model.MediaDocument,
+}
+
+// MediaOptions configures thumbnails and download links
+type MediaOptions struct {
+	URLSecret      []byte        // Key signing the download URLs
+	URLTTL         time.Duration // Lifetime of download URLs
+	URLPrefix      string        // Path the download endpoint is served under, such as /v1/media
+	ThumbnailSize  int           // Longest side of thumbnails in pixels
+	MaxImagePixels int           // Largest width times height of an image that is decoded
+}
+
+// MediaFile is the content of a media file served by a download link
+type MediaFile struct {
+	Filename    string
+	ContentType string
+	Kind        string
+	Body        io.ReadCloser
+}
+
+// MediaUsecase defines the interface for the photos and documents of cars
+type MediaUsecase interface {
+	UploadMedia(upload *model.MediaUpload) (*model.Media, error)
+	GetCarMedia(carID string) ([]model.Media, error)
+	DeleteMedia(carID, id string) error
+	OpenMedia(link model.MediaLink) (*MediaFile, error)
+	RemoveOrphanedFiles(limit int) (int, error)
+	WithTenant(tenantID string) MediaUsecase
+}
+
+type mediaUsecase struct {
+	mediaRepo repository.MediaRepository
+	store     blobstore.BlobStore
+	opts      MediaOptions
+	tenantID  string
+	now       func() time.Time
+}
+
+// NewMediaUsecase creates a new instance of MediaUsecase storing files in store
+func NewMediaUsecase(mediaRepo repository.MediaRepository, store blobstore.BlobStore, opts MediaOptions) MediaUsecase {
+	return &mediaUsecase{mediaRepo: mediaRepo, store: store, opts: opts, tenantID: model.DefaultTenantID, now: time.Now}
+}
+
+// WithTenant returns a copy of the usecase that only manages the media of tenantID
+func (u *mediaUsecase) WithTenant(tenantID string) MediaUsecase {
+	return &mediaUsecase{mediaRepo: u.mediaRepo.WithTenant(tenantID), store: u.store, opts: u.opts, tenantID: tenantID, now: u.now}
+}
+
+// UploadMedia sniffs the type of an uploaded file, stores it with a
+// thumbnail for images and records its metadata. A missing car returns
+// repository.ErrNotFound, and the stored files are removed again.
+func (u *mediaUsecase) UploadMedia(upload *model.MediaUpload) (*model.Media, error) {
+	// Car IDs are part of the storage key; those that are not UUIDs name no car
+	if _, err := uuid.Parse(upload.CarID); err != nil {
+		return nil, repository.ErrNotFound
+	}
+	contentType := http.DetectContentType(upload.Content)
+	kind, ok := mediaKinds[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: got %s", ErrMediaType, contentType)
+	}
+	sum := sha256.Sum256(upload.Content)
+	media := &model.Media{
+		ID:          uuid.New().String(),
+		CarID:       upload.CarID,
+		Kind:        kind,
+		Filename:    cleanFilename(upload.Filename),
+		ContentType: contentType,
+		Size:        int64(len(upload.Content)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		CreatedBy:   auditActor(upload.Actor),
+	}
+	media.StorageKey = u.tenantID + "/cars/" + upload.CarID + "/" + media.ID
+
+	var thumbnail []byte
+	if kind == model.MediaImage {
+		img, err := u.decodeImage(upload.Content)
+		if err != nil {
+			return nil, err
+		}
+		width, height := img.Bounds().Dx(), img.Bounds().Dy()
+		media.Width, media.Height = &width, &height
+		if thumbnail, err = u.thumbnail(img); err != nil {
+			return nil, err
+		}
+		key := media.StorageKey + "-thumb.jpg"
+		media.ThumbnailKey = &key
+	}
+
+	ctx := context.Background()
+	if err := u.store.Put(ctx, media.StorageKey, bytes.NewReader(upload.Content), media.Size, contentType); err != nil {
+		return nil, err
+	}
+	if media.ThumbnailKey != nil {
+		if err := u.store.Put(ctx, *media.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+			u.removeFiles(media)
+			return nil, err
+		}
+	}
+	if err := u.mediaRepo.CreateMedia(media); err != nil {
+		u.removeFiles(media)
+		return nil, err
+	}
+	u.sign(media)
+	return media, nil
+}
+
+// GetCarMedia retrieves the media of a car with fresh download links
+func (u *mediaUsecase) GetCarMedia(carID string) ([]model.Media, error) {
+	media, err := u.mediaRepo.GetMediaByCarID(carID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range media {
+		u.sign(&media[i])
+	}
+	return media, nil
+}
+
+// DeleteMedia removes a media file of a car and its metadata. Links handed
+// out before stop working as the files are gone.
+func (u *mediaUsecase) DeleteMedia(carID, id string) error {
+	media, err := u.mediaRepo.GetMedia(id)
+	if err != nil {
+		return err
+	}
+	if media.CarID != carID {
+		return repository.ErrNotFound
+	}
+	if err := u.mediaRepo.DeleteMedia(carID, id); err != nil {
+		return err
+	}
+	u.removeFiles(media)
+	return nil
+}
+
+// OpenMedia checks the signature and expiry of a download link and opens
+// the file it serves. The link names its tenant, so any tenant works.
+func (u *mediaUsecase) OpenMedia(link model.MediaLink) (*MediaFile, error) {
+	if link.Variant == "" {
+		link.Variant = model.MediaOriginal
+	}
+	expected := u.signature(link.TenantID, link.MediaID, link.Variant, link.Expires)
+	if !hmac.Equal([]byte(link.Signature), []byte(expected)) || u.now().Unix() > link.Expires {
+		return nil, ErrMediaLink
+	}
+	media, err := u.mediaRepo.WithTenant(link.TenantID).GetMedia(link.MediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &MediaFile{Filename: media.Filename, ContentType: media.ContentType, Kind: media.Kind}
+	key := media.StorageKey
+	if link.Variant == model.MediaThumbnail {
+		if media.ThumbnailKey == nil {
+			return nil, repository.ErrNotFound
+		}
+		key = *media.ThumbnailKey
+		file.ContentType = "image/jpeg"
+		file.Filename = strings.TrimSuffix(media.Filename, path.Ext(media.Filename)) + "-thumb.jpg"
+	}
+	body, err := u.store.Get(context.Background(), key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	file.Body = body
+	return file, nil
+}
+
+// decodeImage decodes an image, refusing those with more pixels than allowed
+// before decoding them
+func (u *mediaUsecase) decodeImage(content []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if u.opts.MaxImagePixels > 0 && config.Width*config.Height > u.opts.MaxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d is larger than %d pixels", ErrInvalidImage, config.Width, config.Height, u.opts.MaxImagePixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	return img, nil
+}
+
+// thumbnail returns img scaled down to fit the thumbnail size as a JPEG,
+// with transparent areas on white
+func (u *mediaUsecase) thumbnail(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if longest := max(width, height); u.opts.ThumbnailSize > 0 && longest > u.opts.ThumbnailSize {
+		width = max(1, width*u.opts.ThumbnailSize/longest)
+		height = max(1, height*u.opts.ThumbnailSize/longest)
+	}
+	thumb := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.Draw(thumb, thumb.Bounds(), image.NewUniform(color.White), image.Point{}, xdraw.Src)
+	xdraw.CatmullRom.Scale(thumb, thumb.Bounds(), img, bounds, xdraw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sign sets the download links of media, valid for the URL lifetime
+func (u *mediaUsecase) sign(media *model.Media) {
+	expires := u.now().Add(u.opts.URLTTL).Truncate(time.Second)
+	media.URL = u.link(media.ID, model.MediaOriginal, expires.Unix())
+	if media.ThumbnailKey != nil {
+		media.ThumbnailURL = u.link(media.ID, model.MediaThumbnail, expires.Unix())
+	}
+	media.URLExpiresAt = &expires
+}
+
+func (u *mediaUsecase) link(mediaID, variant string, expires int64) string {
+	query := url.Values{
+		"tenant":    {u.tenantID},
+		"variant":   {variant},
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {u.signature(u.tenantID, mediaID, variant, expires)},
+	}
+	return u.opts.URLPrefix + "/" + mediaID + "?" + query.Encode()
+}
+
+// signature is the HMAC-SHA256 of what a link serves until when
+func (u *mediaUsecase) signature(tenantID, mediaID, variant string, expires int64) string {
+	mac := hmac.New(sha256.New, u.opts.URLSecret)
+	mac.Write([]byte(tenantID + "\n" + mediaID + "\n" + variant + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// RemoveOrphanedFiles removes up to limit stored files of the media deleted
+// from every tenant, including those deleted with their car, and returns the
+// number removed. Files that fail to be removed are tried again next time.
+func (u *mediaUsecase) RemoveOrphanedFiles(limit int) (int, error) {
+	keys, err := u.mediaRepo.GetOrphanedFiles(limit)
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	ctx := context.Background()
+	removed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if err := u.store.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to remove media file")
+			continue
+		}
+		removed = append(removed, key)
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+	return len(removed), u.mediaRepo.DeleteOrphanedFiles(removed)
+}
+
+// removeFiles deletes the stored files of media; a failure only leaves an
+// unreferenced file behind, which RemoveOrphanedFiles removes later for
+// deleted media
+func (u *mediaUsecase) removeFiles(media *model.Media) {
+	ctx := context.Background()
+	_ = u.store.Delete(ctx, media.StorageKey)
+	if media.ThumbnailKey != nil {
+		_ = u.store.Delete(ctx, *media.ThumbnailKey)
+	}
+}
+
+// cleanFilename returns the base name of an uploaded file without control
+// characters, at most 255 bytes long
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		name = "file"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
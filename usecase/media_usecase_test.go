@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/blobstore"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testCarID = "6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+
+func newTestMediaUsecase(t *testing.T) (MediaUsecase, *mock.MockMediaRepository, *blobstore.LocalStore) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockMediaRepository(ctrl)
+	mockRepo.EXPECT().WithTenant(gomock.Any()).Return(mockRepo).AnyTimes()
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	uc := NewMediaUsecase(mockRepo, store, MediaOptions{
+		URLSecret:      []byte("secret"),
+		URLTTL:         15 * time.Minute,
+		URLPrefix:      "/v1/media",
+		ThumbnailSize:  100,
+		MaxImagePixels: 1000 * 1000,
+	})
+	return uc.WithTenant("tenant1"), mockRepo, store
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func readBlob(t *testing.T, store blobstore.BlobStore, key string) []byte {
+	r, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+// linkOf parses a signed download URL
+func linkOf(t *testing.T, rawURL string) model.MediaLink {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	return model.MediaLink{
+		TenantID:  u.Query().Get("tenant"),
+		MediaID:   strings.TrimPrefix(u.Path, "/v1/media/"),
+		Variant:   u.Query().Get("variant"),
+		Expires:   expires,
+		Signature: u.Query().Get("signature"),
+	}
+}
+
+func TestMediaUsecase_UploadMedia(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		uc, mockRepo, store := newTestMediaUsecase(t)
+		content := pngImage(t, 400, 200)
+		var created *model.Media
+		mockRepo.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(media *model.Media) error {
+			created = media
+			return nil
+		})
+
+		media, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Filename: `C:\photos\front.png`, Content: content, Actor: "admin"})
+		require.NoError(t, err)
+		assert.Same(t, created, media)
+		assert.Equal(t, model.MediaImage, media.Kind)
+		assert.Equal(t, "front.png", media.Filename)
+		assert.Equal(t, "image/png", media.ContentType)
+		assert.Equal(t, int64(len(content)), media.Size)
+		assert.Equal(t, 400, *media.Width)
+		assert.Equal(t, 200, *media.Height)
+		assert.Len(t, media.Checksum, 64)
+		assert.Equal(t, "tenant1/cars/"+testCarID+"/"+media.ID, media.StorageKey)
+		assert.Equal(t, content, readBlob(t, store, media.StorageKey))
+
+		// The thumbnail fits the thumbnail size and keeps the aspect ratio
+		thumb, err := jpeg.Decode(bytes.NewReader(readBlob(t, store, *media.ThumbnailKey)))
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(100, 50), thumb.Bounds().Size())
+
+		assert.True(t, strings.HasPrefix(media.URL, "/v1/media/"+media.ID+"?"))
+		assert.Contains(t, media.ThumbnailURL, "variant=thumbnail")
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), *media.URLExpiresAt, 2*time.Second)
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		uc, mockRepo, _ := newTestMediaUsecase(t)
+		mockRepo.EXPECT().CreateMedia(gomock.Any()).Return(nil)
+
+		media, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Filename: "brochure.pdf", Content: []byte("%PDF-1.7\n...")})
+		require.NoError(t, err)
+		assert.Equal(t, model.MediaDocument, media.Kind)
+		assert.Equal(t, "application/pdf", media.ContentType)
+		assert.Nil(t, media.ThumbnailKey)
+		assert.Empty(t, media.ThumbnailURL)
+		assert.Equal(t, "system", media.CreatedBy)
+	})
+
+	t.Run("SniffedNotNamed", func(t *testing.T) {
+		uc, _, _ := newTestMediaUsecase(t)
+		_, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Filename: "photo.jpg", Content: []byte("<html><script>")})
+		assert.ErrorIs(t, err, ErrMediaType)
+		assert.ErrorContains(t, err, "got text/html")
+	})
+
+	t.Run("TooManyPixels", func(t *testing.T) {
+		uc, _, _ := newTestMediaUsecase(t)
+		_, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Content: pngImage(t, 2000, 600)})
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+
+	t.Run("CorruptImage", func(t *testing.T) {
+		uc, _, _ := newTestMediaUsecase(t)
+		content := pngImage(t, 10, 10)
+		_, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Content: content[:len(content)/2]})
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+
+	t.Run("MissingCar", func(t *testing.T) {
+		uc, mockRepo, store := newTestMediaUsecase(t)
+		var key string
+		mockRepo.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(media *model.Media) error {
+			key = media.StorageKey
+			return repository.ErrNotFound
+		})
+		_, err := uc.UploadMedia(&model.MediaUpload{CarID: testCarID, Content: pngImage(t, 10, 10)})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		// The stored files are removed again
+		_, err = store.Get(context.Background(), key)
+		assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+		_, err = uc.UploadMedia(&model.MediaUpload{CarID: "../../etc", Content: pngImage(t, 10, 10)})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestMediaUsecase_OpenMedia(t *testing.T) {
+	uc, mockRepo, store := newTestMediaUsecase(t)
+	thumbKey := "tenant1/cars/car1/media1-thumb.jpg"
+	media := &model.Media{ID: "media1", CarID: "car1", Kind: model.MediaImage, Filename: "front.png", ContentType: "image/png",
+		StorageKey: "tenant1/cars/car1/media1", ThumbnailKey: &thumbKey}
+	require.NoError(t, store.Put(context.Background(), media.StorageKey, strings.NewReader("original"), 8, ""))
+	require.NoError(t, store.Put(context.Background(), thumbKey, strings.NewReader("thumb"), 5, ""))
+	mockRepo.EXPECT().GetMediaByCarID("car1").Return([]model.Media{*media}, nil)
+	listed, err := uc.GetCarMedia("car1")
+	require.NoError(t, err)
+
+	t.Run("Original", func(t *testing.T) {
+		mockRepo.EXPECT().GetMedia("media1").Return(media, nil)
+		file, err := uc.OpenMedia(linkOf(t, listed[0].URL))
+		require.NoError(t, err)
+		defer file.Body.Close()
+		data, _ := io.ReadAll(file.Body)
+		assert.Equal(t, "original", string(data))
+		assert.Equal(t, "image/png", file.ContentType)
+		assert.Equal(t, "front.png", file.Filename)
+	})
+
+	t.Run("Thumbnail", func(t *testing.T) {
+		mockRepo.EXPECT().GetMedia("media1").Return(media, nil)
+		file, err := uc.OpenMedia(linkOf(t, listed[0].ThumbnailURL))
+		require.NoError(t, err)
+		defer file.Body.Close()
+		data, _ := io.ReadAll(file.Body)
+		assert.Equal(t, "thumb", string(data))
+		assert.Equal(t, "image/jpeg", file.ContentType)
+		assert.Equal(t, "front-thumb.jpg", file.Filename)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		for _, tamper := range []func(*model.MediaLink){
+			func(l *model.MediaLink) { l.TenantID = "tenant2" },
+			func(l *model.MediaLink) { l.MediaID = "media2" },
+			func(l *model.MediaLink) { l.Variant = model.MediaThumbnail },
+			func(l *model.MediaLink) { l.Expires += 3600 },
+			func(l *model.MediaLink) { l.Signature = "" },
+		} {
+			link := linkOf(t, listed[0].URL)
+			tamper(&link)
+			_, err := uc.OpenMedia(link)
+			assert.ErrorIs(t, err, ErrMediaLink)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		// Links handed out an hour ago expired 45 minutes ago
+		mediaUC := uc.(*mediaUsecase)
+		mediaUC.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		mockRepo.EXPECT().GetMediaByCarID("car1").Return([]model.Media{*media}, nil)
+		old, err := uc.GetCarMedia("car1")
+		mediaUC.now = time.Now
+		require.NoError(t, err)
+
+		_, err = uc.OpenMedia(linkOf(t, old[0].URL))
+		assert.ErrorIs(t, err, ErrMediaLink)
+	})
+}
+
+func TestMediaUsecase_DeleteMedia(t *testing.T) {
+	uc, mockRepo, store := newTestMediaUsecase(t)
+	media := &model.Media{ID: "media1", CarID: "car1", StorageKey: "tenant1/cars/car1/media1"}
+	require.NoError(t, store.Put(context.Background(), media.StorageKey, strings.NewReader("x"), 1, ""))
+
+	mockRepo.EXPECT().GetMedia("media1").Return(media, nil)
+	assert.ErrorIs(t, uc.DeleteMedia("car2", "media1"), repository.ErrNotFound)
+
+	mockRepo.EXPECT().GetMedia("media1").Return(media, nil)
+	mockRepo.EXPECT().DeleteMedia("car1", "media1").Return(nil)
+	require.NoError(t, uc.DeleteMedia("car1", "media1"))
+	_, err := store.Get(context.Background(), media.StorageKey)
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+func TestMediaUsecase_RemoveOrphanedFiles(t *testing.T) {
+	uc, mockRepo, store := newTestMediaUsecase(t)
+	key := "tenant1/cars/car1/media1"
+	require.NoError(t, store.Put(context.Background(), key, strings.NewReader("x"), 1, ""))
+
+	// Files the store fails to remove stay queued for the next sweep
+	mockRepo.EXPECT().GetOrphanedFiles(10).Return([]string{key, "tenant1/../escape", "tenant1/cars/car1/gone"}, nil)
+	mockRepo.EXPECT().DeleteOrphanedFiles([]string{key, "tenant1/cars/car1/gone"}).Return(nil)
+	removed, err := uc.RemoveOrphanedFiles(10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	_, err = store.Get(context.Background(), key)
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	mockRepo.EXPECT().GetOrphanedFiles(10).Return([]string{}, nil)
+	removed, err = uc.RemoveOrphanedFiles(10)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
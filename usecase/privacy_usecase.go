@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+// Pseudonyms of the personal fields of erased customers. The email is
+// derived from the customer ID, so it stays unique and valid.
+const (
+	erasedName        = "Erased customer"
+	erasedEmailDomain = "erased.invalid"
+)
+
+// PrivacyUsecase answers the data subject requests of customers: access to
+// all data held on them, and its erasure
+type PrivacyUsecase interface {
+	ExportCustomerData(id, actor string) (*model.CustomerDataExport, error)
+	EraseCustomer(id, actor string) (*model.Customer, error)
+	WithTenant(tenantID string) PrivacyUsecase
+}
+
+type privacyUsecase struct {
+	customerRepo    repository.CustomerRepository
+	customerCarRepo repository.CustomerCarRepository
+	carRepo         repository.CarRepository
+	maintenanceRepo repository.MaintenanceRepository
+	outboxRepo      repository.OutboxRepository
+	transactor      repository.Transactor
+}
+
+// NewPrivacyUsecase creates a new instance of PrivacyUsecase. Exports and
+// erasures are recorded as customer.data_exported and customer.erased events
+// in outboxRepo, which also holds the audit trail included in exports.
+func NewPrivacyUsecase(customerRepo repository.CustomerRepository, customerCarRepo repository.CustomerCarRepository,
+	carRepo repository.CarRepository, maintenanceRepo repository.MaintenanceRepository, outboxRepo repository.OutboxRepository,
+	transactor repository.Transactor) PrivacyUsecase {
+	return &privacyUsecase{
+		customerRepo:    customerRepo,
+		customerCarRepo: customerCarRepo,
+		carRepo:         carRepo,
+		maintenanceRepo: maintenanceRepo,
+		outboxRepo:      outboxRepo,
+		transactor:      transactor,
+	}
+}
+
+// WithTenant returns a copy of the usecase that only handles the customers of tenantID
+func (u *privacyUsecase) WithTenant(tenantID string) PrivacyUsecase {
+	return NewPrivacyUsecase(u.customerRepo.WithTenant(tenantID), u.customerCarRepo.WithTenant(tenantID),
+		u.carRepo.WithTenant(tenantID), u.maintenanceRepo.WithTenant(tenantID), u.outboxRepo.WithTenant(tenantID), u.transactor)
+}
+
+// ExportCustomerData returns the customer with id, the cars linked to them,
+// their maintenance records and the audit trail of the customer and links,
+// read in one transaction that also records the export
+func (u *privacyUsecase) ExportCustomerData(id, actor string) (*model.CustomerDataExport, error) {
+	var export *model.CustomerDataExport
+	err := u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		customer, err := getCustomer(u.customerRepo.WithTx(tx), id)
+		if err != nil {
+			return err
+		}
+		customerCars, err := u.customerCarRepo.WithTx(tx).GetByCustomerID(id)
+		if err != nil {
+			return err
+		}
+		carIDs := make([]string, len(customerCars))
+		for i, customerCar := range customerCars {
+			carIDs[i] = customerCar.CarID
+		}
+		cars := []model.Car{}
+		if len(carIDs) > 0 {
+			if cars, err = u.carRepo.WithTx(tx).GetCarsByIDs(uniqueStrings(carIDs)); err != nil {
+				return err
+			}
+		}
+		records, err := u.maintenanceRepo.WithTx(tx).GetCustomerRecords(id)
+		if err != nil {
+			return err
+		}
+		events, err := u.outboxRepo.WithTx(tx).GetCustomerEvents(id)
+		if err != nil {
+			return err
+		}
+
+		if customerCars == nil {
+			customerCars = []*model.CustomerCar{}
+		}
+		export = &model.CustomerDataExport{
+			ExportedAt:         time.Now().UTC(),
+			Customer:           customer,
+			CustomerCars:       customerCars,
+			Cars:               cars,
+			MaintenanceRecords: records,
+			Events:             events,
+		}
+		return u.appendEvent(tx, model.EventCustomerDataExported, id, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// EraseCustomer replaces the personal data of the customer with id by
+// pseudonyms, also in the payloads of their recorded events, and clears the
+// workshop and notes of the maintenance records of their cars. The customer
+// keeps its ID, links and timestamps, so references and statistics stay
+// intact. Returns the erased customer.
+func (u *privacyUsecase) EraseCustomer(id, actor string) (*model.Customer, error) {
+	var erased *model.Customer
+	err := u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.customerRepo.WithTx(tx)
+		customer, err := getCustomer(repo, id)
+		if err != nil {
+			return err
+		}
+		pseudonymize(customer)
+		customer.UpdatedBy = auditActor(actor)
+		if err := repo.Update(id, customer); err != nil {
+			return err
+		}
+		if err := u.maintenanceRepo.WithTx(tx).EraseCustomerRecords(id); err != nil {
+			return err
+		}
+
+		redacted, err := json.Marshal(map[string]string{
+			"name": customer.Name, "address": customer.Address, "phone": customer.Phone, "email": customer.Email,
+		})
+		if err != nil {
+			return err
+		}
+		types := []string{model.EventCustomerCreated, model.EventCustomerUpdated}
+		if err := u.outboxRepo.WithTx(tx).RedactEvents(aggregateCustomer, id, types, redacted); err != nil {
+			return err
+		}
+
+		if erased, err = repo.Get(id); err != nil {
+			return err
+		}
+		return u.appendEvent(tx, model.EventCustomerErased, id, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erased, nil
+}
+
+// appendEvent records a data subject request on the customer with id in tx
+func (u *privacyUsecase) appendEvent(tx *sqlx.Tx, eventType, id, actor string) error {
+	if actor == "" {
+		actor = "system"
+	}
+	event, err := newEvent(eventType, aggregateCustomer, id, model.CustomerPrivacyAction{ID: id, Actor: actor})
+	if err != nil {
+		return err
+	}
+	return u.outboxRepo.WithTx(tx).Append(event)
+}
+
+// getCustomer returns the customer with id, or repository.ErrNotFound
+func getCustomer(repo repository.CustomerRepository, id string) (*model.Customer, error) {
+	customer, err := repo.Get(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// pseudonymize replaces the personal fields of customer with values that only
+// derive from its ID
+func pseudonymize(customer *model.Customer) {
+	customer.Name = erasedName
+	customer.Address = ""
+	customer.Phone = ""
+	customer.Email = "erased-" + customer.ID + "@" + erasedEmailDomain
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	mock_repository "github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type privacyMocks struct {
+	customerRepo    *mock_repository.MockCustomerRepository
+	customerCarRepo *mock_repository.MockCustomerCarRepository
+	carRepo         *mock_repository.MockCarRepository
+	maintenanceRepo *mock_repository.MockMaintenanceRepository
+	outboxRepo      *mock_repository.MockOutboxRepository
+}
+
+// newTestPrivacyUsecase returns a PrivacyUsecase whose transactions run
+// directly against the returned mocks
+func newTestPrivacyUsecase(ctrl *gomock.Controller) (PrivacyUsecase, privacyMocks) {
+	m := privacyMocks{
+		customerRepo:    mock_repository.NewMockCustomerRepository(ctrl),
+		customerCarRepo: mock_repository.NewMockCustomerCarRepository(ctrl),
+		carRepo:         mock_repository.NewMockCarRepository(ctrl),
+		maintenanceRepo: mock_repository.NewMockMaintenanceRepository(ctrl),
+		outboxRepo:      mock_repository.NewMockOutboxRepository(ctrl),
+	}
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	m.customerRepo.EXPECT().WithTx(gomock.Any()).Return(m.customerRepo).AnyTimes()
+	m.customerCarRepo.EXPECT().WithTx(gomock.Any()).Return(m.customerCarRepo).AnyTimes()
+	m.carRepo.EXPECT().WithTx(gomock.Any()).Return(m.carRepo).AnyTimes()
+	m.maintenanceRepo.EXPECT().WithTx(gomock.Any()).Return(m.maintenanceRepo).AnyTimes()
+	m.outboxRepo.EXPECT().WithTx(gomock.Any()).Return(m.outboxRepo).AnyTimes()
+	return NewPrivacyUsecase(m.customerRepo, m.customerCarRepo, m.carRepo, m.maintenanceRepo, m.outboxRepo, transactor), m
+}
+
+// expectPrivacyEvent expects the event recording a data subject request on customer cust1 by actor
+func expectPrivacyEvent(t *testing.T, outboxRepo *mock_repository.MockOutboxRepository, eventType, actor string) {
+	outboxRepo.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		require.Len(t, events, 1)
+		assert.Equal(t, eventType, events[0].Type)
+		assert.Equal(t, "cust1", events[0].AggregateID)
+		var action model.CustomerPrivacyAction
+		require.NoError(t, json.Unmarshal(events[0].Data, &action))
+		assert.Equal(t, model.CustomerPrivacyAction{ID: "cust1", Actor: actor}, action)
+		return nil
+	})
+}
+
+func TestPrivacyUsecase_ExportCustomerData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	u, m := newTestPrivacyUsecase(ctrl)
+	customer := &model.Customer{ID: "cust1", Name: "John", Email: "john@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		m.customerRepo.EXPECT().Get("cust1").Return(customer, nil)
+		m.customerCarRepo.EXPECT().GetByCustomerID("cust1").Return([]*model.CustomerCar{
+			{ID: "cc1", CarID: "car1", CustomerID: "cust1"}, {ID: "cc2", CarID: "car1", CustomerID: "cust1"},
+		}, nil)
+		m.carRepo.EXPECT().GetCarsByIDs([]string{"car1"}).Return([]model.Car{{ID: "car1", Name: "Camry"}}, nil)
+		m.maintenanceRepo.EXPECT().GetCustomerRecords("cust1").Return([]model.MaintenanceRecord{
+			{ID: "mr1", CustomerCarID: "cc1", Workshop: "Downtown Service Center", Notes: "Called John about the brakes"},
+		}, nil)
+		m.outboxRepo.EXPECT().GetCustomerEvents("cust1").Return([]model.Event{{ID: "e1", Type: model.EventCustomerCreated}}, nil)
+		expectPrivacyEvent(t, m.outboxRepo, model.EventCustomerDataExported, "admin")
+
+		export, err := u.ExportCustomerData("cust1", "admin")
+		require.NoError(t, err)
+		assert.Equal(t, customer, export.Customer)
+		assert.Len(t, export.CustomerCars, 2)
+		assert.Equal(t, "Camry", export.Cars[0].Name)
+		assert.Equal(t, "Called John about the brakes", export.MaintenanceRecords[0].Notes)
+		assert.Equal(t, "e1", export.Events[0].ID)
+		assert.False(t, export.ExportedAt.IsZero())
+	})
+
+	t.Run("NoCars", func(t *testing.T) {
+		m.customerRepo.EXPECT().Get("cust1").Return(customer, nil)
+		m.customerCarRepo.EXPECT().GetByCustomerID("cust1").Return(nil, nil)
+		m.maintenanceRepo.EXPECT().GetCustomerRecords("cust1").Return([]model.MaintenanceRecord{}, nil)
+		m.outboxRepo.EXPECT().GetCustomerEvents("cust1").Return([]model.Event{}, nil)
+		expectPrivacyEvent(t, m.outboxRepo, model.EventCustomerDataExported, "system")
+
+		export, err := u.ExportCustomerData("cust1", "")
+		require.NoError(t, err)
+		assert.Empty(t, export.CustomerCars)
+		assert.NotNil(t, export.CustomerCars)
+		assert.Empty(t, export.Cars)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		m.customerRepo.EXPECT().Get("missing").Return(&model.Customer{}, sql.ErrNoRows)
+
+		_, err := u.ExportCustomerData("missing", "admin")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestPrivacyUsecase_EraseCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	u, m := newTestPrivacyUsecase(ctrl)
+
+	t.Run("Success", func(t *testing.T) {
+		customer := &model.Customer{ID: "cust1", Name: "John", Address: "1 Main St", Phone: "555-123-4567",
+			Email: "john@example.com", CreatedBy: "admin", UpdatedBy: "editor"}
+		m.customerRepo.EXPECT().Get("cust1").Return(customer, nil)
+		m.customerRepo.EXPECT().Update("cust1", gomock.Any()).DoAndReturn(func(id string, c *model.Customer) error {
+			assert.Equal(t, "Erased customer", c.Name)
+			assert.Empty(t, c.Address)
+			assert.Empty(t, c.Phone)
+			assert.Equal(t, "erased-cust1@erased.invalid", c.Email)
+			assert.Equal(t, "admin", c.CreatedBy)
+			// The erasure is credited to the actor
+			assert.Equal(t, "api_key:k1", c.UpdatedBy)
+			return nil
+		})
+		m.maintenanceRepo.EXPECT().EraseCustomerRecords("cust1").Return(nil)
+		// The personal data is also removed from the recorded events
+		m.outboxRepo.EXPECT().RedactEvents("customer", "cust1", []string{model.EventCustomerCreated, model.EventCustomerUpdated}, gomock.Any()).
+			DoAndReturn(func(_, _ string, _ []string, redacted json.RawMessage) error {
+				assert.JSONEq(t, `{"name":"Erased customer","address":"","phone":"","email":"erased-cust1@erased.invalid"}`, string(redacted))
+				return nil
+			})
+		m.customerRepo.EXPECT().Get("cust1").Return(&model.Customer{ID: "cust1", Name: "Erased customer"}, nil)
+		expectPrivacyEvent(t, m.outboxRepo, model.EventCustomerErased, "api_key:k1")
+
+		erased, err := u.EraseCustomer("cust1", "api_key:k1")
+		require.NoError(t, err)
+		assert.Equal(t, "Erased customer", erased.Name)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		m.customerRepo.EXPECT().Get("missing").Return(&model.Customer{}, sql.ErrNoRows)
+
+		_, err := u.EraseCustomer("missing", "admin")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
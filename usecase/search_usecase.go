@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+)
+
+// ErrEmptySearch is returned for search queries without any letter or digit
+var ErrEmptySearch = errors.New("search query must contain a letter or digit")
+
+// defaultSearchLimit is the number of results returned when no limit is given
+const defaultSearchLimit = 20
+
+// SearchUsecase defines the interface for searching across resources
+type SearchUsecase interface {
+	Search(query model.SearchQuery) ([]model.SearchResult, error)
+	WithTenant(tenantID string) SearchUsecase
+}
+
+type searchUsecase struct {
+	searchRepo repository.SearchRepository
+}
+
+// NewSearchUsecase creates a new instance of SearchUsecase
+func NewSearchUsecase(searchRepo repository.SearchRepository) SearchUsecase {
+	return &searchUsecase{searchRepo: searchRepo}
+}
+
+// WithTenant returns a copy of the usecase that only searches the records of tenantID
+func (u *searchUsecase) WithTenant(tenantID string) SearchUsecase {
+	return &searchUsecase{searchRepo: u.searchRepo.WithTenant(tenantID)}
+}
+
+// Search returns the customers, suppliers and cars best matching the query
+func (u *searchUsecase) Search(query model.SearchQuery) ([]model.SearchResult, error) {
+	if len(model.SearchTerms(query.Q)) == 0 {
+		return nil, ErrEmptySearch
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+	return u.searchRepo.Search(query.Q, query.Limit)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchUsecase_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockSearchRepository(ctrl)
+	uc := NewSearchUsecase(mockRepo)
+
+	t.Run("DefaultLimit", func(t *testing.T) {
+		want := []model.SearchResult{{Type: "car", ID: "car1", Name: "Camry"}}
+		mockRepo.EXPECT().Search("camry", 20).Return(want, nil)
+
+		results, err := uc.Search(model.SearchQuery{Q: "camry"})
+		require.NoError(t, err)
+		assert.Equal(t, want, results)
+	})
+
+	t.Run("GivenLimit", func(t *testing.T) {
+		mockRepo.EXPECT().Search("an", 5).Return([]model.SearchResult{}, nil)
+		_, err := uc.Search(model.SearchQuery{Q: "an", Limit: 5})
+		assert.NoError(t, err)
+	})
+
+	t.Run("NoTerms", func(t *testing.T) {
+		_, err := uc.Search(model.SearchQuery{Q: "%%"})
+		assert.ErrorIs(t, err, ErrEmptySearch)
+	})
+}
@@ -3,42 +3,116 @@ package usecase
 import (
 	"github.com/GoodsChain/backend/repository"
 	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
 )
 
 type SupplierUsecase interface {
 	CreateSupplier(supplier *model.Supplier) error
 	GetSupplier(id string) (*model.Supplier, error)
+	GetSuppliersByIDs(ids []string) ([]*model.Supplier, error)
 	UpdateSupplier(id string, supplier *model.Supplier) error
 	DeleteSupplier(id string) error
-	GetAllSuppliers() ([]*model.Supplier, error)
+	GetAllSuppliers(filter model.SupplierFilter) ([]*model.Supplier, error)
+	StreamSuppliers(filter model.SupplierFilter, fn func(supplier *model.Supplier) error) error
+	WithTenant(tenantID string) SupplierUsecase
 }
 
 type supplierUsecase struct {
 	supplierRepo repository.SupplierRepository
+	transactor   repository.Transactor
+	outboxRepo   repository.OutboxRepository
 }
 
-func NewSupplierUsecase(supplierRepo repository.SupplierRepository) SupplierUsecase {
+// NewSupplierUsecase creates a new instance of SupplierUsecase. Changes record
+// supplier.created, supplier.updated and supplier.deleted events in outboxRepo in
+// the transaction of the change.
+func NewSupplierUsecase(supplierRepo repository.SupplierRepository, transactor repository.Transactor,
+	outboxRepo repository.OutboxRepository) SupplierUsecase {
 	return &supplierUsecase{
 		supplierRepo: supplierRepo,
+		transactor:   transactor,
+		outboxRepo:   outboxRepo,
+	}
+}
+
+// WithTenant returns a copy of the usecase that only manages the suppliers of tenantID
+func (u *supplierUsecase) WithTenant(tenantID string) SupplierUsecase {
+	return &supplierUsecase{
+		supplierRepo: u.supplierRepo.WithTenant(tenantID),
+		transactor:   u.transactor,
+		outboxRepo:   u.outboxRepo.WithTenant(tenantID),
 	}
 }
 
 func (u *supplierUsecase) CreateSupplier(supplier *model.Supplier) error {
-	return u.supplierRepo.Create(supplier)
+	supplier.CreatedBy = auditActor(supplier.CreatedBy)
+	supplier.UpdatedBy = auditActor(supplier.UpdatedBy)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.supplierRepo.WithTx(tx)
+		if err := repo.Create(supplier); err != nil {
+			return err
+		}
+		// Read the row back, the database sets the audit fields
+		created, err := repo.Get(supplier.ID)
+		if err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionCreated, created.ID, created)
+	})
 }
 
 func (u *supplierUsecase) GetSupplier(id string) (*model.Supplier, error) {
 	return u.supplierRepo.Get(id)
 }
 
+// GetSuppliersByIDs returns the suppliers with the given IDs; missing IDs are left out
+func (u *supplierUsecase) GetSuppliersByIDs(ids []string) ([]*model.Supplier, error) {
+	return u.supplierRepo.GetByIDs(ids)
+}
+
+// UpdateSupplier fails without updating anything when the supplier does not exist
 func (u *supplierUsecase) UpdateSupplier(id string, supplier *model.Supplier) error {
-	return u.supplierRepo.Update(id, supplier)
+	supplier.UpdatedBy = auditActor(supplier.UpdatedBy)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.supplierRepo.WithTx(tx)
+		if err := repo.Update(id, supplier); err != nil {
+			return err
+		}
+		updated, err := repo.Get(id)
+		if err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionUpdated, id, updated)
+	})
 }
 
+// DeleteSupplier fails when the supplier does not exist
 func (u *supplierUsecase) DeleteSupplier(id string) error {
-	return u.supplierRepo.Delete(id)
+	return u.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+		repo := u.supplierRepo.WithTx(tx)
+		if _, err := repo.Get(id); err != nil {
+			return err
+		}
+		if err := repo.Delete(id); err != nil {
+			return err
+		}
+		return u.appendEvent(tx, actionDeleted, id, nil)
+	})
 }
 
-func (u *supplierUsecase) GetAllSuppliers() ([]*model.Supplier, error) {
-	return u.supplierRepo.GetAll()
+func (u *supplierUsecase) GetAllSuppliers(filter model.SupplierFilter) ([]*model.Supplier, error) {
+	return u.supplierRepo.GetAll(filter)
+}
+
+func (u *supplierUsecase) StreamSuppliers(filter model.SupplierFilter, fn func(supplier *model.Supplier) error) error {
+	return u.supplierRepo.Stream(filter, fn)
+}
+
+// appendEvent records a supplier change event in tx
+func (u *supplierUsecase) appendEvent(tx *sqlx.Tx, action, id string, supplier *model.Supplier) error {
+	event, err := entityEvent(aggregateSupplier, action, id, supplier)
+	if err != nil {
+		return err
+	}
+	return u.outboxRepo.WithTx(tx).Append(event)
 }
@@ -6,16 +6,40 @@ import (
 
 	"github.com/GoodsChain/backend/model"
 	mock_repository "github.com/GoodsChain/backend/mock"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/mock/gomock"
 )
 
+// newTestSupplierUsecase returns a SupplierUsecase whose transactions run
+// directly against the returned mocks
+func newTestSupplierUsecase(ctrl *gomock.Controller) (SupplierUsecase, *mock_repository.MockSupplierRepository, *mock_repository.MockOutboxRepository) {
+	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
+	mockOutbox := mock_repository.NewMockOutboxRepository(ctrl)
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	mockRepo.EXPECT().WithTx(gomock.Any()).Return(mockRepo).AnyTimes()
+	mockOutbox.EXPECT().WithTx(gomock.Any()).Return(mockOutbox).AnyTimes()
+	return NewSupplierUsecase(mockRepo, transactor, mockOutbox), mockRepo, mockOutbox
+}
+
+// expectSupplierEvent expects a single event of eventType for supplier 1
+func expectSupplierEvent(t *testing.T, mockOutbox *mock_repository.MockOutboxRepository, eventType string) {
+	mockOutbox.EXPECT().Append(gomock.Any()).DoAndReturn(func(events ...*model.Event) error {
+		if len(events) != 1 || events[0].Type != eventType || events[0].AggregateType != "supplier" || events[0].AggregateID != "1" {
+			t.Errorf("Expected one %s event for supplier 1, got %v", eventType, events)
+		}
+		return nil
+	})
+}
+
 func TestCreateSupplier(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
-	usecase := NewSupplierUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestSupplierUsecase(ctrl)
 	
 	supplier := &model.Supplier{
 		ID:   "1",
@@ -25,6 +49,8 @@ func TestCreateSupplier(t *testing.T) {
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().Create(supplier).Return(nil)
+		mockRepo.EXPECT().Get("1").Return(supplier, nil)
+		expectSupplierEvent(t, mockOutbox, model.EventSupplierCreated)
 		
 		err := usecase.CreateSupplier(supplier)
 		if err != nil {
@@ -48,8 +74,7 @@ func TestGetSupplier(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
-	usecase := NewSupplierUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestSupplierUsecase(ctrl)
 	
 	supplier := &model.Supplier{
 		ID:   "1",
@@ -83,13 +108,31 @@ func TestGetSupplier(t *testing.T) {
 	})
 }
 
+func TestGetSuppliersByIDs(t *testing.T) {
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	
+	usecase, mockRepo, _ := newTestSupplierUsecase(ctrl)
+	
+	suppliers := []*model.Supplier{{ID: "1"}}
+	mockRepo.EXPECT().GetByIDs([]string{"1", "2"}).Return(suppliers, nil)
+	
+	result, err := usecase.GetSuppliersByIDs([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("Expected 1 supplier, got %d", len(result))
+	}
+}
+
 func TestUpdateSupplier(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
-	usecase := NewSupplierUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestSupplierUsecase(ctrl)
 	
 	supplier := &model.Supplier{
 		ID:   "1",
@@ -99,6 +142,8 @@ func TestUpdateSupplier(t *testing.T) {
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().Update("1", supplier).Return(nil)
+		mockRepo.EXPECT().Get("1").Return(supplier, nil)
+		expectSupplierEvent(t, mockOutbox, model.EventSupplierUpdated)
 		
 		err := usecase.UpdateSupplier("1", supplier)
 		if err != nil {
@@ -122,12 +167,13 @@ func TestDeleteSupplier(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
-	usecase := NewSupplierUsecase(mockRepo)
+	usecase, mockRepo, mockOutbox := newTestSupplierUsecase(ctrl)
 	
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().Get("1").Return(&model.Supplier{ID: "1"}, nil)
 		mockRepo.EXPECT().Delete("1").Return(nil)
+		expectSupplierEvent(t, mockOutbox, model.EventSupplierDeleted)
 		
 		err := usecase.DeleteSupplier("1")
 		if err != nil {
@@ -137,6 +183,7 @@ func TestDeleteSupplier(t *testing.T) {
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("delete error")
+		mockRepo.EXPECT().Get("1").Return(&model.Supplier{ID: "1"}, nil)
 		mockRepo.EXPECT().Delete("1").Return(expectedErr)
 		
 		err := usecase.DeleteSupplier("1")
@@ -144,6 +191,16 @@ func TestDeleteSupplier(t *testing.T) {
 			t.Errorf("Expected %v, got %v", expectedErr, err)
 		}
 	})
+	
+	t.Run("Not Found", func(t *testing.T) {
+		expectedErr := errors.New("not found")
+		mockRepo.EXPECT().Get("1").Return(nil, expectedErr)
+		
+		err := usecase.DeleteSupplier("1")
+		if err != expectedErr {
+			t.Errorf("Expected %v, got %v", expectedErr, err)
+		}
+	})
 }
 
 func TestGetAllSuppliers(t *testing.T) {
@@ -151,8 +208,7 @@ func TestGetAllSuppliers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	
-	mockRepo := mock_repository.NewMockSupplierRepository(ctrl)
-	usecase := NewSupplierUsecase(mockRepo)
+	usecase, mockRepo, _ := newTestSupplierUsecase(ctrl)
 	
 	suppliers := []*model.Supplier{
 		{ID: "1", Name: "Supplier 1"},
@@ -161,9 +217,9 @@ func TestGetAllSuppliers(t *testing.T) {
 	
 	// Test cases
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.EXPECT().GetAll().Return(suppliers, nil)
+		mockRepo.EXPECT().GetAll(model.SupplierFilter{}).Return(suppliers, nil)
 		
-		result, err := usecase.GetAllSuppliers()
+		result, err := usecase.GetAllSuppliers(model.SupplierFilter{})
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -174,9 +230,9 @@ func TestGetAllSuppliers(t *testing.T) {
 	
 	t.Run("Repository Error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mockRepo.EXPECT().GetAll().Return(nil, expectedErr)
+		mockRepo.EXPECT().GetAll(model.SupplierFilter{}).Return(nil, expectedErr)
 		
-		result, err := usecase.GetAllSuppliers()
+		result, err := usecase.GetAllSuppliers(model.SupplierFilter{})
 		if err != expectedErr {
 			t.Errorf("Expected %v, got %v", expectedErr, err)
 		}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/GoodsChain/backend/auth"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/google/uuid"
+)
+
+// ErrUsernameTaken is returned when creating a user whose username already exists
+var ErrUsernameTaken = errors.New("username is already taken")
+
+// UserUsecase defines the interface for user account business logic
+type UserUsecase interface {
+	CreateUser(user *model.User, password string) error
+	GetUser(id string) (*model.User, error)
+}
+
+type userUsecase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUserUsecase creates a new instance of UserUsecase
+func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+	return &userUsecase{userRepo: userRepo}
+}
+
+// CreateUser hashes the password and stores a new user account
+func (u *userUsecase) CreateUser(user *model.User, password string) error {
+	user.Username = strings.TrimSpace(user.Username)
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	if user.Role == "" {
+		user.Role = model.RoleViewer
+	}
+	if user.TenantID == "" {
+		user.TenantID = model.DefaultTenantID
+	}
+	if user.CreatedBy == "" {
+		user.CreatedBy = "system"
+	}
+	if user.UpdatedBy == "" {
+		user.UpdatedBy = user.CreatedBy
+	}
+
+	if _, err := u.userRepo.GetByUsername(user.Username); err == nil {
+		return ErrUsernameTaken
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+
+	return u.userRepo.Create(user)
+}
+
+// GetUser retrieves a user account by its ID
+func (u *userUsecase) GetUser(id string) (*model.User, error) {
+	return u.userRepo.GetByID(id)
+}
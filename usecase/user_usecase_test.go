@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoodsChain/backend/auth"
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserUsecase_CreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockUserRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		user := &model.User{Username: " jdoe ", Email: "jdoe@example.com"}
+		mockUserRepo.EXPECT().GetByUsername("jdoe").Return(nil, repository.ErrNotFound).Times(1)
+		mockUserRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(u *model.User) error {
+			assert.NotEmpty(t, u.ID)
+			assert.Equal(t, "jdoe", u.Username)
+			assert.Equal(t, model.RoleViewer, u.Role) // Default role
+			assert.Equal(t, "system", u.CreatedBy)
+			ok, err := auth.VerifyPassword(u.PasswordHash, "s3cret-password")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			return nil
+		}).Times(1)
+
+		err := uc.CreateUser(user, "s3cret-password")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UsernameTaken", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByUsername("jdoe").Return(&model.User{Username: "jdoe"}, nil).Times(1)
+
+		err := uc.CreateUser(&model.User{Username: "jdoe"}, "s3cret-password")
+		assert.ErrorIs(t, err, ErrUsernameTaken)
+	})
+
+	t.Run("PasswordTooShort", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByUsername("jdoe").Return(nil, repository.ErrNotFound).Times(1)
+
+		err := uc.CreateUser(&model.User{Username: "jdoe"}, "short")
+		assert.ErrorIs(t, err, auth.ErrPasswordTooShort)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByUsername("jdoe").Return(nil, errors.New("db down")).Times(1)
+
+		err := uc.CreateUser(&model.User{Username: "jdoe"}, "s3cret-password")
+		assert.EqualError(t, err, "db down")
+	})
+}
+
+func TestUserUsecase_GetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockUserRepo)
+
+	expected := &model.User{ID: "user-1", Username: "jdoe"}
+	mockUserRepo.EXPECT().GetByID("user-1").Return(expected, nil).Times(1)
+
+	user, err := uc.GetUser("user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, user)
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/webhook"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhookURL is returned for endpoints that are not absolute http(s)
+// URLs of a public host
+var ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https URL of a public host")
+
+// defaultDeliveryLimit is the number of log entries returned when no limit is given
+const defaultDeliveryLimit = 50
+
+// WebhookUsecase defines the interface for webhook subscription business logic
+type WebhookUsecase interface {
+	CreateWebhook(req *model.WebhookRequest) (*model.WebhookSubscription, error)
+	GetWebhook(id string) (*model.WebhookSubscription, error)
+	GetAllWebhooks() ([]model.WebhookSubscription, error)
+	UpdateWebhook(id string, req *model.WebhookRequest) (*model.WebhookSubscription, error)
+	DeleteWebhook(id string) error
+	GetDeliveries(id string, filter model.DeliveryFilter) ([]model.WebhookDelivery, error)
+	Redeliver(id, deliveryID string) (*model.WebhookDelivery, error)
+	WithTenant(tenantID string) WebhookUsecase
+}
+
+type webhookUsecase struct {
+	webhookRepo  repository.WebhookRepository
+	allowPrivate bool
+}
+
+// NewWebhookUsecase creates a new instance of WebhookUsecase. Endpoints on
+// localhost and private addresses are refused unless allowPrivate is set.
+func NewWebhookUsecase(webhookRepo repository.WebhookRepository, allowPrivate bool) WebhookUsecase {
+	return &webhookUsecase{webhookRepo: webhookRepo, allowPrivate: allowPrivate}
+}
+
+// WithTenant returns a copy of the usecase that only manages the
+// subscriptions of tenantID, which receive the events of tenantID
+func (u *webhookUsecase) WithTenant(tenantID string) WebhookUsecase {
+	return &webhookUsecase{webhookRepo: u.webhookRepo.WithTenant(tenantID), allowPrivate: u.allowPrivate}
+}
+
+// CreateWebhook creates a subscription, generating a secret if none is given.
+// The returned subscription is the only one that includes the secret.
+func (u *webhookUsecase) CreateWebhook(req *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	if err := u.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = webhook.NewSecret(); err != nil {
+			return nil, err
+		}
+	}
+	actor := req.Actor
+	if actor == "" {
+		actor = "system"
+	}
+
+	sub := &model.WebhookSubscription{
+		ID:          uuid.New().String(),
+		URL:         req.URL,
+		EventTypes:  uniqueStrings(req.EventTypes),
+		Secret:      secret,
+		Description: req.Description,
+		Active:      req.Active == nil || *req.Active,
+		CreatedBy:   actor,
+		UpdatedBy:   actor,
+	}
+	if err := u.webhookRepo.Create(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// GetWebhook retrieves a subscription by its ID
+func (u *webhookUsecase) GetWebhook(id string) (*model.WebhookSubscription, error) {
+	return u.webhookRepo.GetByID(id)
+}
+
+// GetAllWebhooks retrieves all subscriptions
+func (u *webhookUsecase) GetAllWebhooks() ([]model.WebhookSubscription, error) {
+	return u.webhookRepo.GetAll()
+}
+
+// UpdateWebhook replaces a subscription's settings. The secret is rotated
+// only when a new one is given, and active is kept when omitted.
+func (u *webhookUsecase) UpdateWebhook(id string, req *model.WebhookRequest) (*model.WebhookSubscription, error) {
+	if err := u.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	current, err := u.webhookRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	current.URL = req.URL
+	current.EventTypes = uniqueStrings(req.EventTypes)
+	current.Secret = req.Secret
+	current.Description = req.Description
+	if req.Active != nil {
+		current.Active = *req.Active
+	}
+	current.UpdatedBy = req.Actor
+	if current.UpdatedBy == "" {
+		current.UpdatedBy = "system"
+	}
+	if err := u.webhookRepo.Update(id, current); err != nil {
+		return nil, err
+	}
+	current.Secret = ""
+	return current, nil
+}
+
+// DeleteWebhook removes a subscription and its delivery log
+func (u *webhookUsecase) DeleteWebhook(id string) error {
+	return u.webhookRepo.Delete(id)
+}
+
+// GetDeliveries returns the most recent deliveries of a subscription
+func (u *webhookUsecase) GetDeliveries(id string, filter model.DeliveryFilter) ([]model.WebhookDelivery, error) {
+	if _, err := u.webhookRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	return u.webhookRepo.GetDeliveries(id, filter.Status, filter.Limit)
+}
+
+// Redeliver queues the payload of a logged delivery to be sent again,
+// whatever the outcome of the original
+func (u *webhookUsecase) Redeliver(id, deliveryID string) (*model.WebhookDelivery, error) {
+	return u.webhookRepo.Redeliver(id, deliveryID)
+}
+
+// checkURL rejects endpoints that are not http(s) URLs, and those on
+// localhost or a private IP address unless they are allowed. Names that
+// resolve to private addresses are refused when deliveries are dialed.
+func (u *webhookUsecase) checkURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	if !u.allowPrivate && !webhook.PublicHost(parsed.Hostname()) {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
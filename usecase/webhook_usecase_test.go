@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	uc := NewWebhookUsecase(mockRepo, false)
+
+	t.Run("GeneratesSecret", func(t *testing.T) {
+		mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(sub *model.WebhookSubscription) error {
+			assert.NotEmpty(t, sub.ID)
+			assert.True(t, strings.HasPrefix(sub.Secret, "whsec_"))
+			assert.True(t, sub.Active)
+			assert.Equal(t, "system", sub.CreatedBy)
+			// Duplicate event types are dropped
+			assert.Equal(t, []string{model.EventCarPriceChanged}, []string(sub.EventTypes))
+			return nil
+		})
+
+		sub, err := uc.CreateWebhook(&model.WebhookRequest{URL: "https://example.com/hook",
+			EventTypes: []string{model.EventCarPriceChanged, model.EventCarPriceChanged}})
+		require.NoError(t, err)
+		assert.NotEmpty(t, sub.Secret)
+	})
+
+	t.Run("KeepsGivenSecretAndActive", func(t *testing.T) {
+		inactive := false
+		mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(sub *model.WebhookSubscription) error {
+			assert.Equal(t, "a-long-shared-secret", sub.Secret)
+			assert.False(t, sub.Active)
+			assert.Equal(t, "alice", sub.CreatedBy)
+			return nil
+		})
+
+		_, err := uc.CreateWebhook(&model.WebhookRequest{URL: "http://hooks.example.com:8080/hook", EventTypes: []string{model.EventCustomerCarCreated},
+			Secret: "a-long-shared-secret", Active: &inactive, Actor: "alice"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("RejectsNonHTTPURL", func(t *testing.T) {
+		_, err := uc.CreateWebhook(&model.WebhookRequest{URL: "ftp://example.com/hook", EventTypes: []string{model.EventCarPriceChanged}})
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	})
+
+	t.Run("RejectsInternalHosts", func(t *testing.T) {
+		for _, url := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.5/hook", "http://[::1]:9000/hook", "http://[::ffff:192.168.1.1]/hook", "http://api.localhost/hook"} {
+			_, err := uc.CreateWebhook(&model.WebhookRequest{URL: url, EventTypes: []string{model.EventCarPriceChanged}})
+			assert.ErrorIs(t, err, ErrInvalidWebhookURL, url)
+		}
+	})
+
+	t.Run("AllowsInternalHostsWhenEnabled", func(t *testing.T) {
+		mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+		_, err := NewWebhookUsecase(mockRepo, true).CreateWebhook(&model.WebhookRequest{URL: "http://localhost:8080/hook",
+			EventTypes: []string{model.EventCarPriceChanged}})
+		assert.NoError(t, err)
+	})
+}
+
+func TestWebhookUsecase_UpdateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	uc := NewWebhookUsecase(mockRepo, false)
+
+	current := &model.WebhookSubscription{ID: "sub1", URL: "https://example.com/old", Active: false, CreatedBy: "admin"}
+	mockRepo.EXPECT().GetByID("sub1").Return(current, nil)
+	mockRepo.EXPECT().Update("sub1", gomock.Any()).DoAndReturn(func(id string, sub *model.WebhookSubscription) error {
+		assert.Equal(t, "https://example.com/new", sub.URL)
+		assert.False(t, sub.Active) // Omitted active keeps the current value
+		assert.Equal(t, "new-secret-value-123", sub.Secret)
+		return nil
+	})
+
+	sub, err := uc.UpdateWebhook("sub1", &model.WebhookRequest{URL: "https://example.com/new",
+		EventTypes: []string{model.EventCarPriceChanged}, Secret: "new-secret-value-123"})
+	require.NoError(t, err)
+	assert.Empty(t, sub.Secret) // Secrets are only returned on create
+
+	mockRepo.EXPECT().GetByID("missing").Return(nil, repository.ErrNotFound)
+	_, err = uc.UpdateWebhook("missing", &model.WebhookRequest{URL: "https://example.com/new"})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestWebhookUsecase_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	uc := NewWebhookUsecase(mockRepo, false)
+
+	mockRepo.EXPECT().GetByID("sub1").Return(&model.WebhookSubscription{ID: "sub1"}, nil)
+	mockRepo.EXPECT().GetDeliveries("sub1", model.DeliveryStatusDead, 50).Return([]model.WebhookDelivery{{ID: "d1"}}, nil)
+	deliveries, err := uc.GetDeliveries("sub1", model.DeliveryFilter{Status: model.DeliveryStatusDead})
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+
+	mockRepo.EXPECT().GetByID("missing").Return(nil, repository.ErrNotFound)
+	_, err = uc.GetDeliveries("missing", model.DeliveryFilter{Limit: 10})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
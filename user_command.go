@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoodsChain/backend/config"
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/GoodsChain/backend/usecase"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rs/zerolog/log"
+)
+
+const userUsage = `Usage: goodschain user <command> [arguments]
+
+Commands:
+  create --username NAME --email EMAIL [--role admin|editor|viewer] [--password PASSWORD]
+         [--tenant ID]
+         Create a user account. The password is read from stdin when omitted.
+`
+
+// runUser executes the `user` subcommand
+func runUser(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	username := fs.String("username", "", "login name")
+	email := fs.String("email", "", "email address")
+	role := fs.String("role", model.RoleViewer, "role: admin, editor or viewer")
+	password := fs.String("password", "", "password (read from stdin when omitted)")
+	tenantID := fs.String("tenant", model.DefaultTenantID, "ID of the tenant of the user")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	if *password == "" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			log.Error().Err(err).Msg("Failed to read password from stdin")
+			return 1
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	user := &model.User{Username: *username, Email: *email, Role: *role, TenantID: *tenantID, CreatedBy: "cli"}
+	if err := binding.Validator.ValidateStruct(user); err != nil {
+		log.Error().Err(err).Msg("Invalid user")
+		return 2
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open database")
+		return 1
+	}
+	defer db.Close()
+
+	userUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db))
+	if err := userUsecase.CreateUser(user, *password); err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("Failed to create user")
+		return 1
+	}
+
+	log.Info().Str("id", user.ID).Str("username", user.Username).Str("role", user.Role).Str("tenant_id", user.TenantID).Msg("User created")
+	return 0
+}
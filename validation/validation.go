@@ -0,0 +1,125 @@
+// Package validation converts go-playground validator errors, as produced by
+// Gin's `binding` struct tags, into field-level errors keyed by JSON name.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/GoodsChain/backend/i18n"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single failed validation rule
+type FieldError struct {
+	Field   string `json:"field" example:"email" description:"JSON name of the invalid field"`
+	Rule    string `json:"rule" example:"email" description:"Validation rule that failed"`
+	Message string `json:"message" example:"email must be a valid email address" description:"Human-readable description of the failure"`
+	Key     string `json:"key,omitempty" example:"validation.email" description:"Message key, the same in every language"`
+	// Values of the placeholders of the message
+	Params map[string]interface{} `json:"params,omitempty" swaggertype:"object" description:"Values of the placeholders of the message, such as field and param"`
+}
+
+// newFieldError returns the field error of a failed rule described by msg
+func newFieldError(field, rule string, msg i18n.Message) FieldError {
+	return FieldError{Field: field, Rule: rule, Message: msg.String(), Key: msg.Key, Params: msg.Params}
+}
+
+// Localize returns the field errors with their messages in lang
+func Localize(fieldErrors []FieldError, lang string) []FieldError {
+	localized := make([]FieldError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		if fe.Key != "" {
+			fe.Message = i18n.Message{Key: fe.Key, Params: fe.Params}.Localize(lang)
+		}
+		localized[i] = fe
+	}
+	return localized
+}
+
+// Struct validates obj with the same binding rules Gin applies to request
+// bodies and returns the failures as field errors
+func Struct(obj interface{}) []FieldError {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+	return FieldErrors(err, obj)
+}
+
+// FieldErrors converts a validation error for obj into field errors. A JSON
+// value of the wrong type is reported with the "type" rule; other errors that
+// are not validator.ValidationErrors are returned as a single entry without
+// a field.
+func FieldErrors(err error, obj interface{}) []FieldError {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		msg := i18n.M("validation.type", "field", typeError.Field, "type", typeError.Type.String())
+		return []FieldError{newFieldError(typeError.Field, "type", msg)}
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		field := JSONFieldName(obj, fe.StructField())
+		fieldErrors = append(fieldErrors, newFieldError(field, fe.Tag(), message(field, fe)))
+	}
+	return fieldErrors
+}
+
+// JSONFieldName returns the JSON name of a struct field, or its query
+// parameter name for fields bound from a form, falling back to the Go field
+// name when the field has neither tag
+func JSONFieldName(obj interface{}, structField string) string {
+	t := reflect.TypeOf(obj)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return structField
+	}
+	f, ok := t.FieldByName(structField)
+	if !ok {
+		return structField
+	}
+	for _, tag := range []string{"json", "form"} {
+		if name := strings.Split(f.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return structField
+}
+
+// message describes a failed rule
+func message(field string, fe validator.FieldError) i18n.Message {
+	switch fe.Tag() {
+	case "required", "email":
+		return i18n.M("validation."+fe.Tag(), "field", field)
+	case "gt", "gte", "lt", "lte", "oneof":
+		return i18n.M("validation."+fe.Tag(), "field", field, "param", fe.Param())
+	case "min", "max":
+		return i18n.M("validation."+fe.Tag()+unit(fe), "field", field, "param", fe.Param())
+	default:
+		return i18n.M("validation.failed", "field", field, "rule", fe.Tag())
+	}
+}
+
+// unit is the suffix of the message key of a min or max rule, naming what
+// the bound counts: characters of a string, items of a collection, or
+// nothing for a number
+func unit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return ".string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return ".items"
+	default:
+		return ""
+	}
+}
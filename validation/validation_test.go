@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sample struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email_address" binding:"required,email"`
+	Age   int    `binding:"gte=18"`
+}
+
+func TestStruct(t *testing.T) {
+	assert.Nil(t, Struct(&sample{Name: "a", Email: "a@example.com", Age: 18}))
+
+	errs := Struct(&sample{Email: "nope", Age: 3})
+	assert.Equal(t, []FieldError{
+		{Field: "name", Rule: "required", Message: "name is required",
+			Key: "validation.required", Params: map[string]interface{}{"field": "name"}},
+		{Field: "email_address", Rule: "email", Message: "email_address must be a valid email address",
+			Key: "validation.email", Params: map[string]interface{}{"field": "email_address"}},
+		{Field: "Age", Rule: "gte", Message: "Age must be at least 18",
+			Key: "validation.gte", Params: map[string]interface{}{"field": "Age", "param": "18"}},
+	}, errs)
+}
+
+func TestFieldErrors_NonValidationError(t *testing.T) {
+	errs := FieldErrors(errors.New("unexpected EOF"), &sample{})
+	assert.Equal(t, []FieldError{{Message: "unexpected EOF"}}, errs)
+}
+
+func TestFieldErrors_TypeError(t *testing.T) {
+	var s sample
+	err := json.Unmarshal([]byte(`{"name": 5}`), &s)
+	assert.Equal(t, []FieldError{{Field: "name", Rule: "type", Message: "name must be of type string",
+		Key: "validation.type", Params: map[string]interface{}{"field": "name", "type": "string"}}}, FieldErrors(err, &s))
+}
+
+func TestFieldErrors_Query(t *testing.T) {
+	type query struct {
+		Limit int      `form:"limit" binding:"min=1"`
+		Tags  []string `form:"tags" binding:"max=2"`
+	}
+	errs := Struct(&query{Tags: []string{"a", "b", "c"}})
+	assert.Equal(t, []FieldError{
+		{Field: "limit", Rule: "min", Message: "limit must be at least 1",
+			Key: "validation.min", Params: map[string]interface{}{"field": "limit", "param": "1"}},
+		{Field: "tags", Rule: "max", Message: "tags must be at most 2 items",
+			Key: "validation.max.items", Params: map[string]interface{}{"field": "tags", "param": "2"}},
+	}, errs)
+}
+
+func TestLocalize(t *testing.T) {
+	errs := Struct(&sample{Name: "a", Email: "a@example.com", Age: 3})
+	localized := Localize(errs, "vi")
+	assert.Equal(t, "Age phải lớn hơn hoặc bằng 18", localized[0].Message)
+	assert.Equal(t, "validation.gte", localized[0].Key)
+	assert.Equal(t, "Age must be at least 18", errs[0].Message, "the original errors are left untouched")
+
+	// Errors without a key keep their message
+	plain := []FieldError{{Message: "unexpected EOF"}}
+	assert.Equal(t, plain, Localize(plain, "vi"))
+}
+
+func TestJSONFieldName(t *testing.T) {
+	assert.Equal(t, "email_address", JSONFieldName([]*sample{}, "Email"))
+	assert.Equal(t, "Age", JSONFieldName(sample{}, "Age"))
+	assert.Equal(t, "Missing", JSONFieldName(sample{}, "Missing"))
+	assert.Equal(t, "X", JSONFieldName("not a struct", "X"))
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for deliveries to addresses outside the
+// public internet, such as loopback, private and link-local ones
+var ErrForbiddenAddress = errors.New("webhook endpoint is not a public address")
+
+// reservedPrefixes are ranges that are not public but not covered by the
+// netip.Addr predicates: "this network", carrier-grade NAT, benchmarking and
+// reserved addresses
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// NewClient returns the client deliveries are sent with. Unless
+// allowPrivate is set, it only connects to public addresses: the address is
+// checked when it is dialed, after DNS resolution, so a host that resolves
+// or rebinds to an internal address is refused. Redirects are not followed;
+// a redirect response counts as a failed delivery.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || !PublicAddress(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// No proxy: the address dialed must be the endpoint's own
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 4,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// PublicAddress reports whether ip is a public unicast address
+func PublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicHost reports whether a webhook URL host may be public: IP literals
+// must be public addresses and localhost names are refused. Other names are
+// checked when deliveries are dialed.
+func PublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return PublicAddress(ip)
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicAddress(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::1":   true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"0.1.2.3":              false,
+		"224.0.0.1":            false,
+		"::1":                  false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:93.184.216.34": true,
+	} {
+		assert.Equal(t, public, PublicAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestPublicHost(t *testing.T) {
+	assert.True(t, PublicHost("hooks.example.com"))
+	assert.True(t, PublicHost("93.184.216.34"))
+	assert.False(t, PublicHost("localhost"))
+	assert.False(t, PublicHost("LOCALHOST."))
+	assert.False(t, PublicHost("api.localhost"))
+	assert.False(t, PublicHost("[::1]"))
+	assert.False(t, PublicHost("169.254.169.254"))
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/internal", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+
+	t.Run("RefusesLoopback", func(t *testing.T) {
+		_, err := NewClient(time.Second, false).Get(server.URL)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+
+	t.Run("RefusesNamesResolvingToLoopback", func(t *testing.T) {
+		_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		require.NoError(t, err)
+		_, err = NewClient(time.Second, false).Get("http://localhost:" + port)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+
+	t.Run("AllowsLoopbackWhenEnabled", func(t *testing.T) {
+		resp, err := NewClient(time.Second, true).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("DoesNotFollowRedirects", func(t *testing.T) {
+		resp, err := NewClient(time.Second, true).Get(server.URL + "/redirect")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/GoodsChain/backend/model"
+	"github.com/GoodsChain/backend/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// maxResponseBody is how much of a response body is kept in the delivery log
+const maxResponseBody = 1024
+
+// Options configures a Dispatcher
+type Options struct {
+	Interval    time.Duration // Time between polls of the outbox and the delivery queue
+	BatchSize   int           // Events fanned out, and deliveries sent, per round
+	MaxAttempts int           // Attempts before a delivery is dead-lettered
+	BaseBackoff time.Duration // Delay after the first failed attempt, doubled after each further one
+	MaxBackoff  time.Duration // Upper bound of the delay between attempts
+	Lease       time.Duration // Time a claimed delivery is hidden from other dispatchers
+}
+
+func (o Options) withDefaults() Options {
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 50
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 8
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 30 * time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 6 * time.Hour
+	}
+	if o.Lease <= 0 {
+		o.Lease = 2 * time.Minute
+	}
+	return o
+}
+
+// Dispatcher fans outbox events out into webhook deliveries and sends due
+// deliveries. Several dispatchers can share a database: rows are claimed
+// with SKIP LOCKED, so each event and delivery is handled by one of them.
+type Dispatcher struct {
+	transactor  repository.Transactor
+	outboxRepo  repository.OutboxRepository
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+	opts        Options
+}
+
+// NewDispatcher creates a new Dispatcher. client should have a timeout;
+// zero options take their defaults.
+func NewDispatcher(transactor repository.Transactor, outboxRepo repository.OutboxRepository,
+	webhookRepo repository.WebhookRepository, client *http.Client, opts Options) *Dispatcher {
+	return &Dispatcher{
+		transactor:  transactor,
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		client:      client,
+		opts:        opts.withDefaults(),
+	}
+}
+
+// Run dispatches every Interval until ctx is cancelled. Requests in flight
+// when ctx is cancelled are not recorded and are retried after their lease.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := d.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Webhook dispatch failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce queues deliveries for new outbox events, then sends one batch of
+// due deliveries
+func (d *Dispatcher) RunOnce(ctx context.Context) error {
+	if err := d.queueEvents(); err != nil {
+		return fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	return d.sendDue(ctx)
+}
+
+// queueEvents creates a delivery per event and matching subscription, in the
+// same transaction that marks the events dispatched
+func (d *Dispatcher) queueEvents() error {
+	for {
+		var claimed int
+		err := d.transactor.WithinTransaction(func(tx *sqlx.Tx) error {
+			events, err := d.outboxRepo.WithTx(tx).ClaimUndispatched(d.opts.BatchSize)
+			if err != nil {
+				return err
+			}
+			claimed = len(events)
+			if claimed == 0 {
+				return nil
+			}
+
+			ids := make([]string, len(events))
+			for i := range events {
+				payload, err := json.Marshal(&events[i])
+				if err != nil {
+					return err
+				}
+				if _, err := d.webhookRepo.WithTx(tx).QueueDeliveries(&events[i], payload); err != nil {
+					return err
+				}
+				ids[i] = events[i].ID
+			}
+			return d.outboxRepo.WithTx(tx).MarkDispatched(ids)
+		})
+		if err != nil || claimed < d.opts.BatchSize {
+			return err
+		}
+	}
+}
+
+// sendDue sends the claimed deliveries concurrently and records the outcomes
+func (d *Dispatcher) sendDue(ctx context.Context) error {
+	dispatches, err := d.webhookRepo.ClaimDueDeliveries(d.opts.BatchSize, d.opts.Lease)
+	if err != nil {
+		return fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range dispatches {
+		wg.Add(1)
+		go func(dispatch *model.WebhookDispatch) {
+			defer wg.Done()
+			d.send(ctx, dispatch)
+			if ctx.Err() != nil {
+				return
+			}
+			if err := d.webhookRepo.RecordAttempt(&dispatch.WebhookDelivery); err != nil {
+				log.Error().Err(err).Str("delivery_id", dispatch.ID).Msg("Failed to record webhook attempt")
+			}
+		}(&dispatches[i])
+	}
+	wg.Wait()
+	return nil
+}
+
+// send makes one delivery attempt and updates the delivery with its outcome
+func (d *Dispatcher) send(ctx context.Context, dispatch *model.WebhookDispatch) {
+	delivery := &dispatch.WebhookDelivery
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus, delivery.ResponseBody, delivery.LastError = 0, "", ""
+
+	err := d.post(ctx, dispatch, now)
+	if err == nil {
+		delivery.Status = model.DeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		delivery.Status = model.DeliveryStatusDead
+		log.Warn().Str("delivery_id", delivery.ID).Str("subscription_id", delivery.SubscriptionID).
+			Int("attempts", delivery.Attempts).Err(err).Msg("Webhook delivery dead-lettered")
+		return
+	}
+	delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts, d.opts.BaseBackoff, d.opts.MaxBackoff))
+}
+
+// post sends the signed request; any response other than 2xx is an error
+func (d *Dispatcher) post(ctx context.Context, dispatch *model.WebhookDispatch, now time.Time) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(dispatch.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GoodsChain-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, dispatch.EventType)
+	req.Header.Set(HeaderDelivery, dispatch.ID)
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(now.Unix()))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, now, dispatch.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	_, _ = io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
+	dispatch.ResponseStatus = resp.StatusCode
+	dispatch.ResponseBody = string(body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Backoff returns the delay before the next attempt after attempts failed
+// attempts: base, 2*base, 4*base, ... capped at max
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
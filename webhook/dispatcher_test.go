@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoodsChain/backend/mock"
+	"github.com/GoodsChain/backend/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type dispatcherMocks struct {
+	outboxRepo  *mock.MockOutboxRepository
+	webhookRepo *mock.MockWebhookRepository
+}
+
+func newTestDispatcher(t *testing.T, opts Options) (*Dispatcher, dispatcherMocks) {
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	m := dispatcherMocks{
+		outboxRepo:  mock.NewMockOutboxRepository(ctrl),
+		webhookRepo: mock.NewMockWebhookRepository(ctrl),
+	}
+	transactor.EXPECT().WithinTransaction(gomock.Any()).DoAndReturn(func(fn func(tx *sqlx.Tx) error) error {
+		return fn(nil)
+	}).AnyTimes()
+	m.outboxRepo.EXPECT().WithTx(gomock.Any()).Return(m.outboxRepo).AnyTimes()
+	m.webhookRepo.EXPECT().WithTx(gomock.Any()).Return(m.webhookRepo).AnyTimes()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	return NewDispatcher(transactor, m.outboxRepo, m.webhookRepo, client, opts), m
+}
+
+// receiver is a local webhook endpoint that verifies signatures
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int // Responses to return, in order; 200 once exhausted
+	received []*http.Request
+	bodies   [][]byte
+	errors   []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, req)
+	r.bodies = append(r.bodies, body)
+	r.errors = append(r.errors, Verify("s3cret", req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, time.Minute))
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("ack"))
+}
+
+func pendingDispatch(url string, attempts int) model.WebhookDispatch {
+	return model.WebhookDispatch{
+		WebhookDelivery: model.WebhookDelivery{
+			ID:             "delivery1",
+			SubscriptionID: "sub1",
+			EventID:        "event1",
+			EventType:      model.EventCarPriceChanged,
+			Payload:        json.RawMessage(`{"id":"event1","type":"car.price_changed","data":{"previous_price":25000}}`),
+			Status:         model.DeliveryStatusPending,
+			Attempts:       attempts,
+		},
+		URL:    url,
+		Secret: "s3cret",
+	}
+}
+
+func TestDispatcher_RunOnce(t *testing.T) {
+	opts := Options{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	t.Run("QueuesEventsAndDelivers", func(t *testing.T) {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		d, m := newTestDispatcher(t, opts)
+
+		event := model.Event{ID: "event1", Type: model.EventCarPriceChanged, AggregateType: "car", AggregateID: "car1",
+			Data: json.RawMessage(`{"previous_price":25000}`)}
+		gomock.InOrder(
+			m.outboxRepo.EXPECT().ClaimUndispatched(10).Return([]model.Event{event}, nil),
+			m.webhookRepo.EXPECT().QueueDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(func(e *model.Event, payload []byte) (int64, error) {
+				assert.Equal(t, "event1", e.ID)
+				assert.JSONEq(t, `{"id":"event1","type":"car.price_changed","aggregate_type":"car","aggregate_id":"car1",
+					"data":{"previous_price":25000},"created_at":"0001-01-01T00:00:00Z"}`, string(payload))
+				return 1, nil
+			}),
+			m.outboxRepo.EXPECT().MarkDispatched([]string{"event1"}).Return(nil),
+		)
+		m.webhookRepo.EXPECT().ClaimDueDeliveries(10, 2*time.Minute).Return([]model.WebhookDispatch{pendingDispatch(server.URL, 0)}, nil)
+		m.webhookRepo.EXPECT().RecordAttempt(gomock.Any()).DoAndReturn(func(d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryStatusDelivered, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			assert.Equal(t, http.StatusOK, d.ResponseStatus)
+			assert.Equal(t, "ack", d.ResponseBody)
+			assert.NotNil(t, d.DeliveredAt)
+			assert.Empty(t, d.LastError)
+			return nil
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+		require.Len(t, recv.received, 1)
+		req := recv.received[0]
+		assert.NoError(t, recv.errors[0])
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, model.EventCarPriceChanged, req.Header.Get(HeaderEvent))
+		assert.Equal(t, "delivery1", req.Header.Get(HeaderDelivery))
+		assert.JSONEq(t, `{"id":"event1","type":"car.price_changed","data":{"previous_price":25000}}`, string(recv.bodies[0]))
+	})
+
+	t.Run("FailureSchedulesRetry", func(t *testing.T) {
+		recv := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		d, m := newTestDispatcher(t, opts)
+
+		m.outboxRepo.EXPECT().ClaimUndispatched(10).Return([]model.Event{}, nil)
+		m.webhookRepo.EXPECT().ClaimDueDeliveries(10, gomock.Any()).Return([]model.WebhookDispatch{pendingDispatch(server.URL, 1)}, nil)
+		before := time.Now()
+		m.webhookRepo.EXPECT().RecordAttempt(gomock.Any()).DoAndReturn(func(d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryStatusPending, d.Status)
+			assert.Equal(t, 2, d.Attempts)
+			assert.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus)
+			assert.Equal(t, "endpoint responded with status 503", d.LastError)
+			assert.Nil(t, d.DeliveredAt)
+			// Second failure waits twice the base backoff
+			assert.WithinDuration(t, before.Add(2*time.Minute), d.NextAttemptAt, 5*time.Second)
+			return nil
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+	})
+
+	t.Run("DeadLetterAfterMaxAttempts", func(t *testing.T) {
+		d, m := newTestDispatcher(t, opts)
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close() // Connection refused
+
+		m.outboxRepo.EXPECT().ClaimUndispatched(10).Return([]model.Event{}, nil)
+		m.webhookRepo.EXPECT().ClaimDueDeliveries(10, gomock.Any()).Return([]model.WebhookDispatch{pendingDispatch(url, 2)}, nil)
+		m.webhookRepo.EXPECT().RecordAttempt(gomock.Any()).DoAndReturn(func(d *model.WebhookDelivery) error {
+			assert.Equal(t, model.DeliveryStatusDead, d.Status)
+			assert.Equal(t, 3, d.Attempts)
+			assert.Equal(t, 0, d.ResponseStatus)
+			assert.NotEmpty(t, d.LastError)
+			return nil
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+	})
+
+	t.Run("CancelledAttemptIsNotRecorded", func(t *testing.T) {
+		d, m := newTestDispatcher(t, opts)
+		ctx, cancel := context.WithCancel(context.Background())
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		m.outboxRepo.EXPECT().ClaimUndispatched(10).Return([]model.Event{}, nil)
+		m.webhookRepo.EXPECT().ClaimDueDeliveries(10, gomock.Any()).Return([]model.WebhookDispatch{pendingDispatch(server.URL, 0)}, nil)
+		m.webhookRepo.EXPECT().RecordAttempt(gomock.Any()).Times(0)
+
+		require.NoError(t, d.RunOnce(ctx))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	assert.Equal(t, 30*time.Second, Backoff(1, base, max))
+	assert.Equal(t, time.Minute, Backoff(2, base, max))
+	assert.Equal(t, 8*time.Minute, Backoff(5, base, max))
+	assert.Equal(t, max, Backoff(6, base, max))
+	assert.Equal(t, max, Backoff(60, base, max))
+}
@@ -0,0 +1,71 @@
+// Package webhook delivers outbox events to subscribed HTTP endpoints with
+// signed requests, retrying failed deliveries with exponential backoff.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderEvent     = "X-GoodsChain-Event"
+	HeaderDelivery  = "X-GoodsChain-Delivery"
+	HeaderTimestamp = "X-GoodsChain-Timestamp"
+	HeaderSignature = "X-GoodsChain-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Signature verification errors
+var (
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	ErrStaleTimestamp   = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// Sign returns the signature header value for body sent at timestamp: the
+// hex HMAC-SHA256 of "<unix timestamp>.<body>" keyed with secret. Including
+// the timestamp lets receivers reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the timestamp and signature headers of a received delivery.
+// Timestamps further than tolerance from now are rejected; a zero tolerance
+// disables the check.
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+	timestamp := time.Unix(unix, 0)
+	if tolerance > 0 {
+		if age := time.Since(timestamp); age > tolerance || age < -tolerance {
+			return ErrStaleTimestamp
+		}
+	}
+	if !strings.HasPrefix(signatureHeader, signaturePrefix) ||
+		!hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signatureHeader)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewSecret generates a random signing secret
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
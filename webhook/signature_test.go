@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"car.price_changed"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", now, body)
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.Len(t, signature, len("sha256=")+64)
+
+	assert.NoError(t, Verify("secret", timestamp, signature, body, time.Minute))
+	assert.ErrorIs(t, Verify("other", timestamp, signature, body, time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", timestamp, signature, []byte(`{}`), time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", timestamp, strings.TrimPrefix(signature, "sha256="), body, time.Minute), ErrInvalidSignature)
+
+	// The timestamp is part of the signed content
+	later := strconv.FormatInt(now.Unix()+1, 10)
+	assert.ErrorIs(t, Verify("secret", later, signature, body, time.Minute), ErrInvalidSignature)
+
+	old := now.Add(-time.Hour)
+	oldSignature := Sign("secret", old, body)
+	oldTimestamp := strconv.FormatInt(old.Unix(), 10)
+	assert.ErrorIs(t, Verify("secret", oldTimestamp, oldSignature, body, 5*time.Minute), ErrStaleTimestamp)
+	assert.NoError(t, Verify("secret", oldTimestamp, oldSignature, body, 0))
+	assert.ErrorIs(t, Verify("secret", "yesterday", signature, body, 0), ErrStaleTimestamp)
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	require.NoError(t, err)
+	b, err := NewSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(a, "whsec_"))
+	assert.NotEqual(t, a, b)
+}